@@ -0,0 +1,123 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+var _ Cacher[struct{}, struct{}] = (*sizedLRU[struct{}, struct{}])(nil)
+
+type sizedEntry[K comparable, V any] struct {
+	Key   K
+	Value V
+	Size  int
+}
+
+// sizedLRU is a key value store bounded by total size of its entries, as
+// reported by size function. If the size is attempted to be exceeded, then
+// least recently used entries are evicted until the new entry fits.
+// Entries, which alone are larger than max size, are never cached.
+type sizedLRU[K comparable, V any] struct {
+	lock        sync.Mutex
+	entryMap    map[K]*list.Element
+	entryList   *list.List
+	maxSize     int
+	currentSize int
+	size        func(K, V) int
+}
+
+// NewSizedLRU returns cache, which total size of entries doesn't exceed
+// [maxSize]. Size of each entry is calculated with [size] once, when the entry
+// is put into the cache. Returned cache is safe for concurrent use.
+func NewSizedLRU[K comparable, V any](maxSize int, size func(K, V) int) Cacher[K, V] {
+	return &sizedLRU[K, V]{
+		entryMap:  make(map[K]*list.Element, minCacheSize),
+		entryList: list.New(),
+		maxSize:   maxSize,
+		size:      size,
+	}
+}
+
+func (c *sizedLRU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *sizedLRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *sizedLRU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *sizedLRU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+func (c *sizedLRU[K, V]) remove(e *list.Element) {
+	val := e.Value.(*sizedEntry[K, V])
+	c.entryList.Remove(e)
+	delete(c.entryMap, val.Key)
+	c.currentSize -= val.Size
+}
+
+func (c *sizedLRU[K, V]) put(key K, value V) {
+	newSize := c.size(key, value)
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+	if newSize > c.maxSize {
+		return
+	}
+
+	for c.currentSize+newSize > c.maxSize {
+		c.remove(c.entryList.Front())
+	}
+
+	c.entryMap[key] = c.entryList.PushBack(&sizedEntry[K, V]{
+		Key:   key,
+		Value: value,
+		Size:  newSize,
+	})
+	c.currentSize += newSize
+}
+
+func (c *sizedLRU[K, V]) get(key K) (V, bool) {
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*sizedEntry[K, V])
+		return val.Value, true
+	}
+	return utils.Zero[V](), false
+}
+
+func (c *sizedLRU[K, V]) evict(key K) {
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *sizedLRU[K, V]) flush() {
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
+	c.entryList = list.New()
+	c.currentSize = 0
+}
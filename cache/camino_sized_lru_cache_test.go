@@ -0,0 +1,77 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestSizedLRU(t *testing.T) {
+	for _, test := range CacherTests {
+		cache := NewSizedLRU(test.Size, func(ids.ID, int) int { return 1 })
+		test.Func(t, cache)
+	}
+}
+
+func TestSizedLRUEviction(t *testing.T) {
+	require := require.New(t)
+
+	// value is entry size
+	cache := NewSizedLRU(10, func(_ ids.ID, size int) int { return size })
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, 4)
+	cache.Put(id2, 5)
+	_, ok := cache.Get(id1)
+	require.True(ok)
+
+	// id2 is least recently used and must be evicted to fit id3
+	cache.Put(id3, 6)
+	_, ok = cache.Get(id2)
+	require.False(ok)
+	val, ok := cache.Get(id1)
+	require.True(ok)
+	require.Equal(4, val)
+	val, ok = cache.Get(id3)
+	require.True(ok)
+	require.Equal(6, val)
+
+	// replacing value updates entry size
+	cache.Put(id1, 1)
+	cache.Put(id2, 3)
+	for _, id := range []ids.ID{id1, id2, id3} {
+		_, ok = cache.Get(id)
+		require.True(ok)
+	}
+
+	// entry larger than max size isn't cached and doesn't evict others,
+	// but previous value with the same key is removed
+	cache.Put(id1, 11)
+	_, ok = cache.Get(id1)
+	require.False(ok)
+	_, ok = cache.Get(id2)
+	require.True(ok)
+	_, ok = cache.Get(id3)
+	require.True(ok)
+
+	cache.Evict(id2)
+	cache.Put(id1, 4)
+	for _, id := range []ids.ID{id1, id3} {
+		_, ok = cache.Get(id)
+		require.True(ok)
+	}
+
+	cache.Flush()
+	cache.Put(id1, 10)
+	val, ok = cache.Get(id1)
+	require.True(ok)
+	require.Equal(10, val)
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ Cacher[struct{}, struct{}] = (*ExpiringLRU[struct{}, struct{}])(nil)
+
+type expiringEntry[K comparable, V any] struct {
+	Key    K
+	Value  V
+	Expiry time.Time
+}
+
+// ExpiringLRU is a key value store with bounded size, which entries expire
+// after TTL has passed since they were put into the cache. If RefreshOnAccess
+// is set, successful Get also extends entry expiry by TTL. Expired entries are
+// never returned. If the size is attempted to be exceeded, then the least
+// recently used entry is evicted before the insertion is done.
+type ExpiringLRU[K comparable, V any] struct {
+	lock      sync.Mutex
+	entryMap  map[K]*list.Element
+	entryList *list.List
+
+	Size            int
+	TTL             time.Duration
+	RefreshOnAccess bool
+	Clock           mockable.Clock
+}
+
+func (c *ExpiringLRU[K, V]) Put(key K, value V) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.put(key, value)
+}
+
+func (c *ExpiringLRU[K, V]) Get(key K) (V, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.get(key)
+}
+
+func (c *ExpiringLRU[K, V]) Evict(key K) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.evict(key)
+}
+
+func (c *ExpiringLRU[K, V]) Flush() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.flush()
+}
+
+// Len returns number of not yet expired entries in the cache.
+func (c *ExpiringLRU[K, V]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.init()
+	c.removeExpired()
+	return c.entryList.Len()
+}
+
+func (c *ExpiringLRU[K, V]) init() {
+	if c.entryMap == nil {
+		c.entryMap = make(map[K]*list.Element, minCacheSize)
+	}
+	if c.entryList == nil {
+		c.entryList = list.New()
+	}
+	if c.Size <= 0 {
+		c.Size = 1
+	}
+}
+
+func (c *ExpiringLRU[K, V]) resize() {
+	for c.entryList.Len() > c.Size {
+		c.remove(c.entryList.Front())
+	}
+}
+
+// removeExpired removes all expired entries from the cache.
+func (c *ExpiringLRU[K, V]) removeExpired() {
+	now := c.Clock.Time()
+	for e := c.entryList.Front(); e != nil; {
+		next := e.Next()
+		if val := e.Value.(*expiringEntry[K, V]); !now.Before(val.Expiry) {
+			c.remove(e)
+		}
+		e = next
+	}
+}
+
+func (c *ExpiringLRU[K, V]) remove(e *list.Element) {
+	c.entryList.Remove(e)
+	delete(c.entryMap, e.Value.(*expiringEntry[K, V]).Key)
+}
+
+func (c *ExpiringLRU[K, V]) put(key K, value V) {
+	c.init()
+	c.resize()
+
+	expiry := c.Clock.Time().Add(c.TTL)
+
+	if e, ok := c.entryMap[key]; ok {
+		c.entryList.MoveToBack(e)
+
+		val := e.Value.(*expiringEntry[K, V])
+		val.Value = value
+		val.Expiry = expiry
+		return
+	}
+
+	if c.entryList.Len() >= c.Size {
+		// try to free space by removing expired entries first,
+		// fallback to least recently used entry
+		c.removeExpired()
+		if c.entryList.Len() >= c.Size {
+			c.remove(c.entryList.Front())
+		}
+	}
+
+	c.entryMap[key] = c.entryList.PushBack(&expiringEntry[K, V]{
+		Key:    key,
+		Value:  value,
+		Expiry: expiry,
+	})
+}
+
+func (c *ExpiringLRU[K, V]) get(key K) (V, bool) {
+	c.init()
+	c.resize()
+
+	e, ok := c.entryMap[key]
+	if !ok {
+		return utils.Zero[V](), false
+	}
+
+	val := e.Value.(*expiringEntry[K, V])
+	now := c.Clock.Time()
+	if !now.Before(val.Expiry) {
+		c.remove(e)
+		return utils.Zero[V](), false
+	}
+
+	c.entryList.MoveToBack(e)
+	if c.RefreshOnAccess {
+		val.Expiry = now.Add(c.TTL)
+	}
+	return val.Value, true
+}
+
+func (c *ExpiringLRU[K, V]) evict(key K) {
+	c.init()
+	c.resize()
+
+	if e, ok := c.entryMap[key]; ok {
+		c.remove(e)
+	}
+}
+
+func (c *ExpiringLRU[K, V]) flush() {
+	c.init()
+
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
+	c.entryList = list.New()
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestExpiringLRU(t *testing.T) {
+	for _, test := range CacherTests {
+		cache := &ExpiringLRU[ids.ID, int]{Size: test.Size, TTL: time.Hour}
+		test.Func(t, cache)
+	}
+}
+
+func TestExpiringLRUExpiry(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	ttl := 10 * time.Second
+	cache := &ExpiringLRU[ids.ID, int]{Size: 3, TTL: ttl}
+	cache.Clock.Set(now)
+
+	id1 := ids.ID{1}
+	id2 := ids.ID{2}
+	id3 := ids.ID{3}
+
+	cache.Put(id1, 1)
+	cache.Clock.Set(now.Add(ttl / 2))
+	cache.Put(id2, 2)
+
+	// get doesn't extend expiry without refresh
+	val, ok := cache.Get(id1)
+	require.True(ok)
+	require.Equal(1, val)
+
+	cache.Clock.Set(now.Add(ttl))
+	_, ok = cache.Get(id1)
+	require.False(ok)
+	val, ok = cache.Get(id2)
+	require.True(ok)
+	require.Equal(2, val)
+	require.Equal(1, cache.Len())
+
+	// put of existing key resets its expiry
+	cache.Put(id2, 22)
+	cache.Clock.Set(now.Add(2*ttl - time.Second))
+	val, ok = cache.Get(id2)
+	require.True(ok)
+	require.Equal(22, val)
+
+	// expired entries are evicted before non-expired least recently used ones
+	cache.Put(id1, 1)
+	cache.Put(id3, 3)
+	cache.Clock.Set(now.Add(2 * ttl))
+	cache.Put(ids.ID{4}, 4)
+	require.Equal(3, cache.Len())
+	_, ok = cache.Get(id2)
+	require.False(ok)
+	_, ok = cache.Get(id1)
+	require.True(ok)
+	_, ok = cache.Get(id3)
+	require.True(ok)
+}
+
+func TestExpiringLRURefreshOnAccess(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Unix(1000, 0)
+	ttl := 10 * time.Second
+	cache := &ExpiringLRU[ids.ID, int]{Size: 1, TTL: ttl, RefreshOnAccess: true}
+	cache.Clock.Set(now)
+
+	id1 := ids.ID{1}
+	cache.Put(id1, 1)
+
+	for i := 1; i <= 3; i++ {
+		cache.Clock.Set(now.Add(time.Duration(i) * (ttl - time.Second)))
+		val, ok := cache.Get(id1)
+		require.True(ok)
+		require.Equal(1, val)
+	}
+
+	cache.Clock.Set(now.Add(3*(ttl-time.Second) + ttl))
+	_, ok := cache.Get(id1)
+	require.False(ok)
+	require.Zero(cache.Len())
+}
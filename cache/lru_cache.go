@@ -6,58 +6,60 @@ package cache
 import (
 	"container/list"
 	"sync"
+
+	"github.com/ava-labs/avalanchego/utils"
 )
 
 const minCacheSize = 32
 
-var _ Cacher = (*LRU)(nil)
+var _ Cacher[struct{}, struct{}] = (*LRU[struct{}, struct{}])(nil)
 
-type entry struct {
-	Key   interface{}
-	Value interface{}
+type entry[K comparable, V any] struct {
+	Key   K
+	Value V
 }
 
 // LRU is a key value store with bounded size. If the size is attempted to be
 // exceeded, then an element is removed from the cache before the insertion is
 // done, based on evicting the least recently used value.
-type LRU struct {
+type LRU[K comparable, V any] struct {
 	lock      sync.Mutex
-	entryMap  map[interface{}]*list.Element
+	entryMap  map[K]*list.Element
 	entryList *list.List
 	Size      int
 }
 
-func (c *LRU) Put(key, value interface{}) {
+func (c *LRU[K, V]) Put(key K, value V) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.put(key, value)
 }
 
-func (c *LRU) Get(key interface{}) (interface{}, bool) {
+func (c *LRU[K, V]) Get(key K) (V, bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	return c.get(key)
 }
 
-func (c *LRU) Evict(key interface{}) {
+func (c *LRU[K, V]) Evict(key K) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.evict(key)
 }
 
-func (c *LRU) Flush() {
+func (c *LRU[K, V]) Flush() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.flush()
 }
 
-func (c *LRU) init() {
+func (c *LRU[K, V]) init() {
 	if c.entryMap == nil {
-		c.entryMap = make(map[interface{}]*list.Element, minCacheSize)
+		c.entryMap = make(map[K]*list.Element, minCacheSize)
 	}
 	if c.entryList == nil {
 		c.entryList = list.New()
@@ -67,17 +69,17 @@ func (c *LRU) init() {
 	}
 }
 
-func (c *LRU) resize() {
+func (c *LRU[K, V]) resize() {
 	for c.entryList.Len() > c.Size {
 		e := c.entryList.Front()
 		c.entryList.Remove(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		delete(c.entryMap, val.Key)
 	}
 }
 
-func (c *LRU) put(key, value interface{}) {
+func (c *LRU[K, V]) put(key K, value V) {
 	c.init()
 	c.resize()
 
@@ -86,12 +88,12 @@ func (c *LRU) put(key, value interface{}) {
 			e = c.entryList.Front()
 			c.entryList.MoveToBack(e)
 
-			val := e.Value.(*entry)
+			val := e.Value.(*entry[K, V])
 			delete(c.entryMap, val.Key)
 			val.Key = key
 			val.Value = value
 		} else {
-			e = c.entryList.PushBack(&entry{
+			e = c.entryList.PushBack(&entry[K, V]{
 				Key:   key,
 				Value: value,
 			})
@@ -100,25 +102,25 @@ func (c *LRU) put(key, value interface{}) {
 	} else {
 		c.entryList.MoveToBack(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		val.Value = value
 	}
 }
 
-func (c *LRU) get(key interface{}) (interface{}, bool) {
+func (c *LRU[K, V]) get(key K) (V, bool) {
 	c.init()
 	c.resize()
 
 	if e, ok := c.entryMap[key]; ok {
 		c.entryList.MoveToBack(e)
 
-		val := e.Value.(*entry)
+		val := e.Value.(*entry[K, V])
 		return val.Value, true
 	}
-	return struct{}{}, false
+	return utils.Zero[V](), false
 }
 
-func (c *LRU) evict(key interface{}) {
+func (c *LRU[K, V]) evict(key K) {
 	c.init()
 	c.resize()
 
@@ -128,9 +130,9 @@ func (c *LRU) evict(key interface{}) {
 	}
 }
 
-func (c *LRU) flush() {
+func (c *LRU[K, V]) flush() {
 	c.init()
 
-	c.entryMap = make(map[interface{}]*list.Element, minCacheSize)
+	c.entryMap = make(map[K]*list.Element, minCacheSize)
 	c.entryList = list.New()
 }
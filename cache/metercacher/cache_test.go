@@ -9,12 +9,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 )
 
 func TestInterface(t *testing.T) {
 	for _, test := range cache.CacherTests {
-		cache := &cache.LRU{Size: test.Size}
-		c, err := New("", prometheus.NewRegistry(), cache)
+		cache := &cache.LRU[ids.ID, int]{Size: test.Size}
+		c, err := New[ids.ID, int]("", prometheus.NewRegistry(), cache)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -8,33 +8,33 @@ import (
 	"sync"
 )
 
-var _ Deduplicator = (*EvictableLRU)(nil)
+var _ Deduplicator[struct{}, Evictable[struct{}]] = (*EvictableLRU[struct{}, Evictable[struct{}]])(nil)
 
 // EvictableLRU is an LRU cache that notifies the objects when they are evicted.
-type EvictableLRU struct {
+type EvictableLRU[K comparable, V Evictable[K]] struct {
 	lock      sync.Mutex
-	entryMap  map[interface{}]*list.Element
+	entryMap  map[K]*list.Element
 	entryList *list.List
 	Size      int
 }
 
-func (c *EvictableLRU) Deduplicate(value Evictable) Evictable {
+func (c *EvictableLRU[K, V]) Deduplicate(value V) V {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	return c.deduplicate(value)
 }
 
-func (c *EvictableLRU) Flush() {
+func (c *EvictableLRU[K, V]) Flush() {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	c.flush()
 }
 
-func (c *EvictableLRU) init() {
+func (c *EvictableLRU[K, V]) init() {
 	if c.entryMap == nil {
-		c.entryMap = make(map[interface{}]*list.Element)
+		c.entryMap = make(map[K]*list.Element)
 	}
 	if c.entryList == nil {
 		c.entryList = list.New()
@@ -44,18 +44,18 @@ func (c *EvictableLRU) init() {
 	}
 }
 
-func (c *EvictableLRU) resize() {
+func (c *EvictableLRU[K, V]) resize() {
 	for c.entryList.Len() > c.Size {
 		e := c.entryList.Front()
 		c.entryList.Remove(e)
 
-		val := e.Value.(Evictable)
+		val := e.Value.(V)
 		delete(c.entryMap, val.Key())
 		val.Evict()
 	}
 }
 
-func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
+func (c *EvictableLRU[K, V]) deduplicate(value V) V {
 	c.init()
 	c.resize()
 
@@ -65,7 +65,7 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 			e = c.entryList.Front()
 			c.entryList.MoveToBack(e)
 
-			val := e.Value.(Evictable)
+			val := e.Value.(V)
 			delete(c.entryMap, val.Key())
 			val.Evict()
 
@@ -77,13 +77,13 @@ func (c *EvictableLRU) deduplicate(value Evictable) Evictable {
 	} else {
 		c.entryList.MoveToBack(e)
 
-		val := e.Value.(Evictable)
+		val := e.Value.(V)
 		value = val
 	}
 	return value
 }
 
-func (c *EvictableLRU) flush() {
+func (c *EvictableLRU[K, V]) flush() {
 	c.init()
 
 	size := c.Size
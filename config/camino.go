@@ -4,25 +4,105 @@
 package config
 
 import (
+	"encoding/json"
 	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
 
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/tenant"
 	"github.com/spf13/viper"
 )
 
 const (
-	DaoProposalBondAmountKey = "dao-proposal-bond-amount"
+	DaoProposalBondAmountKey        = "dao-proposal-bond-amount"
+	APITenantsFileKey               = "api-tenants-file"
+	MaxSystemUnlockDepositTxSizeKey = "max-system-unlock-deposit-tx-size"
+	StatePruningKey                 = "camino-state-pruning-enabled"
+	StateHistoryRetentionKey        = "camino-state-history-retention"
+	ArchiveRemovedDepositsKey       = "camino-archive-removed-deposits"
+	DepositsCacheSizeKey            = "camino-deposits-cache-size"
+	ClaimablesCacheSizeKey          = "camino-claimables-cache-size"
+	VerifyStateInvariantsKey        = "camino-verify-state-invariants"
+	PrefetchBlockStateKey           = "camino-prefetch-block-state"
+	MinChangeAmountKey              = "camino-min-change-amount"
+	MaxMultisigAliasDepthKey        = "camino-max-multisig-alias-depth"
+	MaxMultisigAliasMemoSizeKey     = "camino-max-multisig-alias-memo-size"
+	MultisigAliasUsageRetentionKey  = "camino-multisig-alias-usage-retention"
 )
 
 func addCaminoFlags(fs *flag.FlagSet) {
 	// Bond amount required to place a DAO proposal on the Primary Network
 	fs.Uint64(DaoProposalBondAmountKey, genesis.LocalParams.CaminoConfig.DaoProposalBondAmount, "Amount, in nAVAX, required to place a DAO proposal")
+	// Platform API tenants
+	fs.String(APITenantsFileKey, "", "Specifies a JSON file with platform API tenants (name, token, rate limits and allowed methods)")
+	// Max size of a single system unlock deposit tx
+	fs.Int(MaxSystemUnlockDepositTxSizeKey, 0, "Max size, in bytes, of a single system unlock deposit tx. If 0, default limit is used")
+	// Pruning of removed deposits and locked offers
+	fs.Bool(StatePruningKey, false, "If true, removed deposits and locked ended deposit offers are moved from active platform chain state into archive")
+	// Archive of removed deposits
+	fs.Bool(ArchiveRemovedDepositsKey, false, "If true, removed deposits are retained in platform chain state archive for historical queries. Implied by --"+StatePruningKey)
+	// History of camino state
+	fs.Uint64(StateHistoryRetentionKey, 0, "Number of accepted blocks, for which history of deposits, claimables and address states is retained for historical queries. If 0, history isn't recorded")
+	// Camino state caches
+	fs.Int(DepositsCacheSizeKey, 0, "Number of deposits cached in memory. If 0, default size is used")
+	fs.Int(ClaimablesCacheSizeKey, 0, "Number of claimables cached in memory. If 0, default size is used")
+	// Camino state consistency check
+	fs.Bool(VerifyStateInvariantsKey, false, "If true, consistency of deposits, bonds and claimables in platform chain state is verified on node start")
+	// Concurrent state reads during block verification
+	fs.Bool(PrefetchBlockStateKey, false, "If true, utxos and deposits referenced by platform chain block txs are concurrently read from database before block txs are verified")
+	// Dust change threshold
+	fs.Uint64(MinChangeAmountKey, 0, "Unlocked change outputs, in nAVAX, with lesser amount aren't produced by platform chain tx builder, but burned together with tx fee. If 0, all change is returned")
+	// Nesting of multisig aliases
+	fs.Int(MaxMultisigAliasDepthKey, 0, "Max nesting depth of multisig aliases accepted by platform chain credential verification. If 0, default depth is used")
+	// Size of multisig alias memo
+	fs.Int(MaxMultisigAliasMemoSizeKey, 0, "Max size, in bytes, of multisig alias memo accepted by platform chain alias txs. If 0, default size is used")
+	// Usage of multisig aliases
+	fs.Int(MultisigAliasUsageRetentionKey, 0, "Number of last platform chain txs, in which multisig alias was used to prove owners, retained per alias for usage queries. If 0, usage isn't recorded")
 }
 
-func getCaminoPlatformConfig(v *viper.Viper) config.CaminoConfig {
+func getCaminoPlatformConfig(v *viper.Viper) (config.CaminoConfig, error) {
 	conf := config.CaminoConfig{
-		DaoProposalBondAmount: v.GetUint64(DaoProposalBondAmountKey),
+		DaoProposalBondAmount:        v.GetUint64(DaoProposalBondAmountKey),
+		MaxSystemUnlockDepositTxSize: v.GetInt(MaxSystemUnlockDepositTxSizeKey),
+		StatePruning:                 v.GetBool(StatePruningKey),
+		HistoryRetention:             v.GetUint64(StateHistoryRetentionKey),
+		ArchiveRemovedDeposits:       v.GetBool(ArchiveRemovedDepositsKey),
+		DepositsCacheSize:            v.GetInt(DepositsCacheSizeKey),
+		ClaimablesCacheSize:          v.GetInt(ClaimablesCacheSizeKey),
+		VerifyStateInvariants:        v.GetBool(VerifyStateInvariantsKey),
+		PrefetchBlockState:           v.GetBool(PrefetchBlockStateKey),
+		MinChangeAmount:              v.GetUint64(MinChangeAmountKey),
+		MaxMultisigAliasDepth:        v.GetInt(MaxMultisigAliasDepthKey),
+		MaxMultisigAliasMemoSize:     v.GetInt(MaxMultisigAliasMemoSizeKey),
+		MultisigAliasUsageRetention:  v.GetInt(MultisigAliasUsageRetentionKey),
 	}
-	return conf
+	apiTenants, err := getAPITenants(v)
+	if err != nil {
+		return config.CaminoConfig{}, err
+	}
+	conf.APITenants = apiTenants
+	return conf, nil
+}
+
+func getAPITenants(v *viper.Viper) ([]tenant.Config, error) {
+	if !v.IsSet(APITenantsFileKey) {
+		return nil, nil
+	}
+
+	fileBytes, err := os.ReadFile(filepath.Clean(GetExpandedArg(v, APITenantsFileKey)))
+	if err != nil {
+		return nil, err
+	}
+
+	tenants := []tenant.Config{}
+	if err := json.Unmarshal(fileBytes, &tenants); err != nil {
+		return nil, fmt.Errorf("problem unmarshaling api tenants: %w", err)
+	}
+	if err := tenant.VerifyConfigs(tenants); err != nil {
+		return nil, fmt.Errorf("invalid api tenants: %w", err)
+	}
+	return tenants, nil
 }
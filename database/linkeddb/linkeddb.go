@@ -55,7 +55,7 @@ type linkedDB struct {
 	headKeyIsSynced, headKeyExists, headKeyIsUpdated, updatedHeadKeyExists bool
 	headKey, updatedHeadKey                                                []byte
 	// these variables provide caching for the nodes.
-	nodeCache    cache.Cacher // key -> *node
+	nodeCache    cache.Cacher[string, *node] // key -> *node
 	updatedNodes map[string]*node
 
 	// db is the underlying database that this list is stored in.
@@ -74,7 +74,7 @@ type node struct {
 
 func New(db database.Database, cacheSize int) LinkedDB {
 	return &linkedDB{
-		nodeCache:    &cache.LRU{Size: cacheSize},
+		nodeCache:    &cache.LRU[string, *node]{Size: cacheSize},
 		updatedNodes: make(map[string]*node),
 		db:           db,
 		batch:        db.NewBatch(),
@@ -313,8 +313,7 @@ func (ldb *linkedDB) getNode(key []byte) (node, error) {
 	defer ldb.cacheLock.Unlock()
 
 	keyStr := string(key)
-	if nodeIntf, exists := ldb.nodeCache.Get(keyStr); exists {
-		n := nodeIntf.(*node)
+	if n, exists := ldb.nodeCache.Get(keyStr); exists {
 		if n == nil {
 			return node{}, database.ErrNotFound
 		}
@@ -323,9 +322,7 @@ func (ldb *linkedDB) getNode(key []byte) (node, error) {
 
 	nodeBytes, err := ldb.db.Get(nodeKey(key))
 	if err == database.ErrNotFound {
-		// Passing [nil] without the pointer cast would result in a panic when
-		// performing the type assertion in the above cache check.
-		ldb.nodeCache.Put(keyStr, (*node)(nil))
+		ldb.nodeCache.Put(keyStr, nil)
 		return node{}, err
 	}
 	if err != nil {
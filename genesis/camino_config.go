@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 )
 
@@ -24,6 +25,10 @@ type Camino struct {
 	DepositOffers            []DepositOffer     `json:"depositOffers"`
 	Allocations              []CaminoAllocation `json:"allocations"`
 	InitialMultisigAddresses []MultisigAlias    `json:"initialMultisigAddresses"`
+	// Max nesting depth of initial multisig aliases, if zero, secp256k1fx.DefaultMaxAliasDepth is used
+	MaxMultisigAliasDepth int `json:"maxMultisigAliasDepth,omitempty"`
+	// Max memo size of initial multisig aliases, if zero, multisig.MaxMemoSize is used
+	MaxMultisigAliasMemoSize int `json:"maxMultisigAliasMemoSize,omitempty"`
 }
 
 func (c Camino) Unparse(networkID uint32, starttime uint64) (UnparsedCamino, error) {
@@ -33,6 +38,8 @@ func (c Camino) Unparse(networkID uint32, starttime uint64) (UnparsedCamino, err
 		DepositOffers:            make([]UnparsedDepositOffer, len(c.DepositOffers)),
 		Allocations:              make([]UnparsedCaminoAllocation, len(c.Allocations)),
 		InitialMultisigAddresses: make([]UnparsedMultisigAlias, len(c.InitialMultisigAddresses)),
+		MaxMultisigAliasDepth:    c.MaxMultisigAliasDepth,
+		MaxMultisigAliasMemoSize: c.MaxMultisigAliasMemoSize,
 	}
 
 	avaxAddr, err := address.Format(
@@ -54,7 +61,7 @@ func (c Camino) Unparse(networkID uint32, starttime uint64) (UnparsedCamino, err
 	}
 
 	for i := range uc.DepositOffers {
-		uc.DepositOffers[i], err = c.DepositOffers[i].Unparse(starttime)
+		uc.DepositOffers[i], err = c.DepositOffers[i].Unparse(networkID, starttime)
 		if err != nil {
 			return uc, err
 		}
@@ -211,27 +218,57 @@ type AddressStates struct {
 }
 
 type DepositOffer struct {
-	InterestRateNominator   uint64 `json:"interestRateNominator"`
-	Start                   uint64 `json:"start"`
-	End                     uint64 `json:"end"`
-	MinAmount               uint64 `json:"minAmount"`
-	MinDuration             uint32 `json:"minDuration"`
-	MaxDuration             uint32 `json:"maxDuration"`
-	UnlockPeriodDuration    uint32 `json:"unlockPeriodDuration"`
-	NoRewardsPeriodDuration uint32 `json:"noRewardsPeriodDuration"`
-	Memo                    string `json:"memo"`
-	Flags                   uint64 `json:"flags"`
+	InterestRateNominator   uint64                     `json:"interestRateNominator"`
+	Start                   uint64                     `json:"start"`
+	End                     uint64                     `json:"end"`
+	MinAmount               uint64                     `json:"minAmount"`
+	TotalMaxAmount          uint64                     `json:"totalMaxAmount"`
+	MinDuration             uint32                     `json:"minDuration"`
+	MaxDuration             uint32                     `json:"maxDuration"`
+	UnlockPeriodDuration    uint32                     `json:"unlockPeriodDuration"`
+	NoRewardsPeriodDuration uint32                     `json:"noRewardsPeriodDuration"`
+	Memo                    string                     `json:"memo"`
+	Flags                   uint64                     `json:"flags"`
+	Tiers                   []deposit.InterestRateTier `json:"tiers,omitempty"`
+	MaxAmount               uint64                     `json:"maxAmount,omitempty"`
+
+	EarlyUnlockPenaltyNominator       uint64 `json:"earlyUnlockPenaltyNominator,omitempty"`
+	EarlyUnlockRewardPenaltyNominator uint64 `json:"earlyUnlockRewardPenaltyNominator,omitempty"`
+
+	RewardSchedule deposit.VestingSchedule `json:"rewardSchedule"`
+
+	RequiredAddressState as.AddressState `json:"requiredAddressState,omitempty"`
+
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+
+	RewardAssetID ids.ID `json:"rewardAssetID"`
+
+	OwnerAddress ids.ShortID `json:"ownerAddress"`
 }
 
-func (parsedOffer DepositOffer) Unparse(startime uint64) (UnparsedDepositOffer, error) {
+func (parsedOffer DepositOffer) Unparse(networkID uint32, startime uint64) (UnparsedDepositOffer, error) {
 	unparsedOffer := UnparsedDepositOffer{
 		InterestRateNominator:   parsedOffer.InterestRateNominator,
 		MinAmount:               parsedOffer.MinAmount,
+		TotalMaxAmount:          parsedOffer.TotalMaxAmount,
 		MinDuration:             parsedOffer.MinDuration,
 		MaxDuration:             parsedOffer.MaxDuration,
 		UnlockPeriodDuration:    parsedOffer.UnlockPeriodDuration,
 		NoRewardsPeriodDuration: parsedOffer.NoRewardsPeriodDuration,
 		Memo:                    parsedOffer.Memo,
+		Tiers:                   parsedOffer.Tiers,
+		MaxAmount:               parsedOffer.MaxAmount,
+
+		EarlyUnlockPenaltyNominator:       parsedOffer.EarlyUnlockPenaltyNominator,
+		EarlyUnlockRewardPenaltyNominator: parsedOffer.EarlyUnlockRewardPenaltyNominator,
+
+		RewardSchedule: parsedOffer.RewardSchedule,
+
+		RequiredAddressState: parsedOffer.RequiredAddressState,
+
+		Name: parsedOffer.Name,
+		URI:  parsedOffer.URI,
 	}
 
 	offerStartOffset, err := math.Sub(parsedOffer.Start, startime)
@@ -249,6 +286,27 @@ func (parsedOffer DepositOffer) Unparse(startime uint64) (UnparsedDepositOffer,
 	if parsedOffer.Flags&deposit.OfferFlagLocked != 0 {
 		unparsedOffer.Flags.Locked = true
 	}
+	if parsedOffer.Flags&deposit.OfferFlagCompounding != 0 {
+		unparsedOffer.Flags.Compounding = true
+	}
+	if parsedOffer.Flags&deposit.OfferFlagEarlyUnlock != 0 {
+		unparsedOffer.Flags.EarlyUnlock = true
+	}
+	if parsedOffer.Flags&deposit.OfferFlagPaused != 0 {
+		unparsedOffer.Flags.Paused = true
+	}
+
+	if parsedOffer.RewardAssetID != ids.Empty {
+		unparsedOffer.RewardAssetID = parsedOffer.RewardAssetID.String()
+	}
+
+	if parsedOffer.OwnerAddress != ids.ShortEmpty {
+		ownerAddress, err := address.Format(configChainIDAlias, constants.GetHRP(networkID), parsedOffer.OwnerAddress.Bytes())
+		if err != nil {
+			return unparsedOffer, fmt.Errorf("while unparsing cannot format deposit offer owner address: %w", err)
+		}
+		unparsedOffer.OwnerAddress = ownerAddress
+	}
 
 	return unparsedOffer, nil
 }
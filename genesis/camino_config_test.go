@@ -41,6 +41,7 @@ func TestUnparse(t *testing.T) {
 					Start:                   2,
 					End:                     3,
 					MinAmount:               4,
+					TotalMaxAmount:          40,
 					MinDuration:             5,
 					MaxDuration:             6,
 					UnlockPeriodDuration:    7,
@@ -78,6 +79,7 @@ func TestUnparse(t *testing.T) {
 					StartOffset:             2,
 					EndOffset:               3,
 					MinAmount:               4,
+					TotalMaxAmount:          40,
 					MinDuration:             5,
 					MaxDuration:             6,
 					UnlockPeriodDuration:    7,
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -20,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/avm"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
 	"github.com/ava-labs/avalanchego/vms/nftfx"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
@@ -194,6 +196,7 @@ func validateCaminoConfig(config *Config) error {
 	// validate msig aliases
 	txID := ids.Empty
 	uniqAliases := set.NewSet[ids.ShortID](len(config.Camino.InitialMultisigAddresses))
+	aliases := make(aliasGetter, len(config.Camino.InitialMultisigAddresses))
 	for _, configMsigAlias := range config.Camino.InitialMultisigAddresses {
 		if expectedAlias := configMsigAlias.ComputeAlias(txID); configMsigAlias.Alias != expectedAlias {
 			hrp := constants.GetHRP(config.NetworkID)
@@ -212,10 +215,26 @@ func validateCaminoConfig(config *Config) error {
 			return fmt.Errorf("wrong msig alias definition: %w", err)
 		}
 
+		if err := multisig.VerifyMemoSize(msigAlias.Memo, config.Camino.MaxMultisigAliasMemoSize); err != nil {
+			return fmt.Errorf("wrong msig alias definition: %w", err)
+		}
+
 		if uniqAliases.Contains(configMsigAlias.Alias) {
 			return fmt.Errorf("duplicated Multisig alias: %s (%s)", configMsigAlias.Alias.Hex(), configMsigAlias.Memo)
 		}
 		uniqAliases.Add(configMsigAlias.Alias)
+		aliases[msigAlias.ID] = msigAlias
+	}
+
+	// validate msig aliases nesting
+	for _, configMsigAlias := range config.Camino.InitialMultisigAddresses {
+		if err := secp256k1fx.VerifyAliasNesting(
+			&secp256k1fx.OutputOwners{Addrs: []ids.ShortID{configMsigAlias.Alias}},
+			aliases,
+			config.Camino.MaxMultisigAliasDepth,
+		); err != nil {
+			return fmt.Errorf("wrong msig alias definition: %w", err)
+		}
 	}
 
 	if nodes.Len() == 0 {
@@ -364,14 +383,14 @@ func buildPGenesis(config *Config, hrp string, xGenesisBytes []byte, xGenesisDat
 	// Getting args from allocations
 
 	for _, allocation := range config.Camino.Allocations {
-		var addrState uint64
+		addrState := as.AddressStateEmpty
 		if allocation.AddressStates.ConsortiumMember {
-			addrState |= pchaintxs.AddressStateConsortiumBit
+			addrState = addrState.Set(as.AddressStateConsortium)
 		}
 		if allocation.AddressStates.KYCVerified {
-			addrState |= pchaintxs.AddressStateKycVerifiedBit
+			addrState = addrState.Set(as.AddressStateKycVerified)
 		}
-		if addrState != 0 {
+		if addrState != as.AddressStateEmpty {
 			platformvmArgs.Camino.AddressStates = append(platformvmArgs.Camino.AddressStates, genesis.AddressState{
 				Address: allocation.AVAXAddr,
 				State:   addrState,
@@ -557,18 +576,63 @@ func GetGenesisBlocksIDs(genesisBytes []byte, genesis *genesis.Genesis) ([]ids.I
 	return blockIDs, nil
 }
 
+// GetGenesisDepositOffers returns parsed genesis deposit offers of [networkID] network
+func GetGenesisDepositOffers(networkID uint32) ([]*deposit.Offer, error) {
+	configDepositOffers := GetConfig(networkID).Camino.DepositOffers
+	offers := make([]*deposit.Offer, len(configDepositOffers))
+	for i := range configDepositOffers {
+		offer, err := DepositOfferFromConfig(configDepositOffers[i])
+		if err != nil {
+			return nil, err
+		}
+		offers[i] = offer
+	}
+	return offers, nil
+}
+
+// GetGenesisAllocations returns copy of genesis camino allocations of [networkID] network
+func GetGenesisAllocations(networkID uint32) []CaminoAllocation {
+	configAllocations := GetConfig(networkID).Camino.Allocations
+	allocations := make([]CaminoAllocation, len(configAllocations))
+	for i := range configAllocations {
+		allocations[i] = configAllocations[i]
+		allocations[i].PlatformAllocations = append(
+			[]PlatformAllocation(nil),
+			configAllocations[i].PlatformAllocations...,
+		)
+	}
+	return allocations
+}
+
 func DepositOfferFromConfig(configDepositOffer DepositOffer) (*deposit.Offer, error) {
 	offer := &deposit.Offer{
 		InterestRateNominator:   configDepositOffer.InterestRateNominator,
 		Start:                   configDepositOffer.Start,
 		End:                     configDepositOffer.End,
 		MinAmount:               configDepositOffer.MinAmount,
+		TotalMaxAmount:          configDepositOffer.TotalMaxAmount,
 		MinDuration:             configDepositOffer.MinDuration,
 		MaxDuration:             configDepositOffer.MaxDuration,
 		UnlockPeriodDuration:    configDepositOffer.UnlockPeriodDuration,
 		NoRewardsPeriodDuration: configDepositOffer.NoRewardsPeriodDuration,
 		Memo:                    types.JSONByteSlice(configDepositOffer.Memo),
 		Flags:                   configDepositOffer.Flags,
+		Tiers:                   configDepositOffer.Tiers,
+		MaxAmount:               configDepositOffer.MaxAmount,
+
+		EarlyUnlockPenaltyNominator:       configDepositOffer.EarlyUnlockPenaltyNominator,
+		EarlyUnlockRewardPenaltyNominator: configDepositOffer.EarlyUnlockRewardPenaltyNominator,
+
+		RewardSchedule: configDepositOffer.RewardSchedule,
+
+		RequiredAddressState: configDepositOffer.RequiredAddressState,
+
+		Name: configDepositOffer.Name,
+		URI:  configDepositOffer.URI,
+
+		RewardAssetID: configDepositOffer.RewardAssetID,
+
+		OwnerAddress: configDepositOffer.OwnerAddress,
 	}
 	if err := offer.SetID(); err != nil {
 		return nil, err
@@ -576,6 +640,16 @@ func DepositOfferFromConfig(configDepositOffer DepositOffer) (*deposit.Offer, er
 	return offer, nil
 }
 
+// aliasGetter is secp256k1fx.AliasGetter over genesis msig aliases
+type aliasGetter map[ids.ShortID]*multisig.Alias
+
+func (a aliasGetter) GetMultisigAlias(aliasID ids.ShortID) (*multisig.Alias, error) {
+	if alias, ok := a[aliasID]; ok {
+		return alias, nil
+	}
+	return nil, database.ErrNotFound
+}
+
 func MultisigAliasFromConfig(configMsigAlias MultisigAlias) (*multisig.Alias, error) {
 	return &multisig.Alias{
 		Owners: &secp256k1fx.OutputOwners{
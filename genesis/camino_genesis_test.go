@@ -8,6 +8,9 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/stretchr/testify/require"
 )
 
@@ -53,3 +56,95 @@ func TestGenesisChainData(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGenesisDepositOffers(t *testing.T) {
+	require := require.New(t)
+
+	for _, networkID := range []uint32{constants.CaminoID, constants.ColumbusID, constants.KopernikusID, constants.LocalID} {
+		config := GetConfig(networkID)
+		offers, err := GetGenesisDepositOffers(networkID)
+		require.NoError(err)
+		require.Len(offers, len(config.Camino.DepositOffers))
+		for i, offer := range offers {
+			expectedOffer, err := DepositOfferFromConfig(config.Camino.DepositOffers[i])
+			require.NoError(err)
+			require.Equal(expectedOffer, offer)
+			require.NotEqual(ids.Empty, offer.ID)
+		}
+	}
+}
+
+func TestFromConfigWithDepositOfferExtensions(t *testing.T) {
+	require := require.New(t)
+
+	config := *GetConfig(constants.LocalID)
+	config.Camino.LockModeBondDeposit = true
+	config.Camino.DepositOffers = append(
+		append([]DepositOffer(nil), config.Camino.DepositOffers...),
+		DepositOffer{
+			InterestRateNominator: 80_000,
+			Start:                 config.StartTime,
+			End:                   config.StartTime + 365*24*60*60,
+			MinAmount:             1,
+			MinDuration:           60,
+			MaxDuration:           365 * 24 * 60 * 60,
+			Memo:                  "offer with extensions",
+			Tiers: []deposit.InterestRateTier{
+				{MinAmount: 1000, InterestRateNominator: 90_000},
+				{MinAmount: 10_000, InterestRateNominator: 100_000},
+			},
+			MaxAmount:                         1_000_000,
+			Flags:                             deposit.OfferFlagEarlyUnlock,
+			EarlyUnlockPenaltyNominator:       100_000,
+			EarlyUnlockRewardPenaltyNominator: 500_000,
+			RewardSchedule:                    deposit.VestingSchedule{CliffDuration: 30, VestingDuration: 30},
+			RequiredAddressState:              as.AddressStateKycVerified,
+			Name:                              "offer name",
+			URI:                               "https://example.com/offer",
+			RewardAssetID:                     ids.ID{1},
+			OwnerAddress:                      ids.ShortID{2},
+		},
+	)
+	extendedOffer := config.Camino.DepositOffers[len(config.Camino.DepositOffers)-1]
+
+	genesisBytes, _, err := FromConfig(&config)
+	require.NoError(err)
+	platformGenesis, err := genesis.Parse(genesisBytes)
+	require.NoError(err)
+
+	require.Len(platformGenesis.Camino.DepositOffers, len(config.Camino.DepositOffers))
+	for i, offer := range platformGenesis.Camino.DepositOffers {
+		expectedOffer, err := DepositOfferFromConfig(config.Camino.DepositOffers[i])
+		require.NoError(err)
+		require.Equal(expectedOffer, offer)
+	}
+	parsedOffer := platformGenesis.Camino.DepositOffers[len(config.Camino.DepositOffers)-1]
+	require.Equal(extendedOffer.Tiers, parsedOffer.Tiers)
+	require.Equal(extendedOffer.MaxAmount, parsedOffer.MaxAmount)
+	require.Equal(extendedOffer.EarlyUnlockPenaltyNominator, parsedOffer.EarlyUnlockPenaltyNominator)
+	require.Equal(extendedOffer.EarlyUnlockRewardPenaltyNominator, parsedOffer.EarlyUnlockRewardPenaltyNominator)
+	require.Equal(extendedOffer.RewardSchedule, parsedOffer.RewardSchedule)
+	require.Equal(extendedOffer.RequiredAddressState, parsedOffer.RequiredAddressState)
+	require.Equal(extendedOffer.Name, parsedOffer.Name)
+	require.Equal(extendedOffer.URI, parsedOffer.URI)
+	require.Equal(extendedOffer.RewardAssetID, parsedOffer.RewardAssetID)
+	require.Equal(extendedOffer.OwnerAddress, parsedOffer.OwnerAddress)
+}
+
+func TestGetGenesisAllocations(t *testing.T) {
+	require := require.New(t)
+
+	config := GetConfig(constants.KopernikusID)
+	allocations := GetGenesisAllocations(constants.KopernikusID)
+	require.Equal(config.Camino.Allocations, allocations)
+
+	// modifying returned allocations must not affect genesis config
+	for i := range allocations {
+		allocations[i].XAmount++
+		for j := range allocations[i].PlatformAllocations {
+			allocations[i].PlatformAllocations[j].Amount++
+		}
+	}
+	require.NotEqual(config.Camino.Allocations, allocations)
+	require.Equal(config.Camino.Allocations, GetGenesisAllocations(constants.KopernikusID))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/math"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 )
 
@@ -23,6 +24,8 @@ type UnparsedCamino struct {
 	DepositOffers            []UnparsedDepositOffer     `json:"depositOffers"`
 	Allocations              []UnparsedCaminoAllocation `json:"allocations"`
 	InitialMultisigAddresses []UnparsedMultisigAlias    `json:"initialMultisigAddresses"`
+	MaxMultisigAliasDepth    int                        `json:"maxMultisigAliasDepth,omitempty"`
+	MaxMultisigAliasMemoSize int                        `json:"maxMultisigAliasMemoSize,omitempty"`
 }
 
 func (uc UnparsedCamino) Parse(startTime uint64) (Camino, error) {
@@ -32,6 +35,8 @@ func (uc UnparsedCamino) Parse(startTime uint64) (Camino, error) {
 		DepositOffers:            make([]DepositOffer, len(uc.DepositOffers)),
 		Allocations:              make([]CaminoAllocation, len(uc.Allocations)),
 		InitialMultisigAddresses: make([]MultisigAlias, len(uc.InitialMultisigAddresses)),
+		MaxMultisigAliasDepth:    uc.MaxMultisigAliasDepth,
+		MaxMultisigAliasMemoSize: uc.MaxMultisigAliasMemoSize,
 	}
 
 	_, _, avaxAddrBytes, err := address.Parse(uc.InitialAdmin)
@@ -192,31 +197,64 @@ func (uma UnparsedMultisigAlias) Parse() (MultisigAlias, error) {
 }
 
 type UnparsedDepositOffer struct {
-	InterestRateNominator   uint64                    `json:"interestRateNominator"`
-	StartOffset             uint64                    `json:"startOffset"`
-	EndOffset               uint64                    `json:"endOffset"`
-	MinAmount               uint64                    `json:"minAmount"`
-	MinDuration             uint32                    `json:"minDuration"`
-	MaxDuration             uint32                    `json:"maxDuration"`
-	UnlockPeriodDuration    uint32                    `json:"unlockPeriodDuration"`
-	NoRewardsPeriodDuration uint32                    `json:"noRewardsPeriodDuration"`
-	Memo                    string                    `json:"memo"`
-	Flags                   UnparsedDepositOfferFlags `json:"flags"`
+	InterestRateNominator   uint64                     `json:"interestRateNominator"`
+	StartOffset             uint64                     `json:"startOffset"`
+	EndOffset               uint64                     `json:"endOffset"`
+	MinAmount               uint64                     `json:"minAmount"`
+	TotalMaxAmount          uint64                     `json:"totalMaxAmount"`
+	MinDuration             uint32                     `json:"minDuration"`
+	MaxDuration             uint32                     `json:"maxDuration"`
+	UnlockPeriodDuration    uint32                     `json:"unlockPeriodDuration"`
+	NoRewardsPeriodDuration uint32                     `json:"noRewardsPeriodDuration"`
+	Memo                    string                     `json:"memo"`
+	Flags                   UnparsedDepositOfferFlags  `json:"flags"`
+	Tiers                   []deposit.InterestRateTier `json:"tiers,omitempty"`
+	MaxAmount               uint64                     `json:"maxAmount,omitempty"`
+
+	EarlyUnlockPenaltyNominator       uint64 `json:"earlyUnlockPenaltyNominator,omitempty"`
+	EarlyUnlockRewardPenaltyNominator uint64 `json:"earlyUnlockRewardPenaltyNominator,omitempty"`
+
+	RewardSchedule deposit.VestingSchedule `json:"rewardSchedule"`
+
+	RequiredAddressState as.AddressState `json:"requiredAddressState,omitempty"`
+
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+
+	RewardAssetID string `json:"rewardAssetID,omitempty"`
+
+	OwnerAddress string `json:"ownerAddress,omitempty"`
 }
 
 type UnparsedDepositOfferFlags struct {
-	Locked bool `json:"locked"`
+	Locked      bool `json:"locked"`
+	Compounding bool `json:"compounding"`
+	EarlyUnlock bool `json:"earlyUnlock"`
+	Paused      bool `json:"paused"`
 }
 
 func (udo UnparsedDepositOffer) Parse(startTime uint64) (DepositOffer, error) {
 	do := DepositOffer{
 		InterestRateNominator:   udo.InterestRateNominator,
 		MinAmount:               udo.MinAmount,
+		TotalMaxAmount:          udo.TotalMaxAmount,
 		MinDuration:             udo.MinDuration,
 		MaxDuration:             udo.MaxDuration,
 		UnlockPeriodDuration:    udo.UnlockPeriodDuration,
 		NoRewardsPeriodDuration: udo.NoRewardsPeriodDuration,
 		Memo:                    udo.Memo,
+		Tiers:                   udo.Tiers,
+		MaxAmount:               udo.MaxAmount,
+
+		EarlyUnlockPenaltyNominator:       udo.EarlyUnlockPenaltyNominator,
+		EarlyUnlockRewardPenaltyNominator: udo.EarlyUnlockRewardPenaltyNominator,
+
+		RewardSchedule: udo.RewardSchedule,
+
+		RequiredAddressState: udo.RequiredAddressState,
+
+		Name: udo.Name,
+		URI:  udo.URI,
 	}
 
 	offerStartTime, err := math.Add64(startTime, udo.StartOffset)
@@ -234,6 +272,31 @@ func (udo UnparsedDepositOffer) Parse(startTime uint64) (DepositOffer, error) {
 	if udo.Flags.Locked {
 		do.Flags |= deposit.OfferFlagLocked
 	}
+	if udo.Flags.Compounding {
+		do.Flags |= deposit.OfferFlagCompounding
+	}
+	if udo.Flags.EarlyUnlock {
+		do.Flags |= deposit.OfferFlagEarlyUnlock
+	}
+	if udo.Flags.Paused {
+		do.Flags |= deposit.OfferFlagPaused
+	}
+
+	if udo.RewardAssetID != "" {
+		rewardAssetID, err := ids.FromString(udo.RewardAssetID)
+		if err != nil {
+			return do, fmt.Errorf("cannot parse deposit offer reward asset id: %w", err)
+		}
+		do.RewardAssetID = rewardAssetID
+	}
+
+	if udo.OwnerAddress != "" {
+		ownerAddress, err := address.ParseToID(udo.OwnerAddress)
+		if err != nil {
+			return do, fmt.Errorf("cannot parse deposit offer owner address: %w", err)
+		}
+		do.OwnerAddress = ownerAddress
+	}
 
 	return do, nil
 }
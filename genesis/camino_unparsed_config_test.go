@@ -10,8 +10,10 @@ import (
 	"testing"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/stretchr/testify/require"
 )
@@ -97,6 +99,7 @@ func TestParse(t *testing.T) {
 					StartOffset:             2,
 					EndOffset:               3,
 					MinAmount:               4,
+					TotalMaxAmount:          40,
 					MinDuration:             5,
 					MaxDuration:             6,
 					UnlockPeriodDuration:    7,
@@ -134,6 +137,7 @@ func TestParse(t *testing.T) {
 					Start:                   2,
 					End:                     3,
 					MinAmount:               4,
+					TotalMaxAmount:          40,
 					MinDuration:             5,
 					MaxDuration:             6,
 					UnlockPeriodDuration:    7,
@@ -180,6 +184,10 @@ func TestParse(t *testing.T) {
 }
 
 func TestParsingAndUnparsingDepositOffer(t *testing.T) {
+	networkID := constants.LocalID
+	ownerAddress, err := address.Format(configChainIDAlias, constants.GetHRP(networkID), ids.ShortID{1}.Bytes())
+	require.NoError(t, err)
+
 	tests := map[string]struct {
 		startTime uint64
 		udo       UnparsedDepositOffer
@@ -229,6 +237,101 @@ func TestParsingAndUnparsingDepositOffer(t *testing.T) {
 				NoRewardsPeriodDuration: 31536000,
 			},
 		},
+		"Offer with tiers": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				Tiers: []deposit.InterestRateTier{
+					{MinAmount: 1000, InterestRateNominator: 90000},
+					{MinAmount: 10000, InterestRateNominator: 100000},
+				},
+			},
+		},
+		"Offer with max amount": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				MaxAmount:             1000000,
+			},
+		},
+		"Offer with early unlock": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator:             80000,
+				EndOffset:                         63072000,
+				MinAmount:                         1,
+				MinDuration:                       60,
+				MaxDuration:                       31536000,
+				Flags:                             UnparsedDepositOfferFlags{EarlyUnlock: true},
+				EarlyUnlockPenaltyNominator:       100000,
+				EarlyUnlockRewardPenaltyNominator: 500000,
+			},
+		},
+		"Offer with reward schedule": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				RewardSchedule:        deposit.VestingSchedule{CliffDuration: 30, VestingDuration: 30},
+			},
+		},
+		"Offer with required address state": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				RequiredAddressState:  as.AddressStateKycVerified,
+			},
+		},
+		"Offer with name and uri": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				Name:                  "offer name",
+				URI:                   "https://example.com/offer",
+			},
+		},
+		"Offer with reward asset and owner address": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				RewardAssetID:         ids.ID{2}.String(),
+				OwnerAddress:          ownerAddress,
+			},
+		},
+		"Paused offer": {
+			startTime: uint64(1670956381),
+			udo: UnparsedDepositOffer{
+				InterestRateNominator: 80000,
+				EndOffset:             63072000,
+				MinAmount:             1,
+				MinDuration:           60,
+				MaxDuration:           31536000,
+				Flags:                 UnparsedDepositOfferFlags{Paused: true},
+			},
+		},
 		"Template does require OfferID as well": {
 			startTime: uint64(0),
 			udo: UnparsedDepositOffer{
@@ -251,7 +354,7 @@ func TestParsingAndUnparsingDepositOffer(t *testing.T) {
 
 			// Don't check template equality
 			if tt.startTime > 0 {
-				udo, err := do.Unparse(tt.startTime)
+				udo, err := do.Unparse(networkID, tt.startTime)
 				require.NoError(t, err)
 				require.Equal(t, tt.udo, udo)
 			}
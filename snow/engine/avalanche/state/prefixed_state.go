@@ -21,27 +21,27 @@ var uniqueEdgeID = ids.Empty.Prefix(edgeID)
 type prefixedState struct {
 	state *state
 
-	vtx, status cache.Cacher
-	uniqueVtx   cache.Deduplicator
+	vtx, status cache.Cacher[ids.ID, ids.ID]
+	uniqueVtx   cache.Deduplicator[ids.ID, *uniqueVertex]
 }
 
 func newPrefixedState(state *state, idCacheSizes int) *prefixedState {
 	return &prefixedState{
 		state:     state,
-		vtx:       &cache.LRU{Size: idCacheSizes},
-		status:    &cache.LRU{Size: idCacheSizes},
-		uniqueVtx: &cache.EvictableLRU{Size: idCacheSizes},
+		vtx:       &cache.LRU[ids.ID, ids.ID]{Size: idCacheSizes},
+		status:    &cache.LRU[ids.ID, ids.ID]{Size: idCacheSizes},
+		uniqueVtx: &cache.EvictableLRU[ids.ID, *uniqueVertex]{Size: idCacheSizes},
 	}
 }
 
 func (s *prefixedState) UniqueVertex(vtx *uniqueVertex) *uniqueVertex {
-	return s.uniqueVtx.Deduplicate(vtx).(*uniqueVertex)
+	return s.uniqueVtx.Deduplicate(vtx)
 }
 
 func (s *prefixedState) Vertex(id ids.ID) vertex.StatelessVertex {
 	var vID ids.ID
-	if cachedVtxIDIntf, found := s.vtx.Get(id); found {
-		vID = cachedVtxIDIntf.(ids.ID)
+	if cachedID, found := s.vtx.Get(id); found {
+		vID = cachedID
 	} else {
 		vID = id.Prefix(vtxID)
 		s.vtx.Put(id, vID)
@@ -53,8 +53,8 @@ func (s *prefixedState) Vertex(id ids.ID) vertex.StatelessVertex {
 func (s *prefixedState) SetVertex(vtx vertex.StatelessVertex) error {
 	rawVertexID := vtx.ID()
 	var vID ids.ID
-	if cachedVtxIDIntf, found := s.vtx.Get(rawVertexID); found {
-		vID = cachedVtxIDIntf.(ids.ID)
+	if cachedID, found := s.vtx.Get(rawVertexID); found {
+		vID = cachedID
 	} else {
 		vID = rawVertexID.Prefix(vtxID)
 		s.vtx.Put(rawVertexID, vID)
@@ -65,8 +65,8 @@ func (s *prefixedState) SetVertex(vtx vertex.StatelessVertex) error {
 
 func (s *prefixedState) Status(id ids.ID) choices.Status {
 	var sID ids.ID
-	if cachedStatusIDIntf, found := s.status.Get(id); found {
-		sID = cachedStatusIDIntf.(ids.ID)
+	if cachedID, found := s.status.Get(id); found {
+		sID = cachedID
 	} else {
 		sID = id.Prefix(vtxStatusID)
 		s.status.Put(id, sID)
@@ -77,8 +77,8 @@ func (s *prefixedState) Status(id ids.ID) choices.Status {
 
 func (s *prefixedState) SetStatus(id ids.ID, status choices.Status) error {
 	var sID ids.ID
-	if cachedStatusIDIntf, found := s.status.Get(id); found {
-		sID = cachedStatusIDIntf.(ids.ID)
+	if cachedID, found := s.status.Get(id); found {
+		sID = cachedID
 	} else {
 		sID = id.Prefix(vtxStatusID)
 		s.status.Put(id, sID)
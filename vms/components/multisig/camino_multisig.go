@@ -4,6 +4,7 @@
 package multisig
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -16,6 +17,8 @@ import (
 // MaxMemoSize is the maximum number of bytes in the memo field
 const MaxMemoSize = 256
 
+var ErrMemoTooLarge = errors.New("msig alias memo is larger")
+
 type Alias struct {
 	ID     ids.ShortID         `serialize:"true" json:"id"`
 	Memo   types.JSONByteSlice `serialize:"true" json:"memo"`
@@ -27,8 +30,8 @@ func (ma *Alias) InitCtx(ctx *snow.Context) {
 }
 
 func (ma *Alias) Verify() error {
-	if len(ma.Memo) > MaxMemoSize {
-		return fmt.Errorf("msig alias memo is larger (%d bytes) than max of %d bytes", len(ma.Memo), MaxMemoSize)
+	if err := VerifyMemoSize(ma.Memo, MaxMemoSize); err != nil {
+		return err
 	}
 
 	return ma.Owners.Verify()
@@ -38,6 +41,18 @@ func (ma *Alias) VerifyState() error {
 	return ma.Verify()
 }
 
+// VerifyMemoSize returns ErrMemoTooLarge, if [memo] is larger than [maxSize] bytes.
+// If [maxSize] is zero or greater than MaxMemoSize, MaxMemoSize is used.
+func VerifyMemoSize(memo []byte, maxSize int) error {
+	if maxSize <= 0 || maxSize > MaxMemoSize {
+		maxSize = MaxMemoSize
+	}
+	if len(memo) > maxSize {
+		return fmt.Errorf("%w (%d bytes) than max of %d bytes", ErrMemoTooLarge, len(memo), maxSize)
+	}
+	return nil
+}
+
 func ComputeAliasID(txID ids.ID) ids.ShortID {
 	return hashing.ComputeHash160Array(txID[:])
 }
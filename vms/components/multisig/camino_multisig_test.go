@@ -40,3 +40,35 @@ func TestVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyMemoSize(t *testing.T) {
+	tests := map[string]struct {
+		memoSize    int
+		maxSize     int
+		expectedErr error
+	}{
+		"OK: configured max size": {
+			memoSize: 10,
+			maxSize:  10,
+		},
+		"Memo is larger than configured max size": {
+			memoSize:    11,
+			maxSize:     10,
+			expectedErr: ErrMemoTooLarge,
+		},
+		"OK: zero max size, default max size is used": {
+			memoSize: MaxMemoSize,
+		},
+		"Configured max size is greater than default max size": {
+			memoSize:    MaxMemoSize + 1,
+			maxSize:     MaxMemoSize + 10,
+			expectedErr: ErrMemoTooLarge,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyMemoSize(make([]byte, tt.memoSize), tt.maxSize)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -90,7 +90,7 @@ type state struct {
 	// Keys:   Type ID
 	// Values: Cache that stores uniqueIDs for values that were put with that type ID
 	//         (Saves us from having to re-compute uniqueIDs)
-	uniqueIDCaches map[uint64]*cache.LRU
+	uniqueIDCaches map[uint64]*cache.LRU[ids.ID, ids.ID]
 }
 
 func (s *state) RegisterType(
@@ -212,10 +212,10 @@ func (s *state) uniqueID(id ids.ID, typeID uint64) ids.ID {
 	uIDCache, cacheExists := s.uniqueIDCaches[typeID]
 	if cacheExists {
 		if uID, uIDExists := uIDCache.Get(id); uIDExists { // Get the uniqueID associated with [typeID] and [ID]
-			return uID.(ids.ID)
+			return uID
 		}
 	} else {
-		s.uniqueIDCaches[typeID] = &cache.LRU{Size: cacheSize}
+		s.uniqueIDCaches[typeID] = &cache.LRU[ids.ID, ids.ID]{Size: cacheSize}
 	}
 	uID := id.Prefix(typeID)
 	s.uniqueIDCaches[typeID].Put(id, uID)
@@ -227,7 +227,7 @@ func NewState() (State, error) {
 	state := &state{
 		marshallers:    make(map[uint64]func(interface{}) ([]byte, error)),
 		unmarshallers:  make(map[uint64]func([]byte) (interface{}, error)),
-		uniqueIDCaches: make(map[uint64]*cache.LRU),
+		uniqueIDCaches: make(map[uint64]*cache.LRU[ids.ID, ids.ID]),
 	}
 
 	// Register ID, Status and time.Time so they can be put/get without client code
@@ -0,0 +1,71 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addrstate
+
+// AddressState is a bitset of address state flags
+type AddressState uint64
+
+// AddressStateBit is an index of single address state flag, max 63
+type AddressStateBit uint8
+
+const (
+	AddressStateBitRoleAdmin AddressStateBit = 0
+	AddressStateBitRoleKyc   AddressStateBit = 1
+
+	AddressStateBitKycVerified AddressStateBit = 32
+	AddressStateBitKycExpired  AddressStateBit = 33
+
+	AddressStateBitConsortium   AddressStateBit = 38
+	AddressStateBitNodeDeferred AddressStateBit = 39
+
+	AddressStateBitMax AddressStateBit = 63
+)
+
+const (
+	AddressStateEmpty AddressState = 0
+
+	AddressStateRoleAdmin AddressState = AddressState(1) << AddressStateBitRoleAdmin
+	AddressStateRoleKyc   AddressState = AddressState(1) << AddressStateBitRoleKyc
+	AddressStateRoleBits               = AddressStateRoleAdmin | AddressStateRoleKyc
+
+	AddressStateKycVerified AddressState = AddressState(1) << AddressStateBitKycVerified
+	AddressStateKycExpired  AddressState = AddressState(1) << AddressStateBitKycExpired
+	AddressStateKycBits                  = AddressStateKycVerified | AddressStateKycExpired
+
+	AddressStateConsortium   AddressState = AddressState(1) << AddressStateBitConsortium
+	AddressStateNodeDeferred AddressState = AddressState(1) << AddressStateBitNodeDeferred
+	AddressStateVoteBits                  = AddressStateConsortium | AddressStateNodeDeferred
+
+	AddressStateValidBits = AddressStateRoleBits | AddressStateKycBits | AddressStateVoteBits
+)
+
+// Has returns true if all flags of [states] are set
+func (as AddressState) Has(states AddressState) bool {
+	return as&states == states
+}
+
+// HasAny returns true if at least one flag of [states] is set
+func (as AddressState) HasAny(states AddressState) bool {
+	return as&states != 0
+}
+
+// Set returns address state with flags of [states] set
+func (as AddressState) Set(states AddressState) AddressState {
+	return as | states
+}
+
+// Remove returns address state with flags of [states] unset
+func (as AddressState) Remove(states AddressState) AddressState {
+	return as &^ states
+}
+
+// ToAddressState returns address state with only this bit set
+func (asb AddressStateBit) ToAddressState() AddressState {
+	return AddressState(1) << asb
+}
+
+// IsValid returns true if this bit is known address state flag
+func (asb AddressStateBit) IsValid() bool {
+	return asb <= AddressStateBitMax && AddressStateValidBits.Has(asb.ToAddressState())
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package addrstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressState(t *testing.T) {
+	require := require.New(t)
+
+	states := AddressStateEmpty.Set(AddressStateRoleAdmin | AddressStateKycVerified)
+	require.True(states.Has(AddressStateRoleAdmin))
+	require.True(states.Has(AddressStateRoleAdmin | AddressStateKycVerified))
+	require.False(states.Has(AddressStateRoleAdmin | AddressStateRoleKyc))
+	require.True(states.HasAny(AddressStateRoleBits))
+	require.False(states.HasAny(AddressStateVoteBits))
+
+	states = states.Remove(AddressStateRoleAdmin | AddressStateConsortium)
+	require.Equal(AddressStateKycVerified, states)
+}
+
+func TestAddressStateBit(t *testing.T) {
+	tests := map[string]struct {
+		bit           AddressStateBit
+		expectedState AddressState
+		expectedValid bool
+	}{
+		"RoleAdmin": {
+			bit:           AddressStateBitRoleAdmin,
+			expectedState: 0b1,
+			expectedValid: true,
+		},
+		"KycVerified": {
+			bit:           AddressStateBitKycVerified,
+			expectedState: 0b0100000000000000000000000000000000,
+			expectedValid: true,
+		},
+		"NodeDeferred": {
+			bit:           AddressStateBitNodeDeferred,
+			expectedState: 0b1000000000000000000000000000000000000000,
+			expectedValid: true,
+		},
+		"Unknown bit": {
+			bit:           AddressStateBitMax,
+			expectedState: 1 << 63,
+		},
+		"Bit above max": {
+			bit:           AddressStateBitMax + 1,
+			expectedState: 0,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.expectedState, tt.bit.ToAddressState())
+			require.Equal(t, tt.expectedValid, tt.bit.IsValid())
+		})
+	}
+}
@@ -50,14 +50,15 @@ type Camino struct {
 }
 
 func (c Camino) ParseToGenesis() genesis.Camino {
-	return genesis.Camino{
+	genesisCamino := genesis.Camino{
 		VerifyNodeSignature: c.VerifyNodeSignature,
 		LockModeBondDeposit: c.LockModeBondDeposit,
 		InitialAdmin:        c.InitialAdmin,
 		AddressStates:       c.AddressStates,
-		DepositOffers:       c.DepositOffers,
 		MultisigAliases:     c.MultisigAliases,
 	}
+	genesisCamino.SetDepositOffers(c.DepositOffers)
+	return genesisCamino
 }
 
 // BuildGenesis build the genesis state of the Platform Chain (and thereby the Avalanche network.)
@@ -272,7 +273,7 @@ func buildCaminoGenesis(args *BuildGenesisArgs, reply *BuildGenesisReply) error
 	}
 
 	// Marshal genesis to bytes
-	bytes, err := genesis.Codec.Marshal(genesis.Version, g)
+	bytes, err := genesis.Codec.Marshal(camino.CodecVersion(), g)
 	if err != nil {
 		return fmt.Errorf("couldn't marshal genesis: %w", err)
 	}
@@ -15,7 +15,6 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/blocks/executor"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
-	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	txBuilder "github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
@@ -77,7 +76,7 @@ func caminoBuildBlock(
 		return nil, fmt.Errorf("could not find next deposits to unlock: %w", err)
 	}
 	if shouldUnlock {
-		unlockDepositTx, err := txBuilder.NewSystemUnlockDepositTx(depositsTxIDs)
+		unlockDepositTxs, err := txBuilder.NewSystemUnlockDepositTx(depositsTxIDs)
 		if err != nil {
 			return nil, fmt.Errorf("could not build tx to unlock deposits: %w", err)
 		}
@@ -86,7 +85,7 @@ func caminoBuildBlock(
 			timestamp,
 			parentID,
 			height,
-			[]*txs.Tx{unlockDepositTx},
+			unlockDepositTxs,
 		)
 	}
 
@@ -18,12 +18,21 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/codec/reflectcodec"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
-// Version is the current default codec version
-const Version = txs.Version
+const (
+	// Version is the current default codec version
+	Version = txs.Version
+
+	// GenesisExtensionsVersion is genesis codec version, that additionally serializes
+	// fields tagged with GenesisExtensionsTagName. It's only used for genesis with
+	// such fields set, so genesis without them has the same bytes as before.
+	GenesisExtensionsVersion = Version + 1
+	GenesisExtensionsTagName = "serializeV1"
+)
 
 // GenesisCode allows blocks of larger than usual size to be parsed.
 // While this gives flexibility in accommodating large genesis blocks
@@ -38,10 +47,14 @@ func init() {
 	c := linearcodec.NewCaminoDefault()
 	Codec = codec.NewDefaultManager()
 	gc := linearcodec.NewCaminoCustomMaxLength(math.MaxInt32)
+	gcExt := linearcodec.NewCamino(
+		[]string{reflectcodec.DefaultTagName, GenesisExtensionsTagName},
+		math.MaxInt32,
+	)
 	GenesisCodec = codec.NewManager(math.MaxInt32)
 
 	errs := wrappers.Errs{}
-	for _, c := range []codec.CaminoRegistry{c, gc} {
+	for _, c := range []codec.CaminoRegistry{c, gc, gcExt} {
 		errs.Add(
 			RegisterApricotBlockTypes(c),
 			txs.RegisterUnsignedTxsTypes(c),
@@ -51,6 +64,7 @@ func init() {
 	errs.Add(
 		Codec.RegisterCodec(Version, c),
 		GenesisCodec.RegisterCodec(Version, gc),
+		GenesisCodec.RegisterCodec(GenesisExtensionsVersion, gcExt),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
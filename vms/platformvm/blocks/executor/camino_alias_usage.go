@@ -0,0 +1,45 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var _ secp256k1fx.AliasUsageRecorder = (*aliasUsageDiff)(nil)
+
+// aliasUsageDiff is state diff, that collects multisig aliases,
+// which were used by fx verification during single tx execution.
+type aliasUsageDiff struct {
+	state.Diff
+	lock        sync.Mutex
+	usedAliases set.Set[ids.ShortID]
+}
+
+func newAliasUsageDiff(diff state.Diff) *aliasUsageDiff {
+	return &aliasUsageDiff{Diff: diff}
+}
+
+func (d *aliasUsageDiff) RecordAliasUsage(aliasID ids.ShortID) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.usedAliases.Add(aliasID)
+}
+
+// addUsages adds collected aliases usages by [txID] to underlying diff.
+func (d *aliasUsageDiff) addUsages(txID ids.ID) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	aliasIDs := d.usedAliases.List()
+	utils.Sort(aliasIDs)
+	for _, aliasID := range aliasIDs {
+		d.Diff.AddMultisigAliasUsage(aliasID, txID)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAliasUsageDiff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	txID := ids.ID{1}
+	aliasID1 := ids.ShortID{1}
+	aliasID2 := ids.ShortID{2}
+
+	diff := state.NewMockDiff(ctrl)
+	usageDiff := newAliasUsageDiff(diff)
+
+	// recorder must be found by fx through wrapping alias getter
+	var msig secp256k1fx.AliasGetter = usageDiff
+	recorder, ok := secp256k1fx.WithAliasUsageRecorder(msig, usageDiff).(secp256k1fx.AliasUsageRecorder)
+	require.True(t, ok)
+
+	recorder.RecordAliasUsage(aliasID2)
+	usageDiff.RecordAliasUsage(aliasID1)
+	usageDiff.RecordAliasUsage(aliasID2)
+
+	gomock.InOrder(
+		diff.EXPECT().AddMultisigAliasUsage(aliasID1, txID),
+		diff.EXPECT().AddMultisigAliasUsage(aliasID2, txID),
+	)
+	usageDiff.addUsages(txID)
+}
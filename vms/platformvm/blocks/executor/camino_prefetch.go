@@ -0,0 +1,74 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// Max number of goroutines, that concurrently read state during prefetch
+const prefetchWorkers = 8
+
+// prefetchCaminoState concurrently reads utxos consumed by [blockTxs] and deposits,
+// that lock those utxos, from [chain], so they are cached by underlying state
+// before txs are executed one by one. Deposit offers are always kept in memory,
+// so they aren't prefetched. Read errors are ignored, they will be returned
+// during txs execution.
+func prefetchCaminoState(chain state.Chain, blockTxs []*txs.Tx) {
+	utxoIDs := []ids.ID{}
+	for _, tx := range blockTxs {
+		utxoIDs = append(utxoIDs, tx.Unsigned.InputIDs().List()...)
+	}
+	utxos := make([]*avax.UTXO, len(utxoIDs))
+	parallelFor(len(utxoIDs), func(i int) {
+		utxos[i], _ = chain.GetUTXO(utxoIDs[i])
+	})
+
+	depositTxIDs := set.Set[ids.ID]{}
+	for _, utxo := range utxos {
+		if utxo == nil {
+			continue
+		}
+		if lockedOut, ok := locked.AsOut(utxo.Out); ok && lockedOut.DepositTxID != ids.Empty {
+			depositTxIDs.Add(lockedOut.DepositTxID)
+		}
+	}
+	depositTxIDsList := depositTxIDs.List()
+	parallelFor(len(depositTxIDsList), func(i int) {
+		_, _ = chain.GetDeposit(depositTxIDsList[i])
+	})
+}
+
+// parallelFor calls [f] for each index in [0, n) using at most prefetchWorkers goroutines
+// and waits for all calls to finish.
+func parallelFor(n int, f func(i int)) {
+	workers := prefetchWorkers
+	if n < workers {
+		workers = n
+	}
+
+	indices := make(chan int)
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				f(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
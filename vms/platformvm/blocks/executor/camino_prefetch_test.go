@@ -0,0 +1,60 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestPrefetchCaminoState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	depositTxID := ids.ID{10}
+	newUTXO := func(txID ids.ID, depositTxID ids.ID) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: txID},
+			Out: &locked.Out{
+				IDs:             locked.IDs{DepositTxID: depositTxID},
+				TransferableOut: &secp256k1fx.TransferOutput{Amt: 1},
+			},
+		}
+	}
+	depositedUTXO1 := newUTXO(ids.ID{1}, depositTxID)
+	depositedUTXO2 := newUTXO(ids.ID{2}, depositTxID)
+	unlockedUTXO := &avax.UTXO{UTXOID: avax.UTXOID{TxID: ids.ID{3}}, Out: &secp256k1fx.TransferOutput{Amt: 1}}
+	missingUTXOID := avax.UTXOID{TxID: ids.ID{4}}
+
+	newTx := func(utxoIDs ...avax.UTXOID) *txs.Tx {
+		ins := make([]*avax.TransferableInput, len(utxoIDs))
+		for i := range utxoIDs {
+			ins[i] = &avax.TransferableInput{UTXOID: utxoIDs[i]}
+		}
+		return &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{Ins: ins}}}
+	}
+	blockTxs := []*txs.Tx{
+		newTx(depositedUTXO1.UTXOID, unlockedUTXO.UTXOID),
+		newTx(depositedUTXO2.UTXOID, missingUTXOID),
+	}
+
+	chain := state.NewMockChain(ctrl)
+	for _, utxo := range []*avax.UTXO{depositedUTXO1, depositedUTXO2, unlockedUTXO} {
+		chain.EXPECT().GetUTXO(utxo.InputID()).Return(utxo, nil)
+	}
+	chain.EXPECT().GetUTXO(missingUTXOID.InputID()).Return(nil, database.ErrNotFound)
+	chain.EXPECT().GetDeposit(depositTxID).Return(&deposit.Deposit{}, nil)
+
+	prefetchCaminoState(chain, blockTxs)
+}
@@ -413,13 +413,24 @@ func (v *verifier) standardBlock(
 		atomicRequests: make(map[ids.ID]*atomic.Requests),
 	}
 
+	if v.txExecutorBackend.Config.CaminoConfig.PrefetchBlockState {
+		prefetchCaminoState(onAcceptState, b.Transactions)
+	}
+
 	// Finally we process the transactions
 	funcs := make([]func(), 0, len(b.Transactions))
+	recordAliasUsages := v.txExecutorBackend.Config.CaminoConfig.MultisigAliasUsageRetention > 0
 	for _, tx := range b.Transactions {
+		var txState state.Diff = onAcceptState
+		var usageDiff *aliasUsageDiff
+		if recordAliasUsages {
+			usageDiff = newAliasUsageDiff(onAcceptState)
+			txState = usageDiff
+		}
 		txExecutor := executor.CaminoStandardTxExecutor{
 			StandardTxExecutor: executor.StandardTxExecutor{
 				Backend: v.txExecutorBackend,
-				State:   onAcceptState,
+				State:   txState,
 				Tx:      tx,
 			},
 		}
@@ -428,6 +439,9 @@ func (v *verifier) standardBlock(
 			v.MarkDropped(txID, err.Error()) // cache tx as dropped
 			return err
 		}
+		if usageDiff != nil {
+			usageDiff.addUsages(tx.ID())
+		}
 		// ensure it doesn't overlap with current input batch
 		if blkState.inputs.Overlaps(txExecutor.Inputs) {
 			return errConflictingBatchTxs
@@ -7,6 +7,9 @@ import (
 	"context"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -16,6 +19,9 @@ type CaminoClient interface {
 
 	// GetMultisigAlias returns the alias definition of the given multisig address
 	GetMultisigAlias(ctx context.Context, multisigAddress string, options ...rpc.Option) (*GetMultisigAliasReply, error)
+
+	// GetTxSigners returns signers addresses of every credential of the given signed tx
+	GetTxSigners(ctx context.Context, txBytes []byte, options ...rpc.Option) ([][]ids.ShortID, error)
 }
 
 func (c *client) GetConfiguration(ctx context.Context, options ...rpc.Option) (*GetConfigurationReply, error) {
@@ -31,3 +37,24 @@ func (c *client) GetMultisigAlias(ctx context.Context, multisigAddress string, o
 	}, res, options...)
 	return res, err
 }
+
+func (c *client) GetTxSigners(ctx context.Context, txBytes []byte, options ...rpc.Option) ([][]ids.ShortID, error) {
+	txStr, err := formatting.Encode(formatting.Hex, txBytes)
+	if err != nil {
+		return nil, err
+	}
+	res := &GetTxSignersReply{}
+	if err := c.requester.SendRequest(ctx, "platform.getTxSigners", &api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, res, options...); err != nil {
+		return nil, err
+	}
+	signers := make([][]ids.ShortID, len(res.Signers))
+	for i, credSigners := range res.Signers {
+		if signers[i], err = address.ParseToIDs(credSigners); err != nil {
+			return nil, err
+		}
+	}
+	return signers, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	defaultClientAliasCacheSize = 1024
+	// aliases could be modified on-chain, so cached ones must be refetched eventually
+	clientAliasCacheTTL = time.Minute
+)
+
+var (
+	_ secp256k1fx.AliasGetter = (*clientAliasGetter)(nil)
+
+	errAliasReplyMismatch = errors.New("multisig alias reply has inconsistent owners")
+)
+
+// clientAliasGetter is multisig alias getter, that fetches aliases from node API.
+type clientAliasGetter struct {
+	ctx     context.Context
+	client  CaminoClient
+	options []rpc.Option
+	// aliasID -> *multisig.Alias or nil, if alias doesn't exist
+	cache cache.Cacher[ids.ShortID, *multisig.Alias]
+}
+
+// NewClientAliasGetter returns secp256k1fx.AliasGetter, that fetches multisig aliases
+// with [client] and caches them for a minute. It allows off-node signers and wallets to resolve
+// multisig aliases with the same logic as the node does, when preparing credentials.
+// If [cacheSize] is zero, default cache size is used.
+func NewClientAliasGetter(
+	ctx context.Context,
+	client CaminoClient,
+	cacheSize int,
+	options ...rpc.Option,
+) secp256k1fx.AliasGetter {
+	if cacheSize <= 0 {
+		cacheSize = defaultClientAliasCacheSize
+	}
+	return &clientAliasGetter{
+		ctx:     ctx,
+		client:  client,
+		options: options,
+		cache: &cache.ExpiringLRU[ids.ShortID, *multisig.Alias]{
+			Size: cacheSize,
+			TTL:  clientAliasCacheTTL,
+		},
+	}
+}
+
+// GetMultisigAlias returns alias with [aliasID] or database.ErrNotFound, if there is no such alias.
+func (g *clientAliasGetter) GetMultisigAlias(aliasID ids.ShortID) (*multisig.Alias, error) {
+	if alias, ok := g.cache.Get(aliasID); ok {
+		if alias == nil {
+			return nil, database.ErrNotFound
+		}
+		return alias, nil
+	}
+
+	reply, err := g.client.GetMultisigAlias(g.ctx, aliasID.String(), g.options...)
+	if err != nil {
+		// api returns error with ErrAliasNotFound message, if alias doesn't exist
+		if strings.Contains(err.Error(), secp256k1fx.ErrAliasNotFound.Error()) {
+			g.cache.Put(aliasID, nil)
+			return nil, database.ErrNotFound
+		}
+		return nil, err
+	}
+
+	alias, err := aliasFromReply(aliasID, reply)
+	if err != nil {
+		return nil, err
+	}
+	g.cache.Put(aliasID, alias)
+	return alias, nil
+}
+
+// aliasFromReply converts api [reply] into multisig alias with [aliasID]
+func aliasFromReply(aliasID ids.ShortID, reply *GetMultisigAliasReply) (*multisig.Alias, error) {
+	addrs, err := address.ParseToIDs(reply.Addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: uint32(reply.Threshold),
+		Addrs:     addrs,
+	}
+
+	var owners verify.State
+	switch {
+	case len(reply.Weights) > 0:
+		if len(reply.Weights) != len(addrs) {
+			return nil, errAliasReplyMismatch
+		}
+		weights := make([]uint32, len(reply.Weights))
+		for i, weight := range reply.Weights {
+			weights[i] = uint32(weight)
+		}
+		owners = &secp256k1fx.WeightedOutputOwners{
+			OutputOwners: outputOwners,
+			Weights:      weights,
+		}
+	case len(reply.ActiveFrom) > 0:
+		if len(reply.ActiveFrom) != len(addrs) || len(reply.ActiveUntil) != len(addrs) {
+			return nil, errAliasReplyMismatch
+		}
+		activeFrom := make([]uint64, len(reply.ActiveFrom))
+		activeUntil := make([]uint64, len(reply.ActiveUntil))
+		for i := range addrs {
+			activeFrom[i] = uint64(reply.ActiveFrom[i])
+			activeUntil[i] = uint64(reply.ActiveUntil[i])
+		}
+		owners = &secp256k1fx.TimeLockedOutputOwners{
+			OutputOwners: outputOwners,
+			ActiveFrom:   activeFrom,
+			ActiveUntil:  activeUntil,
+		}
+	default:
+		owners = &outputOwners
+	}
+
+	alias := &multisig.Alias{
+		ID:     aliasID,
+		Memo:   reply.Memo,
+		Owners: owners,
+	}
+	if err := alias.Verify(); err != nil {
+		return nil, err
+	}
+	return alias, nil
+}
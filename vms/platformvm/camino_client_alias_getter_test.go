@@ -0,0 +1,154 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+
+	utilsjson "github.com/ava-labs/avalanchego/utils/json"
+)
+
+var _ CaminoClient = (*testAliasClient)(nil)
+
+// testAliasClient returns [replies] for multisig aliases and counts requests
+type testAliasClient struct {
+	replies  map[string]*GetMultisigAliasReply
+	err      error
+	requests int
+}
+
+func (*testAliasClient) GetConfiguration(context.Context, ...rpc.Option) (*GetConfigurationReply, error) {
+	return nil, nil
+}
+
+func (c *testAliasClient) GetMultisigAlias(_ context.Context, multisigAddress string, _ ...rpc.Option) (*GetMultisigAliasReply, error) {
+	c.requests++
+	if c.err != nil {
+		return nil, c.err
+	}
+	reply, ok := c.replies[multisigAddress]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", secp256k1fx.ErrAliasNotFound, multisigAddress)
+	}
+	return reply, nil
+}
+
+func (*testAliasClient) GetTxSigners(context.Context, []byte, ...rpc.Option) ([][]ids.ShortID, error) {
+	return nil, nil
+}
+
+func TestClientAliasGetter(t *testing.T) {
+	addr1 := ids.ShortID{1}
+	addr2 := ids.ShortID{2}
+	aliasID := ids.ShortID{3}
+	formatAddr := func(addr ids.ShortID) string {
+		addrStr, err := address.Format("P", "local", addr[:])
+		require.NoError(t, err)
+		return addrStr
+	}
+	apiOwner := APIOwner{
+		Addresses: []string{formatAddr(addr1), formatAddr(addr2)},
+		Threshold: 1,
+	}
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr1, addr2},
+	}
+	errTest := errors.New("test error")
+
+	tests := map[string]struct {
+		reply         *GetMultisigAliasReply
+		clientErr     error
+		expectedAlias *multisig.Alias
+		expectedErr   error
+	}{
+		"OK: plain owners": {
+			reply: &GetMultisigAliasReply{Memo: []byte{1}, APIOwner: apiOwner},
+			expectedAlias: &multisig.Alias{
+				ID:     aliasID,
+				Memo:   []byte{1},
+				Owners: &outputOwners,
+			},
+		},
+		"OK: weighted owners": {
+			reply: &GetMultisigAliasReply{
+				APIOwner: apiOwner,
+				Weights:  []utilsjson.Uint32{1, 2},
+			},
+			expectedAlias: &multisig.Alias{
+				ID: aliasID,
+				Owners: &secp256k1fx.WeightedOutputOwners{
+					OutputOwners: outputOwners,
+					Weights:      []uint32{1, 2},
+				},
+			},
+		},
+		"OK: time-locked owners": {
+			reply: &GetMultisigAliasReply{
+				APIOwner:    apiOwner,
+				ActiveFrom:  []utilsjson.Uint64{0, 10},
+				ActiveUntil: []utilsjson.Uint64{20, 0},
+			},
+			expectedAlias: &multisig.Alias{
+				ID: aliasID,
+				Owners: &secp256k1fx.TimeLockedOutputOwners{
+					OutputOwners: outputOwners,
+					ActiveFrom:   []uint64{0, 10},
+					ActiveUntil:  []uint64{20, 0},
+				},
+			},
+		},
+		"Alias not found": {
+			expectedErr: database.ErrNotFound,
+		},
+		"Fail: client error": {
+			clientErr:   errTest,
+			expectedErr: errTest,
+		},
+		"Fail: weights mismatch": {
+			reply: &GetMultisigAliasReply{
+				APIOwner: apiOwner,
+				Weights:  []utilsjson.Uint32{1},
+			},
+			expectedErr: errAliasReplyMismatch,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := &testAliasClient{
+				replies: map[string]*GetMultisigAliasReply{},
+				err:     tt.clientErr,
+			}
+			if tt.reply != nil {
+				client.replies[aliasID.String()] = tt.reply
+			}
+			getter := NewClientAliasGetter(context.Background(), client, 0)
+
+			alias, err := getter.GetMultisigAlias(aliasID)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Equal(t, tt.expectedAlias, alias)
+
+			// second request is served from cache, if first one succeeded or alias wasn't found
+			alias, err = getter.GetMultisigAlias(aliasID)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Equal(t, tt.expectedAlias, alias)
+			expectedRequests := 1
+			if tt.expectedErr != nil && tt.expectedErr != database.ErrNotFound {
+				expectedRequests = 2
+			}
+			require.Equal(t, expectedRequests, client.requests)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package platformvm
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,16 +13,21 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
 	"go.uber.org/zap"
@@ -38,6 +44,11 @@ var (
 	errEncodeTransferables    = errors.New("can't encode transferables as string")
 	errWrongOwnerType         = errors.New("wrong owner type")
 	errSerializeOwners        = errors.New("can't serialize owners")
+	errFeeAssetInAssetAmounts = errors.New("fee asset amount must be set with amountToLock")
+	errAliasWeightsMismatch   = errors.New("number of alias owners weights is different from number of addresses")
+	errAliasTimeLocksMismatch = errors.New("number of alias owners time locks is different from number of addresses")
+
+	errAliasWeightedAndTimeLocked = errors.New("alias owners can't be both weighted and time-locked")
 )
 
 // CaminoService defines the API calls that can be made to the platform chain
@@ -114,7 +125,12 @@ func (s *CaminoService) GetBalance(_ *http.Request, args *GetBalanceRequest, res
 utxoFor:
 	for _, utxo := range utxos {
 		assetID := utxo.AssetID()
-		switch out := utxo.Out.(type) {
+		utxoOut := utxo.Out
+		if lockedOut, ok := locked.AsOut(utxoOut); ok {
+			// labeled outs are counted as ordinary locked outs
+			utxoOut = lockedOut
+		}
+		switch out := utxoOut.(type) {
 		case *secp256k1fx.TransferOutput:
 			unlockedOutputs[assetID] = utilsjson.SafeAdd(unlockedOutputs[assetID], utilsjson.Uint64(out.Amount()))
 			balances[assetID] = utilsjson.SafeAdd(balances[assetID], utilsjson.Uint64(out.Amount()))
@@ -234,10 +250,11 @@ type SetAddressStateArgs struct {
 	api.UserPass
 	api.JSONFromAddrs
 
-	Change  platformapi.Owner `json:"change"`
-	Address string            `json:"address"`
-	State   uint8             `json:"state"`
-	Remove  bool              `json:"remove"`
+	Change  platformapi.Owner   `json:"change"`
+	Address string              `json:"address"`
+	State   as.AddressStateBit  `json:"state"`
+	Remove  bool                `json:"remove"`
+	Memo    types.JSONByteSlice `json:"memo"`
 }
 
 // AddAdressState issues an AddAdressStateTx
@@ -266,6 +283,7 @@ func (s *CaminoService) SetAddressState(_ *http.Request, args *SetAddressStateAr
 		args.State,  // The state to change
 		privKeys,    // Keys providing the staked tokens
 		change,
+		args.Memo,
 	)
 	if err != nil {
 		return fmt.Errorf(errCreateTx, err)
@@ -279,6 +297,51 @@ func (s *CaminoService) SetAddressState(_ *http.Request, args *SetAddressStateAr
 	return nil
 }
 
+type SetAddressStatesArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	Change  platformapi.Owner    `json:"change"`
+	Address string               `json:"address"`
+	Ops     []txs.AddressStateOp `json:"ops"`
+	Memo    types.JSONByteSlice  `json:"memo"`
+}
+
+// SetAddressStates issues an AddressStateBatchTx, that atomically applies multiple state changes
+func (s *CaminoService) SetAddressStates(_ *http.Request, args *SetAddressStatesArgs, response *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: SetAddressStates called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	targetAddr, err := avax.ParseServiceAddress(s.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse param Address: %w", err)
+	}
+
+	tx, err := s.vm.txBuilder.NewAddressStateBatchTx(
+		targetAddr,
+		args.Ops,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf(errCreateTx, err)
+	}
+
+	response.TxID = tx.ID()
+
+	return s.vm.Builder.AddUnverifiedTx(tx)
+}
+
 // GetAdressStates retrieves the state applied to an address (see setAddressState)
 func (s *CaminoService) GetAddressStates(_ *http.Request, args *api.JSONAddress, response *utilsjson.Uint64) error {
 	s.vm.ctx.Log.Debug("Platform: GetAddressStates called")
@@ -298,9 +361,80 @@ func (s *CaminoService) GetAddressStates(_ *http.Request, args *api.JSONAddress,
 	return nil
 }
 
+type GetAddressesWithStatesArgs struct {
+	// Only addresses, which states contain all bits of this mask will be returned.
+	// If zero, all addresses with non-zero states will be returned.
+	StatesMask utilsjson.Uint64 `json:"statesMask"`
+	// Address to start after. If empty, starts at beginning.
+	StartAddress string           `json:"startAddress"`
+	Limit        utilsjson.Uint32 `json:"limit"`
+}
+
+type APIAddressStates struct {
+	Address string           `json:"address"`
+	States  utilsjson.Uint64 `json:"states"`
+}
+
+type GetAddressesWithStatesReply struct {
+	Addresses  []APIAddressStates `json:"addresses"`
+	EndAddress string             `json:"endAddress"`
+}
+
+// GetAddressesWithStates returns page of addresses with states matching given mask, sorted by address
+func (s *CaminoService) GetAddressesWithStates(_ *http.Request, args *GetAddressesWithStatesArgs, response *GetAddressesWithStatesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetAddressesWithStates called")
+
+	startAddress := ids.ShortEmpty
+	if args.StartAddress != "" {
+		var err error
+		startAddress, err = avax.ParseServiceAddress(s.addrManager, args.StartAddress)
+		if err != nil {
+			return err
+		}
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	iterator, err := s.vm.state.GetAddressStatesIterator()
+	if err != nil {
+		return err
+	}
+	defer iterator.Release()
+
+	statesMask := as.AddressState(args.StatesMask)
+	response.Addresses = []APIAddressStates{}
+	response.EndAddress = args.StartAddress
+	for len(response.Addresses) < limit && iterator.Next() {
+		address := iterator.Address()
+		states := iterator.Value()
+		if args.StartAddress != "" && bytes.Compare(address[:], startAddress[:]) <= 0 ||
+			!states.Has(statesMask) {
+			continue
+		}
+		addressStr, err := s.addrManager.FormatLocalAddress(address)
+		if err != nil {
+			return err
+		}
+		response.Addresses = append(response.Addresses, APIAddressStates{
+			Address: addressStr,
+			States:  utilsjson.Uint64(states),
+		})
+		response.EndAddress = addressStr
+	}
+	return iterator.Error()
+}
+
 type GetMultisigAliasReply struct {
 	Memo types.JSONByteSlice `json:"memo"`
 	APIOwner
+	// Weights of owners addresses, only set for weighted alias owners
+	Weights []utilsjson.Uint32 `json:"weights,omitempty"`
+	// Activation and expiration times of owners addresses, only set for time-locked alias owners
+	ActiveFrom  []utilsjson.Uint64 `json:"activeFrom,omitempty"`
+	ActiveUntil []utilsjson.Uint64 `json:"activeUntil,omitempty"`
 }
 
 // GetMultisigAlias retrieves the owners and threshold for a given multisig alias
@@ -313,155 +447,256 @@ func (s *CaminoService) GetMultisigAlias(_ *http.Request, args *api.JSONAddress,
 	}
 
 	alias, err := s.vm.state.GetMultisigAlias(addr)
-	if err != nil {
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", secp256k1fx.ErrAliasNotFound, args.Address)
+	} else if err != nil {
 		return err
 	}
-	owners, ok := alias.Owners.(*secp256k1fx.OutputOwners)
-	if !ok {
+
+	return s.setMultisigAliasReply(response, alias.Memo, alias.Owners)
+}
+
+// setMultisigAliasReply sets [reply] fields to multisig alias [memo] and [owners]
+func (s *CaminoService) setMultisigAliasReply(reply *GetMultisigAliasReply, memo []byte, owners verify.State) error {
+	outputOwners, weights, err := secp256k1fx.OwnersWeights(owners)
+	if err != nil {
 		return errWrongOwnerType
 	}
 
-	response.Memo = alias.Memo
-	response.Threshold = utilsjson.Uint32(owners.Threshold)
-	response.Addresses = make([]string, len(owners.Addrs))
-
-	for index, addr := range owners.Addrs {
-		addrString, err := s.addrManager.FormatLocalAddress(addr)
-		if err != nil {
+	reply.Memo = memo
+	reply.Threshold = utilsjson.Uint32(outputOwners.Threshold)
+	reply.Addresses = make([]string, len(outputOwners.Addrs))
+	for index, addr := range outputOwners.Addrs {
+		if reply.Addresses[index], err = s.addrManager.FormatLocalAddress(addr); err != nil {
 			return err
 		}
-		response.Addresses[index] = addrString
 	}
-
+	if weights != nil {
+		reply.Weights = make([]utilsjson.Uint32, len(weights))
+		for index, weight := range weights {
+			reply.Weights[index] = utilsjson.Uint32(weight)
+		}
+	}
+	if timeLockedOwners, ok := owners.(*secp256k1fx.TimeLockedOutputOwners); ok {
+		reply.ActiveFrom = make([]utilsjson.Uint64, len(timeLockedOwners.ActiveFrom))
+		reply.ActiveUntil = make([]utilsjson.Uint64, len(timeLockedOwners.ActiveUntil))
+		for index := range timeLockedOwners.Addrs {
+			reply.ActiveFrom[index] = utilsjson.Uint64(timeLockedOwners.ActiveFrom[index])
+			reply.ActiveUntil[index] = utilsjson.Uint64(timeLockedOwners.ActiveUntil[index])
+		}
+	}
 	return nil
 }
 
-type SpendArgs struct {
-	api.JSONFromAddrs
+type GetMultisigAliasesArgs struct {
+	// Alias to start after. If empty, starts at beginning.
+	StartAlias string           `json:"startAlias"`
+	Limit      utilsjson.Uint32 `json:"limit"`
+}
 
-	To           platformapi.Owner   `json:"to"`
-	Change       platformapi.Owner   `json:"change"`
-	LockMode     byte                `json:"lockMode"`
-	AmountToLock utilsjson.Uint64    `json:"amountToLock"`
-	AmountToBurn utilsjson.Uint64    `json:"amountToBurn"`
-	AsOf         utilsjson.Uint64    `json:"asOf"`
-	Encoding     formatting.Encoding `json:"encoding"`
+type APIMultisigAlias struct {
+	Alias string `json:"alias"`
+	GetMultisigAliasReply
 }
 
-type SpendReply struct {
-	Ins     string          `json:"ins"`
-	Outs    string          `json:"outs"`
-	Signers [][]ids.ShortID `json:"signers"`
-	Owners  string          `json:"owners"`
+type GetMultisigAliasesReply struct {
+	Aliases  []APIMultisigAlias `json:"aliases"`
+	EndAlias string             `json:"endAlias"`
 }
 
-func (s *CaminoService) Spend(_ *http.Request, args *SpendArgs, response *SpendReply) error {
-	s.vm.ctx.Log.Debug("Platform: Spend called")
+// GetMultisigAliases returns page of multisig aliases sorted by alias id
+func (s *CaminoService) GetMultisigAliases(_ *http.Request, args *GetMultisigAliasesArgs, response *GetMultisigAliasesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMultisigAliases called")
 
-	privKeys, err := s.getFakeKeys(&args.JSONFromAddrs)
-	if err != nil {
-		return err
+	startAlias := ids.ShortEmpty
+	if args.StartAlias != "" {
+		var err error
+		startAlias, err = avax.ParseServiceAddress(s.addrManager, args.StartAlias)
+		if err != nil {
+			return err
+		}
 	}
-	if len(privKeys) == 0 {
-		return errNoKeys
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
 	}
 
-	to, err := s.getOutputOwner(&args.To)
+	aliases, err := s.vm.state.GetMultisigAliases(startAlias, limit)
 	if err != nil {
 		return err
 	}
 
-	change, err := s.getOutputOwner(&args.Change)
-	if err != nil {
-		return err
+	response.Aliases = make([]APIMultisigAlias, len(aliases))
+	for i, alias := range aliases {
+		apiAlias := &response.Aliases[i]
+		if apiAlias.Alias, err = s.addrManager.FormatLocalAddress(alias.ID); err != nil {
+			return err
+		}
+		if err := s.setMultisigAliasReply(&apiAlias.GetMultisigAliasReply, alias.Memo, alias.Owners); err != nil {
+			return err
+		}
 	}
 
-	ins, outs, signers, owners, err := s.vm.txBuilder.Lock(
-		privKeys,
-		uint64(args.AmountToLock),
-		uint64(args.AmountToBurn),
-		locked.State(args.LockMode),
-		to,
-		change,
-		uint64(args.AsOf),
-	)
+	response.EndAlias = args.StartAlias
+	if len(aliases) > 0 {
+		response.EndAlias = response.Aliases[len(aliases)-1].Alias
+	}
+	return nil
+}
+
+type GetMultisigAliasesByMemberReply struct {
+	Aliases []string `json:"aliases"`
+}
+
+// GetMultisigAliasesByMember returns multisig aliases, which owners contain given address
+func (s *CaminoService) GetMultisigAliasesByMember(_ *http.Request, args *api.JSONAddress, response *GetMultisigAliasesByMemberReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMultisigAliasesByMember called")
+
+	member, err := avax.ParseServiceAddress(s.addrManager, args.Address)
 	if err != nil {
-		return fmt.Errorf("%w: %s", errCreateTransferables, err)
+		return err
 	}
 
-	bytes, err := txs.Codec.Marshal(txs.Version, ins)
+	aliasIDs, err := s.vm.state.GetMultisigAliasIDsByMember(member)
 	if err != nil {
-		return fmt.Errorf("%w: %s", errSerializeTransferables, err)
+		return err
 	}
 
-	if response.Ins, err = formatting.Encode(args.Encoding, bytes); err != nil {
-		return fmt.Errorf("%w: %s", errEncodeTransferables, err)
+	response.Aliases = make([]string, len(aliasIDs))
+	for i, aliasID := range aliasIDs {
+		if response.Aliases[i], err = s.addrManager.FormatLocalAddress(aliasID); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	bytes, err = txs.Codec.Marshal(txs.Version, outs)
+type MultisigAliasVersion struct {
+	// Height of block, that replaced this alias version
+	Height utilsjson.Uint64 `json:"height"`
+	GetMultisigAliasReply
+}
+
+type GetMultisigAliasHistoryReply struct {
+	Versions []MultisigAliasVersion `json:"versions"`
+}
+
+// GetMultisigAliasHistory returns previous versions of multisig alias in order they were replaced
+func (s *CaminoService) GetMultisigAliasHistory(_ *http.Request, args *api.JSONAddress, response *GetMultisigAliasHistoryReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMultisigAliasHistory called")
+
+	aliasID, err := avax.ParseServiceAddress(s.addrManager, args.Address)
 	if err != nil {
-		return fmt.Errorf("%w: %s", errSerializeTransferables, err)
+		return err
 	}
 
-	if response.Outs, err = formatting.Encode(args.Encoding, bytes); err != nil {
-		return fmt.Errorf("%w: %s", errEncodeTransferables, err)
+	versions, err := s.vm.state.GetMultisigAliasHistory(aliasID)
+	if err != nil {
+		return err
 	}
 
-	response.Signers = make([][]ids.ShortID, len(signers))
-	for i, cred := range signers {
-		response.Signers[i] = make([]ids.ShortID, len(cred))
-		for j, sig := range cred {
-			response.Signers[i][j] = sig.Address()
+	response.Versions = make([]MultisigAliasVersion, len(versions))
+	for i, version := range versions {
+		apiVersion := &response.Versions[i]
+		apiVersion.Height = utilsjson.Uint64(version.Height)
+		if err := s.setMultisigAliasReply(&apiVersion.GetMultisigAliasReply, version.Alias.Memo, version.Alias.Owners); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	bytes, err = txs.Codec.Marshal(txs.Version, owners)
+type MultisigAliasUsage struct {
+	// Height of block, that contains tx
+	Height utilsjson.Uint64 `json:"height"`
+	TxID   ids.ID           `json:"txID"`
+}
+
+type GetMultisigAliasUsagesReply struct {
+	Usages []MultisigAliasUsage `json:"usages"`
+}
+
+// GetMultisigAliasUsages returns last txs, in which multisig alias was used to prove owners, in order of their heights.
+// Number of retained usages is limited by node config, no usages are retained, if it's zero.
+func (s *CaminoService) GetMultisigAliasUsages(_ *http.Request, args *api.JSONAddress, response *GetMultisigAliasUsagesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMultisigAliasUsages called")
+
+	aliasID, err := avax.ParseServiceAddress(s.addrManager, args.Address)
 	if err != nil {
-		return fmt.Errorf("%w: %s", errSerializeOwners, err)
+		return err
 	}
-	if response.Owners, err = formatting.Encode(args.Encoding, bytes); err != nil {
-		return fmt.Errorf("%w: %s", errSerializeOwners, err)
+
+	usages, err := s.vm.state.GetMultisigAliasUsages(aliasID)
+	if err != nil {
+		return err
+	}
+
+	response.Usages = make([]MultisigAliasUsage, len(usages))
+	for i, usage := range usages {
+		response.Usages[i] = MultisigAliasUsage{
+			Height: utilsjson.Uint64(usage.Height),
+			TxID:   usage.TxID,
+		}
 	}
 	return nil
 }
 
-type RegisterNodeArgs struct {
+type SetMultisigAliasArgs struct {
 	api.UserPass
 	api.JSONFromAddrs
 
-	Change                  platformapi.Owner `json:"change"`
-	OldNodeID               ids.NodeID        `json:"oldNodeID"`
-	NewNodeID               ids.NodeID        `json:"newNodeID"`
-	ConsortiumMemberAddress string            `json:"consortiumMemberAddress"`
+	// Alias, which will be changed. If empty, new alias will be created
+	Alias  string            `json:"alias"`
+	Owners platformapi.Owner `json:"owners"`
+	// Optional weights of owners addresses in the same order as addresses.
+	// If set, owners threshold is the minimal total weight of signers
+	Weights []utilsjson.Uint32 `json:"weights"`
+	// Optional activation and expiration times of owners addresses in the same order as addresses.
+	// Zero expiration time means never. Can't be used together with weights
+	ActiveFrom  []utilsjson.Uint64  `json:"activeFrom"`
+	ActiveUntil []utilsjson.Uint64  `json:"activeUntil"`
+	AliasMemo   types.JSONByteSlice `json:"aliasMemo"`
+	Change      platformapi.Owner   `json:"change"`
+	Memo        types.JSONByteSlice `json:"memo"`
 }
 
-// RegisterNode issues an RegisterNodeTx
-func (s *CaminoService) RegisterNode(_ *http.Request, args *RegisterNodeArgs, reply *api.JSONTxID) error {
-	s.vm.ctx.Log.Debug("Platform: RegisterNode called")
+// SetMultisigAlias issues an MultisigAliasTx
+func (s *CaminoService) SetMultisigAlias(_ *http.Request, args *SetMultisigAliasArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: SetMultisigAlias called")
+
+	aliasID := ids.ShortEmpty
+	if args.Alias != "" {
+		var err error
+		aliasID, err = avax.ParseServiceAddress(s.addrManager, args.Alias)
+		if err != nil {
+			return fmt.Errorf("couldn't parse alias: %w", err)
+		}
+	}
 
 	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
 	if err != nil {
 		return err
 	}
 
-	change, err := s.getOutputOwner(&args.Change)
+	owners, err := s.getAliasOwners(&args.Owners, args.Weights, args.ActiveFrom, args.ActiveUntil)
 	if err != nil {
-		return err
+		return fmt.Errorf("couldn't parse owners: %w", err)
 	}
 
-	// Parse the consortium member address.
-	consortiumMemberAddress, err := avax.ParseServiceAddress(s.addrManager, args.ConsortiumMemberAddress)
+	change, err := s.getOutputOwner(&args.Change)
 	if err != nil {
-		return fmt.Errorf("couldn't parse consortiumMemberAddress: %w", err)
+		return err
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewRegisterNodeTx(
-		args.OldNodeID,
-		args.NewNodeID,
-		consortiumMemberAddress,
+	tx, err := s.vm.txBuilder.NewMultisigAliasTx(
+		aliasID,
+		owners,
+		args.AliasMemo,
 		privKeys,
 		change,
+		args.Memo,
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -469,63 +704,66 @@ func (s *CaminoService) RegisterNode(_ *http.Request, args *RegisterNodeArgs, re
 
 	reply.TxID = tx.ID()
 
-	if err = s.vm.Builder.AddUnverifiedTx(tx); err != nil {
-		return err
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
 	}
+
 	return nil
 }
 
-type ClaimArgs struct {
+type RotateMultisigAliasArgs struct {
 	api.UserPass
 	api.JSONFromAddrs
 
-	DepositTxIDs    []ids.ID            `json:"depositTxIDs"`
-	ClaimableOwners []platformapi.Owner `json:"claimableOwners"`
-	AmountToClaim   []uint64            `json:"amountToClaim"`
-	ClaimTo         platformapi.Owner   `json:"claimTo"`
-	Change          platformapi.Owner   `json:"change"`
+	// Alias, which will be retired
+	Alias string `json:"alias"`
+	// Owners of successor alias
+	Owners platformapi.Owner `json:"owners"`
+	// Optional weights of successor owners addresses in the same order as addresses.
+	// If set, owners threshold is the minimal total weight of signers
+	Weights []utilsjson.Uint32 `json:"weights"`
+	// Optional activation and expiration times of successor owners addresses in the same order as addresses.
+	// Zero expiration time means never. Can't be used together with weights
+	ActiveFrom  []utilsjson.Uint64 `json:"activeFrom"`
+	ActiveUntil []utilsjson.Uint64 `json:"activeUntil"`
+	// Memo of successor alias
+	AliasMemo types.JSONByteSlice `json:"aliasMemo"`
+	Change    platformapi.Owner   `json:"change"`
+	Memo      types.JSONByteSlice `json:"memo"`
 }
 
-// Claim issues an ClaimTx
-func (s *CaminoService) Claim(_ *http.Request, args *ClaimArgs, reply *api.JSONTxID) error {
-	s.vm.ctx.Log.Debug("Platform: Claim called")
+// RotateMultisigAlias issues an RotateMultisigAliasTx
+func (s *CaminoService) RotateMultisigAlias(_ *http.Request, args *RotateMultisigAliasArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: RotateMultisigAlias called")
 
-	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	retiredAliasID, err := avax.ParseServiceAddress(s.addrManager, args.Alias)
 	if err != nil {
-		return err
+		return fmt.Errorf("couldn't parse alias: %w", err)
 	}
 
-	change, err := s.getOutputOwner(&args.Change)
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
 	if err != nil {
 		return err
 	}
 
-	claimTo, err := s.getOutputOwner(&args.ClaimTo)
+	owners, err := s.getAliasOwners(&args.Owners, args.Weights, args.ActiveFrom, args.ActiveUntil)
 	if err != nil {
-		return err
+		return fmt.Errorf("couldn't parse owners: %w", err)
 	}
 
-	claimableOwnerIDs := make([]ids.ID, len(args.ClaimableOwners))
-	for i := range args.ClaimableOwners {
-		claimableOwner, err := s.getOutputOwner(&args.ClaimableOwners[i])
-		if err != nil {
-			return fmt.Errorf("failed to parse api owner to secp owner: %w", err)
-		}
-		ownerID, err := txs.GetOwnerID(claimableOwner)
-		if err != nil {
-			return fmt.Errorf("failed to calculate ownerID from owner: %w", err)
-		}
-		claimableOwnerIDs[i] = ownerID
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
 	}
 
 	// Create the transaction
-	tx, err := s.vm.txBuilder.NewClaimTx(
-		args.DepositTxIDs,
-		claimableOwnerIDs,
-		args.AmountToClaim,
-		claimTo,
+	tx, err := s.vm.txBuilder.NewRotateMultisigAliasTx(
+		retiredAliasID,
+		owners,
+		args.AliasMemo,
 		privKeys,
 		change,
+		args.Memo,
 	)
 	if err != nil {
 		return fmt.Errorf("couldn't create tx: %w", err)
@@ -540,68 +778,679 @@ func (s *CaminoService) Claim(_ *http.Request, args *ClaimArgs, reply *api.JSONT
 	return nil
 }
 
-func (s *CaminoService) GetRegisteredShortIDLink(_ *http.Request, args *api.JSONAddress, response *api.JSONAddress) error {
-	s.vm.ctx.Log.Debug("Platform: GetRegisteredShortIDLink called")
+type GetMultisigAliasSuccessorReply struct {
+	// Successor of retired alias
+	Successor string `json:"successor"`
+}
 
-	var id ids.ShortID
-	isNodeID := false
-	if nodeID, err := ids.NodeIDFromString(args.Address); err == nil {
-		id = ids.ShortID(nodeID)
-		isNodeID = true
-	} else {
-		id, err = avax.ParseServiceAddress(s.addrManager, args.Address)
-		if err != nil {
-			return err
-		}
-	}
+// GetMultisigAliasSuccessor returns successor of retired multisig alias
+func (s *CaminoService) GetMultisigAliasSuccessor(_ *http.Request, args *api.JSONAddress, response *GetMultisigAliasSuccessorReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetMultisigAliasSuccessor called")
 
-	link, err := s.vm.state.GetShortIDLink(id, state.ShortLinkKeyRegisterNode)
+	aliasID, err := avax.ParseServiceAddress(s.addrManager, args.Address)
 	if err != nil {
 		return err
 	}
 
-	if isNodeID {
-		response.Address, err = s.addrManager.FormatLocalAddress(link)
-		if err != nil {
-			return err
-		}
-	} else {
-		response.Address = ids.NodeID(link).String()
+	successorID, err := s.vm.state.GetShortIDLink(aliasID, state.ShortLinkKeyMultisigAliasSuccessor)
+	if err != nil {
+		return fmt.Errorf("couldn't get alias successor: %w", err)
 	}
-	return nil
-}
 
-type GetClaimablesArgs struct {
-	platformapi.Owner
+	response.Successor, err = s.addrManager.FormatLocalAddress(successorID)
+	return err
 }
 
-type GetClaimablesReply struct {
-	ValidatorRewards      uint64 `json:"validatorRewards"`
-	ExpiredDepositRewards uint64 `json:"expiredDepositRewards"`
+type GetTxSignersReply struct {
+	// Signers addresses of every tx credential in the same order as credential signatures
+	Signers [][]string `json:"signers"`
 }
 
-// GetClaimables returns the amount of claimable tokens for given owner
-func (s *CaminoService) GetClaimables(_ *http.Request, args *GetClaimablesArgs, response *GetClaimablesReply) error {
-	s.vm.ctx.Log.Debug("Platform: GetClaimables called")
+// GetTxSigners returns addresses, that signed credentials of given signed tx
+func (s *CaminoService) GetTxSigners(_ *http.Request, args *api.FormattedTx, response *GetTxSignersReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetTxSigners called")
 
-	claimableOwner, err := s.getOutputOwner(&args.Owner)
+	txBytes, err := formatting.Decode(args.Encoding, args.Tx)
 	if err != nil {
-		return err
+		return fmt.Errorf("problem decoding transaction: %w", err)
 	}
-
-	ownerID, err := txs.GetOwnerID(claimableOwner)
+	tx, err := txs.Parse(txs.Codec, txBytes)
 	if err != nil {
-		return err
+		return fmt.Errorf("couldn't parse tx: %w", err)
 	}
 
-	claimable, err := s.vm.state.GetClaimable(ownerID)
-	if err == database.ErrNotFound {
-		claimable = &state.Claimable{}
-	} else if err != nil {
-		return err
+	signers, err := fx.RecoverSigners(s.vm.fx, tx.Unsigned, tx.Creds)
+	if err != nil {
+		return fmt.Errorf("couldn't recover tx signers: %w", err)
 	}
 
-	response.ValidatorRewards = claimable.ValidatorReward
+	response.Signers = make([][]string, len(signers))
+	for i, credSigners := range signers {
+		response.Signers[i] = make([]string, len(credSigners))
+		for j, signer := range credSigners {
+			if response.Signers[i][j], err = s.addrManager.FormatLocalAddress(signer); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type SpendArgs struct {
+	api.JSONFromAddrs
+
+	To           platformapi.Owner   `json:"to"`
+	Change       platformapi.Owner   `json:"change"`
+	LockMode     byte                `json:"lockMode"`
+	AmountToLock utilsjson.Uint64    `json:"amountToLock"`
+	AmountToBurn utilsjson.Uint64    `json:"amountToBurn"`
+	AsOf         utilsjson.Uint64    `json:"asOf"`
+	Encoding     formatting.Encoding `json:"encoding"`
+	// MaxInputs is the max number of consumed inputs, zero means unlimited
+	MaxInputs int `json:"maxInputs"`
+	// AssetAmountsToLock are amounts of non-fee assets, that will be
+	// transferred to [To] along with [AmountToLock] of fee asset
+	AssetAmountsToLock map[ids.ID]utilsjson.Uint64 `json:"assetAmountsToLock"`
+}
+
+type SpendReply struct {
+	Ins     string          `json:"ins"`
+	Outs    string          `json:"outs"`
+	Signers [][]ids.ShortID `json:"signers"`
+	Owners  string          `json:"owners"`
+}
+
+func (s *CaminoService) Spend(_ *http.Request, args *SpendArgs, response *SpendReply) error {
+	s.vm.ctx.Log.Debug("Platform: Spend called")
+
+	privKeys, err := s.getFakeKeys(&args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+	if len(privKeys) == 0 {
+		return errNoKeys
+	}
+
+	to, err := s.getOutputOwner(&args.To)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	amountsToLock := map[ids.ID]uint64{s.vm.ctx.AVAXAssetID: uint64(args.AmountToLock)}
+	for assetID, amount := range args.AssetAmountsToLock {
+		if assetID == s.vm.ctx.AVAXAssetID {
+			return errFeeAssetInAssetAmounts
+		}
+		amountsToLock[assetID] = uint64(amount)
+	}
+
+	ins, outs, signers, owners, err := s.vm.txBuilder.LockAssets(
+		privKeys,
+		amountsToLock,
+		uint64(args.AmountToBurn),
+		locked.State(args.LockMode),
+		to,
+		change,
+		args.MaxInputs,
+		uint64(args.AsOf),
+		0,
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errCreateTransferables, err)
+	}
+
+	bytes, err := txs.Codec.Marshal(txs.Version, ins)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errSerializeTransferables, err)
+	}
+
+	if response.Ins, err = formatting.Encode(args.Encoding, bytes); err != nil {
+		return fmt.Errorf("%w: %s", errEncodeTransferables, err)
+	}
+
+	bytes, err = txs.Codec.Marshal(txs.Version, outs)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errSerializeTransferables, err)
+	}
+
+	if response.Outs, err = formatting.Encode(args.Encoding, bytes); err != nil {
+		return fmt.Errorf("%w: %s", errEncodeTransferables, err)
+	}
+
+	response.Signers = make([][]ids.ShortID, len(signers))
+	for i, cred := range signers {
+		response.Signers[i] = make([]ids.ShortID, len(cred))
+		for j, sig := range cred {
+			response.Signers[i][j] = sig.Address()
+		}
+	}
+
+	bytes, err = txs.Codec.Marshal(txs.Version, owners)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errSerializeOwners, err)
+	}
+	if response.Owners, err = formatting.Encode(args.Encoding, bytes); err != nil {
+		return fmt.Errorf("%w: %s", errSerializeOwners, err)
+	}
+	return nil
+}
+
+type RegisterNodeArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	Change                  platformapi.Owner   `json:"change"`
+	OldNodeID               ids.NodeID          `json:"oldNodeID"`
+	NewNodeID               ids.NodeID          `json:"newNodeID"`
+	ConsortiumMemberAddress string              `json:"consortiumMemberAddress"`
+	Memo                    types.JSONByteSlice `json:"memo"`
+}
+
+// RegisterNode issues an RegisterNodeTx
+func (s *CaminoService) RegisterNode(_ *http.Request, args *RegisterNodeArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: RegisterNode called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	// Parse the consortium member address.
+	consortiumMemberAddress, err := avax.ParseServiceAddress(s.addrManager, args.ConsortiumMemberAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't parse consortiumMemberAddress: %w", err)
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewRegisterNodeTx(
+		args.OldNodeID,
+		args.NewNodeID,
+		consortiumMemberAddress,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err = s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ClaimRequest describes which rewards of a single owner will be claimed
+type ClaimRequest struct {
+	// Rewards owner
+	Owner platformapi.Owner `json:"owner"`
+	// Bitmask of rewards kinds to claim:
+	// 1 - active deposits rewards, 2 - expired deposits rewards, 4 - validator rewards
+	ClaimType builder.ClaimType `json:"claimType"`
+	// Active deposits which rewards will be claimed
+	DepositTxIDs []ids.ID `json:"depositTxIDs"`
+	// Max amount of expired deposits and validator rewards to claim, zero means all
+	Amount utilsjson.Uint64 `json:"amount"`
+}
+
+type ClaimArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	Claims  []ClaimRequest      `json:"claims"`
+	ClaimTo platformapi.Owner   `json:"claimTo"`
+	Change  platformapi.Owner   `json:"change"`
+	Memo    types.JSONByteSlice `json:"memo"`
+
+	// Offer of new deposit, that all claimed rewards will be deposited with by the same tx.
+	// Empty means that claimed rewards won't be deposited.
+	DepositOfferID  ids.ID           `json:"depositOfferID"`
+	DepositDuration utilsjson.Uint32 `json:"depositDuration"`
+	RewardsAddress  string           `json:"rewardsAddress"`
+
+	// Deprecated: use [Claims] instead.
+	// Active deposits which rewards will be claimed
+	DepositTxIDs []ids.ID `json:"depositTxIDs"`
+	// Deprecated: use [Claims] instead.
+	// Owners which expired deposits and validator rewards will be claimed
+	ClaimableOwners []platformapi.Owner `json:"claimableOwners"`
+	// Deprecated: use [Claims] instead.
+	// Amounts to claim, one per each of [ClaimableOwners]
+	AmountToClaim []uint64 `json:"amountToClaim"`
+}
+
+// Claim issues an ClaimTx or ClaimDepositTx, if [args.DepositOfferID] isn't empty
+func (s *CaminoService) Claim(_ *http.Request, args *ClaimArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: Claim called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	claimTo, err := s.getOutputOwner(&args.ClaimTo)
+	if err != nil {
+		return err
+	}
+
+	claimRequests := make([]builder.ClaimRequest, len(args.Claims))
+	for i := range args.Claims {
+		owner, err := s.getOutputOwner(&args.Claims[i].Owner)
+		if err != nil {
+			return fmt.Errorf("failed to parse api owner to secp owner: %w", err)
+		}
+		ownerID, err := txs.GetOwnerID(owner)
+		if err != nil {
+			return fmt.Errorf("failed to calculate ownerID from owner: %w", err)
+		}
+		claimRequests[i] = builder.ClaimRequest{
+			OwnerID:      ownerID,
+			Type:         args.Claims[i].ClaimType,
+			DepositTxIDs: args.Claims[i].DepositTxIDs,
+			Amount:       uint64(args.Claims[i].Amount),
+		}
+	}
+
+	if len(args.DepositTxIDs) > 0 || len(args.ClaimableOwners) > 0 {
+		claimableOwnerIDs := make([]ids.ID, len(args.ClaimableOwners))
+		for i := range args.ClaimableOwners {
+			claimableOwner, err := s.getOutputOwner(&args.ClaimableOwners[i])
+			if err != nil {
+				return fmt.Errorf("failed to parse api owner to secp owner: %w", err)
+			}
+			ownerID, err := txs.GetOwnerID(claimableOwner)
+			if err != nil {
+				return fmt.Errorf("failed to calculate ownerID from owner: %w", err)
+			}
+			claimableOwnerIDs[i] = ownerID
+		}
+
+		legacyClaimRequests, err := builder.LegacyClaimRequests(s.vm.state, args.DepositTxIDs, claimableOwnerIDs, args.AmountToClaim)
+		if err != nil {
+			return fmt.Errorf("couldn't translate claim args: %w", err)
+		}
+		claimRequests = append(claimRequests, legacyClaimRequests...)
+	}
+
+	var claimDeposit *builder.ClaimDeposit
+	if args.DepositOfferID != ids.Empty {
+		rewardsAddress, err := avax.ParseServiceAddress(s.addrManager, args.RewardsAddress)
+		if err != nil {
+			return fmt.Errorf("couldn't parse rewardsAddress: %w", err)
+		}
+		claimDeposit = &builder.ClaimDeposit{
+			DepositOfferID: args.DepositOfferID,
+			Duration:       uint32(args.DepositDuration),
+			RewardsAddress: rewardsAddress,
+		}
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewClaimTx(
+		claimRequests,
+		claimDeposit,
+		claimTo,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	return nil
+}
+
+type RenewDepositArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	// ID of expired deposit tx, which deposit will be renewed
+	DepositTxID ids.ID `json:"depositTxID"`
+	// Unlocked amount, that will be deposited in addition to renewed deposit amount
+	AdditionalAmount utilsjson.Uint64 `json:"additionalAmount"`
+	// Offer of new deposit, empty means the best available offer
+	DepositOfferID  ids.ID              `json:"depositOfferID"`
+	DepositDuration utilsjson.Uint32    `json:"duration"`
+	RewardsAddress  string              `json:"rewardsAddress"`
+	Change          platformapi.Owner   `json:"change"`
+	Memo            types.JSONByteSlice `json:"memo"`
+}
+
+// RenewDeposit issues an RenewDepositTx
+func (s *CaminoService) RenewDeposit(_ *http.Request, args *RenewDepositArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: RenewDeposit called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	rewardsAddress, err := avax.ParseServiceAddress(s.addrManager, args.RewardsAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't parse rewardsAddress: %w", err)
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewRenewDepositTx(
+		args.DepositTxID,
+		uint64(args.AdditionalAmount),
+		uint32(args.DepositDuration),
+		args.DepositOfferID,
+		rewardsAddress,
+		privKeys,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	return nil
+}
+
+type SetDepositRewardsOwnerArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	// ID of deposit tx, which deposit rewards owner will be changed
+	DepositTxID  ids.ID              `json:"depositTxID"`
+	RewardsOwner platformapi.Owner   `json:"rewardsOwner"`
+	Change       platformapi.Owner   `json:"change"`
+	Memo         types.JSONByteSlice `json:"memo"`
+}
+
+// SetDepositRewardsOwner issues an DepositRewardsOwnerTx
+func (s *CaminoService) SetDepositRewardsOwner(_ *http.Request, args *SetDepositRewardsOwnerArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: SetDepositRewardsOwner called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	rewardsOwner, err := s.getOutputOwner(&args.RewardsOwner)
+	if err != nil {
+		return fmt.Errorf("couldn't parse rewardsOwner: %w", err)
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewDepositRewardsOwnerTx(
+		args.DepositTxID,
+		rewardsOwner,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	return nil
+}
+
+type SplitDepositArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	// ID of deposit tx, which deposit will be split
+	DepositTxID ids.ID `json:"depositTxID"`
+	// Amount of deposit principal, that will be moved into new deposit
+	SplitAmount  utilsjson.Uint64    `json:"splitAmount"`
+	RewardsOwner platformapi.Owner   `json:"rewardsOwner"`
+	Change       platformapi.Owner   `json:"change"`
+	Memo         types.JSONByteSlice `json:"memo"`
+}
+
+// SplitDeposit issues an SplitDepositTx
+func (s *CaminoService) SplitDeposit(_ *http.Request, args *SplitDepositArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: SplitDeposit called")
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	rewardsOwner, err := s.getOutputOwner(&args.RewardsOwner)
+	if err != nil {
+		return fmt.Errorf("couldn't parse rewardsOwner: %w", err)
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewSplitDepositTx(
+		args.DepositTxID,
+		uint64(args.SplitAmount),
+		rewardsOwner,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	return nil
+}
+
+type SetDepositOfferArgs struct {
+	api.UserPass
+	api.JSONFromAddrs
+
+	// Deposit offer, that will be created, if its id is empty, or will replace existing offer with its id.
+	// Version of updated offer must match its current version
+	DepositOffer deposit.Offer `json:"depositOffer"`
+	// Address of admin, that creates or updates offer
+	AdminAddress string              `json:"adminAddress"`
+	Change       platformapi.Owner   `json:"change"`
+	Memo         types.JSONByteSlice `json:"memo"`
+}
+
+// SetDepositOffer issues an DepositOfferTx
+func (s *CaminoService) SetDepositOffer(_ *http.Request, args *SetDepositOfferArgs, reply *api.JSONTxID) error {
+	s.vm.ctx.Log.Debug("Platform: SetDepositOffer called")
+
+	adminAddress, err := avax.ParseServiceAddress(s.addrManager, args.AdminAddress)
+	if err != nil {
+		return fmt.Errorf("couldn't parse adminAddress: %w", err)
+	}
+
+	privKeys, err := s.getKeystoreKeys(&args.UserPass, &args.JSONFromAddrs)
+	if err != nil {
+		return err
+	}
+
+	change, err := s.getOutputOwner(&args.Change)
+	if err != nil {
+		return err
+	}
+
+	// Create the transaction
+	tx, err := s.vm.txBuilder.NewDepositOfferTx(
+		&args.DepositOffer,
+		adminAddress,
+		privKeys,
+		change,
+		args.Memo,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+
+	if err := s.vm.Builder.AddUnverifiedTx(tx); err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CaminoService) GetRegisteredShortIDLink(_ *http.Request, args *api.JSONAddress, response *api.JSONAddress) error {
+	s.vm.ctx.Log.Debug("Platform: GetRegisteredShortIDLink called")
+
+	var id ids.ShortID
+	isNodeID := false
+	if nodeID, err := ids.NodeIDFromString(args.Address); err == nil {
+		id = ids.ShortID(nodeID)
+		isNodeID = true
+	} else {
+		id, err = avax.ParseServiceAddress(s.addrManager, args.Address)
+		if err != nil {
+			return err
+		}
+	}
+
+	link, err := s.vm.state.GetShortIDLink(id, state.ShortLinkKeyRegisterNode)
+	if err != nil {
+		return err
+	}
+
+	if isNodeID {
+		response.Address, err = s.addrManager.FormatLocalAddress(link)
+		if err != nil {
+			return err
+		}
+	} else {
+		response.Address = ids.NodeID(link).String()
+	}
+	return nil
+}
+
+type APIRegisteredNode struct {
+	NodeID                  string `json:"nodeID"`
+	ConsortiumMemberAddress string `json:"consortiumMemberAddress"`
+}
+
+type GetRegisteredNodesReply struct {
+	Nodes []APIRegisteredNode `json:"nodes"`
+}
+
+// GetRegisteredNodes returns all registered nodes with their consortium member addresses, sorted by node id
+func (s *CaminoService) GetRegisteredNodes(_ *http.Request, _ *struct{}, response *GetRegisteredNodesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetRegisteredNodes called")
+
+	links, err := s.vm.state.GetShortIDLinks(state.ShortLinkKeyRegisterNode)
+	if err != nil {
+		return err
+	}
+
+	// register node links are stored in both directions, so we only take links from consortium members
+	nodeIDs := make([]ids.NodeID, 0, len(links)/2)
+	for id := range links {
+		states, err := s.vm.state.GetAddressStates(id)
+		if err != nil {
+			return err
+		}
+		if states.Has(as.AddressStateConsortium) {
+			nodeIDs = append(nodeIDs, ids.NodeID(links[id]))
+		}
+	}
+	utils.Sort(nodeIDs)
+
+	response.Nodes = make([]APIRegisteredNode, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		response.Nodes[i].NodeID = nodeID.String()
+		if response.Nodes[i].ConsortiumMemberAddress, err = s.addrManager.FormatLocalAddress(links[ids.ShortID(nodeID)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type GetClaimablesArgs struct {
+	platformapi.Owner
+	// RewardAssetID is asset of claimable deposit rewards, empty id means primary network asset.
+	RewardAssetID ids.ID `json:"rewardAssetID"`
+}
+
+type GetClaimablesReply struct {
+	// ClaimableID is id, that must be used to claim these claimables with ClaimTx
+	ClaimableID           ids.ID `json:"claimableID"`
+	ValidatorRewards      uint64 `json:"validatorRewards"`
+	ExpiredDepositRewards uint64 `json:"expiredDepositRewards"`
+}
+
+// GetClaimables returns the amount of claimable tokens for given owner and reward asset
+func (s *CaminoService) GetClaimables(_ *http.Request, args *GetClaimablesArgs, response *GetClaimablesReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetClaimables called")
+
+	claimableOwner, err := s.getOutputOwner(&args.Owner)
+	if err != nil {
+		return err
+	}
+
+	ownerID, err := txs.GetOwnerID(claimableOwner)
+	if err != nil {
+		return err
+	}
+
+	claimableID := state.ClaimableID(ownerID, args.RewardAssetID, s.vm.ctx.AVAXAssetID)
+	claimable, err := s.vm.state.GetClaimable(claimableID)
+	if err == database.ErrNotFound {
+		claimable = &state.Claimable{}
+	} else if err != nil {
+		return err
+	}
+
+	response.ClaimableID = claimableID
+	response.ValidatorRewards = claimable.ValidatorReward
 	response.ExpiredDepositRewards = claimable.DepositReward
 
 	return nil
@@ -615,6 +1464,8 @@ type APIDeposit struct {
 	Start               uint64 `json:"start"`
 	Duration            uint32 `json:"duration"`
 	Amount              uint64 `json:"amount"`
+	// Claims are deposit reward claims in order of their timestamps
+	Claims []*deposit.Claim `json:"claims,omitempty"`
 }
 
 func APIDepositFromDeposit(depositTxID ids.ID, deposit *deposit.Deposit) *APIDeposit {
@@ -654,8 +1505,63 @@ func (s *CaminoService) GetDeposits(_ *http.Request, args *GetDepositsArgs, repl
 		if err != nil {
 			return err
 		}
+		claims, err := s.vm.state.GetDepositClaims(args.DepositTxIDs[i])
+		if err != nil {
+			return fmt.Errorf("could't get deposit claims from state: %w", err)
+		}
 		reply.AvailableRewards[i] = deposit.ClaimableReward(offer, reply.Timestamp)
 		reply.Deposits[i] = APIDepositFromDeposit(args.DepositTxIDs[i], deposit)
+		reply.Deposits[i].Claims = claims
+	}
+	return nil
+}
+
+type GetDepositsByRewardOwnerArgs struct {
+	platformapi.Owner
+}
+
+// GetDepositsByRewardOwner returns deposits, which rewards are owned by given owner
+func (s *CaminoService) GetDepositsByRewardOwner(_ *http.Request, args *GetDepositsByRewardOwnerArgs, reply *GetDepositsReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetDepositsByRewardOwner called")
+
+	rewardOwner, err := s.getOutputOwner(&args.Owner)
+	if err != nil {
+		return err
+	}
+
+	ownerID, err := txs.GetOwnerID(rewardOwner)
+	if err != nil {
+		return err
+	}
+
+	depositTxIDs, err := s.vm.state.GetDepositIDsByRewardOwner(ownerID)
+	if err != nil {
+		return fmt.Errorf("could't get deposit ids from state: %w", err)
+	}
+
+	return s.GetDeposits(nil, &GetDepositsArgs{DepositTxIDs: depositTxIDs}, reply)
+}
+
+type GetArchivedDepositsReply struct {
+	Deposits []*APIDeposit `json:"deposits"`
+}
+
+// GetArchivedDeposits returns removed deposits by IDs as they were at removal time.
+// Deposits are only archived, if node has archive of removed deposits or state pruning enabled.
+func (s *CaminoService) GetArchivedDeposits(_ *http.Request, args *GetDepositsArgs, reply *GetArchivedDepositsReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetArchivedDeposits called")
+	reply.Deposits = make([]*APIDeposit, len(args.DepositTxIDs))
+	for i := range args.DepositTxIDs {
+		deposit, err := s.vm.state.GetArchivedDeposit(args.DepositTxIDs[i])
+		if err != nil {
+			return fmt.Errorf("couldn't get archived deposit %s from state: %w", args.DepositTxIDs[i], err)
+		}
+		claims, err := s.vm.state.GetDepositClaims(args.DepositTxIDs[i])
+		if err != nil {
+			return fmt.Errorf("couldn't get deposit %s claims from state: %w", args.DepositTxIDs[i], err)
+		}
+		reply.Deposits[i] = APIDepositFromDeposit(args.DepositTxIDs[i], deposit)
+		reply.Deposits[i].Claims = claims
 	}
 	return nil
 }
@@ -791,6 +1697,69 @@ func (s *Service) getOutputOwner(args *platformapi.Owner) (*secp256k1fx.OutputOw
 	return nil, nil
 }
 
+// getAliasOwners returns multisig alias owners, which are weighted if [weights] aren't empty
+// or time-locked if [activeFrom] or [activeUntil] aren't empty
+func (s *Service) getAliasOwners(
+	args *platformapi.Owner,
+	weights []utilsjson.Uint32,
+	activeFrom []utilsjson.Uint64,
+	activeUntil []utilsjson.Uint64,
+) (verify.State, error) {
+	isTimeLocked := len(activeFrom) != 0 || len(activeUntil) != 0
+	switch {
+	case len(weights) == 0 && !isTimeLocked:
+		owners, err := s.getOutputOwner(args)
+		if owners == nil || err != nil {
+			// avoid returning non-nil interface with nil owners
+			return nil, err
+		}
+		return owners, nil
+	case len(weights) != 0 && isTimeLocked:
+		return nil, errAliasWeightedAndTimeLocked
+	case len(weights) != 0 && len(weights) != len(args.Addresses):
+		return nil, errAliasWeightsMismatch
+	case isTimeLocked && (len(activeFrom) != len(args.Addresses) || len(activeUntil) != len(args.Addresses)):
+		return nil, errAliasTimeLocksMismatch
+	}
+
+	outputOwners := secp256k1fx.OutputOwners{
+		Locktime:  uint64(args.Locktime),
+		Threshold: uint32(args.Threshold),
+		Addrs:     make([]ids.ShortID, len(args.Addresses)),
+	}
+	for i, addr := range args.Addresses {
+		addrBytes, err := avax.ParseServiceAddress(s.addrManager, addr)
+		if err != nil {
+			return nil, err
+		}
+		outputOwners.Addrs[i] = addrBytes
+	}
+
+	if isTimeLocked {
+		ret := &secp256k1fx.TimeLockedOutputOwners{
+			OutputOwners: outputOwners,
+			ActiveFrom:   make([]uint64, len(activeFrom)),
+			ActiveUntil:  make([]uint64, len(activeUntil)),
+		}
+		for i := range activeFrom {
+			ret.ActiveFrom[i] = uint64(activeFrom[i])
+			ret.ActiveUntil[i] = uint64(activeUntil[i])
+		}
+		ret.Sort()
+		return ret, nil
+	}
+
+	ret := &secp256k1fx.WeightedOutputOwners{
+		OutputOwners: outputOwners,
+		Weights:      make([]uint32, len(weights)),
+	}
+	for i, weight := range weights {
+		ret.Weights[i] = uint32(weight)
+	}
+	ret.Sort()
+	return ret, nil
+}
+
 type GetAllDepositOffersArgs struct {
 	Active bool `json:"active"`
 }
@@ -811,7 +1780,7 @@ func (s *CaminoService) GetAllDepositOffers(_ *http.Request, args *GetAllDeposit
 	if args.Active {
 		var activeOffers []*deposit.Offer
 		for _, offer := range depositOffers {
-			if offer.Flags&deposit.OfferFlagLocked == 0 {
+			if offer.Flags&deposit.OfferFlagLocked == 0 && !offer.IsPaused() {
 				activeOffers = append(activeOffers, offer)
 			}
 		}
@@ -821,3 +1790,73 @@ func (s *CaminoService) GetAllDepositOffers(_ *http.Request, args *GetAllDeposit
 	response.DepositOffers = depositOffers
 	return nil
 }
+
+type GetBestDepositOfferArgs struct {
+	Amount   utilsjson.Uint64 `json:"amount"`
+	Duration utilsjson.Uint32 `json:"duration"`
+}
+
+type GetBestDepositOfferReply struct {
+	DepositOfferID ids.ID         `json:"depositOfferID"`
+	DepositOffer   *deposit.Offer `json:"depositOffer"`
+}
+
+// GetBestDepositOffer returns active unrestricted deposit offer that admits deposit
+// of requested amount for requested duration and gives the biggest reward for it.
+func (s *CaminoService) GetBestDepositOffer(_ *http.Request, args *GetBestDepositOfferArgs, response *GetBestDepositOfferReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetBestDepositOffer called")
+
+	offer, err := s.vm.txBuilder.BestDepositOffer(uint64(args.Amount), uint32(args.Duration))
+	if err != nil {
+		return err
+	}
+
+	response.DepositOfferID = offer.ID
+	response.DepositOffer = offer
+	return nil
+}
+
+type GetDepositStatsReply struct {
+	// Active (not unlocked) deposited amounts by deposit offer ids
+	ActiveAmounts      map[ids.ID]utilsjson.Uint64 `json:"activeAmounts"`
+	TotalActiveAmount  utilsjson.Uint64            `json:"totalActiveAmount"`
+	OutstandingRewards utilsjson.Uint64            `json:"outstandingRewards"`
+}
+
+// GetDepositStats returns aggregated active deposited amounts and not yet claimed
+// primary network asset deposit rewards of last accepted state.
+func (s *CaminoService) GetDepositStats(_ *http.Request, _ *struct{}, response *GetDepositStatsReply) error {
+	s.vm.ctx.Log.Debug("Platform: GetDepositStats called")
+
+	stats, err := s.vm.state.GetDepositStats()
+	if err != nil {
+		return err
+	}
+
+	response.ActiveAmounts = make(map[ids.ID]utilsjson.Uint64, len(stats.ActiveAmounts))
+	for offerID, amount := range stats.ActiveAmounts {
+		response.ActiveAmounts[offerID] = utilsjson.Uint64(amount)
+	}
+	response.TotalActiveAmount = utilsjson.Uint64(stats.TotalActiveAmount)
+	response.OutstandingRewards = utilsjson.Uint64(stats.OutstandingRewards)
+	return nil
+}
+
+type VerifyCaminoStateInvariantsReply struct {
+	Consistent bool   `json:"consistent"`
+	Error      string `json:"error,omitempty"`
+}
+
+// VerifyCaminoStateInvariants checks, that deposits, bonds and claimables in state are consistent.
+// It iterates over all utxos, so access to it should be restricted with api tenants.
+func (s *CaminoService) VerifyCaminoStateInvariants(_ *http.Request, _ *struct{}, response *VerifyCaminoStateInvariantsReply) error {
+	s.vm.ctx.Log.Debug("Platform: VerifyCaminoStateInvariants called")
+
+	if err := s.vm.state.VerifyCaminoStateInvariants(); err != nil {
+		response.Error = err.Error()
+		return nil
+	}
+
+	response.Consistent = true
+	return nil
+}
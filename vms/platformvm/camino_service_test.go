@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -22,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/stretchr/testify/require"
 )
@@ -436,3 +438,33 @@ func TestSpend(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "0x00000000000100000000000000000000000100000001fceda8f90fcb5d30614b99d79fc4baa2930776262dcf0a4e", spendReply.Owners)
 }
+
+func TestGetTxSigners(t *testing.T) {
+	require := require.New(t)
+	service := defaultCaminoService(t, api.Camino{}, []api.UTXO{})
+	signers := [][]*crypto.PrivateKeySECP256K1R{{keys[0], keys[1]}, {keys[2]}}
+
+	tx, err := txs.NewSigned(&txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    testNetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+	}}, txs.Codec, signers)
+	require.NoError(err)
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	expectedSigners := make([][]string, len(signers))
+	for i, credKeys := range signers {
+		for _, key := range credKeys {
+			addr, err := service.addrManager.FormatLocalAddress(key.Address())
+			require.NoError(err)
+			expectedSigners[i] = append(expectedSigners[i], addr)
+		}
+	}
+
+	reply := GetTxSignersReply{}
+	require.NoError(service.GetTxSigners(nil, &json_api.FormattedTx{
+		Tx:       txStr,
+		Encoding: formatting.Hex,
+	}, &reply))
+	require.Equal(expectedSigners, reply.Signers)
+}
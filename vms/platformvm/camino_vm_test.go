@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/nodeid"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
@@ -81,9 +82,10 @@ func TestRemoveDeferredValidator(t *testing.T) {
 	tx, err := vm.txBuilder.NewAddressStateTx(
 		consortiumMemberKey.Address(),
 		false,
-		txs.AddressStateConsortium,
+		as.AddressStateBitConsortium,
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -104,6 +106,7 @@ func TestRemoveDeferredValidator(t *testing.T) {
 		consortiumMemberKey.Address(),
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0], nodeKey, consortiumMemberKey},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -152,9 +155,10 @@ func TestRemoveDeferredValidator(t *testing.T) {
 	tx, err = vm.txBuilder.NewAddressStateTx(
 		consortiumMemberKey.Address(),
 		false,
-		txs.AddressStateNodeDeferred,
+		as.AddressStateBitNodeDeferred,
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -176,7 +180,7 @@ func TestRemoveDeferredValidator(t *testing.T) {
 
 	// Verify that the validator's owner's deferred state and consortium member is true
 	ownerState, _ := vm.state.GetAddressStates(consortiumMemberKey.Address())
-	require.Equal(ownerState, txs.AddressStateNodeDeferredBit|txs.AddressStateConsortiumBit)
+	require.Equal(ownerState, as.AddressStateNodeDeferred|as.AddressStateConsortium)
 
 	// Fast-forward clock to time for validator to be rewarded
 	vm.clock.Set(endTime)
@@ -227,7 +231,7 @@ func TestRemoveDeferredValidator(t *testing.T) {
 
 	// Verify that the validator's owner's deferred state is false
 	ownerState, _ = vm.state.GetAddressStates(consortiumMemberKey.Address())
-	require.Equal(ownerState, txs.AddressStateConsortiumBit)
+	require.Equal(ownerState, as.AddressStateConsortium)
 
 	timestamp := vm.state.GetTimestamp()
 	require.Equal(endTime.Unix(), timestamp.Unix())
@@ -280,9 +284,10 @@ func TestRemoveReactivatedValidator(t *testing.T) {
 	tx, err := vm.txBuilder.NewAddressStateTx(
 		consortiumMemberKey.Address(),
 		false,
-		txs.AddressStateConsortium,
+		as.AddressStateBitConsortium,
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -303,6 +308,7 @@ func TestRemoveReactivatedValidator(t *testing.T) {
 		consortiumMemberKey.Address(),
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0], nodeKey, consortiumMemberKey},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -352,9 +358,10 @@ func TestRemoveReactivatedValidator(t *testing.T) {
 	tx, err = vm.txBuilder.NewAddressStateTx(
 		consortiumMemberKey.Address(),
 		false,
-		txs.AddressStateNodeDeferred,
+		as.AddressStateBitNodeDeferred,
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -378,9 +385,10 @@ func TestRemoveReactivatedValidator(t *testing.T) {
 	tx, err = vm.txBuilder.NewAddressStateTx(
 		consortiumMemberKey.Address(),
 		true,
-		txs.AddressStateNodeDeferred,
+		as.AddressStateBitNodeDeferred,
 		[]*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]},
 		outputOwners,
+		nil,
 	)
 	require.NoError(err)
 	err = vm.Builder.AddUnverifiedTx(tx)
@@ -487,7 +495,9 @@ func TestDepositsAutoUnlock(t *testing.T) {
 		depositOffer.ID,
 		depositOwnerAddr,
 		[]*crypto.PrivateKeySECP256K1R{depositOwnerKey},
+		nil,
 		&depositOwner,
+		nil,
 	)
 	require.NoError(err)
 	buildAndAcceptBlock(t, vm, depositTx)
@@ -3,6 +3,44 @@
 
 package config
 
+import "github.com/ava-labs/avalanchego/vms/platformvm/tenant"
+
 type CaminoConfig struct {
 	DaoProposalBondAmount uint64
+	// Platform API tenants, if empty, API is served without tenant restrictions
+	APITenants []tenant.Config
+	// Max size in bytes of a single system unlock deposit tx,
+	// if zero, default size is used
+	MaxSystemUnlockDepositTxSize int
+	// If true, removed deposits and locked ended deposit offers
+	// are moved from active state into archive
+	StatePruning bool
+	// If true, removed deposits are retained in archive for historical queries,
+	// it's implied by StatePruning
+	ArchiveRemovedDeposits bool
+	// Number of accepted blocks, for which history of deposits, claimables
+	// and address states is retained, if zero, history isn't recorded
+	HistoryRetention uint64
+	// Size of deposits cache, if zero, default size is used
+	DepositsCacheSize int
+	// Size of claimables cache, if zero, default size is used
+	ClaimablesCacheSize int
+	// If true, camino state invariants are verified on node start
+	VerifyStateInvariants bool
+	// If true, utxos and deposits referenced by standard block txs are concurrently
+	// read into state caches before block txs are verified
+	PrefetchBlockState bool
+	// Unlocked change outputs of fee asset with lesser amount aren't produced
+	// by utxo handler Lock, but burned together with fee,
+	// if zero, all change is returned
+	MinChangeAmount uint64
+	// Max nesting depth of multisig aliases accepted by credential verification,
+	// must be the same for all network nodes, if zero, secp256k1fx.DefaultMaxAliasDepth is used
+	MaxMultisigAliasDepth int
+	// Max size of multisig alias memo accepted by alias txs, must not exceed multisig.MaxMemoSize,
+	// must be the same for all network nodes, if zero, multisig.MaxMemoSize is used
+	MaxMultisigAliasMemoSize int
+	// Number of last txs, in which multisig alias was used to prove owners, that are retained
+	// per alias for usage queries, if zero, multisig aliases usage isn't recorded
+	MultisigAliasUsageRetention int
 }
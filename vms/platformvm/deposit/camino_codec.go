@@ -0,0 +1,26 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package deposit
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+)
+
+const codecVersion = 0
+
+// offerCodec is used to compute offer ids. Offers don't have interface fields,
+// so it produces the same bytes as platformvm genesis codec without registering any types.
+var offerCodec codec.Manager
+
+func init() {
+	lc := linearcodec.NewCaminoCustomMaxLength(math.MaxInt32)
+	offerCodec = codec.NewManager(math.MaxInt32)
+
+	if err := offerCodec.RegisterCodec(codecVersion, lc); err != nil {
+		panic(err)
+	}
+}
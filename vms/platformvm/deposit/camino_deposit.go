@@ -6,6 +6,7 @@ package deposit
 import (
 	"errors"
 	"fmt"
+	stdmath "math"
 	"math/big"
 	"time"
 
@@ -13,7 +14,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
-	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
 )
 
@@ -22,12 +24,69 @@ const (
 	interestRateDenominator = 1_000_000 * interestRateBase
 
 	OfferFlagLocked uint64 = 0b1
+	// Rewards of deposits with this offer are compounded every CompoundingPeriod,
+	// so unclaimed rewards accrue interest too
+	OfferFlagCompounding uint64 = 0b10
+
+	// Deposits with this offer can be fully unlocked before their unlock period ends,
+	// paying early unlock penalty
+	OfferFlagEarlyUnlock uint64 = 0b100
+
+	// New deposits can't be created with paused offer, but existing deposits aren't affected.
+	// Unlike locked offer, paused offer can be resumed by clearing this flag.
+	OfferFlagPaused uint64 = 0b1000
+
+	// Deposited tokens of deposits with this offer can't be bonded,
+	// so they are fully liquid, when they are unlocked
+	OfferFlagNoBonding uint64 = 0b10000
+
+	// CompoundingPeriod is duration in seconds after which accrued rewards
+	// of compounding deposits start to accrue interest
+	CompoundingPeriod = 24 * 60 * 60
+
+	// EarlyUnlockPenaltyDenominator is denominator of offer early unlock penalty nominators
+	EarlyUnlockPenaltyDenominator = 1_000_000
+
+	// MaxNameSize is maximum size of offer name in bytes
+	MaxNameSize = 64
+	// MaxURISize is maximum size of offer uri in bytes
+	MaxURISize = 256
 )
 
-var bigInterestRateDenominator = (&big.Int{}).SetInt64(interestRateDenominator)
+var (
+	bigInterestRateDenominator       = (&big.Int{}).SetInt64(interestRateDenominator)
+	bigEarlyUnlockPenaltyDenominator = (&big.Int{}).SetInt64(EarlyUnlockPenaltyDenominator)
+)
 
+// Offer fields without serialize tag aren't serialized as part of offer.
+// They are persisted separately with OfferExtension and, except Version,
+// hashed into offer id by SetID, if they aren't empty.
 type Offer struct {
 	ID ids.ID `json:"id"`
+	// Version is incremented each time offer is updated in state.
+	Version uint64 `json:"version"`
+	// Tiers override interest rate for deposits with bigger amounts, sorted by min amount.
+	Tiers []InterestRateTier `json:"tiers,omitempty"`
+	// MaxAmount is maximum amount of single deposit, zero means no limit.
+	MaxAmount uint64 `json:"maxAmount,omitempty"`
+	// EarlyUnlockPenaltyNominator is share of early unlocked principal, that must be transferred to treasury.
+	EarlyUnlockPenaltyNominator uint64 `json:"earlyUnlockPenaltyNominator,omitempty"`
+	// EarlyUnlockRewardPenaltyNominator is share of accrued not claimed reward, that is slashed on early unlock.
+	EarlyUnlockRewardPenaltyNominator uint64 `json:"earlyUnlockRewardPenaltyNominator,omitempty"`
+	// RewardSchedule limits claimable reward of deposits with this offer by reward vested share.
+	// Zero schedule means that reward is claimable as soon as it accrues.
+	RewardSchedule VestingSchedule `json:"rewardSchedule"`
+	// RequiredAddressState is address state, that one of deposit tx signers must have
+	// to create deposit with this offer. Empty address state means no requirement.
+	RequiredAddressState as.AddressState `json:"requiredAddressState,omitempty"`
+	// Name is human-readable offer name and URI points to offer description, both are for display only.
+	Name string `json:"name,omitempty"`
+	URI  string `json:"uri,omitempty"`
+	// RewardAssetID is asset, in which deposit rewards are paid. Empty id means primary network asset.
+	RewardAssetID ids.ID `json:"rewardAssetID"`
+	// OwnerAddress is address, that must additionally sign deposits with this offer.
+	// Empty address means that offer isn't restricted.
+	OwnerAddress ids.ShortID `json:"ownerAddress"`
 
 	InterestRateNominator   uint64              `serialize:"true" json:"interestRateNominator"`
 	Start                   uint64              `serialize:"true" json:"start"`
@@ -43,16 +102,216 @@ type Offer struct {
 	Flags                   uint64              `serialize:"true" json:"flags"`
 }
 
-// Sets offer id from its bytes hash
+// InterestRateTier is interest rate of deposits with amount not less than MinAmount
+type InterestRateTier struct {
+	MinAmount             uint64 `serialize:"true" json:"minAmount"`
+	InterestRateNominator uint64 `serialize:"true" json:"interestRateNominator"`
+}
+
+// VestingSchedule describes vesting of tokens relative to deposit start:
+// nothing is vested during CliffDuration, then tokens vest linearly during VestingDuration.
+type VestingSchedule struct {
+	CliffDuration   uint32 `serialize:"true" json:"cliffDuration"`
+	VestingDuration uint32 `serialize:"true" json:"vestingDuration"`
+}
+
+// IsZero returns true if schedule vests all tokens at deposit start
+func (s VestingSchedule) IsZero() bool {
+	return s.CliffDuration == 0 && s.VestingDuration == 0
+}
+
+// Duration returns duration in seconds after which all tokens are vested
+func (s VestingSchedule) Duration() uint64 {
+	return uint64(s.CliffDuration) + uint64(s.VestingDuration)
+}
+
+// vested returns vested part of [amount] after [passedDuration] seconds since deposit start
+func (s VestingSchedule) vested(amount, passedDuration uint64) uint64 {
+	if passedDuration >= s.Duration() {
+		return amount
+	}
+	if passedDuration <= uint64(s.CliffDuration) {
+		return 0
+	}
+
+	bigVestedAmount := (&big.Int{}).SetUint64(amount)
+	bigPassedVestingDuration := (&big.Int{}).SetUint64(passedDuration - uint64(s.CliffDuration))
+	bigVestingDuration := (&big.Int{}).SetUint64(uint64(s.VestingDuration))
+
+	// vestedAmount := amount * passedVestingDuration / vestingDuration
+	bigVestedAmount.Mul(bigVestedAmount, bigPassedVestingDuration)
+	bigVestedAmount.Div(bigVestedAmount, bigVestingDuration)
+	return bigVestedAmount.Uint64()
+}
+
+// OfferExtension holds offer fields, that aren't serialized as part of offer,
+// so they could be persisted or transferred alongside offer bytes.
+type OfferExtension struct {
+	Tiers     []InterestRateTier `serialize:"true" json:"tiers,omitempty"`
+	MaxAmount uint64             `serialize:"true" json:"maxAmount,omitempty"`
+
+	EarlyUnlockPenaltyNominator       uint64 `serialize:"true" json:"earlyUnlockPenaltyNominator,omitempty"`
+	EarlyUnlockRewardPenaltyNominator uint64 `serialize:"true" json:"earlyUnlockRewardPenaltyNominator,omitempty"`
+
+	RewardSchedule VestingSchedule `serialize:"true" json:"rewardSchedule"`
+
+	RequiredAddressState as.AddressState `serialize:"true" json:"requiredAddressState,omitempty"`
+
+	Name string `serialize:"true" json:"name,omitempty"`
+	URI  string `serialize:"true" json:"uri,omitempty"`
+
+	RewardAssetID ids.ID `serialize:"true" json:"rewardAssetID"`
+
+	OwnerAddress ids.ShortID `serialize:"true" json:"ownerAddress"`
+}
+
+// Extension returns offer fields, that aren't serialized as part of offer, except version.
+func (o *Offer) Extension() OfferExtension {
+	return OfferExtension{
+		Tiers:     o.Tiers,
+		MaxAmount: o.MaxAmount,
+
+		EarlyUnlockPenaltyNominator:       o.EarlyUnlockPenaltyNominator,
+		EarlyUnlockRewardPenaltyNominator: o.EarlyUnlockRewardPenaltyNominator,
+
+		RewardSchedule: o.RewardSchedule,
+
+		RequiredAddressState: o.RequiredAddressState,
+
+		Name: o.Name,
+		URI:  o.URI,
+
+		RewardAssetID: o.RewardAssetID,
+
+		OwnerAddress: o.OwnerAddress,
+	}
+}
+
+func (e *OfferExtension) IsEmpty() bool {
+	return len(e.Tiers) == 0 && e.MaxAmount == 0 &&
+		e.EarlyUnlockPenaltyNominator == 0 && e.EarlyUnlockRewardPenaltyNominator == 0 &&
+		e.RewardSchedule.IsZero() && e.RequiredAddressState == as.AddressStateEmpty &&
+		e.Name == "" && e.URI == "" && e.RewardAssetID == ids.Empty &&
+		e.OwnerAddress == ids.ShortEmpty
+}
+
+// ApplyTo sets [offer] fields from extension
+func (e *OfferExtension) ApplyTo(offer *Offer) {
+	if len(e.Tiers) > 0 {
+		offer.Tiers = e.Tiers
+	}
+	offer.MaxAmount = e.MaxAmount
+	offer.EarlyUnlockPenaltyNominator = e.EarlyUnlockPenaltyNominator
+	offer.EarlyUnlockRewardPenaltyNominator = e.EarlyUnlockRewardPenaltyNominator
+	offer.RewardSchedule = e.RewardSchedule
+	offer.RequiredAddressState = e.RequiredAddressState
+	offer.Name = e.Name
+	offer.URI = e.URI
+	offer.RewardAssetID = e.RewardAssetID
+	offer.OwnerAddress = e.OwnerAddress
+}
+
+// Offer id parts, that are appended to offer bytes by SetID.
+// Each part is appended only if it isn't empty, so ids of offers without them don't change.
+
+type offerDisplayFields struct {
+	Name string `serialize:"true"`
+	URI  string `serialize:"true"`
+}
+
+type offerRewardAsset struct {
+	RewardAssetID ids.ID `serialize:"true"`
+}
+
+type offerOwner struct {
+	OwnerAddress ids.ShortID `serialize:"true"`
+}
+
+type offerTerms struct {
+	Tiers     []InterestRateTier `serialize:"true"`
+	MaxAmount uint64             `serialize:"true"`
+
+	EarlyUnlockPenaltyNominator       uint64 `serialize:"true"`
+	EarlyUnlockRewardPenaltyNominator uint64 `serialize:"true"`
+
+	RewardSchedule VestingSchedule `serialize:"true"`
+
+	RequiredAddressState as.AddressState `serialize:"true"`
+}
+
+func (t *offerTerms) isEmpty() bool {
+	return len(t.Tiers) == 0 && t.MaxAmount == 0 &&
+		t.EarlyUnlockPenaltyNominator == 0 && t.EarlyUnlockRewardPenaltyNominator == 0 &&
+		t.RewardSchedule.IsZero() && t.RequiredAddressState == as.AddressStateEmpty
+}
+
+// Sets offer id from its bytes hash.
+// Offer name and uri are hashed too, if any of them isn't empty.
+// Offer reward asset and owner address are hashed too, if they aren't empty.
+// Offer deposit terms are hashed too, if any of them isn't empty.
 func (o *Offer) SetID() error {
-	bytes, err := blocks.GenesisCodec.Marshal(blocks.Version, o)
+	bytes, err := offerCodec.Marshal(codecVersion, o)
 	if err != nil {
 		return err
 	}
+	if o.Name != "" || o.URI != "" {
+		if bytes, err = appendOfferPart(bytes, &offerDisplayFields{
+			Name: o.Name,
+			URI:  o.URI,
+		}); err != nil {
+			return err
+		}
+	}
+	if o.RewardAssetID != ids.Empty {
+		if bytes, err = appendOfferPart(bytes, &offerRewardAsset{
+			RewardAssetID: o.RewardAssetID,
+		}); err != nil {
+			return err
+		}
+	}
+	if o.OwnerAddress != ids.ShortEmpty {
+		if bytes, err = appendOfferPart(bytes, &offerOwner{
+			OwnerAddress: o.OwnerAddress,
+		}); err != nil {
+			return err
+		}
+	}
+	if terms := (&offerTerms{
+		Tiers:     o.Tiers,
+		MaxAmount: o.MaxAmount,
+
+		EarlyUnlockPenaltyNominator:       o.EarlyUnlockPenaltyNominator,
+		EarlyUnlockRewardPenaltyNominator: o.EarlyUnlockRewardPenaltyNominator,
+
+		RewardSchedule: o.RewardSchedule,
+
+		RequiredAddressState: o.RequiredAddressState,
+	}); !terms.isEmpty() {
+		if bytes, err = appendOfferPart(bytes, terms); err != nil {
+			return err
+		}
+	}
 	o.ID = hashing.ComputeHash256Array(bytes)
 	return nil
 }
 
+// appendOfferPart appends bytes of offer [part], that must be hashed into offer id, to offer [bytes]
+func appendOfferPart(bytes []byte, part interface{}) ([]byte, error) {
+	partBytes, err := offerCodec.Marshal(codecVersion, part)
+	if err != nil {
+		return nil, err
+	}
+	return append(bytes, partBytes...), nil
+}
+
+// RewardAsset returns asset, in which deposit rewards of this offer are paid.
+func (o *Offer) RewardAsset(primaryAssetID ids.ID) ids.ID {
+	if o.RewardAssetID == ids.Empty {
+		return primaryAssetID
+	}
+	return o.RewardAssetID
+}
+
 // Time when this offer becomes active
 func (o *Offer) StartTime() time.Time {
 	return time.Unix(int64(o.Start), 0)
@@ -67,6 +326,59 @@ func (o *Offer) RemainingAmount() uint64 {
 	return o.TotalMaxAmount - o.DepositedAmount
 }
 
+// AdmitsAmount returns true if single deposit of [amount] is within offer min and max amount
+// and doesn't exceed offer remaining amount
+func (o *Offer) AdmitsAmount(amount uint64) bool {
+	return o.MinAmount <= amount &&
+		(o.MaxAmount == 0 || amount <= o.MaxAmount) &&
+		(o.TotalMaxAmount == 0 || amount <= o.RemainingAmount())
+}
+
+// IsRestricted returns true if deposits with this offer must be
+// additionally signed by offer owner address
+func (o *Offer) IsRestricted() bool {
+	return o.OwnerAddress != ids.ShortEmpty
+}
+
+// IsCompounding returns true if deposits with this offer have compounding rewards
+func (o *Offer) IsCompounding() bool {
+	return o.Flags&OfferFlagCompounding != 0
+}
+
+// IsPaused returns true if new deposits can't be created with this offer for now
+func (o *Offer) IsPaused() bool {
+	return o.Flags&OfferFlagPaused != 0
+}
+
+// AllowsEarlyUnlock returns true if deposits with this offer can be unlocked before their unlock period ends
+func (o *Offer) AllowsEarlyUnlock() bool {
+	return o.Flags&OfferFlagEarlyUnlock != 0
+}
+
+// AllowsBonding returns true if deposited tokens of deposits with this offer can be bonded
+func (o *Offer) AllowsBonding() bool {
+	return o.Flags&OfferFlagNoBonding == 0
+}
+
+// EarlyUnlockPenalty returns amount of tokens, that must be transferred to treasury
+// when [amount] is unlocked before it becomes unlockable.
+func (o *Offer) EarlyUnlockPenalty(amount uint64) uint64 {
+	return applyPenalty(amount, o.EarlyUnlockPenaltyNominator)
+}
+
+// InterestRateNominatorFor returns interest rate nominator of deposit with [amount]:
+// rate of the last tier with min amount not greater than [amount] or offer base rate.
+func (o *Offer) InterestRateNominatorFor(amount uint64) uint64 {
+	interestRateNominator := o.InterestRateNominator
+	for _, tier := range o.Tiers {
+		if tier.MinAmount > amount {
+			break
+		}
+		interestRateNominator = tier.InterestRateNominator
+	}
+	return interestRateNominator
+}
+
 func (o *Offer) InterestRateFloat64() float64 {
 	return float64(o.InterestRateNominator) / float64(interestRateDenominator)
 }
@@ -104,10 +416,79 @@ func (o *Offer) Verify() error {
 		)
 	}
 
+	if o.MaxAmount > 0 && o.MinAmount > o.MaxAmount {
+		return fmt.Errorf(
+			"deposit offer minimum amount (%v) is greater than maximum amount (%v)",
+			o.MinAmount,
+			o.MaxAmount,
+		)
+	}
+
+	if o.RewardSchedule.Duration() > uint64(o.MinDuration) {
+		return fmt.Errorf(
+			"deposit offer reward schedule duration (%v) is greater than minimum duration (%v)",
+			o.RewardSchedule.Duration(),
+			o.MinDuration,
+		)
+	}
+
+	if o.EarlyUnlockPenaltyNominator > EarlyUnlockPenaltyDenominator ||
+		o.EarlyUnlockRewardPenaltyNominator > EarlyUnlockPenaltyDenominator {
+		return fmt.Errorf(
+			"deposit offer early unlock penalty nominators (%v, %v) are greater than denominator (%v)",
+			o.EarlyUnlockPenaltyNominator,
+			o.EarlyUnlockRewardPenaltyNominator,
+			EarlyUnlockPenaltyDenominator,
+		)
+	}
+
+	if !o.AllowsEarlyUnlock() && (o.EarlyUnlockPenaltyNominator > 0 || o.EarlyUnlockRewardPenaltyNominator > 0) {
+		return errors.New("deposit offer has early unlock penalty, but doesn't allow early unlock")
+	}
+
+	if o.TotalMaxAmount > 0 && o.MinAmount > o.TotalMaxAmount {
+		return fmt.Errorf(
+			"deposit offer minimum amount (%v) is greater than total maximum amount (%v)",
+			o.MinAmount,
+			o.TotalMaxAmount,
+		)
+	}
+
+	if o.TotalMaxAmount > 0 && o.DepositedAmount > o.TotalMaxAmount {
+		return fmt.Errorf(
+			"deposit offer deposited amount (%v) is greater than total maximum amount (%v)",
+			o.DepositedAmount,
+			o.TotalMaxAmount,
+		)
+	}
+
+	for i, tier := range o.Tiers {
+		previousMinAmount := o.MinAmount
+		if i > 0 {
+			previousMinAmount = o.Tiers[i-1].MinAmount
+		}
+		if tier.MinAmount <= previousMinAmount {
+			return fmt.Errorf(
+				"deposit offer tier %d min amount (%v) is not greater than previous min amount (%v)",
+				i,
+				tier.MinAmount,
+				previousMinAmount,
+			)
+		}
+	}
+
 	if len(o.Memo) > avax.MaxMemoSize {
 		return fmt.Errorf("deposit offer memo is larger (%d bytes) than max of %d bytes", len(o.Memo), avax.MaxMemoSize)
 	}
 
+	if len(o.Name) > MaxNameSize {
+		return fmt.Errorf("deposit offer name is larger (%d bytes) than max of %d bytes", len(o.Name), MaxNameSize)
+	}
+
+	if len(o.URI) > MaxURISize {
+		return fmt.Errorf("deposit offer uri is larger (%d bytes) than max of %d bytes", len(o.URI), MaxURISize)
+	}
+
 	return nil
 }
 
@@ -118,6 +499,18 @@ type Deposit struct {
 	Start               uint64 `serialize:"true"`
 	Duration            uint32 `serialize:"true"`
 	Amount              uint64 `serialize:"true"`
+
+	// Rewards owner, that was set after deposit creation.
+	// Nil means that rewards are owned by rewards owner of tx, that created this deposit.
+	// Isn't serialized as part of deposit.
+	RewardOwner *secp256k1fx.OutputOwners
+}
+
+// Claim is record of deposit reward claim
+type Claim struct {
+	ClaimTxID ids.ID `serialize:"true" json:"claimTxID"`
+	Timestamp uint64 `serialize:"true" json:"timestamp"`
+	Amount    uint64 `serialize:"true" json:"amount"`
 }
 
 func (deposit *Deposit) StartTime() time.Time {
@@ -169,12 +562,14 @@ func (deposit *Deposit) UnlockableAmount(offer *Offer, unlockTime uint64) uint64
 }
 
 // Returns amount of tokens that can be claimed as reward for [deposit] at [claimetime] (seconds).
+// Reward accrued till [claimTime] is limited by reward vested according to offer reward schedule.
 //
 // Precondition: all args are valid in conjunction.
 func (deposit *Deposit) ClaimableReward(offer *Offer, claimTime uint64) uint64 {
 	if deposit.Start > claimTime {
 		return 0
 	}
+	passedDuration := claimTime - deposit.Start
 
 	rewardsEndTime, err := math.Add64(
 		deposit.Start,
@@ -187,32 +582,130 @@ func (deposit *Deposit) ClaimableReward(offer *Offer, claimTime uint64) uint64 {
 
 	claimTime = math.Min(claimTime, rewardsEndTime)
 
-	bigTotalRewardAmount := (&big.Int{}).SetUint64(deposit.Amount)
-	bigPassedDepositDuration := (&big.Int{}).SetUint64(claimTime - deposit.Start)
-	bigInterestRateNominator := (&big.Int{}).SetUint64(offer.InterestRateNominator)
-
-	// totalRewardAmount := depositAmount * offer.InterestRate * passedDepositDuration / interestRateBase
-	bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigPassedDepositDuration)
-	bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigInterestRateNominator)
-	bigTotalRewardAmount.Div(bigTotalRewardAmount, bigInterestRateDenominator)
+	reward := offer.reward(deposit.Amount, claimTime-deposit.Start)
+	if !offer.RewardSchedule.IsZero() {
+		reward = math.Min(reward, offer.RewardSchedule.vested(deposit.TotalReward(offer), passedDuration))
+	}
+	if reward < deposit.ClaimedRewardAmount {
+		// could only happen if offer reward schedule was updated
+		return 0
+	}
+	return reward - deposit.ClaimedRewardAmount
+}
 
-	return bigTotalRewardAmount.Uint64() - deposit.ClaimedRewardAmount
+// Returns amount of tokens that will be claimable as reward for [deposit], if it will be
+// early unlocked at [unlockTime] (seconds): reward accrued till [unlockTime] and not claimed yet,
+// reduced by offer early unlock reward penalty.
+//
+// Precondition: all args are valid in conjunction.
+func (deposit *Deposit) EarlyUnlockReward(offer *Offer, unlockTime uint64) uint64 {
+	claimableReward := deposit.ClaimableReward(offer, unlockTime)
+	return claimableReward - applyPenalty(claimableReward, offer.EarlyUnlockRewardPenaltyNominator)
 }
 
 // Returns amount of tokens that can be claimed as reward for [depositAmount].
 //
 // Precondition: all args are valid in conjunction.
 func (deposit *Deposit) TotalReward(offer *Offer) uint64 {
-	bigTotalRewardAmount := (&big.Int{}).SetUint64(deposit.Amount)
+	return offer.reward(deposit.Amount, uint64(deposit.Duration-offer.NoRewardsPeriodDuration))
+}
+
+// Split returns two deposits, that [deposit] will be split into: remaining deposit with
+// principal reduced by [splitAmount] and new deposit with [splitAmount] principal.
+// Both deposits have the same offer, start and duration as [deposit]. Unlocked amount and
+// claimed reward are divided between them proportionally to their principal, rounding down
+// for the new deposit. Remaining deposit keeps [deposit] rewards owner.
+//
+// Precondition: 0 < [splitAmount] < deposit.Amount.
+func (deposit *Deposit) Split(splitAmount uint64) (*Deposit, *Deposit) {
+	bigAmount := (&big.Int{}).SetUint64(deposit.Amount)
+	bigSplitAmount := (&big.Int{}).SetUint64(splitAmount)
+
+	// splitValue := value * splitAmount / amount
+	split := func(value uint64) uint64 {
+		bigValue := (&big.Int{}).SetUint64(value)
+		bigValue.Mul(bigValue, bigSplitAmount)
+		bigValue.Div(bigValue, bigAmount)
+		return bigValue.Uint64()
+	}
+
+	splitUnlockedAmount := split(deposit.UnlockedAmount)
+	splitClaimedRewardAmount := split(deposit.ClaimedRewardAmount)
+
+	remainingDeposit := &Deposit{
+		DepositOfferID:      deposit.DepositOfferID,
+		UnlockedAmount:      deposit.UnlockedAmount - splitUnlockedAmount,
+		ClaimedRewardAmount: deposit.ClaimedRewardAmount - splitClaimedRewardAmount,
+		Start:               deposit.Start,
+		Duration:            deposit.Duration,
+		Amount:              deposit.Amount - splitAmount,
+		RewardOwner:         deposit.RewardOwner,
+	}
+	newDeposit := &Deposit{
+		DepositOfferID:      deposit.DepositOfferID,
+		UnlockedAmount:      splitUnlockedAmount,
+		ClaimedRewardAmount: splitClaimedRewardAmount,
+		Start:               deposit.Start,
+		Duration:            deposit.Duration,
+		Amount:              splitAmount,
+	}
+	return remainingDeposit, newDeposit
+}
+
+// Returns reward for [amount] deposited with this offer for [rewardsDuration] seconds.
+// Compounding rewards are calculated with integer math and truncated after each compounding period,
+// so all nodes get the same result.
+func (o *Offer) reward(amount, rewardsDuration uint64) uint64 {
+	bigInterestRateNominator := (&big.Int{}).SetUint64(o.InterestRateNominatorFor(amount))
+
+	if !o.IsCompounding() {
+		bigTotalRewardAmount := (&big.Int{}).SetUint64(amount)
+		bigRewardsDuration := (&big.Int{}).SetUint64(rewardsDuration)
+
+		// totalRewardAmount := amount * offer.InterestRate * rewardsDuration / interestRateBase
+		bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigRewardsDuration)
+		bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigInterestRateNominator)
+		bigTotalRewardAmount.Div(bigTotalRewardAmount, bigInterestRateDenominator)
+		return uint64OrMax(bigTotalRewardAmount)
+	}
+
+	bigAmount := (&big.Int{}).SetUint64(amount)
+	bigTotalAmount := (&big.Int{}).Set(bigAmount)
+	bigPeriodInterest := &big.Int{}
+
+	// totalAmount += totalAmount * offer.InterestRate * CompoundingPeriod / interestRateBase, for each full period
+	bigCompoundingPeriod := (&big.Int{}).SetUint64(CompoundingPeriod)
+	for periods := rewardsDuration / CompoundingPeriod; periods > 0; periods-- {
+		bigPeriodInterest.Mul(bigTotalAmount, bigCompoundingPeriod)
+		bigPeriodInterest.Mul(bigPeriodInterest, bigInterestRateNominator)
+		bigPeriodInterest.Div(bigPeriodInterest, bigInterestRateDenominator)
+		bigTotalAmount.Add(bigTotalAmount, bigPeriodInterest)
+	}
 
-	// rewardsPeriodDuration = deposit.Duration - offer.NoRewardsPeriodDuration
-	bigRewardsPeriodDuration := (&big.Int{}).SetUint64(uint64(deposit.Duration - offer.NoRewardsPeriodDuration))
-	bigInterestRateNominator := (&big.Int{}).SetUint64(offer.InterestRateNominator)
+	// totalAmount += totalAmount * offer.InterestRate * remainingDuration / interestRateBase, for the last partial period
+	bigRemainingDuration := (&big.Int{}).SetUint64(rewardsDuration % CompoundingPeriod)
+	bigPeriodInterest.Mul(bigTotalAmount, bigRemainingDuration)
+	bigPeriodInterest.Mul(bigPeriodInterest, bigInterestRateNominator)
+	bigPeriodInterest.Div(bigPeriodInterest, bigInterestRateDenominator)
+	bigTotalAmount.Add(bigTotalAmount, bigPeriodInterest)
 
-	// totalRewardAmount := depositAmount * offer.InterestRate * rewardsPeriodDuration / interestRateBase
-	bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigRewardsPeriodDuration)
-	bigTotalRewardAmount.Mul(bigTotalRewardAmount, bigInterestRateNominator)
-	bigTotalRewardAmount.Div(bigTotalRewardAmount, bigInterestRateDenominator)
+	return uint64OrMax(bigTotalAmount.Sub(bigTotalAmount, bigAmount))
+}
+
+// uint64OrMax returns [value] as uint64 or max uint64, if [value] doesn't fit into uint64.
+// big.Int.Uint64 result is undefined in that case, so it must not be used directly
+// for values that could overflow.
+func uint64OrMax(value *big.Int) uint64 {
+	if !value.IsUint64() {
+		return stdmath.MaxUint64
+	}
+	return value.Uint64()
+}
 
-	return bigTotalRewardAmount.Uint64()
+// Returns [amount] * [penaltyNominator] / EarlyUnlockPenaltyDenominator
+func applyPenalty(amount, penaltyNominator uint64) uint64 {
+	bigPenalty := (&big.Int{}).SetUint64(amount)
+	bigPenalty.Mul(bigPenalty, (&big.Int{}).SetUint64(penaltyNominator))
+	bigPenalty.Div(bigPenalty, bigEarlyUnlockPenaltyDenominator)
+	return bigPenalty.Uint64()
 }
@@ -3,8 +3,16 @@
 package deposit
 
 import (
+	"math"
+	"math/big"
+	"math/rand"
+	"strings"
 	"testing"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/stretchr/testify/require"
 )
 
@@ -48,3 +56,572 @@ func TestTotalReward(t *testing.T) {
 		})
 	}
 }
+
+func TestCompoundingReward(t *testing.T) {
+	require := require.New(t)
+
+	const (
+		year   = 365 * CompoundingPeriod
+		amount = 1_000_000_000
+	)
+	offer := &Offer{InterestRateNominator: 100_000} // 10% per year
+	compoundingOffer := &Offer{InterestRateNominator: 100_000, Flags: OfferFlagCompounding}
+	dep := &Deposit{Amount: amount, Duration: year}
+
+	// linear reward
+	require.EqualValues(100_000_000, dep.TotalReward(offer))
+
+	// daily compounded reward: amount * ((1 + 0.1/365)^365 - 1) ~ 105_155_781, minus truncation errors
+	compoundedReward := dep.TotalReward(compoundingOffer)
+	require.InDelta(105_155_781, compoundedReward, 365)
+
+	// compounding doesn't affect first period
+	require.Equal(dep.ClaimableReward(offer, CompoundingPeriod), dep.ClaimableReward(compoundingOffer, CompoundingPeriod))
+
+	// claimable reward grows over time and reaches total reward
+	previousReward := uint64(0)
+	for claimTime := uint64(0); claimTime <= year; claimTime += CompoundingPeriod / 2 {
+		reward := dep.ClaimableReward(compoundingOffer, claimTime)
+		require.GreaterOrEqual(reward, previousReward)
+		previousReward = reward
+	}
+	require.Equal(compoundedReward, previousReward)
+
+	// already claimed reward is subtracted
+	dep.ClaimedRewardAmount = 5
+	require.Equal(compoundedReward-5, dep.ClaimableReward(compoundingOffer, year))
+}
+
+func TestTieredReward(t *testing.T) {
+	offer := &Offer{
+		InterestRateNominator: 100_000,
+		MinDuration:           1,
+		MaxDuration:           1,
+		Start:                 0,
+		End:                   1,
+		Tiers: []InterestRateTier{
+			{MinAmount: 1000, InterestRateNominator: 200_000},
+			{MinAmount: 2000, InterestRateNominator: 300_000},
+		},
+	}
+	require.NoError(t, offer.Verify())
+
+	tests := map[string]struct {
+		amount                        uint64
+		expectedInterestRateNominator uint64
+	}{
+		"Below first tier": {
+			amount:                        999,
+			expectedInterestRateNominator: 100_000,
+		},
+		"First tier": {
+			amount:                        1000,
+			expectedInterestRateNominator: 200_000,
+		},
+		"Last tier": {
+			amount:                        5000,
+			expectedInterestRateNominator: 300_000,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(tt.expectedInterestRateNominator, offer.InterestRateNominatorFor(tt.amount))
+			dep := &Deposit{Amount: tt.amount, Duration: interestRateBase}
+			require.Equal(tt.amount*tt.expectedInterestRateNominator/1_000_000, dep.TotalReward(offer))
+		})
+	}
+
+	// tiers must be sorted by min amount
+	offer.Tiers[1].MinAmount = offer.Tiers[0].MinAmount
+	require.Error(t, offer.Verify())
+}
+
+func TestOfferVerifyTotalMaxAmount(t *testing.T) {
+	validOffer := func() *Offer {
+		return &Offer{
+			Start:          0,
+			End:            1,
+			MinDuration:    1,
+			MaxDuration:    1,
+			MinAmount:      1,
+			TotalMaxAmount: 10,
+		}
+	}
+
+	offer := validOffer()
+	require.NoError(t, offer.Verify())
+
+	offer.DepositedAmount = offer.TotalMaxAmount
+	require.NoError(t, offer.Verify())
+
+	offer.DepositedAmount = offer.TotalMaxAmount + 1
+	require.Error(t, offer.Verify())
+
+	offer = validOffer()
+	offer.MinAmount = offer.TotalMaxAmount + 1
+	require.Error(t, offer.Verify())
+
+	// zero total max amount means no limit
+	offer.TotalMaxAmount = 0
+	offer.DepositedAmount = 100
+	require.NoError(t, offer.Verify())
+}
+
+func TestOfferVerifyDisplayFields(t *testing.T) {
+	tests := map[string]struct {
+		name        string
+		uri         string
+		expectedErr bool
+	}{
+		"OK": {
+			name: strings.Repeat("n", MaxNameSize),
+			uri:  strings.Repeat("u", MaxURISize),
+		},
+		"Name is too big": {
+			name:        strings.Repeat("n", MaxNameSize+1),
+			expectedErr: true,
+		},
+		"URI is too big": {
+			uri:         strings.Repeat("u", MaxURISize+1),
+			expectedErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			offer := &Offer{
+				Start:       0,
+				End:         1,
+				MinDuration: 1,
+				MaxDuration: 1,
+				Name:        tt.name,
+				URI:         tt.uri,
+			}
+			if tt.expectedErr {
+				require.Error(t, offer.Verify())
+			} else {
+				require.NoError(t, offer.Verify())
+			}
+		})
+	}
+}
+
+func TestOfferSetIDWithDisplayFields(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1}
+	require.NoError(offer.SetID())
+	bytes, err := offerCodec.Marshal(codecVersion, offer)
+	require.NoError(err)
+	// offer without name and uri has the same id as before display fields were introduced
+	require.Equal(ids.ID(hashing.ComputeHash256Array(bytes)), offer.ID)
+
+	idWithoutDisplayFields := offer.ID
+	offer.Name = "name"
+	require.NoError(offer.SetID())
+	idWithName := offer.ID
+	require.NotEqual(idWithoutDisplayFields, idWithName)
+
+	offer.URI = "uri"
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithName, offer.ID)
+	require.NotEqual(idWithoutDisplayFields, offer.ID)
+}
+
+func TestOfferSetIDWithRewardAsset(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1, Name: "name"}
+	require.NoError(offer.SetID())
+	idWithoutRewardAsset := offer.ID
+
+	offer.RewardAssetID = ids.ID{1}
+	require.NoError(offer.SetID())
+	idWithRewardAsset1 := offer.ID
+	require.NotEqual(idWithoutRewardAsset, idWithRewardAsset1)
+
+	offer.RewardAssetID = ids.ID{2}
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithRewardAsset1, offer.ID)
+	require.NotEqual(idWithoutRewardAsset, offer.ID)
+}
+
+func TestOfferSetIDWithOwnerAddress(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1}
+	require.NoError(offer.SetID())
+	idWithoutOwner := offer.ID
+
+	offer.OwnerAddress = ids.ShortID{1}
+	require.NoError(offer.SetID())
+	idWithOwner1 := offer.ID
+	require.NotEqual(idWithoutOwner, idWithOwner1)
+
+	offer.OwnerAddress = ids.ShortID{2}
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithOwner1, offer.ID)
+	require.NotEqual(idWithoutOwner, offer.ID)
+}
+
+func TestOfferSetIDWithTiers(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1}
+	require.NoError(offer.SetID())
+	idWithoutTiers := offer.ID
+
+	offer.Tiers = []InterestRateTier{}
+	require.NoError(offer.SetID())
+	require.Equal(idWithoutTiers, offer.ID)
+
+	offer.Tiers = []InterestRateTier{{MinAmount: 10, InterestRateNominator: 1}}
+	require.NoError(offer.SetID())
+	idWithTier1 := offer.ID
+	require.NotEqual(idWithoutTiers, idWithTier1)
+
+	offer.Tiers = []InterestRateTier{{MinAmount: 10, InterestRateNominator: 2}}
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithTier1, offer.ID)
+	require.NotEqual(idWithoutTiers, offer.ID)
+}
+
+func TestOfferSetIDWithMaxAmount(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1}
+	require.NoError(offer.SetID())
+	idWithoutMaxAmount := offer.ID
+
+	offer.MaxAmount = 10
+	require.NoError(offer.SetID())
+	idWithMaxAmount1 := offer.ID
+	require.NotEqual(idWithoutMaxAmount, idWithMaxAmount1)
+
+	offer.MaxAmount = 20
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithMaxAmount1, offer.ID)
+	require.NotEqual(idWithoutMaxAmount, offer.ID)
+}
+
+func TestOfferSetIDWithEarlyUnlockPenalties(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1, Flags: OfferFlagEarlyUnlock}
+	require.NoError(offer.SetID())
+	idWithoutPenalties := offer.ID
+
+	offer.EarlyUnlockPenaltyNominator = 10
+	require.NoError(offer.SetID())
+	idWithPenalty := offer.ID
+	require.NotEqual(idWithoutPenalties, idWithPenalty)
+
+	offer.EarlyUnlockPenaltyNominator = 0
+	offer.EarlyUnlockRewardPenaltyNominator = 10
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithPenalty, offer.ID)
+	require.NotEqual(idWithoutPenalties, offer.ID)
+}
+
+func TestOfferSetIDWithRewardSchedule(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 10, MaxDuration: 10}
+	require.NoError(offer.SetID())
+	idWithoutSchedule := offer.ID
+
+	offer.RewardSchedule = VestingSchedule{CliffDuration: 5}
+	require.NoError(offer.SetID())
+	idWithCliff := offer.ID
+	require.NotEqual(idWithoutSchedule, idWithCliff)
+
+	offer.RewardSchedule = VestingSchedule{VestingDuration: 5}
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithCliff, offer.ID)
+	require.NotEqual(idWithoutSchedule, offer.ID)
+}
+
+func TestOfferSetIDWithRequiredAddressState(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{End: 1, MinDuration: 1, MaxDuration: 1}
+	require.NoError(offer.SetID())
+	idWithoutAddressState := offer.ID
+
+	offer.RequiredAddressState = as.AddressStateKycVerified
+	require.NoError(offer.SetID())
+	idWithKycVerified := offer.ID
+	require.NotEqual(idWithoutAddressState, idWithKycVerified)
+
+	offer.RequiredAddressState = as.AddressStateConsortium
+	require.NoError(offer.SetID())
+	require.NotEqual(idWithKycVerified, offer.ID)
+	require.NotEqual(idWithoutAddressState, offer.ID)
+}
+
+func TestOfferExtension(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{
+		Version:                           1,
+		Tiers:                             []InterestRateTier{{MinAmount: 10, InterestRateNominator: 1}},
+		MaxAmount:                         2,
+		EarlyUnlockPenaltyNominator:       3,
+		EarlyUnlockRewardPenaltyNominator: 4,
+		RewardSchedule:                    VestingSchedule{CliffDuration: 5, VestingDuration: 6},
+		RequiredAddressState:              7,
+		Name:                              "name",
+		URI:                               "uri",
+		RewardAssetID:                     ids.ID{8},
+		OwnerAddress:                      ids.ShortID{9},
+		MinDuration:                       10,
+	}
+	extension := offer.Extension()
+	require.False(extension.IsEmpty())
+
+	appliedOffer := &Offer{Version: 1, MinDuration: 10}
+	extension.ApplyTo(appliedOffer)
+	require.Equal(offer, appliedOffer)
+
+	emptyExtension := (&Offer{Version: 1, MinDuration: 10}).Extension()
+	require.True(emptyExtension.IsEmpty())
+}
+
+func TestOfferRewardAsset(t *testing.T) {
+	primaryAssetID := ids.ID{1}
+	require.Equal(t, primaryAssetID, (&Offer{}).RewardAsset(primaryAssetID))
+	require.Equal(t, ids.ID{2}, (&Offer{RewardAssetID: ids.ID{2}}).RewardAsset(primaryAssetID))
+}
+
+func TestOfferAdmitsAmount(t *testing.T) {
+	tests := map[string]struct {
+		offer    *Offer
+		amount   uint64
+		expected bool
+	}{
+		"Less than min amount": {
+			offer:  &Offer{MinAmount: 10},
+			amount: 9,
+		},
+		"Greater than max amount": {
+			offer:  &Offer{MinAmount: 10, MaxAmount: 20},
+			amount: 21,
+		},
+		"Greater than remaining amount": {
+			offer:  &Offer{MinAmount: 10, TotalMaxAmount: 100, DepositedAmount: 90},
+			amount: 11,
+		},
+		"OK": {
+			offer:    &Offer{MinAmount: 10, MaxAmount: 20, TotalMaxAmount: 100, DepositedAmount: 80},
+			amount:   20,
+			expected: true,
+		},
+		"OK: no max amount": {
+			offer:    &Offer{MinAmount: 10},
+			amount:   1_000_000,
+			expected: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.offer.AdmitsAmount(tt.amount))
+		})
+	}
+}
+
+func TestEarlyUnlock(t *testing.T) {
+	require := require.New(t)
+
+	offer := &Offer{
+		Start:                             0,
+		End:                               1,
+		MinDuration:                       1,
+		MaxDuration:                       interestRateBase,
+		InterestRateNominator:             100_000, // 10%
+		Flags:                             OfferFlagEarlyUnlock,
+		EarlyUnlockPenaltyNominator:       EarlyUnlockPenaltyDenominator / 20, // 5%
+		EarlyUnlockRewardPenaltyNominator: EarlyUnlockPenaltyDenominator / 2,  // 50%
+	}
+	require.NoError(offer.Verify())
+	require.True(offer.AllowsEarlyUnlock())
+
+	deposit := &Deposit{
+		Duration:            interestRateBase,
+		Amount:              1_000_000,
+		ClaimedRewardAmount: 10_000,
+	}
+
+	require.Equal(uint64(50_000), offer.EarlyUnlockPenalty(deposit.Amount))
+	// half a year accrued 50_000 reward, 10_000 of it is claimed, half of the rest is slashed
+	require.Equal(uint64(20_000), deposit.EarlyUnlockReward(offer, interestRateBase/2))
+
+	offer.EarlyUnlockPenaltyNominator = EarlyUnlockPenaltyDenominator + 1
+	require.Error(offer.Verify())
+
+	offer.EarlyUnlockPenaltyNominator = 1
+	offer.Flags = 0
+	require.False(offer.AllowsEarlyUnlock())
+	require.Error(offer.Verify())
+}
+
+func TestDepositSplit(t *testing.T) {
+	require := require.New(t)
+
+	rewardsOwner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	deposit := &Deposit{
+		DepositOfferID:      ids.ID{1},
+		UnlockedAmount:      101,
+		ClaimedRewardAmount: 33,
+		Start:               10,
+		Duration:            100,
+		Amount:              1000,
+		RewardOwner:         rewardsOwner,
+	}
+
+	remainingDeposit, newDeposit := deposit.Split(300)
+	require.Equal(&Deposit{
+		DepositOfferID:      ids.ID{1},
+		UnlockedAmount:      71,
+		ClaimedRewardAmount: 24,
+		Start:               10,
+		Duration:            100,
+		Amount:              700,
+		RewardOwner:         rewardsOwner,
+	}, remainingDeposit)
+	require.Equal(&Deposit{
+		DepositOfferID:      ids.ID{1},
+		UnlockedAmount:      30,
+		ClaimedRewardAmount: 9,
+		Start:               10,
+		Duration:            100,
+		Amount:              300,
+	}, newDeposit)
+
+	// original deposit isn't modified
+	require.Equal(uint64(1000), deposit.Amount)
+}
+
+func TestClaimableRewardWithRewardSchedule(t *testing.T) {
+	offer := &Offer{
+		Start:                 0,
+		End:                   1,
+		MinDuration:           100,
+		MaxDuration:           100,
+		InterestRateNominator: 1_000_000, // 100%
+		RewardSchedule: VestingSchedule{
+			CliffDuration:   20,
+			VestingDuration: 40,
+		},
+	}
+	require.NoError(t, offer.Verify())
+
+	deposit := &Deposit{
+		Duration: 100,
+		Amount:   interestRateBase,
+	}
+	// total reward is 100 and accrues 1 per second
+
+	tests := map[string]struct {
+		claimTime           uint64
+		claimedRewardAmount uint64
+		expected            uint64
+	}{
+		"Before cliff": {
+			claimTime: 20,
+			expected:  0,
+		},
+		"Vesting, limited by vested reward": {
+			claimTime: 30,
+			expected:  25,
+		},
+		"Vesting, limited by accrued reward": {
+			claimTime: 50,
+			expected:  50,
+		},
+		"Vesting, partially claimed": {
+			claimTime:           30,
+			claimedRewardAmount: 10,
+			expected:            15,
+		},
+		"After vesting": {
+			claimTime: 100,
+			expected:  100,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			deposit := *deposit
+			deposit.ClaimedRewardAmount = tt.claimedRewardAmount
+			require.Equal(t, tt.expected, deposit.ClaimableReward(offer, tt.claimTime))
+		})
+	}
+
+	offer.RewardSchedule.VestingDuration = 81
+	require.Error(t, offer.Verify())
+}
+
+func TestRewardOverflow(t *testing.T) {
+	require := require.New(t)
+
+	deposit := &Deposit{
+		Amount:   math.MaxUint64,
+		Duration: math.MaxUint32,
+	}
+
+	// reward, that doesn't fit into uint64, is capped instead of being truncated
+	for _, offer := range []*Offer{
+		{InterestRateNominator: 1_000_000},
+		{InterestRateNominator: 1_000_000, Flags: OfferFlagCompounding},
+		{InterestRateNominator: 1, Tiers: []InterestRateTier{{MinAmount: 1, InterestRateNominator: math.MaxUint64}}},
+	} {
+		require.Equal(uint64(math.MaxUint64), deposit.TotalReward(offer))
+		require.Equal(uint64(math.MaxUint64), deposit.ClaimableReward(offer, math.MaxUint32))
+	}
+
+	// reward of max amount, that fits into uint64, is exact
+	offer := &Offer{InterestRateNominator: 1}
+	deposit.Duration = interestRateBase
+	require.Equal(uint64(math.MaxUint64/1_000_000), deposit.TotalReward(offer))
+}
+
+func TestRewardProperties(t *testing.T) {
+	require := require.New(t)
+
+	rng := rand.New(rand.NewSource(0)) // #nosec G404
+
+	for i := 0; i < 1000; i++ {
+		amount := rng.Uint64()
+		if i%2 == 0 {
+			amount >>= rng.Intn(64)
+		}
+		duration := uint32(rng.Int63n(10 * interestRateBase))
+		offer := &Offer{
+			InterestRateNominator:   uint64(rng.Int63n(2_000_000)),
+			NoRewardsPeriodDuration: uint32(rng.Int63n(int64(duration) + 1)),
+		}
+		deposit := &Deposit{
+			Start:    rng.Uint64() >> 1,
+			Duration: duration,
+			Amount:   amount,
+		}
+		rewardsDuration := uint64(duration - offer.NoRewardsPeriodDuration)
+
+		// linear reward is exact: amount * rate * duration / denominator
+		expectedReward := (&big.Int{}).SetUint64(amount)
+		expectedReward.Mul(expectedReward, (&big.Int{}).SetUint64(offer.InterestRateNominator))
+		expectedReward.Mul(expectedReward, (&big.Int{}).SetUint64(rewardsDuration))
+		expectedReward.Div(expectedReward, big.NewInt(interestRateDenominator))
+		if !expectedReward.IsUint64() {
+			expectedReward.SetUint64(math.MaxUint64)
+		}
+		totalReward := deposit.TotalReward(offer)
+		require.Equal(expectedReward.Uint64(), totalReward)
+
+		// claimable reward grows with time and reaches total reward at deposit end
+		claimTime := deposit.Start + uint64(rng.Int63n(int64(duration)+1))
+		claimableReward := deposit.ClaimableReward(offer, claimTime)
+		require.LessOrEqual(claimableReward, deposit.ClaimableReward(offer, claimTime+1))
+		require.LessOrEqual(claimableReward, totalReward)
+		require.Equal(totalReward, deposit.ClaimableReward(offer, deposit.Start+uint64(duration)))
+	}
+}
@@ -4,10 +4,16 @@
 package fx
 
 import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
+var errSignerNotRecovered = errors.New("couldn't recover signer of credential signature")
+
 type CaminoFx interface {
 	// Recovers signers addresses from [verifies] credentials for [utx] transaction
 	RecoverAddresses(utx secp256k1fx.UnsignedTx, verifies []verify.Verifiable) (secp256k1fx.RecoverMap, error)
@@ -17,13 +23,57 @@ type CaminoFx interface {
 	// provided utxo with no restrictions on the destination. If the transaction
 	// can't spend the output based on the input and credential, a non-nil error
 	// should be returned. Multisig aliases supported.
-	VerifyMultisigTransfer(txIntf, inIntf, credIntf, utxoIntf, msigIntf interface{}) error
+	VerifyMultisigTransfer(
+		tx secp256k1fx.UnsignedTx,
+		in *secp256k1fx.TransferInput,
+		cred secp256k1fx.CredentialIntf,
+		utxo *secp256k1fx.TransferOutput,
+		msig secp256k1fx.AliasGetter,
+	) error
 
-	// VerifyMultisigPermission returns nil if credential [credIntf] proves that [controlGroup] assents to transaction [utx].
+	// VerifyMultisigPermission returns nil if credential [cred] proves that [controlGroup] assents to transaction [tx].
 	// Multisig aliases supported.
-	VerifyMultisigPermission(txIntf, inIntf, credIntf, controlGroup, msigIntf interface{}) error
+	VerifyMultisigPermission(
+		tx secp256k1fx.UnsignedTx,
+		in *secp256k1fx.Input,
+		cred secp256k1fx.CredentialIntf,
+		controlGroup secp256k1fx.MultisigOwners,
+		msig secp256k1fx.AliasGetter,
+	) error
 
-	// VerifyMultisigUnorderedPermission returns nil if credential [credIntf] proves [ownerIntf].
+	// VerifyMultisigUnorderedPermission returns nil if credentials [creds] prove [owners].
 	// Multisig aliases supported. Signatures order and number doesn't matter.
-	VerifyMultisigUnorderedPermission(txIntf, credIntf, ownerIntf, msigIntf interface{}) error
+	VerifyMultisigUnorderedPermission(
+		tx secp256k1fx.UnsignedTx,
+		creds []verify.Verifiable,
+		owners secp256k1fx.MultisigOwners,
+		msig secp256k1fx.AliasGetter,
+	) error
+}
+
+// RecoverSigners returns signers addresses of every credential in [creds] for [utx] transaction.
+// Addresses are returned in the same order as signatures of credential.
+func RecoverSigners(fx CaminoFx, utx secp256k1fx.UnsignedTx, creds []verify.Verifiable) ([][]ids.ShortID, error) {
+	signers := make([][]ids.ShortID, len(creds))
+	for i, cred := range creds {
+		recovered, err := fx.RecoverAddresses(utx, []verify.Verifiable{cred})
+		if err != nil {
+			return nil, err
+		}
+		sigSigners := make(map[[crypto.SECP256K1RSigLen]byte]ids.ShortID, len(recovered))
+		for addr, sig := range recovered {
+			sigSigners[sig] = addr
+		}
+		// RecoverAddresses already checked, that cred is secp256k1fx.CredentialIntf
+		sigs := cred.(secp256k1fx.CredentialIntf).Signatures()
+		signers[i] = make([]ids.ShortID, len(sigs))
+		for j, sig := range sigs {
+			signer, ok := sigSigners[sig]
+			if !ok {
+				return nil, errSignerNotRecovered
+			}
+			signers[i][j] = signer
+		}
+	}
+	return signers, nil
 }
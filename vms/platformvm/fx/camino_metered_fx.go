@@ -0,0 +1,147 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fx
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	routineLabel = "routine"
+	errorLabel   = "error"
+
+	otherErrorLabel = "other"
+)
+
+var (
+	_ Fx = (*meteredFx)(nil)
+
+	// typed fx errors, that are reported with their own failure label
+	fxErrorLabels = []struct {
+		err   error
+		label string
+	}{
+		{secp256k1fx.ErrCyclicAliases, "cyclic_aliases"},
+		{secp256k1fx.ErrAliasDepthExceeded, "alias_depth_exceeded"},
+		{secp256k1fx.ErrThresholdNotMet, "threshold_not_met"},
+		{secp256k1fx.ErrSignerNotInAlias, "signer_not_in_alias"},
+		{secp256k1fx.ErrMemberNotActive, "member_not_active"},
+		{secp256k1fx.ErrAliasNotFound, "alias_not_found"},
+	}
+)
+
+// meteredFx records number, latency and failures of camino fx verification routines.
+type meteredFx struct {
+	Fx
+	duration *prometheus.HistogramVec
+	failures *prometheus.CounterVec
+}
+
+// NewMeteredFx returns [fx], which camino verification routines are metered.
+// Number of calls is reported as count of duration histogram.
+func NewMeteredFx(fx Fx, namespace string, registerer prometheus.Registerer) (Fx, error) {
+	m := &meteredFx{
+		Fx: fx,
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "verify_duration",
+				Help:      "Duration (in ns) of camino fx verification routines",
+				Buckets:   prometheus.ExponentialBuckets(float64(10*time.Microsecond), 4, 8),
+			},
+			[]string{routineLabel},
+		),
+		failures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "verify_failures",
+				Help:      "Number of failed camino fx verifications by error",
+			},
+			[]string{routineLabel, errorLabel},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.duration),
+		registerer.Register(m.failures),
+	)
+	return m, errs.Err
+}
+
+func (m *meteredFx) RecoverAddresses(utx secp256k1fx.UnsignedTx, verifies []verify.Verifiable) (secp256k1fx.RecoverMap, error) {
+	start := time.Now()
+	recovered, err := m.Fx.RecoverAddresses(utx, verifies)
+	m.observe("recover_addresses", start, err)
+	return recovered, err
+}
+
+func (m *meteredFx) VerifyMultisigOwner(outIntf, msigIntf interface{}) error {
+	start := time.Now()
+	err := m.Fx.VerifyMultisigOwner(outIntf, msigIntf)
+	m.observe("multisig_owner", start, err)
+	return err
+}
+
+func (m *meteredFx) VerifyMultisigTransfer(
+	tx secp256k1fx.UnsignedTx,
+	in *secp256k1fx.TransferInput,
+	cred secp256k1fx.CredentialIntf,
+	utxo *secp256k1fx.TransferOutput,
+	msig secp256k1fx.AliasGetter,
+) error {
+	start := time.Now()
+	err := m.Fx.VerifyMultisigTransfer(tx, in, cred, utxo, msig)
+	m.observe("multisig_transfer", start, err)
+	return err
+}
+
+func (m *meteredFx) VerifyMultisigPermission(
+	tx secp256k1fx.UnsignedTx,
+	in *secp256k1fx.Input,
+	cred secp256k1fx.CredentialIntf,
+	controlGroup secp256k1fx.MultisigOwners,
+	msig secp256k1fx.AliasGetter,
+) error {
+	start := time.Now()
+	err := m.Fx.VerifyMultisigPermission(tx, in, cred, controlGroup, msig)
+	m.observe("multisig_permission", start, err)
+	return err
+}
+
+func (m *meteredFx) VerifyMultisigUnorderedPermission(
+	tx secp256k1fx.UnsignedTx,
+	creds []verify.Verifiable,
+	owners secp256k1fx.MultisigOwners,
+	msig secp256k1fx.AliasGetter,
+) error {
+	start := time.Now()
+	err := m.Fx.VerifyMultisigUnorderedPermission(tx, creds, owners, msig)
+	m.observe("multisig_unordered_permission", start, err)
+	return err
+}
+
+func (m *meteredFx) observe(routine string, start time.Time, err error) {
+	m.duration.WithLabelValues(routine).Observe(float64(time.Since(start)))
+	if err != nil {
+		m.failures.WithLabelValues(routine, errorLabelOf(err)).Inc()
+	}
+}
+
+// errorLabelOf returns failure label of [err]: label of wrapped typed fx error or "other"
+func errorLabelOf(err error) string {
+	for _, fxErr := range fxErrorLabels {
+		if errors.Is(err, fxErr.err) {
+			return fxErr.label
+		}
+	}
+	return otherErrorLabel
+}
@@ -0,0 +1,67 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package fx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMeteredFx(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	innerFx := NewMockFx(ctrl)
+	registry := prometheus.NewRegistry()
+	fx, err := NewMeteredFx(innerFx, "fx", registry)
+	require.NoError(err)
+
+	errOther := errors.New("other")
+	innerFx.EXPECT().VerifyMultisigOwner(nil, nil).Return(nil)
+	innerFx.EXPECT().VerifyMultisigOwner(nil, nil).Return(fmt.Errorf("wrapped: %w", secp256k1fx.ErrThresholdNotMet))
+	innerFx.EXPECT().VerifyMultisigUnorderedPermission(nil, nil, nil, nil).Return(errOther)
+
+	require.NoError(fx.VerifyMultisigOwner(nil, nil))
+	require.ErrorIs(fx.VerifyMultisigOwner(nil, nil), secp256k1fx.ErrThresholdNotMet)
+	require.ErrorIs(fx.VerifyMultisigUnorderedPermission(nil, nil, nil, nil), errOther)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	counts := map[string]uint64{}
+	failures := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			labels := map[string]string{}
+			for _, label := range metric.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			switch family.GetName() {
+			case "fx_verify_duration":
+				counts[labels[routineLabel]] = metric.Histogram.GetSampleCount()
+			case "fx_verify_failures":
+				failures[labels[routineLabel]+"/"+labels[errorLabel]] = metric.Counter.GetValue()
+			}
+		}
+	}
+	require.Equal(map[string]uint64{
+		"multisig_owner":                2,
+		"multisig_unordered_permission": 1,
+	}, counts)
+	require.Equal(map[string]float64{
+		"multisig_owner/threshold_not_met":    1,
+		"multisig_unordered_permission/other": 1,
+	}, failures)
+
+	_, err = NewMeteredFx(innerFx, "fx", registry)
+	require.Error(err)
+}
@@ -126,7 +126,7 @@ func (mr *MockFxMockRecorder) VerifyMultisigOwner(arg0, arg1 interface{}) *gomoc
 }
 
 // VerifyMultisigTransfer mocks base method.
-func (m *MockFx) VerifyMultisigTransfer(arg0, arg1, arg2, arg3, arg4 interface{}) error {
+func (m *MockFx) VerifyMultisigTransfer(arg0 secp256k1fx.UnsignedTx, arg1 *secp256k1fx.TransferInput, arg2 secp256k1fx.CredentialIntf, arg3 *secp256k1fx.TransferOutput, arg4 secp256k1fx.AliasGetter) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyMultisigTransfer", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
@@ -168,7 +168,7 @@ func (mr *MockFxMockRecorder) VerifyTransfer(arg0, arg1, arg2, arg3 interface{})
 }
 
 // VerifyMultisigPermission mocks base method.
-func (m *MockFx) VerifyMultisigPermission(arg0, arg1, arg2, arg3, arg4 interface{}) error {
+func (m *MockFx) VerifyMultisigPermission(arg0 secp256k1fx.UnsignedTx, arg1 *secp256k1fx.Input, arg2 secp256k1fx.CredentialIntf, arg3 secp256k1fx.MultisigOwners, arg4 secp256k1fx.AliasGetter) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyMultisigPermission", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
@@ -182,7 +182,7 @@ func (mr *MockFxMockRecorder) VerifyMultisigPermission(arg0, arg1, arg2, arg3, a
 }
 
 // VerifyMultisigUnorderedPermission mocks base method.
-func (m *MockFx) VerifyMultisigUnorderedPermission(arg0, arg1, arg2, arg3 interface{}) error {
+func (m *MockFx) VerifyMultisigUnorderedPermission(arg0 secp256k1fx.UnsignedTx, arg1 []verify.Verifiable, arg2 secp256k1fx.MultisigOwners, arg3 secp256k1fx.AliasGetter) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "VerifyMultisigUnorderedPermission", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
@@ -4,14 +4,18 @@
 package genesis
 
 import (
+	"errors"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
+var errWrongDepositOfferExtensionsNumber = errors.New("number of deposit offer extensions doesn't match number of deposit offers")
+
 // Camino genesis args
 type Camino struct {
 	VerifyNodeSignature      bool                     `serialize:"true"`
@@ -22,10 +26,45 @@ type Camino struct {
 	Blocks                   []*Block                 `serialize:"true"` // arranged in a block order
 	ConsortiumMembersNodeIDs []ConsortiumMemberNodeID `serialize:"true"`
 	MultisigAliases          []*multisig.Alias        `serialize:"true"`
+	// Offer fields, that aren't serialized as part of offer, arranged in DepositOffers order.
+	// Empty, if all offers have empty extensions.
+	DepositOfferExtensions []deposit.OfferExtension `serializeV1:"true"`
+}
+
+// SetDepositOffers sets genesis deposit offers to [offers] and their extensions,
+// if any of them isn't empty.
+func (c *Camino) SetDepositOffers(offers []*deposit.Offer) {
+	c.DepositOffers = offers
+	c.DepositOfferExtensions = nil
+
+	extensions := make([]deposit.OfferExtension, len(offers))
+	hasExtensions := false
+	for i, offer := range offers {
+		extensions[i] = offer.Extension()
+		hasExtensions = hasExtensions || !extensions[i].IsEmpty()
+	}
+	if hasExtensions {
+		c.DepositOfferExtensions = extensions
+	}
+}
+
+// CodecVersion returns codec version, that must be used to serialize genesis with these args.
+// Extensions version is only used, if there are deposit offer extensions.
+func (c *Camino) CodecVersion() uint16 {
+	if len(c.DepositOfferExtensions) != 0 {
+		return ExtensionsVersion
+	}
+	return Version
 }
 
 func (c *Camino) Init() error {
-	for _, offer := range c.DepositOffers {
+	if len(c.DepositOfferExtensions) != 0 && len(c.DepositOfferExtensions) != len(c.DepositOffers) {
+		return errWrongDepositOfferExtensionsNumber
+	}
+	for i, offer := range c.DepositOffers {
+		if len(c.DepositOfferExtensions) != 0 {
+			c.DepositOfferExtensions[i].ApplyTo(offer)
+		}
 		if err := offer.SetID(); err != nil {
 			return err
 		}
@@ -44,8 +83,8 @@ type ConsortiumMemberNodeID struct {
 }
 
 type AddressState struct {
-	Address ids.ShortID `serialize:"true"`
-	State   uint64      `serialize:"true"`
+	Address ids.ShortID     `serialize:"true"`
+	State   as.AddressState `serialize:"true"`
 }
 
 type Block struct {
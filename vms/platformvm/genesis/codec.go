@@ -7,6 +7,9 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 )
 
-const Version = blocks.Version
+const (
+	Version           = blocks.Version
+	ExtensionsVersion = blocks.GenesisExtensionsVersion
+)
 
 var Codec = blocks.GenesisCodec
@@ -10,11 +10,17 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
+// MaxLabelLen is max length of locked out label
+const MaxLabelLen = 64
+
 var (
 	errInvalidLockState = errors.New("invalid lockState")
 	errNestedLocks      = errors.New("shouldn't nest locks")
+	errEmptyLabel       = errors.New("labeled out has empty label")
+	errLabelTooLong     = errors.New("labeled out label is too long")
 	ThisTxID            = ids.ID{'t', 'h', 'i', 's', ' ', 't', 'x', ' ', 'i', 'd'}
 )
 
@@ -25,6 +31,8 @@ const (
 	StateDeposited       State = 0b01
 	StateBonded          State = 0b10
 	StateDepositedBonded State = 0b11
+	// Escrowed funds are held by escrow tx and can't be deposited or bonded
+	StateEscrowed State = 0b100
 )
 
 var stateStrings = map[State]string{
@@ -32,6 +40,7 @@ var stateStrings = map[State]string{
 	StateDeposited:       "deposited",
 	StateBonded:          "bonded",
 	StateDepositedBonded: "depositedBonded",
+	StateEscrowed:        "escrowed",
 }
 
 func (ls State) String() string {
@@ -43,7 +52,7 @@ func (ls State) String() string {
 }
 
 func (ls State) Verify() error {
-	if ls < StateUnlocked || StateDepositedBonded < ls {
+	if (ls < StateUnlocked || StateDepositedBonded < ls) && ls != StateEscrowed {
 		return errInvalidLockState
 	}
 	return nil
@@ -61,16 +70,26 @@ func (ls State) IsDepositedBonded() bool {
 	return StateDepositedBonded&ls == StateDepositedBonded
 }
 
+func (ls State) IsEscrowed() bool {
+	return StateEscrowed&ls == StateEscrowed
+}
+
 /**********************  IDs *********************/
 
 type IDs struct {
 	DepositTxID ids.ID `serialize:"true" json:"depositTxID"`
 	BondTxID    ids.ID `serialize:"true" json:"bondTxID"`
+	// EscrowTxID isn't serialized as part of IDs, escrowed ins and outs
+	// are serialized as EscrowIn and EscrowOut.
+	EscrowTxID ids.ID `json:"-"`
 }
 
-var IDsEmpty = IDs{ids.Empty, ids.Empty}
+var IDsEmpty = IDs{}
 
 func (lock IDs) LockState() State {
+	if lock.EscrowTxID != ids.Empty {
+		return StateEscrowed
+	}
 	lockState := StateUnlocked
 	if lock.DepositTxID != ids.Empty {
 		lockState = StateDeposited
@@ -88,6 +107,9 @@ func (lock IDs) Lock(lockState State) IDs {
 	if lockState.IsBonded() {
 		lock.BondTxID = ThisTxID
 	}
+	if lockState.IsEscrowed() {
+		lock.EscrowTxID = ThisTxID
+	}
 	return lock
 }
 
@@ -98,6 +120,9 @@ func (lock IDs) Unlock(lockState State) IDs {
 	if lockState.IsBonded() {
 		lock.BondTxID = ids.Empty
 	}
+	if lockState.IsEscrowed() {
+		lock.EscrowTxID = ids.Empty
+	}
 	return lock
 }
 
@@ -111,11 +136,15 @@ func (lock *IDs) FixLockID(txID ids.ID, appliedLockState State) {
 		if lock.BondTxID == ThisTxID {
 			lock.BondTxID = txID
 		}
+	case StateEscrowed:
+		if lock.EscrowTxID == ThisTxID {
+			lock.EscrowTxID = txID
+		}
 	}
 }
 
 func (lock IDs) IsLocked() bool {
-	return lock.DepositTxID != ids.Empty || lock.BondTxID != ids.Empty
+	return lock.DepositTxID != ids.Empty || lock.BondTxID != ids.Empty || lock.EscrowTxID != ids.Empty
 }
 
 func (lock IDs) IsLockedWith(lockState State) bool {
@@ -130,6 +159,8 @@ func (lock IDs) IsNewlyLockedWith(lockState State) bool {
 		return lock.BondTxID == ThisTxID
 	case StateDepositedBonded:
 		return lock.DepositTxID == ThisTxID && lock.BondTxID == ThisTxID
+	case StateEscrowed:
+		return lock.EscrowTxID == ThisTxID
 	}
 	return false
 }
@@ -142,6 +173,8 @@ func (lock *IDs) Match(lockState State, txIDs set.Set[ids.ID]) bool {
 		return txIDs.Contains(lock.BondTxID)
 	case StateDepositedBonded:
 		return lock.BondTxID == lock.DepositTxID && txIDs.Contains(lock.DepositTxID)
+	case StateEscrowed:
+		return txIDs.Contains(lock.EscrowTxID)
 	}
 	return false
 }
@@ -161,7 +194,8 @@ func (out *Out) Addresses() [][]byte {
 }
 
 func (out *Out) Verify() error {
-	if _, nested := out.TransferableOut.(*Out); nested {
+	switch out.TransferableOut.(type) {
+	case *Out, *EscrowOut, *LabeledOut:
 		return errNestedLocks
 	}
 	return out.TransferableOut.Verify()
@@ -173,8 +207,128 @@ type In struct {
 }
 
 func (in *In) Verify() error {
-	if _, nested := in.TransferableIn.(*In); nested {
+	switch in.TransferableIn.(type) {
+	case *In, *EscrowIn:
+		return errNestedLocks
+	}
+	return in.TransferableIn.Verify()
+}
+
+/**********************  Escrow In / Out *********************/
+
+// EscrowOut is output escrowed by EscrowTxID
+type EscrowOut struct {
+	EscrowTxID           ids.ID `serialize:"true" json:"escrowTxID"`
+	avax.TransferableOut `serialize:"true" json:"output"`
+}
+
+func (out *EscrowOut) Addresses() [][]byte {
+	if addressable, ok := out.TransferableOut.(avax.Addressable); ok {
+		return addressable.Addresses()
+	}
+	return nil
+}
+
+func (out *EscrowOut) Verify() error {
+	switch out.TransferableOut.(type) {
+	case *Out, *EscrowOut, *LabeledOut:
+		return errNestedLocks
+	}
+	return out.TransferableOut.Verify()
+}
+
+// EscrowIn is input, that consumes output escrowed by EscrowTxID
+type EscrowIn struct {
+	EscrowTxID          ids.ID `serialize:"true" json:"escrowTxID"`
+	avax.TransferableIn `serialize:"true" json:"input"`
+}
+
+func (in *EscrowIn) Verify() error {
+	switch in.TransferableIn.(type) {
+	case *In, *EscrowIn:
 		return errNestedLocks
 	}
 	return in.TransferableIn.Verify()
 }
+
+/**********************  Labeled Out *********************/
+
+// LabeledOut is locked out with short human-readable label, e.g. "bond:node-7".
+// Label is preserved, when out lock state changes, but out stays locked.
+// Inputs consuming labeled out are ordinary locked ins.
+type LabeledOut struct {
+	Label string `serialize:"true" json:"label"`
+	Out   `serialize:"true"`
+}
+
+func (out *LabeledOut) Verify() error {
+	switch {
+	case len(out.Label) == 0:
+		return errEmptyLabel
+	case len(out.Label) > MaxLabelLen:
+		return fmt.Errorf("%w: %d > %d", errLabelTooLong, len(out.Label), MaxLabelLen)
+	}
+	return out.Out.Verify()
+}
+
+// AsOut returns [out] as *Out and true, if [out] is Out or LabeledOut, otherwise false.
+func AsOut(out verify.State) (*Out, bool) {
+	switch out := out.(type) {
+	case *Out:
+		return out, true
+	case *LabeledOut:
+		return &out.Out, true
+	}
+	return nil, false
+}
+
+// OutLabel returns label of [out], if its LabeledOut, otherwise empty string.
+func OutLabel(out verify.State) string {
+	if labeledOut, ok := out.(*LabeledOut); ok {
+		return labeledOut.Label
+	}
+	return ""
+}
+
+// WithLabel returns locked [out] with [label], if [label] isn't empty and [out] is Out,
+// otherwise returns [out] unchanged.
+func WithLabel(out avax.TransferableOut, label string) avax.TransferableOut {
+	if lockedOut, ok := out.(*Out); ok && label != "" {
+		return &LabeledOut{Label: label, Out: *lockedOut}
+	}
+	return out
+}
+
+// NewOut returns [out] locked with [lockIDs]: escrowed out is EscrowOut, other locked out is Out.
+//
+// Precondition: [lockIDs] are locked.
+func NewOut(lockIDs IDs, out avax.TransferableOut) avax.TransferableOut {
+	if lockIDs.EscrowTxID != ids.Empty {
+		return &EscrowOut{EscrowTxID: lockIDs.EscrowTxID, TransferableOut: out}
+	}
+	return &Out{IDs: lockIDs, TransferableOut: out}
+}
+
+// NewIn returns [in] locked with [lockIDs]: escrowed in is EscrowIn, other locked in is In.
+//
+// Precondition: [lockIDs] are locked.
+func NewIn(lockIDs IDs, in avax.TransferableIn) avax.TransferableIn {
+	if lockIDs.EscrowTxID != ids.Empty {
+		return &EscrowIn{EscrowTxID: lockIDs.EscrowTxID, TransferableIn: in}
+	}
+	return &In{IDs: lockIDs, TransferableIn: in}
+}
+
+// OutIDs returns lock ids and inner out of locked [out] and true,
+// or false, if [out] is neither Out, LabeledOut nor EscrowOut.
+func OutIDs(out verify.State) (IDs, avax.TransferableOut, bool) {
+	switch out := out.(type) {
+	case *Out:
+		return out.IDs, out.TransferableOut, true
+	case *LabeledOut:
+		return out.IDs, out.TransferableOut, true
+	case *EscrowOut:
+		return IDs{EscrowTxID: out.EscrowTxID}, out.TransferableOut, true
+	}
+	return IDsEmpty, nil, false
+}
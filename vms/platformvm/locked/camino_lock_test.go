@@ -0,0 +1,110 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package locked
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestStateVerify(t *testing.T) {
+	require := require.New(t)
+	for _, lockState := range []State{
+		StateUnlocked,
+		StateDeposited,
+		StateBonded,
+		StateDepositedBonded,
+		StateEscrowed,
+	} {
+		require.NoError(lockState.Verify())
+	}
+	require.ErrorIs((StateEscrowed | StateDeposited).Verify(), errInvalidLockState)
+}
+
+func TestIDsEscrow(t *testing.T) {
+	require := require.New(t)
+	escrowTxID := ids.ID{1}
+
+	lockIDs := IDsEmpty.Lock(StateEscrowed)
+	require.Equal(IDs{EscrowTxID: ThisTxID}, lockIDs)
+	require.True(lockIDs.IsLocked())
+	require.True(lockIDs.IsNewlyLockedWith(StateEscrowed))
+	require.Equal(StateEscrowed, lockIDs.LockState())
+	require.True(lockIDs.IsLockedWith(StateEscrowed))
+	require.False(lockIDs.IsLockedWith(StateDeposited))
+
+	lockIDs.FixLockID(escrowTxID, StateEscrowed)
+	require.Equal(IDs{EscrowTxID: escrowTxID}, lockIDs)
+	require.True(lockIDs.Match(StateEscrowed, set.Set[ids.ID]{escrowTxID: struct{}{}}))
+	require.False(lockIDs.Match(StateDeposited, set.Set[ids.ID]{escrowTxID: struct{}{}}))
+
+	require.Equal(IDsEmpty, lockIDs.Unlock(StateEscrowed))
+}
+
+func TestNewOutAndOutIDs(t *testing.T) {
+	require := require.New(t)
+	innerOut := &secp256k1fx.TransferOutput{Amt: 1}
+
+	escrowIDs := IDs{EscrowTxID: ids.ID{1}}
+	escrowOut := NewOut(escrowIDs, innerOut)
+	require.Equal(&EscrowOut{EscrowTxID: ids.ID{1}, TransferableOut: innerOut}, escrowOut)
+	lockIDs, out, ok := OutIDs(escrowOut)
+	require.True(ok)
+	require.Equal(escrowIDs, lockIDs)
+	require.Equal(innerOut, out)
+
+	depositIDs := IDs{DepositTxID: ids.ID{2}}
+	depositOut := NewOut(depositIDs, innerOut)
+	require.Equal(&Out{IDs: depositIDs, TransferableOut: innerOut}, depositOut)
+	lockIDs, out, ok = OutIDs(depositOut)
+	require.True(ok)
+	require.Equal(depositIDs, lockIDs)
+	require.Equal(innerOut, out)
+
+	_, _, ok = OutIDs(innerOut)
+	require.False(ok)
+
+	require.ErrorIs((&EscrowOut{TransferableOut: depositOut}).Verify(), errNestedLocks)
+	require.ErrorIs((&Out{TransferableOut: escrowOut}).Verify(), errNestedLocks)
+}
+
+func TestLabeledOut(t *testing.T) {
+	require := require.New(t)
+	innerOut := &secp256k1fx.TransferOutput{Amt: 1}
+	bondIDs := IDs{BondTxID: ids.ID{1}}
+	bondOut := &Out{IDs: bondIDs, TransferableOut: innerOut}
+
+	labeledOut := WithLabel(bondOut, "bond:node-7")
+	require.Equal(&LabeledOut{Label: "bond:node-7", Out: *bondOut}, labeledOut)
+	require.NoError(labeledOut.Verify())
+	require.Equal("bond:node-7", OutLabel(labeledOut))
+	require.Empty(OutLabel(bondOut))
+
+	out, ok := AsOut(labeledOut)
+	require.True(ok)
+	require.Equal(bondOut, out)
+	_, ok = AsOut(innerOut)
+	require.False(ok)
+
+	lockIDs, out2, ok := OutIDs(labeledOut)
+	require.True(ok)
+	require.Equal(bondIDs, lockIDs)
+	require.Equal(innerOut, out2)
+
+	// not locked or empty label outs aren't labeled
+	require.Equal(innerOut, WithLabel(innerOut, "label"))
+	require.Equal(bondOut, WithLabel(bondOut, ""))
+
+	require.ErrorIs((&LabeledOut{Out: *bondOut}).Verify(), errEmptyLabel)
+	require.ErrorIs((&LabeledOut{
+		Label: string(make([]byte, MaxLabelLen+1)),
+		Out:   *bondOut,
+	}).Verify(), errLabelTooLong)
+	require.ErrorIs((&Out{TransferableOut: labeledOut}).Verify(), errNestedLocks)
+}
@@ -39,7 +39,7 @@ func VerifyLockMode(
 		for _, output := range outs {
 			out := output.Out
 
-			if outerOut, ok := out.(*Out); ok {
+			if outerOut, ok := AsOut(out); ok {
 				out = outerOut.TransferableOut
 			}
 
@@ -58,7 +58,8 @@ func VerifyLockMode(
 			in = outerIn.TransferableIn
 		}
 
-		if _, ok := in.(*In); ok {
+		switch in.(type) {
+		case *In, *EscrowIn:
 			return ErrWrongInType
 		}
 	}
@@ -70,7 +71,8 @@ func VerifyLockMode(
 			out = outerOut.TransferableOut
 		}
 
-		if _, ok := out.(*Out); ok {
+		switch out.(type) {
+		case *Out, *EscrowOut, *LabeledOut:
 			return ErrWrongOutType
 		}
 	}
@@ -78,25 +80,21 @@ func VerifyLockMode(
 	return nil
 }
 
-// Verifies that [ins] and [outs] aren't stakeable or locked types.
+// Verifies that [ins] and [outs] aren't stakeable, locked or escrowed types.
 func VerifyNoLocks(
 	ins []*avax.TransferableInput,
 	outs []*avax.TransferableOutput,
 ) error {
 	for _, input := range ins {
-		if _, ok := input.In.(*In); ok {
-			return ErrWrongInType
-		}
-		if _, ok := input.In.(*stakeable.LockIn); ok {
+		switch input.In.(type) {
+		case *In, *EscrowIn, *stakeable.LockIn:
 			return ErrWrongInType
 		}
 	}
 
 	for _, output := range outs {
-		if _, ok := output.Out.(*Out); ok {
-			return ErrWrongOutType
-		}
-		if _, ok := output.Out.(*stakeable.LockOut); ok {
+		switch output.Out.(type) {
+		case *Out, *EscrowOut, *LabeledOut, *stakeable.LockOut:
 			return ErrWrongOutType
 		}
 	}
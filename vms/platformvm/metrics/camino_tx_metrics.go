@@ -18,7 +18,16 @@ type caminoTxMetrics struct {
 	numUnlockDepositTxs,
 	numClaimTxs,
 	numRegisterNodeTxs,
-	numRewardsImportTxs prometheus.Counter
+	numRewardsImportTxs,
+	numBaseTxs,
+	numAddressStateBatchTxs,
+	numRenewDepositTxs,
+	numDepositRewardsOwnerTxs,
+	numSplitDepositTxs,
+	numMultisigAliasTxs,
+	numRotateMultisigAliasTxs,
+	numDepositOfferTxs,
+	numClaimDepositTxs prometheus.Counter
 }
 
 func newCaminoTxMetrics(
@@ -34,12 +43,21 @@ func newCaminoTxMetrics(
 	m := &caminoTxMetrics{
 		txMetrics: *txm,
 		// Camino specific tx metrics
-		numAddressStateTxs:  newTxMetric(namespace, "add_address_state", registerer, &errs),
-		numDepositTxs:       newTxMetric(namespace, "deposit", registerer, &errs),
-		numUnlockDepositTxs: newTxMetric(namespace, "unlock_deposit", registerer, &errs),
-		numClaimTxs:         newTxMetric(namespace, "claim", registerer, &errs),
-		numRegisterNodeTxs:  newTxMetric(namespace, "register_node", registerer, &errs),
-		numRewardsImportTxs: newTxMetric(namespace, "rewards_import", registerer, &errs),
+		numAddressStateTxs:        newTxMetric(namespace, "add_address_state", registerer, &errs),
+		numDepositTxs:             newTxMetric(namespace, "deposit", registerer, &errs),
+		numUnlockDepositTxs:       newTxMetric(namespace, "unlock_deposit", registerer, &errs),
+		numClaimTxs:               newTxMetric(namespace, "claim", registerer, &errs),
+		numRegisterNodeTxs:        newTxMetric(namespace, "register_node", registerer, &errs),
+		numRewardsImportTxs:       newTxMetric(namespace, "rewards_import", registerer, &errs),
+		numBaseTxs:                newTxMetric(namespace, "base", registerer, &errs),
+		numAddressStateBatchTxs:   newTxMetric(namespace, "address_state_batch", registerer, &errs),
+		numRenewDepositTxs:        newTxMetric(namespace, "renew_deposit", registerer, &errs),
+		numDepositRewardsOwnerTxs: newTxMetric(namespace, "deposit_rewards_owner", registerer, &errs),
+		numSplitDepositTxs:        newTxMetric(namespace, "split_deposit", registerer, &errs),
+		numMultisigAliasTxs:       newTxMetric(namespace, "multisig_alias", registerer, &errs),
+		numRotateMultisigAliasTxs: newTxMetric(namespace, "rotate_multisig_alias", registerer, &errs),
+		numDepositOfferTxs:        newTxMetric(namespace, "deposit_offer", registerer, &errs),
+		numClaimDepositTxs:        newTxMetric(namespace, "claim_deposit", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -70,6 +88,42 @@ func (*txMetrics) RewardsImportTx(*txs.RewardsImportTx) error {
 	return nil
 }
 
+func (*txMetrics) BaseTx(*txs.BaseTx) error {
+	return nil
+}
+
+func (*txMetrics) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	return nil
+}
+
+func (*txMetrics) RenewDepositTx(*txs.RenewDepositTx) error {
+	return nil
+}
+
+func (*txMetrics) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	return nil
+}
+
+func (*txMetrics) SplitDepositTx(*txs.SplitDepositTx) error {
+	return nil
+}
+
+func (*txMetrics) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	return nil
+}
+
+func (*txMetrics) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	return nil
+}
+
+func (*txMetrics) DepositOfferTx(*txs.DepositOfferTx) error {
+	return nil
+}
+
+func (*txMetrics) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	return nil
+}
+
 // camino metrics
 
 func (m *caminoTxMetrics) AddressStateTx(*txs.AddressStateTx) error {
@@ -101,3 +155,48 @@ func (m *caminoTxMetrics) RewardsImportTx(*txs.RewardsImportTx) error {
 	m.numRegisterNodeTxs.Inc()
 	return nil
 }
+
+func (m *caminoTxMetrics) BaseTx(*txs.BaseTx) error {
+	m.numBaseTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	m.numAddressStateBatchTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) RenewDepositTx(*txs.RenewDepositTx) error {
+	m.numRenewDepositTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	m.numDepositRewardsOwnerTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) SplitDepositTx(*txs.SplitDepositTx) error {
+	m.numSplitDepositTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	m.numMultisigAliasTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	m.numRotateMultisigAliasTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) DepositOfferTx(*txs.DepositOfferTx) error {
+	m.numDepositOfferTxs.Inc()
+	return nil
+}
+
+func (m *caminoTxMetrics) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	m.numClaimDepositTxs.Inc()
+	return nil
+}
@@ -0,0 +1,222 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package msig
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errAlreadySigned = errors.New("signer already signed")
+
+// SigningRequest is a request for [Signer] to sign [TxHash],
+// which is needed for credentials with [CredIndices].
+type SigningRequest struct {
+	Signer      ids.ShortID
+	TxHash      []byte
+	CredIndices []int
+}
+
+// CredentialProgress is a signing progress of credential with [CredIndex].
+type CredentialProgress struct {
+	CredIndex int
+	// Owners members, that already signed, sorted by address
+	Signed       []ids.ShortID
+	ThresholdMet bool
+}
+
+// Coordinator collects signatures of multisig owners for unsigned tx
+// and builds tx credentials, when owners thresholds are met.
+// Coordinator isn't safe for concurrent use.
+type Coordinator struct {
+	txHash  []byte
+	factory crypto.FactorySECP256K1R
+	msig    secp256k1fx.AliasGetter
+	time    uint64
+	owners  []*secp256k1fx.OutputOwners
+	// members of owners with the same index, that can sign at [time]
+	members []set.Set[ids.ShortID]
+	// signer address -> signature of txHash
+	sigs map[ids.ShortID][crypto.SECP256K1RSigLen]byte
+}
+
+// NewCoordinator returns signing coordinator for [utx], which credentials must prove [owners]
+// with the same index. Multisig aliases are resolved with [msig] and time-locked alias members
+// must be active at [time].
+func NewCoordinator(
+	utx secp256k1fx.UnsignedTx,
+	owners []*secp256k1fx.OutputOwners,
+	msig secp256k1fx.AliasGetter,
+	time uint64,
+) (*Coordinator, error) {
+	c := &Coordinator{
+		txHash:  hashing.ComputeHash256(utx.Bytes()),
+		msig:    msig,
+		time:    time,
+		owners:  owners,
+		members: make([]set.Set[ids.ShortID], len(owners)),
+		sigs:    map[ids.ShortID][crypto.SECP256K1RSigLen]byte{},
+	}
+
+	for i, credOwners := range owners {
+		members := set.Set[ids.ShortID]{}
+		// nothing is verified, so every active member is visited
+		_, err := secp256k1fx.TraverseOwners(credOwners, msig, 0, time, func(addr ids.ShortID, _, _ uint32) (bool, error) {
+			members.Add(addr)
+			return false, nil
+		})
+		if err != nil && err != secp256k1fx.ErrThresholdNotMet {
+			return nil, fmt.Errorf("credential %d: %w", i, err)
+		}
+		c.members[i] = members
+	}
+	return c, nil
+}
+
+// SigningRequests returns requests for members, that didn't sign yet, of owners,
+// which threshold isn't met yet. Requests are sorted by signer address.
+func (c *Coordinator) SigningRequests() ([]SigningRequest, error) {
+	requests := map[ids.ShortID]*SigningRequest{}
+	for i, members := range c.members {
+		thresholdMet, err := c.thresholdMet(i)
+		if err != nil {
+			return nil, err
+		}
+		if thresholdMet {
+			continue
+		}
+		for signer := range members {
+			if _, signed := c.sigs[signer]; signed {
+				continue
+			}
+			request, ok := requests[signer]
+			if !ok {
+				request = &SigningRequest{Signer: signer, TxHash: c.txHash}
+				requests[signer] = request
+			}
+			request.CredIndices = append(request.CredIndices, i)
+		}
+	}
+
+	signers := make([]ids.ShortID, 0, len(requests))
+	for signer := range requests {
+		signers = append(signers, signer)
+	}
+	utils.Sort(signers)
+
+	sortedRequests := make([]SigningRequest, len(signers))
+	for i, signer := range signers {
+		sortedRequests[i] = *requests[signer]
+	}
+	return sortedRequests, nil
+}
+
+// AddSignature validates [sig] of tx hash and returns its signer address.
+// Signer must be active member of at least one of owners.
+func (c *Coordinator) AddSignature(sig []byte) (ids.ShortID, error) {
+	pk, err := c.factory.RecoverHashPublicKey(c.txHash, sig)
+	if err != nil {
+		return ids.ShortEmpty, err
+	}
+	signer := pk.Address()
+
+	if _, signed := c.sigs[signer]; signed {
+		return ids.ShortEmpty, fmt.Errorf("%w: %s", errAlreadySigned, signer)
+	}
+
+	isMember := false
+	for _, members := range c.members {
+		if members.Contains(signer) {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		return ids.ShortEmpty, fmt.Errorf("%w: %s", secp256k1fx.ErrSignerNotInAlias, signer)
+	}
+
+	var fixedSig [crypto.SECP256K1RSigLen]byte
+	copy(fixedSig[:], sig)
+	c.sigs[signer] = fixedSig
+	return signer, nil
+}
+
+// Progress returns signing progress of every credential.
+func (c *Coordinator) Progress() ([]CredentialProgress, error) {
+	progress := make([]CredentialProgress, len(c.members))
+	for i, members := range c.members {
+		thresholdMet, err := c.thresholdMet(i)
+		if err != nil {
+			return nil, err
+		}
+		signed := []ids.ShortID{}
+		for signer := range c.sigs {
+			if members.Contains(signer) {
+				signed = append(signed, signer)
+			}
+		}
+		utils.Sort(signed)
+		progress[i] = CredentialProgress{
+			CredIndex:    i,
+			Signed:       signed,
+			ThresholdMet: thresholdMet,
+		}
+	}
+	return progress, nil
+}
+
+// Credentials returns tx credentials, built from collected signatures.
+// Credentials contain their own signature indices, so tx inputs signature
+// indices are overridden by them. Every owners threshold must be met.
+func (c *Coordinator) Credentials() ([]verify.Verifiable, error) {
+	creds := make([]verify.Verifiable, len(c.owners))
+	for i, owners := range c.owners {
+		cred := &secp256k1fx.MultisigCredential{}
+		totalVerified, err := secp256k1fx.TraverseOwners(owners, c.msig, 0, c.time, func(addr ids.ShortID, totalVisited, totalVerified uint32) (bool, error) {
+			sig, signed := c.sigs[addr]
+			if !signed {
+				return false, nil
+			}
+			// nested alias, that didn't meet threshold, could leave signatures
+			if totalVerified < uint32(len(cred.Sigs)) {
+				cred.Sigs = cred.Sigs[:totalVerified]
+				cred.SigIdxs = cred.SigIdxs[:totalVerified]
+			}
+			cred.Sigs = append(cred.Sigs, sig)
+			cred.SigIdxs = append(cred.SigIdxs, totalVisited)
+			return true, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("credential %d: %w", i, err)
+		}
+		cred.Sigs = cred.Sigs[:totalVerified]
+		cred.SigIdxs = cred.SigIdxs[:totalVerified]
+		creds[i] = cred
+	}
+	return creds, nil
+}
+
+// thresholdMet returns true, if collected signatures meet threshold of owners with index [i]
+func (c *Coordinator) thresholdMet(i int) (bool, error) {
+	_, err := secp256k1fx.TraverseOwners(c.owners[i], c.msig, 0, c.time, func(addr ids.ShortID, _, _ uint32) (bool, error) {
+		_, signed := c.sigs[addr]
+		return signed, nil
+	})
+	switch err {
+	case nil:
+		return true, nil
+	case secp256k1fx.ErrThresholdNotMet:
+		return false, nil
+	default:
+		return false, fmt.Errorf("credential %d: %w", i, err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package msig
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCoordinator(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	factory := crypto.FactorySECP256K1R{}
+	keys := make([]*crypto.PrivateKeySECP256K1R, 4)
+	addrs := make([]ids.ShortID, len(keys))
+	for i := range keys {
+		key, err := factory.NewPrivateKey()
+		require.NoError(err)
+		keys[i] = key.(*crypto.PrivateKeySECP256K1R)
+		addrs[i] = keys[i].Address()
+	}
+	aliasID := ids.ShortID{0xa1}
+	aliasAddrs := []ids.ShortID{addrs[1], addrs[2]}
+	utils.Sort(aliasAddrs)
+	owner0Addrs := []ids.ShortID{aliasID, addrs[0]}
+	utils.Sort(owner0Addrs)
+
+	aliases := map[ids.ShortID]*multisig.Alias{
+		aliasID: {
+			ID: aliasID,
+			Owners: &secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     aliasAddrs,
+			},
+		},
+	}
+	msig := secp256k1fx.NewMockAliasGetter(ctrl)
+	msig.EXPECT().GetMultisigAlias(gomock.Any()).DoAndReturn(
+		func(addr ids.ShortID) (*multisig.Alias, error) {
+			if alias, ok := aliases[addr]; ok {
+				return alias, nil
+			}
+			return nil, database.ErrNotFound
+		}).AnyTimes()
+
+	// credential 0: alias (addrs[1] and addrs[2]) or addrs[0]
+	// credential 1: addrs[3]
+	owners := []*secp256k1fx.OutputOwners{
+		{Threshold: 1, Addrs: owner0Addrs},
+		{Threshold: 1, Addrs: []ids.ShortID{addrs[3]}},
+	}
+	utx := &secp256k1fx.TestTx{UnsignedBytes: []byte{1, 2, 3}}
+	txHash := hashing.ComputeHash256(utx.Bytes())
+
+	coordinator, err := NewCoordinator(utx, owners, msig, 0)
+	require.NoError(err)
+
+	requests, err := coordinator.SigningRequests()
+	require.NoError(err)
+	require.Len(requests, len(addrs))
+	for _, request := range requests {
+		require.Equal(txHash, request.TxHash)
+		if request.Signer == addrs[3] {
+			require.Equal([]int{1}, request.CredIndices)
+		} else {
+			require.Equal([]int{0}, request.CredIndices)
+		}
+	}
+
+	sign := func(key *crypto.PrivateKeySECP256K1R) []byte {
+		sig, err := key.SignHash(txHash)
+		require.NoError(err)
+		return sig
+	}
+
+	// non-member signature
+	nonMemberKey, err := factory.NewPrivateKey()
+	require.NoError(err)
+	_, err = coordinator.AddSignature(sign(nonMemberKey.(*crypto.PrivateKeySECP256K1R)))
+	require.ErrorIs(err, secp256k1fx.ErrSignerNotInAlias)
+
+	// alias threshold isn't met with one signature
+	signer, err := coordinator.AddSignature(sign(keys[1]))
+	require.NoError(err)
+	require.Equal(addrs[1], signer)
+	_, err = coordinator.AddSignature(sign(keys[1]))
+	require.ErrorIs(err, errAlreadySigned)
+
+	progress, err := coordinator.Progress()
+	require.NoError(err)
+	require.Equal([]CredentialProgress{
+		{CredIndex: 0, Signed: []ids.ShortID{addrs[1]}},
+		{CredIndex: 1, Signed: []ids.ShortID{}},
+	}, progress)
+
+	_, err = coordinator.Credentials()
+	require.ErrorIs(err, secp256k1fx.ErrThresholdNotMet)
+
+	_, err = coordinator.AddSignature(sign(keys[2]))
+	require.NoError(err)
+	_, err = coordinator.AddSignature(sign(keys[3]))
+	require.NoError(err)
+
+	progress, err = coordinator.Progress()
+	require.NoError(err)
+	for _, credProgress := range progress {
+		require.True(credProgress.ThresholdMet)
+	}
+
+	requests, err = coordinator.SigningRequests()
+	require.NoError(err)
+	require.Empty(requests)
+
+	creds, err := coordinator.Credentials()
+	require.NoError(err)
+	require.Len(creds, len(owners))
+
+	vm := secp256k1fx.TestVM{
+		Codec: linearcodec.NewDefault(),
+		Log:   logging.NoLog{},
+	}
+	fx := secp256k1fx.Fx{}
+	require.NoError(fx.Initialize(&vm))
+	require.NoError(fx.Bootstrapped())
+	for i, cred := range creds {
+		multisigCred, ok := cred.(*secp256k1fx.MultisigCredential)
+		require.True(ok)
+		in := &secp256k1fx.Input{SigIndices: multisigCred.SigIdxs}
+		require.NoError(fx.VerifyMultisigPermission(utx, in, multisigCred, owners[i], msig))
+	}
+}
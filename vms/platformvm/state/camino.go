@@ -6,6 +6,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/ava-labs/avalanchego/cache"
@@ -15,12 +16,14 @@ import (
 	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	choices "github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/bloom"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
@@ -32,24 +35,41 @@ import (
 )
 
 const (
-	addressStateCacheSize = 1024
-	depositsCacheSize     = 1024
-	shortLinksCacheSize   = 1024
-	msigOwnersCacheSize   = 16_384
-	claimablesCacheSize   = 1024
+	addressStateCacheSize      = 1024
+	defaultDepositsCacheSize   = 1024
+	shortLinksCacheSize        = 1024
+	msigOwnersCacheSize        = 16_384
+	defaultClaimablesCacheSize = 1024
 )
 
 var (
 	_ CaminoState = (*caminoState)(nil)
 
-	caminoPrefix              = []byte("camino")
-	addressStatePrefix        = []byte("addressState")
-	depositOffersPrefix       = []byte("depositOffers")
-	depositsPrefix            = []byte("deposits")
-	depositIDsByEndtimePrefix = []byte("depositIDsByEndtime")
-	multisigOwnersPrefix      = []byte("multisigOwners")
-	shortLinksPrefix          = []byte("shortLinks")
-	claimablesPrefix          = []byte("claimables")
+	caminoPrefix                  = []byte("camino")
+	addressStatePrefix            = []byte("addressState")
+	depositOffersPrefix           = []byte("depositOffers")
+	depositOfferExtensionsPrefix  = []byte("depositOfferExtensions")
+	depositsPrefix                = []byte("deposits")
+	depositIDsByEndtimePrefix     = []byte("depositIDsByEndtime")
+	depositIDsByRewardOwnerPrefix = []byte("depositIDsByRewardOwner")
+	depositIDsByOfferPrefix       = []byte("depositIDsByOffer")
+	depositRewardOwnersPrefix     = []byte("depositRewardOwners")
+	depositClaimsPrefix           = []byte("depositClaims")
+	depositStatsPrefix            = []byte("depositStats")
+	multisigOwnersPrefix          = []byte("multisigOwners")
+	multisigAliasesByMemberPrefix = []byte("multisigAliasesByMember")
+	multisigAliasHistoryPrefix    = []byte("multisigAliasHistory")
+	multisigAliasUsagesPrefix     = []byte("multisigAliasUsages")
+	shortLinksPrefix              = []byte("shortLinks")
+	claimablesPrefix              = []byte("claimables")
+	claimableRewardAssetsPrefix   = []byte("claimableRewardAssets")
+	utxoIDsByOwnerPrefix          = []byte("utxoIDsByOwner")
+	utxoCountByOwnerPrefix        = []byte("utxoCountByOwner")
+	utxoIDsByLockTxPrefix         = []byte("utxoIDsByLockTx")
+	archivedDepositOffersPrefix   = []byte("archivedDepositOffers")
+	archivedDepositsPrefix        = []byte("archivedDeposits")
+	caminoHistoryPrefix           = []byte("caminoHistory")
+	caminoHistoryHeightsPrefix    = []byte("caminoHistoryHeights")
 
 	// Used for prefixing the validatorsDB
 	deferredPrefix = []byte("deferred")
@@ -57,21 +77,37 @@ var (
 	nodeSignatureKey                 = []byte("nodeSignature")
 	depositBondModeKey               = []byte("depositBondMode")
 	notDistributedValidatorRewardKey = []byte("notDistributedValidatorReward")
+	utxoOwnerIndexKey                = []byte("utxoOwnerIndex")
+	utxoLockIndexKey                 = []byte("utxoLockIndex")
+	depositRewardOwnerIndexKey       = []byte("depositRewardOwnerIndex")
+	caminoHistoryStartHeightKey      = []byte("caminoHistoryStartHeight")
+	caminoHistoryHeightKey           = []byte("caminoHistoryHeight")
+	caminoSchemaVersionKey           = []byte("caminoSchemaVersion")
+	multisigAliasMemberIndexKey      = []byte("multisigAliasMemberIndex")
+	depositOfferIndexKey             = []byte("depositOfferIndex")
+	depositStatsIndexKey             = []byte("depositStatsIndex")
+	depositOutstandingRewardsKey     = []byte("depositOutstandingRewards")
 
 	errWrongTxType      = errors.New("unexpected tx type")
 	errNonExistingOffer = errors.New("deposit offer doesn't exist")
 	errNotUniqueTx      = errors.New("not unique genesis tx")
+	errWrongOwnerType   = errors.New("wrong owner type")
 )
 
 type CaminoApply interface {
 	ApplyCaminoState(State)
+	// MarshalCaminoDiff returns canonical bytes of camino modifications,
+	// that can be applied with ApplyMarshalledCaminoDiff.
+	MarshalCaminoDiff() ([]byte, error)
 }
 
 type CaminoDiff interface {
 	// Address State
 
-	SetAddressStates(ids.ShortID, uint64)
-	GetAddressStates(ids.ShortID) (uint64, error)
+	SetAddressStates(ids.ShortID, as.AddressState)
+	GetAddressStates(ids.ShortID) (as.AddressState, error)
+	// Returns iterator over all addresses with non-zero states in ascending order of addresses
+	GetAddressStatesIterator() (AddressStatesIterator, error)
 
 	// Deposit offers
 
@@ -91,21 +127,38 @@ type CaminoDiff interface {
 	GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
 	GetNextToUnlockDepositTime(removedDepositIDs set.Set[ids.ID]) (time.Time, error)
 	GetNextToUnlockDepositIDsAndTime(removedDepositIDs set.Set[ids.ID]) ([]ids.ID, time.Time, error)
+	// Returns sorted ids of deposits, which were created with offer [offerID]
+	GetDepositIDsByOffer(offerID ids.ID) ([]ids.ID, error)
+	// claim should never be nil
+	AddDepositClaim(depositTxID ids.ID, claim *deposit.Claim)
+	// Returns claims of deposit [depositTxID] in order of their timestamps
+	GetDepositClaims(depositTxID ids.ID) ([]*deposit.Claim, error)
 
 	// Multisig Owners
 
 	GetMultisigAlias(ids.ShortID) (*multisig.Alias, error)
+	// Returns at most [limit] multisig aliases with ids greater than [start], sorted by alias id.
+	// If [start] is ids.ShortEmpty, starts at beginning.
+	GetMultisigAliases(start ids.ShortID, limit int) ([]*multisig.Alias, error)
+	// GetMultisigAliasIDsByMember returns sorted ids of multisig aliases, which owners contain [member] address.
+	GetMultisigAliasIDsByMember(member ids.ShortID) ([]ids.ShortID, error)
 	SetMultisigAlias(*multisig.Alias)
+	// AddMultisigAliasUsage records, that multisig alias [aliasID] was used to prove owners by tx [txID]
+	AddMultisigAliasUsage(aliasID ids.ShortID, txID ids.ID)
 
 	// ShortIDsLink
 
 	SetShortIDLink(id ids.ShortID, key ShortLinkKey, link *ids.ShortID)
 	GetShortIDLink(id ids.ShortID, key ShortLinkKey) (ids.ShortID, error)
+	// GetShortIDLinks returns all links with [key] as map from id to its link.
+	GetShortIDLinks(key ShortLinkKey) (map[ids.ShortID]ids.ShortID, error)
 
 	// Claimable & rewards
 
 	SetClaimable(ownerID ids.ID, claimable *Claimable)
 	GetClaimable(ownerID ids.ID) (*Claimable, error)
+	// Returns iterator over all claimables in ascending order of owner ids
+	GetClaimablesIterator() (ClaimablesIterator, error)
 	SetNotDistributedValidatorReward(reward uint64)
 	GetNotDistributedValidatorReward() (uint64, error)
 
@@ -122,6 +175,8 @@ type Camino interface {
 	CaminoDiff
 
 	LockedUTXOs(set.Set[ids.ID], set.Set[ids.ShortID], locked.State) ([]*avax.UTXO, error)
+	// Returns sorted ids of deposits, which rewards are owned by [ownerID]
+	GetDepositIDsByRewardOwner(ownerID ids.ID) ([]ids.ID, error)
 	CaminoConfig() (*CaminoConfig, error)
 	Config() (*config.Config, error)
 }
@@ -131,9 +186,25 @@ type CaminoState interface {
 	CaminoDiff
 
 	CaminoConfig() *CaminoConfig
+	OwnerUTXOIDs(ownerID, previous ids.ID, limit int) ([]ids.ID, error)
+	OwnerUTXOsCount(ownerID ids.ID) (uint64, error)
+	GetDepositStats() (*DepositStats, error)
+	GetArchivedDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
+	GetMultisigAliasHistory(aliasID ids.ShortID) ([]*MultisigAliasVersion, error)
+	GetMultisigAliasUsages(aliasID ids.ShortID) ([]*MultisigAliasUsage, error)
+	GetCaminoHistoricalView(height uint64) (CaminoHistoricalView, error)
+	observeAppliedDiff(diff *caminoDiff, duration time.Duration)
+	verifyInvariants(depositedAmounts map[ids.ID]uint64) error
+	dump(w io.Writer) (ids.ID, error)
+	indexUTXO(utxo *avax.UTXO) error
+	unindexUTXO(utxo *avax.UTXO) error
+	lockedUTXOIDs(lockTxID ids.ID) ([]ids.ID, error)
+	indexLockedUTXO(utxo *avax.UTXO) error
+	unindexLockedUTXO(utxo *avax.UTXO) error
+	depositIDsByRewardOwner(s *state, ownerID ids.ID) ([]ids.ID, error)
 	SyncGenesis(*state, *genesis.State) error
 	Load(*state) error
-	Write() error
+	Write(*state) error
 	Close() error
 }
 
@@ -144,10 +215,12 @@ type CaminoConfig struct {
 
 type caminoDiff struct {
 	deferredStakerDiffs                   diffStakers
-	modifiedAddressStates                 map[ids.ShortID]uint64
+	modifiedAddressStates                 map[ids.ShortID]as.AddressState
 	modifiedDepositOffers                 map[ids.ID]*deposit.Offer
 	modifiedDeposits                      map[ids.ID]*depositDiff
+	addedDepositClaims                    map[ids.ID][]*deposit.Claim
 	modifiedMultisigOwners                map[ids.ShortID]*multisig.Alias
+	addedMultisigAliasUsages              map[ids.ShortID][]ids.ID
 	modifiedShortLinks                    map[ids.ID]*ids.ShortID
 	modifiedClaimables                    map[ids.ID]*Claimable
 	modifiedNotDistributedValidatorReward *uint64
@@ -167,91 +240,153 @@ type caminoState struct {
 	deferredValidatorList linkeddb.LinkedDB
 
 	// Address State
-	addressStateCache cache.Cacher
+	addressStateCache cache.Cacher[ids.ShortID, as.AddressState]
 	addressStateDB    database.Database
+	// addresses with non-zero states, nil until loaded
+	addressStateFilter      bloom.Filter
+	addressStateFilterSize  uint64
+	addressStateFilterCount uint64
 
 	// Deposit offers
 	depositOffers   map[ids.ID]*deposit.Offer
 	depositOffersDB database.Database
+	// offerID -> offer data, that isn't part of serialized offer (version, tiers)
+	depositOfferExtensionsDB database.Database
 
 	// Deposits
 	depositsNextToUnlockTime *time.Time
 	depositsNextToUnlockIDs  []ids.ID
-	depositsCache            cache.Cacher
+	depositsCache            cache.Cacher[ids.ID, *deposit.Deposit]
 	depositsDB               database.Database
-	depositIDsByEndtimeDB    database.Database
+	// big-endian endtime + depositTxID -> nil, sorted by deposit endtime,
+	// so next to unlock deposits are found with a seek to the beginning of index
+	depositIDsByEndtimeDB database.Database
+	// reward ownerID + depositTxID -> nil
+	depositIDsByRewardOwnerDB database.Database
+	// offerID + depositTxID -> nil
+	depositIDsByOfferDB database.Database
+	// depositTxID -> rewards owner, that was set after deposit creation
+	depositRewardOwnersDB database.Database
+	// depositTxID + big-endian timestamp + claimTxID -> claim
+	depositClaimsDB database.Database
+	// offerID -> sum of active principal of deposits with this offer
+	depositStatsDB database.Database
 
 	// MSIG aliases
-	multisigOwnersCache cache.Cacher
+	multisigOwnersCache cache.Cacher[ids.ShortID, *multisig.Alias]
 	multisigOwnersDB    database.Database
+	// member address + aliasID -> nil
+	multisigAliasesByMemberDB database.Database
+	// aliasID + big-endian height -> alias version, that was replaced by block with this height
+	multisigAliasHistoryDB database.Database
+	// aliasID + big-endian height + txID -> nil
+	multisigAliasUsagesDB database.Database
+	// number of last usages retained per alias, zero means usage isn't recorded
+	multisigAliasUsageRetention int
 
 	// ShortIDs link
-	shortLinksCache cache.Cacher
+	shortLinksCache cache.Cacher[ids.ID, ids.ShortID]
 	shortLinksDB    database.Database
 
 	//  Claimables
 	notDistributedValidatorReward uint64
 	claimablesDB                  database.Database
-	claimablesCache               cache.Cacher
+	claimablesCache               cache.Cacher[ids.ID, *Claimable]
+	// claimableID -> reward asset id, only for claimables with not primary network asset rewards
+	claimableRewardAssetsDB database.Database
+
+	// UTXOs by owner index
+	utxoIDsByOwnerDB   database.Database
+	utxoCountByOwnerDB database.Database
+
+	// UTXOs by lock tx index
+	// lockTxID + utxoID -> nil
+	utxoIDsByLockTxDB database.Database
+
+	// Archive of pruned deposits and deposit offers
+	pruningEnabled          bool
+	archiveDepositsEnabled  bool
+	archivedDepositOfferIDs set.Set[ids.ID]
+	archivedDepositOffersDB database.Database
+	archivedDepositsDB      database.Database
+
+	// History of deposits, claimables and address states
+	historyRetention   uint64
+	historyStarted     bool
+	historyStartHeight uint64
+	historyHeight      uint64
+	// bucket + key + height -> value before block with height
+	historyDB database.Database
+	// height + bucket + key -> nil
+	historyHeightsDB database.Database
+
+	metrics *caminoMetrics
 }
 
 func newCaminoDiff() *caminoDiff {
 	return &caminoDiff{
-		modifiedAddressStates:  make(map[ids.ShortID]uint64),
-		modifiedDepositOffers:  make(map[ids.ID]*deposit.Offer),
-		modifiedDeposits:       make(map[ids.ID]*depositDiff),
-		modifiedMultisigOwners: make(map[ids.ShortID]*multisig.Alias),
-		modifiedShortLinks:     make(map[ids.ID]*ids.ShortID),
-		modifiedClaimables:     make(map[ids.ID]*Claimable),
+		modifiedAddressStates:    make(map[ids.ShortID]as.AddressState),
+		modifiedDepositOffers:    make(map[ids.ID]*deposit.Offer),
+		modifiedDeposits:         make(map[ids.ID]*depositDiff),
+		addedDepositClaims:       make(map[ids.ID][]*deposit.Claim),
+		modifiedMultisigOwners:   make(map[ids.ShortID]*multisig.Alias),
+		addedMultisigAliasUsages: make(map[ids.ShortID][]ids.ID),
+		modifiedShortLinks:       make(map[ids.ID]*ids.ShortID),
+		modifiedClaimables:       make(map[ids.ID]*Claimable),
 	}
 }
 
-func newCaminoState(baseDB, validatorsDB database.Database, metricsReg prometheus.Registerer) (*caminoState, error) {
-	addressStateCache, err := metercacher.New(
+func newCaminoState(baseDB, validatorsDB database.Database, metricsReg prometheus.Registerer, conf config.CaminoConfig) (*caminoState, error) {
+	addressStateCache, err := metercacher.New[ids.ShortID, as.AddressState](
 		"address_state_cache",
 		metricsReg,
-		&cache.LRU{Size: addressStateCacheSize},
+		&cache.LRU[ids.ShortID, as.AddressState]{Size: addressStateCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	depositsCache, err := metercacher.New(
+	depositsCache, err := metercacher.New[ids.ID, *deposit.Deposit](
 		"deposits_cache",
 		metricsReg,
-		&cache.LRU{Size: depositsCacheSize},
+		&cache.LRU[ids.ID, *deposit.Deposit]{Size: cacheSize(conf.DepositsCacheSize, defaultDepositsCacheSize)},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	shortLinksCache, err := metercacher.New(
+	shortLinksCache, err := metercacher.New[ids.ID, ids.ShortID](
 		"short_links_cache",
 		metricsReg,
-		&cache.LRU{Size: shortLinksCacheSize},
+		&cache.LRU[ids.ID, ids.ShortID]{Size: shortLinksCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	multisigOwnersCache, err := metercacher.New(
+	multisigOwnersCache, err := metercacher.New[ids.ShortID, *multisig.Alias](
 		"msig_owners_cache",
 		metricsReg,
-		&cache.LRU{Size: msigOwnersCacheSize},
+		&cache.LRU[ids.ShortID, *multisig.Alias]{Size: msigOwnersCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	claimablesCache, err := metercacher.New(
+	claimablesCache, err := metercacher.New[ids.ID, *Claimable](
 		"claimables_cache",
 		metricsReg,
-		&cache.LRU{Size: claimablesCacheSize},
+		&cache.LRU[ids.ID, *Claimable]{Size: cacheSize(conf.ClaimablesCacheSize, defaultClaimablesCacheSize)},
 	)
 	if err != nil {
 		return nil, err
 	}
 
+	stateMetrics, err := newCaminoMetrics(metricsReg)
+	if err != nil {
+		return nil, err
+	}
+
 	deferredValidatorsDB := prefixdb.New(deferredPrefix, validatorsDB)
 
 	return &caminoState{
@@ -260,25 +395,50 @@ func newCaminoState(baseDB, validatorsDB database.Database, metricsReg prometheu
 		addressStateCache: addressStateCache,
 
 		// Deposit offers
-		depositOffers:   make(map[ids.ID]*deposit.Offer),
-		depositOffersDB: prefixdb.New(depositOffersPrefix, baseDB),
+		depositOffers:            make(map[ids.ID]*deposit.Offer),
+		depositOffersDB:          prefixdb.New(depositOffersPrefix, baseDB),
+		depositOfferExtensionsDB: prefixdb.New(depositOfferExtensionsPrefix, baseDB),
 
 		// Deposits
-		depositsCache:         depositsCache,
-		depositsDB:            prefixdb.New(depositsPrefix, baseDB),
-		depositIDsByEndtimeDB: prefixdb.New(depositIDsByEndtimePrefix, baseDB),
+		depositsCache:             depositsCache,
+		depositsDB:                prefixdb.New(depositsPrefix, baseDB),
+		depositIDsByEndtimeDB:     prefixdb.New(depositIDsByEndtimePrefix, baseDB),
+		depositIDsByRewardOwnerDB: prefixdb.New(depositIDsByRewardOwnerPrefix, baseDB),
+		depositIDsByOfferDB:       prefixdb.New(depositIDsByOfferPrefix, baseDB),
+		depositRewardOwnersDB:     prefixdb.New(depositRewardOwnersPrefix, baseDB),
+		depositClaimsDB:           prefixdb.New(depositClaimsPrefix, baseDB),
+		depositStatsDB:            prefixdb.New(depositStatsPrefix, baseDB),
 
 		// Multisig Owners
-		multisigOwnersCache: multisigOwnersCache,
-		multisigOwnersDB:    prefixdb.New(multisigOwnersPrefix, baseDB),
+		multisigOwnersCache:       multisigOwnersCache,
+		multisigOwnersDB:          prefixdb.New(multisigOwnersPrefix, baseDB),
+		multisigAliasesByMemberDB: prefixdb.New(multisigAliasesByMemberPrefix, baseDB),
+		multisigAliasHistoryDB:    prefixdb.New(multisigAliasHistoryPrefix, baseDB),
+		multisigAliasUsagesDB:     prefixdb.New(multisigAliasUsagesPrefix, baseDB),
 
 		// Short links
 		shortLinksCache: shortLinksCache,
 		shortLinksDB:    prefixdb.New(shortLinksPrefix, baseDB),
 
 		//  Claimable & rewards
-		claimablesCache: claimablesCache,
-		claimablesDB:    prefixdb.New(claimablesPrefix, baseDB),
+		claimablesCache:         claimablesCache,
+		claimablesDB:            prefixdb.New(claimablesPrefix, baseDB),
+		claimableRewardAssetsDB: prefixdb.New(claimableRewardAssetsPrefix, baseDB),
+
+		// UTXOs by owner index
+		utxoIDsByOwnerDB:   prefixdb.New(utxoIDsByOwnerPrefix, baseDB),
+		utxoCountByOwnerDB: prefixdb.New(utxoCountByOwnerPrefix, baseDB),
+
+		// UTXOs by lock tx index
+		utxoIDsByLockTxDB: prefixdb.New(utxoIDsByLockTxPrefix, baseDB),
+
+		// Archive
+		archivedDepositOffersDB: prefixdb.New(archivedDepositOffersPrefix, baseDB),
+		archivedDepositsDB:      prefixdb.New(archivedDepositsPrefix, baseDB),
+
+		// History
+		historyDB:        prefixdb.New(caminoHistoryPrefix, baseDB),
+		historyHeightsDB: prefixdb.New(caminoHistoryHeightsPrefix, baseDB),
 
 		// Deferred Stakers
 		deferredStakers:       newBaseStakers(),
@@ -287,9 +447,18 @@ func newCaminoState(baseDB, validatorsDB database.Database, metricsReg prometheu
 
 		caminoDB:   prefixdb.New(caminoPrefix, baseDB),
 		caminoDiff: newCaminoDiff(),
+		metrics:    stateMetrics,
 	}, nil
 }
 
+// cacheSize returns [configured] cache size or [defaultSize], if it isn't configured
+func cacheSize(configured, defaultSize int) int {
+	if configured <= 0 {
+		return defaultSize
+	}
+	return configured
+}
+
 // Return current genesis args
 func (cs *caminoState) CaminoConfig() *CaminoConfig {
 	return &CaminoConfig{
@@ -323,11 +492,11 @@ func (cs *caminoState) SyncGenesis(s *state, g *genesis.State) error {
 		return err
 	}
 	cs.SetAddressStates(g.Camino.InitialAdmin,
-		initalAdminAddressState|txs.AddressStateRoleAdminBit)
+		initalAdminAddressState.Set(as.AddressStateRoleAdmin))
 
 	addrStateTx, err := txs.NewSigned(&txs.AddressStateTx{
 		Address: g.Camino.InitialAdmin,
-		State:   txs.AddressStateRoleAdmin,
+		State:   as.AddressStateBitRoleAdmin,
 		Remove:  false,
 	}, txs.Codec, nil)
 	if err != nil {
@@ -487,30 +656,70 @@ func (cs *caminoState) Load(s *state) error {
 	}
 	cs.lockModeBondDeposit = mode
 
+	// must be applied before camino state buckets are loaded
+	if err := cs.applyMigrations(caminoMigrations); err != nil {
+		return err
+	}
+
+	cs.pruningEnabled = s.cfg.CaminoConfig.StatePruning
+	cs.archiveDepositsEnabled = s.cfg.CaminoConfig.StatePruning || s.cfg.CaminoConfig.ArchiveRemovedDeposits
+	cs.historyRetention = s.cfg.CaminoConfig.HistoryRetention
+	cs.multisigAliasUsageRetention = s.cfg.CaminoConfig.MultisigAliasUsageRetention
+
 	errs := wrappers.Errs{}
 	errs.Add(
+		cs.loadAddressStateFilter(),
 		cs.loadDepositOffers(),
+		cs.loadArchivedDepositOfferIDs(),
 		cs.loadDeposits(),
 		cs.loadValidatorRewards(),
 		cs.loadDeferredValidators(s),
+		cs.loadUTXOOwnerIndex(s),
+		cs.loadUTXOLockIndex(s),
+		cs.loadDepositIDsByRewardOwner(s),
+		cs.loadDepositIDsByOffer(),
+		cs.loadDepositStats(), // must be called after loadDepositOffers
+		cs.loadMultisigAliasesByMember(),
+		cs.loadHistory(),
 	)
 	return errs.Err
 }
 
-func (cs *caminoState) Write() error {
+// Write writes camino modifications into state base database. Base database is versiondb,
+// so all camino changes are staged in memory and flushed to disk with the rest of
+// block changes as a single batch by state.CommitBatch.
+func (cs *caminoState) Write(s *state) error {
 	errs := wrappers.Errs{}
 	// Write the singletons (only once after sync)
 	if cs.genesisSynced {
 		errs.Add(
 			database.PutBool(cs.caminoDB, nodeSignatureKey, cs.verifyNodeSignature),
 			database.PutBool(cs.caminoDB, depositBondModeKey, cs.lockModeBondDeposit),
+			// utxos and deposits from genesis are indexed on write, so there is nothing to build on load
+			database.PutBool(cs.caminoDB, utxoOwnerIndexKey, true),
+			database.PutBool(cs.caminoDB, utxoLockIndexKey, true),
+			database.PutBool(cs.caminoDB, depositRewardOwnerIndexKey, true),
+			database.PutBool(cs.caminoDB, multisigAliasMemberIndexKey, true),
+			database.PutBool(cs.caminoDB, depositOfferIndexKey, true),
+			database.PutBool(cs.caminoDB, depositStatsIndexKey, true),
+			// state created from genesis already has current schema
+			database.PutUInt64(cs.caminoDB, caminoSchemaVersionKey, currentSchemaVersion(caminoMigrations)),
 		)
 	}
 	errs.Add(
+		cs.writeHistory(s.currentHeight), // must be called before other writes
 		cs.writeAddressStates(),
 		cs.writeDepositOffers(),
+		// must be called after writeDepositOffers
+		cs.writeArchivedDepositOffers(uint64(s.GetTimestamp().Unix())),
+		cs.writeDepositStats(),             // must be called before writeDeposits
+		cs.writeDepositIDsByRewardOwner(s), // must be called before writeDeposits
 		cs.writeDeposits(),
+		cs.writeDepositClaims(),
+		cs.writeMultisigAliasesByMember(),             // must be called before writeMultisigOwners
+		cs.writeMultisigAliasHistory(s.currentHeight), // must be called before writeMultisigOwners
 		cs.writeMultisigOwners(),
+		cs.writeMultisigAliasUsages(s.currentHeight),
 		cs.writeShortLinks(),
 		cs.writeClaimableAndValidatorRewards(),
 		cs.writeDeferredStakers(),
@@ -524,12 +733,29 @@ func (cs *caminoState) Close() error {
 		cs.caminoDB.Close(),
 		cs.addressStateDB.Close(),
 		cs.depositOffersDB.Close(),
+		cs.depositOfferExtensionsDB.Close(),
 		cs.depositsDB.Close(),
 		cs.depositIDsByEndtimeDB.Close(),
+		cs.depositIDsByRewardOwnerDB.Close(),
+		cs.depositIDsByOfferDB.Close(),
+		cs.depositRewardOwnersDB.Close(),
+		cs.depositClaimsDB.Close(),
+		cs.depositStatsDB.Close(),
 		cs.multisigOwnersDB.Close(),
+		cs.multisigAliasesByMemberDB.Close(),
+		cs.multisigAliasHistoryDB.Close(),
+		cs.multisigAliasUsagesDB.Close(),
 		cs.shortLinksDB.Close(),
 		cs.claimablesDB.Close(),
+		cs.claimableRewardAssetsDB.Close(),
 		cs.deferredValidatorsDB.Close(),
+		cs.utxoIDsByOwnerDB.Close(),
+		cs.utxoCountByOwnerDB.Close(),
+		cs.utxoIDsByLockTxDB.Close(),
+		cs.archivedDepositOffersDB.Close(),
+		cs.archivedDepositsDB.Close(),
+		cs.historyDB.Close(),
+		cs.historyHeightsDB.Close(),
 	)
 	return errs.Err
 }
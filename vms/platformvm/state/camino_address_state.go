@@ -5,58 +5,114 @@ package state
 
 import (
 	"encoding/binary"
+	"math"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/bloom"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+)
+
+const (
+	minAddressStateFilterSize           = 1024
+	addressStateFilterFalsePositiveRate = 0.01
 )
 
 // Set a new state assigned to the address id
-func (cs *caminoState) SetAddressStates(address ids.ShortID, states uint64) {
+func (cs *caminoState) SetAddressStates(address ids.ShortID, states as.AddressState) {
 	cs.modifiedAddressStates[address] = states
 	cs.addressStateCache.Evict(address)
 }
 
 // Return the current state (if exists) for an address
-func (cs *caminoState) GetAddressStates(address ids.ShortID) (uint64, error) {
+func (cs *caminoState) GetAddressStates(address ids.ShortID) (as.AddressState, error) {
 	// Try to get from modified state
 	item, ok := cs.modifiedAddressStates[address]
 	// Try to get from cache
 	if !ok {
-		var itemIntf interface{}
-		if itemIntf, ok = cs.addressStateCache.Get(address); ok {
-			item = itemIntf.(uint64)
-		}
+		item, ok = cs.addressStateCache.Get(address)
+	}
+	// Addresses, that aren't in filter, don't have any states
+	if !ok && cs.addressStateFilter != nil && !cs.addressStateFilter.Check(address[:]) {
+		return as.AddressStateEmpty, nil
 	}
 	// Finally get it from database
 	if !ok {
 		uintBytes, err := cs.addressStateDB.Get(address[:])
 		switch err {
 		case nil:
-			item = binary.LittleEndian.Uint64(uintBytes)
+			item = as.AddressState(binary.LittleEndian.Uint64(uintBytes))
 		case database.ErrNotFound:
-			item = 0
+			item = as.AddressStateEmpty
 		default:
-			return 0, err
+			return as.AddressStateEmpty, err
 		}
 		cs.addressStateCache.Put(address, item)
 	}
 	return item, nil
 }
 
+// GetAddressStatesIterator returns iterator over all addresses with non-zero states
+func (cs *caminoState) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	return newDiffAddressStatesIterator(
+		&dbAddressStatesIterator{iterator: cs.addressStateDB.NewIterator()},
+		cs.modifiedAddressStates,
+	), nil
+}
+
 func (cs *caminoState) writeAddressStates() error {
 	for key, val := range cs.modifiedAddressStates {
 		delete(cs.modifiedAddressStates, key)
-		if val == 0 {
+		if val == as.AddressStateEmpty {
 			if err := cs.addressStateDB.Delete(key[:]); err != nil {
 				return err
 			}
 		} else {
 			buf := make([]byte, 8)
-			binary.LittleEndian.PutUint64(buf, val)
+			binary.LittleEndian.PutUint64(buf, uint64(val))
 			if err := cs.addressStateDB.Put(key[:], buf); err != nil {
 				return err
 			}
+			if cs.addressStateFilter != nil {
+				cs.addressStateFilter.Add(key[:])
+				cs.addressStateFilterCount++
+			}
 		}
 	}
+	// false positive rate of overfilled filter grows, so we rebuild it with bigger size
+	if cs.addressStateFilter != nil && cs.addressStateFilterCount > cs.addressStateFilterSize {
+		return cs.loadAddressStateFilter()
+	}
+	return nil
+}
+
+// loadAddressStateFilter builds bloom filter of addresses with non-zero states
+func (cs *caminoState) loadAddressStateFilter() error {
+	count, err := database.Count(cs.addressStateDB)
+	if err != nil {
+		return err
+	}
+
+	filterSize := uint64(2 * count)
+	if filterSize < minAddressStateFilterSize {
+		filterSize = minAddressStateFilterSize
+	}
+	filter, err := bloom.New(filterSize, addressStateFilterFalsePositiveRate, math.MaxUint64)
+	if err != nil {
+		return err
+	}
+
+	addressStatesIt := cs.addressStateDB.NewIterator()
+	defer addressStatesIt.Release()
+	for addressStatesIt.Next() {
+		filter.Add(addressStatesIt.Key())
+	}
+	if err := addressStatesIt.Error(); err != nil {
+		return err
+	}
+
+	cs.addressStateFilter = filter
+	cs.addressStateFilterSize = filterSize
+	cs.addressStateFilterCount = uint64(count)
 	return nil
 }
@@ -0,0 +1,174 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+)
+
+var (
+	_ AddressStatesIterator = (*dbAddressStatesIterator)(nil)
+	_ AddressStatesIterator = (*diffAddressStatesIterator)(nil)
+)
+
+// AddressStatesIterator iterates over addresses with non-zero address states
+// in ascending order of addresses.
+type AddressStatesIterator interface {
+	// Next attempts to move the iterator to the next address. It returns false
+	// once there are no more addresses or iterator failed.
+	Next() bool
+
+	// Address returns the current address. Address should only be called
+	// after a call to Next which returned true.
+	Address() ids.ShortID
+
+	// Value returns states of the current address. Value should only be called
+	// after a call to Next which returned true.
+	Value() as.AddressState
+
+	// Error returns error, that stopped iteration, if any.
+	Error() error
+
+	// Release any resources associated with the iterator. This must be called
+	// after the interator is no longer needed.
+	Release()
+}
+
+// dbAddressStatesIterator iterates over address states stored in database
+type dbAddressStatesIterator struct {
+	iterator database.Iterator
+	address  ids.ShortID
+	states   as.AddressState
+	err      error
+}
+
+func (it *dbAddressStatesIterator) Next() bool {
+	if it.err != nil || !it.iterator.Next() {
+		return false
+	}
+
+	address, err := ids.ToShortID(it.iterator.Key())
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.address = address
+	it.states = as.AddressState(binary.LittleEndian.Uint64(it.iterator.Value()))
+	return true
+}
+
+func (it *dbAddressStatesIterator) Address() ids.ShortID {
+	return it.address
+}
+
+func (it *dbAddressStatesIterator) Value() as.AddressState {
+	return it.states
+}
+
+func (it *dbAddressStatesIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.iterator.Error()
+}
+
+func (it *dbAddressStatesIterator) Release() {
+	it.iterator.Release()
+}
+
+// diffAddressStatesIterator iterates over parent address states,
+// overridden by modified address states. Zero modified states are treated as removed.
+type diffAddressStatesIterator struct {
+	parent         AddressStatesIterator
+	parentHasValue bool
+	parentStarted  bool
+
+	modifiedAddresses []ids.ShortID
+	modified          map[ids.ShortID]as.AddressState
+
+	address ids.ShortID
+	states  as.AddressState
+}
+
+func newDiffAddressStatesIterator(parent AddressStatesIterator, modified map[ids.ShortID]as.AddressState) *diffAddressStatesIterator {
+	modifiedAddresses := make([]ids.ShortID, 0, len(modified))
+	// copying modified states, so iterator won't be affected by further state changes
+	modifiedCopy := make(map[ids.ShortID]as.AddressState, len(modified))
+	for address, states := range modified {
+		modifiedAddresses = append(modifiedAddresses, address)
+		modifiedCopy[address] = states
+	}
+	utils.Sort(modifiedAddresses)
+
+	return &diffAddressStatesIterator{
+		parent:            parent,
+		modifiedAddresses: modifiedAddresses,
+		modified:          modifiedCopy,
+	}
+}
+
+func (it *diffAddressStatesIterator) Next() bool {
+	if !it.parentStarted {
+		it.parentStarted = true
+		it.parentHasValue = it.parent.Next()
+	}
+
+	for it.parentHasValue || len(it.modifiedAddresses) > 0 {
+		var cmp int
+		switch {
+		case !it.parentHasValue:
+			cmp = 1
+		case len(it.modifiedAddresses) == 0:
+			cmp = -1
+		default:
+			parentAddress := it.parent.Address()
+			cmp = bytes.Compare(parentAddress[:], it.modifiedAddresses[0][:])
+		}
+
+		if cmp < 0 {
+			// parent address states aren't modified
+			it.address = it.parent.Address()
+			it.states = it.parent.Value()
+			it.parentHasValue = it.parent.Next()
+			return true
+		}
+
+		if cmp == 0 {
+			// parent address states are overridden by modified ones
+			it.parentHasValue = it.parent.Next()
+		}
+
+		address := it.modifiedAddresses[0]
+		it.modifiedAddresses = it.modifiedAddresses[1:]
+		if states := it.modified[address]; states != as.AddressStateEmpty {
+			it.address = address
+			it.states = states
+			return true
+		}
+	}
+	return false
+}
+
+func (it *diffAddressStatesIterator) Address() ids.ShortID {
+	return it.address
+}
+
+func (it *diffAddressStatesIterator) Value() as.AddressState {
+	return it.states
+}
+
+func (it *diffAddressStatesIterator) Error() error {
+	return it.parent.Error()
+}
+
+func (it *diffAddressStatesIterator) Release() {
+	it.parent.Release()
+}
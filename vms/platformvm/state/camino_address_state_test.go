@@ -0,0 +1,115 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAddressStatesIterator(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	collect := func(chain Chain) ([]ids.ShortID, []as.AddressState) {
+		it, err := chain.GetAddressStatesIterator()
+		require.NoError(err)
+		defer it.Release()
+		addresses := []ids.ShortID{}
+		states := []as.AddressState{}
+		for it.Next() {
+			addresses = append(addresses, it.Address())
+			states = append(states, it.Value())
+		}
+		require.NoError(it.Error())
+		return addresses, states
+	}
+
+	s := newEmptyState(t)
+	s.SetAddressStates(ids.ShortID{5}, 5)
+	s.SetAddressStates(ids.ShortID{1}, 1)
+	s.SetAddressStates(ids.ShortID{3}, 3)
+	s.SetAddressStates(ids.ShortID{4}, 0)
+	require.NoError(s.caminoState.Write(s))
+
+	// persisted states
+	addresses, states := collect(s)
+	require.Equal([]ids.ShortID{{1}, {3}, {5}}, addresses)
+	require.Equal([]as.AddressState{1, 3, 5}, states)
+
+	// persisted states with not written modifications
+	s.SetAddressStates(ids.ShortID{3}, 33)
+	s.SetAddressStates(ids.ShortID{5}, 0)
+	s.SetAddressStates(ids.ShortID{2}, 2)
+	addresses, states = collect(s)
+	require.Equal([]ids.ShortID{{1}, {2}, {3}}, addresses)
+	require.Equal([]as.AddressState{1, 2, 33}, states)
+
+	// diff on top of state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).Times(2)
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.SetAddressStates(ids.ShortID{1}, 0)
+	d.SetAddressStates(ids.ShortID{4}, 4)
+	d.SetAddressStates(ids.ShortID{6}, 0)
+	addresses, states = collect(d)
+	require.Equal([]ids.ShortID{{2}, {3}, {4}}, addresses)
+	require.Equal([]as.AddressState{2, 33, 4}, states)
+}
+
+func TestAddressStateFilter(t *testing.T) {
+	require := require.New(t)
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+
+	address1 := ids.ShortID{1}
+	address2 := ids.ShortID{2}
+	s.SetAddressStates(address1, 1)
+	require.NoError(cs.writeAddressStates())
+	require.NoError(cs.loadAddressStateFilter())
+	require.Equal(uint64(1), cs.addressStateFilterCount)
+
+	// addresses, that aren't in filter, aren't read from database
+	// (except for rare false positives)
+	readFromDB := 0
+	for i := 0; i < 100; i++ {
+		address := ids.ShortID{0, byte(i)}
+		require.NoError(cs.addressStateDB.Put(address[:], []byte{1, 0, 0, 0, 0, 0, 0, 0}))
+		states, err := s.GetAddressStates(address)
+		require.NoError(err)
+		if states != 0 {
+			readFromDB++
+		}
+	}
+	require.Less(readFromDB, 10)
+
+	states, err := s.GetAddressStates(address1)
+	require.NoError(err)
+	require.Equal(as.AddressState(1), states)
+
+	// written addresses are added to filter
+	s.SetAddressStates(address2, 2)
+	require.NoError(cs.writeAddressStates())
+	states, err = s.GetAddressStates(address2)
+	require.NoError(err)
+	require.Equal(as.AddressState(2), states)
+
+	// overfilled filter is rebuilt
+	cs.addressStateFilterCount = cs.addressStateFilterSize
+	s.SetAddressStates(ids.ShortID{3}, 3)
+	require.NoError(cs.writeAddressStates())
+	addressesCount, err := database.Count(cs.addressStateDB)
+	require.NoError(err)
+	require.Equal(uint64(addressesCount), cs.addressStateFilterCount)
+	require.Equal(uint64(minAddressStateFilterSize), cs.addressStateFilterSize)
+}
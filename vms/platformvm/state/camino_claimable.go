@@ -8,6 +8,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
@@ -16,6 +17,18 @@ type Claimable struct {
 	Owner           *secp256k1fx.OutputOwners `serialize:"true"`
 	ValidatorReward uint64                    `serialize:"true"`
 	DepositReward   uint64                    `serialize:"true"`
+	// RewardAssetID is asset of deposit reward, if it isn't primary network asset.
+	// It isn't serialized as part of claimable, claimable id is derived from it instead.
+	RewardAssetID ids.ID
+}
+
+// ClaimableID returns id of claimable with deposit rewards of [ownerID] in [rewardAssetID].
+// Claimables with primary network asset rewards have the same id as their owner.
+func ClaimableID(ownerID, rewardAssetID, primaryAssetID ids.ID) ids.ID {
+	if rewardAssetID == ids.Empty || rewardAssetID == primaryAssetID {
+		return ownerID
+	}
+	return hashing.ComputeHash256Array(append(ownerID[:], rewardAssetID[:]...))
 }
 
 func (cs *caminoState) SetClaimable(ownerID ids.ID, claimable *Claimable) {
@@ -31,11 +44,11 @@ func (cs *caminoState) GetClaimable(ownerID ids.ID) (*Claimable, error) {
 		return claimable, nil
 	}
 
-	if claimableIntf, ok := cs.claimablesCache.Get(ownerID); ok {
-		if claimableIntf == nil {
+	if claimable, ok := cs.claimablesCache.Get(ownerID); ok {
+		if claimable == nil {
 			return nil, database.ErrNotFound
 		}
-		return claimableIntf.(*Claimable), nil
+		return claimable, nil
 	}
 
 	claimableBytes, err := cs.claimablesDB.Get(ownerID[:])
@@ -50,12 +63,25 @@ func (cs *caminoState) GetClaimable(ownerID ids.ID) (*Claimable, error) {
 	if _, err := blocks.GenesisCodec.Unmarshal(claimableBytes, claimable); err != nil {
 		return nil, err
 	}
+	if claimable.RewardAssetID, err = cs.getClaimableRewardAsset(ownerID); err != nil {
+		return nil, err
+	}
 
 	cs.claimablesCache.Put(ownerID, claimable)
 
 	return claimable, nil
 }
 
+func (cs *caminoState) GetClaimablesIterator() (ClaimablesIterator, error) {
+	return newDiffClaimablesIterator(
+		&dbClaimablesIterator{
+			iterator:             cs.claimablesDB.NewIterator(),
+			claimableRewardAsset: cs.getClaimableRewardAsset,
+		},
+		cs.modifiedClaimables,
+	), nil
+}
+
 func (cs *caminoState) SetNotDistributedValidatorReward(reward uint64) {
 	cs.modifiedNotDistributedValidatorReward = &reward
 }
@@ -95,12 +121,33 @@ func (cs *caminoState) writeClaimableAndValidatorRewards() error {
 			if err := cs.claimablesDB.Put(key[:], claimableBytes); err != nil {
 				return err
 			}
+			// claimable reward asset never changes for the same claimable id,
+			// so it is kept after claimable removal for historical views
+			if claimable.RewardAssetID != ids.Empty {
+				if err := cs.claimableRewardAssetsDB.Put(key[:], claimable.RewardAssetID[:]); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// getClaimableRewardAsset returns reward asset of persisted claimable [claimableID].
+// Returns empty id for claimables with primary network asset rewards.
+func (cs *caminoState) getClaimableRewardAsset(claimableID ids.ID) (ids.ID, error) {
+	assetIDBytes, err := cs.claimableRewardAssetsDB.Get(claimableID[:])
+	switch err {
+	case nil:
+		return ids.ToID(assetIDBytes)
+	case database.ErrNotFound:
+		return ids.Empty, nil
+	default:
+		return ids.Empty, err
+	}
+}
+
 func (cs *caminoState) loadValidatorRewards() error {
 	notDistributedValidatorReward, err := database.GetUInt64(cs.caminoDB, notDistributedValidatorRewardKey)
 	if err == database.ErrNotFound {
@@ -0,0 +1,189 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+)
+
+var (
+	_ ClaimablesIterator = (*dbClaimablesIterator)(nil)
+	_ ClaimablesIterator = (*diffClaimablesIterator)(nil)
+)
+
+// ClaimablesIterator iterates over ownerID -> claimable entries
+// in ascending order of owner ids.
+type ClaimablesIterator interface {
+	// Next attempts to move the iterator to the next claimable. It returns false
+	// once there are no more claimables or iterator failed.
+	Next() bool
+
+	// OwnerID returns the owner id of current claimable. OwnerID should only be called
+	// after a call to Next which returned true.
+	OwnerID() ids.ID
+
+	// Value returns the current claimable. Value should only be called after a
+	// call to Next which returned true.
+	Value() *Claimable
+
+	// Error returns error, that stopped iteration, if any.
+	Error() error
+
+	// Release any resources associated with the iterator. This must be called
+	// after the interator is no longer needed.
+	Release()
+}
+
+// dbClaimablesIterator iterates over claimables stored in database
+type dbClaimablesIterator struct {
+	iterator             database.Iterator
+	claimableRewardAsset func(claimableID ids.ID) (ids.ID, error)
+	ownerID              ids.ID
+	claimable            *Claimable
+	err                  error
+}
+
+func (it *dbClaimablesIterator) Next() bool {
+	if it.err != nil || !it.iterator.Next() {
+		return false
+	}
+
+	ownerID, err := ids.ToID(it.iterator.Key())
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	claimable := &Claimable{}
+	if _, err := blocks.GenesisCodec.Unmarshal(it.iterator.Value(), claimable); err != nil {
+		it.err = err
+		return false
+	}
+	if it.claimableRewardAsset != nil {
+		if claimable.RewardAssetID, err = it.claimableRewardAsset(ownerID); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.ownerID = ownerID
+	it.claimable = claimable
+	return true
+}
+
+func (it *dbClaimablesIterator) OwnerID() ids.ID {
+	return it.ownerID
+}
+
+func (it *dbClaimablesIterator) Value() *Claimable {
+	return it.claimable
+}
+
+func (it *dbClaimablesIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.iterator.Error()
+}
+
+func (it *dbClaimablesIterator) Release() {
+	it.iterator.Release()
+}
+
+// diffClaimablesIterator iterates over parent claimables,
+// overridden by modified claimables. Nil modified claimables are treated as removed.
+type diffClaimablesIterator struct {
+	parent         ClaimablesIterator
+	parentHasValue bool
+	parentStarted  bool
+
+	modifiedOwnerIDs []ids.ID
+	modified         map[ids.ID]*Claimable
+
+	ownerID   ids.ID
+	claimable *Claimable
+}
+
+func newDiffClaimablesIterator(parent ClaimablesIterator, modified map[ids.ID]*Claimable) *diffClaimablesIterator {
+	modifiedOwnerIDs := make([]ids.ID, 0, len(modified))
+	for ownerID := range modified {
+		modifiedOwnerIDs = append(modifiedOwnerIDs, ownerID)
+	}
+	utils.Sort(modifiedOwnerIDs)
+
+	// copying modified claimables, so iterator won't be affected by further state changes
+	modifiedCopy := make(map[ids.ID]*Claimable, len(modified))
+	for ownerID, claimable := range modified {
+		modifiedCopy[ownerID] = claimable
+	}
+
+	return &diffClaimablesIterator{
+		parent:           parent,
+		modifiedOwnerIDs: modifiedOwnerIDs,
+		modified:         modifiedCopy,
+	}
+}
+
+func (it *diffClaimablesIterator) Next() bool {
+	if !it.parentStarted {
+		it.parentStarted = true
+		it.parentHasValue = it.parent.Next()
+	}
+
+	for it.parentHasValue || len(it.modifiedOwnerIDs) > 0 {
+		var cmp int
+		switch {
+		case !it.parentHasValue:
+			cmp = 1
+		case len(it.modifiedOwnerIDs) == 0:
+			cmp = -1
+		default:
+			parentOwnerID := it.parent.OwnerID()
+			cmp = bytes.Compare(parentOwnerID[:], it.modifiedOwnerIDs[0][:])
+		}
+
+		if cmp < 0 {
+			// parent claimable isn't modified
+			it.ownerID = it.parent.OwnerID()
+			it.claimable = it.parent.Value()
+			it.parentHasValue = it.parent.Next()
+			return true
+		}
+
+		if cmp == 0 {
+			// parent claimable is overridden by modified one
+			it.parentHasValue = it.parent.Next()
+		}
+
+		ownerID := it.modifiedOwnerIDs[0]
+		it.modifiedOwnerIDs = it.modifiedOwnerIDs[1:]
+		if claimable := it.modified[ownerID]; claimable != nil {
+			it.ownerID = ownerID
+			it.claimable = claimable
+			return true
+		}
+	}
+	return false
+}
+
+func (it *diffClaimablesIterator) OwnerID() ids.ID {
+	return it.ownerID
+}
+
+func (it *diffClaimablesIterator) Value() *Claimable {
+	return it.claimable
+}
+
+func (it *diffClaimablesIterator) Error() error {
+	return it.parent.Error()
+}
+
+func (it *diffClaimablesIterator) Release() {
+	it.parent.Release()
+}
@@ -21,6 +21,11 @@ func TestGetClaimable(t *testing.T) {
 	claimable := &Claimable{Owner: &secp256k1fx.OutputOwners{Addrs: []ids.ShortID{}}}
 	claimableBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, claimable)
 	require.NoError(t, err)
+	rewardAssetID := ids.ID{2}
+	claimableWithRewardAsset := &Claimable{
+		Owner:         &secp256k1fx.OutputOwners{Addrs: []ids.ShortID{}},
+		RewardAssetID: rewardAssetID,
+	}
 	testError := errors.New("test error")
 
 	tests := map[string]struct {
@@ -42,7 +47,7 @@ func TestGetClaimable(t *testing.T) {
 		},
 		"Fail: claimable in cache, but removed": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
 				cache.EXPECT().Get(claimableOwnerID).Return(nil, true)
 				return &caminoState{
 					claimablesCache: cache,
@@ -65,7 +70,7 @@ func TestGetClaimable(t *testing.T) {
 		},
 		"OK: claimable in cache": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
 				cache.EXPECT().Get(claimableOwnerID).Return(claimable, true)
 				return &caminoState{
 					claimablesCache: cache,
@@ -77,23 +82,45 @@ func TestGetClaimable(t *testing.T) {
 		},
 		"OK: claimable in db": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
 				cache.EXPECT().Get(claimableOwnerID).Return(nil, false)
 				cache.EXPECT().Put(claimableOwnerID, claimable)
 				db := database.NewMockDatabase(c)
 				db.EXPECT().Get(claimableOwnerID[:]).Return(claimableBytes, nil)
+				rewardAssetsDB := database.NewMockDatabase(c)
+				rewardAssetsDB.EXPECT().Get(claimableOwnerID[:]).Return(nil, database.ErrNotFound)
 				return &caminoState{
-					claimablesDB:    db,
-					claimablesCache: cache,
-					caminoDiff:      &caminoDiff{},
+					claimablesDB:            db,
+					claimableRewardAssetsDB: rewardAssetsDB,
+					claimablesCache:         cache,
+					caminoDiff:              &caminoDiff{},
 				}
 			},
 			claimableOwnerID:  claimableOwnerID,
 			expectedClaimable: claimable,
 		},
+		"OK: claimable with reward asset in db": {
+			caminoState: func(c *gomock.Controller) *caminoState {
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
+				cache.EXPECT().Get(claimableOwnerID).Return(nil, false)
+				cache.EXPECT().Put(claimableOwnerID, claimableWithRewardAsset)
+				db := database.NewMockDatabase(c)
+				db.EXPECT().Get(claimableOwnerID[:]).Return(claimableBytes, nil)
+				rewardAssetsDB := database.NewMockDatabase(c)
+				rewardAssetsDB.EXPECT().Get(claimableOwnerID[:]).Return(rewardAssetID[:], nil)
+				return &caminoState{
+					claimablesDB:            db,
+					claimableRewardAssetsDB: rewardAssetsDB,
+					claimablesCache:         cache,
+					caminoDiff:              &caminoDiff{},
+				}
+			},
+			claimableOwnerID:  claimableOwnerID,
+			expectedClaimable: claimableWithRewardAsset,
+		},
 		"Fail: db error": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
 				cache.EXPECT().Get(claimableOwnerID).Return(nil, false)
 				db := database.NewMockDatabase(c)
 				db.EXPECT().Get(claimableOwnerID[:]).Return(nil, testError)
@@ -130,11 +157,11 @@ func TestSetClaimable(t *testing.T) {
 		caminoState         func(*gomock.Controller) *caminoState
 		claimableOwnerID    ids.ID
 		claimable           *Claimable
-		expectedCaminoState func(cache.Cacher) *caminoState
+		expectedCaminoState func(cache.Cacher[ids.ID, *Claimable]) *caminoState
 	}{
 		"OK": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *Claimable](c)
 				cache.EXPECT().Evict(ownerID)
 				return &caminoState{
 					claimablesCache: cache,
@@ -143,7 +170,7 @@ func TestSetClaimable(t *testing.T) {
 			},
 			claimableOwnerID: ownerID,
 			claimable:        claimable,
-			expectedCaminoState: func(claimablesCache cache.Cacher) *caminoState {
+			expectedCaminoState: func(claimablesCache cache.Cacher[ids.ID, *Claimable]) *caminoState {
 				return &caminoState{
 					claimablesCache: claimablesCache,
 					caminoDiff: &caminoDiff{
@@ -238,6 +265,10 @@ func TestWriteClaimableAndValidatorRewards(t *testing.T) {
 	claimable1 := &Claimable{Owner: &secp256k1fx.OutputOwners{}, ValidatorReward: 1, DepositReward: 2}
 	claimableBytes1, err := blocks.GenesisCodec.Marshal(blocks.Version, claimable1)
 	require.NoError(t, err)
+	rewardAssetID := ids.ID{3}
+	claimableWithRewardAsset := &Claimable{Owner: &secp256k1fx.OutputOwners{}, DepositReward: 2, RewardAssetID: rewardAssetID}
+	claimableWithRewardAssetBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, claimableWithRewardAsset)
+	require.NoError(t, err)
 
 	tests := map[string]struct {
 		caminoState         func(*gomock.Controller) *caminoState
@@ -290,6 +321,32 @@ func TestWriteClaimableAndValidatorRewards(t *testing.T) {
 			},
 			expectedErr: testError,
 		},
+		"OK: claimable with reward asset": {
+			caminoState: func(c *gomock.Controller) *caminoState {
+				claimablesDB := database.NewMockDatabase(c)
+				claimablesDB.EXPECT().Put(claimableOwnerID1[:], claimableWithRewardAssetBytes).Return(nil)
+				claimableRewardAssetsDB := database.NewMockDatabase(c)
+				claimableRewardAssetsDB.EXPECT().Put(claimableOwnerID1[:], rewardAssetID[:]).Return(nil)
+				return &caminoState{
+					claimablesDB:            claimablesDB,
+					claimableRewardAssetsDB: claimableRewardAssetsDB,
+					caminoDiff: &caminoDiff{
+						modifiedClaimables: map[ids.ID]*Claimable{
+							claimableOwnerID1: claimableWithRewardAsset,
+						},
+					},
+				}
+			},
+			expectedCaminoState: func(actualState *caminoState) *caminoState {
+				return &caminoState{
+					claimablesDB:            actualState.claimablesDB,
+					claimableRewardAssetsDB: actualState.claimableRewardAssetsDB,
+					caminoDiff: &caminoDiff{
+						modifiedClaimables: map[ids.ID]*Claimable{},
+					},
+				}
+			},
+		},
 		"OK: modifiedNotDistributedValidatorReward is nil": {
 			caminoState: func(c *gomock.Controller) *caminoState {
 				return &caminoState{caminoDiff: &caminoDiff{}}
@@ -346,3 +403,79 @@ func TestWriteClaimableAndValidatorRewards(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClaimablesIterator(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newClaimable := func(reward uint64) *Claimable {
+		return &Claimable{
+			Owner:           &secp256k1fx.OutputOwners{Addrs: []ids.ShortID{}},
+			ValidatorReward: reward,
+		}
+	}
+	collect := func(chain Chain) ([]ids.ID, []*Claimable) {
+		it, err := chain.GetClaimablesIterator()
+		require.NoError(err)
+		defer it.Release()
+		ownerIDs := []ids.ID{}
+		claimables := []*Claimable{}
+		for it.Next() {
+			ownerIDs = append(ownerIDs, it.OwnerID())
+			claimables = append(claimables, it.Value())
+		}
+		require.NoError(it.Error())
+		return ownerIDs, claimables
+	}
+
+	s := newEmptyState(t)
+	s.SetClaimable(ids.ID{5}, newClaimable(5))
+	s.SetClaimable(ids.ID{1}, newClaimable(1))
+	s.SetClaimable(ids.ID{3}, newClaimable(3))
+	require.NoError(s.caminoState.Write(s))
+
+	// persisted claimables
+	ownerIDs, claimables := collect(s)
+	require.Equal([]ids.ID{{1}, {3}, {5}}, ownerIDs)
+	require.Equal([]*Claimable{newClaimable(1), newClaimable(3), newClaimable(5)}, claimables)
+
+	// persisted claimables with not written modifications
+	s.SetClaimable(ids.ID{3}, newClaimable(33))
+	s.SetClaimable(ids.ID{5}, nil)
+	s.SetClaimable(ids.ID{2}, newClaimable(2))
+	ownerIDs, claimables = collect(s)
+	require.Equal([]ids.ID{{1}, {2}, {3}}, ownerIDs)
+	require.Equal([]*Claimable{newClaimable(1), newClaimable(2), newClaimable(33)}, claimables)
+
+	// diff on top of state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).Times(2)
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.SetClaimable(ids.ID{1}, nil)
+	d.SetClaimable(ids.ID{4}, newClaimable(4))
+	d.SetClaimable(ids.ID{6}, nil)
+	ownerIDs, claimables = collect(d)
+	require.Equal([]ids.ID{{2}, {3}, {4}}, ownerIDs)
+	require.Equal([]*Claimable{newClaimable(2), newClaimable(33), newClaimable(4)}, claimables)
+}
+
+func TestClaimableID(t *testing.T) {
+	require := require.New(t)
+	ownerID := ids.ID{1}
+	primaryAssetID := ids.ID{2}
+	rewardAssetID1 := ids.ID{3}
+	rewardAssetID2 := ids.ID{4}
+
+	require.Equal(ownerID, ClaimableID(ownerID, ids.Empty, primaryAssetID))
+	require.Equal(ownerID, ClaimableID(ownerID, primaryAssetID, primaryAssetID))
+
+	claimableID1 := ClaimableID(ownerID, rewardAssetID1, primaryAssetID)
+	claimableID2 := ClaimableID(ownerID, rewardAssetID2, primaryAssetID)
+	require.NotEqual(ownerID, claimableID1)
+	require.NotEqual(ownerID, claimableID2)
+	require.NotEqual(claimableID1, claimableID2)
+	require.Equal(claimableID1, ClaimableID(ownerID, rewardAssetID1, primaryAssetID))
+}
@@ -16,6 +16,8 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 type depositDiff struct {
@@ -45,11 +47,11 @@ func (cs *caminoState) GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
 		return depositDiff.Deposit, nil
 	}
 
-	if depositIntf, ok := cs.depositsCache.Get(depositTxID); ok {
-		if depositIntf == nil {
+	if d, ok := cs.depositsCache.Get(depositTxID); ok {
+		if d == nil {
 			return nil, database.ErrNotFound
 		}
-		return depositIntf.(*deposit.Deposit), nil
+		return d, nil
 	}
 
 	depositBytes, err := cs.depositsDB.Get(depositTxID[:])
@@ -65,11 +67,32 @@ func (cs *caminoState) GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
 		return nil, err
 	}
 
+	if d.RewardOwner, err = cs.getDepositRewardOwner(depositTxID); err != nil {
+		return nil, err
+	}
+
 	cs.depositsCache.Put(depositTxID, d)
 
 	return d, nil
 }
 
+// getDepositRewardOwner returns persisted rewards owner of deposit [depositTxID],
+// that was set after deposit creation, or nil, if there is no such owner.
+func (cs *caminoState) getDepositRewardOwner(depositTxID ids.ID) (*secp256k1fx.OutputOwners, error) {
+	rewardOwnerBytes, err := cs.depositRewardOwnersDB.Get(depositTxID[:])
+	if err == database.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	rewardOwner := &secp256k1fx.OutputOwners{}
+	if _, err := blocks.GenesisCodec.Unmarshal(rewardOwnerBytes, rewardOwner); err != nil {
+		return nil, err
+	}
+	return rewardOwner, nil
+}
+
 func (cs *caminoState) GetNextToUnlockDepositTime(removedDepositIDs set.Set[ids.ID]) (time.Time, error) {
 	if cs.depositsNextToUnlockTime == nil {
 		return mockable.MaxTime, database.ErrNotFound
@@ -156,6 +179,15 @@ func (cs *caminoState) writeDeposits() error {
 			if err := cs.depositIDsByEndtimeDB.Delete(depositToKey(depositTxID[:], depositDiff.Deposit)); err != nil {
 				return err
 			}
+			if err := cs.depositIDsByOfferDB.Delete(ownerPrefixedKey(depositDiff.DepositOfferID, depositTxID)); err != nil {
+				return err
+			}
+			if err := cs.depositRewardOwnersDB.Delete(depositTxID[:]); err != nil {
+				return err
+			}
+			if err := cs.archiveDeposit(depositTxID, depositDiff.Deposit); err != nil {
+				return err
+			}
 		} else {
 			depositBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositDiff.Deposit)
 			if err != nil {
@@ -164,11 +196,23 @@ func (cs *caminoState) writeDeposits() error {
 			if err := cs.depositsDB.Put(depositTxID[:], depositBytes); err != nil {
 				return err
 			}
+			if depositDiff.RewardOwner != nil {
+				rewardOwnerBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositDiff.RewardOwner)
+				if err != nil {
+					return fmt.Errorf("failed to serialize deposit rewards owner: %w", err)
+				}
+				if err := cs.depositRewardOwnersDB.Put(depositTxID[:], rewardOwnerBytes); err != nil {
+					return err
+				}
+			}
 
 			if depositDiff.added {
 				if err := cs.depositIDsByEndtimeDB.Put(depositToKey(depositTxID[:], depositDiff.Deposit), nil); err != nil {
 					return err
 				}
+				if err := cs.depositIDsByOfferDB.Put(ownerPrefixedKey(depositDiff.DepositOfferID, depositTxID), nil); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -242,6 +286,143 @@ func (cs caminoState) getNextToUnlockDepositIDsAndTimeFromDB(removedDepositIDs s
 	return nextDeposits, time.Unix(int64(nextDepositsEndTimestamp), 0), nil
 }
 
+// depositIDsByRewardOwner returns sorted ids of deposits, which rewards are owned by [ownerID].
+func (cs *caminoState) depositIDsByRewardOwner(s *state, ownerID ids.ID) ([]ids.ID, error) {
+	depositIDs := set.Set[ids.ID]{}
+
+	iterator := cs.depositIDsByRewardOwnerDB.NewIteratorWithPrefix(ownerID[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		depositID, err := ids.ToID(iterator.Key()[len(ownerID):])
+		if err != nil {
+			return nil, err
+		}
+		depositIDs.Add(depositID)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	for depositTxID, depositDiff := range cs.modifiedDeposits {
+		switch {
+		case depositDiff.removed:
+			depositIDs.Remove(depositTxID)
+		case depositDiff.added || depositDiff.RewardOwner != nil:
+			depositOwnerID, err := depositRewardOwnerID(s, depositTxID, depositDiff.Deposit)
+			if err != nil {
+				return nil, err
+			}
+			if depositOwnerID == ownerID {
+				depositIDs.Add(depositTxID)
+			} else {
+				depositIDs.Remove(depositTxID)
+			}
+		}
+	}
+
+	depositIDsList := depositIDs.List()
+	utils.Sort(depositIDsList)
+	return depositIDsList, nil
+}
+
+// writeDepositIDsByRewardOwner must be called before writeDeposits,
+// because writeDeposits clears modified deposits.
+func (cs *caminoState) writeDepositIDsByRewardOwner(s *state) error {
+	for depositTxID, depositDiff := range cs.modifiedDeposits {
+		if !depositDiff.added && !depositDiff.removed && depositDiff.RewardOwner == nil {
+			continue
+		}
+
+		// removing deposit from index of its persisted owner
+		if !depositDiff.added {
+			persistedRewardOwner, err := cs.getDepositRewardOwner(depositTxID)
+			if err != nil {
+				return err
+			}
+			oldOwnerID, err := depositRewardOwnerID(s, depositTxID, &deposit.Deposit{RewardOwner: persistedRewardOwner})
+			if err != nil {
+				return err
+			}
+			if err := cs.depositIDsByRewardOwnerDB.Delete(ownerPrefixedKey(oldOwnerID, depositTxID)); err != nil {
+				return err
+			}
+		}
+
+		if depositDiff.removed {
+			continue
+		}
+
+		ownerID, err := depositRewardOwnerID(s, depositTxID, depositDiff.Deposit)
+		if err != nil {
+			return err
+		}
+		if err := cs.depositIDsByRewardOwnerDB.Put(ownerPrefixedKey(ownerID, depositTxID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadDepositIDsByRewardOwner builds deposits by reward owner index from existing deposits,
+// if database was created before index was introduced.
+func (cs *caminoState) loadDepositIDsByRewardOwner(s *state) error {
+	if _, err := database.GetBool(cs.caminoDB, depositRewardOwnerIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	depositIterator := cs.depositsDB.NewIterator()
+	defer depositIterator.Release()
+	for depositIterator.Next() {
+		depositTxID, err := ids.ToID(depositIterator.Key())
+		if err != nil {
+			return err
+		}
+		ownerID, err := depositRewardOwnerID(s, depositTxID, &deposit.Deposit{})
+		if err != nil {
+			return err
+		}
+		if err := cs.depositIDsByRewardOwnerDB.Put(ownerPrefixedKey(ownerID, depositTxID), nil); err != nil {
+			return err
+		}
+	}
+	if err := depositIterator.Error(); err != nil {
+		return err
+	}
+
+	return database.PutBool(cs.caminoDB, depositRewardOwnerIndexKey, true)
+}
+
+// depositRewardOwnerID returns owner id of deposit rewards owner.
+func depositRewardOwnerID(chain Chain, depositTxID ids.ID, d *deposit.Deposit) (ids.ID, error) {
+	rewardsOwner, err := DepositRewardsOwner(chain, depositTxID, d)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return txs.GetOwnerID(rewardsOwner)
+}
+
+// DepositRewardsOwner returns rewards owner of deposit [d], created by tx [depositTxID].
+// If rewards owner was changed after deposit creation, its new owner is returned.
+func DepositRewardsOwner(chain Chain, depositTxID ids.ID, d *deposit.Deposit) (*secp256k1fx.OutputOwners, error) {
+	if d.RewardOwner != nil {
+		return d.RewardOwner, nil
+	}
+
+	tx, _, err := chain.GetTx(depositTxID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deposit tx %s: %w", depositTxID, err)
+	}
+	rewardsOwner, err := txs.DepositRewardsOwner(tx.Unsigned)
+	if err != nil {
+		return nil, err
+	}
+	secpOwner, ok := rewardsOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return nil, fmt.Errorf("%w: rewards owner is %T", errWrongTxType, rewardsOwner)
+	}
+	return secpOwner, nil
+}
+
 // depositTxID must be ids.ID 32 bytes
 func depositToKey(depositTxID []byte, deposit *deposit.Deposit) []byte {
 	depositSortKey := make([]byte, 8+32)
@@ -258,3 +439,69 @@ func bytesToDepositIDAndEndtime(depositSortKeyBytes []byte) (ids.ID, uint64, err
 	}
 	return depositID, binary.BigEndian.Uint64(depositSortKeyBytes[:8]), nil
 }
+
+// GetDepositIDsByOffer returns sorted ids of deposits, which were created with offer [offerID].
+func (cs *caminoState) GetDepositIDsByOffer(offerID ids.ID) ([]ids.ID, error) {
+	depositIDs := set.Set[ids.ID]{}
+
+	iterator := cs.depositIDsByOfferDB.NewIteratorWithPrefix(offerID[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		depositID, err := ids.ToID(iterator.Key()[len(offerID):])
+		if err != nil {
+			return nil, err
+		}
+		depositIDs.Add(depositID)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return overrideDepositIDsByOffer(depositIDs, cs.modifiedDeposits, offerID), nil
+}
+
+// overrideDepositIDsByOffer overrides [depositIDs] of [offerID] with [modified] deposits
+// and returns them sorted.
+func overrideDepositIDsByOffer(depositIDs set.Set[ids.ID], modified map[ids.ID]*depositDiff, offerID ids.ID) []ids.ID {
+	for depositTxID, depositDiff := range modified {
+		switch {
+		case depositDiff.removed:
+			depositIDs.Remove(depositTxID)
+		case depositDiff.added && depositDiff.DepositOfferID == offerID:
+			depositIDs.Add(depositTxID)
+		}
+	}
+
+	depositIDsList := depositIDs.List()
+	utils.Sort(depositIDsList)
+	return depositIDsList
+}
+
+// loadDepositIDsByOffer builds deposits by offer index from existing deposits,
+// if database was created before index was introduced.
+func (cs *caminoState) loadDepositIDsByOffer() error {
+	if _, err := database.GetBool(cs.caminoDB, depositOfferIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	depositIterator := cs.depositsDB.NewIterator()
+	defer depositIterator.Release()
+	for depositIterator.Next() {
+		depositTxID, err := ids.ToID(depositIterator.Key())
+		if err != nil {
+			return err
+		}
+		d := &deposit.Deposit{}
+		if _, err := blocks.GenesisCodec.Unmarshal(depositIterator.Value(), d); err != nil {
+			return err
+		}
+		if err := cs.depositIDsByOfferDB.Put(ownerPrefixedKey(d.DepositOfferID, depositTxID), nil); err != nil {
+			return err
+		}
+	}
+	if err := depositIterator.Error(); err != nil {
+		return err
+	}
+
+	return database.PutBool(cs.caminoDB, depositOfferIndexKey, true)
+}
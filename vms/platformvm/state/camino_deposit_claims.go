@@ -0,0 +1,63 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+func (cs *caminoState) AddDepositClaim(depositTxID ids.ID, claim *deposit.Claim) {
+	cs.addedDepositClaims[depositTxID] = append(cs.addedDepositClaims[depositTxID], claim)
+}
+
+// GetDepositClaims returns claims of deposit [depositTxID] in order of their timestamps.
+func (cs *caminoState) GetDepositClaims(depositTxID ids.ID) ([]*deposit.Claim, error) {
+	var claims []*deposit.Claim
+
+	iterator := cs.depositClaimsDB.NewIteratorWithPrefix(depositTxID[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		claim := &deposit.Claim{}
+		if _, err := blocks.GenesisCodec.Unmarshal(iterator.Value(), claim); err != nil {
+			return nil, err
+		}
+		claims = append(claims, claim)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return append(claims, cs.addedDepositClaims[depositTxID]...), nil
+}
+
+func (cs *caminoState) writeDepositClaims() error {
+	for depositTxID, claims := range cs.addedDepositClaims {
+		delete(cs.addedDepositClaims, depositTxID)
+		for _, claim := range claims {
+			claimBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, claim)
+			if err != nil {
+				return fmt.Errorf("failed to serialize deposit claim: %w", err)
+			}
+			if err := cs.depositClaimsDB.Put(depositClaimKey(depositTxID, claim), claimBytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// depositClaimKey returns depositTxID + big-endian timestamp + claimTxID,
+// so claims of the same deposit are sorted by their timestamps.
+func depositClaimKey(depositTxID ids.ID, claim *deposit.Claim) []byte {
+	key := make([]byte, 32+8+32)
+	copy(key, depositTxID[:])
+	binary.BigEndian.PutUint64(key[32:], claim.Timestamp)
+	copy(key[40:], claim.ClaimTxID[:])
+	return key
+}
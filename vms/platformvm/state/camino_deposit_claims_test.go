@@ -0,0 +1,74 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+func TestGetDepositClaims(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+
+	depositTxID1 := ids.ID{1}
+	depositTxID2 := ids.ID{2}
+	claim1 := &deposit.Claim{ClaimTxID: ids.ID{11}, Timestamp: 20, Amount: 1}
+	claim2 := &deposit.Claim{ClaimTxID: ids.ID{12}, Timestamp: 10, Amount: 2}
+	claim3 := &deposit.Claim{ClaimTxID: ids.ID{13}, Timestamp: 30, Amount: 3}
+	s.AddDepositClaim(depositTxID1, claim2)
+	s.AddDepositClaim(depositTxID1, claim1)
+	s.AddDepositClaim(depositTxID2, claim3)
+
+	// not written claims
+	claims, err := s.GetDepositClaims(depositTxID1)
+	require.NoError(err)
+	require.Equal([]*deposit.Claim{claim2, claim1}, claims)
+
+	require.NoError(cs.writeDepositClaims())
+	require.Empty(cs.addedDepositClaims)
+
+	// written claims
+	claims, err = s.GetDepositClaims(depositTxID1)
+	require.NoError(err)
+	require.Equal([]*deposit.Claim{claim2, claim1}, claims)
+	claims, err = s.GetDepositClaims(depositTxID2)
+	require.NoError(err)
+	require.Equal([]*deposit.Claim{claim3}, claims)
+	claims, err = s.GetDepositClaims(ids.ID{3})
+	require.NoError(err)
+	require.Empty(claims)
+
+	// diff overlay
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	claim4 := &deposit.Claim{ClaimTxID: ids.ID{14}, Timestamp: 40, Amount: 4}
+	d.AddDepositClaim(depositTxID1, claim4)
+
+	claims, err = d.GetDepositClaims(depositTxID1)
+	require.NoError(err)
+	require.Equal([]*deposit.Claim{claim2, claim1, claim4}, claims)
+
+	// claims are kept after deposit removal
+	s.AddDeposit(depositTxID2, &deposit.Deposit{Duration: 1})
+	require.NoError(cs.writeDeposits())
+	s.RemoveDeposit(depositTxID2, &deposit.Deposit{Duration: 1})
+	require.NoError(cs.writeDeposits())
+	claims, err = s.GetDepositClaims(depositTxID2)
+	require.NoError(err)
+	require.Equal([]*deposit.Claim{claim3}, claims)
+}
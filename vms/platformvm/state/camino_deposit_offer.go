@@ -4,7 +4,9 @@
 package state
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
@@ -12,6 +14,31 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 )
 
+var errDepositOfferVersionMismatch = errors.New("deposit offer version mismatch")
+
+// depositOfferExtension holds offer data, that isn't part of serialized offer
+type depositOfferExtension struct {
+	Version                uint64 `serialize:"true"`
+	deposit.OfferExtension `serialize:"true"`
+}
+
+func newDepositOfferExtension(offer *deposit.Offer) *depositOfferExtension {
+	return &depositOfferExtension{
+		Version:        offer.Version,
+		OfferExtension: offer.Extension(),
+	}
+}
+
+func (e *depositOfferExtension) isEmpty() bool {
+	return e.Version == 0 && e.OfferExtension.IsEmpty()
+}
+
+// applyTo sets [offer] fields from extension
+func (e *depositOfferExtension) applyTo(offer *deposit.Offer) {
+	offer.Version = e.Version
+	e.OfferExtension.ApplyTo(offer)
+}
+
 func (cs *caminoState) SetDepositOffer(offer *deposit.Offer) {
 	cs.modifiedDepositOffers[offer.ID] = offer
 }
@@ -26,7 +53,8 @@ func (cs *caminoState) GetDepositOffer(offerID ids.ID) (*deposit.Offer, error) {
 	// Try to get it from state
 	if !ok {
 		if offer, ok = cs.depositOffers[offerID]; !ok {
-			return nil, database.ErrNotFound
+			// Try to get it from archive
+			return cs.getArchivedDepositOffer(offerID)
 		}
 	}
 	return offer, nil
@@ -47,9 +75,17 @@ func (cs *caminoState) GetAllDepositOffers() ([]*deposit.Offer, error) {
 		}
 	}
 
+	sortDepositOffers(offers)
 	return offers, nil
 }
 
+// sortDepositOffers sorts [offers] by their ids, so offers order doesn't depend on map iteration.
+func sortDepositOffers(offers []*deposit.Offer) {
+	sort.Slice(offers, func(i, j int) bool {
+		return offers[i].ID.Less(offers[j].ID)
+	})
+}
+
 func (cs *caminoState) loadDepositOffers() error {
 	depositOffersIt := cs.depositOffersDB.NewIterator()
 	defer depositOffersIt.Release()
@@ -65,6 +101,9 @@ func (cs *caminoState) loadDepositOffers() error {
 		if _, err := blocks.GenesisCodec.Unmarshal(depositOfferBytes, depositOffer); err != nil {
 			return err
 		}
+		if err := cs.loadDepositOfferExtension(depositOffer); err != nil {
+			return err
+		}
 
 		cs.depositOffers[depositOfferID] = depositOffer
 	}
@@ -83,6 +122,9 @@ func (cs *caminoState) writeDepositOffers() error {
 			if err := cs.depositOffersDB.Delete(offerID[:]); err != nil {
 				return err
 			}
+			if err := cs.depositOfferExtensionsDB.Delete(offerID[:]); err != nil {
+				return err
+			}
 			delete(cs.depositOffers, offerID)
 		} else {
 			offerBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, offer)
@@ -92,8 +134,55 @@ func (cs *caminoState) writeDepositOffers() error {
 			if err := cs.depositOffersDB.Put(offerID[:], offerBytes); err != nil {
 				return err
 			}
+			if extension := newDepositOfferExtension(offer); extension.isEmpty() {
+				if err := cs.depositOfferExtensionsDB.Delete(offerID[:]); err != nil {
+					return err
+				}
+			} else {
+				extensionBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, extension)
+				if err != nil {
+					return fmt.Errorf("failed to serialize deposit offer extension: %w", err)
+				}
+				if err := cs.depositOfferExtensionsDB.Put(offerID[:], extensionBytes); err != nil {
+					return err
+				}
+			}
 			cs.depositOffers[offerID] = offer
 		}
 	}
 	return nil
 }
+
+// loadDepositOfferExtension sets [offer] fields, that are persisted separately from offer bytes.
+func (cs *caminoState) loadDepositOfferExtension(offer *deposit.Offer) error {
+	extensionBytes, err := cs.depositOfferExtensionsDB.Get(offer.ID[:])
+	if err == database.ErrNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	extension := &depositOfferExtension{}
+	if _, err := blocks.GenesisCodec.Unmarshal(extensionBytes, extension); err != nil {
+		return err
+	}
+	extension.applyTo(offer)
+	return nil
+}
+
+// UpdateDepositOffer replaces existing deposit offer with [offer] and increments its version.
+// Version of [offer] must be equal to the version of the current offer,
+// so updates based on outdated offer are rejected.
+func UpdateDepositOffer(chain Chain, offer *deposit.Offer) error {
+	currentOffer, err := chain.GetDepositOffer(offer.ID)
+	if err != nil {
+		return fmt.Errorf("couldn't get deposit offer %s: %w", offer.ID, err)
+	}
+	if offer.Version != currentOffer.Version {
+		return fmt.Errorf("%w: expected %d, got %d",
+			errDepositOfferVersionMismatch, currentOffer.Version, offer.Version)
+	}
+	updatedOffer := *offer
+	updatedOffer.Version++
+	chain.SetDepositOffer(&updatedOffer)
+	return nil
+}
@@ -281,7 +281,16 @@ func TestGetAllDepositOffers(t *testing.T) {
 func TestWriteDepositOffers(t *testing.T) {
 	depositOffer1 := &deposit.Offer{ID: ids.ID{1}}
 	depositOffer2 := &deposit.Offer{ID: ids.ID{2}}
-	depositOffer2modified := &deposit.Offer{ID: ids.ID{2}, MinAmount: 1}
+	depositOffer2modified := &deposit.Offer{
+		ID:            ids.ID{2},
+		Version:       2,
+		Tiers:         []deposit.InterestRateTier{{MinAmount: 2, InterestRateNominator: 3}},
+		MinAmount:     1,
+		Name:          "name",
+		URI:           "uri",
+		RewardAssetID: ids.ID{22},
+		OwnerAddress:  ids.ShortID{23},
+	}
 	depositOffer3 := &deposit.Offer{ID: ids.ID{3}}
 	depositOffer4 := &deposit.Offer{ID: ids.ID{4}}
 	depositOffer2modifiedBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositOffer2modified)
@@ -290,6 +299,19 @@ func TestWriteDepositOffers(t *testing.T) {
 	require.NoError(t, err)
 	depositOffer3Bytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositOffer3)
 	require.NoError(t, err)
+	depositOffer2modifiedExtensionBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &depositOfferExtension{
+		Version: depositOffer2modified.Version,
+		OfferExtension: deposit.OfferExtension{
+			Tiers: depositOffer2modified.Tiers,
+			Name:  depositOffer2modified.Name,
+			URI:   depositOffer2modified.URI,
+
+			RewardAssetID: depositOffer2modified.RewardAssetID,
+
+			OwnerAddress: depositOffer2modified.OwnerAddress,
+		},
+	})
+	require.NoError(t, err)
 	testError := errors.New("test error")
 
 	tests := map[string]struct {
@@ -349,6 +371,10 @@ func TestWriteDepositOffers(t *testing.T) {
 				depositOffersDB.EXPECT().Put(depositOffer2.ID[:], depositOffer2modifiedBytes).Return(nil)
 				depositOffersDB.EXPECT().Put(depositOffer3.ID[:], depositOffer3Bytes).Return(nil)
 				depositOffersDB.EXPECT().Delete(depositOffer4.ID[:]).Return(nil)
+				depositOfferExtensionsDB := database.NewMockDatabase(c)
+				depositOfferExtensionsDB.EXPECT().Put(depositOffer2.ID[:], depositOffer2modifiedExtensionBytes).Return(nil)
+				depositOfferExtensionsDB.EXPECT().Delete(depositOffer3.ID[:]).Return(nil)
+				depositOfferExtensionsDB.EXPECT().Delete(depositOffer4.ID[:]).Return(nil)
 				return &caminoState{
 					depositOffers: map[ids.ID]*deposit.Offer{
 						depositOffer1.ID: depositOffer1,
@@ -362,7 +388,8 @@ func TestWriteDepositOffers(t *testing.T) {
 							depositOffer4.ID: nil,
 						},
 					},
-					depositOffersDB: depositOffersDB,
+					depositOffersDB:          depositOffersDB,
+					depositOfferExtensionsDB: depositOfferExtensionsDB,
 				}
 			},
 			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
@@ -375,7 +402,8 @@ func TestWriteDepositOffers(t *testing.T) {
 					caminoDiff: &caminoDiff{
 						modifiedDepositOffers: map[ids.ID]*deposit.Offer{},
 					},
-					depositOffersDB: actualCaminoState.depositOffersDB,
+					depositOffersDB:          actualCaminoState.depositOffersDB,
+					depositOfferExtensionsDB: actualCaminoState.depositOfferExtensionsDB,
 				}
 			},
 		},
@@ -393,7 +421,12 @@ func TestWriteDepositOffers(t *testing.T) {
 
 func TestLoadDepositOffers(t *testing.T) {
 	depositOffer1 := &deposit.Offer{ID: ids.ID{1}, Memo: []byte("1")}
-	depositOffer2 := &deposit.Offer{ID: ids.ID{2}, Memo: []byte("2")}
+	depositOffer2 := &deposit.Offer{
+		ID:      ids.ID{2},
+		Version: 3,
+		Tiers:   []deposit.InterestRateTier{{MinAmount: 2, InterestRateNominator: 3}},
+		Memo:    []byte("2"),
+	}
 	depositOffer3 := &deposit.Offer{ID: ids.ID{3}, Memo: []byte("3")}
 	depositOffer1Bytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositOffer1)
 	require.NoError(t, err)
@@ -401,6 +434,11 @@ func TestLoadDepositOffers(t *testing.T) {
 	require.NoError(t, err)
 	depositOffer3Bytes, err := blocks.GenesisCodec.Marshal(blocks.Version, depositOffer3)
 	require.NoError(t, err)
+	depositOffer2ExtensionBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &depositOfferExtension{
+		Version:        depositOffer2.Version,
+		OfferExtension: deposit.OfferExtension{Tiers: depositOffer2.Tiers},
+	})
+	require.NoError(t, err)
 
 	tests := map[string]struct {
 		caminoState         func(*gomock.Controller) *caminoState
@@ -423,14 +461,20 @@ func TestLoadDepositOffers(t *testing.T) {
 
 				depositOffersDB := database.NewMockDatabase(c)
 				depositOffersDB.EXPECT().NewIterator().Return(offersIterator)
+				depositOfferExtensionsDB := database.NewMockDatabase(c)
+				depositOfferExtensionsDB.EXPECT().Get(depositOffer1.ID[:]).Return(nil, database.ErrNotFound)
+				depositOfferExtensionsDB.EXPECT().Get(depositOffer2.ID[:]).Return(depositOffer2ExtensionBytes, nil)
+				depositOfferExtensionsDB.EXPECT().Get(depositOffer3.ID[:]).Return(nil, database.ErrNotFound)
 				return &caminoState{
-					depositOffers:   map[ids.ID]*deposit.Offer{},
-					depositOffersDB: depositOffersDB,
+					depositOffers:            map[ids.ID]*deposit.Offer{},
+					depositOffersDB:          depositOffersDB,
+					depositOfferExtensionsDB: depositOfferExtensionsDB,
 				}
 			},
 			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
 				return &caminoState{
-					depositOffersDB: actualCaminoState.depositOffersDB,
+					depositOffersDB:          actualCaminoState.depositOffersDB,
+					depositOfferExtensionsDB: actualCaminoState.depositOfferExtensionsDB,
 					depositOffers: map[ids.ID]*deposit.Offer{
 						depositOffer1.ID: depositOffer1,
 						depositOffer2.ID: depositOffer2,
@@ -468,3 +512,48 @@ func TestLoadDepositOffers(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateDepositOffer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parentStateID := ids.GenerateTestID()
+	s := newEmptyState(t)
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+
+	offer := &deposit.Offer{ID: ids.ID{1}, End: 10, Memo: []byte{1}}
+	s.SetDepositOffer(offer)
+	require.NoError(s.caminoState.Write(s))
+
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+
+	// offer from the state is updated, version is incremented
+	updatedOffer := *offer
+	updatedOffer.Flags = deposit.OfferFlagLocked
+	require.NoError(UpdateDepositOffer(d, &updatedOffer))
+	diffOffer, err := d.GetDepositOffer(offer.ID)
+	require.NoError(err)
+	require.Equal(uint64(1), diffOffer.Version)
+	require.Equal(deposit.OfferFlagLocked, diffOffer.Flags)
+	require.Zero(updatedOffer.Version)
+
+	// update of outdated offer version is rejected
+	require.ErrorIs(UpdateDepositOffer(d, &updatedOffer), errDepositOfferVersionMismatch)
+
+	// not existing offer can't be updated
+	require.ErrorIs(UpdateDepositOffer(d, &deposit.Offer{ID: ids.ID{2}}), database.ErrNotFound)
+
+	// version is persisted
+	d.Apply(s)
+	require.NoError(s.caminoState.Write(s))
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	cs.depositOffers = map[ids.ID]*deposit.Offer{}
+	require.NoError(cs.loadDepositOffers())
+	stateOffer, err := s.GetDepositOffer(offer.ID)
+	require.NoError(err)
+	require.Equal(diffOffer, stateOffer)
+}
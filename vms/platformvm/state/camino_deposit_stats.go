@@ -0,0 +1,213 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+// DepositStats holds aggregates over all active deposits
+type DepositStats struct {
+	// Sum of active (not unlocked) principal of deposits by their offer ids
+	ActiveAmounts map[ids.ID]uint64
+	// Sum of active (not unlocked) principal of all deposits
+	TotalActiveAmount uint64
+	// Sum of not claimed primary network asset rewards of all deposits
+	OutstandingRewards uint64
+}
+
+// GetDepositStats returns aggregates over active deposits, that are written to database.
+func (cs *caminoState) GetDepositStats() (*DepositStats, error) {
+	stats := &DepositStats{ActiveAmounts: map[ids.ID]uint64{}}
+
+	iterator := cs.depositStatsDB.NewIterator()
+	defer iterator.Release()
+	for iterator.Next() {
+		offerID, err := ids.ToID(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		activeAmount, err := database.ParseUInt64(iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		stats.ActiveAmounts[offerID] = activeAmount
+		stats.TotalActiveAmount += activeAmount
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	outstandingRewards, err := database.GetUInt64(cs.caminoDB, depositOutstandingRewardsKey)
+	if err != nil && err != database.ErrNotFound {
+		return nil, err
+	}
+	stats.OutstandingRewards = outstandingRewards
+	return stats, nil
+}
+
+// writeDepositStats must be called before writeDeposits,
+// because it needs modified deposits and their persisted versions.
+func (cs *caminoState) writeDepositStats() error {
+	activeAmountsDiff := map[ids.ID]aggregateDiff{}
+	outstandingRewardsDiff := aggregateDiff{}
+
+	for depositTxID, depositDiff := range cs.modifiedDeposits {
+		if !depositDiff.added {
+			oldDeposit, err := cs.getPersistedDeposit(depositTxID)
+			if err != nil {
+				return err
+			}
+			activeAmount, outstandingReward, err := cs.depositStatsContribution(oldDeposit)
+			if err != nil {
+				return err
+			}
+			diff := activeAmountsDiff[oldDeposit.DepositOfferID]
+			diff.sub += activeAmount
+			activeAmountsDiff[oldDeposit.DepositOfferID] = diff
+			outstandingRewardsDiff.sub += outstandingReward
+		}
+		if !depositDiff.removed {
+			activeAmount, outstandingReward, err := cs.depositStatsContribution(depositDiff.Deposit)
+			if err != nil {
+				return err
+			}
+			diff := activeAmountsDiff[depositDiff.DepositOfferID]
+			diff.add += activeAmount
+			activeAmountsDiff[depositDiff.DepositOfferID] = diff
+			outstandingRewardsDiff.add += outstandingReward
+		}
+	}
+
+	for offerID, diff := range activeAmountsDiff {
+		activeAmount, err := database.GetUInt64(cs.depositStatsDB, offerID[:])
+		if err != nil && err != database.ErrNotFound {
+			return err
+		}
+		activeAmount = diff.applyTo(activeAmount)
+		if activeAmount == 0 {
+			err = cs.depositStatsDB.Delete(offerID[:])
+		} else {
+			err = database.PutUInt64(cs.depositStatsDB, offerID[:], activeAmount)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if outstandingRewardsDiff.add == outstandingRewardsDiff.sub {
+		return nil
+	}
+	outstandingRewards, err := database.GetUInt64(cs.caminoDB, depositOutstandingRewardsKey)
+	if err != nil && err != database.ErrNotFound {
+		return err
+	}
+	return database.PutUInt64(cs.caminoDB, depositOutstandingRewardsKey, outstandingRewardsDiff.applyTo(outstandingRewards))
+}
+
+// loadDepositStats builds deposit stats from existing deposits,
+// if database was created before deposit stats were introduced.
+// Must be called after deposit offers are loaded.
+func (cs *caminoState) loadDepositStats() error {
+	if _, err := database.GetBool(cs.caminoDB, depositStatsIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	activeAmounts := map[ids.ID]uint64{}
+	outstandingRewards := uint64(0)
+
+	depositIterator := cs.depositsDB.NewIterator()
+	defer depositIterator.Release()
+	for depositIterator.Next() {
+		d := &deposit.Deposit{}
+		if _, err := blocks.GenesisCodec.Unmarshal(depositIterator.Value(), d); err != nil {
+			return err
+		}
+		activeAmount, outstandingReward, err := cs.depositStatsContribution(d)
+		if err != nil {
+			return err
+		}
+		activeAmounts[d.DepositOfferID] = saturatingAdd(activeAmounts[d.DepositOfferID], activeAmount)
+		outstandingRewards = saturatingAdd(outstandingRewards, outstandingReward)
+	}
+	if err := depositIterator.Error(); err != nil {
+		return err
+	}
+
+	for offerID, activeAmount := range activeAmounts {
+		if activeAmount == 0 {
+			continue
+		}
+		if err := database.PutUInt64(cs.depositStatsDB, offerID[:], activeAmount); err != nil {
+			return err
+		}
+	}
+	if err := database.PutUInt64(cs.caminoDB, depositOutstandingRewardsKey, outstandingRewards); err != nil {
+		return err
+	}
+	return database.PutBool(cs.caminoDB, depositStatsIndexKey, true)
+}
+
+// getPersistedDeposit returns deposit as it is written to database, ignoring not written modifications.
+func (cs *caminoState) getPersistedDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
+	depositBytes, err := cs.depositsDB.Get(depositTxID[:])
+	if err != nil {
+		return nil, err
+	}
+	d := &deposit.Deposit{}
+	if _, err := blocks.GenesisCodec.Unmarshal(depositBytes, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// depositStatsContribution returns active principal and not claimed reward of deposit [d].
+// If deposit offer is missing, deposit reward can't be calculated, so only principal is aggregated.
+func (cs *caminoState) depositStatsContribution(d *deposit.Deposit) (uint64, uint64, error) {
+	activeAmount := saturatingSub(d.Amount, d.UnlockedAmount)
+	offer, err := cs.GetDepositOffer(d.DepositOfferID)
+	if err == database.ErrNotFound {
+		return activeAmount, 0, nil
+	} else if err != nil {
+		return 0, 0, err
+	}
+	if offer.RewardAssetID != ids.Empty {
+		// rewards in other assets aren't aggregated
+		return activeAmount, 0, nil
+	}
+	outstandingReward := saturatingSub(d.TotalReward(offer), d.ClaimedRewardAmount)
+	return activeAmount, outstandingReward, nil
+}
+
+// aggregateDiff accumulates additions and subtractions of aggregate value separately,
+// so it doesn't overflow or underflow in the middle of aggregation
+type aggregateDiff struct {
+	add, sub uint64
+}
+
+// applyTo returns [value] with accumulated diff applied to it.
+// Result is clamped to [0, MaxUint64]. Aggregates are informational,
+// so they must never fail block acceptance.
+func (d aggregateDiff) applyTo(value uint64) uint64 {
+	return saturatingSub(saturatingAdd(value, d.add), d.sub)
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if sum := a + b; sum >= a {
+		return sum
+	}
+	return math.MaxUint64
+}
+
+func saturatingSub(a, b uint64) uint64 {
+	if a < b {
+		return 0
+	}
+	return a - b
+}
@@ -0,0 +1,117 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositStats(t *testing.T) {
+	require := require.New(t)
+
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+
+	offer1 := &deposit.Offer{ID: ids.ID{1}, InterestRateNominator: 100_000_000_000}
+	offer2 := &deposit.Offer{ID: ids.ID{2}, InterestRateNominator: 200_000_000_000}
+	s.SetDepositOffer(offer1)
+	s.SetDepositOffer(offer2)
+	require.NoError(cs.writeDepositOffers())
+
+	depositTxID1 := ids.ID{11}
+	depositTxID2 := ids.ID{12}
+	depositTxID3 := ids.ID{13}
+	deposit1 := &deposit.Deposit{DepositOfferID: offer1.ID, Duration: 100, Amount: 1000}
+	deposit2 := &deposit.Deposit{DepositOfferID: offer1.ID, Duration: 200, Amount: 2000}
+	deposit3 := &deposit.Deposit{DepositOfferID: offer2.ID, Duration: 300, Amount: 3000}
+	s.AddDeposit(depositTxID1, deposit1)
+	s.AddDeposit(depositTxID2, deposit2)
+	s.AddDeposit(depositTxID3, deposit3)
+
+	// not written deposits aren't counted
+	stats, err := s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(&DepositStats{ActiveAmounts: map[ids.ID]uint64{}}, stats)
+
+	// added deposits
+	require.NoError(cs.writeDepositStats())
+	require.NoError(cs.writeDeposits())
+
+	stats, err = s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(&DepositStats{
+		ActiveAmounts:     map[ids.ID]uint64{offer1.ID: 3000, offer2.ID: 3000},
+		TotalActiveAmount: 6000,
+		OutstandingRewards: deposit1.TotalReward(offer1) +
+			deposit2.TotalReward(offer1) +
+			deposit3.TotalReward(offer2),
+	}, stats)
+
+	// claimed and partially unlocked deposit
+	modifiedDeposit2 := &deposit.Deposit{
+		DepositOfferID:      offer1.ID,
+		Duration:            200,
+		Amount:              2000,
+		UnlockedAmount:      500,
+		ClaimedRewardAmount: 10,
+	}
+	s.ModifyDeposit(depositTxID2, modifiedDeposit2)
+	require.NoError(cs.writeDepositStats())
+	require.NoError(cs.writeDeposits())
+
+	stats, err = s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(&DepositStats{
+		ActiveAmounts:     map[ids.ID]uint64{offer1.ID: 2500, offer2.ID: 3000},
+		TotalActiveAmount: 5500,
+		OutstandingRewards: deposit1.TotalReward(offer1) +
+			deposit2.TotalReward(offer1) - 10 +
+			deposit3.TotalReward(offer2),
+	}, stats)
+
+	// removed deposits
+	s.RemoveDeposit(depositTxID1, deposit1)
+	s.RemoveDeposit(depositTxID3, deposit3)
+	require.NoError(cs.writeDepositStats())
+	require.NoError(cs.writeDeposits())
+
+	expectedStats := &DepositStats{
+		ActiveAmounts:      map[ids.ID]uint64{offer1.ID: 1500},
+		TotalActiveAmount:  1500,
+		OutstandingRewards: deposit2.TotalReward(offer1) - 10,
+	}
+	stats, err = s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(expectedStats, stats)
+
+	// stats are built for state without them
+	require.NoError(cs.depositStatsDB.Delete(offer1.ID[:]))
+	require.NoError(cs.caminoDB.Delete(depositOutstandingRewardsKey))
+	require.NoError(cs.caminoDB.Delete(depositStatsIndexKey))
+	require.NoError(cs.loadDepositStats())
+
+	stats, err = s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(expectedStats, stats)
+
+	// deposit with missing offer contributes only its principal
+	depositTxID4 := ids.ID{14}
+	deposit4 := &deposit.Deposit{DepositOfferID: ids.ID{3}, Duration: 400, Amount: 4000}
+	s.AddDeposit(depositTxID4, deposit4)
+	require.NoError(cs.writeDepositStats())
+	require.NoError(cs.writeDeposits())
+
+	stats, err = s.GetDepositStats()
+	require.NoError(err)
+	require.Equal(&DepositStats{
+		ActiveAmounts:      map[ids.ID]uint64{offer1.ID: 1500, deposit4.DepositOfferID: 4000},
+		TotalActiveAmount:  5500,
+		OutstandingRewards: deposit2.TotalReward(offer1) - 10,
+	}, stats)
+}
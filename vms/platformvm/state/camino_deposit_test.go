@@ -11,10 +11,14 @@ import (
 	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
@@ -24,6 +28,10 @@ func TestGetDeposit(t *testing.T) {
 	deposit1 := &deposit.Deposit{Duration: 101}
 	depositBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, deposit1)
 	require.NoError(t, err)
+	rewardOwner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	rewardOwnerBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, rewardOwner)
+	require.NoError(t, err)
+	depositWithRewardOwner := &deposit.Deposit{Duration: 101, RewardOwner: rewardOwner}
 	testError := errors.New("test error")
 
 	tests := map[string]struct {
@@ -57,7 +65,7 @@ func TestGetDeposit(t *testing.T) {
 		},
 		"Fail: deposit in cache, but removed": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				cache.EXPECT().Get(depositTxID).Return(nil, true)
 				return &caminoState{
 					depositsCache: cache,
@@ -119,7 +127,7 @@ func TestGetDeposit(t *testing.T) {
 		},
 		"OK: deposit in cache": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				cache.EXPECT().Get(depositTxID).Return(deposit1, true)
 				return &caminoState{
 					depositsCache: cache,
@@ -137,30 +145,61 @@ func TestGetDeposit(t *testing.T) {
 		},
 		"OK: deposit in db": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				cache.EXPECT().Get(depositTxID).Return(nil, false)
 				cache.EXPECT().Put(depositTxID, deposit1)
 				db := database.NewMockDatabase(c)
 				db.EXPECT().Get(depositTxID[:]).Return(depositBytes, nil)
+				rewardOwnersDB := database.NewMockDatabase(c)
+				rewardOwnersDB.EXPECT().Get(depositTxID[:]).Return(nil, database.ErrNotFound)
 				return &caminoState{
-					depositsDB:    db,
-					depositsCache: cache,
-					caminoDiff:    &caminoDiff{},
+					depositsDB:            db,
+					depositRewardOwnersDB: rewardOwnersDB,
+					depositsCache:         cache,
+					caminoDiff:            &caminoDiff{},
 				}
 			},
 			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
 				return &caminoState{
-					depositsDB:    actualCaminoState.depositsDB,
-					depositsCache: actualCaminoState.depositsCache,
-					caminoDiff:    &caminoDiff{},
+					depositsDB:            actualCaminoState.depositsDB,
+					depositRewardOwnersDB: actualCaminoState.depositRewardOwnersDB,
+					depositsCache:         actualCaminoState.depositsCache,
+					caminoDiff:            &caminoDiff{},
 				}
 			},
 			depositTxID:     depositTxID,
 			expectedDeposit: deposit1,
 		},
+		"OK: deposit with changed rewards owner in db": {
+			caminoState: func(c *gomock.Controller) *caminoState {
+				cache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
+				cache.EXPECT().Get(depositTxID).Return(nil, false)
+				cache.EXPECT().Put(depositTxID, depositWithRewardOwner)
+				db := database.NewMockDatabase(c)
+				db.EXPECT().Get(depositTxID[:]).Return(depositBytes, nil)
+				rewardOwnersDB := database.NewMockDatabase(c)
+				rewardOwnersDB.EXPECT().Get(depositTxID[:]).Return(rewardOwnerBytes, nil)
+				return &caminoState{
+					depositsDB:            db,
+					depositRewardOwnersDB: rewardOwnersDB,
+					depositsCache:         cache,
+					caminoDiff:            &caminoDiff{},
+				}
+			},
+			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
+				return &caminoState{
+					depositsDB:            actualCaminoState.depositsDB,
+					depositRewardOwnersDB: actualCaminoState.depositRewardOwnersDB,
+					depositsCache:         actualCaminoState.depositsCache,
+					caminoDiff:            &caminoDiff{},
+				}
+			},
+			depositTxID:     depositTxID,
+			expectedDeposit: depositWithRewardOwner,
+		},
 		"Fail: db error": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				cache := cache.NewMockCacher(c)
+				cache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				cache.EXPECT().Get(depositTxID).Return(nil, false)
 				db := database.NewMockDatabase(c)
 				db.EXPECT().Get(depositTxID[:]).Return(nil, testError)
@@ -239,7 +278,7 @@ func TestModifyDeposit(t *testing.T) {
 	}{
 		"OK": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				depositsCache := cache.NewMockCacher(c)
+				depositsCache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				depositsCache.EXPECT().Evict(depositTxID)
 				return &caminoState{
 					depositsCache: depositsCache,
@@ -283,7 +322,7 @@ func TestRemoveDeposit(t *testing.T) {
 	}{
 		"OK": {
 			caminoState: func(c *gomock.Controller) *caminoState {
-				depositsCache := cache.NewMockCacher(c)
+				depositsCache := cache.NewMockCacher[ids.ID, *deposit.Deposit](c)
 				depositsCache.EXPECT().Evict(depositTxID)
 				return &caminoState{
 					depositsCache: depositsCache,
@@ -687,8 +726,17 @@ func TestWriteDeposits(t *testing.T) {
 				depositIDsByEndtimeDB.EXPECT().Put(depositToKey(depositTxID1[:], deposit1), nil).Return(nil)
 				depositIDsByEndtimeDB.EXPECT().Delete(depositToKey(depositTxID3[:], deposit3)).Return(nil)
 
+				depositIDsByOfferDB := database.NewMockDatabase(c)
+				depositIDsByOfferDB.EXPECT().Put(ownerPrefixedKey(deposit1.DepositOfferID, depositTxID1), nil).Return(nil)
+				depositIDsByOfferDB.EXPECT().Delete(ownerPrefixedKey(deposit3.DepositOfferID, depositTxID3)).Return(nil)
+
+				depositRewardOwnersDB := database.NewMockDatabase(c)
+				depositRewardOwnersDB.EXPECT().Delete(depositTxID3[:]).Return(nil)
+
 				return &caminoState{
 					depositIDsByEndtimeDB: depositIDsByEndtimeDB,
+					depositIDsByOfferDB:   depositIDsByOfferDB,
+					depositRewardOwnersDB: depositRewardOwnersDB,
 					depositsDB:            depositsDB,
 					caminoDiff: &caminoDiff{
 						modifiedDeposits: map[ids.ID]*depositDiff{
@@ -704,6 +752,8 @@ func TestWriteDeposits(t *testing.T) {
 			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
 				return &caminoState{
 					depositIDsByEndtimeDB: actualCaminoState.depositIDsByEndtimeDB,
+					depositIDsByOfferDB:   actualCaminoState.depositIDsByOfferDB,
+					depositRewardOwnersDB: actualCaminoState.depositRewardOwnersDB,
 					depositsDB:            actualCaminoState.depositsDB,
 					caminoDiff: &caminoDiff{
 						modifiedDeposits: map[ids.ID]*depositDiff{},
@@ -731,8 +781,17 @@ func TestWriteDeposits(t *testing.T) {
 				depositIDsByEndtimeDB.EXPECT().Delete(depositToKey(depositTxID2[:], deposit2)).Return(nil)
 				depositIDsByEndtimeDB.EXPECT().NewIterator().Return(depositsIterator)
 
+				depositIDsByOfferDB := database.NewMockDatabase(c)
+				depositIDsByOfferDB.EXPECT().Put(ownerPrefixedKey(deposit1.DepositOfferID, depositTxID1), nil).Return(nil)
+				depositIDsByOfferDB.EXPECT().Delete(ownerPrefixedKey(deposit2.DepositOfferID, depositTxID2)).Return(nil)
+
+				depositRewardOwnersDB := database.NewMockDatabase(c)
+				depositRewardOwnersDB.EXPECT().Delete(depositTxID2[:]).Return(nil)
+
 				return &caminoState{
 					depositIDsByEndtimeDB: depositIDsByEndtimeDB,
+					depositIDsByOfferDB:   depositIDsByOfferDB,
+					depositRewardOwnersDB: depositRewardOwnersDB,
 					depositsDB:            depositsDB,
 					caminoDiff: &caminoDiff{
 						modifiedDeposits: map[ids.ID]*depositDiff{
@@ -747,6 +806,8 @@ func TestWriteDeposits(t *testing.T) {
 			expectedCaminoState: func(actualCaminoState *caminoState) *caminoState {
 				return &caminoState{
 					depositIDsByEndtimeDB: actualCaminoState.depositIDsByEndtimeDB,
+					depositIDsByOfferDB:   actualCaminoState.depositIDsByOfferDB,
+					depositRewardOwnersDB: actualCaminoState.depositRewardOwnersDB,
 					depositsDB:            actualCaminoState.depositsDB,
 					caminoDiff: &caminoDiff{
 						modifiedDeposits: map[ids.ID]*depositDiff{},
@@ -831,3 +892,169 @@ func TestLoadDeposits(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDepositIDsByRewardOwner(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newEmptyState(t)
+
+	owner1 := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	owner2 := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{2}}}
+	owner1ID, err := txs.GetOwnerID(owner1)
+	require.NoError(err)
+	owner2ID, err := txs.GetOwnerID(owner2)
+	require.NoError(err)
+
+	newDepositTx := func(duration uint32, owner *secp256k1fx.OutputOwners) *txs.Tx {
+		tx, err := txs.NewSigned(&txs.DepositTx{DepositDuration: duration, RewardsOwner: owner}, txs.Codec, nil)
+		require.NoError(err)
+		return tx
+	}
+	depositTx1 := newDepositTx(1, owner1)
+	depositTx2 := newDepositTx(2, owner1)
+	depositTx3 := newDepositTx(3, owner2)
+	owner1DepositIDs := []ids.ID{depositTx1.ID(), depositTx2.ID()}
+	utils.Sort(owner1DepositIDs)
+
+	for _, tx := range []*txs.Tx{depositTx1, depositTx2, depositTx3} {
+		s.AddTx(tx, status.Committed)
+		s.AddDeposit(tx.ID(), &deposit.Deposit{Duration: tx.Unsigned.(*txs.DepositTx).DepositDuration})
+	}
+
+	// not written deposits
+	depositIDs, err := s.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Equal(owner1DepositIDs, depositIDs)
+
+	require.NoError(s.caminoState.Write(s))
+
+	// written deposits
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Equal(owner1DepositIDs, depositIDs)
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner2ID)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTx3.ID()}, depositIDs)
+
+	// diff overlay
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	depositTx4 := newDepositTx(4, owner2)
+	d.AddTx(depositTx4, status.Committed)
+	d.AddDeposit(depositTx4.ID(), &deposit.Deposit{Duration: 4})
+	d.RemoveDeposit(depositTx3.ID(), &deposit.Deposit{Duration: 3})
+
+	depositIDs, err = d.GetDepositIDsByRewardOwner(owner2ID)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTx4.ID()}, depositIDs)
+	depositIDs, err = d.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Equal(owner1DepositIDs, depositIDs)
+
+	// removal from state
+	s.RemoveDeposit(depositTx1.ID(), &deposit.Deposit{Duration: 1})
+	require.NoError(s.caminoState.Write(s))
+
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTx2.ID()}, depositIDs)
+
+	// changing rewards owner
+	owner2DepositIDs := []ids.ID{depositTx2.ID(), depositTx3.ID()}
+	utils.Sort(owner2DepositIDs)
+	depositWithChangedOwner := &deposit.Deposit{Duration: 2, RewardOwner: owner2}
+	s.ModifyDeposit(depositTx2.ID(), depositWithChangedOwner)
+
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Empty(depositIDs)
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner2ID)
+	require.NoError(err)
+	require.Equal(owner2DepositIDs, depositIDs)
+
+	require.NoError(s.caminoState.Write(s))
+
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner1ID)
+	require.NoError(err)
+	require.Empty(depositIDs)
+	depositIDs, err = s.GetDepositIDsByRewardOwner(owner2ID)
+	require.NoError(err)
+	require.Equal(owner2DepositIDs, depositIDs)
+	actualDeposit, err := s.GetDeposit(depositTx2.ID())
+	require.NoError(err)
+	require.Equal(depositWithChangedOwner, actualDeposit)
+}
+
+func TestGetDepositIDsByOffer(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+
+	offerID1 := ids.ID{1}
+	offerID2 := ids.ID{2}
+	depositTxID1 := ids.ID{11}
+	depositTxID2 := ids.ID{12}
+	depositTxID3 := ids.ID{13}
+	deposit1 := &deposit.Deposit{DepositOfferID: offerID1, Duration: 1}
+	deposit2 := &deposit.Deposit{DepositOfferID: offerID1, Duration: 2}
+	deposit3 := &deposit.Deposit{DepositOfferID: offerID2, Duration: 3}
+	s.AddDeposit(depositTxID1, deposit1)
+	s.AddDeposit(depositTxID2, deposit2)
+	s.AddDeposit(depositTxID3, deposit3)
+
+	// not written deposits
+	depositIDs, err := s.GetDepositIDsByOffer(offerID1)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID1, depositTxID2}, depositIDs)
+
+	require.NoError(cs.writeDeposits())
+
+	// written deposits
+	depositIDs, err = s.GetDepositIDsByOffer(offerID1)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID1, depositTxID2}, depositIDs)
+	depositIDs, err = s.GetDepositIDsByOffer(offerID2)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID3}, depositIDs)
+
+	// diff overlay
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	depositTxID4 := ids.ID{14}
+	d.AddDeposit(depositTxID4, &deposit.Deposit{DepositOfferID: offerID2, Duration: 4})
+	d.RemoveDeposit(depositTxID1, deposit1)
+
+	depositIDs, err = d.GetDepositIDsByOffer(offerID1)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID2}, depositIDs)
+	depositIDs, err = d.GetDepositIDsByOffer(offerID2)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID3, depositTxID4}, depositIDs)
+
+	// removal from state
+	s.RemoveDeposit(depositTxID1, deposit1)
+	require.NoError(cs.writeDeposits())
+
+	depositIDs, err = s.GetDepositIDsByOffer(offerID1)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID2}, depositIDs)
+
+	// index is built for state without it
+	require.NoError(cs.depositIDsByOfferDB.Delete(ownerPrefixedKey(offerID2, depositTxID3)))
+	require.NoError(cs.loadDepositIDsByOffer())
+	depositIDs, err = s.GetDepositIDsByOffer(offerID2)
+	require.NoError(err)
+	require.Equal([]ids.ID{depositTxID3}, depositIDs)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
@@ -67,13 +68,14 @@ func (d *diff) LockedUTXOs(txIDs set.Set[ids.ID], addresses set.Set[ids.ShortID]
 	for utxoID := range remaining {
 		utxo := d.modifiedUTXOs[utxoID].utxo
 		if utxo != nil {
-			if lockedOut, ok := utxo.Out.(*locked.Out); ok &&
-				lockedOut.IDs.Match(lockState, txIDs) {
+			if lockIDs, _, ok := locked.OutIDs(utxo.Out); ok &&
+				lockIDs.Match(lockState, txIDs) {
 				retUtxos = append(retUtxos, utxo)
 			}
 		}
 	}
 
+	sortUTXOs(retUtxos)
 	return retUtxos, nil
 }
 
@@ -93,23 +95,37 @@ func (d *diff) CaminoConfig() (*CaminoConfig, error) {
 	return parentState.CaminoConfig()
 }
 
-func (d *diff) SetAddressStates(address ids.ShortID, states uint64) {
+func (d *diff) SetAddressStates(address ids.ShortID, states as.AddressState) {
 	d.caminoDiff.modifiedAddressStates[address] = states
 }
 
-func (d *diff) GetAddressStates(address ids.ShortID) (uint64, error) {
+func (d *diff) GetAddressStates(address ids.ShortID) (as.AddressState, error) {
 	if states, ok := d.caminoDiff.modifiedAddressStates[address]; ok {
 		return states, nil
 	}
 
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
-		return 0, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+		return as.AddressStateEmpty, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
 	}
 
 	return parentState.GetAddressStates(address)
 }
 
+func (d *diff) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetAddressStatesIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDiffAddressStatesIterator(parentIterator, d.caminoDiff.modifiedAddressStates), nil
+}
+
 func (d *diff) SetDepositOffer(offer *deposit.Offer) {
 	d.caminoDiff.modifiedDepositOffers[offer.ID] = offer
 }
@@ -152,6 +168,7 @@ func (d *diff) GetAllDepositOffers() ([]*deposit.Offer, error) {
 		}
 	}
 
+	sortDepositOffers(offers)
 	return offers, nil
 }
 
@@ -183,6 +200,75 @@ func (d *diff) GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
 	return parentState.GetDeposit(depositTxID)
 }
 
+func (d *diff) GetDepositIDsByOffer(offerID ids.ID) ([]ids.ID, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentDepositIDs, err := parentState.GetDepositIDsByOffer(offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	depositIDs := set.NewSet[ids.ID](len(parentDepositIDs))
+	depositIDs.Add(parentDepositIDs...)
+	return overrideDepositIDsByOffer(depositIDs, d.caminoDiff.modifiedDeposits, offerID), nil
+}
+
+func (d *diff) AddDepositClaim(depositTxID ids.ID, claim *deposit.Claim) {
+	d.caminoDiff.addedDepositClaims[depositTxID] = append(d.caminoDiff.addedDepositClaims[depositTxID], claim)
+}
+
+func (d *diff) GetDepositClaims(depositTxID ids.ID) ([]*deposit.Claim, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentClaims, err := parentState.GetDepositClaims(depositTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(parentClaims, d.caminoDiff.addedDepositClaims[depositTxID]...), nil
+}
+
+func (d *diff) GetDepositIDsByRewardOwner(ownerID ids.ID) ([]ids.ID, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentDepositIDs, err := parentState.GetDepositIDsByRewardOwner(ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	depositIDs := set.NewSet[ids.ID](len(parentDepositIDs))
+	depositIDs.Add(parentDepositIDs...)
+	for depositTxID, depositDiff := range d.caminoDiff.modifiedDeposits {
+		switch {
+		case depositDiff.removed:
+			depositIDs.Remove(depositTxID)
+		case depositDiff.added || depositDiff.RewardOwner != nil:
+			depositOwnerID, err := depositRewardOwnerID(d, depositTxID, depositDiff.Deposit)
+			if err != nil {
+				return nil, err
+			}
+			if depositOwnerID == ownerID {
+				depositIDs.Add(depositTxID)
+			} else {
+				depositIDs.Remove(depositTxID)
+			}
+		}
+	}
+
+	depositIDsList := depositIDs.List()
+	utils.Sort(depositIDsList)
+	return depositIDsList, nil
+}
+
 func (d *diff) GetNextToUnlockDepositTime(removedDepositIDs set.Set[ids.ID]) (time.Time, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -272,6 +358,10 @@ func (d *diff) SetMultisigAlias(owner *multisig.Alias) {
 	d.caminoDiff.modifiedMultisigOwners[owner.ID] = owner
 }
 
+func (d *diff) AddMultisigAliasUsage(aliasID ids.ShortID, txID ids.ID) {
+	d.caminoDiff.addedMultisigAliasUsages[aliasID] = append(d.caminoDiff.addedMultisigAliasUsages[aliasID], txID)
+}
+
 func (d *diff) GetMultisigAlias(alias ids.ShortID) (*multisig.Alias, error) {
 	if msigOwner, ok := d.caminoDiff.modifiedMultisigOwners[alias]; ok {
 		if msigOwner == nil {
@@ -288,6 +378,36 @@ func (d *diff) GetMultisigAlias(alias ids.ShortID) (*multisig.Alias, error) {
 	return parentState.GetMultisigAlias(alias)
 }
 
+func (d *diff) GetMultisigAliases(start ids.ShortID, limit int) ([]*multisig.Alias, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	aliases, err := parentState.GetMultisigAliases(start, aliasesParentLimit(limit, len(d.caminoDiff.modifiedMultisigOwners)))
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeMultisigAliases(aliases, d.caminoDiff.modifiedMultisigOwners, start, limit), nil
+}
+
+func (d *diff) GetMultisigAliasIDsByMember(member ids.ShortID) ([]ids.ShortID, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentAliasIDs, err := parentState.GetMultisigAliasIDsByMember(member)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasIDs := set.NewSet[ids.ShortID](len(parentAliasIDs))
+	aliasIDs.Add(parentAliasIDs...)
+	return overrideMultisigAliasIDsByMember(aliasIDs, d.caminoDiff.modifiedMultisigOwners, member), nil
+}
+
 func (d *diff) SetShortIDLink(id ids.ShortID, key ShortLinkKey, link *ids.ShortID) {
 	d.caminoDiff.modifiedShortLinks[toShortLinkKey(id, key)] = link
 }
@@ -308,6 +428,21 @@ func (d *diff) GetShortIDLink(id ids.ShortID, key ShortLinkKey) (ids.ShortID, er
 	return parentState.GetShortIDLink(id, key)
 }
 
+func (d *diff) GetShortIDLinks(key ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	links, err := parentState.GetShortIDLinks(key)
+	if err != nil {
+		return nil, err
+	}
+
+	overrideShortIDLinks(links, d.caminoDiff.modifiedShortLinks, key)
+	return links, nil
+}
+
 func (d *diff) SetClaimable(ownerID ids.ID, claimable *Claimable) {
 	d.caminoDiff.modifiedClaimables[ownerID] = claimable
 }
@@ -328,6 +463,20 @@ func (d *diff) GetClaimable(ownerID ids.ID) (*Claimable, error) {
 	return parentState.GetClaimable(ownerID)
 }
 
+func (d *diff) GetClaimablesIterator() (ClaimablesIterator, error) {
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+
+	parentIterator, err := parentState.GetClaimablesIterator()
+	if err != nil {
+		return nil, err
+	}
+
+	return newDiffClaimablesIterator(parentIterator, d.caminoDiff.modifiedClaimables), nil
+}
+
 func (d *diff) SetNotDistributedValidatorReward(reward uint64) {
 	d.caminoDiff.modifiedNotDistributedValidatorReward = &reward
 }
@@ -388,6 +537,8 @@ func (d *diff) GetDeferredStakerIterator() (StakerIterator, error) {
 
 // Finally apply all changes
 func (d *diff) ApplyCaminoState(baseState State) {
+	startTime := time.Now()
+
 	if d.caminoDiff.modifiedNotDistributedValidatorReward != nil {
 		baseState.SetNotDistributedValidatorReward(*d.caminoDiff.modifiedNotDistributedValidatorReward)
 	}
@@ -411,10 +562,22 @@ func (d *diff) ApplyCaminoState(baseState State) {
 		}
 	}
 
+	for depositTxID, claims := range d.caminoDiff.addedDepositClaims {
+		for _, claim := range claims {
+			baseState.AddDepositClaim(depositTxID, claim)
+		}
+	}
+
 	for _, v := range d.caminoDiff.modifiedMultisigOwners {
 		baseState.SetMultisigAlias(v)
 	}
 
+	for aliasID, txIDs := range d.caminoDiff.addedMultisigAliasUsages {
+		for _, txID := range txIDs {
+			baseState.AddMultisigAliasUsage(aliasID, txID)
+		}
+	}
+
 	for fullKey, link := range d.caminoDiff.modifiedShortLinks {
 		id, key := fromShortLinkKey(fullKey)
 		baseState.SetShortIDLink(id, key, link)
@@ -435,4 +598,8 @@ func (d *diff) ApplyCaminoState(baseState State) {
 			}
 		}
 	}
+
+	if s, ok := baseState.(*state); ok {
+		s.caminoState.observeAppliedDiff(d.caminoDiff, time.Since(startTime))
+	}
 }
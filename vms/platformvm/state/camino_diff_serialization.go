@@ -0,0 +1,303 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const serializedCaminoDiffVersion uint16 = 0
+
+var errWrongSerializedDiffVersion = errors.New("unsupported serialized camino diff version")
+
+// serializedCaminoDiff is canonical representation of caminoDiff:
+// all its entries are sorted by their keys.
+type serializedCaminoDiff struct {
+	Version                          uint16                        `serialize:"true"`
+	NotDistributedValidatorRewardSet bool                          `serialize:"true"`
+	NotDistributedValidatorReward    uint64                        `serialize:"true"`
+	AddressStates                    []serializedAddressStates     `serialize:"true"`
+	DepositOffers                    []serializedDepositOffer      `serialize:"true"`
+	Deposits                         []serializedDeposit           `serialize:"true"`
+	DepositClaims                    []serializedDepositClaims     `serialize:"true"`
+	MultisigAliases                  []*multisig.Alias             `serialize:"true"`
+	ShortLinks                       []serializedShortLink         `serialize:"true"`
+	RemovedShortLinks                []ids.ID                      `serialize:"true"`
+	Claimables                       []serializedClaimable         `serialize:"true"`
+	RemovedClaimables                []ids.ID                      `serialize:"true"`
+	DeferredValidators               []serializedDeferredValidator `serialize:"true"`
+}
+
+type serializedAddressStates struct {
+	Address ids.ShortID     `serialize:"true"`
+	States  as.AddressState `serialize:"true"`
+}
+
+type serializedDepositOffer struct {
+	// offer id and extension aren't serialized as part of offer
+	ID        ids.ID                 `serialize:"true"`
+	Extension *depositOfferExtension `serialize:"true"`
+	Offer     *deposit.Offer         `serialize:"true"`
+}
+
+type serializedDeposit struct {
+	DepositTxID ids.ID           `serialize:"true"`
+	Added       bool             `serialize:"true"`
+	Removed     bool             `serialize:"true"`
+	Deposit     *deposit.Deposit `serialize:"true"`
+	// deposit rewards owner isn't serialized as part of deposit
+	RewardOwnerSet bool                     `serialize:"true"`
+	RewardOwner    secp256k1fx.OutputOwners `serialize:"true"`
+}
+
+type serializedDepositClaims struct {
+	DepositTxID ids.ID           `serialize:"true"`
+	Claims      []*deposit.Claim `serialize:"true"`
+}
+
+type serializedShortLink struct {
+	Key  ids.ID      `serialize:"true"`
+	Link ids.ShortID `serialize:"true"`
+}
+
+type serializedClaimable struct {
+	OwnerID   ids.ID     `serialize:"true"`
+	Claimable *Claimable `serialize:"true"`
+	// claimable reward asset isn't serialized as part of claimable
+	RewardAssetID ids.ID `serialize:"true"`
+}
+
+type serializedDeferredValidator struct {
+	TxID    ids.ID `serialize:"true"`
+	Deleted bool   `serialize:"true"`
+}
+
+// MarshalCaminoDiff returns canonical bytes of camino part of this diff.
+// Same diff modifications always result in same bytes. Multisig alias usages
+// are node-local index, so they aren't part of canonical bytes.
+func (d *diff) MarshalCaminoDiff() ([]byte, error) {
+	cd := d.caminoDiff
+	sd := serializedCaminoDiff{Version: serializedCaminoDiffVersion}
+
+	if cd.modifiedNotDistributedValidatorReward != nil {
+		sd.NotDistributedValidatorRewardSet = true
+		sd.NotDistributedValidatorReward = *cd.modifiedNotDistributedValidatorReward
+	}
+
+	for address, states := range cd.modifiedAddressStates {
+		sd.AddressStates = append(sd.AddressStates, serializedAddressStates{Address: address, States: states})
+	}
+	utils.Sort(sd.AddressStates)
+
+	for offerID, offer := range cd.modifiedDepositOffers {
+		sd.DepositOffers = append(sd.DepositOffers, serializedDepositOffer{
+			ID:        offerID,
+			Extension: newDepositOfferExtension(offer),
+			Offer:     offer,
+		})
+	}
+	utils.Sort(sd.DepositOffers)
+
+	for depositTxID, depositDiff := range cd.modifiedDeposits {
+		serialized := serializedDeposit{
+			DepositTxID: depositTxID,
+			Added:       depositDiff.added,
+			Removed:     depositDiff.removed,
+			Deposit:     depositDiff.Deposit,
+		}
+		if depositDiff.RewardOwner != nil {
+			serialized.RewardOwnerSet = true
+			serialized.RewardOwner = *depositDiff.RewardOwner
+		}
+		sd.Deposits = append(sd.Deposits, serialized)
+	}
+	utils.Sort(sd.Deposits)
+
+	for depositTxID, claims := range cd.addedDepositClaims {
+		sd.DepositClaims = append(sd.DepositClaims, serializedDepositClaims{
+			DepositTxID: depositTxID,
+			Claims:      claims,
+		})
+	}
+	utils.Sort(sd.DepositClaims)
+
+	aliasIDs := make([]ids.ShortID, 0, len(cd.modifiedMultisigOwners))
+	for aliasID := range cd.modifiedMultisigOwners {
+		aliasIDs = append(aliasIDs, aliasID)
+	}
+	utils.Sort(aliasIDs)
+	for _, aliasID := range aliasIDs {
+		sd.MultisigAliases = append(sd.MultisigAliases, cd.modifiedMultisigOwners[aliasID])
+	}
+
+	for fullKey, link := range cd.modifiedShortLinks {
+		if link == nil {
+			sd.RemovedShortLinks = append(sd.RemovedShortLinks, fullKey)
+		} else {
+			sd.ShortLinks = append(sd.ShortLinks, serializedShortLink{Key: fullKey, Link: *link})
+		}
+	}
+	utils.Sort(sd.ShortLinks)
+	utils.Sort(sd.RemovedShortLinks)
+
+	for ownerID, claimable := range cd.modifiedClaimables {
+		if claimable == nil {
+			sd.RemovedClaimables = append(sd.RemovedClaimables, ownerID)
+		} else {
+			sd.Claimables = append(sd.Claimables, serializedClaimable{
+				OwnerID:       ownerID,
+				Claimable:     claimable,
+				RewardAssetID: claimable.RewardAssetID,
+			})
+		}
+	}
+	utils.Sort(sd.Claimables)
+	utils.Sort(sd.RemovedClaimables)
+
+	for _, validatorDiffs := range cd.deferredStakerDiffs.validatorDiffs {
+		for _, validatorDiff := range validatorDiffs {
+			if validatorDiff.validatorModified {
+				sd.DeferredValidators = append(sd.DeferredValidators, serializedDeferredValidator{
+					TxID:    validatorDiff.validator.TxID,
+					Deleted: validatorDiff.validatorDeleted,
+				})
+			}
+		}
+	}
+	utils.Sort(sd.DeferredValidators)
+
+	diffBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, sd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize camino diff: %w", err)
+	}
+	return diffBytes, nil
+}
+
+// ApplyMarshalledCaminoDiff parses camino diff [diffBytes], created with diff.MarshalCaminoDiff,
+// and applies it to [chain]. Deferred validators txs must be present in [chain].
+func ApplyMarshalledCaminoDiff(chain Chain, diffBytes []byte) error {
+	sd := serializedCaminoDiff{}
+	if _, err := blocks.GenesisCodec.Unmarshal(diffBytes, &sd); err != nil {
+		return fmt.Errorf("failed to parse camino diff: %w", err)
+	}
+	if sd.Version != serializedCaminoDiffVersion {
+		return fmt.Errorf("%w: %d", errWrongSerializedDiffVersion, sd.Version)
+	}
+
+	if sd.NotDistributedValidatorRewardSet {
+		chain.SetNotDistributedValidatorReward(sd.NotDistributedValidatorReward)
+	}
+
+	for _, addressStates := range sd.AddressStates {
+		chain.SetAddressStates(addressStates.Address, addressStates.States)
+	}
+
+	for _, depositOffer := range sd.DepositOffers {
+		depositOffer.Offer.ID = depositOffer.ID
+		depositOffer.Extension.applyTo(depositOffer.Offer)
+		chain.SetDepositOffer(depositOffer.Offer)
+	}
+
+	for _, depositDiff := range sd.Deposits {
+		if depositDiff.RewardOwnerSet {
+			rewardOwner := depositDiff.RewardOwner
+			depositDiff.Deposit.RewardOwner = &rewardOwner
+		}
+		switch {
+		case depositDiff.Added:
+			chain.AddDeposit(depositDiff.DepositTxID, depositDiff.Deposit)
+		case depositDiff.Removed:
+			chain.RemoveDeposit(depositDiff.DepositTxID, depositDiff.Deposit)
+		default:
+			chain.ModifyDeposit(depositDiff.DepositTxID, depositDiff.Deposit)
+		}
+	}
+
+	for _, depositClaims := range sd.DepositClaims {
+		for _, claim := range depositClaims.Claims {
+			chain.AddDepositClaim(depositClaims.DepositTxID, claim)
+		}
+	}
+
+	for _, alias := range sd.MultisigAliases {
+		chain.SetMultisigAlias(alias)
+	}
+
+	for _, shortLink := range sd.ShortLinks {
+		id, key := fromShortLinkKey(shortLink.Key)
+		link := shortLink.Link
+		chain.SetShortIDLink(id, key, &link)
+	}
+	for _, fullKey := range sd.RemovedShortLinks {
+		id, key := fromShortLinkKey(fullKey)
+		chain.SetShortIDLink(id, key, nil)
+	}
+
+	for _, claimable := range sd.Claimables {
+		claimable.Claimable.RewardAssetID = claimable.RewardAssetID
+		chain.SetClaimable(claimable.OwnerID, claimable.Claimable)
+	}
+	for _, ownerID := range sd.RemovedClaimables {
+		chain.SetClaimable(ownerID, nil)
+	}
+
+	for _, deferredValidator := range sd.DeferredValidators {
+		tx, _, err := chain.GetTx(deferredValidator.TxID)
+		if err != nil {
+			return fmt.Errorf("failed to get deferred validator tx %s: %w", deferredValidator.TxID, err)
+		}
+		stakerTx, ok := tx.Unsigned.(txs.Staker)
+		if !ok {
+			return fmt.Errorf("%w: %T", errWrongTxType, tx.Unsigned)
+		}
+		staker, err := NewCurrentStaker(deferredValidator.TxID, stakerTx, 0)
+		if err != nil {
+			return err
+		}
+		if deferredValidator.Deleted {
+			chain.DeleteDeferredValidator(staker)
+		} else {
+			chain.PutDeferredValidator(staker)
+		}
+	}
+	return nil
+}
+
+func (a serializedAddressStates) Less(b serializedAddressStates) bool {
+	return a.Address.Less(b.Address)
+}
+
+func (a serializedDepositOffer) Less(b serializedDepositOffer) bool {
+	return a.ID.Less(b.ID)
+}
+
+func (a serializedDeposit) Less(b serializedDeposit) bool {
+	return a.DepositTxID.Less(b.DepositTxID)
+}
+
+func (a serializedDepositClaims) Less(b serializedDepositClaims) bool {
+	return a.DepositTxID.Less(b.DepositTxID)
+}
+
+func (a serializedShortLink) Less(b serializedShortLink) bool {
+	return a.Key.Less(b.Key)
+}
+
+func (a serializedClaimable) Less(b serializedClaimable) bool {
+	return a.OwnerID.Less(b.OwnerID)
+}
+
+func (a serializedDeferredValidator) Less(b serializedDeferredValidator) bool {
+	return a.TxID.Less(b.TxID)
+}
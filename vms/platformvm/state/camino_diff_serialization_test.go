@@ -0,0 +1,69 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestMarshalCaminoDiff(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(newEmptyState(t), true).AnyTimes()
+	newDiff := func() *diff {
+		d, err := NewCaminoDiff(parentStateID, stateVersions)
+		require.NoError(err)
+		return d.(*diff)
+	}
+
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	link := ids.ShortID{10}
+
+	d1 := newDiff()
+	d1.SetNotDistributedValidatorReward(7)
+	d1.SetAddressStates(ids.ShortID{2}, 3)
+	d1.SetAddressStates(ids.ShortID{1}, 4)
+	d1.SetDepositOffer(&deposit.Offer{ID: ids.ID{1}, End: 10, Memo: []byte{1}})
+	d1.AddDeposit(ids.ID{1}, &deposit.Deposit{DepositOfferID: ids.ID{1}, Amount: 1, Duration: 1})
+	d1.ModifyDeposit(ids.ID{2}, &deposit.Deposit{DepositOfferID: ids.ID{1}, Amount: 2, UnlockedAmount: 1})
+	d1.RemoveDeposit(ids.ID{3}, &deposit.Deposit{DepositOfferID: ids.ID{1}, Amount: 3})
+	d1.SetMultisigAlias(&multisig.Alias{ID: ids.ShortID{2}, Memo: []byte{2}, Owners: owner})
+	d1.SetMultisigAlias(&multisig.Alias{ID: ids.ShortID{1}, Memo: []byte{1}, Owners: owner})
+	d1.SetShortIDLink(ids.ShortID{1}, ShortLinkKeyRegisterNode, &link)
+	d1.SetShortIDLink(ids.ShortID{2}, ShortLinkKeyRegisterNode, nil)
+	d1.SetClaimable(ids.ID{1}, &Claimable{Owner: owner, ValidatorReward: 1, DepositReward: 2})
+	d1.SetClaimable(ids.ID{2}, nil)
+
+	diffBytes, err := d1.MarshalCaminoDiff()
+	require.NoError(err)
+
+	d2 := newDiff()
+	require.NoError(ApplyMarshalledCaminoDiff(d2, diffBytes))
+	require.Equal(d1.caminoDiff, d2.caminoDiff)
+
+	// same modifications result in same bytes
+	diffBytes2, err := d2.MarshalCaminoDiff()
+	require.NoError(err)
+	require.Equal(diffBytes, diffBytes2)
+
+	// unknown version
+	wrongVersionBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, serializedCaminoDiff{
+		Version: serializedCaminoDiffVersion + 1,
+	})
+	require.NoError(err)
+	require.ErrorIs(ApplyMarshalledCaminoDiff(newDiff(), wrongVersionBytes), errWrongSerializedDiffVersion)
+}
@@ -0,0 +1,270 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+type caminoStateDump struct {
+	Hash  ids.ID          `json:"hash"`
+	State json.RawMessage `json:"state"`
+}
+
+type caminoStateDumpContent struct {
+	NotDistributedValidatorReward uint64                `json:"notDistributedValidatorReward"`
+	AddressStates                 []dumpedAddressStates `json:"addressStates"`
+	DepositOffers                 []*deposit.Offer      `json:"depositOffers"`
+	Deposits                      []dumpedDeposit       `json:"deposits"`
+	MultisigAliases               []dumpedMultisigAlias `json:"multisigAliases"`
+	ShortLinks                    []dumpedShortLink     `json:"shortLinks"`
+	Claimables                    []dumpedClaimable     `json:"claimables"`
+	DeferredValidatorTxIDs        []ids.ID              `json:"deferredValidatorTxIDs"`
+}
+
+type dumpedAddressStates struct {
+	Address ids.ShortID     `json:"address"`
+	States  as.AddressState `json:"states"`
+}
+
+type dumpedDeposit struct {
+	DepositTxID         ids.ID `json:"depositTxID"`
+	DepositOfferID      ids.ID `json:"depositOfferID"`
+	UnlockedAmount      uint64 `json:"unlockedAmount"`
+	ClaimedRewardAmount uint64 `json:"claimedRewardAmount"`
+	Start               uint64 `json:"start"`
+	Duration            uint32 `json:"duration"`
+	Amount              uint64 `json:"amount"`
+}
+
+type dumpedOwner struct {
+	Locktime  uint64        `json:"locktime"`
+	Threshold uint32        `json:"threshold"`
+	Addresses []ids.ShortID `json:"addresses"`
+	// Weights are only set for weighted multisig alias owners
+	Weights []uint32 `json:"weights,omitempty"`
+	// ActiveFrom and ActiveUntil are only set for time-locked multisig alias owners
+	ActiveFrom  []uint64 `json:"activeFrom,omitempty"`
+	ActiveUntil []uint64 `json:"activeUntil,omitempty"`
+}
+
+type dumpedMultisigAlias struct {
+	ID     ids.ShortID         `json:"id"`
+	Memo   types.JSONByteSlice `json:"memo"`
+	Owners *dumpedOwner        `json:"owners"`
+}
+
+type dumpedShortLink struct {
+	ID   ids.ShortID  `json:"id"`
+	Key  ShortLinkKey `json:"key"`
+	Link ids.ShortID  `json:"link"`
+}
+
+type dumpedClaimable struct {
+	OwnerID         ids.ID       `json:"ownerID"`
+	Owner           *dumpedOwner `json:"owner"`
+	ValidatorReward uint64       `json:"validatorReward"`
+	DepositReward   uint64       `json:"depositReward"`
+}
+
+// DumpCaminoState writes canonical json dump of persisted camino state into [w]
+// and returns hash of dumped state. Nodes with equal camino state at the same height
+// produce equal dumps, so hashes can be compared to check state equality.
+func (s *state) DumpCaminoState(w io.Writer) (ids.ID, error) {
+	return s.caminoState.dump(w)
+}
+
+// dump writes canonical json dump of persisted camino state into [w]. All entries are sorted by their ids.
+func (cs *caminoState) dump(w io.Writer) (ids.ID, error) {
+	content := caminoStateDumpContent{
+		NotDistributedValidatorReward: cs.notDistributedValidatorReward,
+	}
+
+	if err := dumpBucket(cs.addressStateDB, func(key, value []byte) error {
+		address, err := ids.ToShortID(key)
+		if err != nil {
+			return err
+		}
+		content.AddressStates = append(content.AddressStates, dumpedAddressStates{
+			Address: address,
+			States:  as.AddressState(binary.LittleEndian.Uint64(value)),
+		})
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := dumpBucket(cs.depositOffersDB, func(key, value []byte) error {
+		offerID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		offer := &deposit.Offer{ID: offerID}
+		if _, err := blocks.GenesisCodec.Unmarshal(value, offer); err != nil {
+			return err
+		}
+		if err := cs.loadDepositOfferExtension(offer); err != nil {
+			return err
+		}
+		content.DepositOffers = append(content.DepositOffers, offer)
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := dumpBucket(cs.depositsDB, func(key, value []byte) error {
+		depositTxID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		d := &deposit.Deposit{}
+		if _, err := blocks.GenesisCodec.Unmarshal(value, d); err != nil {
+			return err
+		}
+		content.Deposits = append(content.Deposits, dumpedDeposit{
+			DepositTxID:         depositTxID,
+			DepositOfferID:      d.DepositOfferID,
+			UnlockedAmount:      d.UnlockedAmount,
+			ClaimedRewardAmount: d.ClaimedRewardAmount,
+			Start:               d.Start,
+			Duration:            d.Duration,
+			Amount:              d.Amount,
+		})
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := dumpBucket(cs.multisigOwnersDB, func(key, value []byte) error {
+		aliasID, err := ids.ToShortID(key)
+		if err != nil {
+			return err
+		}
+		alias := &msigAlias{}
+		if _, err := blocks.GenesisCodec.Unmarshal(value, alias); err != nil {
+			return err
+		}
+		owners, weights, err := secp256k1fx.OwnersWeights(alias.Owners)
+		if err != nil {
+			return errWrongOwnerType
+		}
+		dumpedOwners := newDumpedOwner(owners)
+		dumpedOwners.Weights = weights
+		if timeLockedOwners, ok := alias.Owners.(*secp256k1fx.TimeLockedOutputOwners); ok {
+			dumpedOwners.ActiveFrom = timeLockedOwners.ActiveFrom
+			dumpedOwners.ActiveUntil = timeLockedOwners.ActiveUntil
+		}
+		content.MultisigAliases = append(content.MultisigAliases, dumpedMultisigAlias{
+			ID:     aliasID,
+			Memo:   alias.Memo,
+			Owners: dumpedOwners,
+		})
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := dumpBucket(cs.shortLinksDB, func(key, value []byte) error {
+		fullKey, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		link, err := ids.ToShortID(value)
+		if err != nil {
+			return err
+		}
+		id, linkKey := fromShortLinkKey(fullKey)
+		content.ShortLinks = append(content.ShortLinks, dumpedShortLink{ID: id, Key: linkKey, Link: link})
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	if err := dumpBucket(cs.claimablesDB, func(key, value []byte) error {
+		ownerID, err := ids.ToID(key)
+		if err != nil {
+			return err
+		}
+		claimable := &Claimable{}
+		if _, err := blocks.GenesisCodec.Unmarshal(value, claimable); err != nil {
+			return err
+		}
+		content.Claimables = append(content.Claimables, dumpedClaimable{
+			OwnerID:         ownerID,
+			Owner:           newDumpedOwner(claimable.Owner),
+			ValidatorReward: claimable.ValidatorReward,
+			DepositReward:   claimable.DepositReward,
+		})
+		return nil
+	}); err != nil {
+		return ids.Empty, err
+	}
+
+	// deferred validators list is ordered by insertion, so it must be sorted
+	deferredValidatorsIterator := cs.deferredValidatorList.NewIterator()
+	defer deferredValidatorsIterator.Release()
+	for deferredValidatorsIterator.Next() {
+		txID, err := ids.ToID(deferredValidatorsIterator.Key())
+		if err != nil {
+			return ids.Empty, err
+		}
+		content.DeferredValidatorTxIDs = append(content.DeferredValidatorTxIDs, txID)
+	}
+	if err := deferredValidatorsIterator.Error(); err != nil {
+		return ids.Empty, err
+	}
+	utils.Sort(content.DeferredValidatorTxIDs)
+
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return ids.Empty, err
+	}
+	dump := caminoStateDump{
+		Hash:  hashing.ComputeHash256Array(contentBytes),
+		State: contentBytes,
+	}
+	dumpBytes, err := json.Marshal(dump)
+	if err != nil {
+		return ids.Empty, err
+	}
+	if _, err := w.Write(dumpBytes); err != nil {
+		return ids.Empty, err
+	}
+	return dump.Hash, nil
+}
+
+// dumpBucket calls [dumpEntry] for each entry of [db] in ascending order of keys.
+func dumpBucket(db database.Iteratee, dumpEntry func(key, value []byte) error) error {
+	iterator := db.NewIterator()
+	defer iterator.Release()
+	for iterator.Next() {
+		if err := dumpEntry(iterator.Key(), iterator.Value()); err != nil {
+			return err
+		}
+	}
+	return iterator.Error()
+}
+
+func newDumpedOwner(owner *secp256k1fx.OutputOwners) *dumpedOwner {
+	if owner == nil {
+		return nil
+	}
+	return &dumpedOwner{
+		Locktime:  owner.Locktime,
+		Threshold: owner.Threshold,
+		Addresses: owner.Addrs,
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestDumpCaminoState(t *testing.T) {
+	require := require.New(t)
+
+	owner := &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	link := ids.ShortID{10}
+	offers := []*deposit.Offer{
+		{ID: ids.ID{2}, End: 2, Memo: []byte{2}},
+		{ID: ids.ID{1}, End: 1, Memo: []byte{1}},
+	}
+	modifications := []func(s *state){
+		func(s *state) { s.SetAddressStates(ids.ShortID{2}, 2) },
+		func(s *state) { s.SetAddressStates(ids.ShortID{1}, 1) },
+		func(s *state) { s.SetDepositOffer(offers[0]) },
+		func(s *state) { s.SetDepositOffer(offers[1]) },
+		func(s *state) {
+			s.SetMultisigAlias(&multisig.Alias{ID: ids.ShortID{1}, Memo: []byte{1}, Owners: owner})
+		},
+		func(s *state) { s.SetShortIDLink(ids.ShortID{1}, ShortLinkKeyRegisterNode, &link) },
+		func(s *state) { s.SetClaimable(ids.ID{1}, &Claimable{Owner: owner, ValidatorReward: 1}) },
+		func(s *state) { s.SetClaimable(ids.ID{2}, &Claimable{Owner: owner, DepositReward: 2}) },
+	}
+
+	dump := func(s *state) ([]byte, ids.ID) {
+		buf := &bytes.Buffer{}
+		hash, err := s.DumpCaminoState(buf)
+		require.NoError(err)
+		return buf.Bytes(), hash
+	}
+
+	// same state written in different order
+	s1 := newEmptyState(t)
+	for _, modify := range modifications {
+		modify(s1)
+		require.NoError(s1.caminoState.Write(s1))
+	}
+	s2 := newEmptyState(t)
+	for i := len(modifications) - 1; i >= 0; i-- {
+		modifications[i](s2)
+	}
+	require.NoError(s2.caminoState.Write(s2))
+
+	dump1, hash1 := dump(s1)
+	dump2, hash2 := dump(s2)
+	require.Equal(dump1, dump2)
+	require.Equal(hash1, hash2)
+
+	// dump contains its content hash
+	dumped := caminoStateDump{}
+	require.NoError(json.Unmarshal(dump1, &dumped))
+	require.Equal(hash1, dumped.Hash)
+	require.Equal(hash1, ids.ID(hashing.ComputeHash256Array(dumped.State)))
+
+	// getters results don't depend on modifications order
+	offers1, err := s1.GetAllDepositOffers()
+	require.NoError(err)
+	offers2, err := s2.GetAllDepositOffers()
+	require.NoError(err)
+	require.Equal([]*deposit.Offer{offers[1], offers[0]}, offers1)
+	require.Equal(offers1, offers2)
+
+	// different state
+	s2.SetAddressStates(ids.ShortID{1}, 3)
+	require.NoError(s2.caminoState.Write(s2))
+	_, hash2 = dump(s2)
+	require.NotEqual(hash1, hash2)
+}
@@ -0,0 +1,280 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+const (
+	depositsHistoryBucket byte = iota
+	claimablesHistoryBucket
+	addressStatesHistoryBucket
+)
+
+var (
+	_ CaminoHistoricalView = (*caminoHistoricalView)(nil)
+
+	errHeightNotInHistory = errors.New("height is out of camino state history window")
+)
+
+// CaminoHistoricalView is read-only view of persisted camino state at some accepted height.
+type CaminoHistoricalView interface {
+	GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
+	GetClaimable(ownerID ids.ID) (*Claimable, error)
+	GetAddressStates(address ids.ShortID) (as.AddressState, error)
+}
+
+type caminoHistoricalView struct {
+	cs     *caminoState
+	height uint64
+}
+
+// GetCaminoHistoricalView returns read-only view of deposits, claimables and address states
+// at accepted [height]. Height must be within configured history retention window.
+func (cs *caminoState) GetCaminoHistoricalView(height uint64) (CaminoHistoricalView, error) {
+	minHeight := cs.historyStartHeight
+	if cs.historyHeight > cs.historyRetention && cs.historyHeight-cs.historyRetention > minHeight {
+		minHeight = cs.historyHeight - cs.historyRetention
+	}
+	if cs.historyRetention == 0 || !cs.historyStarted || height < minHeight || height > cs.historyHeight {
+		return nil, fmt.Errorf("%w: requested %d", errHeightNotInHistory, height)
+	}
+	return &caminoHistoricalView{cs: cs, height: height}, nil
+}
+
+func (v *caminoHistoricalView) GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
+	depositBytes, err := v.cs.historicalValue(depositsHistoryBucket, depositTxID[:], v.height, v.cs.depositsDB)
+	if err != nil {
+		return nil, err
+	}
+	d := &deposit.Deposit{}
+	if _, err := blocks.GenesisCodec.Unmarshal(depositBytes, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (v *caminoHistoricalView) GetClaimable(ownerID ids.ID) (*Claimable, error) {
+	claimableBytes, err := v.cs.historicalValue(claimablesHistoryBucket, ownerID[:], v.height, v.cs.claimablesDB)
+	if err != nil {
+		return nil, err
+	}
+	claimable := &Claimable{}
+	if _, err := blocks.GenesisCodec.Unmarshal(claimableBytes, claimable); err != nil {
+		return nil, err
+	}
+	if claimable.RewardAssetID, err = v.cs.getClaimableRewardAsset(ownerID); err != nil {
+		return nil, err
+	}
+	return claimable, nil
+}
+
+func (v *caminoHistoricalView) GetAddressStates(address ids.ShortID) (as.AddressState, error) {
+	statesBytes, err := v.cs.historicalValue(addressStatesHistoryBucket, address[:], v.height, v.cs.addressStateDB)
+	switch err {
+	case nil:
+		return as.AddressState(binary.LittleEndian.Uint64(statesBytes)), nil
+	case database.ErrNotFound:
+		return as.AddressStateEmpty, nil
+	default:
+		return as.AddressStateEmpty, err
+	}
+}
+
+// historicalValue returns value of [key] from [bucket] at [height].
+// If [key] wasn't modified after [height], value is read from [currentDB].
+func (cs *caminoState) historicalValue(bucket byte, key []byte, height uint64, currentDB database.Database) ([]byte, error) {
+	historyIt := cs.historyDB.NewIteratorWithStartAndPrefix(
+		historyKey(bucket, key, height+1),
+		historyKeyPrefix(bucket, key),
+	)
+	defer historyIt.Release()
+
+	if historyIt.Next() {
+		// first change after [height] holds value, that [key] had at [height]
+		value := historyIt.Value()
+		if len(value) == 0 {
+			return nil, database.ErrNotFound
+		}
+		return value, nil
+	}
+	if err := historyIt.Error(); err != nil {
+		return nil, err
+	}
+	return currentDB.Get(key)
+}
+
+// writeHistory records persisted values of modified deposits, claimables and address states,
+// that they had before block with [height], and prunes history out of retention window.
+// Must be called before modifications are written.
+func (cs *caminoState) writeHistory(height uint64) error {
+	if cs.historyRetention == 0 {
+		return nil
+	}
+
+	if !cs.historyStarted {
+		// state before the first recorded block is the earliest reconstructable one
+		startHeight := height
+		if startHeight > 0 {
+			startHeight--
+		}
+		if err := database.PutUInt64(cs.caminoDB, caminoHistoryStartHeightKey, startHeight); err != nil {
+			return err
+		}
+		cs.historyStarted = true
+		cs.historyStartHeight = startHeight
+	}
+
+	for depositTxID := range cs.modifiedDeposits {
+		if err := cs.recordHistory(depositsHistoryBucket, depositTxID[:], height, cs.depositsDB); err != nil {
+			return err
+		}
+	}
+	for ownerID := range cs.modifiedClaimables {
+		if err := cs.recordHistory(claimablesHistoryBucket, ownerID[:], height, cs.claimablesDB); err != nil {
+			return err
+		}
+	}
+	for address := range cs.modifiedAddressStates {
+		if err := cs.recordHistory(addressStatesHistoryBucket, address[:], height, cs.addressStateDB); err != nil {
+			return err
+		}
+	}
+
+	if height > cs.historyHeight {
+		cs.historyHeight = height
+		if err := database.PutUInt64(cs.caminoDB, caminoHistoryHeightKey, height); err != nil {
+			return err
+		}
+	}
+
+	if cs.historyHeight <= cs.historyRetention {
+		return nil
+	}
+	return cs.pruneHistory(cs.historyHeight - cs.historyRetention)
+}
+
+func (cs *caminoState) recordHistory(bucket byte, key []byte, height uint64, currentDB database.Database) error {
+	recordKey := historyKey(bucket, key, height)
+	// there could be several writes at the same height,
+	// only the value before the first one is needed
+	if has, err := cs.historyDB.Has(recordKey); err != nil {
+		return err
+	} else if has {
+		return nil
+	}
+
+	value, err := currentDB.Get(key)
+	switch err {
+	case nil:
+	case database.ErrNotFound:
+		value = []byte{}
+	default:
+		return err
+	}
+
+	if err := cs.historyDB.Put(recordKey, value); err != nil {
+		return err
+	}
+	return cs.historyHeightsDB.Put(historyHeightKey(height, bucket, key), nil)
+}
+
+// pruneHistory removes all history records that are not needed
+// to reconstruct state at [minHeight] or later.
+func (cs *caminoState) pruneHistory(minHeight uint64) error {
+	heightsIt := cs.historyHeightsDB.NewIterator()
+	defer heightsIt.Release()
+
+	for heightsIt.Next() {
+		heightKey := heightsIt.Key()
+		if binary.BigEndian.Uint64(heightKey) > minHeight {
+			break
+		}
+		// height key is height + bucket + key, history key is bucket + key + height
+		recordKey := make([]byte, 0, len(heightKey))
+		recordKey = append(recordKey, heightKey[8:]...)
+		recordKey = append(recordKey, heightKey[:8]...)
+		if err := cs.historyDB.Delete(recordKey); err != nil {
+			return err
+		}
+		if err := cs.historyHeightsDB.Delete(heightKey); err != nil {
+			return err
+		}
+	}
+	if err := heightsIt.Error(); err != nil {
+		return err
+	}
+
+	if minHeight > cs.historyStartHeight {
+		cs.historyStartHeight = minHeight
+		return database.PutUInt64(cs.caminoDB, caminoHistoryStartHeightKey, minHeight)
+	}
+	return nil
+}
+
+// loadHistory loads history window or, if history is disabled, removes recorded history.
+func (cs *caminoState) loadHistory() error {
+	startHeight, err := database.GetUInt64(cs.caminoDB, caminoHistoryStartHeightKey)
+	switch err {
+	case nil:
+		cs.historyStarted = true
+		cs.historyStartHeight = startHeight
+	case database.ErrNotFound:
+		return nil
+	default:
+		return err
+	}
+
+	if cs.historyHeight, err = database.GetUInt64(cs.caminoDB, caminoHistoryHeightKey); err != nil && err != database.ErrNotFound {
+		return err
+	}
+
+	if cs.historyRetention != 0 {
+		return nil
+	}
+
+	// history that was recorded before it was disabled will have a gap, if it'll be enabled again
+	cs.historyStarted = false
+	cs.historyStartHeight = 0
+	cs.historyHeight = 0
+	if err := database.Clear(cs.historyDB, cs.historyDB); err != nil {
+		return err
+	}
+	if err := database.Clear(cs.historyHeightsDB, cs.historyHeightsDB); err != nil {
+		return err
+	}
+	if err := cs.caminoDB.Delete(caminoHistoryStartHeightKey); err != nil {
+		return err
+	}
+	return cs.caminoDB.Delete(caminoHistoryHeightKey)
+}
+
+// historyKeyPrefix returns bucket + key
+func historyKeyPrefix(bucket byte, key []byte) []byte {
+	prefix := make([]byte, 0, 1+len(key)+8)
+	prefix = append(prefix, bucket)
+	return append(prefix, key...)
+}
+
+// historyKey returns bucket + key + height
+func historyKey(bucket byte, key []byte, height uint64) []byte {
+	return append(historyKeyPrefix(bucket, key), database.PackUInt64(height)...)
+}
+
+// historyHeightKey returns height + bucket + key
+func historyHeightKey(height uint64, bucket byte, key []byte) []byte {
+	heightKey := make([]byte, 0, 8+1+len(key))
+	heightKey = append(heightKey, database.PackUInt64(height)...)
+	heightKey = append(heightKey, bucket)
+	return append(heightKey, key...)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCaminoHistoricalView(t *testing.T) {
+	require := require.New(t)
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	cs.historyRetention = 3
+
+	address := ids.ShortID{1}
+	ownerID := ids.ID{2}
+	newClaimable := func(reward uint64) *Claimable {
+		return &Claimable{
+			Owner:           &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{address}},
+			ValidatorReward: reward,
+		}
+	}
+	depositTx, err := txs.NewSigned(&txs.DepositTx{RewardsOwner: &secp256k1fx.OutputOwners{}}, txs.Codec, nil)
+	require.NoError(err)
+	offer := &deposit.Offer{ID: ids.ID{3}, End: 10, Memo: []byte{3}}
+	deposit1 := &deposit.Deposit{DepositOfferID: offer.ID, Amount: 1, Duration: 1}
+	s.AddTx(depositTx, status.Committed)
+	s.SetDepositOffer(offer)
+
+	write := func(height uint64) {
+		s.SetHeight(height)
+		require.NoError(s.caminoState.Write(s))
+	}
+
+	// height 1
+	s.SetAddressStates(address, 1)
+	s.SetClaimable(ownerID, newClaimable(1))
+	s.AddDeposit(depositTx.ID(), deposit1)
+	write(1)
+	// height 2
+	s.SetAddressStates(address, 2)
+	s.SetClaimable(ownerID, newClaimable(2))
+	write(2)
+	// height 3
+	s.SetAddressStates(address, 0)
+	s.SetClaimable(ownerID, nil)
+	s.RemoveDeposit(depositTx.ID(), deposit1)
+	write(3)
+	// height 4, second write at the same height
+	s.SetAddressStates(address, 4)
+	write(4)
+	s.SetAddressStates(address, 5)
+	write(4)
+
+	// history before height 1 is pruned, history after height 4 doesn't exist yet
+	for _, height := range []uint64{0, 5} {
+		_, err := s.GetCaminoHistoricalView(height)
+		require.ErrorIs(err, errHeightNotInHistory)
+	}
+
+	tests := map[uint64]struct {
+		expectedStates    as.AddressState
+		expectedClaimable *Claimable
+		expectedDeposit   *deposit.Deposit
+	}{
+		1: {expectedStates: 1, expectedClaimable: newClaimable(1), expectedDeposit: deposit1},
+		2: {expectedStates: 2, expectedClaimable: newClaimable(2), expectedDeposit: deposit1},
+		3: {},
+		4: {expectedStates: 5},
+	}
+	for height, tt := range tests {
+		view, err := s.GetCaminoHistoricalView(height)
+		require.NoError(err)
+
+		states, err := view.GetAddressStates(address)
+		require.NoError(err)
+		require.Equal(tt.expectedStates, states, "height %d", height)
+
+		claimable, err := view.GetClaimable(ownerID)
+		if tt.expectedClaimable == nil {
+			require.ErrorIs(err, database.ErrNotFound)
+		} else {
+			require.NoError(err)
+			require.Equal(tt.expectedClaimable, claimable, "height %d", height)
+		}
+
+		d, err := view.GetDeposit(depositTx.ID())
+		if tt.expectedDeposit == nil {
+			require.ErrorIs(err, database.ErrNotFound)
+		} else {
+			require.NoError(err)
+			require.Equal(tt.expectedDeposit, d, "height %d", height)
+		}
+	}
+
+	// disabling history removes it
+	cs.historyRetention = 0
+	require.NoError(cs.loadHistory())
+	_, err = s.GetCaminoHistoricalView(4)
+	require.ErrorIs(err, errHeightNotInHistory)
+	isEmpty, err := database.IsEmpty(cs.historyDB)
+	require.NoError(err)
+	require.True(isEmpty)
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errDepositAmountMismatch = errors.New("deposited utxos amount doesn't match deposit amount")
+	errBondAmountMismatch    = errors.New("bonded utxos amount doesn't match validator weight")
+	errInvalidClaimable      = errors.New("invalid claimable")
+)
+
+// VerifyCaminoStateInvariants checks, that persisted camino state is consistent:
+// amounts of deposited utxos match not unlocked deposit amounts, amounts of bonded
+// utxos match primary network validator weights and claimables have owner and
+// not overflowing non-zero rewards.
+func (s *state) VerifyCaminoStateInvariants() error {
+	depositedAmounts, bondedAmounts, err := s.lockedAmounts()
+	if err != nil {
+		return err
+	}
+
+	if err := s.caminoState.verifyInvariants(depositedAmounts); err != nil {
+		return err
+	}
+
+	currentStakerIterator, err := s.GetCurrentStakerIterator()
+	if err != nil {
+		return err
+	}
+	pendingStakerIterator, err := s.GetPendingStakerIterator()
+	if err != nil {
+		currentStakerIterator.Release()
+		return err
+	}
+	deferredStakerIterator, err := s.GetDeferredStakerIterator()
+	if err != nil {
+		currentStakerIterator.Release()
+		pendingStakerIterator.Release()
+		return err
+	}
+	for _, stakerIterator := range []StakerIterator{currentStakerIterator, pendingStakerIterator, deferredStakerIterator} {
+		if err := verifyValidatorBonds(stakerIterator, bondedAmounts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockedAmounts returns amounts of persisted utxos locked by deposit and bond txs.
+func (s *state) lockedAmounts() (map[ids.ID]uint64, map[ids.ID]uint64, error) {
+	depositedAmounts := map[ids.ID]uint64{}
+	bondedAmounts := map[ids.ID]uint64{}
+
+	utxoIterator := prefixdb.New(avaxUTXOPrefix, s.utxoDB).NewIterator()
+	defer utxoIterator.Release()
+
+	for utxoIterator.Next() {
+		utxo := &avax.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(utxoIterator.Value(), utxo); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse utxo: %w", err)
+		}
+		lockedOut, ok := locked.AsOut(utxo.Out)
+		if !ok {
+			continue
+		}
+		if lockedOut.DepositTxID != ids.Empty {
+			amount, err := math.Add64(depositedAmounts[lockedOut.DepositTxID], lockedOut.Amount())
+			if err != nil {
+				return nil, nil, err
+			}
+			depositedAmounts[lockedOut.DepositTxID] = amount
+		}
+		if lockedOut.BondTxID != ids.Empty {
+			amount, err := math.Add64(bondedAmounts[lockedOut.BondTxID], lockedOut.Amount())
+			if err != nil {
+				return nil, nil, err
+			}
+			bondedAmounts[lockedOut.BondTxID] = amount
+		}
+	}
+	return depositedAmounts, bondedAmounts, utxoIterator.Error()
+}
+
+// verifyValidatorBonds checks, that primary network validators from [stakerIterator]
+// have bonded amount equal to their weight. Iterator is released.
+func verifyValidatorBonds(stakerIterator StakerIterator, bondedAmounts map[ids.ID]uint64) error {
+	defer stakerIterator.Release()
+	for stakerIterator.Next() {
+		staker := stakerIterator.Value()
+		if staker.SubnetID != constants.PrimaryNetworkID ||
+			staker.Priority != txs.PrimaryNetworkValidatorCurrentPriority &&
+				staker.Priority != txs.PrimaryNetworkValidatorPendingPriority {
+			continue
+		}
+		if bondedAmount := bondedAmounts[staker.TxID]; bondedAmount != staker.Weight {
+			return fmt.Errorf("%w: validator tx %s has weight %d, but bonded %d",
+				errBondAmountMismatch, staker.TxID, staker.Weight, bondedAmount)
+		}
+	}
+	return nil
+}
+
+// verifyInvariants checks persisted deposits against [depositedAmounts] and claimables.
+func (cs *caminoState) verifyInvariants(depositedAmounts map[ids.ID]uint64) error {
+	depositsIterator := cs.depositsDB.NewIterator()
+	defer depositsIterator.Release()
+
+	checkedDeposits := 0
+	for depositsIterator.Next() {
+		depositTxID, err := ids.ToID(depositsIterator.Key())
+		if err != nil {
+			return err
+		}
+		d := &deposit.Deposit{}
+		if _, err := blocks.GenesisCodec.Unmarshal(depositsIterator.Value(), d); err != nil {
+			return err
+		}
+		if lockedAmount := d.Amount - d.UnlockedAmount; depositedAmounts[depositTxID] != lockedAmount {
+			return fmt.Errorf("%w: deposit %s has locked amount %d, but deposited %d",
+				errDepositAmountMismatch, depositTxID, lockedAmount, depositedAmounts[depositTxID])
+		}
+		if _, ok := depositedAmounts[depositTxID]; ok {
+			checkedDeposits++
+		}
+	}
+	if err := depositsIterator.Error(); err != nil {
+		return err
+	}
+	if checkedDeposits != len(depositedAmounts) {
+		return fmt.Errorf("%w: %d utxo deposit locks don't have deposits",
+			errDepositAmountMismatch, len(depositedAmounts)-checkedDeposits)
+	}
+
+	claimablesIterator, err := cs.GetClaimablesIterator()
+	if err != nil {
+		return err
+	}
+	defer claimablesIterator.Release()
+	for claimablesIterator.Next() {
+		claimable := claimablesIterator.Value()
+		if claimable.Owner == nil {
+			return fmt.Errorf("%w: claimable %s doesn't have owner",
+				errInvalidClaimable, claimablesIterator.OwnerID())
+		}
+		reward, err := math.Add64(claimable.ValidatorReward, claimable.DepositReward)
+		if err != nil {
+			return fmt.Errorf("%w: claimable %s reward overflows: %s",
+				errInvalidClaimable, claimablesIterator.OwnerID(), err)
+		}
+		if reward == 0 {
+			return fmt.Errorf("%w: claimable %s has zero reward",
+				errInvalidClaimable, claimablesIterator.OwnerID())
+		}
+	}
+	return claimablesIterator.Error()
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestVerifyCaminoStateInvariants(t *testing.T) {
+	assetID := ids.ID{'a'}
+	owner := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	depositTxID := ids.ID{1}
+	bondTxID := ids.ID{2}
+
+	tests := map[string]struct {
+		depositedAmount uint64
+		deposit         *deposit.Deposit
+		bondedAmount    uint64
+		validatorWeight uint64
+		claimable       *Claimable
+		expectedErr     error
+	}{
+		"OK": {
+			depositedAmount: 6,
+			deposit:         &deposit.Deposit{Amount: 10, UnlockedAmount: 4},
+			bondedAmount:    5,
+			validatorWeight: 5,
+			claimable:       &Claimable{Owner: &owner, DepositReward: 1},
+		},
+		"Deposited amount mismatch": {
+			depositedAmount: 7,
+			deposit:         &deposit.Deposit{Amount: 10, UnlockedAmount: 4},
+			expectedErr:     errDepositAmountMismatch,
+		},
+		"Deposit lock without deposit": {
+			depositedAmount: 6,
+			expectedErr:     errDepositAmountMismatch,
+		},
+		"Bonded amount mismatch": {
+			bondedAmount:    4,
+			validatorWeight: 5,
+			expectedErr:     errBondAmountMismatch,
+		},
+		"Validator without bond": {
+			validatorWeight: 5,
+			expectedErr:     errBondAmountMismatch,
+		},
+		"Claimable without reward": {
+			claimable:   &Claimable{Owner: &owner},
+			expectedErr: errInvalidClaimable,
+		},
+		"Claimable without owner": {
+			claimable:   &Claimable{ValidatorReward: 1},
+			expectedErr: errInvalidClaimable,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			s := newEmptyState(t)
+			cs, ok := s.caminoState.(*caminoState)
+			require.True(ok)
+
+			if tt.depositedAmount != 0 {
+				s.AddUTXO(generateTestUTXO(ids.ID{10}, assetID, tt.depositedAmount, owner, depositTxID, ids.Empty))
+			}
+			if tt.bondedAmount != 0 {
+				s.AddUTXO(generateTestUTXO(ids.ID{11}, assetID, tt.bondedAmount, owner, ids.Empty, bondTxID))
+			}
+			s.AddUTXO(generateTestUTXO(ids.ID{12}, assetID, 1, owner, ids.Empty, ids.Empty))
+			require.NoError(s.writeUTXOs())
+
+			if tt.deposit != nil {
+				cs.modifiedDeposits[depositTxID] = &depositDiff{Deposit: tt.deposit, added: true}
+				require.NoError(cs.writeDeposits())
+			}
+			if tt.validatorWeight != 0 {
+				s.PutCurrentValidator(&Staker{
+					TxID:     bondTxID,
+					SubnetID: constants.PrimaryNetworkID,
+					Weight:   tt.validatorWeight,
+					Priority: txs.PrimaryNetworkValidatorCurrentPriority,
+				})
+			}
+			if tt.claimable != nil {
+				s.SetClaimable(ids.ID{3}, tt.claimable)
+				// claimable without owner can't be serialized,
+				// so it's verified while it's still only modified in memory
+				if tt.claimable.Owner != nil {
+					require.NoError(cs.writeClaimableAndValidatorRewards())
+				}
+			}
+
+			require.ErrorIs(s.VerifyCaminoStateInvariants(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// lockedUTXOIDs returns IDs of utxos locked by [lockTxID] with any lock state.
+func (cs *caminoState) lockedUTXOIDs(lockTxID ids.ID) ([]ids.ID, error) {
+	iterator := cs.utxoIDsByLockTxDB.NewIteratorWithPrefix(lockTxID[:])
+	defer iterator.Release()
+
+	utxoIDs := []ids.ID{}
+	for iterator.Next() {
+		utxoID, err := ids.ToID(iterator.Key()[len(lockTxID):])
+		if err != nil {
+			return nil, err
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iterator.Error()
+}
+
+// indexLockedUTXO adds [utxo] to lock tx index, if its out is locked.
+func (cs *caminoState) indexLockedUTXO(utxo *avax.UTXO) error {
+	utxoID := utxo.InputID()
+	for _, lockTxID := range utxoLockTxIDs(utxo) {
+		if err := cs.utxoIDsByLockTxDB.Put(lockTxPrefixedKey(lockTxID, utxoID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexLockedUTXO removes [utxo] from lock tx index, if its out is locked.
+func (cs *caminoState) unindexLockedUTXO(utxo *avax.UTXO) error {
+	utxoID := utxo.InputID()
+	for _, lockTxID := range utxoLockTxIDs(utxo) {
+		if err := cs.utxoIDsByLockTxDB.Delete(lockTxPrefixedKey(lockTxID, utxoID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadUTXOLockIndex builds lock tx index from existing utxos,
+// if database was created before index was introduced.
+func (cs *caminoState) loadUTXOLockIndex(s *state) error {
+	if _, err := database.GetBool(cs.caminoDB, utxoLockIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	utxoIterator := prefixdb.New(avaxUTXOPrefix, s.utxoDB).NewIterator()
+	defer utxoIterator.Release()
+
+	for utxoIterator.Next() {
+		utxo := &avax.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(utxoIterator.Value(), utxo); err != nil {
+			return fmt.Errorf("failed to parse utxo: %w", err)
+		}
+		if err := cs.indexLockedUTXO(utxo); err != nil {
+			return err
+		}
+	}
+	if err := utxoIterator.Error(); err != nil {
+		return err
+	}
+
+	return database.PutBool(cs.caminoDB, utxoLockIndexKey, true)
+}
+
+// utxoLockTxIDs returns ids of txs, that locked [utxo] out.
+func utxoLockTxIDs(utxo *avax.UTXO) []ids.ID {
+	lockIDs, _, ok := locked.OutIDs(utxo.Out)
+	if !ok {
+		return nil
+	}
+	lockTxIDs := make([]ids.ID, 0, 2)
+	for _, lockTxID := range []ids.ID{lockIDs.DepositTxID, lockIDs.BondTxID, lockIDs.EscrowTxID} {
+		if lockTxID != ids.Empty {
+			lockTxIDs = append(lockTxIDs, lockTxID)
+		}
+	}
+	return lockTxIDs
+}
+
+// lockTxPrefixedKey returns [lockTxID] concatenated with [utxoID]
+func lockTxPrefixedKey(lockTxID, utxoID ids.ID) []byte {
+	key := make([]byte, 0, len(lockTxID)+len(utxoID))
+	key = append(key, lockTxID[:]...)
+	return append(key, utxoID[:]...)
+}
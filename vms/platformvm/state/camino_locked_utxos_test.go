@@ -0,0 +1,73 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestLockedUTXOsIndex(t *testing.T) {
+	require := require.New(t)
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+
+	owner := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	depositTxID := ids.ID{100}
+	bondTxID := ids.ID{101}
+	assetID := ids.ID{'a'}
+
+	unlockedUTXO := generateTestUTXO(ids.ID{1}, assetID, 1, owner, ids.Empty, ids.Empty)
+	depositedUTXO := generateTestUTXO(ids.ID{2}, assetID, 1, owner, depositTxID, ids.Empty)
+	bondedUTXO := generateTestUTXO(ids.ID{3}, assetID, 1, owner, ids.Empty, bondTxID)
+	depositedBondedUTXO := generateTestUTXO(ids.ID{4}, assetID, 1, owner, depositTxID, bondTxID)
+	for _, utxo := range []*avax.UTXO{unlockedUTXO, depositedUTXO, bondedUTXO, depositedBondedUTXO} {
+		s.AddUTXO(utxo)
+	}
+	require.NoError(s.writeUTXOs())
+
+	requireIndex := func(depositUTXOIDs, bondUTXOIDs []ids.ID) {
+		utxoIDs, err := cs.lockedUTXOIDs(depositTxID)
+		require.NoError(err)
+		require.ElementsMatch(depositUTXOIDs, utxoIDs)
+		utxoIDs, err = cs.lockedUTXOIDs(bondTxID)
+		require.NoError(err)
+		require.ElementsMatch(bondUTXOIDs, utxoIDs)
+	}
+
+	requireIndex(
+		[]ids.ID{depositedUTXO.InputID(), depositedBondedUTXO.InputID()},
+		[]ids.ID{bondedUTXO.InputID(), depositedBondedUTXO.InputID()},
+	)
+
+	// deletion
+	s.DeleteUTXO(depositedBondedUTXO.InputID())
+	require.NoError(s.writeUTXOs())
+
+	requireIndex(
+		[]ids.ID{depositedUTXO.InputID()},
+		[]ids.ID{bondedUTXO.InputID()},
+	)
+
+	// rebuilding index for database without it
+	require.NoError(cs.utxoIDsByLockTxDB.Delete(lockTxPrefixedKey(depositTxID, depositedUTXO.InputID())))
+	require.NoError(cs.utxoIDsByLockTxDB.Delete(lockTxPrefixedKey(bondTxID, bondedUTXO.InputID())))
+	require.NoError(cs.caminoDB.Delete(utxoLockIndexKey))
+	require.NoError(cs.loadUTXOLockIndex(s))
+
+	requireIndex(
+		[]ids.ID{depositedUTXO.InputID()},
+		[]ids.ID{bondedUTXO.InputID()},
+	)
+	indexed, err := database.GetBool(cs.caminoDB, utxoLockIndexKey)
+	require.NoError(err)
+	require.True(indexed)
+}
@@ -0,0 +1,63 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+type caminoMetrics struct {
+	modifiedDeposits      metric.Averager
+	modifiedClaimables    metric.Averager
+	modifiedAddressStates metric.Averager
+	applyDuration         metric.Averager
+}
+
+func newCaminoMetrics(registerer prometheus.Registerer) (*caminoMetrics, error) {
+	errs := wrappers.Errs{}
+	m := &caminoMetrics{
+		modifiedDeposits: metric.NewAveragerWithErrs(
+			"",
+			"camino_diff_modified_deposits",
+			"number of deposits modified by accepted block",
+			registerer,
+			&errs,
+		),
+		modifiedClaimables: metric.NewAveragerWithErrs(
+			"",
+			"camino_diff_modified_claimables",
+			"number of claimables modified by accepted block",
+			registerer,
+			&errs,
+		),
+		modifiedAddressStates: metric.NewAveragerWithErrs(
+			"",
+			"camino_diff_modified_address_states",
+			"number of address states modified by accepted block",
+			registerer,
+			&errs,
+		),
+		applyDuration: metric.NewAveragerWithErrs(
+			"",
+			"camino_diff_apply_duration",
+			"time (in ns) of applying camino diff of accepted block",
+			registerer,
+			&errs,
+		),
+	}
+	return m, errs.Err
+}
+
+// observeAppliedDiff records size of camino [diff], that was applied to state, and time it took.
+func (cs *caminoState) observeAppliedDiff(diff *caminoDiff, duration time.Duration) {
+	cs.metrics.modifiedDeposits.Observe(float64(len(diff.modifiedDeposits)))
+	cs.metrics.modifiedClaimables.Observe(float64(len(diff.modifiedClaimables)))
+	cs.metrics.modifiedAddressStates.Observe(float64(len(diff.modifiedAddressStates)))
+	cs.metrics.applyDuration.Observe(float64(duration))
+}
@@ -0,0 +1,59 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var (
+	// caminoMigrations are applied in order of their declaration on node start.
+	// Schema version of camino state is the number of applied migrations.
+	// New migrations must only be appended to the end of this list.
+	caminoMigrations = []caminoMigration{}
+
+	errUnknownSchemaVersion = errors.New("unknown camino state schema version")
+)
+
+type caminoMigration struct {
+	// description is used for error messages
+	description string
+	// migrate changes persisted camino state format. It's called before camino state is loaded.
+	migrate func(cs *caminoState) error
+}
+
+// currentSchemaVersion returns schema version of camino state created with current node version.
+func currentSchemaVersion(migrations []caminoMigration) uint64 {
+	return uint64(len(migrations))
+}
+
+// applyMigrations applies [migrations], that weren't applied yet, and records resulting schema version.
+// Database without schema version is considered to have version 0.
+func (cs *caminoState) applyMigrations(migrations []caminoMigration) error {
+	version, err := database.GetUInt64(cs.caminoDB, caminoSchemaVersionKey)
+	switch {
+	case err == database.ErrNotFound:
+		version = 0
+	case err != nil:
+		return err
+	case version > currentSchemaVersion(migrations):
+		return fmt.Errorf("%w: %d, latest known version is %d",
+			errUnknownSchemaVersion, version, currentSchemaVersion(migrations))
+	}
+
+	for ; version < currentSchemaVersion(migrations); version++ {
+		migration := migrations[version]
+		if err := migration.migrate(cs); err != nil {
+			return fmt.Errorf("failed to migrate camino state to version %d (%s): %w",
+				version+1, migration.description, err)
+		}
+		if err := database.PutUInt64(cs.caminoDB, caminoSchemaVersionKey, version+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
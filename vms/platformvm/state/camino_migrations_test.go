@@ -0,0 +1,85 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+func TestApplyMigrations(t *testing.T) {
+	errMigration := errors.New("test migration error")
+
+	tests := map[string]struct {
+		storedVersion   uint64 // 0 - not stored
+		failingVersion  uint64 // 1-based version, that migration fails to reach, 0 - no failure
+		expectedApplied []uint64
+		expectedVersion uint64
+		expectedErr     error
+	}{
+		"Database without version": {
+			expectedApplied: []uint64{1, 2, 3},
+			expectedVersion: 3,
+		},
+		"Partially migrated database": {
+			storedVersion:   1,
+			expectedApplied: []uint64{2, 3},
+			expectedVersion: 3,
+		},
+		"Up to date database": {
+			storedVersion:   3,
+			expectedApplied: []uint64{},
+			expectedVersion: 3,
+		},
+		"Unknown version": {
+			storedVersion:   4,
+			expectedApplied: []uint64{},
+			expectedVersion: 4,
+			expectedErr:     errUnknownSchemaVersion,
+		},
+		"Failed migration": {
+			failingVersion:  2,
+			expectedApplied: []uint64{1},
+			expectedVersion: 1,
+			expectedErr:     errMigration,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			cs, ok := newEmptyState(t).caminoState.(*caminoState)
+			require.True(ok)
+			if tt.storedVersion != 0 {
+				require.NoError(database.PutUInt64(cs.caminoDB, caminoSchemaVersionKey, tt.storedVersion))
+			}
+
+			applied := []uint64{}
+			migrations := make([]caminoMigration, 3)
+			for i := range migrations {
+				version := uint64(i + 1)
+				migrations[i] = caminoMigration{
+					description: "test migration",
+					migrate: func(*caminoState) error {
+						if version == tt.failingVersion {
+							return errMigration
+						}
+						applied = append(applied, version)
+						return nil
+					},
+				}
+			}
+
+			err := cs.applyMigrations(migrations)
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.expectedApplied, applied)
+			version, err := database.GetUInt64(cs.caminoDB, caminoSchemaVersionKey)
+			require.NoError(err)
+			require.Equal(tt.expectedVersion, version)
+		})
+	}
+}
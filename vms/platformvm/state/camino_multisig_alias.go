@@ -4,13 +4,19 @@
 package state
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
+	"math"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
 )
 
@@ -19,6 +25,13 @@ type msigAlias struct {
 	Owners verify.State        `serialize:"true"`
 }
 
+// MultisigAliasVersion is previous version of multisig alias,
+// that was replaced by block with [Height].
+type MultisigAliasVersion struct {
+	Height uint64          `json:"height"`
+	Alias  *multisig.Alias `json:"alias"`
+}
+
 func (cs *caminoState) SetMultisigAlias(ma *multisig.Alias) {
 	cs.modifiedMultisigOwners[ma.ID] = ma
 	cs.multisigOwnersCache.Evict(ma.ID)
@@ -36,7 +49,7 @@ func (cs *caminoState) GetMultisigAlias(id ids.ShortID) (*multisig.Alias, error)
 		if alias == nil {
 			return nil, database.ErrNotFound
 		}
-		return alias.(*multisig.Alias), nil
+		return alias, nil
 	}
 
 	maBytes, err := cs.multisigOwnersDB.Get(id[:])
@@ -59,6 +72,91 @@ func (cs *caminoState) GetMultisigAlias(id ids.ShortID) (*multisig.Alias, error)
 	}, nil
 }
 
+// GetMultisigAliases returns at most [limit] multisig aliases with ids greater than [start],
+// sorted by alias id. If [start] is ids.ShortEmpty, starts at beginning.
+func (cs *caminoState) GetMultisigAliases(start ids.ShortID, limit int) ([]*multisig.Alias, error) {
+	iterator := cs.multisigOwnersDB.NewIteratorWithStart(start[:])
+	defer iterator.Release()
+
+	dbLimit := aliasesParentLimit(limit, len(cs.modifiedMultisigOwners))
+
+	aliases := []*multisig.Alias{}
+	for len(aliases) < dbLimit && iterator.Next() {
+		aliasID, err := ids.ToShortID(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		if aliasID == start && start != ids.ShortEmpty {
+			continue
+		}
+		multisigAlias := &msigAlias{}
+		if _, err = blocks.GenesisCodec.Unmarshal(iterator.Value(), multisigAlias); err != nil {
+			return nil, err
+		}
+		aliases = append(aliases, &multisig.Alias{
+			ID:     aliasID,
+			Memo:   multisigAlias.Memo,
+			Owners: multisigAlias.Owners,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return mergeMultisigAliases(aliases, cs.modifiedMultisigOwners, start, limit), nil
+}
+
+// aliasesParentLimit returns number of aliases that must be requested from parent,
+// so [limit] aliases could be returned after merging with [modifiedCount] modified aliases.
+// Modified aliases could remove at most [modifiedCount] parent aliases.
+func aliasesParentLimit(limit, modifiedCount int) int {
+	if limit > math.MaxInt-modifiedCount {
+		return math.MaxInt
+	}
+	return limit + modifiedCount
+}
+
+// mergeMultisigAliases overrides sorted [aliases] with [modified] aliases, that are greater than [start],
+// and returns at most [limit] of resulting aliases sorted by id. Nil modified aliases are treated as removed.
+func mergeMultisigAliases(
+	aliases []*multisig.Alias,
+	modified map[ids.ShortID]*multisig.Alias,
+	start ids.ShortID,
+	limit int,
+) []*multisig.Alias {
+	merged := make(map[ids.ShortID]*multisig.Alias, len(aliases)+len(modified))
+	for _, alias := range aliases {
+		merged[alias.ID] = alias
+	}
+	for aliasID, alias := range modified {
+		if start != ids.ShortEmpty && bytes.Compare(aliasID[:], start[:]) <= 0 {
+			continue
+		}
+		if alias == nil {
+			delete(merged, aliasID)
+		} else {
+			merged[aliasID] = alias
+		}
+	}
+
+	aliasIDs := make([]ids.ShortID, 0, len(merged))
+	for aliasID := range merged {
+		aliasIDs = append(aliasIDs, aliasID)
+	}
+	utils.Sort(aliasIDs)
+
+	// [aliases] are expected to contain at least limit+len(modified) first aliases (if there are that many),
+	// so first [limit] merged aliases are not affected by aliases, that wasn't returned
+	if len(aliasIDs) > limit {
+		aliasIDs = aliasIDs[:limit]
+	}
+	result := make([]*multisig.Alias, len(aliasIDs))
+	for i, aliasID := range aliasIDs {
+		result[i] = merged[aliasID]
+	}
+	return result
+}
+
 func (cs *caminoState) writeMultisigOwners() error {
 	for key, alias := range cs.modifiedMultisigOwners {
 		delete(cs.modifiedMultisigOwners, key)
@@ -82,3 +180,193 @@ func (cs *caminoState) writeMultisigOwners() error {
 	}
 	return nil
 }
+
+// GetMultisigAliasHistory returns persisted previous versions of multisig alias [aliasID]
+// in order they were replaced. If alias was modified several times by one block,
+// only version before this block is recorded.
+func (cs *caminoState) GetMultisigAliasHistory(aliasID ids.ShortID) ([]*MultisigAliasVersion, error) {
+	iterator := cs.multisigAliasHistoryDB.NewIteratorWithPrefix(aliasID[:])
+	defer iterator.Release()
+
+	versions := []*MultisigAliasVersion{}
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != len(aliasID)+8 {
+			return nil, fmt.Errorf("wrong multisig alias history key length: %d", len(key))
+		}
+		alias := &msigAlias{}
+		if _, err := blocks.GenesisCodec.Unmarshal(iterator.Value(), alias); err != nil {
+			return nil, err
+		}
+		versions = append(versions, &MultisigAliasVersion{
+			Height: binary.BigEndian.Uint64(key[len(aliasID):]),
+			Alias: &multisig.Alias{
+				ID:     aliasID,
+				Memo:   alias.Memo,
+				Owners: alias.Owners,
+			},
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// writeMultisigAliasHistory records persisted versions of modified multisig aliases,
+// that they had before block with [height]. Must be called before writeMultisigOwners.
+func (cs *caminoState) writeMultisigAliasHistory(height uint64) error {
+	for aliasID := range cs.modifiedMultisigOwners {
+		aliasBytes, err := cs.multisigOwnersDB.Get(aliasID[:])
+		switch {
+		case err == database.ErrNotFound:
+			continue
+		case err != nil:
+			return err
+		}
+
+		key := make([]byte, len(aliasID)+8)
+		copy(key, aliasID[:])
+		binary.BigEndian.PutUint64(key[len(aliasID):], height)
+
+		// there could be several writes at the same height,
+		// only the version before the first one is recorded
+		if has, err := cs.multisigAliasHistoryDB.Has(key); err != nil {
+			return err
+		} else if has {
+			continue
+		}
+		if err := cs.multisigAliasHistoryDB.Put(key, aliasBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetMultisigAliasIDsByMember returns sorted ids of multisig aliases, which owners contain [member] address.
+func (cs *caminoState) GetMultisigAliasIDsByMember(member ids.ShortID) ([]ids.ShortID, error) {
+	aliasIDs := set.Set[ids.ShortID]{}
+
+	iterator := cs.multisigAliasesByMemberDB.NewIteratorWithPrefix(member[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		aliasID, err := ids.ToShortID(iterator.Key()[len(member):])
+		if err != nil {
+			return nil, err
+		}
+		aliasIDs.Add(aliasID)
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	return overrideMultisigAliasIDsByMember(aliasIDs, cs.modifiedMultisigOwners, member), nil
+}
+
+// overrideMultisigAliasIDsByMember overrides [aliasIDs] of [member] with [modified] aliases
+// and returns them sorted. Nil modified aliases are treated as removed.
+func overrideMultisigAliasIDsByMember(
+	aliasIDs set.Set[ids.ShortID],
+	modified map[ids.ShortID]*multisig.Alias,
+	member ids.ShortID,
+) []ids.ShortID {
+	for aliasID, alias := range modified {
+		isMember := false
+		if alias != nil {
+			for _, aliasMember := range aliasMembers(alias.Owners) {
+				if aliasMember == member {
+					isMember = true
+					break
+				}
+			}
+		}
+		if isMember {
+			aliasIDs.Add(aliasID)
+		} else {
+			aliasIDs.Remove(aliasID)
+		}
+	}
+
+	aliasIDsList := aliasIDs.List()
+	utils.Sort(aliasIDsList)
+	return aliasIDsList
+}
+
+// writeMultisigAliasesByMember must be called before writeMultisigOwners,
+// because it reads previous aliases from database and writeMultisigOwners clears modified aliases.
+func (cs *caminoState) writeMultisigAliasesByMember() error {
+	for aliasID, alias := range cs.modifiedMultisigOwners {
+		aliasBytes, err := cs.multisigOwnersDB.Get(aliasID[:])
+		switch err {
+		case nil:
+			oldAlias := &msigAlias{}
+			if _, err := blocks.GenesisCodec.Unmarshal(aliasBytes, oldAlias); err != nil {
+				return err
+			}
+			for _, member := range aliasMembers(oldAlias.Owners) {
+				if err := cs.multisigAliasesByMemberDB.Delete(memberPrefixedKey(member, aliasID)); err != nil {
+					return err
+				}
+			}
+		case database.ErrNotFound:
+		default:
+			return err
+		}
+
+		if alias == nil {
+			continue
+		}
+		for _, member := range aliasMembers(alias.Owners) {
+			if err := cs.multisigAliasesByMemberDB.Put(memberPrefixedKey(member, aliasID), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadMultisigAliasesByMember builds multisig aliases by member index from existing aliases,
+// if database was created before index was introduced.
+func (cs *caminoState) loadMultisigAliasesByMember() error {
+	if _, err := database.GetBool(cs.caminoDB, multisigAliasMemberIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	aliasIterator := cs.multisigOwnersDB.NewIterator()
+	defer aliasIterator.Release()
+	for aliasIterator.Next() {
+		aliasID, err := ids.ToShortID(aliasIterator.Key())
+		if err != nil {
+			return err
+		}
+		alias := &msigAlias{}
+		if _, err := blocks.GenesisCodec.Unmarshal(aliasIterator.Value(), alias); err != nil {
+			return err
+		}
+		for _, member := range aliasMembers(alias.Owners) {
+			if err := cs.multisigAliasesByMemberDB.Put(memberPrefixedKey(member, aliasID), nil); err != nil {
+				return err
+			}
+		}
+	}
+	if err := aliasIterator.Error(); err != nil {
+		return err
+	}
+
+	return database.PutBool(cs.caminoDB, multisigAliasMemberIndexKey, true)
+}
+
+// aliasMembers returns addresses of multisig alias [owners].
+func aliasMembers(owners verify.State) []ids.ShortID {
+	if outputOwners, _, err := secp256k1fx.OwnersWeights(owners); err == nil {
+		return outputOwners.Addrs
+	}
+	return nil
+}
+
+func memberPrefixedKey(member, aliasID ids.ShortID) []byte {
+	key := make([]byte, len(member)+len(aliasID))
+	copy(key, member[:])
+	copy(key[len(member):], aliasID[:])
+	return key
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetMultisigAliases(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newAlias := func(id byte) *multisig.Alias {
+		return &multisig.Alias{
+			ID:     ids.ShortID{id},
+			Memo:   []byte{id},
+			Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{id, id}}},
+		}
+	}
+	aliasIDs := func(chain Chain, start ids.ShortID, limit int) []ids.ShortID {
+		aliases, err := chain.GetMultisigAliases(start, limit)
+		require.NoError(err)
+		aliasIDs := make([]ids.ShortID, len(aliases))
+		for i, alias := range aliases {
+			aliasIDs[i] = alias.ID
+		}
+		return aliasIDs
+	}
+
+	s := newEmptyState(t)
+	for _, id := range []byte{5, 1, 3, 4} {
+		s.SetMultisigAlias(newAlias(id))
+	}
+	require.NoError(s.caminoState.Write(s))
+
+	// persisted aliases
+	require.Equal([]ids.ShortID{{1}, {3}, {4}, {5}}, aliasIDs(s, ids.ShortEmpty, math.MaxInt))
+	require.Equal([]ids.ShortID{{1}, {3}}, aliasIDs(s, ids.ShortEmpty, 2))
+	require.Equal([]ids.ShortID{{4}, {5}}, aliasIDs(s, ids.ShortID{3}, 2))
+	require.Equal([]ids.ShortID{{3}}, aliasIDs(s, ids.ShortID{2}, 1))
+	aliases, err := s.GetMultisigAliases(ids.ShortID{3}, 1)
+	require.NoError(err)
+	require.Len(aliases, 1)
+	require.Equal(newAlias(4).Owners, aliases[0].Owners)
+	require.EqualValues(newAlias(4).Memo, aliases[0].Memo)
+
+	// not written modifications
+	s.caminoState.(*caminoState).modifiedMultisigOwners[ids.ShortID{1}] = nil
+	s.SetMultisigAlias(newAlias(2))
+	require.Equal([]ids.ShortID{{2}, {3}, {4}, {5}}, aliasIDs(s, ids.ShortEmpty, math.MaxInt))
+	require.Equal([]ids.ShortID{{2}, {3}}, aliasIDs(s, ids.ShortEmpty, 2))
+	require.Equal([]ids.ShortID{{3}, {4}}, aliasIDs(s, ids.ShortID{2}, 2))
+
+	// diff on top of state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.(*diff).caminoDiff.modifiedMultisigOwners[ids.ShortID{2}] = nil
+	d.(*diff).caminoDiff.modifiedMultisigOwners[ids.ShortID{3}] = nil
+	d.SetMultisigAlias(newAlias(6))
+	require.Equal([]ids.ShortID{{4}, {5}, {6}}, aliasIDs(d, ids.ShortEmpty, math.MaxInt))
+	require.Equal([]ids.ShortID{{4}}, aliasIDs(d, ids.ShortEmpty, 1))
+	require.Equal([]ids.ShortID{{5}, {6}}, aliasIDs(d, ids.ShortID{4}, 2))
+	require.Empty(aliasIDs(d, ids.ShortID{6}, 2))
+}
+
+func TestGetMultisigAliasIDsByMember(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	member1 := ids.ShortID{1}
+	member2 := ids.ShortID{2}
+	newAlias := func(id byte, members ...ids.ShortID) *multisig.Alias {
+		return &multisig.Alias{
+			ID:     ids.ShortID{id},
+			Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: members},
+		}
+	}
+	aliasIDsByMember := func(chain Chain, member ids.ShortID) []ids.ShortID {
+		aliasIDs, err := chain.GetMultisigAliasIDsByMember(member)
+		require.NoError(err)
+		return aliasIDs
+	}
+
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	s.SetMultisigAlias(newAlias(13, member1))
+	s.SetMultisigAlias(newAlias(11, member1, member2))
+	s.SetMultisigAlias(newAlias(12, member2))
+	require.NoError(s.caminoState.Write(s))
+
+	// persisted aliases
+	require.Equal([]ids.ShortID{{11}, {13}}, aliasIDsByMember(s, member1))
+	require.Equal([]ids.ShortID{{11}, {12}}, aliasIDsByMember(s, member2))
+	require.Empty(aliasIDsByMember(s, ids.ShortID{3}))
+
+	// persisted alias owners change
+	s.SetMultisigAlias(newAlias(11, member2))
+	require.NoError(s.caminoState.Write(s))
+	require.Equal([]ids.ShortID{{13}}, aliasIDsByMember(s, member1))
+	require.Equal([]ids.ShortID{{11}, {12}}, aliasIDsByMember(s, member2))
+
+	// not written modifications
+	cs.modifiedMultisigOwners[ids.ShortID{12}] = nil
+	s.SetMultisigAlias(newAlias(10, member2))
+	require.Equal([]ids.ShortID{{10}, {11}}, aliasIDsByMember(s, member2))
+
+	// diff on top of state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.(*diff).caminoDiff.modifiedMultisigOwners[ids.ShortID{10}] = nil
+	d.SetMultisigAlias(newAlias(14, member1, member2))
+	require.Equal([]ids.ShortID{{13}, {14}}, aliasIDsByMember(d, member1))
+	require.Equal([]ids.ShortID{{11}, {14}}, aliasIDsByMember(d, member2))
+
+	// index is built for state without it
+	require.NoError(s.caminoState.Write(s))
+	require.NoError(cs.caminoDB.Delete(multisigAliasMemberIndexKey))
+	require.NoError(cs.multisigAliasesByMemberDB.Delete(memberPrefixedKey(member2, ids.ShortID{11})))
+	require.NoError(cs.loadMultisigAliasesByMember())
+	require.Equal([]ids.ShortID{{10}, {11}}, aliasIDsByMember(s, member2))
+}
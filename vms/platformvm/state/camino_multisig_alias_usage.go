@@ -0,0 +1,103 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+const multisigAliasUsageKeyLength = 20 + 8 + 32
+
+// MultisigAliasUsage is tx with [TxID], accepted in block with [Height],
+// in which multisig alias was used to prove owners.
+type MultisigAliasUsage struct {
+	Height uint64 `json:"height"`
+	TxID   ids.ID `json:"txID"`
+}
+
+func (cs *caminoState) AddMultisigAliasUsage(aliasID ids.ShortID, txID ids.ID) {
+	cs.addedMultisigAliasUsages[aliasID] = append(cs.addedMultisigAliasUsages[aliasID], txID)
+}
+
+// GetMultisigAliasUsages returns persisted last usages of multisig alias [aliasID]
+// in order of their heights.
+func (cs *caminoState) GetMultisigAliasUsages(aliasID ids.ShortID) ([]*MultisigAliasUsage, error) {
+	iterator := cs.multisigAliasUsagesDB.NewIteratorWithPrefix(aliasID[:])
+	defer iterator.Release()
+
+	usages := []*MultisigAliasUsage{}
+	for iterator.Next() {
+		key := iterator.Key()
+		if len(key) != multisigAliasUsageKeyLength {
+			return nil, fmt.Errorf("wrong multisig alias usage key length: %d", len(key))
+		}
+		txID, err := ids.ToID(key[len(aliasID)+8:])
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, &MultisigAliasUsage{
+			Height: binary.BigEndian.Uint64(key[len(aliasID):]),
+			TxID:   txID,
+		})
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+	return usages, nil
+}
+
+// writeMultisigAliasUsages records added usages of multisig aliases at block [height]
+// and removes usages, that are older than last multisigAliasUsageRetention ones.
+// If usage retention is zero, added usages are discarded.
+func (cs *caminoState) writeMultisigAliasUsages(height uint64) error {
+	for aliasID, txIDs := range cs.addedMultisigAliasUsages {
+		delete(cs.addedMultisigAliasUsages, aliasID)
+		if cs.multisigAliasUsageRetention <= 0 {
+			continue
+		}
+		for _, txID := range txIDs {
+			if err := cs.multisigAliasUsagesDB.Put(multisigAliasUsageKey(aliasID, height, txID), nil); err != nil {
+				return err
+			}
+		}
+		if err := cs.pruneMultisigAliasUsages(aliasID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneMultisigAliasUsages removes the oldest usages of alias [aliasID],
+// so only multisigAliasUsageRetention last usages are left.
+func (cs *caminoState) pruneMultisigAliasUsages(aliasID ids.ShortID) error {
+	keys := [][]byte{}
+	iterator := cs.multisigAliasUsagesDB.NewIteratorWithPrefix(aliasID[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	if err := iterator.Error(); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(keys)-cs.multisigAliasUsageRetention; i++ {
+		if err := cs.multisigAliasUsagesDB.Delete(keys[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// multisigAliasUsageKey returns aliasID + big-endian height + txID,
+// so usages of the same alias are sorted by their heights.
+func multisigAliasUsageKey(aliasID ids.ShortID, height uint64, txID ids.ID) []byte {
+	key := make([]byte, multisigAliasUsageKeyLength)
+	copy(key, aliasID[:])
+	binary.BigEndian.PutUint64(key[len(aliasID):], height)
+	copy(key[len(aliasID)+8:], txID[:])
+	return key
+}
@@ -0,0 +1,68 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestMultisigAliasUsages(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	cs.multisigAliasUsageRetention = 2
+
+	aliasID1 := ids.ShortID{1}
+	aliasID2 := ids.ShortID{2}
+
+	// usages are recorded by diff and applied to state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.AddMultisigAliasUsage(aliasID1, ids.ID{11})
+	d.AddMultisigAliasUsage(aliasID2, ids.ID{21})
+	d.ApplyCaminoState(s)
+	require.NoError(cs.writeMultisigAliasUsages(5))
+	require.Empty(cs.addedMultisigAliasUsages)
+
+	usages, err := s.GetMultisigAliasUsages(aliasID1)
+	require.NoError(err)
+	require.Equal([]*MultisigAliasUsage{{Height: 5, TxID: ids.ID{11}}}, usages)
+
+	// only last usages are retained
+	s.AddMultisigAliasUsage(aliasID1, ids.ID{12})
+	require.NoError(cs.writeMultisigAliasUsages(6))
+	s.AddMultisigAliasUsage(aliasID1, ids.ID{13})
+	require.NoError(cs.writeMultisigAliasUsages(7))
+
+	usages, err = s.GetMultisigAliasUsages(aliasID1)
+	require.NoError(err)
+	require.Equal([]*MultisigAliasUsage{
+		{Height: 6, TxID: ids.ID{12}},
+		{Height: 7, TxID: ids.ID{13}},
+	}, usages)
+	usages, err = s.GetMultisigAliasUsages(aliasID2)
+	require.NoError(err)
+	require.Equal([]*MultisigAliasUsage{{Height: 5, TxID: ids.ID{21}}}, usages)
+
+	// usages aren't recorded without retention
+	cs.multisigAliasUsageRetention = 0
+	s.AddMultisigAliasUsage(aliasID2, ids.ID{22})
+	require.NoError(cs.writeMultisigAliasUsages(8))
+	require.Empty(cs.addedMultisigAliasUsages)
+	usages, err = s.GetMultisigAliasUsages(aliasID2)
+	require.NoError(err)
+	require.Equal([]*MultisigAliasUsage{{Height: 5, TxID: ids.ID{21}}}, usages)
+}
@@ -0,0 +1,161 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// must be the same as prefix used by avax.utxoState for utxos
+var avaxUTXOPrefix = []byte("utxo")
+
+// OwnerUTXOIDs returns the slice of IDs of utxos owned by [ownerID], starting after [previous].
+// If [previous] is ids.Empty, starts at beginning. Returns at most [limit] IDs.
+func (cs *caminoState) OwnerUTXOIDs(ownerID, previous ids.ID, limit int) ([]ids.ID, error) {
+	start := ownerID[:]
+	if previous != ids.Empty {
+		start = ownerPrefixedKey(ownerID, previous)
+	}
+
+	iterator := cs.utxoIDsByOwnerDB.NewIteratorWithStartAndPrefix(start, ownerID[:])
+	defer iterator.Release()
+
+	utxoIDs := []ids.ID{}
+	for len(utxoIDs) < limit && iterator.Next() {
+		utxoID, err := ids.ToID(iterator.Key()[len(ownerID):])
+		if err != nil {
+			return nil, err
+		}
+		if utxoID == previous {
+			continue
+		}
+		utxoIDs = append(utxoIDs, utxoID)
+	}
+	return utxoIDs, iterator.Error()
+}
+
+// OwnerUTXOsCount returns number of utxos owned by [ownerID].
+func (cs *caminoState) OwnerUTXOsCount(ownerID ids.ID) (uint64, error) {
+	count, err := database.GetUInt64(cs.utxoCountByOwnerDB, ownerID[:])
+	if err == database.ErrNotFound {
+		return 0, nil
+	}
+	return count, err
+}
+
+// indexUTXO adds [utxo] to owner index, if its out has owner.
+func (cs *caminoState) indexUTXO(utxo *avax.UTXO) error {
+	ownerID, ok, err := utxoOwnerID(utxo)
+	if err != nil || !ok {
+		return err
+	}
+	key := ownerPrefixedKey(ownerID, utxo.InputID())
+	if has, err := cs.utxoIDsByOwnerDB.Has(key); err != nil || has {
+		return err
+	}
+	if err := cs.utxoIDsByOwnerDB.Put(key, nil); err != nil {
+		return err
+	}
+	count, err := cs.OwnerUTXOsCount(ownerID)
+	if err != nil {
+		return err
+	}
+	return database.PutUInt64(cs.utxoCountByOwnerDB, ownerID[:], count+1)
+}
+
+// unindexUTXO removes [utxo] from owner index, if its out has owner.
+func (cs *caminoState) unindexUTXO(utxo *avax.UTXO) error {
+	ownerID, ok, err := utxoOwnerID(utxo)
+	if err != nil || !ok {
+		return err
+	}
+	key := ownerPrefixedKey(ownerID, utxo.InputID())
+	if has, err := cs.utxoIDsByOwnerDB.Has(key); err != nil || !has {
+		return err
+	}
+	if err := cs.utxoIDsByOwnerDB.Delete(key); err != nil {
+		return err
+	}
+	count, err := cs.OwnerUTXOsCount(ownerID)
+	if err != nil {
+		return err
+	}
+	if count <= 1 {
+		return cs.utxoCountByOwnerDB.Delete(ownerID[:])
+	}
+	return database.PutUInt64(cs.utxoCountByOwnerDB, ownerID[:], count-1)
+}
+
+// loadUTXOOwnerIndex builds owner index from existing utxos,
+// if database was created before index was introduced.
+func (cs *caminoState) loadUTXOOwnerIndex(s *state) error {
+	if _, err := database.GetBool(cs.caminoDB, utxoOwnerIndexKey); err != database.ErrNotFound {
+		return err
+	}
+
+	utxoIterator := prefixdb.New(avaxUTXOPrefix, s.utxoDB).NewIterator()
+	defer utxoIterator.Release()
+
+	counts := map[ids.ID]uint64{}
+	for utxoIterator.Next() {
+		utxo := &avax.UTXO{}
+		if _, err := txs.GenesisCodec.Unmarshal(utxoIterator.Value(), utxo); err != nil {
+			return fmt.Errorf("failed to parse utxo: %w", err)
+		}
+		ownerID, ok, err := utxoOwnerID(utxo)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := cs.utxoIDsByOwnerDB.Put(ownerPrefixedKey(ownerID, utxo.InputID()), nil); err != nil {
+			return err
+		}
+		counts[ownerID]++
+	}
+	if err := utxoIterator.Error(); err != nil {
+		return err
+	}
+
+	for ownerID, count := range counts {
+		if err := database.PutUInt64(cs.utxoCountByOwnerDB, ownerID[:], count); err != nil {
+			return err
+		}
+	}
+	return database.PutBool(cs.caminoDB, utxoOwnerIndexKey, true)
+}
+
+// utxoOwnerID returns owner id of [utxo] out and true, or false, if out isn't owned.
+// Locked outs are indexed by owner of inner out.
+func utxoOwnerID(utxo *avax.UTXO) (ids.ID, bool, error) {
+	var out interface{} = utxo.Out
+	if _, lockedOut, ok := locked.OutIDs(utxo.Out); ok {
+		out = lockedOut
+	}
+	owned, ok := out.(fx.Owned)
+	if !ok {
+		return ids.Empty, false, nil
+	}
+	ownerID, err := txs.GetOwnerID(owned.Owners())
+	if err != nil {
+		return ids.Empty, false, err
+	}
+	return ownerID, true, nil
+}
+
+// ownerPrefixedKey returns [ownerID] concatenated with [id]
+func ownerPrefixedKey(ownerID, id ids.ID) []byte {
+	key := make([]byte, 0, len(ownerID)+len(id))
+	key = append(key, ownerID[:]...)
+	return append(key, id[:]...)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestOwnerUTXOsIndex(t *testing.T) {
+	require := require.New(t)
+	s := newEmptyState(t)
+
+	owner1 := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	owner2 := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{2}}}
+	owner1ID, err := txs.GetOwnerID(&owner1)
+	require.NoError(err)
+	owner2ID, err := txs.GetOwnerID(&owner2)
+	require.NoError(err)
+
+	assetID := ids.ID{'a'}
+	owner1UTXOs := []*avax.UTXO{
+		generateTestUTXO(ids.ID{1}, assetID, 1, owner1, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{2}, assetID, 1, owner1, ids.ID{100}, ids.Empty),
+		generateTestUTXO(ids.ID{3}, assetID, 1, owner1, ids.Empty, ids.ID{101}),
+	}
+	owner2UTXO := generateTestUTXO(ids.ID{4}, assetID, 1, owner2, ids.Empty, ids.Empty)
+
+	owner1UTXOIDs := make([]ids.ID, len(owner1UTXOs))
+	for i, utxo := range owner1UTXOs {
+		s.AddUTXO(utxo)
+		owner1UTXOIDs[i] = utxo.InputID()
+	}
+	s.AddUTXO(owner2UTXO)
+	utils.Sort(owner1UTXOIDs)
+	require.NoError(s.writeUTXOs())
+
+	utxoIDs, err := s.OwnerUTXOIDs(owner1ID, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal(owner1UTXOIDs, utxoIDs)
+	count, err := s.OwnerUTXOsCount(owner1ID)
+	require.NoError(err)
+	require.Equal(uint64(3), count)
+
+	// paging
+	utxoIDs, err = s.OwnerUTXOIDs(owner1ID, ids.Empty, 2)
+	require.NoError(err)
+	require.Equal(owner1UTXOIDs[:2], utxoIDs)
+	utxoIDs, err = s.OwnerUTXOIDs(owner1ID, utxoIDs[1], 2)
+	require.NoError(err)
+	require.Equal(owner1UTXOIDs[2:], utxoIDs)
+
+	utxoIDs, err = s.OwnerUTXOIDs(owner2ID, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal([]ids.ID{owner2UTXO.InputID()}, utxoIDs)
+
+	// deletion
+	s.DeleteUTXO(owner1UTXOIDs[0])
+	s.DeleteUTXO(owner2UTXO.InputID())
+	require.NoError(s.writeUTXOs())
+
+	utxoIDs, err = s.OwnerUTXOIDs(owner1ID, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal(owner1UTXOIDs[1:], utxoIDs)
+	count, err = s.OwnerUTXOsCount(owner1ID)
+	require.NoError(err)
+	require.Equal(uint64(2), count)
+
+	utxoIDs, err = s.OwnerUTXOIDs(owner2ID, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Empty(utxoIDs)
+	count, err = s.OwnerUTXOsCount(owner2ID)
+	require.NoError(err)
+	require.Zero(count)
+
+	// rebuilding index for database without it
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	for _, utxoID := range owner1UTXOIDs[1:] {
+		require.NoError(cs.utxoIDsByOwnerDB.Delete(ownerPrefixedKey(owner1ID, utxoID)))
+	}
+	require.NoError(cs.utxoCountByOwnerDB.Delete(owner1ID[:]))
+	require.NoError(cs.loadUTXOOwnerIndex(s))
+
+	utxoIDs, err = s.OwnerUTXOIDs(owner1ID, ids.Empty, math.MaxInt)
+	require.NoError(err)
+	require.Equal(owner1UTXOIDs[1:], utxoIDs)
+	count, err = s.OwnerUTXOsCount(owner1ID)
+	require.NoError(err)
+	require.Equal(uint64(2), count)
+	indexed, err := database.GetBool(cs.caminoDB, utxoOwnerIndexKey)
+	require.NoError(err)
+	require.True(indexed)
+}
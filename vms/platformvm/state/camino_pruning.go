@@ -0,0 +1,99 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+// GetArchivedDeposit returns deposit, that was removed from active state while pruning
+// or archive of removed deposits was enabled. Deposit is returned as it was at removal time.
+func (cs *caminoState) GetArchivedDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
+	depositBytes, err := cs.archivedDepositsDB.Get(depositTxID[:])
+	if err != nil {
+		return nil, err
+	}
+	d := &deposit.Deposit{}
+	if _, err := blocks.GenesisCodec.Unmarshal(depositBytes, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// getArchivedDepositOffer returns deposit offer, that was moved from active state into archive.
+func (cs *caminoState) getArchivedDepositOffer(offerID ids.ID) (*deposit.Offer, error) {
+	if !cs.archivedDepositOfferIDs.Contains(offerID) {
+		return nil, database.ErrNotFound
+	}
+	offerBytes, err := cs.archivedDepositOffersDB.Get(offerID[:])
+	if err != nil {
+		return nil, err
+	}
+	offer := &deposit.Offer{ID: offerID}
+	if _, err := blocks.GenesisCodec.Unmarshal(offerBytes, offer); err != nil {
+		return nil, err
+	}
+	if err := cs.loadDepositOfferExtension(offer); err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// archiveDeposit puts removed deposit into archive, if pruning or archive of removed deposits is enabled.
+func (cs *caminoState) archiveDeposit(depositTxID ids.ID, d *deposit.Deposit) error {
+	if !cs.archiveDepositsEnabled {
+		return nil
+	}
+	depositBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, d)
+	if err != nil {
+		return fmt.Errorf("failed to serialize deposit: %w", err)
+	}
+	return cs.archivedDepositsDB.Put(depositTxID[:], depositBytes)
+}
+
+// loadArchivedDepositOfferIDs loads ids of archived deposit offers.
+func (cs *caminoState) loadArchivedDepositOfferIDs() error {
+	archiveIt := cs.archivedDepositOffersDB.NewIterator()
+	defer archiveIt.Release()
+	for archiveIt.Next() {
+		offerID, err := ids.ToID(archiveIt.Key())
+		if err != nil {
+			return err
+		}
+		cs.archivedDepositOfferIDs.Add(offerID)
+	}
+	return archiveIt.Error()
+}
+
+// writeArchivedDepositOffers moves locked deposit offers, that ended before [chainTime],
+// into archive, if pruning is enabled. Such offers can't be used for new deposits anymore,
+// but could be still referenced by existing deposits, so they are still available with GetDepositOffer.
+func (cs *caminoState) writeArchivedDepositOffers(chainTime uint64) error {
+	if !cs.pruningEnabled {
+		return nil
+	}
+	for offerID, offer := range cs.depositOffers {
+		if offer.Flags&deposit.OfferFlagLocked == 0 || offer.End >= chainTime {
+			continue
+		}
+		offerBytes, err := cs.depositOffersDB.Get(offerID[:])
+		if err != nil {
+			return err
+		}
+		if err := cs.archivedDepositOffersDB.Put(offerID[:], offerBytes); err != nil {
+			return err
+		}
+		if err := cs.depositOffersDB.Delete(offerID[:]); err != nil {
+			return err
+		}
+		delete(cs.depositOffers, offerID)
+		cs.archivedDepositOfferIDs.Add(offerID)
+	}
+	return nil
+}
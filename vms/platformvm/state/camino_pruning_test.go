@@ -0,0 +1,86 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestPruning(t *testing.T) {
+	tests := map[string]struct {
+		pruningEnabled         bool
+		archiveDepositsEnabled bool
+	}{
+		"Pruning disabled":                  {},
+		"Pruning enabled":                   {pruningEnabled: true, archiveDepositsEnabled: true},
+		"Pruning disabled, archive enabled": {archiveDepositsEnabled: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			s := newEmptyState(t)
+			cs, ok := s.caminoState.(*caminoState)
+			require.True(ok)
+			cs.pruningEnabled = tt.pruningEnabled
+			cs.archiveDepositsEnabled = tt.archiveDepositsEnabled
+
+			chainTime := uint64(100)
+			s.SetTimestamp(time.Unix(int64(chainTime), 0))
+			lockedEndedOffer := &deposit.Offer{ID: ids.ID{1}, End: chainTime - 1, Flags: deposit.OfferFlagLocked, Memo: []byte{1}}
+			lockedActiveOffer := &deposit.Offer{ID: ids.ID{2}, End: chainTime, Flags: deposit.OfferFlagLocked, Memo: []byte{2}}
+			endedOffer := &deposit.Offer{ID: ids.ID{3}, End: chainTime - 1, Memo: []byte{3}}
+			for _, offer := range []*deposit.Offer{lockedEndedOffer, lockedActiveOffer, endedOffer} {
+				s.SetDepositOffer(offer)
+			}
+
+			depositTx, err := txs.NewSigned(&txs.DepositTx{
+				DepositOfferID: lockedEndedOffer.ID,
+				RewardsOwner:   &secp256k1fx.OutputOwners{},
+			}, txs.Codec, nil)
+			require.NoError(err)
+			removedDeposit := &deposit.Deposit{DepositOfferID: lockedEndedOffer.ID, Amount: 1, Duration: 1}
+			s.AddTx(depositTx, status.Committed)
+			s.AddDeposit(depositTx.ID(), removedDeposit)
+			require.NoError(s.caminoState.Write(s))
+			s.RemoveDeposit(depositTx.ID(), removedDeposit)
+			require.NoError(s.caminoState.Write(s))
+
+			// deposit offers
+			offers, err := s.GetAllDepositOffers()
+			require.NoError(err)
+			if tt.pruningEnabled {
+				require.ElementsMatch([]*deposit.Offer{lockedActiveOffer, endedOffer}, offers)
+				has, err := cs.depositOffersDB.Has(lockedEndedOffer.ID[:])
+				require.NoError(err)
+				require.False(has)
+			} else {
+				require.ElementsMatch([]*deposit.Offer{lockedEndedOffer, lockedActiveOffer, endedOffer}, offers)
+			}
+			offer, err := s.GetDepositOffer(lockedEndedOffer.ID)
+			require.NoError(err)
+			require.Equal(lockedEndedOffer, offer)
+
+			// deposits
+			_, err = s.GetDeposit(depositTx.ID())
+			require.ErrorIs(err, database.ErrNotFound)
+			archivedDeposit, err := s.GetArchivedDeposit(depositTx.ID())
+			if tt.archiveDepositsEnabled {
+				require.NoError(err)
+				require.Equal(removedDeposit, archivedDeposit)
+			} else {
+				require.ErrorIs(err, database.ErrNotFound)
+			}
+		})
+	}
+}
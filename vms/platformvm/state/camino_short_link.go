@@ -10,7 +10,11 @@ import (
 
 type ShortLinkKey [12]byte
 
-var ShortLinkKeyRegisterNode = ShortLinkKey{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+var (
+	ShortLinkKeyRegisterNode = ShortLinkKey{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	// ShortLinkKeyMultisigAliasSuccessor links retired multisig alias to its successor alias
+	ShortLinkKeyMultisigAliasSuccessor = ShortLinkKey{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+)
 
 func (cs *caminoState) writeShortLinks() error {
 	for nodeID, addr := range cs.modifiedShortLinks {
@@ -44,7 +48,7 @@ func (cs *caminoState) GetShortIDLink(id ids.ShortID, key ShortLinkKey) (ids.Sho
 	}
 
 	if addr, ok := cs.shortLinksCache.Get(linkKey); ok {
-		return addr.(ids.ShortID), nil
+		return addr, nil
 	}
 
 	addrBytes, err := cs.shortLinksDB.Get(linkKey[:])
@@ -74,3 +78,45 @@ func fromShortLinkKey(fullKey ids.ID) (ids.ShortID, ShortLinkKey) {
 	copy(key[:], fullKey[:12])
 	return id, key
 }
+
+// GetShortIDLinks returns all links with [key] as map from id to its link.
+func (cs *caminoState) GetShortIDLinks(key ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	links := map[ids.ShortID]ids.ShortID{}
+
+	iterator := cs.shortLinksDB.NewIteratorWithPrefix(key[:])
+	defer iterator.Release()
+	for iterator.Next() {
+		fullKey, err := ids.ToID(iterator.Key())
+		if err != nil {
+			return nil, err
+		}
+		link, err := ids.ToShortID(iterator.Value())
+		if err != nil {
+			return nil, err
+		}
+		id, _ := fromShortLinkKey(fullKey)
+		links[id] = link
+	}
+	if err := iterator.Error(); err != nil {
+		return nil, err
+	}
+
+	overrideShortIDLinks(links, cs.modifiedShortLinks, key)
+	return links, nil
+}
+
+// overrideShortIDLinks overrides [links] with [modified] links, that have [key].
+// Nil modified links are treated as removed.
+func overrideShortIDLinks(links map[ids.ShortID]ids.ShortID, modified map[ids.ID]*ids.ShortID, key ShortLinkKey) {
+	for fullKey, link := range modified {
+		id, linkKey := fromShortLinkKey(fullKey)
+		if linkKey != key {
+			continue
+		}
+		if link == nil {
+			delete(links, id)
+		} else {
+			links[id] = *link
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestGetShortIDLinks(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	otherKey := ShortLinkKey{1}
+	id1, id2, id3 := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}
+	link1, link2, link3 := ids.ShortID{11}, ids.ShortID{12}, ids.ShortID{13}
+
+	s := newEmptyState(t)
+	s.SetShortIDLink(id1, ShortLinkKeyRegisterNode, &link1)
+	s.SetShortIDLink(id2, ShortLinkKeyRegisterNode, &link2)
+	s.SetShortIDLink(id3, otherKey, &link3)
+	require.NoError(s.caminoState.Write(s))
+
+	// persisted links
+	links, err := s.GetShortIDLinks(ShortLinkKeyRegisterNode)
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]ids.ShortID{id1: link1, id2: link2}, links)
+	links, err = s.GetShortIDLinks(otherKey)
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]ids.ShortID{id3: link3}, links)
+
+	// not written modifications
+	s.SetShortIDLink(id1, ShortLinkKeyRegisterNode, nil)
+	s.SetShortIDLink(id3, ShortLinkKeyRegisterNode, &link1)
+	links, err = s.GetShortIDLinks(ShortLinkKeyRegisterNode)
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]ids.ShortID{id2: link2, id3: link1}, links)
+
+	// diff on top of state
+	parentStateID := ids.GenerateTestID()
+	stateVersions := NewMockVersions(ctrl)
+	stateVersions.EXPECT().GetState(parentStateID).Return(s, true).AnyTimes()
+	d, err := NewCaminoDiff(parentStateID, stateVersions)
+	require.NoError(err)
+	d.SetShortIDLink(id2, ShortLinkKeyRegisterNode, &link3)
+	d.SetShortIDLink(id3, ShortLinkKeyRegisterNode, nil)
+	d.SetShortIDLink(id3, otherKey, nil)
+	links, err = d.GetShortIDLinks(ShortLinkKeyRegisterNode)
+	require.NoError(err)
+	require.Equal(map[ids.ShortID]ids.ShortID{id2: link3}, links)
+	links, err = d.GetShortIDLinks(otherKey)
+	require.NoError(err)
+	require.Empty(links)
+}
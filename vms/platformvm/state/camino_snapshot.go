@@ -0,0 +1,136 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+)
+
+const caminoSnapshotVersion uint16 = 0
+
+var (
+	// buckets of camino state, that are exported into snapshot
+	caminoSnapshotBuckets = [][]byte{
+		caminoPrefix,
+		addressStatePrefix,
+		depositOffersPrefix,
+		depositOfferExtensionsPrefix,
+		archivedDepositOffersPrefix,
+		depositsPrefix,
+		depositIDsByEndtimePrefix,
+		depositIDsByRewardOwnerPrefix,
+		depositIDsByOfferPrefix,
+		depositRewardOwnersPrefix,
+		depositClaimsPrefix,
+		depositStatsPrefix,
+		archivedDepositsPrefix,
+		multisigOwnersPrefix,
+		multisigAliasesByMemberPrefix,
+		multisigAliasHistoryPrefix,
+		shortLinksPrefix,
+		claimablesPrefix,
+		claimableRewardAssetsPrefix,
+	}
+
+	errWrongSnapshotVersion = errors.New("unsupported camino snapshot version")
+	errWrongSnapshotBucket  = errors.New("unexpected camino snapshot bucket")
+)
+
+type caminoSnapshot struct {
+	Version uint16                 `serialize:"true"`
+	Buckets []caminoSnapshotBucket `serialize:"true"`
+}
+
+type caminoSnapshotBucket struct {
+	Prefix  []byte                `serialize:"true"`
+	Entries []caminoSnapshotEntry `serialize:"true"`
+}
+
+type caminoSnapshotEntry struct {
+	Key   []byte `serialize:"true"`
+	Value []byte `serialize:"true"`
+}
+
+// ExportCaminoSnapshot writes persisted camino state (deposits, deposit offers, claimables,
+// address states, multisig aliases and short links) from platform chain [db] into [w].
+// [db] must be the same database, that was used to create platform chain state.
+func ExportCaminoSnapshot(db database.Database, w io.Writer) error {
+	snapshot := caminoSnapshot{
+		Version: caminoSnapshotVersion,
+		Buckets: make([]caminoSnapshotBucket, len(caminoSnapshotBuckets)),
+	}
+
+	for i, prefix := range caminoSnapshotBuckets {
+		snapshot.Buckets[i].Prefix = prefix
+		it := prefixdb.New(prefix, db).NewIterator()
+		for it.Next() {
+			snapshot.Buckets[i].Entries = append(snapshot.Buckets[i].Entries, caminoSnapshotEntry{
+				Key:   it.Key(),
+				Value: it.Value(),
+			})
+		}
+		err := it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+	}
+
+	snapshotBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to serialize camino snapshot: %w", err)
+	}
+	_, err = w.Write(snapshotBytes)
+	return err
+}
+
+// ImportCaminoSnapshot replaces camino state of platform chain [db] with snapshot read from [r].
+// Snapshot must be imported before platform chain state is created on top of [db].
+func ImportCaminoSnapshot(db database.Database, r io.Reader) error {
+	snapshotBytes, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	snapshot := caminoSnapshot{}
+	if _, err := blocks.GenesisCodec.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		return fmt.Errorf("failed to parse camino snapshot: %w", err)
+	}
+	if snapshot.Version != caminoSnapshotVersion {
+		return fmt.Errorf("%w: %d", errWrongSnapshotVersion, snapshot.Version)
+	}
+
+	vdb := versiondb.New(db)
+	for _, bucket := range snapshot.Buckets {
+		if !isCaminoSnapshotBucket(bucket.Prefix) {
+			return fmt.Errorf("%w: %s", errWrongSnapshotBucket, bucket.Prefix)
+		}
+		bucketDB := prefixdb.New(bucket.Prefix, vdb)
+		if err := database.Clear(bucketDB, bucketDB); err != nil {
+			return err
+		}
+		for _, entry := range bucket.Entries {
+			if err := bucketDB.Put(entry.Key, entry.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return vdb.Commit()
+}
+
+func isCaminoSnapshotBucket(prefix []byte) bool {
+	for _, bucketPrefix := range caminoSnapshotBuckets {
+		if string(bucketPrefix) == string(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestCaminoSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	// source state
+	s := newEmptyState(t)
+	cs, ok := s.caminoState.(*caminoState)
+	require.True(ok)
+	offer := &deposit.Offer{ID: ids.ID{1}, End: 10, Memo: []byte{1}}
+	depositTxID := ids.ID{2}
+	deposit1 := &deposit.Deposit{DepositOfferID: offer.ID, Amount: 1, Duration: 1}
+	address := ids.ShortID{3}
+	alias := &multisig.Alias{
+		ID:     ids.ShortID{4},
+		Memo:   []byte{4},
+		Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{address}},
+	}
+	claimableOwnerID := ids.ID{5}
+	claimable := &Claimable{Owner: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{address}}, ValidatorReward: 5}
+
+	s.SetDepositOffer(offer)
+	cs.modifiedDeposits[depositTxID] = &depositDiff{Deposit: deposit1, added: true}
+	s.SetAddressStates(address, 1)
+	s.SetMultisigAlias(alias)
+	s.SetClaimable(claimableOwnerID, claimable)
+	require.NoError(cs.writeDepositOffers())
+	require.NoError(cs.writeDeposits())
+	require.NoError(cs.writeAddressStates())
+	require.NoError(cs.writeMultisigOwners())
+	require.NoError(cs.writeClaimableAndValidatorRewards())
+
+	snapshot := &bytes.Buffer{}
+	require.NoError(ExportCaminoSnapshot(s.baseDB, snapshot))
+	snapshotBytes := snapshot.Bytes()
+
+	// importing into db with some other camino state
+	db := memdb.New()
+	require.NoError(prefixdb.New(depositsPrefix, db).Put([]byte{1}, []byte{1}))
+	require.NoError(ImportCaminoSnapshot(db, bytes.NewReader(snapshotBytes)))
+
+	importedSnapshot := &bytes.Buffer{}
+	require.NoError(ExportCaminoSnapshot(db, importedSnapshot))
+	require.Equal(snapshotBytes, importedSnapshot.Bytes())
+
+	importedState, err := newCaminoState(db, prefixdb.New(validatorsPrefix, db), prometheus.NewRegistry(), config.CaminoConfig{})
+	require.NoError(err)
+	require.NoError(importedState.loadDepositOffers())
+
+	importedOffer, err := importedState.GetDepositOffer(offer.ID)
+	require.NoError(err)
+	require.Equal(offer, importedOffer)
+	importedDeposit, err := importedState.GetDeposit(depositTxID)
+	require.NoError(err)
+	require.Equal(deposit1, importedDeposit)
+	importedStates, err := importedState.GetAddressStates(address)
+	require.NoError(err)
+	require.Equal(as.AddressState(1), importedStates)
+	importedAlias, err := importedState.GetMultisigAlias(alias.ID)
+	require.NoError(err)
+	require.Equal(alias.Owners, importedAlias.Owners)
+	importedClaimable, err := importedState.GetClaimable(claimableOwnerID)
+	require.NoError(err)
+	require.Equal(claimable, importedClaimable)
+
+	// wrong version
+	wrongVersionBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, caminoSnapshot{Version: caminoSnapshotVersion + 1})
+	require.NoError(err)
+	require.ErrorIs(ImportCaminoSnapshot(memdb.New(), bytes.NewReader(wrongVersionBytes)), errWrongSnapshotVersion)
+
+	// unknown bucket
+	wrongBucketBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, caminoSnapshot{
+		Buckets: []caminoSnapshotBucket{{Prefix: []byte("utxo")}},
+	})
+	require.NoError(err)
+	require.ErrorIs(ImportCaminoSnapshot(memdb.New(), bytes.NewReader(wrongBucketBytes)), errWrongSnapshotBucket)
+}
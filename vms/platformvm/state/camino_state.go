@@ -4,13 +4,15 @@
 package state
 
 import (
-	"math"
+	"sort"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
@@ -18,28 +20,64 @@ import (
 
 func (s *state) LockedUTXOs(txIDs set.Set[ids.ID], addresses set.Set[ids.ShortID], lockState locked.State) ([]*avax.UTXO, error) {
 	retUtxos := []*avax.UTXO{}
-	for address := range addresses {
-		utxoIDs, err := s.UTXOIDs(address.Bytes(), ids.ID{}, math.MaxInt)
+	visitedUTXOIDs := set.Set[ids.ID]{}
+	for txID := range txIDs {
+		utxoIDs, err := s.caminoState.lockedUTXOIDs(txID)
 		if err != nil {
 			return nil, err
 		}
 		for _, utxoID := range utxoIDs {
-			utxo, err := s.GetUTXO(utxoID)
-			if err != nil {
-				return nil, err
+			if visitedUTXOIDs.Contains(utxoID) {
+				continue
 			}
-			if utxo == nil {
+			visitedUTXOIDs.Add(utxoID)
+
+			utxo, err := s.GetUTXO(utxoID)
+			if err == database.ErrNotFound {
 				continue
+			} else if err != nil {
+				return nil, err
 			}
-			if lockedOut, ok := utxo.Out.(*locked.Out); ok &&
-				lockedOut.IDs.Match(lockState, txIDs) {
+			if lockIDs, _, ok := locked.OutIDs(utxo.Out); ok &&
+				lockIDs.Match(lockState, txIDs) && isOwnedByAny(utxo, addresses) {
 				retUtxos = append(retUtxos, utxo)
 			}
 		}
 	}
+	sortUTXOs(retUtxos)
 	return retUtxos, nil
 }
 
+// isOwnedByAny returns true, if one of [addresses] is among [utxo] out addresses.
+func isOwnedByAny(utxo *avax.UTXO, addresses set.Set[ids.ShortID]) bool {
+	addressable, ok := utxo.Out.(avax.Addressable)
+	if !ok {
+		return false
+	}
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err == nil && addresses.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortUTXOs sorts [utxos] by their ids, so utxos order doesn't depend on map iteration.
+func sortUTXOs(utxos []*avax.UTXO) {
+	sort.Slice(utxos, func(i, j int) bool {
+		return utxos[i].UTXOID.Less(&utxos[j].UTXOID)
+	})
+}
+
+func (s *state) GetDepositIDsByOffer(offerID ids.ID) ([]ids.ID, error) {
+	return s.caminoState.GetDepositIDsByOffer(offerID)
+}
+
+func (s *state) GetDepositIDsByRewardOwner(ownerID ids.ID) ([]ids.ID, error) {
+	return s.caminoState.depositIDsByRewardOwner(s, ownerID)
+}
+
 func (s *state) Config() (*config.Config, error) {
 	return s.cfg, nil
 }
@@ -48,14 +86,46 @@ func (s *state) CaminoConfig() (*CaminoConfig, error) {
 	return s.caminoState.CaminoConfig(), nil
 }
 
-func (s *state) SetAddressStates(address ids.ShortID, states uint64) {
+func (s *state) OwnerUTXOIDs(ownerID, previous ids.ID, limit int) ([]ids.ID, error) {
+	return s.caminoState.OwnerUTXOIDs(ownerID, previous, limit)
+}
+
+func (s *state) OwnerUTXOsCount(ownerID ids.ID) (uint64, error) {
+	return s.caminoState.OwnerUTXOsCount(ownerID)
+}
+
+func (s *state) GetDepositStats() (*DepositStats, error) {
+	return s.caminoState.GetDepositStats()
+}
+
+func (s *state) GetArchivedDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
+	return s.caminoState.GetArchivedDeposit(depositTxID)
+}
+
+func (s *state) GetMultisigAliasHistory(aliasID ids.ShortID) ([]*MultisigAliasVersion, error) {
+	return s.caminoState.GetMultisigAliasHistory(aliasID)
+}
+
+func (s *state) GetMultisigAliasUsages(aliasID ids.ShortID) ([]*MultisigAliasUsage, error) {
+	return s.caminoState.GetMultisigAliasUsages(aliasID)
+}
+
+func (s *state) GetCaminoHistoricalView(height uint64) (CaminoHistoricalView, error) {
+	return s.caminoState.GetCaminoHistoricalView(height)
+}
+
+func (s *state) SetAddressStates(address ids.ShortID, states as.AddressState) {
 	s.caminoState.SetAddressStates(address, states)
 }
 
-func (s *state) GetAddressStates(address ids.ShortID) (uint64, error) {
+func (s *state) GetAddressStates(address ids.ShortID) (as.AddressState, error) {
 	return s.caminoState.GetAddressStates(address)
 }
 
+func (s *state) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	return s.caminoState.GetAddressStatesIterator()
+}
+
 func (s *state) SetDepositOffer(offer *deposit.Offer) {
 	s.caminoState.SetDepositOffer(offer)
 }
@@ -84,6 +154,14 @@ func (s *state) GetDeposit(depositTxID ids.ID) (*deposit.Deposit, error) {
 	return s.caminoState.GetDeposit(depositTxID)
 }
 
+func (s *state) AddDepositClaim(depositTxID ids.ID, claim *deposit.Claim) {
+	s.caminoState.AddDepositClaim(depositTxID, claim)
+}
+
+func (s *state) GetDepositClaims(depositTxID ids.ID) ([]*deposit.Claim, error) {
+	return s.caminoState.GetDepositClaims(depositTxID)
+}
+
 func (s *state) GetNextToUnlockDepositTime(removedDepositIDs set.Set[ids.ID]) (time.Time, error) {
 	return s.caminoState.GetNextToUnlockDepositTime(removedDepositIDs)
 }
@@ -96,10 +174,22 @@ func (s *state) SetMultisigAlias(owner *multisig.Alias) {
 	s.caminoState.SetMultisigAlias(owner)
 }
 
+func (s *state) AddMultisigAliasUsage(aliasID ids.ShortID, txID ids.ID) {
+	s.caminoState.AddMultisigAliasUsage(aliasID, txID)
+}
+
 func (s *state) GetMultisigAlias(alias ids.ShortID) (*multisig.Alias, error) {
 	return s.caminoState.GetMultisigAlias(alias)
 }
 
+func (s *state) GetMultisigAliases(start ids.ShortID, limit int) ([]*multisig.Alias, error) {
+	return s.caminoState.GetMultisigAliases(start, limit)
+}
+
+func (s *state) GetMultisigAliasIDsByMember(member ids.ShortID) ([]ids.ShortID, error) {
+	return s.caminoState.GetMultisigAliasIDsByMember(member)
+}
+
 func (s *state) SetShortIDLink(id ids.ShortID, key ShortLinkKey, link *ids.ShortID) {
 	s.caminoState.SetShortIDLink(id, key, link)
 }
@@ -108,6 +198,10 @@ func (s *state) GetShortIDLink(id ids.ShortID, key ShortLinkKey) (ids.ShortID, e
 	return s.caminoState.GetShortIDLink(id, key)
 }
 
+func (s *state) GetShortIDLinks(key ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	return s.caminoState.GetShortIDLinks(key)
+}
+
 func (s *state) SetClaimable(ownerID ids.ID, claimable *Claimable) {
 	s.caminoState.SetClaimable(ownerID, claimable)
 }
@@ -116,6 +210,10 @@ func (s *state) GetClaimable(ownerID ids.ID) (*Claimable, error) {
 	return s.caminoState.GetClaimable(ownerID)
 }
 
+func (s *state) GetClaimablesIterator() (ClaimablesIterator, error) {
+	return s.caminoState.GetClaimablesIterator()
+}
+
 func (s *state) SetNotDistributedValidatorReward(reward uint64) {
 	s.caminoState.SetNotDistributedValidatorReward(reward)
 }
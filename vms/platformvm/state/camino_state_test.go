@@ -6,6 +6,8 @@ package state
 import (
 	"testing"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -221,3 +223,31 @@ func TestLockedUTXOs(t *testing.T) {
 		})
 	}
 }
+
+func TestCaminoStateWriteIsBatched(t *testing.T) {
+	require := require.New(t)
+	s := newEmptyState(t)
+	underlyingDB := s.baseDB.GetDatabase()
+	address := ids.ShortID{1}
+
+	entriesBefore, err := database.Count(underlyingDB)
+	require.NoError(err)
+
+	s.SetAddressStates(address, 1)
+	s.SetClaimable(ids.ID{1}, &Claimable{Owner: &secp256k1fx.OutputOwners{}, ValidatorReward: 1})
+	require.NoError(s.caminoState.Write(s))
+
+	// camino changes are only staged until the whole block is committed
+	entriesAfterWrite, err := database.Count(underlyingDB)
+	require.NoError(err)
+	require.Equal(entriesBefore, entriesAfterWrite)
+
+	batch, err := s.baseDB.CommitBatch()
+	require.NoError(err)
+	require.NoError(batch.Write())
+	s.baseDB.Abort()
+
+	has, err := prefixdb.New(addressStatePrefix, underlyingDB).Has(address[:])
+	require.NoError(err)
+	require.True(has)
+}
@@ -15,6 +15,7 @@ import (
 	set "github.com/ava-labs/avalanchego/utils/set"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
 	multisig "github.com/ava-labs/avalanchego/vms/components/multisig"
+	addrstate "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	config "github.com/ava-labs/avalanchego/vms/platformvm/config"
 	deposit "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	locked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
@@ -221,10 +222,10 @@ func (mr *MockChainMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 }
 
 // GetAddressStates mocks base method.
-func (m *MockChain) GetAddressStates(arg0 ids.ShortID) (uint64, error) {
+func (m *MockChain) GetAddressStates(arg0 ids.ShortID) (addrstate.AddressState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAddressStates", arg0)
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(addrstate.AddressState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -235,6 +236,21 @@ func (mr *MockChainMockRecorder) GetAddressStates(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStates", reflect.TypeOf((*MockChain)(nil).GetAddressStates), arg0)
 }
 
+// GetAddressStatesIterator mocks base method.
+func (m *MockChain) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressStatesIterator")
+	ret0, _ := ret[0].(AddressStatesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressStatesIterator indicates an expected call of GetAddressStatesIterator.
+func (mr *MockChainMockRecorder) GetAddressStatesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStatesIterator", reflect.TypeOf((*MockChain)(nil).GetAddressStatesIterator))
+}
+
 // GetAllDepositOffers mocks base method.
 func (m *MockChain) GetAllDepositOffers() ([]*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -280,6 +296,21 @@ func (mr *MockChainMockRecorder) GetClaimable(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimable", reflect.TypeOf((*MockChain)(nil).GetClaimable), arg0)
 }
 
+// GetClaimablesIterator mocks base method.
+func (m *MockChain) GetClaimablesIterator() (ClaimablesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimablesIterator")
+	ret0, _ := ret[0].(ClaimablesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimablesIterator indicates an expected call of GetClaimablesIterator.
+func (mr *MockChainMockRecorder) GetClaimablesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimablesIterator", reflect.TypeOf((*MockChain)(nil).GetClaimablesIterator))
+}
+
 // GetCurrentDelegatorIterator mocks base method.
 func (m *MockChain) GetCurrentDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -386,6 +417,36 @@ func (mr *MockChainMockRecorder) GetNextToUnlockDepositIDsAndTime(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextToUnlockDepositIDsAndTime", reflect.TypeOf((*MockChain)(nil).GetNextToUnlockDepositIDsAndTime), arg0)
 }
 
+// GetDepositIDsByOffer mocks base method.
+func (m *MockChain) GetDepositIDsByOffer(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByOffer", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByOffer indicates an expected call of GetDepositIDsByOffer.
+func (mr *MockChainMockRecorder) GetDepositIDsByOffer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByOffer", reflect.TypeOf((*MockChain)(nil).GetDepositIDsByOffer), arg0)
+}
+
+// GetDepositIDsByRewardOwner mocks base method.
+func (m *MockChain) GetDepositIDsByRewardOwner(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByRewardOwner", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByRewardOwner indicates an expected call of GetDepositIDsByRewardOwner.
+func (mr *MockChainMockRecorder) GetDepositIDsByRewardOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByRewardOwner", reflect.TypeOf((*MockChain)(nil).GetDepositIDsByRewardOwner), arg0)
+}
+
 // GetDepositOffer mocks base method.
 func (m *MockChain) GetDepositOffer(arg0 ids.ID) (*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -416,6 +477,36 @@ func (mr *MockChainMockRecorder) GetMultisigAlias(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAlias", reflect.TypeOf((*MockChain)(nil).GetMultisigAlias), arg0)
 }
 
+// GetMultisigAliases mocks base method.
+func (m *MockChain) GetMultisigAliases(arg0 ids.ShortID, arg1 int) ([]*multisig.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliases", arg0, arg1)
+	ret0, _ := ret[0].([]*multisig.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliases indicates an expected call of GetMultisigAliases.
+func (mr *MockChainMockRecorder) GetMultisigAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliases", reflect.TypeOf((*MockChain)(nil).GetMultisigAliases), arg0, arg1)
+}
+
+// GetMultisigAliasIDsByMember mocks base method.
+func (m *MockChain) GetMultisigAliasIDsByMember(arg0 ids.ShortID) ([]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliasIDsByMember", arg0)
+	ret0, _ := ret[0].([]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliasIDsByMember indicates an expected call of GetMultisigAliasIDsByMember.
+func (mr *MockChainMockRecorder) GetMultisigAliasIDsByMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliasIDsByMember", reflect.TypeOf((*MockChain)(nil).GetMultisigAliasIDsByMember), arg0)
+}
+
 // GetNotDistributedValidatorReward mocks base method.
 func (m *MockChain) GetNotDistributedValidatorReward() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -560,6 +651,21 @@ func (mr *MockChainMockRecorder) GetShortIDLink(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLink", reflect.TypeOf((*MockChain)(nil).GetShortIDLink), arg0, arg1)
 }
 
+// GetShortIDLinks mocks base method.
+func (m *MockChain) GetShortIDLinks(arg0 ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortIDLinks", arg0)
+	ret0, _ := ret[0].(map[ids.ShortID]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortIDLinks indicates an expected call of GetShortIDLinks.
+func (mr *MockChainMockRecorder) GetShortIDLinks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLinks", reflect.TypeOf((*MockChain)(nil).GetShortIDLinks), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockChain) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -699,7 +805,7 @@ func (mr *MockChainMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 }
 
 // SetAddressStates mocks base method.
-func (m *MockChain) SetAddressStates(arg0 ids.ShortID, arg1 uint64) {
+func (m *MockChain) SetAddressStates(arg0 ids.ShortID, arg1 addrstate.AddressState) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetAddressStates", arg0, arg1)
 }
@@ -830,3 +936,42 @@ func (mr *MockChainMockRecorder) RemoveDeposit(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeposit", reflect.TypeOf((*MockChain)(nil).RemoveDeposit), arg0, arg1)
 }
+
+// AddMultisigAliasUsage mocks base method.
+func (m *MockChain) AddMultisigAliasUsage(arg0 ids.ShortID, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMultisigAliasUsage", arg0, arg1)
+}
+
+// AddMultisigAliasUsage indicates an expected call of AddMultisigAliasUsage.
+func (mr *MockChainMockRecorder) AddMultisigAliasUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMultisigAliasUsage", reflect.TypeOf((*MockChain)(nil).AddMultisigAliasUsage), arg0, arg1)
+}
+
+// AddDepositClaim mocks base method.
+func (m *MockChain) AddDepositClaim(arg0 ids.ID, arg1 *deposit.Claim) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddDepositClaim", arg0, arg1)
+}
+
+// AddDepositClaim indicates an expected call of AddDepositClaim.
+func (mr *MockChainMockRecorder) AddDepositClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepositClaim", reflect.TypeOf((*MockChain)(nil).AddDepositClaim), arg0, arg1)
+}
+
+// GetDepositClaims mocks base method.
+func (m *MockChain) GetDepositClaims(arg0 ids.ID) ([]*deposit.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositClaims", arg0)
+	ret0, _ := ret[0].([]*deposit.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositClaims indicates an expected call of GetDepositClaims.
+func (mr *MockChainMockRecorder) GetDepositClaims(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositClaims", reflect.TypeOf((*MockChain)(nil).GetDepositClaims), arg0)
+}
@@ -15,6 +15,7 @@ import (
 	set "github.com/ava-labs/avalanchego/utils/set"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
 	multisig "github.com/ava-labs/avalanchego/vms/components/multisig"
+	addrstate "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	config "github.com/ava-labs/avalanchego/vms/platformvm/config"
 	deposit "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	locked "github.com/ava-labs/avalanchego/vms/platformvm/locked"
@@ -245,10 +246,10 @@ func (mr *MockDiffMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 }
 
 // GetAddressStates mocks base method.
-func (m *MockDiff) GetAddressStates(arg0 ids.ShortID) (uint64, error) {
+func (m *MockDiff) GetAddressStates(arg0 ids.ShortID) (addrstate.AddressState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAddressStates", arg0)
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(addrstate.AddressState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -259,6 +260,21 @@ func (mr *MockDiffMockRecorder) GetAddressStates(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStates", reflect.TypeOf((*MockDiff)(nil).GetAddressStates), arg0)
 }
 
+// GetAddressStatesIterator mocks base method.
+func (m *MockDiff) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressStatesIterator")
+	ret0, _ := ret[0].(AddressStatesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressStatesIterator indicates an expected call of GetAddressStatesIterator.
+func (mr *MockDiffMockRecorder) GetAddressStatesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStatesIterator", reflect.TypeOf((*MockDiff)(nil).GetAddressStatesIterator))
+}
+
 // GetAllDepositOffers mocks base method.
 func (m *MockDiff) GetAllDepositOffers() ([]*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -304,6 +320,21 @@ func (mr *MockDiffMockRecorder) GetClaimable(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimable", reflect.TypeOf((*MockDiff)(nil).GetClaimable), arg0)
 }
 
+// GetClaimablesIterator mocks base method.
+func (m *MockDiff) GetClaimablesIterator() (ClaimablesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimablesIterator")
+	ret0, _ := ret[0].(ClaimablesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimablesIterator indicates an expected call of GetClaimablesIterator.
+func (mr *MockDiffMockRecorder) GetClaimablesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimablesIterator", reflect.TypeOf((*MockDiff)(nil).GetClaimablesIterator))
+}
+
 // GetCurrentDelegatorIterator mocks base method.
 func (m *MockDiff) GetCurrentDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -410,6 +441,36 @@ func (mr *MockDiffMockRecorder) GetNextToUnlockDepositIDsAndTime(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextToUnlockDepositIDsAndTime", reflect.TypeOf((*MockDiff)(nil).GetNextToUnlockDepositIDsAndTime), arg0)
 }
 
+// GetDepositIDsByOffer mocks base method.
+func (m *MockDiff) GetDepositIDsByOffer(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByOffer", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByOffer indicates an expected call of GetDepositIDsByOffer.
+func (mr *MockDiffMockRecorder) GetDepositIDsByOffer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByOffer", reflect.TypeOf((*MockDiff)(nil).GetDepositIDsByOffer), arg0)
+}
+
+// GetDepositIDsByRewardOwner mocks base method.
+func (m *MockDiff) GetDepositIDsByRewardOwner(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByRewardOwner", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByRewardOwner indicates an expected call of GetDepositIDsByRewardOwner.
+func (mr *MockDiffMockRecorder) GetDepositIDsByRewardOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByRewardOwner", reflect.TypeOf((*MockDiff)(nil).GetDepositIDsByRewardOwner), arg0)
+}
+
 // GetDepositOffer mocks base method.
 func (m *MockDiff) GetDepositOffer(arg0 ids.ID) (*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -440,6 +501,36 @@ func (mr *MockDiffMockRecorder) GetMultisigAlias(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAlias", reflect.TypeOf((*MockDiff)(nil).GetMultisigAlias), arg0)
 }
 
+// GetMultisigAliases mocks base method.
+func (m *MockDiff) GetMultisigAliases(arg0 ids.ShortID, arg1 int) ([]*multisig.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliases", arg0, arg1)
+	ret0, _ := ret[0].([]*multisig.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliases indicates an expected call of GetMultisigAliases.
+func (mr *MockDiffMockRecorder) GetMultisigAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliases", reflect.TypeOf((*MockDiff)(nil).GetMultisigAliases), arg0, arg1)
+}
+
+// GetMultisigAliasIDsByMember mocks base method.
+func (m *MockDiff) GetMultisigAliasIDsByMember(arg0 ids.ShortID) ([]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliasIDsByMember", arg0)
+	ret0, _ := ret[0].([]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliasIDsByMember indicates an expected call of GetMultisigAliasIDsByMember.
+func (mr *MockDiffMockRecorder) GetMultisigAliasIDsByMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliasIDsByMember", reflect.TypeOf((*MockDiff)(nil).GetMultisigAliasIDsByMember), arg0)
+}
+
 // GetNotDistributedValidatorReward mocks base method.
 func (m *MockDiff) GetNotDistributedValidatorReward() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -584,6 +675,21 @@ func (mr *MockDiffMockRecorder) GetShortIDLink(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLink", reflect.TypeOf((*MockDiff)(nil).GetShortIDLink), arg0, arg1)
 }
 
+// GetShortIDLinks mocks base method.
+func (m *MockDiff) GetShortIDLinks(arg0 ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortIDLinks", arg0)
+	ret0, _ := ret[0].(map[ids.ShortID]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortIDLinks indicates an expected call of GetShortIDLinks.
+func (mr *MockDiffMockRecorder) GetShortIDLinks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLinks", reflect.TypeOf((*MockDiff)(nil).GetShortIDLinks), arg0)
+}
+
 // GetSubnetTransformation mocks base method.
 func (m *MockDiff) GetSubnetTransformation(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -723,7 +829,7 @@ func (mr *MockDiffMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.Ca
 }
 
 // SetAddressStates mocks base method.
-func (m *MockDiff) SetAddressStates(arg0 ids.ShortID, arg1 uint64) {
+func (m *MockDiff) SetAddressStates(arg0 ids.ShortID, arg1 addrstate.AddressState) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetAddressStates", arg0, arg1)
 }
@@ -818,6 +924,21 @@ func (mr *MockDiffMockRecorder) AddDeposit(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDeposit", reflect.TypeOf((*MockDiff)(nil).AddDeposit), arg0, arg1)
 }
 
+// MarshalCaminoDiff mocks base method.
+func (m *MockDiff) MarshalCaminoDiff() ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarshalCaminoDiff")
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarshalCaminoDiff indicates an expected call of MarshalCaminoDiff.
+func (mr *MockDiffMockRecorder) MarshalCaminoDiff() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarshalCaminoDiff", reflect.TypeOf((*MockDiff)(nil).MarshalCaminoDiff))
+}
+
 // ModifyDeposit mocks base method.
 func (m *MockDiff) ModifyDeposit(arg0 ids.ID, arg1 *deposit.Deposit) {
 	m.ctrl.T.Helper()
@@ -841,3 +962,42 @@ func (mr *MockDiffMockRecorder) RemoveDeposit(arg0, arg1 interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeposit", reflect.TypeOf((*MockDiff)(nil).RemoveDeposit), arg0, arg1)
 }
+
+// AddMultisigAliasUsage mocks base method.
+func (m *MockDiff) AddMultisigAliasUsage(arg0 ids.ShortID, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMultisigAliasUsage", arg0, arg1)
+}
+
+// AddMultisigAliasUsage indicates an expected call of AddMultisigAliasUsage.
+func (mr *MockDiffMockRecorder) AddMultisigAliasUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMultisigAliasUsage", reflect.TypeOf((*MockDiff)(nil).AddMultisigAliasUsage), arg0, arg1)
+}
+
+// AddDepositClaim mocks base method.
+func (m *MockDiff) AddDepositClaim(arg0 ids.ID, arg1 *deposit.Claim) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddDepositClaim", arg0, arg1)
+}
+
+// AddDepositClaim indicates an expected call of AddDepositClaim.
+func (mr *MockDiffMockRecorder) AddDepositClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepositClaim", reflect.TypeOf((*MockDiff)(nil).AddDepositClaim), arg0, arg1)
+}
+
+// GetDepositClaims mocks base method.
+func (m *MockDiff) GetDepositClaims(arg0 ids.ID) ([]*deposit.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositClaims", arg0)
+	ret0, _ := ret[0].([]*deposit.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositClaims indicates an expected call of GetDepositClaims.
+func (mr *MockDiffMockRecorder) GetDepositClaims(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositClaims", reflect.TypeOf((*MockDiff)(nil).GetDepositClaims), arg0)
+}
@@ -8,6 +8,7 @@
 package state
 
 import (
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -18,6 +19,7 @@ import (
 	set "github.com/ava-labs/avalanchego/utils/set"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
 	multisig "github.com/ava-labs/avalanchego/vms/components/multisig"
+	addrstate "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	blocks "github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	config "github.com/ava-labs/avalanchego/vms/platformvm/config"
 	deposit "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
@@ -291,11 +293,26 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// DumpCaminoState mocks base method.
+func (m *MockState) DumpCaminoState(arg0 io.Writer) (ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DumpCaminoState", arg0)
+	ret0, _ := ret[0].(ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DumpCaminoState indicates an expected call of DumpCaminoState.
+func (mr *MockStateMockRecorder) DumpCaminoState(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DumpCaminoState", reflect.TypeOf((*MockState)(nil).DumpCaminoState), arg0)
+}
+
 // GetAddressStates mocks base method.
-func (m *MockState) GetAddressStates(arg0 ids.ShortID) (uint64, error) {
+func (m *MockState) GetAddressStates(arg0 ids.ShortID) (addrstate.AddressState, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAddressStates", arg0)
-	ret0, _ := ret[0].(uint64)
+	ret0, _ := ret[0].(addrstate.AddressState)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -306,6 +323,21 @@ func (mr *MockStateMockRecorder) GetAddressStates(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStates", reflect.TypeOf((*MockState)(nil).GetAddressStates), arg0)
 }
 
+// GetAddressStatesIterator mocks base method.
+func (m *MockState) GetAddressStatesIterator() (AddressStatesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddressStatesIterator")
+	ret0, _ := ret[0].(AddressStatesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAddressStatesIterator indicates an expected call of GetAddressStatesIterator.
+func (mr *MockStateMockRecorder) GetAddressStatesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddressStatesIterator", reflect.TypeOf((*MockState)(nil).GetAddressStatesIterator))
+}
+
 // GetAllDepositOffers mocks base method.
 func (m *MockState) GetAllDepositOffers() ([]*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -321,6 +353,36 @@ func (mr *MockStateMockRecorder) GetAllDepositOffers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllDepositOffers", reflect.TypeOf((*MockState)(nil).GetAllDepositOffers))
 }
 
+// GetArchivedDeposit mocks base method.
+func (m *MockState) GetArchivedDeposit(arg0 ids.ID) (*deposit.Deposit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedDeposit", arg0)
+	ret0, _ := ret[0].(*deposit.Deposit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArchivedDeposit indicates an expected call of GetArchivedDeposit.
+func (mr *MockStateMockRecorder) GetArchivedDeposit(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedDeposit", reflect.TypeOf((*MockState)(nil).GetArchivedDeposit), arg0)
+}
+
+// GetCaminoHistoricalView mocks base method.
+func (m *MockState) GetCaminoHistoricalView(arg0 uint64) (CaminoHistoricalView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCaminoHistoricalView", arg0)
+	ret0, _ := ret[0].(CaminoHistoricalView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCaminoHistoricalView indicates an expected call of GetCaminoHistoricalView.
+func (mr *MockStateMockRecorder) GetCaminoHistoricalView(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCaminoHistoricalView", reflect.TypeOf((*MockState)(nil).GetCaminoHistoricalView), arg0)
+}
+
 // GetChains mocks base method.
 func (m *MockState) GetChains(arg0 ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -351,6 +413,21 @@ func (mr *MockStateMockRecorder) GetClaimable(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimable", reflect.TypeOf((*MockState)(nil).GetClaimable), arg0)
 }
 
+// GetClaimablesIterator mocks base method.
+func (m *MockState) GetClaimablesIterator() (ClaimablesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClaimablesIterator")
+	ret0, _ := ret[0].(ClaimablesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClaimablesIterator indicates an expected call of GetClaimablesIterator.
+func (mr *MockStateMockRecorder) GetClaimablesIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClaimablesIterator", reflect.TypeOf((*MockState)(nil).GetClaimablesIterator))
+}
+
 // GetCurrentDelegatorIterator mocks base method.
 func (m *MockState) GetCurrentDelegatorIterator(arg0 ids.ID, arg1 ids.NodeID) (StakerIterator, error) {
 	m.ctrl.T.Helper()
@@ -457,6 +534,51 @@ func (mr *MockStateMockRecorder) GetNextToUnlockDepositIDsAndTime(arg0 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNextToUnlockDepositIDsAndTime", reflect.TypeOf((*MockState)(nil).GetNextToUnlockDepositIDsAndTime), arg0)
 }
 
+// GetDepositIDsByOffer mocks base method.
+func (m *MockState) GetDepositIDsByOffer(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByOffer", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByOffer indicates an expected call of GetDepositIDsByOffer.
+func (mr *MockStateMockRecorder) GetDepositIDsByOffer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByOffer", reflect.TypeOf((*MockState)(nil).GetDepositIDsByOffer), arg0)
+}
+
+// GetDepositIDsByRewardOwner mocks base method.
+func (m *MockState) GetDepositIDsByRewardOwner(arg0 ids.ID) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositIDsByRewardOwner", arg0)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositIDsByRewardOwner indicates an expected call of GetDepositIDsByRewardOwner.
+func (mr *MockStateMockRecorder) GetDepositIDsByRewardOwner(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositIDsByRewardOwner", reflect.TypeOf((*MockState)(nil).GetDepositIDsByRewardOwner), arg0)
+}
+
+// GetDepositStats mocks base method.
+func (m *MockState) GetDepositStats() (*DepositStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositStats")
+	ret0, _ := ret[0].(*DepositStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositStats indicates an expected call of GetDepositStats.
+func (mr *MockStateMockRecorder) GetDepositStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositStats", reflect.TypeOf((*MockState)(nil).GetDepositStats))
+}
+
 // GetDepositOffer mocks base method.
 func (m *MockState) GetDepositOffer(arg0 ids.ID) (*deposit.Offer, error) {
 	m.ctrl.T.Helper()
@@ -501,6 +623,66 @@ func (mr *MockStateMockRecorder) GetMultisigAlias(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAlias", reflect.TypeOf((*MockState)(nil).GetMultisigAlias), arg0)
 }
 
+// GetMultisigAliases mocks base method.
+func (m *MockState) GetMultisigAliases(arg0 ids.ShortID, arg1 int) ([]*multisig.Alias, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliases", arg0, arg1)
+	ret0, _ := ret[0].([]*multisig.Alias)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliases indicates an expected call of GetMultisigAliases.
+func (mr *MockStateMockRecorder) GetMultisigAliases(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliases", reflect.TypeOf((*MockState)(nil).GetMultisigAliases), arg0, arg1)
+}
+
+// GetMultisigAliasIDsByMember mocks base method.
+func (m *MockState) GetMultisigAliasIDsByMember(arg0 ids.ShortID) ([]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliasIDsByMember", arg0)
+	ret0, _ := ret[0].([]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliasIDsByMember indicates an expected call of GetMultisigAliasIDsByMember.
+func (mr *MockStateMockRecorder) GetMultisigAliasIDsByMember(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliasIDsByMember", reflect.TypeOf((*MockState)(nil).GetMultisigAliasIDsByMember), arg0)
+}
+
+// GetMultisigAliasUsages mocks base method.
+func (m *MockState) GetMultisigAliasUsages(arg0 ids.ShortID) ([]*MultisigAliasUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliasUsages", arg0)
+	ret0, _ := ret[0].([]*MultisigAliasUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliasUsages indicates an expected call of GetMultisigAliasUsages.
+func (mr *MockStateMockRecorder) GetMultisigAliasUsages(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliasUsages", reflect.TypeOf((*MockState)(nil).GetMultisigAliasUsages), arg0)
+}
+
+// GetMultisigAliasHistory mocks base method.
+func (m *MockState) GetMultisigAliasHistory(arg0 ids.ShortID) ([]*MultisigAliasVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMultisigAliasHistory", arg0)
+	ret0, _ := ret[0].([]*MultisigAliasVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMultisigAliasHistory indicates an expected call of GetMultisigAliasHistory.
+func (mr *MockStateMockRecorder) GetMultisigAliasHistory(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMultisigAliasHistory", reflect.TypeOf((*MockState)(nil).GetMultisigAliasHistory), arg0)
+}
+
 // GetNotDistributedValidatorReward mocks base method.
 func (m *MockState) GetNotDistributedValidatorReward() (uint64, error) {
 	m.ctrl.T.Helper()
@@ -645,6 +827,21 @@ func (mr *MockStateMockRecorder) GetShortIDLink(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLink", reflect.TypeOf((*MockState)(nil).GetShortIDLink), arg0, arg1)
 }
 
+// GetShortIDLinks mocks base method.
+func (m *MockState) GetShortIDLinks(arg0 ShortLinkKey) (map[ids.ShortID]ids.ShortID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShortIDLinks", arg0)
+	ret0, _ := ret[0].(map[ids.ShortID]ids.ShortID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShortIDLinks indicates an expected call of GetShortIDLinks.
+func (mr *MockStateMockRecorder) GetShortIDLinks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShortIDLinks", reflect.TypeOf((*MockState)(nil).GetShortIDLinks), arg0)
+}
+
 // GetStartTime mocks base method.
 func (m *MockState) GetStartTime(arg0 ids.NodeID, arg1 ids.ID) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -814,6 +1011,36 @@ func (mr *MockStateMockRecorder) LockedUTXOs(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockedUTXOs", reflect.TypeOf((*MockState)(nil).LockedUTXOs), arg0, arg1, arg2)
 }
 
+// OwnerUTXOIDs mocks base method.
+func (m *MockState) OwnerUTXOIDs(arg0, arg1 ids.ID, arg2 int) ([]ids.ID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerUTXOIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]ids.ID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OwnerUTXOIDs indicates an expected call of OwnerUTXOIDs.
+func (mr *MockStateMockRecorder) OwnerUTXOIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerUTXOIDs", reflect.TypeOf((*MockState)(nil).OwnerUTXOIDs), arg0, arg1, arg2)
+}
+
+// OwnerUTXOsCount mocks base method.
+func (m *MockState) OwnerUTXOsCount(arg0 ids.ID) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerUTXOsCount", arg0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OwnerUTXOsCount indicates an expected call of OwnerUTXOsCount.
+func (mr *MockStateMockRecorder) OwnerUTXOsCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerUTXOsCount", reflect.TypeOf((*MockState)(nil).OwnerUTXOsCount), arg0)
+}
+
 // PutCurrentDelegator mocks base method.
 func (m *MockState) PutCurrentDelegator(arg0 *Staker) {
 	m.ctrl.T.Helper()
@@ -863,7 +1090,7 @@ func (mr *MockStateMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 }
 
 // SetAddressStates mocks base method.
-func (m *MockState) SetAddressStates(arg0 ids.ShortID, arg1 uint64) {
+func (m *MockState) SetAddressStates(arg0 ids.ShortID, arg1 addrstate.AddressState) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetAddressStates", arg0, arg1)
 }
@@ -999,6 +1226,20 @@ func (mr *MockStateMockRecorder) UTXOIDs(arg0, arg1, arg2 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UTXOIDs", reflect.TypeOf((*MockState)(nil).UTXOIDs), arg0, arg1, arg2)
 }
 
+// VerifyCaminoStateInvariants mocks base method.
+func (m *MockState) VerifyCaminoStateInvariants() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCaminoStateInvariants")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyCaminoStateInvariants indicates an expected call of VerifyCaminoStateInvariants.
+func (mr *MockStateMockRecorder) VerifyCaminoStateInvariants() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCaminoStateInvariants", reflect.TypeOf((*MockState)(nil).VerifyCaminoStateInvariants))
+}
+
 // AddDeposit mocks base method.
 func (m *MockState) AddDeposit(arg0 ids.ID, arg1 *deposit.Deposit) {
 	m.ctrl.T.Helper()
@@ -1034,3 +1275,42 @@ func (mr *MockStateMockRecorder) RemoveDeposit(arg0, arg1 interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveDeposit", reflect.TypeOf((*MockState)(nil).RemoveDeposit), arg0, arg1)
 }
+
+// AddMultisigAliasUsage mocks base method.
+func (m *MockState) AddMultisigAliasUsage(arg0 ids.ShortID, arg1 ids.ID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddMultisigAliasUsage", arg0, arg1)
+}
+
+// AddMultisigAliasUsage indicates an expected call of AddMultisigAliasUsage.
+func (mr *MockStateMockRecorder) AddMultisigAliasUsage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMultisigAliasUsage", reflect.TypeOf((*MockState)(nil).AddMultisigAliasUsage), arg0, arg1)
+}
+
+// AddDepositClaim mocks base method.
+func (m *MockState) AddDepositClaim(arg0 ids.ID, arg1 *deposit.Claim) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddDepositClaim", arg0, arg1)
+}
+
+// AddDepositClaim indicates an expected call of AddDepositClaim.
+func (mr *MockStateMockRecorder) AddDepositClaim(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDepositClaim", reflect.TypeOf((*MockState)(nil).AddDepositClaim), arg0, arg1)
+}
+
+// GetDepositClaims mocks base method.
+func (m *MockState) GetDepositClaims(arg0 ids.ID) ([]*deposit.Claim, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDepositClaims", arg0)
+	ret0, _ := ret[0].([]*deposit.Claim)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDepositClaims indicates an expected call of GetDepositClaims.
+func (mr *MockStateMockRecorder) GetDepositClaims(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDepositClaims", reflect.TypeOf((*MockState)(nil).GetDepositClaims), arg0)
+}
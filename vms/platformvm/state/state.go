@@ -16,6 +16,7 @@ package state
 import (
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/google/btree"
@@ -41,6 +42,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
@@ -138,6 +140,30 @@ type State interface {
 	uptime.State
 	avax.UTXOReader
 
+	// OwnerUTXOIDs returns the slice of IDs of utxos owned by [ownerID],
+	// starting after [previous]. Returns at most [limit] IDs.
+	OwnerUTXOIDs(ownerID, previous ids.ID, limit int) ([]ids.ID, error)
+	// OwnerUTXOsCount returns number of utxos owned by [ownerID].
+	OwnerUTXOsCount(ownerID ids.ID) (uint64, error)
+	// GetDepositStats returns active deposit principal by offer and outstanding deposit rewards.
+	GetDepositStats() (*DepositStats, error)
+	// GetArchivedDeposit returns deposit, that was pruned from active state.
+	GetArchivedDeposit(depositTxID ids.ID) (*deposit.Deposit, error)
+	// GetMultisigAliasHistory returns previous versions of multisig alias
+	// in order they were replaced.
+	GetMultisigAliasHistory(aliasID ids.ShortID) ([]*MultisigAliasVersion, error)
+	// GetMultisigAliasUsages returns last recorded usages of multisig alias
+	// in order they were accepted.
+	GetMultisigAliasUsages(aliasID ids.ShortID) ([]*MultisigAliasUsage, error)
+	// GetCaminoHistoricalView returns read-only view of deposits, claimables
+	// and address states at accepted [height].
+	GetCaminoHistoricalView(height uint64) (CaminoHistoricalView, error)
+	// VerifyCaminoStateInvariants checks consistency of persisted camino state.
+	VerifyCaminoStateInvariants() error
+	// DumpCaminoState writes canonical json dump of persisted camino state
+	// into [w] and returns its hash.
+	DumpCaminoState(w io.Writer) (ids.ID, error)
+
 	GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error)
 
 	// Returns a map of node ID --> BLS Public Key for all validators
@@ -242,8 +268,8 @@ type state struct {
 
 	currentHeight uint64
 
-	addedBlocks map[ids.ID]stateBlk // map of blockID -> Block
-	blockCache  cache.Cacher        // cache of blockID -> Block, if the entry is nil, it is not in the database
+	addedBlocks map[ids.ID]stateBlk             // map of blockID -> Block
+	blockCache  cache.Cacher[ids.ID, *stateBlk] // cache of blockID -> Block, if the entry is nil, it is not in the database
 	blockDB     database.Database
 
 	validatorsDB                 database.Database
@@ -266,18 +292,18 @@ type state struct {
 	pendingSubnetDelegatorBaseDB database.Database
 	pendingSubnetDelegatorList   linkeddb.LinkedDB
 
-	validatorWeightDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.NodeID]*ValidatorWeightDiff
+	validatorWeightDiffsCache cache.Cacher[string, map[ids.NodeID]*ValidatorWeightDiff] // cache of heightWithSubnet -> map[ids.NodeID]*ValidatorWeightDiff
 	validatorWeightDiffsDB    database.Database
 
-	validatorPublicKeyDiffsCache cache.Cacher // cache of height -> map[ids.NodeID]*bls.PublicKey
+	validatorPublicKeyDiffsCache cache.Cacher[uint64, map[ids.NodeID]*bls.PublicKey] // cache of height -> map[ids.NodeID]*bls.PublicKey
 	validatorPublicKeyDiffsDB    database.Database
 
-	addedTxs map[ids.ID]*txAndStatus // map of txID -> {*txs.Tx, Status}
-	txCache  cache.Cacher            // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
+	addedTxs map[ids.ID]*txAndStatus            // map of txID -> {*txs.Tx, Status}
+	txCache  cache.Cacher[ids.ID, *txAndStatus] // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
 	txDB     database.Database
 
-	addedRewardUTXOs map[ids.ID][]*avax.UTXO // map of txID -> []*UTXO
-	rewardUTXOsCache cache.Cacher            // cache of txID -> []*UTXO
+	addedRewardUTXOs map[ids.ID][]*avax.UTXO            // map of txID -> []*UTXO
+	rewardUTXOsCache cache.Cacher[ids.ID, []*avax.UTXO] // cache of txID -> []*UTXO
 	rewardUTXODB     database.Database
 
 	modifiedUTXOs map[ids.ID]*avax.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
@@ -289,17 +315,17 @@ type state struct {
 	subnetBaseDB  database.Database
 	subnetDB      linkeddb.LinkedDB
 
-	transformedSubnets     map[ids.ID]*txs.Tx // map of subnetID -> transformSubnetTx
-	transformedSubnetCache cache.Cacher       // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
+	transformedSubnets     map[ids.ID]*txs.Tx            // map of subnetID -> transformSubnetTx
+	transformedSubnetCache cache.Cacher[ids.ID, *txs.Tx] // cache of subnetID -> transformSubnetTx if the entry is nil, it is not in the database
 	transformedSubnetDB    database.Database
 
-	modifiedSupplies map[ids.ID]uint64 // map of subnetID -> current supply
-	supplyCache      cache.Cacher      // cache of subnetID -> current supply if the entry is nil, it is not in the database
+	modifiedSupplies map[ids.ID]uint64             // map of subnetID -> current supply
+	supplyCache      cache.Cacher[ids.ID, *uint64] // cache of subnetID -> current supply if the entry is nil, it is not in the database
 	supplyDB         database.Database
 
-	addedChains  map[ids.ID][]*txs.Tx // maps subnetID -> the newly added chains to the subnet
-	chainCache   cache.Cacher         // cache of subnetID -> the chains after all local modifications []*txs.Tx
-	chainDBCache cache.Cacher         // cache of subnetID -> linkedDB
+	addedChains  map[ids.ID][]*txs.Tx                    // maps subnetID -> the newly added chains to the subnet
+	chainCache   cache.Cacher[ids.ID, []*txs.Tx]         // cache of subnetID -> the chains after all local modifications []*txs.Tx
+	chainDBCache cache.Cacher[ids.ID, linkeddb.LinkedDB] // cache of subnetID -> linkedDB
 	chainDB      database.Database
 
 	// The persisted fields represent the current database value
@@ -385,10 +411,10 @@ func new(
 	metricsReg prometheus.Registerer,
 	rewards reward.Calculator,
 ) (*state, error) {
-	blockCache, err := metercacher.New(
+	blockCache, err := metercacher.New[ids.ID, *stateBlk](
 		"block_cache",
 		metricsReg,
-		&cache.LRU{Size: blockCacheSize},
+		&cache.LRU[ids.ID, *stateBlk]{Size: blockCacheSize},
 	)
 	if err != nil {
 		return nil, err
@@ -411,39 +437,39 @@ func new(
 	pendingSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, pendingValidatorsDB)
 
 	validatorWeightDiffsDB := prefixdb.New(validatorWeightDiffsPrefix, validatorsDB)
-	validatorWeightDiffsCache, err := metercacher.New(
+	validatorWeightDiffsCache, err := metercacher.New[string, map[ids.NodeID]*ValidatorWeightDiff](
 		"validator_weight_diffs_cache",
 		metricsReg,
-		&cache.LRU{Size: validatorDiffsCacheSize},
+		&cache.LRU[string, map[ids.NodeID]*ValidatorWeightDiff]{Size: validatorDiffsCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	validatorPublicKeyDiffsDB := prefixdb.New(validatorPublicKeyDiffsPrefix, validatorsDB)
-	validatorPublicKeyDiffsCache, err := metercacher.New(
+	validatorPublicKeyDiffsCache, err := metercacher.New[uint64, map[ids.NodeID]*bls.PublicKey](
 		"validator_pub_key_diffs_cache",
 		metricsReg,
-		&cache.LRU{Size: validatorDiffsCacheSize},
+		&cache.LRU[uint64, map[ids.NodeID]*bls.PublicKey]{Size: validatorDiffsCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	txCache, err := metercacher.New(
+	txCache, err := metercacher.New[ids.ID, *txAndStatus](
 		"tx_cache",
 		metricsReg,
-		&cache.LRU{Size: txCacheSize},
+		&cache.LRU[ids.ID, *txAndStatus]{Size: txCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	rewardUTXODB := prefixdb.New(rewardUTXOsPrefix, baseDB)
-	rewardUTXOsCache, err := metercacher.New(
+	rewardUTXOsCache, err := metercacher.New[ids.ID, []*avax.UTXO](
 		"reward_utxos_cache",
 		metricsReg,
-		&cache.LRU{Size: rewardUTXOsCacheSize},
+		&cache.LRU[ids.ID, []*avax.UTXO]{Size: rewardUTXOsCacheSize},
 	)
 	if err != nil {
 		return nil, err
@@ -457,43 +483,43 @@ func new(
 
 	subnetBaseDB := prefixdb.New(subnetPrefix, baseDB)
 
-	transformedSubnetCache, err := metercacher.New(
+	transformedSubnetCache, err := metercacher.New[ids.ID, *txs.Tx](
 		"transformed_subnet_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		&cache.LRU[ids.ID, *txs.Tx]{Size: chainCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	supplyCache, err := metercacher.New(
+	supplyCache, err := metercacher.New[ids.ID, *uint64](
 		"supply_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		&cache.LRU[ids.ID, *uint64]{Size: chainCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	chainCache, err := metercacher.New(
+	chainCache, err := metercacher.New[ids.ID, []*txs.Tx](
 		"chain_cache",
 		metricsReg,
-		&cache.LRU{Size: chainCacheSize},
+		&cache.LRU[ids.ID, []*txs.Tx]{Size: chainCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	chainDBCache, err := metercacher.New(
+	chainDBCache, err := metercacher.New[ids.ID, linkeddb.LinkedDB](
 		"chain_db_cache",
 		metricsReg,
-		&cache.LRU{Size: chainDBCacheSize},
+		&cache.LRU[ids.ID, linkeddb.LinkedDB]{Size: chainDBCacheSize},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	caminoState, err := newCaminoState(baseDB, validatorsDB, metricsReg)
+	caminoState, err := newCaminoState(baseDB, validatorsDB, metricsReg, cfg.CaminoConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -678,11 +704,11 @@ func (s *state) GetSubnetTransformation(subnetID ids.ID) (*txs.Tx, error) {
 		return tx, nil
 	}
 
-	if txIntf, cached := s.transformedSubnetCache.Get(subnetID); cached {
-		if txIntf == nil {
+	if tx, cached := s.transformedSubnetCache.Get(subnetID); cached {
+		if tx == nil {
 			return nil, database.ErrNotFound
 		}
-		return txIntf.(*txs.Tx), nil
+		return tx, nil
 	}
 
 	transformSubnetTxID, err := database.GetID(s.transformedSubnetDB, subnetID[:])
@@ -708,8 +734,8 @@ func (s *state) AddSubnetTransformation(transformSubnetTxIntf *txs.Tx) {
 }
 
 func (s *state) GetChains(subnetID ids.ID) ([]*txs.Tx, error) {
-	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
-		return chainsIntf.([]*txs.Tx), nil
+	if chains, cached := s.chainCache.Get(subnetID); cached {
+		return chains, nil
 	}
 	chainDB := s.getChainDB(subnetID)
 	chainDBIt := chainDB.NewIterator()
@@ -740,16 +766,15 @@ func (s *state) AddChain(createChainTxIntf *txs.Tx) {
 	createChainTx := createChainTxIntf.Unsigned.(*txs.CreateChainTx)
 	subnetID := createChainTx.SubnetID
 	s.addedChains[subnetID] = append(s.addedChains[subnetID], createChainTxIntf)
-	if chainsIntf, cached := s.chainCache.Get(subnetID); cached {
-		chains := chainsIntf.([]*txs.Tx)
+	if chains, cached := s.chainCache.Get(subnetID); cached {
 		chains = append(chains, createChainTxIntf)
 		s.chainCache.Put(subnetID, chains)
 	}
 }
 
 func (s *state) getChainDB(subnetID ids.ID) linkeddb.LinkedDB {
-	if chainDBIntf, cached := s.chainDBCache.Get(subnetID); cached {
-		return chainDBIntf.(linkeddb.LinkedDB)
+	if chainDB, cached := s.chainDBCache.Get(subnetID); cached {
+		return chainDB
 	}
 	rawChainDB := prefixdb.New(subnetID[:], s.chainDB)
 	chainDB := linkeddb.NewDefault(rawChainDB)
@@ -761,11 +786,10 @@ func (s *state) GetTx(txID ids.ID) (*txs.Tx, status.Status, error) {
 	if tx, exists := s.addedTxs[txID]; exists {
 		return tx.tx, tx.status, nil
 	}
-	if txIntf, cached := s.txCache.Get(txID); cached {
-		if txIntf == nil {
+	if tx, cached := s.txCache.Get(txID); cached {
+		if tx == nil {
 			return nil, status.Unknown, database.ErrNotFound
 		}
-		tx := txIntf.(*txAndStatus)
 		return tx.tx, tx.status, nil
 	}
 	txBytes, err := s.txDB.Get(txID[:])
@@ -807,7 +831,7 @@ func (s *state) GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error) {
 		return utxos, nil
 	}
 	if utxos, exists := s.rewardUTXOsCache.Get(txID); exists {
-		return utxos.([]*avax.UTXO), nil
+		return utxos, nil
 	}
 
 	rawTxDB := prefixdb.New(txID[:], s.rewardUTXODB)
@@ -891,12 +915,12 @@ func (s *state) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 		return supply, nil
 	}
 
-	supplyIntf, ok := s.supplyCache.Get(subnetID)
+	cachedSupply, ok := s.supplyCache.Get(subnetID)
 	if ok {
-		if supplyIntf == nil {
+		if cachedSupply == nil {
 			return 0, database.ErrNotFound
 		}
-		return supplyIntf.(uint64), nil
+		return *cachedSupply, nil
 	}
 
 	supply, err := database.GetUInt64(s.supplyDB, subnetID[:])
@@ -908,7 +932,7 @@ func (s *state) GetCurrentSupply(subnetID ids.ID) (uint64, error) {
 		return 0, err
 	}
 
-	s.supplyCache.Put(subnetID, supply)
+	s.supplyCache.Put(subnetID, &supply)
 	return supply, nil
 }
 
@@ -931,8 +955,8 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 	}
 	prefixStr := string(prefixBytes)
 
-	if weightDiffsIntf, ok := s.validatorWeightDiffsCache.Get(prefixStr); ok {
-		return weightDiffsIntf.(map[ids.NodeID]*ValidatorWeightDiff), nil
+	if weightDiffs, ok := s.validatorWeightDiffsCache.Get(prefixStr); ok {
+		return weightDiffs, nil
 	}
 
 	rawDiffDB := prefixdb.New(prefixBytes, s.validatorWeightDiffsDB)
@@ -961,8 +985,8 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 }
 
 func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error) {
-	if publicKeyDiffsIntf, ok := s.validatorPublicKeyDiffsCache.Get(height); ok {
-		return publicKeyDiffsIntf.(map[ids.NodeID]*bls.PublicKey), nil
+	if publicKeyDiffs, ok := s.validatorPublicKeyDiffsCache.Get(height); ok {
+		return publicKeyDiffs, nil
 	}
 
 	heightBytes := database.PackUInt64(height)
@@ -1403,7 +1427,7 @@ func (s *state) write(updateValidators bool, height uint64) error {
 		s.writeSubnetSupplies(),
 		s.writeChains(),
 		s.writeMetadata(),
-		s.caminoState.Write(),
+		s.caminoState.Write(s),
 	)
 	return errs.Err
 }
@@ -1543,7 +1567,7 @@ func (s *state) writeBlocks() error {
 		}
 
 		delete(s.addedBlocks, blkID)
-		s.blockCache.Put(blkID, stateBlk)
+		s.blockCache.Put(blkID, &stBlk)
 		if err := s.blockDB.Put(blkID[:], blockBytes); err != nil {
 			return fmt.Errorf("failed to write block %s: %w", blkID, err)
 		}
@@ -1555,12 +1579,11 @@ func (s *state) GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status,
 	if blk, exists := s.addedBlocks[blockID]; exists {
 		return blk.Blk, blk.Status, nil
 	}
-	if blkIntf, cached := s.blockCache.Get(blockID); cached {
-		if blkIntf == nil {
+	if blkState, cached := s.blockCache.Get(blockID); cached {
+		if blkState == nil {
 			return nil, choices.Processing, database.ErrNotFound // status does not matter here
 		}
 
-		blkState := blkIntf.(stateBlk)
 		return blkState.Blk, blkState.Status, nil
 	}
 
@@ -1583,7 +1606,7 @@ func (s *state) GetStatelessBlock(blockID ids.ID) (blocks.Block, choices.Status,
 		return nil, choices.Processing, err
 	}
 
-	s.blockCache.Put(blockID, blkState)
+	s.blockCache.Put(blockID, &blkState)
 	return blkState.Blk, blkState.Status, nil
 }
 
@@ -1894,14 +1917,30 @@ func (s *state) writeUTXOs() error {
 		delete(s.modifiedUTXOs, utxoID)
 
 		if utxo == nil {
+			deletedUTXO, err := s.utxoState.GetUTXO(utxoID)
+			if err != nil {
+				return fmt.Errorf("failed to get deleted UTXO: %w", err)
+			}
 			if err := s.utxoState.DeleteUTXO(utxoID); err != nil {
 				return fmt.Errorf("failed to delete UTXO: %w", err)
 			}
+			if err := s.caminoState.unindexUTXO(deletedUTXO); err != nil {
+				return fmt.Errorf("failed to unindex UTXO: %w", err)
+			}
+			if err := s.caminoState.unindexLockedUTXO(deletedUTXO); err != nil {
+				return fmt.Errorf("failed to unindex locked UTXO: %w", err)
+			}
 			continue
 		}
 		if err := s.utxoState.PutUTXO(utxo); err != nil {
 			return fmt.Errorf("failed to add UTXO: %w", err)
 		}
+		if err := s.caminoState.indexUTXO(utxo); err != nil {
+			return fmt.Errorf("failed to index UTXO: %w", err)
+		}
+		if err := s.caminoState.indexLockedUTXO(utxo); err != nil {
+			return fmt.Errorf("failed to index locked UTXO: %w", err)
+		}
 	}
 	return nil
 }
@@ -1933,8 +1972,9 @@ func (s *state) writeTransformedSubnets() error {
 
 func (s *state) writeSubnetSupplies() error {
 	for subnetID, supply := range s.modifiedSupplies {
+		supply := supply
 		delete(s.modifiedSupplies, subnetID)
-		s.supplyCache.Put(subnetID, supply)
+		s.supplyCache.Put(subnetID, &supply)
 		if err := database.PutUInt64(s.supplyDB, subnetID[:], supply); err != nil {
 			return fmt.Errorf("failed to write subnet supply: %w", err)
 		}
@@ -0,0 +1,72 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tenant
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	errEmptyName         = errors.New("tenant name is empty")
+	errEmptyToken        = errors.New("tenant token is empty")
+	errNegativeRateLimit = errors.New("tenant rate limit is negative")
+	errNegativeBurst     = errors.New("tenant burst is negative")
+	errDuplicateName     = errors.New("duplicate tenant name")
+	errDuplicateToken    = errors.New("duplicate tenant token")
+)
+
+// Config describes a single platform API tenant
+type Config struct {
+	// Tenant name, used in metrics and logs
+	Name string `json:"name"`
+	// Secret token that identifies requests made by this tenant
+	Token string `json:"token"`
+	// Maximum sustained number of requests per second, zero means unlimited
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	// Maximum number of requests that can be served at once,
+	// if zero, defaults to ceil(RequestsPerSecond)
+	Burst int `json:"burst"`
+	// Methods (e.g. "platform.getBalance") this tenant is allowed to call,
+	// empty means that all methods are allowed
+	AllowedMethods []string `json:"allowedMethods"`
+}
+
+// Verify returns nil if [c] is a valid tenant config
+func (c *Config) Verify() error {
+	switch {
+	case c.Name == "":
+		return errEmptyName
+	case c.Token == "":
+		return fmt.Errorf("%w (tenant %s)", errEmptyToken, c.Name)
+	case c.RequestsPerSecond < 0:
+		return fmt.Errorf("%w (tenant %s)", errNegativeRateLimit, c.Name)
+	case c.Burst < 0:
+		return fmt.Errorf("%w (tenant %s)", errNegativeBurst, c.Name)
+	}
+	return nil
+}
+
+// VerifyConfigs returns nil if all [configs] are valid and
+// there are no two tenants with the same name or token
+func VerifyConfigs(configs []Config) error {
+	names := set.NewSet[string](len(configs))
+	tokens := set.NewSet[string](len(configs))
+	for i := range configs {
+		if err := configs[i].Verify(); err != nil {
+			return err
+		}
+		if names.Contains(configs[i].Name) {
+			return fmt.Errorf("%w: %s", errDuplicateName, configs[i].Name)
+		}
+		if tokens.Contains(configs[i].Token) {
+			return fmt.Errorf("%w (tenant %s)", errDuplicateToken, configs[i].Name)
+		}
+		names.Add(configs[i].Name)
+		tokens.Add(configs[i].Token)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// TokenHeader is the http header that must contain tenant token
+const TokenHeader = "Camino-Tenant-Token"
+
+const (
+	unknownTenantLabel = "unknown"
+
+	rejectReasonUnknownTenant    = "unknown_tenant"
+	rejectReasonMethodNotAllowed = "method_not_allowed"
+	rejectReasonRateLimited      = "rate_limited"
+)
+
+var (
+	_ Manager = (*manager)(nil)
+
+	ErrUnknownTenant    = errors.New("unknown api tenant")
+	ErrMethodNotAllowed = errors.New("method isn't allowed for api tenant")
+	ErrRateLimited      = errors.New("api tenant rate limit exceeded")
+)
+
+// Manager authorizes platform API requests on behalf of registered tenants
+type Manager interface {
+	// ValidateRequest returns nil if the tenant identified by [TokenHeader]
+	// of the request is allowed to call requested method right now.
+	// Its signature matches gorilla rpc ValidateRequestFunc.
+	ValidateRequest(i *rpc.RequestInfo, args interface{}) error
+}
+
+type tenant struct {
+	name           string
+	allowedMethods set.Set[string]
+	limiter        *rate.Limiter
+}
+
+type manager struct {
+	// token -> tenant
+	tenants map[string]*tenant
+
+	requests *prometheus.CounterVec
+	rejected *prometheus.CounterVec
+}
+
+func NewManager(
+	configs []Config,
+	namespace string,
+	registerer prometheus.Registerer,
+) (Manager, error) {
+	if err := VerifyConfigs(configs); err != nil {
+		return nil, err
+	}
+
+	m := &manager{
+		tenants: make(map[string]*tenant, len(configs)),
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "api_tenant_requests",
+				Help:      "Number of accepted api requests per tenant and method",
+			},
+			[]string{"tenant", "method"},
+		),
+		rejected: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "api_tenant_rejected_requests",
+				Help:      "Number of rejected api requests per tenant and reason",
+			},
+			[]string{"tenant", "reason"},
+		),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.requests),
+		registerer.Register(m.rejected),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	for i := range configs {
+		cfg := &configs[i]
+
+		limit := rate.Inf
+		burst := cfg.Burst
+		if cfg.RequestsPerSecond > 0 {
+			limit = rate.Limit(cfg.RequestsPerSecond)
+			if burst == 0 {
+				burst = int(math.Ceil(cfg.RequestsPerSecond))
+			}
+		}
+
+		t := &tenant{
+			name:    cfg.Name,
+			limiter: rate.NewLimiter(limit, burst),
+		}
+		if len(cfg.AllowedMethods) > 0 {
+			t.allowedMethods = set.NewSet[string](len(cfg.AllowedMethods))
+			t.allowedMethods.Add(cfg.AllowedMethods...)
+		}
+		m.tenants[cfg.Token] = t
+	}
+
+	return m, nil
+}
+
+func (m *manager) ValidateRequest(i *rpc.RequestInfo, _ interface{}) error {
+	t, ok := m.tenants[i.Request.Header.Get(TokenHeader)]
+	if !ok {
+		m.rejected.WithLabelValues(unknownTenantLabel, rejectReasonUnknownTenant).Inc()
+		return ErrUnknownTenant
+	}
+
+	if t.allowedMethods != nil && !t.allowedMethods.Contains(i.Method) {
+		m.rejected.WithLabelValues(t.name, rejectReasonMethodNotAllowed).Inc()
+		return fmt.Errorf("%w: %s", ErrMethodNotAllowed, i.Method)
+	}
+
+	if !t.limiter.Allow() {
+		m.rejected.WithLabelValues(t.name, rejectReasonRateLimited).Inc()
+		return ErrRateLimited
+	}
+
+	m.requests.WithLabelValues(t.name, i.Method).Inc()
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tenant
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequestInfo(t *testing.T, token, method string) *rpc.RequestInfo {
+	req, err := http.NewRequest(http.MethodPost, "/", nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set(TokenHeader, token)
+	}
+	return &rpc.RequestInfo{Method: method, Request: req}
+}
+
+func TestNewManager(t *testing.T) {
+	tests := map[string]struct {
+		configs     []Config
+		expectedErr error
+	}{
+		"OK": {
+			configs: []Config{
+				{Name: "team1", Token: "token1"},
+				{Name: "team2", Token: "token2", RequestsPerSecond: 10},
+			},
+		},
+		"Empty name": {
+			configs:     []Config{{Token: "token1"}},
+			expectedErr: errEmptyName,
+		},
+		"Empty token": {
+			configs:     []Config{{Name: "team1"}},
+			expectedErr: errEmptyToken,
+		},
+		"Negative rate limit": {
+			configs:     []Config{{Name: "team1", Token: "token1", RequestsPerSecond: -1}},
+			expectedErr: errNegativeRateLimit,
+		},
+		"Negative burst": {
+			configs:     []Config{{Name: "team1", Token: "token1", Burst: -1}},
+			expectedErr: errNegativeBurst,
+		},
+		"Duplicate name": {
+			configs: []Config{
+				{Name: "team1", Token: "token1"},
+				{Name: "team1", Token: "token2"},
+			},
+			expectedErr: errDuplicateName,
+		},
+		"Duplicate token": {
+			configs: []Config{
+				{Name: "team1", Token: "token1"},
+				{Name: "team2", Token: "token1"},
+			},
+			expectedErr: errDuplicateToken,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewManager(tt.configs, "", prometheus.NewRegistry())
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestManagerValidateRequest(t *testing.T) {
+	require := require.New(t)
+
+	m, err := NewManager([]Config{
+		{
+			Name:           "team1",
+			Token:          "token1",
+			AllowedMethods: []string{"platform.getBalance"},
+		},
+		{
+			Name:              "team2",
+			Token:             "token2",
+			RequestsPerSecond: 0.001,
+			Burst:             2,
+		},
+	}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	// unknown or missing token
+	require.ErrorIs(m.ValidateRequest(newRequestInfo(t, "", "platform.getBalance"), nil), ErrUnknownTenant)
+	require.ErrorIs(m.ValidateRequest(newRequestInfo(t, "token3", "platform.getBalance"), nil), ErrUnknownTenant)
+
+	// method restrictions
+	require.NoError(m.ValidateRequest(newRequestInfo(t, "token1", "platform.getBalance"), nil))
+	require.ErrorIs(m.ValidateRequest(newRequestInfo(t, "token1", "platform.issueTx"), nil), ErrMethodNotAllowed)
+
+	// rate limit, team1 limits must not be affected by team2 requests
+	require.NoError(m.ValidateRequest(newRequestInfo(t, "token2", "platform.issueTx"), nil))
+	require.NoError(m.ValidateRequest(newRequestInfo(t, "token2", "platform.getBalance"), nil))
+	require.ErrorIs(m.ValidateRequest(newRequestInfo(t, "token2", "platform.getBalance"), nil), ErrRateLimited)
+	require.NoError(m.ValidateRequest(newRequestInfo(t, "token1", "platform.getBalance"), nil))
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package treasury
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+const (
+	// MaxImportUTXOs is max number of utxos imported by a single rewards import tx.
+	// It must be the same for all network nodes, cause rewards import tx
+	// is only valid if it imports exactly the utxos returned by ImportableUTXOs.
+	MaxImportUTXOs = 1024
+	// ImportPageSize is number of shared memory utxos read at once by ImportableUTXOs.
+	ImportPageSize = 1024
+)
+
+// ImportableUTXOs returns up to MaxImportUTXOs treasury timed utxos exported from [chainID],
+// that are old enough to be imported at [timestamp], in shared memory order.
+func ImportableUTXOs(sharedMemory atomic.SharedMemory, chainID ids.ID, timestamp uint64) ([]*avax.UTXO, error) {
+	return importableUTXOs(sharedMemory, chainID, timestamp, MaxImportUTXOs)
+}
+
+func importableUTXOs(sharedMemory atomic.SharedMemory, chainID ids.ID, timestamp uint64, maxUTXOs int) ([]*avax.UTXO, error) {
+	utxos := []*avax.UTXO{}
+	lastAddr, lastUTXOID := ids.ShortEmpty[:], ids.Empty[:]
+	for len(utxos) < maxUTXOs {
+		utxosBytes, nextAddr, nextUTXOID, err := sharedMemory.Indexed(
+			chainID,
+			AddrTraitsBytes,
+			lastAddr, lastUTXOID, ImportPageSize,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching atomic UTXOs: %w", err)
+		}
+		lastAddr, lastUTXOID = nextAddr, nextUTXOID
+
+		for _, utxoBytes := range utxosBytes {
+			utxo := &avax.TimedUTXO{}
+			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				// that means that this could be simple, not-timed utxo
+				continue
+			}
+
+			if utxo.Timestamp <= timestamp-atomic.SharedMemorySyncBound {
+				utxos = append(utxos, &utxo.UTXO)
+				if len(utxos) == maxUTXOs {
+					break
+				}
+			}
+		}
+
+		if len(utxosBytes) < ImportPageSize {
+			// there are no more utxos in shared memory
+			break
+		}
+	}
+	return utxos, nil
+}
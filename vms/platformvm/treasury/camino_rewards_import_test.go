@@ -0,0 +1,87 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package treasury
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestImportableUTXOs(t *testing.T) {
+	chainID := ids.GenerateTestID()
+	timestamp := uint64(1000)
+
+	timedUTXO := func(txID ids.ID, timestamp uint64) *avax.TimedUTXO {
+		return &avax.TimedUTXO{
+			UTXO: avax.UTXO{
+				UTXOID: avax.UTXOID{TxID: txID},
+				Asset:  avax.Asset{ID: ids.ID{1}},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          1,
+					OutputOwners: *Owner,
+				},
+			},
+			Timestamp: timestamp,
+		}
+	}
+	utxosBytes := func(t *testing.T, utxos ...*avax.TimedUTXO) [][]byte {
+		bytes := make([][]byte, len(utxos))
+		for i, utxo := range utxos {
+			utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+			require.NoError(t, err)
+			bytes[i] = utxoBytes
+		}
+		return bytes
+	}
+
+	readyUTXO1 := timedUTXO(ids.ID{1}, timestamp-atomic.SharedMemorySyncBound)
+	readyUTXO2 := timedUTXO(ids.ID{2}, timestamp-atomic.SharedMemorySyncBound)
+	readyUTXO3 := timedUTXO(ids.ID{3}, timestamp-atomic.SharedMemorySyncBound)
+	notReadyUTXO := timedUTXO(ids.ID{4}, timestamp-atomic.SharedMemorySyncBound+1)
+
+	tests := map[string]struct {
+		sharedMemory  func(*testing.T, *gomock.Controller) atomic.SharedMemory
+		maxUTXOs      int
+		expectedUTXOs []*avax.UTXO
+	}{
+		"Not ready utxos are skipped": {
+			sharedMemory: func(t *testing.T, c *gomock.Controller) atomic.SharedMemory {
+				shm := atomic.NewMockSharedMemory(c)
+				shm.EXPECT().Indexed(chainID, AddrTraitsBytes, ids.ShortEmpty[:], ids.Empty[:], ImportPageSize).
+					Return(utxosBytes(t, readyUTXO1, notReadyUTXO, readyUTXO2), nil, nil, nil)
+				return shm
+			},
+			maxUTXOs:      MaxImportUTXOs,
+			expectedUTXOs: []*avax.UTXO{&readyUTXO1.UTXO, &readyUTXO2.UTXO},
+		},
+		"Limited by max utxos": {
+			sharedMemory: func(t *testing.T, c *gomock.Controller) atomic.SharedMemory {
+				shm := atomic.NewMockSharedMemory(c)
+				shm.EXPECT().Indexed(chainID, AddrTraitsBytes, ids.ShortEmpty[:], ids.Empty[:], ImportPageSize).
+					Return(utxosBytes(t, readyUTXO1, readyUTXO2, readyUTXO3), nil, nil, nil)
+				return shm
+			},
+			maxUTXOs:      2,
+			expectedUTXOs: []*avax.UTXO{&readyUTXO1.UTXO, &readyUTXO2.UTXO},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			utxos, err := importableUTXOs(tt.sharedMemory(t, ctrl), chainID, timestamp, tt.maxUTXOs)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedUTXOs, utxos)
+		})
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errTooManyInputs = errors.New("tx has more inputs than allowed by build context")
+	errTxFeeTooLow   = errors.New("build context tx fee is lower than required tx fee")
+	errNoChangeOwner = errors.New("canonical change requires explicit change owner")
+)
+
+// BuildContext allows advanced callers to control how camino txs are built.
+// Zero-value BuildContext means default builder behavior.
+type BuildContext struct {
+	// Max number of tx inputs, zero means unlimited
+	MaxInputs int
+	// Unix timestamp against which utxos locktime is compared,
+	// zero means current time
+	AsOf uint64
+	// Unlocked AVAX change outputs with lesser amount will be burned
+	// together with tx fee, zero means node configured min change amount
+	MinChangeAmount uint64
+	// Fee that will be burned instead of default tx fee, zero means default fee.
+	// Must not be lower than default fee.
+	TxFee uint64
+	// If true, all unlocked change is merged into single output owned by
+	// explicitly provided change owner. Together with fixed [AsOf], this allows
+	// parties that build the same tx independently to get byte-identical txs.
+	CanonicalChange bool
+	// If not empty, outputs newly locked by tx are labeled with it,
+	// e.g. "bond:node-7". Label is preserved, while funds stay locked.
+	LockLabel string
+}
+
+// txFee returns fee that must be burned by tx with [defaultFee]
+func (b *caminoBuilder) txFee(defaultFee uint64) (uint64, error) {
+	if b.buildCtx.TxFee == 0 {
+		return defaultFee, nil
+	}
+	if b.buildCtx.TxFee < defaultFee {
+		return 0, fmt.Errorf("%w: %d < %d", errTxFeeTooLow, b.buildCtx.TxFee, defaultFee)
+	}
+	return b.buildCtx.TxFee, nil
+}
+
+// lock calls Spender.Lock, respecting builder context: [defaultFee] could be overridden,
+// utxos locktime is compared against context timestamp and small change is burned
+func (b *caminoBuilder) lock(
+	keys []*crypto.PrivateKeySECP256K1R,
+	totalAmountToLock uint64,
+	defaultFee uint64,
+	appliedLockState locked.State,
+	change *secp256k1fx.OutputOwners,
+) (
+	[]*avax.TransferableInput,
+	[]*avax.TransferableOutput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	return b.lockTo(keys, totalAmountToLock, defaultFee, appliedLockState, nil, change)
+}
+
+// lockTo is the same as lock, but unlocked [totalAmountToLock] is transferred to [to] owner.
+// Outputs owned by [to] are never burned as small change.
+func (b *caminoBuilder) lockTo(
+	keys []*crypto.PrivateKeySECP256K1R,
+	totalAmountToLock uint64,
+	defaultFee uint64,
+	appliedLockState locked.State,
+	to *secp256k1fx.OutputOwners,
+	change *secp256k1fx.OutputOwners,
+) (
+	[]*avax.TransferableInput,
+	[]*avax.TransferableOutput,
+	[][]*crypto.PrivateKeySECP256K1R,
+	error,
+) {
+	fee, err := b.txFee(defaultFee)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if b.buildCtx.CanonicalChange && change == nil {
+		return nil, nil, nil, errNoChangeOwner
+	}
+
+	start := time.Now()
+	ins, outs, signers, _, err := b.Lock(
+		keys,
+		totalAmountToLock,
+		fee,
+		appliedLockState,
+		to,
+		change,
+		b.buildCtx.MaxInputs,
+		b.buildCtx.AsOf,
+		b.buildCtx.MinChangeAmount,
+	)
+	if err != nil {
+		b.metrics.markFailed(buildStepSpend)
+		return nil, nil, nil, err
+	}
+	if err := b.verifyLockBalance(ins, outs, fee, appliedLockState); err != nil {
+		b.metrics.markFailed(buildStepSpend)
+		return nil, nil, nil, err
+	}
+	b.metrics.observeLock(len(ins), len(outs), time.Since(start))
+
+	if b.buildCtx.LockLabel != "" {
+		labelNewlyLocked(outs, appliedLockState, b.buildCtx.LockLabel)
+	}
+
+	if b.buildCtx.CanonicalChange {
+		if outs, err = mergeChange(outs, change); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return ins, outs, signers, nil
+}
+
+// verifyLockBalance verifies that [ins] and [outs] produced by Lock satisfy
+// the same lock invariants, that are verified by tx executor flow check
+func (b *caminoBuilder) verifyLockBalance(
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	fee uint64,
+	appliedLockState locked.State,
+) error {
+	now := b.buildCtx.AsOf
+	if now == 0 {
+		now = b.clk.Unix()
+	}
+	utxos := make([]*avax.UTXO, len(ins))
+	for i, in := range ins {
+		consumedUTXO, err := b.state.GetUTXO(in.InputID())
+		if err != nil {
+			return fmt.Errorf("couldn't get consumed utxo %s: %w", in.InputID(), err)
+		}
+		// expired stakeable locked outs are spent as unlocked
+		if stakeableOut, ok := consumedUTXO.Out.(*stakeable.LockOut); ok && stakeableOut.Locktime <= now {
+			consumedUTXO = &avax.UTXO{
+				UTXOID: consumedUTXO.UTXOID,
+				Asset:  consumedUTXO.Asset,
+				Out:    stakeableOut.TransferableOut,
+			}
+		}
+		utxos[i] = consumedUTXO
+	}
+	return utxo.VerifyLockBalance(utxos, ins, outs, fee, b.ctx.AVAXAssetID, appliedLockState)
+}
+
+// verifyBuildContext returns nil if [utx] satisfies builder context limits
+func (b *caminoBuilder) verifyBuildContext(utx txs.UnsignedTx) error {
+	if b.buildCtx.MaxInputs > 0 && utx.InputIDs().Len() > b.buildCtx.MaxInputs {
+		return fmt.Errorf("%w: %d > %d", errTooManyInputs, utx.InputIDs().Len(), b.buildCtx.MaxInputs)
+	}
+	return nil
+}
+
+// labelNewlyLocked labels [outs] newly locked with [appliedLockState]
+// and sorts [outs] again, cause labels change outputs bytes
+func labelNewlyLocked(outs []*avax.TransferableOutput, appliedLockState locked.State, label string) {
+	for _, out := range outs {
+		if lockedOut, ok := out.Out.(*locked.Out); ok && lockedOut.IsNewlyLockedWith(appliedLockState) {
+			out.Out = locked.WithLabel(lockedOut, label)
+		}
+	}
+	avax.SortTransferableOutputs(outs, txs.Codec)
+}
+
+// mergeChange merges all unlocked outputs owned by [change] into single output
+// and returns canonically sorted outputs
+func mergeChange(
+	outs []*avax.TransferableOutput,
+	change *secp256k1fx.OutputOwners,
+) ([]*avax.TransferableOutput, error) {
+	var changeOut *secp256k1fx.TransferOutput
+	mergedOuts := make([]*avax.TransferableOutput, 0, len(outs))
+	for _, out := range outs {
+		transferOut, ok := out.Out.(*secp256k1fx.TransferOutput)
+		if !ok || !transferOut.OutputOwners.Equals(change) {
+			mergedOuts = append(mergedOuts, out)
+			continue
+		}
+		if changeOut == nil {
+			changeOut = &secp256k1fx.TransferOutput{
+				Amt:          transferOut.Amt,
+				OutputOwners: transferOut.OutputOwners,
+			}
+			mergedOuts = append(mergedOuts, &avax.TransferableOutput{
+				Asset: out.Asset,
+				Out:   changeOut,
+			})
+			continue
+		}
+		newAmount, err := math.Add64(changeOut.Amt, transferOut.Amt)
+		if err != nil {
+			return nil, err
+		}
+		changeOut.Amt = newAmount
+	}
+	avax.SortTransferableOutputs(mergedOuts, txs.Codec)
+	return mergedOuts, nil
+}
@@ -4,20 +4,30 @@
 package builder
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 
-	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
-	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
@@ -25,58 +35,195 @@ import (
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
+// Default max size of system unlock deposit tx
+const defaultMaxSystemUnlockDepositTxSize = 64 * units.KiB
+
 var (
 	_ CaminoBuilder = (*caminoBuilder)(nil)
 
 	fakeTreasuryKey      = crypto.FakePrivateKey(treasury.Addr)
 	fakeTreasuryKeychain = secp256k1fx.NewKeychain(fakeTreasuryKey)
 
-	errKeyMissing       = errors.New("couldn't find key matching address")
-	errWrongNodeKeyType = errors.New("node key type isn't *crypto.PrivateKeySECP256K1R")
-	errTxIsNotCommitted = errors.New("tx is not committed")
-	errNotSECPOwner     = errors.New("owner is not *secp256k1fx.OutputOwners")
-	errWrongTxType      = errors.New("wrong transaction type")
-	errWrongLockMode    = errors.New("this tx can't be used with this caminoGenesis.LockModeBondDeposit")
-	errNoUTXOsForImport = errors.New("no utxos for import")
+	errKeyMissing           = errors.New("couldn't find key matching address")
+	errWrongNodeKeyType     = errors.New("node key type isn't *crypto.PrivateKeySECP256K1R")
+	errWrongLockMode        = errors.New("this tx can't be used with this caminoGenesis.LockModeBondDeposit")
+	errNoUTXOsForImport     = errors.New("no utxos for import")
+	errOfferOwnerKeyMissing = errors.New("couldn't sign for deposit offer owner")
+	errNodeSignerFailed     = errors.New("node signer failed to sign tx")
+	errZeroTransferAmount   = errors.New("transfer amount is zero")
+	errNoTransferOwner      = errors.New("transfer owner is empty")
+	errWrongSplitAmount     = errors.New("split amount must be positive and less than deposit amount")
+	errWrongInType          = errors.New("wrong input type")
+
+	errUnexpectedUnlockChunks = errors.New("unexpected number of unlock chunks")
+
+	errInsufficientDepositedFunds = errors.New("insufficient deposited funds owned by keys")
 )
 
+// NodeSigner returns signature of unsigned tx [hash] made with node private key
+type NodeSigner func(hash []byte) ([]byte, error)
+
 type CaminoBuilder interface {
 	Builder
 	CaminoTxBuilder
+	CaminoUnsignedTxBuilder
 	utxo.Spender
+
+	// WithBuildContext returns copy of this builder,
+	// that will build txs respecting [buildCtx]
+	WithBuildContext(buildCtx BuildContext) CaminoBuilder
+
+	// Preview returns what [utx] will consume, produce and burn
+	// if it will be executed, without signing or issuing it
+	Preview(utx txs.UnsignedTx) (*TxPreview, error)
+
+	// BestDepositOffer returns active unrestricted deposit offer, that admits deposit
+	// of [amount] for [duration] and gives the biggest total reward for it
+	BestDepositOffer(amount uint64, duration uint32) (*deposit.Offer, error)
 }
 
 type CaminoTxBuilder interface {
 	NewAddressStateTx(
 		address ids.ShortID,
 		remove bool,
-		state uint8,
+		state as.AddressStateBit,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewAddressStateBatchTx creates tx that atomically applies [ops] to [address] states
+	NewAddressStateBatchTx(
+		address ids.ShortID,
+		ops []txs.AddressStateOp,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewBaseTx creates tx that transfers unlocked [amount] to [to] owner
+	NewBaseTx(
+		amount uint64,
+		to *secp256k1fx.OutputOwners,
 		keys []*crypto.PrivateKeySECP256K1R,
 		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewConsolidateUTXOsTx merges unlocked utxos of one of [keys] owners
+	// into [targetCount] outputs. Locked utxos are not touched.
+	NewConsolidateUTXOsTx(
+		keys []*crypto.PrivateKeySECP256K1R,
+		targetCount int,
+		memo []byte,
 	) (*txs.Tx, error)
 
+	// NewDepositTx creates deposit tx. If deposit offer is restricted,
+	// [offerOwnerKeys] must be able to sign for offer owner address.
+	// If [depositOfferID] is empty, the best offer for [amount] and [duration]
+	// is selected automatically, chosen offer id is set in the returned tx.
 	NewDepositTx(
 		amount uint64,
 		duration uint32,
 		depositOfferID ids.ID,
 		rewardAddress ids.ShortID,
 		keys []*crypto.PrivateKeySECP256K1R,
+		offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
 		change *secp256k1fx.OutputOwners,
+		memo []byte,
 	) (*txs.Tx, error)
 
+	// NewUnlockDepositTx unlocks [amountsToUnlock] (map[depositTxID]amount) of deposits,
+	// zero amount means that all currently unlockable amount of deposit will be unlocked
 	NewUnlockDepositTx(
-		lockTxIDs []ids.ID,
+		amountsToUnlock map[ids.ID]uint64,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+	) (*txs.Tx, error)
+
+	// NewRenewDepositTx creates tx that closes expired deposit [depositTxID] and deposits
+	// its remaining amount together with unlocked [additionalAmount] into new deposit.
+	// Offer selection and [offerOwnerKeys] are the same as for NewDepositTx.
+	NewRenewDepositTx(
+		depositTxID ids.ID,
+		additionalAmount uint64,
+		duration uint32,
+		depositOfferID ids.ID,
+		rewardAddress ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewDepositRewardsOwnerTx creates tx that changes rewards owner of deposit [depositTxID]
+	// to [rewardsOwner]. [keys] must contain keys of current deposit rewards owner.
+	NewDepositRewardsOwnerTx(
+		depositTxID ids.ID,
+		rewardsOwner *secp256k1fx.OutputOwners,
 		keys []*crypto.PrivateKeySECP256K1R,
 		change *secp256k1fx.OutputOwners,
+		memo []byte,
 	) (*txs.Tx, error)
 
+	// NewSplitDepositTx creates tx that moves [splitAmount] of deposit [depositTxID]
+	// principal into new deposit with the same offer and schedule, rewards of which
+	// will be owned by [rewardsOwner]. [keys] must contain keys of deposited utxos owners
+	// and of current deposit rewards owner.
+	NewSplitDepositTx(
+		depositTxID ids.ID,
+		splitAmount uint64,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewMultisigAliasTx creates tx that creates new multisig alias, if [aliasID] is empty,
+	// or changes owners and memo of existing alias [aliasID] to [owners] and [aliasMemo].
+	// If alias is changed, [keys] must contain keys of current alias owners.
+	NewMultisigAliasTx(
+		aliasID ids.ShortID,
+		owners verify.State,
+		aliasMemo []byte,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewRotateMultisigAliasTx creates tx that retires multisig alias [retiredAliasID]
+	// and creates its successor alias with [owners] and [aliasMemo].
+	// [keys] must contain keys of retired alias owners.
+	NewRotateMultisigAliasTx(
+		retiredAliasID ids.ShortID,
+		owners verify.State,
+		aliasMemo []byte,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewDepositOfferTx creates tx that creates new deposit offer [offer], if its id is empty,
+	// or updates existing offer with [offer] id. Updated offer version must match its current version.
+	// [keys] must contain keys of [adminAddress], which must have admin role.
+	NewDepositOfferTx(
+		offer *deposit.Offer,
+		adminAddress ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewClaimTx creates tx that claims rewards described by [claimRequests].
+	// If [claimDeposit] isn't nil, claimed rewards are deposited by the same tx
+	// as described by [claimDeposit]. If its offer is restricted, offer owner must be one of [keys].
 	NewClaimTx(
-		depositTxIDs []ids.ID,
-		claimableOwnerIDs []ids.ID,
-		amountToClaim []uint64,
+		claimRequests []ClaimRequest,
+		claimDeposit *ClaimDeposit,
 		claimTo *secp256k1fx.OutputOwners,
 		keys []*crypto.PrivateKeySECP256K1R,
 		change *secp256k1fx.OutputOwners,
+		memo []byte,
 	) (*txs.Tx, error)
 
 	NewRegisterNodeTx(
@@ -85,13 +232,171 @@ type CaminoTxBuilder interface {
 		ConsortiumMemberAddress ids.ShortID,
 		keys []*crypto.PrivateKeySECP256K1R,
 		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (*txs.Tx, error)
+
+	// NewRegisterNodeTxWithNodeSigner is the same as NewRegisterNodeTx,
+	// but node signature is provided by [nodeSigner], so node private key
+	// doesn't need to be present on the machine where tx is built
+	NewRegisterNodeTxWithNodeSigner(
+		OldNodeID ids.NodeID,
+		NewNodeID ids.NodeID,
+		ConsortiumMemberAddress ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		nodeSigner NodeSigner,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
 	) (*txs.Tx, error)
 
 	NewRewardsImportTx() (*txs.Tx, error)
 
+	// NewSystemUnlockDepositTx returns system txs that unlock [depositTxIDs].
+	// Deposits are split between multiple txs, so that
+	// each tx size doesn't exceed configured limit.
 	NewSystemUnlockDepositTx(
 		depositTxIDs []ids.ID,
-	) (*txs.Tx, error)
+	) ([]*txs.Tx, error)
+}
+
+// CaminoUnsignedTxBuilder builds camino txs without private keys.
+// It only requires addresses of funds owners ([from]) and optional multisig
+// alias [signers]. Built txs have no credentials. Returned signing manifest
+// describes which addresses must sign which credential of the tx.
+type CaminoUnsignedTxBuilder interface {
+	NewUnsignedAddressStateTx(
+		address ids.ShortID,
+		remove bool,
+		state as.AddressStateBit,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedAddressStateBatchTx(
+		address ids.ShortID,
+		ops []txs.AddressStateOp,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedBaseTx(
+		amount uint64,
+		to *secp256k1fx.OutputOwners,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedConsolidateUTXOsTx(
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		targetCount int,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedDepositTx(
+		amount uint64,
+		duration uint32,
+		depositOfferID ids.ID,
+		rewardAddress ids.ShortID,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		offerOwnerSigners []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedUnlockDepositTx(
+		amountsToUnlock map[ids.ID]uint64,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedRenewDepositTx(
+		depositTxID ids.ID,
+		additionalAmount uint64,
+		duration uint32,
+		depositOfferID ids.ID,
+		rewardAddress ids.ShortID,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		offerOwnerSigners []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedDepositRewardsOwnerTx(
+		depositTxID ids.ID,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedSplitDepositTx(
+		depositTxID ids.ID,
+		splitAmount uint64,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedMultisigAliasTx(
+		aliasID ids.ShortID,
+		owners verify.State,
+		aliasMemo []byte,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedRotateMultisigAliasTx(
+		retiredAliasID ids.ShortID,
+		owners verify.State,
+		aliasMemo []byte,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedDepositOfferTx(
+		offer *deposit.Offer,
+		adminAddress ids.ShortID,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedClaimTx(
+		claimRequests []ClaimRequest,
+		claimDeposit *ClaimDeposit,
+		claimTo *secp256k1fx.OutputOwners,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
+
+	NewUnsignedRegisterNodeTx(
+		oldNodeID ids.NodeID,
+		newNodeID ids.NodeID,
+		consortiumMemberAddress ids.ShortID,
+		from []ids.ShortID,
+		signers []ids.ShortID,
+		change *secp256k1fx.OutputOwners,
+		memo []byte,
+	) (txs.UnsignedTx, *txs.SigningManifest, error)
 }
 
 func NewCamino(
@@ -102,7 +407,13 @@ func NewCamino(
 	state state.Chain,
 	atomicUTXOManager avax.AtomicUTXOManager,
 	utxoSpender utxo.Spender,
-) CaminoBuilder {
+	namespace string,
+	registerer prometheus.Registerer,
+) (CaminoBuilder, error) {
+	metrics, err := newBuilderMetrics(namespace, registerer)
+	if err != nil {
+		return nil, err
+	}
 	return &caminoBuilder{
 		builder: builder{
 			AtomicUTXOManager: atomicUTXOManager,
@@ -113,11 +424,22 @@ func NewCamino(
 			clk:               clk,
 			fx:                fx,
 		},
-	}
+		metrics: metrics,
+	}, nil
 }
 
 type caminoBuilder struct {
 	builder
+	buildCtx BuildContext
+	metrics  *builderMetrics
+}
+
+func (b *caminoBuilder) WithBuildContext(buildCtx BuildContext) CaminoBuilder {
+	return &caminoBuilder{
+		builder:  b.builder,
+		buildCtx: buildCtx,
+		metrics:  b.metrics,
+	}
 }
 
 func (b *caminoBuilder) NewAddValidatorTx(
@@ -130,6 +452,7 @@ func (b *caminoBuilder) NewAddValidatorTx(
 	keys []*crypto.PrivateKeySECP256K1R,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
+	start := time.Now()
 	caminoGenesis, err := b.state.CaminoConfig()
 	if err != nil {
 		return nil, err
@@ -148,18 +471,16 @@ func (b *caminoBuilder) NewAddValidatorTx(
 		)
 	}
 
-	ins, outs, signers, _, err := b.Lock(
+	ins, outs, signers, err := b.lock(
 		keys,
 		stakeAmount,
 		b.cfg.AddPrimaryNetworkValidatorFee,
 		locked.StateBonded,
-		nil,
 		&secp256k1fx.OutputOwners{
 			Locktime:  0,
 			Threshold: 1,
 			Addrs:     []ids.ShortID{changeAddr},
 		},
-		0,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
@@ -187,11 +508,7 @@ func (b *caminoBuilder) NewAddValidatorTx(
 		},
 	}
 
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.sign(start, utx, signers)
 }
 
 func (b *caminoBuilder) NewAddSubnetValidatorTx(
@@ -235,40 +552,79 @@ func (b *caminoBuilder) NewAddSubnetValidatorTx(
 }
 
 func (b *caminoBuilder) NewRewardValidatorTx(txID ids.ID) (*txs.Tx, error) {
+	start := time.Now()
 	if state, err := b.state.CaminoConfig(); err != nil {
 		return nil, err
 	} else if !state.LockModeBondDeposit {
 		return b.builder.NewRewardValidatorTx(txID)
 	}
 
-	ins, outs, err := b.Unlock(b.state, []ids.ID{txID}, locked.StateBonded)
+	// single lock tx is never split, so there is exactly one chunk
+	chunks, err := b.UnlockChunks(b.state, []ids.ID{txID}, locked.StateBonded, 0, 0)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
+	if len(chunks) != 1 {
+		return nil, fmt.Errorf("%w: expected 1 unlock chunk, got %d", errUnexpectedUnlockChunks, len(chunks))
+	}
 
 	utx := &txs.CaminoRewardValidatorTx{
 		RewardValidatorTx: txs.RewardValidatorTx{TxID: txID},
-		Ins:               ins,
-		Outs:              outs,
+		Ins:               chunks[0].Ins,
+		Outs:              chunks[0].Outs,
 	}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
 	if err != nil {
+		b.metrics.markFailed(buildStepSign)
 		return nil, err
 	}
-
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.verified(start, tx)
 }
 
 func (b *caminoBuilder) NewAddressStateTx(
 	address ids.ShortID,
 	remove bool,
-	state uint8,
+	state as.AddressStateBit,
 	keys []*crypto.PrivateKeySECP256K1R,
 	change *secp256k1fx.OutputOwners,
+	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, signers, _, err := b.Lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, nil, change, 0)
+	start := time.Now()
+	utx, signers, err := b.newAddressStateTx(address, remove, state, keys, change, memo)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedAddressStateTx(
+	address ids.ShortID,
+	remove bool,
+	state as.AddressStateBit,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newAddressStateTx(address, remove, state, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newAddressStateTx(
+	address ids.ShortID,
+	remove bool,
+	state as.AddressStateBit,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.AddressStateTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
 	// Create the tx
@@ -278,166 +634,1188 @@ func (b *caminoBuilder) NewAddressStateTx(
 			BlockchainID: b.ctx.ChainID,
 			Ins:          ins,
 			Outs:         outs,
+			Memo:         memo,
 		}},
 		Address: address,
 		Remove:  remove,
 		State:   state,
 	}
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-
-	return tx, tx.SyntacticVerify(b.ctx)
+	return utx, signers, nil
 }
 
-func (b *caminoBuilder) NewDepositTx(
-	amount uint64,
-	duration uint32,
-	depositOfferID ids.ID,
-	rewardAddress ids.ShortID,
+func (b *caminoBuilder) NewAddressStateBatchTx(
+	address ids.ShortID,
+	ops []txs.AddressStateOp,
 	keys []*crypto.PrivateKeySECP256K1R,
 	change *secp256k1fx.OutputOwners,
+	memo []byte,
 ) (*txs.Tx, error) {
-	caminoGenesis, err := b.state.CaminoConfig()
+	start := time.Now()
+	utx, signers, err := b.newAddressStateBatchTx(address, ops, keys, change, memo)
 	if err != nil {
 		return nil, err
 	}
-	if !caminoGenesis.LockModeBondDeposit {
-		return nil, errWrongLockMode
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedAddressStateBatchTx(
+	address ids.ShortID,
+	ops []txs.AddressStateOp,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newAddressStateBatchTx(address, ops, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
 	}
+	return b.unsigned(start, utx, txSigners)
+}
 
-	ins, outs, signers, _, err := b.Lock(keys, amount, b.cfg.TxFee, locked.StateDeposited, nil, change, 0)
+func (b *caminoBuilder) newAddressStateBatchTx(
+	address ids.ShortID,
+	ops []txs.AddressStateOp,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.AddressStateBatchTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	utx := &txs.DepositTx{
+	utx := &txs.AddressStateBatchTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    b.ctx.NetworkID,
 			BlockchainID: b.ctx.ChainID,
 			Ins:          ins,
 			Outs:         outs,
+			Memo:         memo,
 		}},
-		DepositOfferID:  depositOfferID,
-		DepositDuration: duration,
-		RewardsOwner: &secp256k1fx.OutputOwners{
-			Locktime:  0,
-			Threshold: 1,
-			Addrs:     []ids.ShortID{rewardAddress},
-		},
+		Address: address,
+		Ops:     ops,
 	}
+	return utx, signers, nil
+}
 
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+func (b *caminoBuilder) NewBaseTx(
+	amount uint64,
+	to *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newBaseTx(amount, to, keys, change, memo)
 	if err != nil {
 		return nil, err
 	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.sign(start, utx, signers)
 }
 
-func (b *caminoBuilder) NewUnlockDepositTx(
-	lockTxIDs []ids.ID,
+func (b *caminoBuilder) NewUnsignedBaseTx(
+	amount uint64,
+	to *secp256k1fx.OutputOwners,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newBaseTx(amount, to, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newBaseTx(
+	amount uint64,
+	to *secp256k1fx.OutputOwners,
 	keys []*crypto.PrivateKeySECP256K1R,
 	change *secp256k1fx.OutputOwners,
-) (*txs.Tx, error) {
+	memo []byte,
+) (*txs.BaseTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	if amount == 0 {
+		return nil, nil, errZeroTransferAmount
+	}
+	if to == nil {
+		return nil, nil, errNoTransferOwner
+	}
+
 	caminoGenesis, err := b.state.CaminoConfig()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if !caminoGenesis.LockModeBondDeposit {
-		return nil, errWrongLockMode
+		return nil, nil, errWrongLockMode
 	}
 
-	// unlocking
-	ins, outs, signers, err := b.UnlockDeposit(b.state, keys, lockTxIDs)
+	// only unlocked utxos will be transferred, locked utxos will stay locked
+	ins, outs, signers, err := b.lockTo(keys, amount, b.cfg.TxFee, locked.StateUnlocked, to, change)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	// burning fee
-	feeIns, feeOuts, feeSigners, _, err := b.Lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, nil, change, 0)
+	utx := &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.ctx.NetworkID,
+		BlockchainID: b.ctx.ChainID,
+		Ins:          ins,
+		Outs:         outs,
+		Memo:         memo,
+	}}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewConsolidateUTXOsTx(
+	keys []*crypto.PrivateKeySECP256K1R,
+	targetCount int,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newConsolidateUTXOsTx(keys, targetCount, memo)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
 	}
+	return b.sign(start, utx, signers)
+}
 
-	ins = append(ins, feeIns...)
-	outs = append(outs, feeOuts...)
-	signers = append(signers, feeSigners...)
+func (b *caminoBuilder) NewUnsignedConsolidateUTXOsTx(
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	targetCount int,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newConsolidateUTXOsTx(fakeKeys(from, signers), targetCount, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
 
-	// we need to sort ins/outs/signers before using them in tx
-	// UnlockDeposit returns unsorted results and we appended arrays
-	avax.SortTransferableInputsWithSigners(ins, signers)
-	avax.SortTransferableOutputs(outs, txs.Codec)
+func (b *caminoBuilder) newConsolidateUTXOsTx(
+	keys []*crypto.PrivateKeySECP256K1R,
+	targetCount int,
+	memo []byte,
+) (*txs.BaseTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
 
-	utx := &txs.UnlockDepositTx{
-		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
-			NetworkID:    b.ctx.NetworkID,
-			BlockchainID: b.ctx.ChainID,
-			Ins:          ins,
-			Outs:         outs,
-		}},
+	fee, err := b.txFee(b.cfg.TxFee)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	ins, outs, signers, err := b.Consolidate(keys, targetCount, b.buildCtx.MaxInputs, fee, b.buildCtx.AsOf)
 	if err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-	return tx, tx.SyntacticVerify(b.ctx)
+
+	utx := &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    b.ctx.NetworkID,
+		BlockchainID: b.ctx.ChainID,
+		Ins:          ins,
+		Outs:         outs,
+		Memo:         memo,
+	}}
+	return utx, signers, nil
 }
 
-func (b *caminoBuilder) NewClaimTx(
-	depositTxIDs []ids.ID,
-	claimableOwnerIDs []ids.ID,
-	amountToClaim []uint64,
-	claimTo *secp256k1fx.OutputOwners,
+func (b *caminoBuilder) NewDepositTx(
+	amount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
 	keys []*crypto.PrivateKeySECP256K1R,
+	offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
 	change *secp256k1fx.OutputOwners,
+	memo []byte,
 ) (*txs.Tx, error) {
-	caminoGenesis, err := b.state.CaminoConfig()
+	start := time.Now()
+	utx, signers, err := b.newDepositTx(amount, duration, depositOfferID, rewardAddress, keys, offerOwnerKeys, change, memo)
 	if err != nil {
 		return nil, err
 	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedDepositTx(
+	amount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	offerOwnerSigners []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newDepositTx(amount, duration, depositOfferID, rewardAddress,
+		fakeKeys(from, signers), fakeKeys(offerOwnerSigners, nil), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newDepositTx(
+	amount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.DepositTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
 	if !caminoGenesis.LockModeBondDeposit {
-		return nil, errWrongLockMode
+		return nil, nil, errWrongLockMode
 	}
 
-	ins, outs, signers, _, err := b.Lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, nil, change, 0)
+	ins, outs, signers, err := b.lock(keys, amount, b.cfg.TxFee, locked.StateDeposited, change)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain(keys...)
-	claimableSignersKC := secp256k1fx.NewKeychain()
+	depositOffer, offerOwnerSigners, err := b.depositOfferWithSigners(amount, duration, depositOfferID, offerOwnerKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offerOwnerSigners != nil {
+		signers = append(signers, offerOwnerSigners)
+	}
 
-	for _, depositTxID := range depositTxIDs {
-		depositRewardsOwner, err := getDepositRewardsOwner(b.state, depositTxID)
-		if err != nil {
-			return nil, err
-		}
+	utx := &txs.DepositTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		DepositOfferID:  depositOffer.ID,
+		DepositDuration: duration,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+	}
+	return utx, signers, nil
+}
+
+// depositOfferWithSigners returns deposit offer with [depositOfferID] or the best offer
+// for [amount] and [duration], if [depositOfferID] is empty. If offer is restricted,
+// it also returns offer owner signers from [offerOwnerKeys].
+func (b *caminoBuilder) depositOfferWithSigners(
+	amount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
+) (*deposit.Offer, []*crypto.PrivateKeySECP256K1R, error) {
+	var depositOffer *deposit.Offer
+	var err error
+	if depositOfferID == ids.Empty {
+		if depositOffer, err = b.BestDepositOffer(amount, duration); err != nil {
+			return nil, nil, err
+		}
+	} else if depositOffer, err = b.state.GetDepositOffer(depositOfferID); err != nil {
+		return nil, nil, err
+	}
+
+	if !depositOffer.IsRestricted() {
+		return depositOffer, nil, nil
+	}
+
+	// restricted offer requires additional offer owner credential
+	_, offerOwnerSigners, err := secp256k1fx.NewKeychain(offerOwnerKeys...).SpendMultiSig(
+		&secp256k1fx.TransferOutput{
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{depositOffer.OwnerAddress},
+			},
+		},
+		0,
+		b.state,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errOfferOwnerKeyMissing, err)
+	}
+	return depositOffer, offerOwnerSigners, nil
+}
+
+func (b *caminoBuilder) NewRenewDepositTx(
+	depositTxID ids.ID,
+	additionalAmount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newRenewDepositTx(depositTxID, additionalAmount, duration,
+		depositOfferID, rewardAddress, keys, offerOwnerKeys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedRenewDepositTx(
+	depositTxID ids.ID,
+	additionalAmount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	offerOwnerSigners []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newRenewDepositTx(depositTxID, additionalAmount, duration, depositOfferID,
+		rewardAddress, fakeKeys(from, signers), fakeKeys(offerOwnerSigners, nil), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newRenewDepositTx(
+	depositTxID ids.ID,
+	additionalAmount uint64,
+	duration uint32,
+	depositOfferID ids.ID,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	offerOwnerKeys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.RenewDepositTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
+
+	oldDeposit, err := b.state.GetDeposit(depositTxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// consuming renewed deposit utxos and depositing them again
+	ins, outs, signers, err := b.Relock(
+		b.state,
+		keys,
+		[]ids.ID{depositTxID},
+		locked.StateDeposited,
+		locked.StateDeposited,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// depositing additional amount and burning fee
+	lockState := locked.StateUnlocked
+	if additionalAmount > 0 {
+		lockState = locked.StateDeposited
+	}
+	feeIns, feeOuts, feeSigners, err := b.lock(keys, additionalAmount, b.cfg.TxFee, lockState, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	ins = append(ins, feeIns...)
+	outs = append(outs, feeOuts...)
+	signers = append(signers, feeSigners...)
+
+	// we need to sort ins/outs/signers before using them in tx,
+	// because we appended arrays
+	avax.SortTransferableInputsWithSigners(ins, signers)
+	avax.SortTransferableOutputs(outs, txs.Codec)
+
+	depositAmount, err := math.Add64(oldDeposit.Amount-oldDeposit.UnlockedAmount, additionalAmount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	depositOffer, offerOwnerSigners, err := b.depositOfferWithSigners(depositAmount, duration, depositOfferID, offerOwnerKeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	if offerOwnerSigners != nil {
+		signers = append(signers, offerOwnerSigners)
+	}
+
+	utx := &txs.RenewDepositTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		DepositTxID:     depositTxID,
+		DepositOfferID:  depositOffer.ID,
+		DepositDuration: duration,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewDepositRewardsOwnerTx(
+	depositTxID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newDepositRewardsOwnerTx(depositTxID, rewardsOwner, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedDepositRewardsOwnerTx(
+	depositTxID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newDepositRewardsOwnerTx(depositTxID, rewardsOwner, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newDepositRewardsOwnerTx(
+	depositTxID ids.ID,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.DepositRewardsOwnerTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
+
+	deposit, err := b.state.GetDeposit(depositTxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	currentRewardsOwner, err := state.DepositRewardsOwner(b.state, depositTxID, deposit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// current rewards owner credential must be the last one
+	rewardsOwnerSigners, err := ownerSigners(secp256k1fx.NewKeychain(keys...), currentRewardsOwner, b.clk.Unix(), b.state)
+	if err != nil {
+		return nil, nil, err
+	}
+	signers = append(signers, rewardsOwnerSigners)
+
+	utx := &txs.DepositRewardsOwnerTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		DepositTxID:  depositTxID,
+		RewardsOwner: rewardsOwner,
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewMultisigAliasTx(
+	aliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newMultisigAliasTx(aliasID, owners, aliasMemo, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedMultisigAliasTx(
+	aliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newMultisigAliasTx(aliasID, owners, aliasMemo, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newMultisigAliasTx(
+	aliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.MultisigAliasTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	changeAuth := &secp256k1fx.Input{}
+	if aliasID != ids.ShortEmpty {
+		// current alias owners credential must be the last one
+		var aliasSigners []*crypto.PrivateKeySECP256K1R
+		changeAuth, aliasSigners, err = b.aliasAuth(aliasID, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+		signers = append(signers, aliasSigners)
+	}
+
+	utx := &txs.MultisigAliasTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		MultisigAlias: multisig.Alias{
+			ID:     aliasID,
+			Memo:   aliasMemo,
+			Owners: owners,
+		},
+		ChangeAuth: changeAuth,
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewRotateMultisigAliasTx(
+	retiredAliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newRotateMultisigAliasTx(retiredAliasID, owners, aliasMemo, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedRotateMultisigAliasTx(
+	retiredAliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newRotateMultisigAliasTx(retiredAliasID, owners, aliasMemo, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newRotateMultisigAliasTx(
+	retiredAliasID ids.ShortID,
+	owners verify.State,
+	aliasMemo []byte,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.RotateMultisigAliasTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// retired alias owners credential must be the last one
+	retiredAliasAuth, aliasSigners, err := b.aliasAuth(retiredAliasID, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	signers = append(signers, aliasSigners)
+
+	utx := &txs.RotateMultisigAliasTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		RetiredAliasID: retiredAliasID,
+		Successor: multisig.Alias{
+			Memo:   aliasMemo,
+			Owners: owners,
+		},
+		RetiredAliasAuth: retiredAliasAuth,
+	}
+	return utx, signers, nil
+}
+
+// aliasAuth returns auth input and signers from [keys], that satisfy owners of existing alias [aliasID].
+func (b *caminoBuilder) aliasAuth(
+	aliasID ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+) (*secp256k1fx.Input, []*crypto.PrivateKeySECP256K1R, error) {
+	if _, err := b.state.GetMultisigAlias(aliasID); err != nil {
+		return nil, nil, fmt.Errorf("couldn't get multisig alias: %w", err)
+	}
+	// alias owners could be weighted, so alias itself is spent.
+	// Alias doesn't count in sig indices, so they are the same as for its owners.
+	in, aliasSigners, err := secp256k1fx.NewKeychain(keys...).SpendMultiSig(
+		&secp256k1fx.TransferOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{aliasID},
+		}},
+		b.clk.Unix(),
+		b.state,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errKeyMissing, err)
+	}
+	return &secp256k1fx.Input{SigIndices: in.(*secp256k1fx.TransferInput).SigIndices}, aliasSigners, nil
+}
+
+func (b *caminoBuilder) NewDepositOfferTx(
+	offer *deposit.Offer,
+	adminAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newDepositOfferTx(offer, adminAddress, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedDepositOfferTx(
+	offer *deposit.Offer,
+	adminAddress ids.ShortID,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newDepositOfferTx(offer, adminAddress, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newDepositOfferTx(
+	offer *deposit.Offer,
+	adminAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.DepositOfferTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// admin credential must be the last one
+	kc := secp256k1fx.NewKeychain(withoutSeparator(keys)...)
+	in, adminSigners, err := kc.SpendMultiSig(
+		&secp256k1fx.TransferOutput{OutputOwners: secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{adminAddress},
+		}},
+		b.clk.Unix(),
+		b.state,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errKeyMissing, err)
+	}
+	signers = append(signers, adminSigners)
+
+	// offer id, version and deposited amount are set by tx executor
+	txOffer := *offer
+	txOffer.ID = ids.Empty
+	txOffer.Version = 0
+	txOffer.DepositedAmount = 0
+
+	utx := &txs.DepositOfferTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		DepositOfferID:        offer.ID,
+		DepositOfferVersion:   offer.Version,
+		DepositOffer:          &txOffer,
+		DepositOfferExtension: offer.Extension(),
+		AdminAddress:          adminAddress,
+		AdminAuth:             &secp256k1fx.Input{SigIndices: in.(*secp256k1fx.TransferInput).SigIndices},
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewSplitDepositTx(
+	depositTxID ids.ID,
+	splitAmount uint64,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newSplitDepositTx(depositTxID, splitAmount, rewardsOwner, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedSplitDepositTx(
+	depositTxID ids.ID,
+	splitAmount uint64,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newSplitDepositTx(depositTxID, splitAmount, rewardsOwner, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newSplitDepositTx(
+	depositTxID ids.ID,
+	splitAmount uint64,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.SplitDepositTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
+
+	oldDeposit, err := b.state.GetDeposit(depositTxID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if splitAmount == 0 || splitAmount >= oldDeposit.Amount {
+		return nil, nil, errWrongSplitAmount
+	}
+	_, newDeposit := oldDeposit.Split(splitAmount)
+	amountToMove := newDeposit.Amount - newDeposit.UnlockedAmount
+
+	currentRewardsOwner, err := state.DepositRewardsOwner(b.state, depositTxID, oldDeposit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	depositTxSet := set.NewSet[ids.ID](1)
+	depositTxSet.Add(depositTxID)
+
+	kc := secp256k1fx.NewKeychain(keys...)
+	utxos, err := b.state.LockedUTXOs(depositTxSet, kc.Addresses(), locked.StateDeposited)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Sorting utxos, so produced ins and outs are deterministic
+	sort.Slice(utxos, func(i, j int) bool {
+		utxoIDi := utxos[i].InputID()
+		utxoIDj := utxos[j].InputID()
+		return bytes.Compare(utxoIDi[:], utxoIDj[:]) < 0
+	})
+
+	// consuming split deposit utxos and depositing them again either
+	// with split deposit or with new deposit created by this tx
+	ins := []*avax.TransferableInput{}
+	outs := []*avax.TransferableOutput{}
+	signers := [][]*crypto.PrivateKeySECP256K1R{}
+	now := b.clk.Unix()
+	for _, utxo := range utxos {
+		if amountToMove == 0 {
+			break
+		}
+
+		out, ok := locked.AsOut(utxo.Out)
+		if !ok || out.DepositTxID != depositTxID {
+			continue
+		}
+
+		innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// We only know how to clone secp256k1 outputs for now
+			continue
+		}
+
+		inIntf, inSigners, err := kc.SpendMultiSig(innerOut, now, b.state)
+		if err != nil {
+			// We couldn't spend the output, so move on to the next one
+			continue
+		}
+
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %T", errWrongInType, inIntf)
+		}
+
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			In: &locked.In{
+				IDs:            out.IDs,
+				TransferableIn: in,
+			},
+		})
+		signers = append(signers, inSigners)
+
+		movedAmount := math.Min(amountToMove, in.Amount())
+		amountToMove -= movedAmount
+
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: utxo.Asset,
+			Out: &locked.Out{
+				IDs: locked.IDs{
+					DepositTxID: locked.ThisTxID,
+					BondTxID:    out.BondTxID,
+				},
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          movedAmount,
+					OutputOwners: innerOut.OutputOwners,
+				},
+			},
+		})
+
+		if remainingAmount := in.Amount() - movedAmount; remainingAmount > 0 {
+			outs = append(outs, &avax.TransferableOutput{
+				Asset: utxo.Asset,
+				Out: &locked.Out{
+					IDs: out.IDs,
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          remainingAmount,
+						OutputOwners: innerOut.OutputOwners,
+					},
+				},
+			})
+		}
+	}
+
+	if amountToMove > 0 {
+		return nil, nil, fmt.Errorf("%w: deposit %s, missing %d", errInsufficientDepositedFunds, depositTxID, amountToMove)
+	}
+
+	// burning fee
+	feeIns, feeOuts, feeSigners, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
 
-		_, signers, able := kc.Match(depositRewardsOwner, b.clk.Unix())
-		if !able {
-			return nil, errKeyMissing
+	ins = append(ins, feeIns...)
+	outs = append(outs, feeOuts...)
+	signers = append(signers, feeSigners...)
+
+	avax.SortTransferableInputsWithSigners(ins, signers)
+	avax.SortTransferableOutputs(outs, txs.Codec)
+
+	// current rewards owner credential must be the last one
+	rewardsOwnerSigners, err := ownerSigners(kc, currentRewardsOwner, now, b.state)
+	if err != nil {
+		return nil, nil, err
+	}
+	signers = append(signers, rewardsOwnerSigners)
+
+	utx := &txs.SplitDepositTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+			Memo:         memo,
+		}},
+		DepositTxID:  depositTxID,
+		SplitAmount:  splitAmount,
+		RewardsOwner: rewardsOwner,
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewUnlockDepositTx(
+	amountsToUnlock map[ids.ID]uint64,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newUnlockDepositTx(amountsToUnlock, keys, change)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedUnlockDepositTx(
+	amountsToUnlock map[ids.ID]uint64,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newUnlockDepositTx(amountsToUnlock, fakeKeys(from, signers), change)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newUnlockDepositTx(
+	amountsToUnlock map[ids.ID]uint64,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+) (*txs.UnlockDepositTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
+
+	// unlocking
+	ins, outs, signers, err := b.UnlockDeposit(b.state, keys, amountsToUnlock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	// burning fee
+	feeIns, feeOuts, feeSigners, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	ins = append(ins, feeIns...)
+	outs = append(outs, feeOuts...)
+	signers = append(signers, feeSigners...)
+
+	// we need to sort ins/outs/signers before using them in tx
+	// UnlockDeposit returns unsorted results and we appended arrays
+	avax.SortTransferableInputsWithSigners(ins, signers)
+	avax.SortTransferableOutputs(outs, txs.Codec)
+
+	utx := &txs.UnlockDepositTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         outs,
+		}},
+	}
+	return utx, signers, nil
+}
+
+func (b *caminoBuilder) NewClaimTx(
+	claimRequests []ClaimRequest,
+	claimDeposit *ClaimDeposit,
+	claimTo *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	start := time.Now()
+	utx, signers, err := b.newClaimTx(claimRequests, claimDeposit, claimTo, keys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewUnsignedClaimTx(
+	claimRequests []ClaimRequest,
+	claimDeposit *ClaimDeposit,
+	claimTo *secp256k1fx.OutputOwners,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	utx, txSigners, err := b.newClaimTx(claimRequests, claimDeposit, claimTo, fakeKeys(from, signers), change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newClaimTx(
+	claimRequests []ClaimRequest,
+	claimDeposit *ClaimDeposit,
+	claimTo *secp256k1fx.OutputOwners,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	caminoGenesis, err := b.state.CaminoConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if !caminoGenesis.LockModeBondDeposit {
+		return nil, nil, errWrongLockMode
+	}
+
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+
+	kc := secp256k1fx.NewKeychain(keys...)
+	claimableSignersKC := secp256k1fx.NewKeychain()
+
+	var (
+		depositTxIDs      []ids.ID
+		claimableOwnerIDs []ids.ID
+		amountToClaim     []uint64
+	)
+
+	for _, claimRequest := range claimRequests {
+		if err := claimRequest.Type.Verify(); err != nil {
+			return nil, nil, err
 		}
 
-		for _, signer := range signers {
-			claimableSignersKC.Add(signer)
+		if claimRequest.Type.Has(ClaimTypeActiveDepositReward) {
+			for _, depositTxID := range claimRequest.DepositTxIDs {
+				deposit, err := b.state.GetDeposit(depositTxID)
+				if err != nil {
+					return nil, nil, err
+				}
+				depositRewardsOwner, err := state.DepositRewardsOwner(b.state, depositTxID, deposit)
+				if err != nil {
+					return nil, nil, err
+				}
+
+				ownerID, err := txs.GetOwnerID(depositRewardsOwner)
+				if err != nil {
+					return nil, nil, err
+				}
+				if ownerID != claimRequest.OwnerID {
+					return nil, nil, fmt.Errorf("%w: deposit %s", errDepositRewardsOwnerMismatch, depositTxID)
+				}
+
+				signers, err := ownerSigners(kc, depositRewardsOwner, b.clk.Unix(), b.state)
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, signer := range signers {
+					claimableSignersKC.Add(signer)
+				}
+				depositTxIDs = append(depositTxIDs, depositTxID)
+			}
 		}
-	}
 
-	for _, ownerID := range claimableOwnerIDs {
-		claimable, err := b.state.GetClaimable(ownerID)
+		if !claimRequest.Type.Has(ClaimTypeExpiredDepositReward | ClaimTypeValidatorReward) {
+			continue
+		}
+
+		claimable, err := b.state.GetClaimable(claimRequest.OwnerID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("couldn't get claimable for ownerID %s: %w", claimRequest.OwnerID, err)
+		}
+
+		claimableAmount, err := claimRequest.Type.claimableAmount(claimable.ValidatorReward, claimable.DepositReward)
 		if err != nil {
-			return nil, fmt.Errorf("couldn't get claimable for ownerID %s: %w", ownerID, err)
+			return nil, nil, fmt.Errorf("%w (ownerID %s)", err, claimRequest.OwnerID)
+		}
+		if claimRequest.Amount > claimableAmount {
+			return nil, nil, fmt.Errorf("%w: ownerID %s, requested %d, claimable %d",
+				errClaimAmountTooBig, claimRequest.OwnerID, claimRequest.Amount, claimableAmount)
+		}
+		if claimRequest.Amount != 0 {
+			claimableAmount = claimRequest.Amount
+		}
+		if claimableAmount == 0 {
+			// Nothing to claim
+			continue
 		}
 
-		_, signers, able := kc.Match(claimable.Owner, b.clk.Unix())
-		if !able {
-			return nil, errKeyMissing
+		signers, err := ownerSigners(kc, claimable.Owner, b.clk.Unix(), b.state)
+		if err != nil {
+			return nil, nil, err
 		}
 		for _, signer := range signers {
 			claimableSignersKC.Add(signer)
 		}
+		claimableOwnerIDs = append(claimableOwnerIDs, claimRequest.OwnerID)
+		amountToClaim = append(amountToClaim, claimableAmount)
 	}
 	signers = append(signers, claimableSignersKC.Keys)
 
@@ -447,18 +1825,39 @@ func (b *caminoBuilder) NewClaimTx(
 			BlockchainID: b.ctx.ChainID,
 			Ins:          ins,
 			Outs:         outs,
+			Memo:         memo,
 		}},
 		DepositTxIDs:      depositTxIDs,
 		ClaimableOwnerIDs: claimableOwnerIDs,
 		ClaimedAmounts:    amountToClaim,
 		ClaimTo:           claimTo,
 	}
+	if claimDeposit == nil {
+		return utx, signers, nil
+	}
 
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	// claimed amount is known only during tx execution, so offer must be specified explicitly
+	if claimDeposit.DepositOfferID == ids.Empty {
+		return nil, nil, errEmptyClaimDepositOfferID
+	}
+	_, offerOwnerSigners, err := b.depositOfferWithSigners(0, claimDeposit.Duration, claimDeposit.DepositOfferID, keys)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	if offerOwnerSigners != nil {
+		signers = append(signers, offerOwnerSigners)
+	}
+
+	return &txs.ClaimDepositTx{
+		ClaimTx:         *utx,
+		DepositOfferID:  claimDeposit.DepositOfferID,
+		DepositDuration: claimDeposit.Duration,
+		RewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{claimDeposit.RewardsAddress},
+		},
+	}, signers, nil
 }
 
 func (b *caminoBuilder) NewRegisterNodeTx(
@@ -467,22 +1866,110 @@ func (b *caminoBuilder) NewRegisterNodeTx(
 	consortiumMemberAddress ids.ShortID,
 	keys []*crypto.PrivateKeySECP256K1R,
 	change *secp256k1fx.OutputOwners,
+	memo []byte,
 ) (*txs.Tx, error) {
-	ins, outs, signers, _, err := b.Lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, nil, change, 0)
+	start := time.Now()
+	utx, signers, err := b.newRegisterNodeTx(oldNodeID, newNodeID, consortiumMemberAddress, keys, change, memo)
 	if err != nil {
-		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+		return nil, err
+	}
+	return b.sign(start, utx, signers)
+}
+
+func (b *caminoBuilder) NewRegisterNodeTxWithNodeSigner(
+	oldNodeID ids.NodeID,
+	newNodeID ids.NodeID,
+	consortiumMemberAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	nodeSigner NodeSigner,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.Tx, error) {
+	if newNodeID == ids.EmptyNodeID {
+		// node signature isn't required
+		return b.NewRegisterNodeTx(oldNodeID, newNodeID, consortiumMemberAddress, keys, change, memo)
+	}
+
+	start := time.Now()
+	nodeKeys := append(keys[:len(keys):len(keys)], crypto.FakePrivateKey(ids.ShortID(newNodeID)))
+	utx, signers, err := b.newRegisterNodeTx(oldNodeID, newNodeID, consortiumMemberAddress, nodeKeys, change, memo)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.initializeUnsigned(utx); err != nil {
+		return nil, err
+	}
+	manifest := txs.NewSigningManifest(signers)
+
+	ptx, err := txs.NewPartiallySignedTx(txs.Codec, utx, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := ptx.Sign(withoutSeparator(keys)...); err != nil {
+		return nil, err
+	}
+
+	nodeSig, err := nodeSigner(txs.SigningHash(utx.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errNodeSignerFailed, err)
+	}
+	// node credential is followed by consortium member credential
+	if err := ptx.AddSignature(manifest.NumCredentials()-2, 0, nodeSig); err != nil {
+		return nil, err
+	}
+
+	tx, err := ptx.Finalize(txs.Codec)
+	if err != nil {
+		b.metrics.markFailed(buildStepSign)
+		return nil, err
+	}
+	return b.verified(start, tx)
+}
+
+func (b *caminoBuilder) NewUnsignedRegisterNodeTx(
+	oldNodeID ids.NodeID,
+	newNodeID ids.NodeID,
+	consortiumMemberAddress ids.ShortID,
+	from []ids.ShortID,
+	signers []ids.ShortID,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	start := time.Now()
+	keys := fakeKeys(from, signers)
+	if newNodeID != ids.EmptyNodeID {
+		keys = append(keys, crypto.FakePrivateKey(ids.ShortID(newNodeID)))
+	}
+	utx, txSigners, err := b.newRegisterNodeTx(oldNodeID, newNodeID, consortiumMemberAddress, keys, change, memo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b.unsigned(start, utx, txSigners)
+}
+
+func (b *caminoBuilder) newRegisterNodeTx(
+	oldNodeID ids.NodeID,
+	newNodeID ids.NodeID,
+	consortiumMemberAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	change *secp256k1fx.OutputOwners,
+	memo []byte,
+) (*txs.RegisterNodeTx, [][]*crypto.PrivateKeySECP256K1R, error) {
+	ins, outs, signers, err := b.lock(keys, 0, b.cfg.TxFee, locked.StateUnlocked, change)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
 	nodeSigners := []*crypto.PrivateKeySECP256K1R{}
 	if newNodeID != ids.EmptyNodeID {
 		nodeSigners, err = getSigner(keys, ids.ShortID(newNodeID))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	signers = append(signers, nodeSigners)
 
-	kc := secp256k1fx.NewKeychain(keys...)
+	kc := secp256k1fx.NewKeychain(withoutSeparator(keys)...)
 	in, consortiumSigners, err := kc.SpendMultiSig(
 		&secp256k1fx.TransferOutput{
 			OutputOwners: secp256k1fx.OutputOwners{
@@ -495,7 +1982,7 @@ func (b *caminoBuilder) NewRegisterNodeTx(
 		b.state,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	sigIndices := in.(*secp256k1fx.TransferInput).SigIndices
 	signers = append(signers, consortiumSigners)
@@ -506,21 +1993,18 @@ func (b *caminoBuilder) NewRegisterNodeTx(
 			BlockchainID: b.ctx.ChainID,
 			Ins:          ins,
 			Outs:         outs,
+			Memo:         memo,
 		}},
 		OldNodeID:               oldNodeID,
 		NewNodeID:               newNodeID,
 		ConsortiumMemberAuth:    &secp256k1fx.Input{SigIndices: sigIndices},
 		ConsortiumMemberAddress: consortiumMemberAddress,
 	}
-
-	tx, err := txs.NewSigned(utx, txs.Codec, signers)
-	if err != nil {
-		return nil, err
-	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return utx, signers, nil
 }
 
 func (b *caminoBuilder) NewRewardsImportTx() (*txs.Tx, error) {
+	start := time.Now()
 	caminoGenesis, err := b.state.CaminoConfig()
 	if err != nil {
 		return nil, err
@@ -530,28 +2014,11 @@ func (b *caminoBuilder) NewRewardsImportTx() (*txs.Tx, error) {
 		return nil, errWrongLockMode
 	}
 
-	allUTXOsBytes, _, _, err := b.ctx.SharedMemory.Indexed(
-		b.ctx.CChainID,
-		treasury.AddrTraitsBytes,
-		ids.ShortEmpty[:], ids.Empty[:], MaxPageSize,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("error fetching atomic UTXOs: %w", err)
-	}
-
 	now := b.clk.Unix()
 
-	utxos := []*avax.UTXO{}
-	for _, utxoBytes := range allUTXOsBytes {
-		utxo := &avax.TimedUTXO{}
-		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
-			// that means that this could be simple, not-timed utxo
-			continue
-		}
-
-		if utxo.Timestamp <= now-atomic.SharedMemorySyncBound {
-			utxos = append(utxos, &utxo.UTXO)
-		}
+	utxos, err := treasury.ImportableUTXOs(b.ctx.SharedMemory, b.ctx.CChainID, now)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(utxos) == 0 {
@@ -587,20 +2054,55 @@ func (b *caminoBuilder) NewRewardsImportTx() (*txs.Tx, error) {
 	}
 	tx, err := txs.NewSigned(utx, txs.Codec, nil)
 	if err != nil {
+		b.metrics.markFailed(buildStepSign)
 		return nil, err
 	}
-
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.verified(start, tx)
 }
 
 func (b *caminoBuilder) NewSystemUnlockDepositTx(
 	depositTxIDs []ids.ID,
-) (*txs.Tx, error) {
-	ins, outs, err := b.Unlock(b.state, depositTxIDs, locked.StateDeposited)
+) ([]*txs.Tx, error) {
+	start := time.Now()
+	maxTxSize := b.cfg.CaminoConfig.MaxSystemUnlockDepositTxSize
+	if maxTxSize <= 0 {
+		maxTxSize = defaultMaxSystemUnlockDepositTxSize
+	}
+
+	emptyTx, err := txs.NewSigned(&txs.UnlockDepositTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+		}},
+	}, txs.Codec, nil)
+	if err != nil {
+		return nil, err
+	}
+	emptyTxSize := len(emptyTx.Bytes())
+
+	// Chunk tx size is estimated as empty tx size plus size of chunk ins and outs.
+	// Empty credentials of system tx are few bytes per input, so they aren't counted.
+	maxChunkSize := math.Max(maxTxSize-emptyTxSize, 1)
+
+	chunks, err := b.UnlockChunks(b.state, depositTxIDs, locked.StateDeposited, 0, maxChunkSize)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
 
+	unlockTxs := make([]*txs.Tx, len(chunks))
+	for i, chunk := range chunks {
+		if unlockTxs[i], err = b.newSystemUnlockDepositTx(start, chunk.Ins, chunk.Outs); err != nil {
+			return nil, err
+		}
+	}
+	return unlockTxs, nil
+}
+
+func (b *caminoBuilder) newSystemUnlockDepositTx(
+	start time.Time,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+) (*txs.Tx, error) {
 	utx := &txs.UnlockDepositTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    b.ctx.NetworkID,
@@ -612,9 +2114,97 @@ func (b *caminoBuilder) NewSystemUnlockDepositTx(
 
 	tx, err := txs.NewSigned(utx, txs.Codec, make([][]*crypto.PrivateKeySECP256K1R, len(ins)))
 	if err != nil {
+		b.metrics.markFailed(buildStepSign)
 		return nil, err
 	}
-	return tx, tx.SyntacticVerify(b.ctx)
+	return b.verified(start, tx)
+}
+
+// sign signs [utx] with [signers] and verifies resulting tx syntactically.
+// Build, that started at [start], is recorded in metrics once tx is verified.
+func (b *caminoBuilder) sign(start time.Time, utx txs.UnsignedTx, signers [][]*crypto.PrivateKeySECP256K1R) (*txs.Tx, error) {
+	if err := b.verifyBuildContext(utx); err != nil {
+		b.metrics.markFailed(buildStepBuildContext)
+		return nil, err
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		b.metrics.markFailed(buildStepSign)
+		return nil, err
+	}
+	return b.verified(start, tx)
+}
+
+// verified verifies [tx] syntactically and records its build,
+// that started at [start], in metrics
+func (b *caminoBuilder) verified(start time.Time, tx *txs.Tx) (*txs.Tx, error) {
+	if err := tx.SyntacticVerify(b.ctx); err != nil {
+		b.metrics.markFailed(buildStepVerify)
+		return nil, err
+	}
+	b.metrics.markBuilt(tx.Unsigned, time.Since(start))
+	return tx, nil
+}
+
+// unsigned initializes [utx] bytes, verifies it syntactically
+// and returns it with signing manifest created from [signers].
+// Build, that started at [start], is recorded in metrics once tx is verified.
+func (b *caminoBuilder) unsigned(
+	start time.Time,
+	utx txs.UnsignedTx,
+	signers [][]*crypto.PrivateKeySECP256K1R,
+) (txs.UnsignedTx, *txs.SigningManifest, error) {
+	if err := b.initializeUnsigned(utx); err != nil {
+		return nil, nil, err
+	}
+	b.metrics.markBuilt(utx, time.Since(start))
+	return utx, txs.NewSigningManifest(signers), nil
+}
+
+// initializeUnsigned initializes [utx] bytes and verifies it syntactically
+func (b *caminoBuilder) initializeUnsigned(utx txs.UnsignedTx) error {
+	if err := b.verifyBuildContext(utx); err != nil {
+		b.metrics.markFailed(buildStepBuildContext)
+		return err
+	}
+	unsignedBytes, err := txs.Codec.Marshal(txs.Version, &utx)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	utx.Initialize(unsignedBytes)
+	if err := utx.SyntacticVerify(b.ctx); err != nil {
+		b.metrics.markFailed(buildStepVerify)
+		return err
+	}
+	return nil
+}
+
+// fakeKeys creates keys that only hold [from] and [signers] addresses,
+// in the same layout as keys expected by the Spender: from keys,
+// then nil separator followed by signer keys, if there are any.
+func fakeKeys(from, signers []ids.ShortID) []*crypto.PrivateKeySECP256K1R {
+	keys := make([]*crypto.PrivateKeySECP256K1R, 0, len(from)+len(signers)+1)
+	for _, addr := range from {
+		keys = append(keys, crypto.FakePrivateKey(addr))
+	}
+	if len(signers) > 0 {
+		keys = append(keys, nil)
+		for _, addr := range signers {
+			keys = append(keys, crypto.FakePrivateKey(addr))
+		}
+	}
+	return keys
+}
+
+// withoutSeparator returns [keys] without nil separator of from and signer keys
+func withoutSeparator(keys []*crypto.PrivateKeySECP256K1R) []*crypto.PrivateKeySECP256K1R {
+	nonNilKeys := make([]*crypto.PrivateKeySECP256K1R, 0, len(keys))
+	for _, key := range keys {
+		if key != nil {
+			nonNilKeys = append(nonNilKeys, key)
+		}
+	}
+	return nonNilKeys
 }
 
 func getSigner(
@@ -628,9 +2218,10 @@ func getSigners(
 	keys []*crypto.PrivateKeySECP256K1R,
 	addresses []ids.ShortID,
 ) ([]*crypto.PrivateKeySECP256K1R, error) {
+	kc := secp256k1fx.NewKeychain(withoutSeparator(keys)...)
 	signers := make([]*crypto.PrivateKeySECP256K1R, len(addresses))
 	for i, addr := range addresses {
-		signer, found := secp256k1fx.NewKeychain(keys...).Get(addr)
+		signer, found := kc.Get(addr)
 		if !found {
 			return nil, fmt.Errorf("%w %s", errKeyMissing, addr.String())
 		}
@@ -645,23 +2236,21 @@ func getSigners(
 	return signers, nil
 }
 
-func getDepositRewardsOwner(state state.Chain, depositTxID ids.ID) (*secp256k1fx.OutputOwners, error) {
-	signedDepositTx, txStatus, err := state.GetTx(depositTxID)
-	if err != nil {
-		return nil, err
-	}
-	if txStatus != status.Committed {
-		return nil, errTxIsNotCommitted
-	}
-	depositTx, ok := signedDepositTx.Unsigned.(*txs.DepositTx)
-	if !ok {
-		return nil, errWrongTxType
+// ownerSigners returns keys from [kc] that are able to sign for [owner].
+// Multisig aliases in [owner], including aliases nested into other aliases,
+// are resolved with [msig].
+func ownerSigners(
+	kc *secp256k1fx.Keychain,
+	owner *secp256k1fx.OutputOwners,
+	time uint64,
+	msig secp256k1fx.AliasGetter,
+) ([]*crypto.PrivateKeySECP256K1R, error) {
+	if owner.Locktime > time {
+		return nil, fmt.Errorf("%w: owner is locked until %d", errKeyMissing, owner.Locktime)
 	}
-
-	depositRewardsOwner, ok := depositTx.RewardsOwner.(*secp256k1fx.OutputOwners)
-	if !ok {
-		return nil, errNotSECPOwner
+	_, signers, err := kc.SpendMultiSig(&secp256k1fx.TransferOutput{OutputOwners: *owner}, time, msig)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errKeyMissing, err)
 	}
-
-	return depositRewardsOwner, nil
+	return signers, nil
 }
@@ -4,6 +4,7 @@
 package builder
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -16,11 +17,15 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/nodeid"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	deposits "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
@@ -56,37 +61,37 @@ func TestCaminoBuilderTxAddressState(t *testing.T) {
 
 	tests := map[string]struct {
 		remove      bool
-		state       uint8
+		state       as.AddressStateBit
 		address     ids.ShortID
 		expectedErr error
 	}{
 		"KYC Role: Add": {
 			remove:      false,
-			state:       txs.AddressStateRoleKyc,
+			state:       as.AddressStateBitRoleKyc,
 			address:     caminoPreFundedKeys[0].PublicKey().Address(),
 			expectedErr: nil,
 		},
 		"KYC Role: Remove": {
 			remove:      true,
-			state:       txs.AddressStateRoleKyc,
+			state:       as.AddressStateBitRoleKyc,
 			address:     caminoPreFundedKeys[0].PublicKey().Address(),
 			expectedErr: nil,
 		},
 		"Admin Role: Add": {
 			remove:      false,
-			state:       txs.AddressStateRoleAdmin,
+			state:       as.AddressStateBitRoleAdmin,
 			address:     caminoPreFundedKeys[0].PublicKey().Address(),
 			expectedErr: nil,
 		},
 		"Admin Role: Remove": {
 			remove:      true,
-			state:       txs.AddressStateRoleAdmin,
+			state:       as.AddressStateBitRoleAdmin,
 			address:     caminoPreFundedKeys[0].PublicKey().Address(),
 			expectedErr: nil,
 		},
 		"Empty Address": {
 			remove:      false,
-			state:       txs.AddressStateRoleKyc,
+			state:       as.AddressStateBitRoleKyc,
 			address:     ids.ShortEmpty,
 			expectedErr: txs.ErrEmptyAddress,
 		},
@@ -100,6 +105,7 @@ func TestCaminoBuilderTxAddressState(t *testing.T) {
 				tt.state,
 				caminoPreFundedKeys,
 				nil,
+				nil,
 			)
 			require.ErrorIs(t, err, tt.expectedErr)
 		})
@@ -192,7 +198,9 @@ func TestUnlockDepositTx(t *testing.T) {
 		Threshold: 1,
 		Addrs:     []ids.ShortID{testKey.PublicKey().Address()},
 	}
-	depositTxID := ids.GenerateTestID()
+	depositTx, err := txs.NewSigned(&txs.DepositTx{RewardsOwner: &outputOwners}, txs.Codec, nil)
+	require.NoError(t, err)
+	depositTxID := depositTx.ID()
 	depositStartTime := time.Now()
 	depositExpiredTime := depositStartTime.Add(100 * time.Second)
 	deposit := &deposits.Deposit{
@@ -239,6 +247,7 @@ func TestUnlockDepositTx(t *testing.T) {
 
 			// Add a deposit to state
 			deposit.DepositOfferID = genesisOffers[0].ID
+			env.state.AddTx(depositTx, status.Committed)
 			env.state.AddDeposit(depositTxID, deposit)
 			err = env.state.Commit()
 			require.NoError(t, err)
@@ -252,7 +261,7 @@ func TestUnlockDepositTx(t *testing.T) {
 			require.NoError(t, err)
 
 			tx, err := env.txBuilder.NewUnlockDepositTx(
-				[]ids.ID{depositTxID},
+				map[ids.ID]uint64{depositTxID: 0},
 				[]*crypto.PrivateKeySECP256K1R{testKey.(*crypto.PrivateKeySECP256K1R)},
 				nil,
 			)
@@ -283,6 +292,28 @@ func TestNewClaimTx(t *testing.T) {
 	rewardOwner1Key, rewardOwner1Addr, rewardOwner1 := generateKeyAndOwner()
 	rewardOwner2Key, rewardOwner2Addr, rewardOwner2 := generateKeyAndOwner()
 	claimableOwnerID := ids.GenerateTestID()
+	rewardOwner1ID, err := txs.GetOwnerID(&rewardOwner1)
+	require.NoError(t, err)
+	rewardOwner2ID, err := txs.GetOwnerID(&rewardOwner2)
+	require.NoError(t, err)
+	multisigRewardOwner := secp256k1fx.OutputOwners{
+		Threshold: 2,
+		Addrs:     []ids.ShortID{rewardOwner1Addr, rewardOwner2Addr},
+	}
+	multisigRewardOwnerID, err := txs.GetOwnerID(&multisigRewardOwner)
+	require.NoError(t, err)
+
+	nestedAliasAddr := ids.ShortID{1}
+	aliasAddr := ids.ShortID{2}
+	nestedAlias := &multisig.Alias{
+		ID:     nestedAliasAddr,
+		Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{rewardOwner2Addr}},
+	}
+	alias := &multisig.Alias{
+		ID:     aliasAddr,
+		Owners: &secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{nestedAliasAddr}},
+	}
+	aliasOwner := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{aliasAddr}}
 
 	feeUTXO := generateTestUTXO(ids.GenerateTestID(), ctx.AVAXAssetID, defaultTxFee, feeUTXOOwner, ids.Empty, ids.Empty)
 
@@ -303,13 +334,15 @@ func TestNewClaimTx(t *testing.T) {
 		SyntacticallyVerified: true,
 	}
 
+	depositOfferID := ids.GenerateTestID()
+	offerOwnerKey, offerOwnerAddr, _ := generateKeyAndOwner()
+
 	type args struct {
-		depositTxIDs      []ids.ID
-		claimableOwnerIDs []ids.ID
-		amountToClaim     []uint64
-		claimTo           *secp256k1fx.OutputOwners
-		keys              []*crypto.PrivateKeySECP256K1R
-		change            *secp256k1fx.OutputOwners
+		claimRequests []ClaimRequest
+		claimDeposit  *ClaimDeposit
+		claimTo       *secp256k1fx.OutputOwners
+		keys          []*crypto.PrivateKeySECP256K1R
+		change        *secp256k1fx.OutputOwners
 	}
 
 	tests := map[string]struct {
@@ -324,14 +357,20 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
 				depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
 				s.EXPECT().GetTx(depositTxID1).Return(depositTx, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
 				keys: []*crypto.PrivateKeySECP256K1R{
 					feeKey,
 					rewardOwner1Key,
@@ -348,22 +387,167 @@ func TestNewClaimTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
+		"OK, single deposit tx, claimed rewards deposited": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// deposits
+				depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
+				s.EXPECT().GetTx(depositTxID1).Return(depositTx, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				// new deposit offer
+				s.EXPECT().GetDepositOffer(depositOfferID).Return(&deposits.Offer{ID: depositOfferID}, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimDeposit: &ClaimDeposit{
+					DepositOfferID: depositOfferID,
+					Duration:       100,
+					RewardsAddress: rewardOwner2Addr,
+				},
+				claimTo: &rewardOwner1,
+				keys: []*crypto.PrivateKeySECP256K1R{
+					feeKey,
+					rewardOwner1Key,
+				},
+			},
+			expectedTx: func(t *testing.T) *txs.Tx {
+				tx, err := txs.NewSigned(&txs.ClaimDepositTx{
+					ClaimTx: txs.ClaimTx{
+						BaseTx:       baseTx,
+						DepositTxIDs: []ids.ID{depositTxID1},
+						ClaimTo:      &rewardOwner1,
+					},
+					DepositOfferID:  depositOfferID,
+					DepositDuration: 100,
+					RewardsOwner:    &rewardOwner2,
+				}, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{feeKey}, {rewardOwner1Key}})
+				require.NoError(t, err)
+				return tx
+			},
+			expectedErr: nil,
+		},
+		"OK, single deposit tx, claimed rewards deposited with restricted offer": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}, offerOwnerAddr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// deposits
+				depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
+				s.EXPECT().GetTx(depositTxID1).Return(depositTx, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				// new deposit offer
+				s.EXPECT().GetDepositOffer(depositOfferID).Return(&deposits.Offer{
+					ID:           depositOfferID,
+					OwnerAddress: offerOwnerAddr,
+				}, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimDeposit: &ClaimDeposit{
+					DepositOfferID: depositOfferID,
+					Duration:       100,
+					RewardsAddress: rewardOwner2Addr,
+				},
+				claimTo: &rewardOwner1,
+				keys: []*crypto.PrivateKeySECP256K1R{
+					feeKey,
+					rewardOwner1Key,
+					offerOwnerKey,
+				},
+			},
+			expectedTx: func(t *testing.T) *txs.Tx {
+				tx, err := txs.NewSigned(&txs.ClaimDepositTx{
+					ClaimTx: txs.ClaimTx{
+						BaseTx:       baseTx,
+						DepositTxIDs: []ids.ID{depositTxID1},
+						ClaimTo:      &rewardOwner1,
+					},
+					DepositOfferID:  depositOfferID,
+					DepositDuration: 100,
+					RewardsOwner:    &rewardOwner2,
+				}, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{feeKey}, {rewardOwner1Key}, {offerOwnerKey}})
+				require.NoError(t, err)
+				return tx
+			},
+			expectedErr: nil,
+		},
+		"Fail, empty claim deposit offer id": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// deposits
+				depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
+				s.EXPECT().GetTx(depositTxID1).Return(depositTx, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimDeposit: &ClaimDeposit{
+					Duration:       100,
+					RewardsAddress: rewardOwner2Addr,
+				},
+				claimTo: &rewardOwner1,
+				keys: []*crypto.PrivateKeySECP256K1R{
+					feeKey,
+					rewardOwner1Key,
+				},
+			},
+			expectedErr: errEmptyClaimDepositOfferID,
+		},
 		"OK, two deposit tx": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}, rewardOwner2Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
 				depositTx1 := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
 				depositTx2 := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner2}}
 				s.EXPECT().GetTx(depositTxID1).Return(depositTx1, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				s.EXPECT().GetTx(depositTxID2).Return(depositTx2, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID2).Return(&deposits.Deposit{}, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1, depositTxID2},
-				claimTo:      &rewardOwner1,
+				claimRequests: []ClaimRequest{
+					{
+						OwnerID:      rewardOwner1ID,
+						Type:         ClaimTypeActiveDepositReward,
+						DepositTxIDs: []ids.ID{depositTxID1},
+					},
+					{
+						OwnerID:      rewardOwner2ID,
+						Type:         ClaimTypeActiveDepositReward,
+						DepositTxIDs: []ids.ID{depositTxID2},
+					},
+				},
+				claimTo: &rewardOwner1,
 				keys: []*crypto.PrivateKeySECP256K1R{
 					feeKey,
 					rewardOwner1Key,
@@ -387,24 +571,30 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}, rewardOwner2Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
-				depositTx1 := &txs.Tx{Unsigned: &txs.DepositTx{
-					RewardsOwner: &secp256k1fx.OutputOwners{
-						Threshold: 2,
-						Addrs: []ids.ShortID{
-							rewardOwner1Key.Address(),
-							rewardOwner2Key.Address(),
-						},
-					},
-				}}
+				depositTx1 := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &multisigRewardOwner}}
 				depositTx2 := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}
 				s.EXPECT().GetTx(depositTxID1).Return(depositTx1, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				s.EXPECT().GetTx(depositTxID2).Return(depositTx2, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID2).Return(&deposits.Deposit{}, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1, depositTxID2},
-				claimTo:      &rewardOwner1,
+				claimRequests: []ClaimRequest{
+					{
+						OwnerID:      multisigRewardOwnerID,
+						Type:         ClaimTypeActiveDepositReward,
+						DepositTxIDs: []ids.ID{depositTxID1},
+					},
+					{
+						OwnerID:      rewardOwner1ID,
+						Type:         ClaimTypeActiveDepositReward,
+						DepositTxIDs: []ids.ID{depositTxID2},
+					},
+				},
+				claimTo: &rewardOwner1,
 				keys: []*crypto.PrivateKeySECP256K1R{
 					feeKey,
 					rewardOwner1Key,
@@ -428,15 +618,19 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// claimables
 				claimable := &state.Claimable{Owner: &rewardOwner1, DepositReward: 10, ValidatorReward: 100}
 				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
 				return s
 			},
 			args: args{
-				claimableOwnerIDs: []ids.ID{claimableOwnerID},
-				amountToClaim:     []uint64{60},
-				claimTo:           &rewardOwner1,
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeAll,
+					Amount:  60,
+				}},
+				claimTo: &rewardOwner1,
 				keys: []*crypto.PrivateKeySECP256K1R{
 					feeKey,
 					rewardOwner1Key,
@@ -460,24 +654,30 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}, rewardOwner2Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
-				depositTx1 := &txs.Tx{Unsigned: &txs.DepositTx{
-					RewardsOwner: &secp256k1fx.OutputOwners{
-						Threshold: 2,
-						Addrs:     []ids.ShortID{rewardOwner1Addr, rewardOwner2Addr},
-					},
-				}}
+				depositTx1 := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &multisigRewardOwner}}
 				s.EXPECT().GetTx(depositTxID1).Return(depositTx1, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				// claimables
 				claimable := &state.Claimable{Owner: &rewardOwner1, DepositReward: 10, ValidatorReward: 100}
 				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs:      []ids.ID{depositTxID1},
-				claimableOwnerIDs: []ids.ID{claimableOwnerID},
-				amountToClaim:     []uint64{60},
-				claimTo:           &rewardOwner1,
+				claimRequests: []ClaimRequest{
+					{
+						OwnerID:      multisigRewardOwnerID,
+						Type:         ClaimTypeActiveDepositReward,
+						DepositTxIDs: []ids.ID{depositTxID1},
+					},
+					{
+						OwnerID: claimableOwnerID,
+						Type:    ClaimTypeExpiredDepositReward | ClaimTypeValidatorReward,
+						Amount:  60,
+					},
+				},
+				claimTo: &rewardOwner1,
 				keys: []*crypto.PrivateKeySECP256K1R{
 					feeKey,
 					rewardOwner1Key,
@@ -497,81 +697,238 @@ func TestNewClaimTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
-		"Fail, deposit errored": {
+		"OK, only validator rewards": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
-				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
-				// deposits
-				s.EXPECT().GetTx(depositTxID1).Return(nil, status.Unknown, database.ErrNotFound)
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner1Addr: {}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// claimables
+				claimable := &state.Claimable{Owner: &rewardOwner1, DepositReward: 10, ValidatorReward: 100}
+				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
-				keys:         []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeValidatorReward,
+				}},
+				claimTo: &rewardOwner1,
+				keys: []*crypto.PrivateKeySECP256K1R{
+					feeKey,
+					rewardOwner1Key,
+				},
 			},
-			expectedErr: database.ErrNotFound,
+			expectedTx: func(t *testing.T) *txs.Tx {
+				tx, err := txs.NewSigned(&txs.ClaimTx{
+					BaseTx:            baseTx,
+					ClaimableOwnerIDs: []ids.ID{claimableOwnerID},
+					ClaimedAmounts:    []uint64{100},
+					ClaimTo:           &rewardOwner1,
+				}, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{feeKey}, {rewardOwner1Key}})
+				require.NoError(t, err)
+				return tx
+			},
+			expectedErr: nil,
 		},
-		"Fail, deposit not committed": {
+		"OK, claimable owned by nested multisig alias": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}, rewardOwner2Addr: {}})
+				// aliases
+				s.EXPECT().GetMultisigAlias(aliasAddr).Return(alias, nil)
+				s.EXPECT().GetMultisigAlias(nestedAliasAddr).Return(nestedAlias, nil)
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// claimables
+				claimable := &state.Claimable{Owner: &aliasOwner, ValidatorReward: 100}
+				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeValidatorReward,
+				}},
+				claimTo: &rewardOwner2,
+				keys: []*crypto.PrivateKeySECP256K1R{
+					feeKey,
+					rewardOwner2Key,
+				},
+			},
+			expectedTx: func(t *testing.T) *txs.Tx {
+				tx, err := txs.NewSigned(&txs.ClaimTx{
+					BaseTx:            baseTx,
+					ClaimableOwnerIDs: []ids.ID{claimableOwnerID},
+					ClaimedAmounts:    []uint64{100},
+					ClaimTo:           &rewardOwner2,
+				}, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{feeKey}, {rewardOwner2Key}})
+				require.NoError(t, err)
+				return tx
+			},
+			expectedErr: nil,
+		},
+		"Fail, wrong claim type": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
-				// deposits
-				s.EXPECT().GetTx(depositTxID1).Return(nil, status.Unknown, nil)
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
-				keys:         []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{OwnerID: claimableOwnerID}},
+				claimTo:       &rewardOwner1,
+				keys:          []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
-			expectedErr: errTxIsNotCommitted,
+			expectedErr: errWrongClaimType,
 		},
-		"Fail, deposit isn't deposit": {
+		"Fail, deposit rewards owner mismatch": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
 				s.EXPECT().GetTx(depositTxID1).Return(
-					&txs.Tx{Unsigned: &txs.CaminoAddValidatorTx{}},
+					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}},
 					status.Committed,
 					nil,
 				)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner2ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
+			},
+			expectedErr: errDepositRewardsOwnerMismatch,
+		},
+		"Fail, only expired deposit rewards while owner has validator rewards": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// claimables
+				claimable := &state.Claimable{Owner: &rewardOwner1, DepositReward: 10, ValidatorReward: 100}
+				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeExpiredDepositReward,
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
+			},
+			expectedErr: errExpiredRewardsBehindValidator,
+		},
+		"Fail, claim amount is bigger than claimable amount of requested type": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// claimables
+				claimable := &state.Claimable{Owner: &rewardOwner1, DepositReward: 10, ValidatorReward: 100}
+				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeValidatorReward,
+					Amount:  101,
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
+			},
+			expectedErr: errClaimAmountTooBig,
+		},
+		"Fail, deposit tx errored": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// deposits
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				s.EXPECT().GetTx(depositTxID1).Return(nil, status.Unknown, database.ErrNotFound)
+				return s
+			},
+			args: args{
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
+			},
+			expectedErr: database.ErrNotFound,
+		},
+		"Fail, deposit not found": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
+				// fee
+				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+				// deposits
+				s.EXPECT().GetDeposit(depositTxID1).Return(nil, database.ErrNotFound)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
-				keys:         []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
-			expectedErr: errWrongTxType,
+			expectedErr: database.ErrNotFound,
 		},
-		"Fail, deposit rewards owner isn't secp type (shouldn't happen)": {
+		"Fail, deposit isn't deposit": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				s.EXPECT().GetTx(depositTxID1).Return(
-					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &avax.TransferableOutput{}}},
+					&txs.Tx{Unsigned: &txs.CaminoAddValidatorTx{}},
 					status.Committed,
 					nil,
 				)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
-				keys:         []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
-			expectedErr: errNotSECPOwner,
+			expectedErr: txs.ErrNotDepositTx,
 		},
 		"Fail, missing deposit signer": {
 			state: func(ctrl *gomock.Controller) state.State {
@@ -579,18 +936,24 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// deposits
 				s.EXPECT().GetTx(depositTxID1).Return(
 					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}},
 					status.Committed,
 					nil,
 				)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
 				return s
 			},
 			args: args{
-				depositTxIDs: []ids.ID{depositTxID1},
-				claimTo:      &rewardOwner1,
-				keys:         []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1},
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
 			expectedErr: errKeyMissing,
 		},
@@ -600,15 +963,19 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// claimables
 				s.EXPECT().GetClaimable(claimableOwnerID).Return(nil, database.ErrNotFound)
 				return s
 			},
 			args: args{
-				claimableOwnerIDs: []ids.ID{claimableOwnerID},
-				amountToClaim:     []uint64{1},
-				claimTo:           &rewardOwner1,
-				keys:              []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeAll,
+					Amount:  1,
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
 			expectedErr: database.ErrNotFound,
 		},
@@ -618,16 +985,20 @@ func TestNewClaimTx(t *testing.T) {
 				s.EXPECT().CaminoConfig().Return(caminoConfig, nil)
 				// fee
 				expectLock(s, map[ids.ShortID][]*avax.UTXO{feeAddr: {feeUTXO}})
+				s.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 				// claimables
-				claimable := &state.Claimable{Owner: &rewardOwner1}
+				claimable := &state.Claimable{Owner: &rewardOwner1, ValidatorReward: 1}
 				s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
 				return s
 			},
 			args: args{
-				claimableOwnerIDs: []ids.ID{claimableOwnerID},
-				amountToClaim:     []uint64{1},
-				claimTo:           &rewardOwner1,
-				keys:              []*crypto.PrivateKeySECP256K1R{feeKey},
+				claimRequests: []ClaimRequest{{
+					OwnerID: claimableOwnerID,
+					Type:    ClaimTypeAll,
+					Amount:  1,
+				}},
+				claimTo: &rewardOwner1,
+				keys:    []*crypto.PrivateKeySECP256K1R{feeKey},
 			},
 			expectedErr: errKeyMissing,
 		},
@@ -643,12 +1014,12 @@ func TestNewClaimTx(t *testing.T) {
 			}()
 
 			tx, err := b.NewClaimTx(
-				tt.args.depositTxIDs,
-				tt.args.claimableOwnerIDs,
-				tt.args.amountToClaim,
+				tt.args.claimRequests,
+				tt.args.claimDeposit,
 				tt.args.claimTo,
 				tt.args.keys,
 				tt.args.change,
+				nil,
 			)
 			require.ErrorIs(err, tt.expectedErr)
 			if tt.expectedTx != nil {
@@ -660,6 +1031,80 @@ func TestNewClaimTx(t *testing.T) {
 	}
 }
 
+func TestLegacyClaimRequests(t *testing.T) {
+	depositTxID1 := ids.GenerateTestID()
+	depositTxID2 := ids.GenerateTestID()
+	depositTxID3 := ids.GenerateTestID()
+	claimableOwnerID1 := ids.GenerateTestID()
+	claimableOwnerID2 := ids.GenerateTestID()
+	_, _, rewardOwner1 := generateKeyAndOwner()
+	_, _, rewardOwner2 := generateKeyAndOwner()
+	rewardOwner1ID, err := txs.GetOwnerID(&rewardOwner1)
+	require.NoError(t, err)
+	rewardOwner2ID, err := txs.GetOwnerID(&rewardOwner2)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		state                 func(*gomock.Controller) state.Chain
+		depositTxIDs          []ids.ID
+		claimableOwnerIDs     []ids.ID
+		amountToClaim         []uint64
+		expectedClaimRequests []ClaimRequest
+		expectedErr           error
+	}{
+		"Wrong amounts len": {
+			state: func(ctrl *gomock.Controller) state.Chain {
+				return state.NewMockChain(ctrl)
+			},
+			claimableOwnerIDs: []ids.ID{claimableOwnerID1},
+			amountToClaim:     []uint64{1, 2},
+			expectedErr:       errWrongClaimAmountsLen,
+		},
+		"OK": {
+			state: func(ctrl *gomock.Controller) state.Chain {
+				s := state.NewMockChain(ctrl)
+				s.EXPECT().GetTx(depositTxID1).Return(&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposits.Deposit{}, nil)
+				s.EXPECT().GetTx(depositTxID2).Return(&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner2}}, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID2).Return(&deposits.Deposit{}, nil)
+				s.EXPECT().GetTx(depositTxID3).Return(&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &rewardOwner1}}, status.Committed, nil)
+				s.EXPECT().GetDeposit(depositTxID3).Return(&deposits.Deposit{}, nil)
+				return s
+			},
+			depositTxIDs:      []ids.ID{depositTxID1, depositTxID2, depositTxID3},
+			claimableOwnerIDs: []ids.ID{claimableOwnerID1, claimableOwnerID2},
+			amountToClaim:     []uint64{10, 0},
+			expectedClaimRequests: []ClaimRequest{
+				{
+					OwnerID:      rewardOwner1ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID1, depositTxID3},
+				},
+				{
+					OwnerID:      rewardOwner2ID,
+					Type:         ClaimTypeActiveDepositReward,
+					DepositTxIDs: []ids.ID{depositTxID2},
+				},
+				{
+					OwnerID: claimableOwnerID1,
+					Type:    ClaimTypeExpiredDepositReward | ClaimTypeValidatorReward,
+					Amount:  10,
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			claimRequests, err := LegacyClaimRequests(tt.state(ctrl), tt.depositTxIDs, tt.claimableOwnerIDs, tt.amountToClaim)
+			require.ErrorIs(t, err, tt.expectedErr)
+			require.Equal(t, tt.expectedClaimRequests, claimRequests)
+		})
+	}
+}
+
 func TestNewRewardsImportTx(t *testing.T) {
 	ctx, _ := defaultCtx(nil)
 	blockTime := time.Unix(1000, 0)
@@ -693,7 +1138,7 @@ func TestNewRewardsImportTx(t *testing.T) {
 					utxosBytes[i] = utxoBytes
 				}
 				shm.EXPECT().Indexed(ctx.CChainID, treasury.AddrTraitsBytes,
-					ids.ShortEmpty[:], ids.Empty[:], MaxPageSize).Return(utxosBytes, nil, nil, nil)
+					ids.ShortEmpty[:], ids.Empty[:], treasury.ImportPageSize).Return(utxosBytes, nil, nil, nil)
 				return shm
 			},
 			utxos: []*avax.TimedUTXO{
@@ -729,6 +1174,58 @@ func TestNewRewardsImportTx(t *testing.T) {
 				return tx
 			},
 		},
+		"Multiple pages": {
+			state: func(ctrl *gomock.Controller) state.State {
+				s := state.NewMockState(ctrl)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				return s
+			},
+			sharedMemory: func(c *gomock.Controller, utxos []*avax.TimedUTXO) atomic.SharedMemory {
+				shm := atomic.NewMockSharedMemory(c)
+				// first page is full of not-timed utxos
+				firstPage := make([][]byte, treasury.ImportPageSize)
+				for i := range firstPage {
+					firstPage[i] = []byte{}
+				}
+				lastAddr, lastUTXOID := []byte{1}, []byte{2}
+				utxosBytes := make([][]byte, len(utxos))
+				for i, utxo := range utxos {
+					utxoBytes, err := txs.Codec.Marshal(txs.Version, utxo)
+					require.NoError(t, err)
+					utxosBytes[i] = utxoBytes
+				}
+				shm.EXPECT().Indexed(ctx.CChainID, treasury.AddrTraitsBytes,
+					ids.ShortEmpty[:], ids.Empty[:], treasury.ImportPageSize).Return(firstPage, lastAddr, lastUTXOID, nil)
+				shm.EXPECT().Indexed(ctx.CChainID, treasury.AddrTraitsBytes,
+					lastAddr, lastUTXOID, treasury.ImportPageSize).Return(utxosBytes, nil, nil, nil)
+				return shm
+			},
+			utxos: []*avax.TimedUTXO{
+				{
+					UTXO:      *generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 1, *treasury.Owner, ids.Empty, ids.Empty),
+					Timestamp: uint64(blockTime.Unix()) - atomic.SharedMemorySyncBound,
+				},
+				{
+					UTXO:      *generateTestUTXO(ids.ID{2}, ctx.AVAXAssetID, 10, *treasury.Owner, ids.Empty, ids.Empty),
+					Timestamp: uint64(blockTime.Unix()) - atomic.SharedMemorySyncBound,
+				},
+			},
+			expectedTx: func(t *testing.T, utxos []*avax.TimedUTXO) *txs.Tx {
+				tx, err := txs.NewSigned(&txs.RewardsImportTx{BaseTx: txs.BaseTx{
+					BaseTx: avax.BaseTx{
+						NetworkID:    ctx.NetworkID,
+						BlockchainID: ctx.ChainID,
+						Ins: []*avax.TransferableInput{
+							generateTestInFromUTXO(&utxos[0].UTXO, []uint32{0}, false),
+							generateTestInFromUTXO(&utxos[1].UTXO, []uint32{0}, false),
+						},
+					},
+					SyntacticallyVerified: true,
+				}}, txs.Codec, nil)
+				require.NoError(t, err)
+				return tx
+			},
+		},
 		"No utxos": {
 			state: func(ctrl *gomock.Controller) state.State {
 				s := state.NewMockState(ctrl)
@@ -738,7 +1235,7 @@ func TestNewRewardsImportTx(t *testing.T) {
 			sharedMemory: func(c *gomock.Controller, utxos []*avax.TimedUTXO) atomic.SharedMemory {
 				shm := atomic.NewMockSharedMemory(c)
 				shm.EXPECT().Indexed(ctx.CChainID, treasury.AddrTraitsBytes,
-					ids.ShortEmpty[:], ids.Empty[:], MaxPageSize).Return(nil, nil, nil, nil)
+					ids.ShortEmpty[:], ids.Empty[:], treasury.ImportPageSize).Return(nil, nil, nil, nil)
 				return shm
 			},
 			expectedErr: errNoUTXOsForImport,
@@ -765,3 +1262,514 @@ func TestNewRewardsImportTx(t *testing.T) {
 		})
 	}
 }
+
+func TestCaminoBuilderNewUnsignedAddressStateTx(t *testing.T) {
+	require := require.New(t)
+	env := newCaminoEnvironment(true, api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	})
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownCaminoEnvironment(env))
+	}()
+
+	key := caminoPreFundedKeys[0]
+	addr := key.PublicKey().Address()
+
+	utx, manifest, err := env.txBuilder.NewUnsignedAddressStateTx(
+		addr,
+		false,
+		as.AddressStateBitRoleKyc,
+		[]ids.ShortID{addr},
+		nil,
+		nil,
+		[]byte("memo"),
+	)
+	require.NoError(err)
+
+	signedTx, err := env.txBuilder.NewAddressStateTx(
+		addr,
+		false,
+		as.AddressStateBitRoleKyc,
+		[]*crypto.PrivateKeySECP256K1R{key},
+		nil,
+		[]byte("memo"),
+	)
+	require.NoError(err)
+
+	require.Equal(signedTx.Unsigned.Bytes(), utx.Bytes())
+	require.Equal(len(signedTx.Creds), manifest.NumCredentials())
+	for _, credSigners := range manifest.Signers {
+		require.Equal([]ids.ShortID{addr}, credSigners)
+	}
+}
+
+func TestCaminoBuilderWithBuildContext(t *testing.T) {
+	env := newCaminoEnvironment(true, api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	})
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownCaminoEnvironment(env))
+	}()
+
+	key := caminoPreFundedKeys[0]
+	addr := key.PublicKey().Address()
+
+	tests := map[string]struct {
+		buildCtx    BuildContext
+		expectedFee uint64
+		expectedErr error
+	}{
+		"Default context": {
+			expectedFee: env.config.TxFee,
+		},
+		"Fee override": {
+			buildCtx:    BuildContext{TxFee: env.config.TxFee + 1},
+			expectedFee: env.config.TxFee + 1,
+		},
+		"Fee override is too low": {
+			buildCtx:    BuildContext{TxFee: env.config.TxFee - 1},
+			expectedErr: errTxFeeTooLow,
+		},
+		"Change is burned": {
+			buildCtx: BuildContext{MinChangeAmount: defaultCaminoBalance + 1},
+		},
+		"Inputs limit isn't exceeded": {
+			buildCtx:    BuildContext{MaxInputs: 1},
+			expectedFee: env.config.TxFee,
+		},
+		"Canonical change without change owner": {
+			buildCtx:    BuildContext{CanonicalChange: true},
+			expectedErr: errNoChangeOwner,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx, err := env.txBuilder.WithBuildContext(tt.buildCtx).NewAddressStateTx(
+				addr,
+				false,
+				as.AddressStateBitRoleKyc,
+				[]*crypto.PrivateKeySECP256K1R{key},
+				nil,
+				nil,
+			)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			utx, ok := tx.Unsigned.(*txs.AddressStateTx)
+			require.True(t, ok)
+			consumed, produced := uint64(0), uint64(0)
+			for _, in := range utx.Ins {
+				consumed += in.In.Amount()
+			}
+			for _, out := range utx.Outs {
+				produced += out.Out.Amount()
+			}
+			if tt.buildCtx.MinChangeAmount > 0 {
+				require.Empty(t, utx.Outs)
+				require.Zero(t, produced)
+				return
+			}
+			require.Equal(t, tt.expectedFee, consumed-produced)
+		})
+	}
+}
+
+func TestMergeChange(t *testing.T) {
+	require := require.New(t)
+	assetID := ids.ID{1}
+	_, _, changeOwner := generateKeyAndOwner()
+	_, _, otherOwner := generateKeyAndOwner()
+
+	outs := []*avax.TransferableOutput{
+		generateTestOut(assetID, 1, changeOwner, ids.Empty, ids.Empty),
+		generateTestOut(assetID, 2, otherOwner, ids.Empty, ids.Empty),
+		generateTestOut(assetID, 3, changeOwner, ids.Empty, ids.Empty),
+		generateTestOut(assetID, 4, changeOwner, ids.ID{2}, ids.Empty),
+	}
+	expectedOuts := []*avax.TransferableOutput{
+		generateTestOut(assetID, 4, changeOwner, ids.Empty, ids.Empty),
+		generateTestOut(assetID, 2, otherOwner, ids.Empty, ids.Empty),
+		generateTestOut(assetID, 4, changeOwner, ids.ID{2}, ids.Empty),
+	}
+	avax.SortTransferableOutputs(expectedOuts, txs.Codec)
+
+	mergedOuts, err := mergeChange(outs, &changeOwner)
+	require.NoError(err)
+	require.Equal(expectedOuts, mergedOuts)
+}
+
+func TestCaminoBuilderVerifyBuildContext(t *testing.T) {
+	utx := &txs.BaseTx{BaseTx: avax.BaseTx{
+		Ins: []*avax.TransferableInput{
+			{UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()}},
+			{UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()}},
+		},
+	}}
+
+	tests := map[string]struct {
+		buildCtx    BuildContext
+		expectedErr error
+	}{
+		"No limit": {},
+		"Limit isn't exceeded": {
+			buildCtx: BuildContext{MaxInputs: 2},
+		},
+		"Too many inputs": {
+			buildCtx:    BuildContext{MaxInputs: 1},
+			expectedErr: errTooManyInputs,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := &caminoBuilder{buildCtx: tt.buildCtx}
+			require.ErrorIs(t, b.verifyBuildContext(utx), tt.expectedErr)
+		})
+	}
+}
+
+func TestNewSystemUnlockDepositTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	_, _, owner := generateKeyAndOwner()
+
+	depositTxIDs := []ids.ID{{1}, {2}, {3}}
+	chunks := make([]*utxo.UnlockChunk, len(depositTxIDs))
+	for i, depositTxID := range depositTxIDs {
+		depositUTXO := generateTestUTXO(ids.ID{10, byte(i)}, ctx.AVAXAssetID, 10, owner, depositTxID, ids.Empty)
+		chunks[i] = &utxo.UnlockChunk{
+			LockTxIDs: []ids.ID{depositTxID},
+			Ins:       []*avax.TransferableInput{generateTestInFromUTXO(depositUTXO, []uint32{0}, true)},
+			Outs: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          10,
+					OutputOwners: owner,
+				},
+			}},
+		}
+	}
+
+	tests := map[string]struct {
+		maxTxSize       int
+		expectedMaxSize int
+	}{
+		"Default size limit": {
+			expectedMaxSize: defaultMaxSystemUnlockDepositTxSize,
+		},
+		"Size limit lower than empty tx": {
+			maxTxSize:       1,
+			expectedMaxSize: 1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			b, db := newCaminoBuilderWithMocks(true, state.NewMockState(ctrl), nil)
+			defer func() {
+				require.NoError(db.Close())
+				ctrl.Finish()
+			}()
+			b.cfg.CaminoConfig.MaxSystemUnlockDepositTxSize = tt.maxTxSize
+
+			spender := utxo.NewMockHandler(ctrl)
+			spender.EXPECT().UnlockChunks(gomock.Any(), depositTxIDs, locked.StateDeposited, 0, gomock.Any()).DoAndReturn(
+				func(_ state.Chain, _ []ids.ID, _ locked.State, _, maxSize int) ([]*utxo.UnlockChunk, error) {
+					require.Positive(maxSize)
+					require.LessOrEqual(maxSize, tt.expectedMaxSize)
+					return chunks, nil
+				},
+			)
+			b.Spender = spender
+
+			unlockTxs, err := b.NewSystemUnlockDepositTx(depositTxIDs)
+			require.NoError(err)
+			require.Len(unlockTxs, len(chunks))
+			for i, chunk := range chunks {
+				utx, ok := unlockTxs[i].Unsigned.(*txs.UnlockDepositTx)
+				require.True(ok)
+				require.Equal(chunk.Ins, utx.Ins)
+				require.Equal(chunk.Outs, utx.Outs)
+			}
+		})
+	}
+}
+
+func TestCaminoBuilderNewRegisterNodeTxWithNodeSigner(t *testing.T) {
+	nodeKey, nodeID := nodeid.GenerateCaminoNodeKeyAndID()
+	otherNodeKey, _ := nodeid.GenerateCaminoNodeKeyAndID()
+	key := caminoPreFundedKeys[0]
+	signerErr := errors.New("signer error")
+
+	tests := map[string]struct {
+		keys        []*crypto.PrivateKeySECP256K1R
+		nodeSigner  NodeSigner
+		expectedErr error
+	}{
+		"Happy path": {
+			keys:       []*crypto.PrivateKeySECP256K1R{key},
+			nodeSigner: nodeKey.SignHash,
+		},
+		"Happy path, keys with signers separator": {
+			keys:       []*crypto.PrivateKeySECP256K1R{key, nil, key},
+			nodeSigner: nodeKey.SignHash,
+		},
+		"Node signer failed": {
+			keys: []*crypto.PrivateKeySECP256K1R{key},
+			nodeSigner: func([]byte) ([]byte, error) {
+				return nil, signerErr
+			},
+			expectedErr: errNodeSignerFailed,
+		},
+		"Wrong node signature": {
+			keys:        []*crypto.PrivateKeySECP256K1R{key},
+			nodeSigner:  otherNodeKey.SignHash,
+			expectedErr: txs.ErrWrongSignatureSigner,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			env := newCaminoEnvironment(true, api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+			})
+			env.ctx.Lock.Lock()
+			defer func() {
+				require.NoError(shutdownCaminoEnvironment(env))
+			}()
+
+			tx, err := env.txBuilder.NewRegisterNodeTxWithNodeSigner(
+				ids.EmptyNodeID,
+				nodeID,
+				key.Address(),
+				tt.keys,
+				tt.nodeSigner,
+				nil,
+				nil,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			expectedTx, err := env.txBuilder.NewRegisterNodeTx(
+				ids.EmptyNodeID,
+				nodeID,
+				key.Address(),
+				[]*crypto.PrivateKeySECP256K1R{key, nodeKey},
+				nil,
+				nil,
+			)
+			require.NoError(err)
+			require.Equal(expectedTx.Bytes(), tx.Bytes())
+		})
+	}
+}
+
+func TestCaminoBuilderNewBaseTx(t *testing.T) {
+	env := newCaminoEnvironment(true, api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	})
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownCaminoEnvironment(env))
+	}()
+
+	key := caminoPreFundedKeys[0]
+	_, _, recipientOwner := generateKeyAndOwner()
+
+	tests := map[string]struct {
+		amount      uint64
+		to          *secp256k1fx.OutputOwners
+		expectedErr error
+	}{
+		"Happy path": {
+			amount: 100,
+			to:     &recipientOwner,
+		},
+		"Zero amount": {
+			to:          &recipientOwner,
+			expectedErr: errZeroTransferAmount,
+		},
+		"No recipient": {
+			amount:      100,
+			expectedErr: errNoTransferOwner,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx, err := env.txBuilder.NewBaseTx(
+				tt.amount,
+				tt.to,
+				[]*crypto.PrivateKeySECP256K1R{key},
+				nil,
+				nil,
+			)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			utx, ok := tx.Unsigned.(*txs.BaseTx)
+			require.True(t, ok)
+			transferred := uint64(0)
+			for _, out := range utx.Outs {
+				secpOut, ok := out.Out.(*secp256k1fx.TransferOutput)
+				require.True(t, ok)
+				if secpOut.OutputOwners.Equals(tt.to) {
+					transferred += secpOut.Amt
+				}
+			}
+			require.Equal(t, tt.amount, transferred)
+		})
+	}
+}
+
+func TestCaminoBuilderNewBaseTxWrongLockMode(t *testing.T) {
+	require := require.New(t)
+	key, _, owner := generateKeyAndOwner()
+
+	ctrl := gomock.NewController(t)
+	s := state.NewMockState(ctrl)
+	s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: false}, nil).Times(2)
+	b, db := newCaminoBuilderWithMocks(true, s, nil)
+	defer func() {
+		require.NoError(db.Close())
+		ctrl.Finish()
+	}()
+
+	tx, err := b.NewBaseTx(100, &owner, []*crypto.PrivateKeySECP256K1R{key}, nil, nil)
+	require.ErrorIs(err, errWrongLockMode)
+	require.Nil(tx)
+
+	tx, err = b.NewConsolidateUTXOsTx([]*crypto.PrivateKeySECP256K1R{key}, 1, nil)
+	require.ErrorIs(err, errWrongLockMode)
+	require.Nil(tx)
+}
+
+func TestCaminoBuilderNewConsolidateUTXOsTx(t *testing.T) {
+	require := require.New(t)
+	ctx, _ := defaultCtx(nil)
+	key, _, owner := generateKeyAndOwner()
+	keys := []*crypto.PrivateKeySECP256K1R{key}
+	buildCtx := BuildContext{MaxInputs: 2, AsOf: 100}
+
+	ctrl := gomock.NewController(t)
+	s := state.NewMockState(ctrl)
+	s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+	b, db := newCaminoBuilderWithMocks(true, s, nil)
+	defer func() {
+		require.NoError(db.Close())
+		ctrl.Finish()
+	}()
+
+	ins := []*avax.TransferableInput{
+		generateTestInFromUTXO(generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 5, owner, ids.Empty, ids.Empty), []uint32{0}, true),
+		generateTestInFromUTXO(generateTestUTXO(ids.ID{2}, ctx.AVAXAssetID, 5, owner, ids.Empty, ids.Empty), []uint32{0}, true),
+	}
+	outs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          10 - b.cfg.TxFee,
+			OutputOwners: owner,
+		},
+	}}
+	spender := utxo.NewMockHandler(ctrl)
+	spender.EXPECT().Consolidate(keys, 1, buildCtx.MaxInputs, b.cfg.TxFee, buildCtx.AsOf).
+		Return(ins, outs, [][]*crypto.PrivateKeySECP256K1R{keys, keys}, nil)
+	b.Spender = spender
+
+	tx, err := b.WithBuildContext(buildCtx).NewConsolidateUTXOsTx(keys, 1, []byte{1})
+	require.NoError(err)
+	utx, ok := tx.Unsigned.(*txs.BaseTx)
+	require.True(ok)
+	require.Equal(ins, utx.Ins)
+	require.Equal(outs, utx.Outs)
+	require.Equal([]byte{1}, []byte(utx.Memo))
+	require.Len(tx.Creds, len(ins))
+}
+
+func TestCaminoBuilderPreview(t *testing.T) {
+	env := newCaminoEnvironment(true, api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	})
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownCaminoEnvironment(env))
+	}()
+
+	addr := caminoPreFundedKeys[0].Address()
+	_, _, recipientOwner := generateKeyAndOwner()
+
+	tests := map[string]struct {
+		utx            func(*testing.T) txs.UnsignedTx
+		expectedBurned uint64
+		expectedErr    error
+	}{
+		"Address state tx": {
+			utx: func(t *testing.T) txs.UnsignedTx {
+				utx, _, err := env.txBuilder.NewUnsignedAddressStateTx(
+					addr, false, as.AddressStateBitRoleKyc, []ids.ShortID{addr}, nil, nil, nil)
+				require.NoError(t, err)
+				return utx
+			},
+			expectedBurned: env.config.TxFee,
+		},
+		"Base tx": {
+			utx: func(t *testing.T) txs.UnsignedTx {
+				utx, _, err := env.txBuilder.NewUnsignedBaseTx(
+					100, &recipientOwner, []ids.ShortID{addr}, nil, nil, nil)
+				require.NoError(t, err)
+				return utx
+			},
+			expectedBurned: env.config.TxFee,
+		},
+		"Unknown utxo": {
+			utx: func(t *testing.T) txs.UnsignedTx {
+				return &txs.BaseTx{BaseTx: avax.BaseTx{
+					Ins: []*avax.TransferableInput{{
+						UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+						Asset:  avax.Asset{ID: env.ctx.AVAXAssetID},
+						In:     &secp256k1fx.TransferInput{Amt: 1},
+					}},
+				}}
+			},
+			expectedErr: errPreviewUTXONotFound,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			utx := tt.utx(t)
+			preview, err := env.txBuilder.Preview(utx)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			require.ElementsMatch(t, utx.InputIDs().List(), preview.ConsumedUTXOIDs)
+			require.Equal(t, tt.expectedBurned, preview.Burned)
+			produced := uint64(0)
+			for lockState, outs := range preview.Produced {
+				for _, out := range outs {
+					lockedOut, ok := out.Out.(*locked.Out)
+					if lockState == locked.StateUnlocked {
+						require.False(t, ok)
+					} else {
+						require.True(t, ok)
+						require.Equal(t, lockState, lockedOut.LockState())
+					}
+					produced += out.Out.Amount()
+				}
+			}
+			require.Equal(t, preview.Consumed, produced+preview.Burned)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// ClaimType is a bitmask of reward kinds that could be claimed with ClaimTx
+type ClaimType uint8
+
+const (
+	// Rewards of active (not yet expired) deposits
+	ClaimTypeActiveDepositReward ClaimType = 1 << iota
+	// Unclaimed rewards of already expired deposits
+	ClaimTypeExpiredDepositReward
+	// Validator rewards
+	ClaimTypeValidatorReward
+
+	ClaimTypeAll = ClaimTypeActiveDepositReward | ClaimTypeExpiredDepositReward | ClaimTypeValidatorReward
+)
+
+var (
+	errWrongClaimType                = errors.New("wrong claim type")
+	errDepositRewardsOwnerMismatch   = errors.New("deposit rewards owner doesn't match claim owner")
+	errClaimAmountTooBig             = errors.New("claim amount is bigger than claimable amount of requested type")
+	errExpiredRewardsBehindValidator = errors.New(
+		"can't claim only expired deposit rewards while owner has unclaimed validator rewards",
+	)
+	errWrongClaimAmountsLen     = errors.New("number of claimed amounts doesn't match number of claimable owners")
+	errEmptyClaimDepositOfferID = errors.New("claim deposit offer id is empty")
+)
+
+// ClaimRequest describes which rewards of a single owner will be claimed
+type ClaimRequest struct {
+	// ID of rewards owner, hash256 of its owner structure
+	OwnerID ids.ID
+	// Kinds of rewards that will be claimed
+	Type ClaimType
+	// Deposits which rewards will be claimed, used only if
+	// [Type] includes ClaimTypeActiveDepositReward.
+	// Rewards owner of each deposit must match [OwnerID].
+	DepositTxIDs []ids.ID
+	// Maximum amount of expired deposit and validator rewards to claim,
+	// zero means that all claimable amount of requested type will be claimed
+	Amount uint64
+}
+
+// ClaimDeposit describes new deposit, that all claimed rewards will be deposited with
+type ClaimDeposit struct {
+	// ID of active offer that will be used for new deposit
+	DepositOfferID ids.ID
+	// Duration of new deposit
+	Duration uint32
+	// Address that will receive new deposit rewards
+	RewardsAddress ids.ShortID
+}
+
+// Verify returns nil if [t] is a valid non-empty claim type
+func (t ClaimType) Verify() error {
+	if t == 0 || t&^ClaimTypeAll != 0 {
+		return errWrongClaimType
+	}
+	return nil
+}
+
+// Has returns true if [t] includes any of [other] types
+func (t ClaimType) Has(other ClaimType) bool {
+	return t&other != 0
+}
+
+// claimableAmount returns how much of [validatorReward] and [depositReward]
+// could be claimed with claim type [t]. ClaimTx executor always claims
+// validator rewards first, so expired deposit rewards can't be claimed
+// alone while there are validator rewards left.
+func (t ClaimType) claimableAmount(validatorReward, depositReward uint64) (uint64, error) {
+	switch {
+	case t.Has(ClaimTypeValidatorReward) && t.Has(ClaimTypeExpiredDepositReward):
+		return math.Add64(validatorReward, depositReward)
+	case t.Has(ClaimTypeValidatorReward):
+		return validatorReward, nil
+	case t.Has(ClaimTypeExpiredDepositReward):
+		if validatorReward > 0 {
+			return 0, errExpiredRewardsBehindValidator
+		}
+		return depositReward, nil
+	}
+	return 0, nil
+}
+
+// LegacyClaimRequests translates claim described with deposit tx ids,
+// claimable owner ids and amounts to claim, as ClaimTx describes it,
+// into claim requests. Active deposits rewards are requested for deposits
+// rewards owners, expired deposits and validator rewards
+// are requested for [claimableOwnerIDs]. Zero amounts are skipped.
+func LegacyClaimRequests(
+	chainState state.Chain,
+	depositTxIDs []ids.ID,
+	claimableOwnerIDs []ids.ID,
+	amountToClaim []uint64,
+) ([]ClaimRequest, error) {
+	if len(claimableOwnerIDs) != len(amountToClaim) {
+		return nil, errWrongClaimAmountsLen
+	}
+
+	claimRequests := []ClaimRequest{}
+	depositRequests := map[ids.ID]int{}
+	for _, depositTxID := range depositTxIDs {
+		deposit, err := chainState.GetDeposit(depositTxID)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get deposit %s: %w", depositTxID, err)
+		}
+		depositRewardsOwner, err := state.DepositRewardsOwner(chainState, depositTxID, deposit)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get rewards owner of deposit %s: %w", depositTxID, err)
+		}
+		ownerID, err := txs.GetOwnerID(depositRewardsOwner)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := depositRequests[ownerID]; ok {
+			claimRequests[i].DepositTxIDs = append(claimRequests[i].DepositTxIDs, depositTxID)
+			continue
+		}
+		depositRequests[ownerID] = len(claimRequests)
+		claimRequests = append(claimRequests, ClaimRequest{
+			OwnerID:      ownerID,
+			Type:         ClaimTypeActiveDepositReward,
+			DepositTxIDs: []ids.ID{depositTxID},
+		})
+	}
+
+	for i, ownerID := range claimableOwnerIDs {
+		if amountToClaim[i] == 0 {
+			continue
+		}
+		claimRequests = append(claimRequests, ClaimRequest{
+			OwnerID: ownerID,
+			Type:    ClaimTypeExpiredDepositReward | ClaimTypeValidatorReward,
+			Amount:  amountToClaim[i],
+		})
+	}
+	return claimRequests, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+)
+
+var errNoSuitableDepositOffer = errors.New("no active deposit offer admits requested amount and duration")
+
+// BestDepositOffer returns active unrestricted deposit offer, that admits deposit of [amount]
+// for [duration] and gives the biggest total reward for it.
+func (b *caminoBuilder) BestDepositOffer(amount uint64, duration uint32) (*deposit.Offer, error) {
+	offers, err := b.state.GetAllDepositOffers()
+	if err != nil {
+		return nil, err
+	}
+
+	chainTime := uint64(b.state.GetTimestamp().Unix())
+
+	var (
+		bestOffer  *deposit.Offer
+		bestReward uint64
+	)
+	for _, offer := range offers {
+		if !offerAdmits(offer, amount, duration, chainTime) {
+			continue
+		}
+		reward := (&deposit.Deposit{Amount: amount, Duration: duration}).TotalReward(offer)
+		// offers with the same reward are compared by id to get deterministic result
+		if bestOffer == nil || reward > bestReward ||
+			reward == bestReward && bytes.Compare(offer.ID[:], bestOffer.ID[:]) < 0 {
+			bestOffer = offer
+			bestReward = reward
+		}
+	}
+
+	if bestOffer == nil {
+		return nil, fmt.Errorf("%w: amount %d, duration %d", errNoSuitableDepositOffer, amount, duration)
+	}
+	return bestOffer, nil
+}
+
+// offerAdmits returns true if deposit of [amount] for [duration] could be
+// created with [offer] at [chainTime] without offer owner signature
+func offerAdmits(offer *deposit.Offer, amount uint64, duration uint32, chainTime uint64) bool {
+	return offer.Flags&deposit.OfferFlagLocked == 0 &&
+		!offer.IsPaused() &&
+		!offer.IsRestricted() &&
+		offer.Start <= chainTime && chainTime <= offer.End &&
+		offer.MinDuration <= duration && duration <= offer.MaxDuration &&
+		offer.AdmitsAmount(amount)
+}
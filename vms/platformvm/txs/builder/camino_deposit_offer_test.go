@@ -0,0 +1,111 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	gomock "github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+)
+
+func TestBestDepositOffer(t *testing.T) {
+	chainTime := uint64(1000)
+	offer := func(id byte, rate uint64, mutate func(*deposit.Offer)) *deposit.Offer {
+		offer := &deposit.Offer{
+			ID:                    ids.ID{id},
+			InterestRateNominator: rate,
+			Start:                 chainTime - 1,
+			End:                   chainTime + 1,
+			MinAmount:             10,
+			MinDuration:           100,
+			MaxDuration:           200,
+		}
+		if mutate != nil {
+			mutate(offer)
+		}
+		return offer
+	}
+
+	tests := map[string]struct {
+		offers          []*deposit.Offer
+		amount          uint64
+		duration        uint32
+		expectedOfferID ids.ID
+		expectedErr     error
+	}{
+		"Highest reward": {
+			offers:          []*deposit.Offer{offer(1, 100, nil), offer(2, 300, nil), offer(3, 200, nil)},
+			amount:          1_000_000_000,
+			duration:        150,
+			expectedOfferID: ids.ID{2},
+		},
+		"Same reward, smaller id wins": {
+			offers:          []*deposit.Offer{offer(3, 100, nil), offer(2, 100, nil)},
+			amount:          1_000_000_000,
+			duration:        150,
+			expectedOfferID: ids.ID{2},
+		},
+		"No-rewards period is taken into account": {
+			offers: []*deposit.Offer{
+				offer(1, 300, func(o *deposit.Offer) { o.NoRewardsPeriodDuration = 100 }),
+				offer(2, 200, nil),
+			},
+			amount:          1_000_000_000_000_000,
+			duration:        150,
+			expectedOfferID: ids.ID{2},
+		},
+		"Inadmissible offers are skipped": {
+			offers: []*deposit.Offer{
+				offer(1, 900, func(o *deposit.Offer) { o.Flags = deposit.OfferFlagLocked }),
+				offer(2, 900, func(o *deposit.Offer) { o.OwnerAddress = ids.ShortID{1} }),
+				offer(3, 900, func(o *deposit.Offer) { o.Start = chainTime + 1 }),
+				offer(4, 900, func(o *deposit.Offer) { o.End = chainTime - 1 }),
+				offer(5, 900, func(o *deposit.Offer) { o.MinDuration = 151 }),
+				offer(6, 900, func(o *deposit.Offer) { o.MaxDuration = 149 }),
+				offer(7, 900, func(o *deposit.Offer) { o.MinAmount = 1_000_000_001 }),
+				offer(8, 900, func(o *deposit.Offer) { o.TotalMaxAmount = 1_000_000_010; o.DepositedAmount = 11 }),
+				offer(9, 900, func(o *deposit.Offer) { o.MaxAmount = 999_999_999 }),
+				offer(10, 900, func(o *deposit.Offer) { o.Flags = deposit.OfferFlagPaused }),
+				offer(11, 100, nil),
+			},
+			amount:          1_000_000_000,
+			duration:        150,
+			expectedOfferID: ids.ID{11},
+		},
+		"No suitable offer": {
+			offers:      []*deposit.Offer{offer(1, 100, func(o *deposit.Offer) { o.Flags = deposit.OfferFlagLocked })},
+			amount:      1_000_000_000,
+			duration:    150,
+			expectedErr: errNoSuitableDepositOffer,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			s := state.NewMockState(ctrl)
+			b, db := newCaminoBuilderWithMocks(true, s, nil)
+			defer func() {
+				require.NoError(db.Close())
+				ctrl.Finish()
+			}()
+
+			s.EXPECT().GetAllDepositOffers().Return(tt.offers, nil)
+			s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+
+			offer, err := b.BestDepositOffer(tt.amount, tt.duration)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+			require.Equal(tt.expectedOfferID, offer.ID)
+		})
+	}
+}
@@ -139,9 +139,12 @@ func newCaminoEnvironment(postBanff bool, caminoGenesisConf api.Camino) *caminoE
 
 	atomicUTXOs := avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
 	uptimes := uptime.NewManager(baseState)
-	utxoHandler := utxo.NewCaminoHandler(ctx, &clk, baseState, fx, true)
+	utxoHandler, err := utxo.NewCaminoHandler(ctx, &clk, baseState, fx, true, 0, prometheus.NewRegistry())
+	if err != nil {
+		panic(err)
+	}
 
-	txBuilder := NewCamino(
+	txBuilder, err := NewCamino(
 		ctx,
 		&config,
 		&clk,
@@ -149,7 +152,12 @@ func newCaminoEnvironment(postBanff bool, caminoGenesisConf api.Camino) *caminoE
 		baseState,
 		atomicUTXOs,
 		utxoHandler,
+		"",
+		prometheus.NewRegistry(),
 	)
+	if err != nil {
+		panic(err)
+	}
 
 	backend := executor.Backend{
 		Config:       &config,
@@ -200,7 +208,7 @@ func newCaminoBuilder(postBanff bool, state state.State) (*caminoBuilder, *versi
 	atomicUTXOs := avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(ctx, &clk, state, fx)
 
-	txBuilder := NewCamino(
+	txBuilder, err := NewCamino(
 		ctx,
 		&config,
 		&clk,
@@ -208,7 +216,12 @@ func newCaminoBuilder(postBanff bool, state state.State) (*caminoBuilder, *versi
 		state,
 		atomicUTXOs,
 		utxoHandler,
+		"",
+		prometheus.NewRegistry(),
 	)
+	if err != nil {
+		panic(err)
+	}
 
 	caminoBuilder, ok := txBuilder.(*caminoBuilder)
 	if !ok {
@@ -526,6 +539,26 @@ func generateTestUTXO(txID ids.ID, assetID ids.ID, amount uint64, outputOwners s
 	return testUTXO
 }
 
+func generateTestOut(assetID ids.ID, amount uint64, outputOwners secp256k1fx.OutputOwners, depositTxID, bondTxID ids.ID) *avax.TransferableOutput {
+	var out avax.TransferableOut = &secp256k1fx.TransferOutput{
+		Amt:          amount,
+		OutputOwners: outputOwners,
+	}
+	if depositTxID != ids.Empty || bondTxID != ids.Empty {
+		out = &locked.Out{
+			IDs: locked.IDs{
+				DepositTxID: depositTxID,
+				BondTxID:    bondTxID,
+			},
+			TransferableOut: out,
+		}
+	}
+	return &avax.TransferableOutput{
+		Asset: avax.Asset{ID: assetID},
+		Out:   out,
+	}
+}
+
 func generateKeyAndOwner() (*crypto.PrivateKeySECP256K1R, ids.ShortID, secp256k1fx.OutputOwners) {
 	key, err := testKeyfactory.NewPrivateKey()
 	if err != nil {
@@ -598,7 +631,7 @@ func newCaminoBuilderWithMocks(postBanff bool, state state.State, sharedMemory a
 	atomicUTXOs := avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
 	utxoHandler := utxo.NewHandler(ctx, &clk, state, fx)
 
-	txBuilder := NewCamino(
+	txBuilder, err := NewCamino(
 		ctx,
 		&config,
 		&clk,
@@ -606,7 +639,12 @@ func newCaminoBuilderWithMocks(postBanff bool, state state.State, sharedMemory a
 		state,
 		atomicUTXOs,
 		utxoHandler,
+		"",
+		prometheus.NewRegistry(),
 	)
+	if err != nil {
+		panic(err)
+	}
 
 	caminoBuilder, ok := txBuilder.(*caminoBuilder)
 	if !ok {
@@ -624,7 +662,8 @@ func expectLock(s *state.MockState, allUTXOs map[ids.ShortID][]*avax.UTXO) {
 		}
 		s.EXPECT().UTXOIDs(addr.Bytes(), ids.Empty, math.MaxInt).Return(utxoids, nil)
 		for _, utxo := range utxos {
-			s.EXPECT().GetUTXO(utxo.InputID()).Return(utxo, nil)
+			// consumed utxos are also read by builder lock balance verification
+			s.EXPECT().GetUTXO(utxo.InputID()).Return(utxo, nil).MinTimes(1)
 			s.EXPECT().GetMultisigAlias(addr).Return(nil, database.ErrNotFound)
 		}
 	}
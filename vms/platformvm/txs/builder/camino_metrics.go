@@ -0,0 +1,113 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// Build steps, used as error class of build failures
+const (
+	buildStepSpend        = "spend"
+	buildStepBuildContext = "build_context"
+	buildStepSign         = "sign"
+	buildStepVerify       = "verify"
+)
+
+const metricsSubsystem = "tx_builder"
+
+type builderMetrics struct {
+	txsBuilt      *prometheus.CounterVec
+	buildFailures *prometheus.CounterVec
+	buildDuration prometheus.Histogram
+
+	lockInputs,
+	lockOutputs,
+	lockDuration metric.Averager
+}
+
+func newBuilderMetrics(
+	namespace string,
+	registerer prometheus.Registerer,
+) (*builderMetrics, error) {
+	errs := wrappers.Errs{}
+	m := &builderMetrics{
+		txsBuilt: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "txs_built",
+				Help:      "Number of txs built, by tx type",
+			},
+			[]string{"tx_type"},
+		),
+		buildFailures: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: metricsSubsystem,
+				Name:      "build_failures",
+				Help:      "Number of failed tx builds, by failed build step",
+			},
+			[]string{"step"},
+		),
+		buildDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: metricsSubsystem,
+			Name:      "build_duration",
+			Help:      "Time (in ns) of a whole tx build",
+			Buckets:   prometheus.ExponentialBuckets(float64(100*time.Microsecond), 4, 8),
+		}),
+		lockInputs: metric.NewAveragerWithErrs(
+			namespace,
+			metricsSubsystem+"_lock_inputs",
+			"number of inputs produced by lock",
+			registerer,
+			&errs,
+		),
+		lockOutputs: metric.NewAveragerWithErrs(
+			namespace,
+			metricsSubsystem+"_lock_outputs",
+			"number of outputs produced by lock",
+			registerer,
+			&errs,
+		),
+		lockDuration: metric.NewAveragerWithErrs(
+			namespace,
+			metricsSubsystem+"_lock_duration",
+			"time (in ns) of a lock",
+			registerer,
+			&errs,
+		),
+	}
+
+	errs.Add(
+		registerer.Register(m.txsBuilt),
+		registerer.Register(m.buildFailures),
+		registerer.Register(m.buildDuration),
+	)
+	return m, errs.Err
+}
+
+// markBuilt is called once [utx] build, that took [duration], passed syntactic verification
+func (m *builderMetrics) markBuilt(utx txs.UnsignedTx, duration time.Duration) {
+	m.txsBuilt.WithLabelValues(reflect.TypeOf(utx).Elem().Name()).Inc()
+	m.buildDuration.Observe(float64(duration))
+}
+
+func (m *builderMetrics) markFailed(step string) {
+	m.buildFailures.WithLabelValues(step).Inc()
+}
+
+func (m *builderMetrics) observeLock(numIns, numOuts int, duration time.Duration) {
+	m.lockInputs.Observe(float64(numIns))
+	m.lockOutputs.Observe(float64(numOuts))
+	m.lockDuration.Observe(float64(duration))
+}
@@ -0,0 +1,61 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+func TestBuilderMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	m, err := newBuilderMetrics("", registry)
+	require.NoError(err)
+
+	m.markBuilt(&txs.BaseTx{}, time.Millisecond)
+	m.markBuilt(&txs.BaseTx{}, time.Millisecond)
+	m.markBuilt(&txs.DepositTx{}, 2*time.Millisecond)
+	m.markFailed(buildStepSpend)
+	m.observeLock(2, 3, time.Second)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			name := family.GetName()
+			for _, label := range metric.Label {
+				name += "/" + label.GetValue()
+			}
+			switch {
+			case metric.Counter != nil:
+				values[name] = metric.Counter.GetValue()
+			case metric.Gauge != nil:
+				values[name] = metric.Gauge.GetValue()
+			case metric.Histogram != nil:
+				values[name+"_count"] = float64(metric.Histogram.GetSampleCount())
+				values[name+"_sum"] = metric.Histogram.GetSampleSum()
+			}
+		}
+	}
+	require.Equal(2.0, values["tx_builder_txs_built/BaseTx"])
+	require.Equal(1.0, values["tx_builder_txs_built/DepositTx"])
+	require.Equal(1.0, values["tx_builder_build_failures/spend"])
+	require.Equal(3.0, values["tx_builder_build_duration_count"])
+	require.Equal(float64(4*time.Millisecond), values["tx_builder_build_duration_sum"])
+	require.Equal(2.0, values["tx_builder_lock_inputs_sum"])
+	require.Equal(3.0, values["tx_builder_lock_outputs_sum"])
+	require.Equal(1.0, values["tx_builder_lock_duration_count"])
+
+	_, err = newBuilderMetrics("", registry)
+	require.Error(err)
+}
@@ -0,0 +1,105 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var errPreviewUTXONotFound = errors.New("couldn't find consumed utxo")
+
+// TxPreview describes what tx will do with funds, if it will be executed
+type TxPreview struct {
+	// IDs of utxos that will be consumed by tx
+	ConsumedUTXOIDs []ids.ID
+	// Total amount of AVAX that will be consumed by tx
+	Consumed uint64
+	// Outputs that will be produced by tx, grouped by their lock state
+	Produced map[locked.State][]*avax.TransferableOutput
+	// Outputs that will be exported to other chain
+	Exported []*avax.TransferableOutput
+	// Total amount of AVAX that will be burned by tx
+	Burned uint64
+}
+
+// Preview returns preview of unsigned tx [utx], without signing or issuing it.
+// All consumed utxos must be either in chain state or imported by tx.
+func (b *caminoBuilder) Preview(utx txs.UnsignedTx) (*TxPreview, error) {
+	preview := &TxPreview{
+		ConsumedUTXOIDs: utx.InputIDs().List(),
+		Produced:        make(map[locked.State][]*avax.TransferableOutput),
+	}
+	utils.Sort(preview.ConsumedUTXOIDs)
+
+	// imported utxos are in shared memory, so their amounts are taken from inputs
+	importedAmounts := map[ids.ID]uint64{}
+	if importTx, ok := utx.(*txs.ImportTx); ok {
+		for _, in := range importTx.ImportedInputs {
+			if in.AssetID() == b.ctx.AVAXAssetID {
+				importedAmounts[in.InputID()] = in.In.Amount()
+			}
+		}
+	}
+
+	for _, utxoID := range preview.ConsumedUTXOIDs {
+		amount, ok := importedAmounts[utxoID]
+		if !ok {
+			utxo, err := b.state.GetUTXO(utxoID)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %s", errPreviewUTXONotFound, utxoID, err)
+			}
+			amounter, ok := utxo.Out.(avax.Amounter)
+			if utxo.AssetID() != b.ctx.AVAXAssetID || !ok {
+				continue
+			}
+			amount = amounter.Amount()
+		}
+
+		newConsumed, err := math.Add64(preview.Consumed, amount)
+		if err != nil {
+			return nil, err
+		}
+		preview.Consumed = newConsumed
+	}
+
+	outs := utx.Outputs()
+	for _, out := range outs {
+		lockState := locked.StateUnlocked
+		if lockedOut, ok := locked.AsOut(out.Out); ok {
+			lockState = lockedOut.LockState()
+		}
+		preview.Produced[lockState] = append(preview.Produced[lockState], out)
+	}
+
+	if exportTx, ok := utx.(*txs.ExportTx); ok {
+		preview.Exported = exportTx.ExportedOutputs
+		outs = append(outs[:len(outs):len(outs)], exportTx.ExportedOutputs...)
+	}
+
+	produced := uint64(0)
+	for _, out := range outs {
+		if out.AssetID() != b.ctx.AVAXAssetID {
+			continue
+		}
+		newProduced, err := math.Add64(produced, out.Out.Amount())
+		if err != nil {
+			return nil, err
+		}
+		produced = newProduced
+	}
+
+	// Some system txs (e.g. rewards) produce more than they consume
+	if preview.Consumed > produced {
+		preview.Burned = preview.Consumed - produced
+	}
+	return preview, nil
+}
@@ -30,7 +30,7 @@ type CaminoAddValidatorTx struct {
 func (tx *CaminoAddValidatorTx) Stake() []*avax.TransferableOutput {
 	var stake []*avax.TransferableOutput
 	for _, out := range tx.Outs {
-		if lockedOut, ok := out.Out.(*locked.Out); ok && lockedOut.IsNewlyLockedWith(locked.StateBonded) {
+		if lockedOut, ok := locked.AsOut(out.Out); ok && lockedOut.IsNewlyLockedWith(locked.StateBonded) {
 			stake = append(stake, out)
 		}
 	}
@@ -59,7 +59,7 @@ func (tx *CaminoAddValidatorTx) SyntacticVerify(ctx *snow.Context) error {
 
 	totalStakeWeight := uint64(0)
 	for _, out := range tx.Outs {
-		lockedOut, ok := out.Out.(*locked.Out)
+		lockedOut, ok := locked.AsOut(out.Out)
 		if ok && lockedOut.IsNewlyLockedWith(locked.StateBonded) {
 			newWeight, err := math.Add64(totalStakeWeight, lockedOut.Amount())
 			if err != nil {
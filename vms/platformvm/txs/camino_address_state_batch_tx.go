@@ -0,0 +1,75 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+)
+
+var (
+	_ UnsignedTx = (*AddressStateBatchTx)(nil)
+
+	errNoAddressStateOps        = errors.New("no address state operations")
+	errNonUniqueAddressStateOps = errors.New("non-unique address state operation")
+)
+
+// AddressStateOp describes single change of address state
+type AddressStateOp struct {
+	// The state to set / unset
+	State as.AddressStateBit `serialize:"true" json:"state"`
+	// Remove or add the flag ?
+	Remove bool `serialize:"true" json:"remove"`
+}
+
+// AddressStateBatchTx is an unsigned AddressStateBatchTx.
+// It atomically applies multiple state changes to a single address.
+type AddressStateBatchTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// The address to add / remove states
+	Address ids.ShortID `serialize:"true" json:"address"`
+	// State changes, applied in order. Each state could be changed only once.
+	Ops []AddressStateOp `serialize:"true" json:"ops"`
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *AddressStateBatchTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.Address == ids.ShortEmpty:
+		return ErrEmptyAddress
+	case len(tx.Ops) == 0:
+		return errNoAddressStateOps
+	}
+
+	states := as.AddressStateEmpty
+	for _, op := range tx.Ops {
+		if !op.State.IsValid() {
+			return ErrInvalidState
+		}
+		stateBit := op.State.ToAddressState()
+		if states.Has(stateBit) {
+			return errNonUniqueAddressStateOps
+		}
+		states = states.Set(stateBit)
+	}
+
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	return tx.BaseTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddressStateBatchTx) Visit(visitor Visitor) error {
+	return visitor.AddressStateBatchTx(tx)
+}
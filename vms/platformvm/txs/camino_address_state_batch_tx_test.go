@@ -0,0 +1,105 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddressStateBatchTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	address := ids.ShortID{1}
+	owner := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{address}}
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *AddressStateBatchTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Empty address": {
+			tx: &AddressStateBatchTx{
+				BaseTx: baseTx,
+				Ops:    []AddressStateOp{{State: as.AddressStateBitKycVerified}},
+			},
+			expectedErr: ErrEmptyAddress,
+		},
+		"No ops": {
+			tx: &AddressStateBatchTx{
+				BaseTx:  baseTx,
+				Address: address,
+			},
+			expectedErr: errNoAddressStateOps,
+		},
+		"Invalid state": {
+			tx: &AddressStateBatchTx{
+				BaseTx:  baseTx,
+				Address: address,
+				Ops: []AddressStateOp{
+					{State: as.AddressStateBitKycVerified},
+					{State: as.AddressStateBitMax},
+				},
+			},
+			expectedErr: ErrInvalidState,
+		},
+		"Non-unique ops": {
+			tx: &AddressStateBatchTx{
+				BaseTx:  baseTx,
+				Address: address,
+				Ops: []AddressStateOp{
+					{State: as.AddressStateBitKycVerified},
+					{State: as.AddressStateBitKycVerified, Remove: true},
+				},
+			},
+			expectedErr: errNonUniqueAddressStateOps,
+		},
+		"Locked output": {
+			tx: &AddressStateBatchTx{
+				BaseTx: BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+					Outs: []*avax.TransferableOutput{{
+						Asset: avax.Asset{ID: ctx.AVAXAssetID},
+						Out: &locked.Out{
+							IDs:             locked.IDsEmpty,
+							TransferableOut: &secp256k1fx.TransferOutput{Amt: 1, OutputOwners: owner},
+						},
+					}},
+				}},
+				Address: address,
+				Ops:     []AddressStateOp{{State: as.AddressStateBitKycVerified}},
+			},
+			expectedErr: locked.ErrWrongOutType,
+		},
+		"OK": {
+			tx: &AddressStateBatchTx{
+				BaseTx:  baseTx,
+				Address: address,
+				Ops: []AddressStateOp{
+					{State: as.AddressStateBitKycExpired, Remove: true},
+					{State: as.AddressStateBitKycVerified},
+				},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
@@ -8,33 +8,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 )
 
-// AddressState flags, max 63
-const (
-	AddressStateRoleAdmin    = uint8(0)
-	AddressStateRoleAdminBit = uint64(0b1)
-	AddressStateRoleKyc      = uint8(1)
-	AddressStateRoleKycBit   = uint64(0b10)
-	AddressStateRoleBits     = uint64(0b11)
-
-	AddressStateKycVerified    = uint8(32)
-	AddressStateKycVerifiedBit = uint64(0b0100000000000000000000000000000000)
-	AddressStateKycExpired     = uint8(33)
-	AddressStateKycExpiredBit  = uint64(0b1000000000000000000000000000000000)
-	AddressStateKycBits        = uint64(0b1100000000000000000000000000000000)
-
-	AddressStateConsortium      = uint8(38)
-	AddressStateConsortiumBit   = uint64(0b0100000000000000000000000000000000000000)
-	AddressStateNodeDeferred    = uint8(39)
-	AddressStateNodeDeferredBit = uint64(0b1000000000000000000000000000000000000000)
-	AddressStateVoteBits        = uint64(0b1100000000000000000000000000000000000000)
-
-	AddressStateMax       = uint8(63)
-	AddressStateValidBits = AddressStateRoleBits | AddressStateKycBits | AddressStateVoteBits
-)
-
 var (
 	_ UnsignedTx = (*AddressStateTx)(nil)
 
@@ -49,7 +26,7 @@ type AddressStateTx struct {
 	// The address to add / remove state
 	Address ids.ShortID `serialize:"true" json:"address"`
 	// The state to set / unset
-	State uint8 `serialize:"true" json:"state"`
+	State as.AddressStateBit `serialize:"true" json:"state"`
 	// Remove or add the flag ?
 	Remove bool `serialize:"true" json:"remove"`
 }
@@ -63,7 +40,7 @@ func (tx *AddressStateTx) SyntacticVerify(ctx *snow.Context) error {
 		return nil
 	case tx.Address == ids.ShortEmpty:
 		return ErrEmptyAddress
-	case tx.State > AddressStateMax || AddressStateValidBits&(uint64(1)<<tx.State) == 0:
+	case !tx.State.IsValid():
 		return ErrInvalidState
 	}
 
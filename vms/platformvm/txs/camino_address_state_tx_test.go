@@ -10,6 +10,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -90,7 +91,7 @@ func TestAddressStateTxSyntacticVerify(t *testing.T) {
 			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
 		}},
 		Address: preFundedKeys[0].PublicKey().Address(),
-		State:   AddressStateRoleAdmin,
+		State:   as.AddressStateBitRoleAdmin,
 		Remove:  false,
 	}
 
@@ -104,7 +105,7 @@ func TestAddressStateTxSyntacticVerify(t *testing.T) {
 			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
 		}},
 		Address: preFundedKeys[0].PublicKey().Address(),
-		State:   AddressStateRoleAdmin,
+		State:   as.AddressStateBitRoleAdmin,
 		Remove:  false,
 	}
 
@@ -118,7 +119,7 @@ func TestAddressStateTxSyntacticVerify(t *testing.T) {
 			Memo:         []byte{1, 2, 3, 4, 5, 6, 7, 8},
 		}},
 		Address: preFundedKeys[0].PublicKey().Address(),
-		State:   AddressStateRoleAdmin,
+		State:   as.AddressStateBitRoleAdmin,
 		Remove:  false,
 	}
 
@@ -143,7 +144,16 @@ func TestAddressStateTxSyntacticVerify(t *testing.T) {
 	require.NoError(err)
 	err = stx.SyntacticVerify(ctx)
 	require.Error(err, ErrInvalidState)
-	addressStateTx.State = AddressStateRoleAdmin
+	addressStateTx.State = as.AddressStateBitRoleAdmin
+
+	// Memo too long
+	addressStateTx.SyntacticallyVerified = false
+	addressStateTx.Memo = make([]byte, avax.MaxMemoSize+1)
+	stx, err = NewSigned(addressStateTx, Codec, signers)
+	require.NoError(err)
+	err = stx.SyntacticVerify(ctx)
+	require.Error(err)
+	addressStateTx.Memo = []byte{1, 2, 3, 4, 5, 6, 7, 8}
 
 	// Locked out
 	stx, err = NewSigned(addressStateTxLocked, Codec, signers)
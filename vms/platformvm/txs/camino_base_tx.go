@@ -3,10 +3,6 @@
 
 package txs
 
-import "errors"
-
-var errNonExecutableTx = errors.New("this tx type can't be executed, its genesis-only")
-
-func (*BaseTx) Visit(Visitor) error {
-	return errNonExecutableTx
+func (tx *BaseTx) Visit(visitor Visitor) error {
+	return visitor.BaseTx(tx)
 }
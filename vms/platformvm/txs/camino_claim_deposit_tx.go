@@ -0,0 +1,60 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+var _ UnsignedTx = (*ClaimDepositTx)(nil)
+
+// ClaimDepositTx is an unsigned claimDepositTx.
+// It claims rewards the same way as ClaimTx, but claimed outputs are minted deposited
+// with new deposit, created by this tx. Deposit amount is total claimed amount.
+// If deposit offer is restricted, offer owner credential is the last one
+// and claimables credential is the last one before it.
+type ClaimDepositTx struct {
+	// Claim of rewards, that will be deposited
+	ClaimTx `serialize:"true"`
+	// ID of active offer that will be used for new deposit
+	DepositOfferID ids.ID `serialize:"true" json:"depositOfferID"`
+	// duration of new deposit
+	DepositDuration uint32 `serialize:"true" json:"duration"`
+	// Where to send new deposit rewards
+	RewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [ClaimDepositTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *ClaimDepositTx) InitCtx(ctx *snow.Context) {
+	tx.ClaimTx.InitCtx(ctx)
+	tx.RewardsOwner.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *ClaimDepositTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	}
+
+	if err := verify.All(tx.RewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify rewards owner: %w", err)
+	}
+
+	// caches that this is valid
+	return tx.ClaimTx.SyntacticVerify(ctx)
+}
+
+func (tx *ClaimDepositTx) Visit(visitor Visitor) error {
+	return visitor.ClaimDepositTx(tx)
+}
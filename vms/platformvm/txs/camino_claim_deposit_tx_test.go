@@ -0,0 +1,63 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimDepositTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	owner1 := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *ClaimDepositTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Bad claim": {
+			tx: &ClaimDepositTx{
+				ClaimTx: ClaimTx{
+					BaseTx:  baseTx,
+					ClaimTo: &owner1,
+				},
+				DepositOfferID: ids.GenerateTestID(),
+				RewardsOwner:   &owner1,
+			},
+			expectedErr: errNoDepositsOrClaimables,
+		},
+		"OK": {
+			tx: &ClaimDepositTx{
+				ClaimTx: ClaimTx{
+					BaseTx:       baseTx,
+					DepositTxIDs: []ids.ID{ids.GenerateTestID()},
+					ClaimTo:      &owner1,
+				},
+				DepositOfferID: ids.GenerateTestID(),
+				RewardsOwner:   &owner1,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
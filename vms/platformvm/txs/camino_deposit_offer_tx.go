@@ -0,0 +1,110 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+)
+
+var (
+	_ UnsignedTx = (*DepositOfferTx)(nil)
+
+	errNilDepositOffer            = errors.New("deposit offer is nil")
+	errEmptyAdminAddress          = errors.New("admin address is empty")
+	errNilAdminAuth               = errors.New("admin auth is nil")
+	errNotZeroDepositedAmount     = errors.New("deposit offer deposited amount is not zero")
+	errNotZeroDepositOfferVersion = errors.New("new deposit offer version is not zero")
+)
+
+// DepositOfferTx is an unsigned depositOfferTx.
+// It creates new deposit offer or updates existing one, if [DepositOfferID] isn't empty.
+// New offer id is computed from offer fields.
+// Last credential of this tx must be signed by [AdminAddress], that must have admin role.
+type DepositOfferTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of deposit offer, that will be updated. Empty id means that new offer will be created
+	DepositOfferID ids.ID `serialize:"true" json:"depositOfferID"`
+	// Version of updated deposit offer, must match its current version in state
+	DepositOfferVersion uint64 `serialize:"true" json:"depositOfferVersion"`
+	// Deposit offer, that will be created or will replace updated offer.
+	// Its id, version and deposited amount are ignored
+	DepositOffer *deposit.Offer `serialize:"true" json:"depositOffer"`
+	// Deposit offer fields, that aren't serialized as part of offer
+	DepositOfferExtension deposit.OfferExtension `serialize:"true" json:"depositOfferExtension"`
+	// Address of admin, that creates or updates offer
+	AdminAddress ids.ShortID `serialize:"true" json:"adminAddress"`
+	// Auth that will be used to verify credential for [AdminAddress].
+	// If [AdminAddress] is msig-alias, auth must match real signatures.
+	AdminAuth verify.Verifiable `serialize:"true" json:"adminAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [DepositOfferTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *DepositOfferTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *DepositOfferTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.DepositOffer == nil:
+		return errNilDepositOffer
+	case tx.AdminAddress == ids.ShortEmpty:
+		return errEmptyAdminAddress
+	case tx.AdminAuth == nil:
+		return errNilAdminAuth
+	case tx.DepositOffer.DepositedAmount != 0:
+		return errNotZeroDepositedAmount
+	case tx.DepositOfferID == ids.Empty && tx.DepositOfferVersion != 0:
+		return errNotZeroDepositOfferVersion
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+
+	if err := tx.AdminAuth.Verify(); err != nil {
+		return fmt.Errorf("failed to verify admin auth: %w", err)
+	}
+
+	if err := tx.Offer().Verify(); err != nil {
+		return err
+	}
+
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+// Offer returns deposit offer defined by this tx with fields from its extension.
+// Offer id and version are taken from tx, so offer id is empty, if tx creates new offer.
+func (tx *DepositOfferTx) Offer() *deposit.Offer {
+	offer := *tx.DepositOffer
+	offer.Tiers = nil
+	tx.DepositOfferExtension.ApplyTo(&offer)
+	offer.ID = tx.DepositOfferID
+	offer.Version = tx.DepositOfferVersion
+	return &offer
+}
+
+func (tx *DepositOfferTx) Visit(visitor Visitor) error {
+	return visitor.DepositOfferTx(tx)
+}
@@ -0,0 +1,183 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositOfferTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	owner1 := secp256k1fx.OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{1}}}
+	adminAddress := ids.ShortID{2}
+	avaxAssetID := ids.ID{5}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	offer := &deposit.Offer{
+		InterestRateNominator: 80_000,
+		Start:                 100,
+		End:                   200,
+		MinAmount:             1,
+		MinDuration:           10,
+		MaxDuration:           20,
+	}
+
+	tests := map[string]struct {
+		tx          *DepositOfferTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Nil deposit offer": {
+			tx: &DepositOfferTx{
+				BaseTx:       baseTx,
+				AdminAddress: adminAddress,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+			expectedErr: errNilDepositOffer,
+		},
+		"Empty admin address": {
+			tx: &DepositOfferTx{
+				BaseTx:       baseTx,
+				DepositOffer: offer,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+			expectedErr: errEmptyAdminAddress,
+		},
+		"Nil admin auth": {
+			tx: &DepositOfferTx{
+				BaseTx:       baseTx,
+				DepositOffer: offer,
+				AdminAddress: adminAddress,
+			},
+			expectedErr: errNilAdminAuth,
+		},
+		"Not zero deposited amount": {
+			tx: &DepositOfferTx{
+				BaseTx: baseTx,
+				DepositOffer: &deposit.Offer{
+					InterestRateNominator: 80_000,
+					Start:                 100,
+					End:                   200,
+					MinAmount:             1,
+					MinDuration:           10,
+					MaxDuration:           20,
+					DepositedAmount:       1,
+				},
+				AdminAddress: adminAddress,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+			expectedErr: errNotZeroDepositedAmount,
+		},
+		"Not zero version of new offer": {
+			tx: &DepositOfferTx{
+				BaseTx:              baseTx,
+				DepositOfferVersion: 1,
+				DepositOffer:        offer,
+				AdminAddress:        adminAddress,
+				AdminAuth:           &secp256k1fx.Input{},
+			},
+			expectedErr: errNotZeroDepositOfferVersion,
+		},
+		"Locked base tx input": {
+			tx: &DepositOfferTx{
+				BaseTx: BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+					Ins: []*avax.TransferableInput{
+						generateTestIn(avaxAssetID, 1, ids.ID{3}, ids.Empty, []uint32{0}),
+					},
+				}},
+				DepositOffer: offer,
+				AdminAddress: adminAddress,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+			expectedErr: locked.ErrWrongInType,
+		},
+		"Locked base tx output": {
+			tx: &DepositOfferTx{
+				BaseTx: BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+					Outs: []*avax.TransferableOutput{
+						generateTestOut(avaxAssetID, 1, owner1, ids.ID{3}, ids.Empty),
+					},
+				}},
+				DepositOffer: offer,
+				AdminAddress: adminAddress,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+			expectedErr: locked.ErrWrongOutType,
+		},
+		"OK: create": {
+			tx: &DepositOfferTx{
+				BaseTx:       baseTx,
+				DepositOffer: offer,
+				DepositOfferExtension: deposit.OfferExtension{
+					Tiers: []deposit.InterestRateTier{{MinAmount: 10, InterestRateNominator: 90_000}},
+				},
+				AdminAddress: adminAddress,
+				AdminAuth:    &secp256k1fx.Input{},
+			},
+		},
+		"OK: update": {
+			tx: &DepositOfferTx{
+				BaseTx:              baseTx,
+				DepositOfferID:      ids.ID{4},
+				DepositOfferVersion: 1,
+				DepositOffer:        offer,
+				AdminAddress:        adminAddress,
+				AdminAuth:           &secp256k1fx.Input{},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
+
+func TestDepositOfferTxOffer(t *testing.T) {
+	tx := &DepositOfferTx{
+		DepositOfferID:      ids.ID{1},
+		DepositOfferVersion: 2,
+		DepositOffer: &deposit.Offer{
+			InterestRateNominator: 80_000,
+			Start:                 100,
+			End:                   200,
+			MinAmount:             1,
+			MinDuration:           10,
+			MaxDuration:           20,
+		},
+		DepositOfferExtension: deposit.OfferExtension{
+			Tiers: []deposit.InterestRateTier{{MinAmount: 10, InterestRateNominator: 90_000}},
+		},
+	}
+
+	require.Equal(t, &deposit.Offer{
+		ID:                    ids.ID{1},
+		Version:               2,
+		Tiers:                 []deposit.InterestRateTier{{MinAmount: 10, InterestRateNominator: 90_000}},
+		InterestRateNominator: 80_000,
+		Start:                 100,
+		End:                   200,
+		MinAmount:             1,
+		MinDuration:           10,
+		MaxDuration:           20,
+	}, tx.Offer())
+}
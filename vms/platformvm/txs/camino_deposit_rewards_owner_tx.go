@@ -0,0 +1,71 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+)
+
+var (
+	_ UnsignedTx = (*DepositRewardsOwnerTx)(nil)
+
+	errEmptyDepositTxID = errors.New("deposit tx id is empty")
+)
+
+// DepositRewardsOwnerTx is an unsigned depositRewardsOwnerTx.
+// It changes rewards owner of existing deposit without unlocking it.
+// Last credential of this tx must be signed by the current deposit rewards owner.
+type DepositRewardsOwnerTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of deposit tx, which deposit rewards owner will be changed
+	DepositTxID ids.ID `serialize:"true" json:"depositTxID"`
+	// New owner of deposit rewards
+	RewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [DepositRewardsOwnerTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *DepositRewardsOwnerTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.RewardsOwner.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *DepositRewardsOwnerTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.DepositTxID == ids.Empty:
+		return errEmptyDepositTxID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := tx.RewardsOwner.Verify(); err != nil {
+		return fmt.Errorf("failed to verify rewards owner: %w", err)
+	}
+
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *DepositRewardsOwnerTx) Visit(visitor Visitor) error {
+	return visitor.DepositRewardsOwnerTx(tx)
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDepositRewardsOwnerTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+	owner1 := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *DepositRewardsOwnerTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Empty deposit tx id": {
+			tx: &DepositRewardsOwnerTx{
+				BaseTx:       baseTx,
+				RewardsOwner: &owner1,
+			},
+			expectedErr: errEmptyDepositTxID,
+		},
+		"Locked base tx output": {
+			tx: &DepositRewardsOwnerTx{
+				BaseTx: BaseTx{BaseTx: avax.BaseTx{
+					NetworkID:    ctx.NetworkID,
+					BlockchainID: ctx.ChainID,
+					Outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 1, owner1, ids.GenerateTestID(), ids.Empty),
+					},
+				}},
+				DepositTxID:  ids.GenerateTestID(),
+				RewardsOwner: &owner1,
+			},
+			expectedErr: locked.ErrWrongOutType,
+		},
+		"OK": {
+			tx: &DepositRewardsOwnerTx{
+				BaseTx:       baseTx,
+				DepositTxID:  ids.GenerateTestID(),
+				RewardsOwner: &owner1,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
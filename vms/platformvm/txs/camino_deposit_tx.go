@@ -43,7 +43,7 @@ func (tx *DepositTx) Duration() uint32 {
 func (tx *DepositTx) DepositAmount() (uint64, error) {
 	depositAmount := uint64(0)
 	for _, out := range tx.Outs {
-		if lockedOut, ok := out.Out.(*locked.Out); ok && lockedOut.IsNewlyLockedWith(locked.StateDeposited) {
+		if lockedOut, ok := locked.AsOut(out.Out); ok && lockedOut.IsNewlyLockedWith(locked.StateDeposited) {
 			newDepositAmount, err := math.Add64(depositAmount, lockedOut.Amount())
 			if err != nil {
 				return 0, err
@@ -4,16 +4,25 @@
 package txs
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 )
 
-var _ UnsignedTx = (*MultisigAliasTx)(nil)
+var (
+	_ UnsignedTx = (*MultisigAliasTx)(nil)
 
-// MultisigAliasTx is an unsigned multisig alias tx
+	errNilChangeAuth  = errors.New("change auth is nil")
+	errNilAliasOwners = errors.New("alias owners are nil")
+)
+
+// MultisigAliasTx is an unsigned multisig alias tx.
+// It creates new multisig alias or changes owners and memo of existing one.
+// If alias is changed, last credential of this tx must be signed by the current alias owners.
 type MultisigAliasTx struct {
 	// Metadata, inputs and outputs
 	BaseTx `serialize:"true"`
@@ -38,6 +47,10 @@ func (tx *MultisigAliasTx) SyntacticVerify(ctx *snow.Context) error {
 		return ErrNilTx
 	case tx.SyntacticallyVerified: // already passed syntactic verification
 		return nil
+	case tx.ChangeAuth == nil:
+		return errNilChangeAuth
+	case tx.MultisigAlias.Owners == nil:
+		return errNilAliasOwners
 	}
 
 	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
@@ -47,11 +60,15 @@ func (tx *MultisigAliasTx) SyntacticVerify(ctx *snow.Context) error {
 		return fmt.Errorf("failed to verify owner or change auth: %w", err)
 	}
 
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
 	// cache that this is valid
 	tx.SyntacticallyVerified = true
 	return nil
 }
 
-func (*MultisigAliasTx) Visit(_ Visitor) error {
-	return errNonExecutableTx
+func (tx *MultisigAliasTx) Visit(visitor Visitor) error {
+	return visitor.MultisigAliasTx(tx)
 }
@@ -0,0 +1,196 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	errWrongSignaturesLen     = errors.New("signatures len doesn't match signers len")
+	errWrongSignatureIndex    = errors.New("signature index is out of bounds")
+	ErrWrongSignatureSigner   = errors.New("signature doesn't belong to expected signer")
+	errPartiallySignedTxDiffs = errors.New("partially signed txs have different unsigned txs")
+	errMissingSignatures      = errors.New("tx doesn't have all required signatures yet")
+
+	emptySig = [crypto.SECP256K1RSigLen]byte{}
+)
+
+// PartiallySignedTx is a serializable container that holds unsigned tx
+// together with signatures collected so far. It allows multiple parties
+// (e.g. owners of multisig alias) to sign the same tx independently
+// and merge their signatures before tx is issued.
+type PartiallySignedTx struct {
+	// Unsigned tx that is being signed
+	Unsigned UnsignedTx `serialize:"true" json:"unsignedTx"`
+	// Signers[i][j] is the address which signature must be
+	// the j-th signature of the i-th tx credential
+	Signers [][]ids.ShortID `serialize:"true" json:"signers"`
+	// Sigs[i][j] is the j-th signature of the i-th tx credential,
+	// empty (zeroed) signature means that it's not collected yet
+	Sigs [][][crypto.SECP256K1RSigLen]byte `serialize:"true" json:"signatures"`
+
+	hash []byte
+}
+
+// NewPartiallySignedTx creates partially signed tx without any signatures
+func NewPartiallySignedTx(c codec.Manager, utx UnsignedTx, manifest *SigningManifest) (*PartiallySignedTx, error) {
+	ptx := &PartiallySignedTx{
+		Unsigned: utx,
+		Signers:  manifest.Signers,
+		Sigs:     make([][][crypto.SECP256K1RSigLen]byte, len(manifest.Signers)),
+	}
+	for i := range ptx.Signers {
+		ptx.Sigs[i] = make([][crypto.SECP256K1RSigLen]byte, len(ptx.Signers[i]))
+	}
+	return ptx, ptx.Initialize(c)
+}
+
+// ParsePartiallySignedTx parses partially signed tx from its byte representation
+func ParsePartiallySignedTx(c codec.Manager, bytes []byte) (*PartiallySignedTx, error) {
+	ptx := &PartiallySignedTx{}
+	if _, err := c.Unmarshal(bytes, ptx); err != nil {
+		return nil, fmt.Errorf("couldn't parse partially signed tx: %w", err)
+	}
+	return ptx, ptx.Initialize(c)
+}
+
+// Initialize initializes unsigned tx bytes and verifies
+// that signatures layout matches signers layout
+func (ptx *PartiallySignedTx) Initialize(c codec.Manager) error {
+	if len(ptx.Sigs) != len(ptx.Signers) {
+		return errWrongSignaturesLen
+	}
+	for i := range ptx.Signers {
+		if len(ptx.Sigs[i]) != len(ptx.Signers[i]) {
+			return errWrongSignaturesLen
+		}
+	}
+
+	unsignedBytes, err := c.Marshal(Version, &ptx.Unsigned)
+	if err != nil {
+		return fmt.Errorf("couldn't marshal UnsignedTx: %w", err)
+	}
+	ptx.Unsigned.Initialize(unsignedBytes)
+	ptx.hash = SigningHash(unsignedBytes)
+	return nil
+}
+
+// Bytes returns byte representation of partially signed tx
+func (ptx *PartiallySignedTx) Bytes(c codec.Manager) ([]byte, error) {
+	return c.Marshal(Version, ptx)
+}
+
+// Sign adds signatures of all [keys] that are required signers of this tx
+func (ptx *PartiallySignedTx) Sign(keys ...*crypto.PrivateKeySECP256K1R) error {
+	for _, key := range keys {
+		addr := key.Address()
+		for i := range ptx.Signers {
+			for j, signer := range ptx.Signers[i] {
+				if signer != addr {
+					continue
+				}
+				sig, err := key.SignHash(ptx.hash)
+				if err != nil {
+					return fmt.Errorf("problem generating signature: %w", err)
+				}
+				copy(ptx.Sigs[i][j][:], sig)
+			}
+		}
+	}
+	return nil
+}
+
+// AddSignature adds [sig] as the [sigIndex] signature of the [credIndex]
+// credential, if it was made by expected signer
+func (ptx *PartiallySignedTx) AddSignature(credIndex, sigIndex int, sig []byte) error {
+	if credIndex < 0 || credIndex >= len(ptx.Signers) ||
+		sigIndex < 0 || sigIndex >= len(ptx.Signers[credIndex]) {
+		return errWrongSignatureIndex
+	}
+
+	factory := crypto.FactorySECP256K1R{}
+	pk, err := factory.RecoverHashPublicKey(ptx.hash, sig)
+	if err != nil {
+		return err
+	}
+	if pk.Address() != ptx.Signers[credIndex][sigIndex] {
+		return ErrWrongSignatureSigner
+	}
+
+	copy(ptx.Sigs[credIndex][sigIndex][:], sig)
+	return nil
+}
+
+// Merge adds signatures collected in [other] to this tx.
+// Both txs must have the same unsigned tx.
+func (ptx *PartiallySignedTx) Merge(other *PartiallySignedTx) error {
+	if string(ptx.Unsigned.Bytes()) != string(other.Unsigned.Bytes()) ||
+		len(ptx.Sigs) != len(other.Sigs) {
+		return errPartiallySignedTxDiffs
+	}
+	for i := range other.Sigs {
+		if len(ptx.Sigs[i]) != len(other.Sigs[i]) {
+			return errPartiallySignedTxDiffs
+		}
+		for j := range other.Sigs[i] {
+			if other.Sigs[i][j] == emptySig || ptx.Sigs[i][j] != emptySig {
+				continue
+			}
+			if err := ptx.AddSignature(i, j, other.Sigs[i][j][:]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MissingSigners returns addresses which signatures are still missing
+func (ptx *PartiallySignedTx) MissingSigners() []ids.ShortID {
+	missingSigners := []ids.ShortID{}
+	for i := range ptx.Sigs {
+		for j := range ptx.Sigs[i] {
+			if ptx.Sigs[i][j] == emptySig {
+				missingSigners = append(missingSigners, ptx.Signers[i][j])
+			}
+		}
+	}
+	return missingSigners
+}
+
+// IsComplete returns true if all required signatures are collected
+func (ptx *PartiallySignedTx) IsComplete() bool {
+	return len(ptx.MissingSigners()) == 0
+}
+
+// Finalize returns signed tx, if all required signatures are collected
+func (ptx *PartiallySignedTx) Finalize(c codec.Manager) (*Tx, error) {
+	if !ptx.IsComplete() {
+		return nil, errMissingSignatures
+	}
+
+	tx := &Tx{
+		Unsigned: ptx.Unsigned,
+		Creds:    make([]verify.Verifiable, len(ptx.Sigs)),
+	}
+	for i := range ptx.Sigs {
+		tx.Creds[i] = &secp256k1fx.Credential{
+			Sigs: append([][crypto.SECP256K1RSigLen]byte(nil), ptx.Sigs[i]...),
+		}
+	}
+
+	signedBytes, err := c.Marshal(Version, tx)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't marshal tx: %w", err)
+	}
+	tx.Initialize(ptx.Unsigned.Bytes(), signedBytes)
+	return tx, nil
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestPartiallySignedTx(t *testing.T) {
+	require := require.New(t)
+
+	key1, key2, key3 := caminoPreFundedKeys[0], caminoPreFundedKeys[1], caminoPreFundedKeys[2]
+	utx := &AddressStateTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    1,
+			BlockchainID: ids.ID{1},
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.ID{1}},
+				Asset:  avax.Asset{ID: ids.ID{2}},
+				In: &secp256k1fx.TransferInput{
+					Amt:   10,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+				},
+			}},
+		}},
+		Address: key3.Address(),
+	}
+	signers := [][]*crypto.PrivateKeySECP256K1R{{key1, key2}}
+
+	expectedTx, err := NewSigned(utx, Codec, signers)
+	require.NoError(err)
+
+	ptx1, err := NewPartiallySignedTx(Codec, utx, NewSigningManifest(signers))
+	require.NoError(err)
+	require.False(ptx1.IsComplete())
+	require.Equal([]ids.ShortID{key1.Address(), key2.Address()}, ptx1.MissingSigners())
+	_, err = ptx1.Finalize(Codec)
+	require.ErrorIs(err, errMissingSignatures)
+
+	// first party signs and serializes tx
+	require.NoError(ptx1.Sign(key1, key3))
+	require.Equal([]ids.ShortID{key2.Address()}, ptx1.MissingSigners())
+	ptxBytes, err := ptx1.Bytes(Codec)
+	require.NoError(err)
+
+	// second party parses tx and signs it
+	ptx2, err := ParsePartiallySignedTx(Codec, ptxBytes)
+	require.NoError(err)
+	require.Equal(ptx1.Unsigned.Bytes(), ptx2.Unsigned.Bytes())
+	ptx2.Sigs[0][0] = emptySig
+	sig, err := key2.SignHash(ptx2.hash)
+	require.NoError(err)
+	require.ErrorIs(ptx2.AddSignature(0, 0, sig), ErrWrongSignatureSigner)
+	require.ErrorIs(ptx2.AddSignature(1, 0, sig), errWrongSignatureIndex)
+	require.NoError(ptx2.AddSignature(0, 1, sig))
+
+	// merge signatures
+	otherUtx := *utx
+	otherUtx.Remove = true
+	ptx3, err := NewPartiallySignedTx(Codec, &otherUtx, NewSigningManifest(signers))
+	require.NoError(err)
+	require.ErrorIs(ptx1.Merge(ptx3), errPartiallySignedTxDiffs)
+	require.NoError(ptx1.Merge(ptx2))
+	require.True(ptx1.IsComplete())
+
+	tx, err := ptx1.Finalize(Codec)
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), tx.Bytes())
+	require.Equal(expectedTx.ID(), tx.ID())
+}
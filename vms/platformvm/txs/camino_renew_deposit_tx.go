@@ -0,0 +1,109 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+)
+
+var (
+	_ UnsignedTx = (*RenewDepositTx)(nil)
+
+	errEmptyRenewedDepositTxID = errors.New("renewed deposit tx id is empty")
+	ErrNotDepositTx            = errors.New("tx doesn't create deposit")
+)
+
+// RenewDepositTx is an unsigned renewDepositTx.
+// It closes expired deposit and deposits its remaining amount again with new offer,
+// without unlocking it. Additional unlocked tokens (e.g. claimed rewards) could be deposited too.
+type RenewDepositTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of expired deposit tx, which deposit will be renewed
+	DepositTxID ids.ID `serialize:"true" json:"depositTxID"`
+	// ID of active offer that will be used for new deposit
+	DepositOfferID ids.ID `serialize:"true" json:"depositOfferID"`
+	// duration of new deposit
+	DepositDuration uint32 `serialize:"true" json:"duration"`
+	// Where to send new deposit rewards
+	RewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [RenewDepositTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *RenewDepositTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.RewardsOwner.InitCtx(ctx)
+}
+
+func (tx *RenewDepositTx) Duration() uint32 {
+	return tx.DepositDuration
+}
+
+// DepositAmount returns amount of tokens, that are deposited by this tx
+func (tx *RenewDepositTx) DepositAmount() (uint64, error) {
+	depositAmount := uint64(0)
+	for _, out := range tx.Outs {
+		if lockedOut, ok := locked.AsOut(out.Out); ok && lockedOut.IsNewlyLockedWith(locked.StateDeposited) {
+			newDepositAmount, err := math.Add64(depositAmount, lockedOut.Amount())
+			if err != nil {
+				return 0, err
+			}
+			depositAmount = newDepositAmount
+		}
+	}
+	return depositAmount, nil
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *RenewDepositTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.DepositTxID == ids.Empty:
+		return errEmptyRenewedDepositTxID
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(tx.RewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify rewards owner: %w", err)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RenewDepositTx) Visit(visitor Visitor) error {
+	return visitor.RenewDepositTx(tx)
+}
+
+// DepositRewardsOwner returns rewards owner of deposit created by [utx].
+// Deposits could be created by DepositTx, RenewDepositTx, SplitDepositTx or ClaimDepositTx.
+func DepositRewardsOwner(utx UnsignedTx) (fx.Owner, error) {
+	switch utx := utx.(type) {
+	case *DepositTx:
+		return utx.RewardsOwner, nil
+	case *RenewDepositTx:
+		return utx.RewardsOwner, nil
+	case *SplitDepositTx:
+		return utx.RewardsOwner, nil
+	case *ClaimDepositTx:
+		return utx.RewardsOwner, nil
+	}
+	return nil, fmt.Errorf("%w: %T", ErrNotDepositTx, utx)
+}
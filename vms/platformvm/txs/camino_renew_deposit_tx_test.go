@@ -0,0 +1,98 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenewDepositTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	owner1 := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *RenewDepositTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Empty renewed deposit tx id": {
+			tx: &RenewDepositTx{
+				BaseTx:       baseTx,
+				RewardsOwner: &owner1,
+			},
+			expectedErr: errEmptyRenewedDepositTxID,
+		},
+		"OK": {
+			tx: &RenewDepositTx{
+				BaseTx:       baseTx,
+				DepositTxID:  ids.GenerateTestID(),
+				RewardsOwner: &owner1,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
+
+func TestDepositRewardsOwner(t *testing.T) {
+	owner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	tests := map[string]struct {
+		utx           UnsignedTx
+		expectedOwner *secp256k1fx.OutputOwners
+		expectedErr   error
+	}{
+		"DepositTx": {
+			utx:           &DepositTx{RewardsOwner: owner},
+			expectedOwner: owner,
+		},
+		"RenewDepositTx": {
+			utx:           &RenewDepositTx{RewardsOwner: owner},
+			expectedOwner: owner,
+		},
+		"SplitDepositTx": {
+			utx:           &SplitDepositTx{RewardsOwner: owner},
+			expectedOwner: owner,
+		},
+		"ClaimDepositTx": {
+			utx:           &ClaimDepositTx{RewardsOwner: owner},
+			expectedOwner: owner,
+		},
+		"Not deposit tx": {
+			utx:         &BaseTx{},
+			expectedErr: ErrNotDepositTx,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rewardsOwner, err := DepositRewardsOwner(tt.utx)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(t, tt.expectedOwner, rewardsOwner)
+			}
+		})
+	}
+}
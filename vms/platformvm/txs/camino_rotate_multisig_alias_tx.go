@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	_ UnsignedTx = (*RotateMultisigAliasTx)(nil)
+
+	errEmptyRetiredAliasID   = errors.New("retired alias id is empty")
+	errNotEmptySuccessorID   = errors.New("successor alias id is not empty")
+	errNilRetiredAliasAuth   = errors.New("retired alias auth is nil")
+	errNilSuccessorOwners    = errors.New("successor alias owners are nil")
+	errSuccessorOwnsRetiring = errors.New("successor alias owners contain retired alias")
+)
+
+// RotateMultisigAliasTx is an unsigned rotateMultisigAliasTx.
+// It retires existing multisig alias and creates its successor alias with new owners.
+// Retired alias can't be used as owner of new outputs or aliases, but outputs
+// already owned by it are still spendable by its last owners.
+// Successor alias id is computed from this tx id.
+// Last credential of this tx must be signed by the retired alias owners.
+type RotateMultisigAliasTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of multisig alias, that will be retired
+	RetiredAliasID ids.ShortID `serialize:"true" json:"retiredAliasID"`
+	// Successor alias definition. Successor.ID must be empty
+	Successor multisig.Alias `serialize:"true" json:"successor"`
+	// Auth that allows retired alias owners to rotate it
+	RetiredAliasAuth verify.Verifiable `serialize:"true" json:"retiredAliasAuthorization"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [RotateMultisigAliasTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *RotateMultisigAliasTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.Successor.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *RotateMultisigAliasTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.RetiredAliasID == ids.ShortEmpty:
+		return errEmptyRetiredAliasID
+	case tx.Successor.ID != ids.ShortEmpty:
+		return errNotEmptySuccessorID
+	case tx.RetiredAliasAuth == nil:
+		return errNilRetiredAliasAuth
+	case tx.Successor.Owners == nil:
+		return errNilSuccessorOwners
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(&tx.Successor, tx.RetiredAliasAuth); err != nil {
+		return fmt.Errorf("failed to verify successor or retired alias auth: %w", err)
+	}
+
+	if owners, _, err := secp256k1fx.OwnersWeights(tx.Successor.Owners); err == nil {
+		for _, addr := range owners.Addrs {
+			if addr == tx.RetiredAliasID {
+				return errSuccessorOwnsRetiring
+			}
+		}
+	}
+
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *RotateMultisigAliasTx) Visit(visitor Visitor) error {
+	return visitor.RotateMultisigAliasTx(tx)
+}
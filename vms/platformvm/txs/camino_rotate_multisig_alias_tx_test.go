@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateMultisigAliasTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	retiredAliasID := ids.GenerateTestShortID()
+	owner1 := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *RotateMultisigAliasTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Empty retired alias id": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:           baseTx,
+				Successor:        multisig.Alias{Owners: &owner1},
+				RetiredAliasAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errEmptyRetiredAliasID,
+		},
+		"Not empty successor id": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:           baseTx,
+				RetiredAliasID:   retiredAliasID,
+				Successor:        multisig.Alias{ID: ids.GenerateTestShortID(), Owners: &owner1},
+				RetiredAliasAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errNotEmptySuccessorID,
+		},
+		"Nil retired alias auth": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:         baseTx,
+				RetiredAliasID: retiredAliasID,
+				Successor:      multisig.Alias{Owners: &owner1},
+			},
+			expectedErr: errNilRetiredAliasAuth,
+		},
+		"Nil successor owners": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:           baseTx,
+				RetiredAliasID:   retiredAliasID,
+				RetiredAliasAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errNilSuccessorOwners,
+		},
+		"Successor owners contain retired alias": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:         baseTx,
+				RetiredAliasID: retiredAliasID,
+				Successor: multisig.Alias{Owners: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{retiredAliasID},
+				}},
+				RetiredAliasAuth: &secp256k1fx.Input{},
+			},
+			expectedErr: errSuccessorOwnsRetiring,
+		},
+		"OK": {
+			tx: &RotateMultisigAliasTx{
+				BaseTx:           baseTx,
+				RetiredAliasID:   retiredAliasID,
+				Successor:        multisig.Alias{Owners: &owner1},
+				RetiredAliasAuth: &secp256k1fx.Input{},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// SigningManifest describes which addresses must sign unsigned tx
+// and in which credential their signatures must be placed
+type SigningManifest struct {
+	// Signers[i][j] is the address which signature must be
+	// the j-th signature of the i-th tx credential
+	Signers [][]ids.ShortID `json:"signers"`
+}
+
+// NewSigningManifest creates signing manifest from signers,
+// that are used by txs builder to sign tx
+func NewSigningManifest(signers [][]*crypto.PrivateKeySECP256K1R) *SigningManifest {
+	manifest := &SigningManifest{
+		Signers: make([][]ids.ShortID, len(signers)),
+	}
+	for i, credSigners := range signers {
+		manifest.Signers[i] = make([]ids.ShortID, len(credSigners))
+		for j, signer := range credSigners {
+			manifest.Signers[i][j] = signer.Address()
+		}
+	}
+	return manifest
+}
+
+// NumCredentials returns number of credentials that signed tx must have
+func (m *SigningManifest) NumCredentials() int {
+	return len(m.Signers)
+}
+
+// SigningRequest describes single signature, that must be made to sign tx
+type SigningRequest struct {
+	// Index of tx credential, that this signature belongs to
+	CredentialIndex int `json:"credentialIndex"`
+	// Index of this signature in credential
+	SignatureIndex int `json:"signatureIndex"`
+	// Address which key must make this signature
+	Signer ids.ShortID `json:"signer"`
+	// Hash that must be signed
+	Hash []byte `json:"hash"`
+}
+
+// SigningRequests returns all signatures that must be made to sign
+// initialized unsigned tx [utx], in the same order as they will be placed in tx credentials.
+// Hardware wallets could display [utx] bytes and sign requested hashes.
+func (m *SigningManifest) SigningRequests(utx UnsignedTx) []SigningRequest {
+	hash := SigningHash(utx.Bytes())
+	requests := []SigningRequest{}
+	for credIndex, credSigners := range m.Signers {
+		for sigIndex, signer := range credSigners {
+			requests = append(requests, SigningRequest{
+				CredentialIndex: credIndex,
+				SignatureIndex:  sigIndex,
+				Signer:          signer,
+				Hash:            hash,
+			})
+		}
+	}
+	return requests
+}
+
+// SigningHash returns hash of unsigned tx bytes, that must be signed by each tx signer
+func SigningHash(unsignedBytes []byte) []byte {
+	return hashing.ComputeHash256(unsignedBytes)
+}
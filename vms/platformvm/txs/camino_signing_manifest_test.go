@@ -0,0 +1,61 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSigningManifestSigningRequests(t *testing.T) {
+	require := require.New(t)
+
+	key1, key2 := caminoPreFundedKeys[0], caminoPreFundedKeys[1]
+	keys := map[ids.ShortID]*crypto.PrivateKeySECP256K1R{
+		key1.Address(): key1,
+		key2.Address(): key2,
+	}
+	utx := &AddressStateTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    1,
+			BlockchainID: ids.ID{1},
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.ID{1}},
+				Asset:  avax.Asset{ID: ids.ID{2}},
+				In: &secp256k1fx.TransferInput{
+					Amt:   10,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0, 1}},
+				},
+			}},
+		}},
+		Address: key2.Address(),
+	}
+	signers := [][]*crypto.PrivateKeySECP256K1R{{key1, key2}, {key2}}
+
+	expectedTx, err := NewSigned(utx, Codec, signers)
+	require.NoError(err)
+
+	manifest := NewSigningManifest(signers)
+	ptx, err := NewPartiallySignedTx(Codec, utx, manifest)
+	require.NoError(err)
+
+	requests := manifest.SigningRequests(ptx.Unsigned)
+	require.Len(requests, 3)
+	for _, request := range requests {
+		require.Equal(SigningHash(utx.Bytes()), request.Hash)
+		sig, err := keys[request.Signer].SignHash(request.Hash)
+		require.NoError(err)
+		require.NoError(ptx.AddSignature(request.CredentialIndex, request.SignatureIndex, sig))
+	}
+
+	tx, err := ptx.Finalize(Codec)
+	require.NoError(err)
+	require.Equal(expectedTx.Bytes(), tx.Bytes())
+}
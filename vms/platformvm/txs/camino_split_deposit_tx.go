@@ -0,0 +1,75 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+)
+
+var (
+	_ UnsignedTx = (*SplitDepositTx)(nil)
+
+	errZeroSplitAmount = errors.New("split amount is zero")
+)
+
+// SplitDepositTx is an unsigned splitDepositTx.
+// It moves [SplitAmount] of existing deposit principal into new deposit created by this tx.
+// New deposit has the same offer, start and duration as split deposit. Unlocked amount and
+// claimed reward of split deposit are divided between deposits proportionally to their amounts.
+// Deposited outputs of split deposit that are consumed by this tx must be produced again either
+// deposited with split deposit or newly deposited with this tx.
+// Last credential of this tx must be signed by the split deposit rewards owner.
+type SplitDepositTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// ID of deposit tx, which deposit will be split
+	DepositTxID ids.ID `serialize:"true" json:"depositTxID"`
+	// Amount of split deposit principal, that will be moved to new deposit
+	SplitAmount uint64 `serialize:"true" json:"splitAmount"`
+	// Where to send new deposit rewards
+	RewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [SplitDepositTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *SplitDepositTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	tx.RewardsOwner.InitCtx(ctx)
+}
+
+// SyntacticVerify returns nil if [tx] is valid
+func (tx *SplitDepositTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case tx.DepositTxID == ids.Empty:
+		return errEmptyDepositTxID
+	case tx.SplitAmount == 0:
+		return errZeroSplitAmount
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return fmt.Errorf("failed to verify BaseTx: %w", err)
+	}
+	if err := verify.All(tx.RewardsOwner); err != nil {
+		return fmt.Errorf("failed to verify rewards owner: %w", err)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+func (tx *SplitDepositTx) Visit(visitor Visitor) error {
+	return visitor.SplitDepositTx(tx)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitDepositTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	owner1 := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+
+	baseTx := BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+	}}
+
+	tests := map[string]struct {
+		tx          *SplitDepositTx
+		expectedErr error
+	}{
+		"Nil tx": {
+			expectedErr: ErrNilTx,
+		},
+		"Empty deposit tx id": {
+			tx: &SplitDepositTx{
+				BaseTx:       baseTx,
+				SplitAmount:  1,
+				RewardsOwner: &owner1,
+			},
+			expectedErr: errEmptyDepositTxID,
+		},
+		"Zero split amount": {
+			tx: &SplitDepositTx{
+				BaseTx:       baseTx,
+				DepositTxID:  ids.GenerateTestID(),
+				RewardsOwner: &owner1,
+			},
+			expectedErr: errZeroSplitAmount,
+		},
+		"OK": {
+			tx: &SplitDepositTx{
+				BaseTx:       baseTx,
+				DepositTxID:  ids.GenerateTestID(),
+				SplitAmount:  1,
+				RewardsOwner: &owner1,
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.tx.SyntacticVerify(ctx), tt.expectedErr)
+		})
+	}
+}
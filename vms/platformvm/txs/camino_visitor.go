@@ -10,4 +10,13 @@ type CaminoVisitor interface {
 	ClaimTx(*ClaimTx) error
 	RegisterNodeTx(*RegisterNodeTx) error
 	RewardsImportTx(*RewardsImportTx) error
+	BaseTx(*BaseTx) error
+	AddressStateBatchTx(*AddressStateBatchTx) error
+	RenewDepositTx(*RenewDepositTx) error
+	DepositRewardsOwnerTx(*DepositRewardsOwnerTx) error
+	SplitDepositTx(*SplitDepositTx) error
+	MultisigAliasTx(*MultisigAliasTx) error
+	RotateMultisigAliasTx(*RotateMultisigAliasTx) error
+	DepositOfferTx(*DepositOfferTx) error
+	ClaimDepositTx(*ClaimDepositTx) error
 }
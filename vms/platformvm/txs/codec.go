@@ -118,6 +118,18 @@ func RegisterUnsignedTxsTypes(targetCodec codec.CaminoRegistry) error {
 		targetCodec.RegisterCustomType(&ClaimTx{}),
 		targetCodec.RegisterCustomType(&RewardsImportTx{}),
 		targetCodec.RegisterCustomType(&secp256k1fx.MultisigCredential{}),
+		targetCodec.RegisterCustomType(&AddressStateBatchTx{}),
+		targetCodec.RegisterCustomType(&RenewDepositTx{}),
+		targetCodec.RegisterCustomType(&DepositRewardsOwnerTx{}),
+		targetCodec.RegisterCustomType(&SplitDepositTx{}),
+		targetCodec.RegisterCustomType(&locked.EscrowIn{}),
+		targetCodec.RegisterCustomType(&locked.EscrowOut{}),
+		targetCodec.RegisterCustomType(&locked.LabeledOut{}),
+		targetCodec.RegisterCustomType(&RotateMultisigAliasTx{}),
+		targetCodec.RegisterCustomType(&secp256k1fx.WeightedOutputOwners{}),
+		targetCodec.RegisterCustomType(&secp256k1fx.TimeLockedOutputOwners{}),
+		targetCodec.RegisterCustomType(&DepositOfferTx{}),
+		targetCodec.RegisterCustomType(&ClaimDepositTx{}),
 	)
 	return errs.Err
 }
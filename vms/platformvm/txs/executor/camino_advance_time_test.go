@@ -14,6 +14,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/nodeid"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
@@ -372,9 +373,10 @@ func deferValidator(env *caminoEnvironment, nodeOwnerAddress ids.ShortID, key *c
 	tx, err := env.txBuilder.NewAddressStateTx(
 		nodeOwnerAddress,
 		false,
-		txs.AddressStateNodeDeferred,
+		as.AddressStateBitNodeDeferred,
 		[]*crypto.PrivateKeySECP256K1R{key},
 		outputOwners,
+		nil,
 	)
 	if err != nil {
 		return nil, err
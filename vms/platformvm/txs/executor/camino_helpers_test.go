@@ -111,9 +111,12 @@ func newCaminoEnvironment(postBanff, addSubnet bool, caminoGenesisConf api.Camin
 
 	atomicUTXOs := avax.NewAtomicUTXOManager(ctx.SharedMemory, txs.Codec)
 	uptimes := uptime.NewManager(baseState)
-	utxoHandler := utxo.NewCaminoHandler(ctx, &clk, baseState, fx, true)
+	utxoHandler, err := utxo.NewCaminoHandler(ctx, &clk, baseState, fx, true, 0, prometheus.NewRegistry())
+	if err != nil {
+		panic(err)
+	}
 
-	txBuilder := builder.NewCamino(
+	txBuilder, err := builder.NewCamino(
 		ctx,
 		&config,
 		&clk,
@@ -121,7 +124,12 @@ func newCaminoEnvironment(postBanff, addSubnet bool, caminoGenesisConf api.Camin
 		baseState,
 		atomicUTXOs,
 		utxoHandler,
+		"",
+		prometheus.NewRegistry(),
 	)
+	if err != nil {
+		panic(err)
+	}
 
 	backend := Backend{
 		Config:       &config,
@@ -576,10 +584,14 @@ func newCaminoEnvironmentWithMocks(
 	uptimes := uptime.NewManager(mockableState)
 
 	if utxoHandler == nil {
-		utxoHandler = utxo.NewCaminoHandler(ctx, &clk, mockableState, fx, true)
+		var err error
+		utxoHandler, err = utxo.NewCaminoHandler(ctx, &clk, mockableState, fx, true, 0, prometheus.NewRegistry())
+		if err != nil {
+			panic(err)
+		}
 	}
 
-	txBuilder := builder.NewCamino(
+	txBuilder, err := builder.NewCamino(
 		ctx,
 		&config,
 		&clk,
@@ -587,7 +599,12 @@ func newCaminoEnvironmentWithMocks(
 		mockableState,
 		atomicUTXOs,
 		utxoHandler,
+		"",
+		prometheus.NewRegistry(),
 	)
+	if err != nil {
+		panic(err)
+	}
 
 	backend := Backend{
 		Config:       &config,
@@ -638,6 +655,23 @@ func expectVerifyMultisigPermission(s *state.MockDiff, addrs []ids.ShortID, alia
 	}
 }
 
+func expectNotRetiredAliases(s *state.MockDiff, addrs ...ids.ShortID) {
+	for _, addr := range addrs {
+		s.EXPECT().GetShortIDLink(addr, state.ShortLinkKeyMultisigAliasSuccessor).
+			Return(ids.ShortEmpty, database.ErrNotFound)
+	}
+}
+
+func expectNotRetiredOuts(s *state.MockDiff, outs []*avax.TransferableOutput) {
+	for _, out := range outs {
+		innerOut := out.Out
+		if lockedOut, ok := locked.AsOut(innerOut); ok {
+			innerOut = lockedOut.TransferableOut
+		}
+		expectNotRetiredAliases(s, innerOut.(*secp256k1fx.TransferOutput).Addrs...)
+	}
+}
+
 func expectVerifyLock(s *state.MockDiff, ins []*avax.TransferableInput, utxos []*avax.UTXO) {
 	expectGetUTXOsFromInputs(s, ins, utxos)
 }
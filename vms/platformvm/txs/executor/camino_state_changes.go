@@ -6,23 +6,45 @@ package executor
 import (
 	"time"
 
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 )
 
-type caminoStateChanges struct{}
+type caminoStateChanges struct {
+	depositOffersToLock []*deposit.Offer
+}
 
-func (*caminoStateChanges) Apply(_ state.Diff) {
+func (cs *caminoStateChanges) Apply(stateDiff state.Diff) {
+	for _, offer := range cs.depositOffersToLock {
+		lockedOffer := *offer
+		lockedOffer.Flags |= deposit.OfferFlagLocked
+		stateDiff.SetDepositOffer(&lockedOffer)
+	}
 }
 
-func (*caminoStateChanges) Len() int {
-	return 0
+func (cs *caminoStateChanges) Len() int {
+	return len(cs.depositOffersToLock)
 }
 
+// caminoAdvanceTimeTo adds to [changes] camino-specific state changes caused by
+// advancing the chain time to the [newChainTime]:
+// not locked deposit offers, that ended before [newChainTime], are locked.
 func caminoAdvanceTimeTo(
 	_ *Backend,
-	_ state.Chain,
-	_ time.Time,
-	_ *stateChanges,
+	parentState state.Chain,
+	newChainTime time.Time,
+	changes *stateChanges,
 ) error {
+	offers, err := parentState.GetAllDepositOffers()
+	if err != nil {
+		return err
+	}
+
+	for _, offer := range offers {
+		if offer.Flags&deposit.OfferFlagLocked == 0 && offer.EndTime().Before(newChainTime) {
+			changes.depositOffersToLock = append(changes.depositOffersToLock, offer)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,47 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaminoAdvanceTimeToLocksEndedDepositOffers(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newChainTime := time.Unix(100, 0)
+
+	endedOffer := &deposit.Offer{ID: ids.ID{1}, End: 99}
+	endingOffer := &deposit.Offer{ID: ids.ID{2}, End: 100}
+	activeOffer := &deposit.Offer{ID: ids.ID{3}, End: 101}
+	lockedOffer := &deposit.Offer{ID: ids.ID{4}, End: 99, Flags: deposit.OfferFlagLocked}
+
+	parentState := state.NewMockChain(ctrl)
+	parentState.EXPECT().GetAllDepositOffers().
+		Return([]*deposit.Offer{endedOffer, endingOffer, activeOffer, lockedOffer}, nil)
+
+	changes := &stateChanges{}
+	require.NoError(caminoAdvanceTimeTo(nil, parentState, newChainTime, changes))
+	require.Equal(1, changes.caminoStateChanges.Len())
+
+	expectedLockedOffer := *endedOffer
+	expectedLockedOffer.Flags = deposit.OfferFlagLocked
+
+	stateDiff := state.NewMockDiff(ctrl)
+	stateDiff.EXPECT().SetDepositOffer(&expectedLockedOffer)
+	changes.caminoStateChanges.Apply(stateDiff)
+
+	// original offer isn't modified
+	require.Zero(endedOffer.Flags)
+}
@@ -16,21 +16,19 @@ import (
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/multisig"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	deposits "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
-	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
-// Max number of items allowed in a page
-const maxPageSize = 1024
-
 var (
 	_ txs.Visitor = (*CaminoStandardTxExecutor)(nil)
 	_ txs.Visitor = (*CaminoProposalTxExecutor)(nil)
@@ -45,10 +43,15 @@ var (
 	errRemoveWrongValidator         = errors.New("attempting to remove wrong validator")
 	errDepositOfferNotActiveYet     = errors.New("deposit offer not active yet")
 	errDepositOfferInactive         = errors.New("deposit offer inactive")
+	errDepositOfferPaused           = errors.New("deposit offer is paused")
 	errDepositToSmall               = errors.New("deposit amount is less than deposit offer minimum amount")
 	errDepositToBig                 = errors.New("deposit amount is greater than deposit offer available amount")
 	errDepositDurationToSmall       = errors.New("deposit duration is less than deposit offer minmum duration")
 	errDepositDurationToBig         = errors.New("deposit duration is greater than deposit offer maximum duration")
+	errDepositorAddressState        = errors.New("deposit tx signers don't have address state required by deposit offer")
+	errDepositNotExpired            = errors.New("deposit isn't expired yet")
+	errRenewedAmountMismatch        = errors.New("consumed deposited amount doesn't match renewed deposit remaining amount")
+	errRenewedDepositToSmall        = errors.New("new deposit amount is less than renewed deposit remaining amount")
 	errSupplyOverflow               = errors.New("resulting total supply would be more, than allowed maximum")
 	errNotConsortiumMember          = errors.New("address isn't consortium member")
 	errValidatorNotFound            = errors.New("validator not found")
@@ -68,6 +71,21 @@ var (
 	errInputsUTXOSMismatch          = errors.New("number of inputs is different from number of utxos")
 	errWrongClaimedAmount           = errors.New("claiming more than was available to claim")
 	errMsigAlias                    = errors.New("can't use msig alias here")
+	errOfferOwnerCredentialMismatch = errors.New("offer owner credential isn't matching")
+	errWrongSplitAmount             = errors.New("split amount must be less than deposit amount")
+	errSplitAmountMismatch          = errors.New("consumed deposited amount doesn't match produced deposited amount")
+	errSplitDepositOutput           = errors.New("output is deposited with another deposit")
+	errSplitClaimedReward           = errors.New("split deposit claimed reward is greater than its total reward")
+	errDepositNotBondable           = errors.New("deposit offer doesn't allow bonding of deposited tokens")
+	errAliasAlreadyExists           = errors.New("multisig alias already exists")
+	errAliasCredentialMismatch      = errors.New("multisig alias credential isn't matching")
+	errWrongAuthType                = errors.New("wrong auth type")
+	errWrongCredentialType          = errors.New("wrong credential type")
+	errAdminCredentialMismatch      = errors.New("admin credential isn't matching")
+	errDepositOfferExists           = errors.New("deposit offer already exists")
+	errDepositOfferEnded            = errors.New("deposit offer end is not after chain time")
+	errDepositOfferTermsChanged     = errors.New("deposit offer update changes terms of existing deposits")
+	errClaimedAssetNotDepositable   = errors.New("claimed asset isn't primary network asset and can't be deposited")
 )
 
 type CaminoStandardTxExecutor struct {
@@ -208,6 +226,14 @@ func (e *CaminoStandardTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error
 			return err
 		}
 
+		if err := utxo.VerifyNotRetiredAliases(e.State, rewardOwner.Addrs); err != nil {
+			return err
+		}
+
+		if err := e.verifyDepositsBondable(tx.Ins); err != nil {
+			return err
+		}
+
 		// Verify the flowcheck
 		if err := e.Backend.FlowChecker.VerifyLock(
 			tx,
@@ -244,6 +270,34 @@ func (e *CaminoStandardTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error
 	return nil
 }
 
+// verifyDepositsBondable verifies that deposited [ins] are deposited
+// with offers, that allow bonding of deposited tokens
+func (e *CaminoStandardTxExecutor) verifyDepositsBondable(ins []*avax.TransferableInput) error {
+	verifiedDeposits := set.Set[ids.ID]{}
+	for _, in := range ins {
+		lockedIn, ok := in.In.(*locked.In)
+		if !ok || lockedIn.DepositTxID == ids.Empty || verifiedDeposits.Contains(lockedIn.DepositTxID) {
+			continue
+		}
+
+		deposit, err := e.State.GetDeposit(lockedIn.DepositTxID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errDepositNotFound, err)
+		}
+
+		depositOffer, err := e.State.GetDepositOffer(deposit.DepositOfferID)
+		if err != nil {
+			return err
+		}
+
+		if !depositOffer.AllowsBonding() {
+			return fmt.Errorf("%w: deposit %s", errDepositNotBondable, lockedIn.DepositTxID)
+		}
+		verifiedDeposits.Add(lockedIn.DepositTxID)
+	}
+	return nil
+}
+
 func (e *CaminoStandardTxExecutor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
 	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
 		return err
@@ -519,7 +573,7 @@ func (e *CaminoProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx)
 		if err != nil {
 			return err
 		}
-		e.OnCommitState.SetAddressStates(nodeOwnerAddressOnCommit, nodeOwnerAddressStateOnCommit&^txs.AddressStateNodeDeferredBit)
+		e.OnCommitState.SetAddressStates(nodeOwnerAddressOnCommit, nodeOwnerAddressStateOnCommit.Remove(as.AddressStateNodeDeferred))
 
 		// Reset deferred bit on node owner address for onAbortState
 		nodeOwnerAddressOnAbort, err := e.OnAbortState.GetShortIDLink(
@@ -533,7 +587,7 @@ func (e *CaminoProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx)
 		if err != nil {
 			return err
 		}
-		e.OnCommitState.SetAddressStates(nodeOwnerAddressOnAbort, nodeOwnerAddressStateOnAbort&^txs.AddressStateNodeDeferredBit)
+		e.OnCommitState.SetAddressStates(nodeOwnerAddressOnAbort, nodeOwnerAddressStateOnAbort.Remove(as.AddressStateNodeDeferred))
 	}
 
 	txID := e.Tx.ID()
@@ -546,6 +600,52 @@ func (e *CaminoProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx)
 	return nil
 }
 
+func (e *CaminoStandardTxExecutor) BaseTx(tx *txs.BaseTx) error {
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
+
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
+
+	if err := locked.VerifyLockMode(tx.Ins, tx.Outs, caminoConfig.LockModeBondDeposit); err != nil {
+		return err
+	}
+
+	// only unlocked funds could be transferred
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if err := e.FlowChecker.VerifySpend(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		e.Tx.Creds,
+		map[ids.ID]uint64{
+			e.Ctx.AVAXAssetID: e.Config.TxFee,
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	txID := e.Tx.ID()
+
+	// Consume the UTXOS
+	utxo.Consume(e.State, tx.Ins)
+	// Produce the UTXOS
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
 func (e *CaminoStandardTxExecutor) DepositTx(tx *txs.DepositTx) error {
 	caminoConfig, err := e.State.CaminoConfig()
 	if err != nil {
@@ -569,33 +669,85 @@ func (e *CaminoStandardTxExecutor) DepositTx(tx *txs.DepositTx) error {
 		return err
 	}
 
-	depositOffer, err := e.State.GetDepositOffer(tx.DepositOfferID)
+	depositOffer, baseTxCreds, err := e.verifyNewDeposit(
+		tx,
+		tx.DepositOfferID,
+		tx.DepositDuration,
+		depositAmount,
+		tx.RewardsOwner,
+	)
 	if err != nil {
 		return err
 	}
 
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateDeposited,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	txID := e.Tx.ID()
+
+	if err := e.addNewDeposit(txID, tx.DepositOfferID, depositOffer, tx.DepositDuration, depositAmount); err != nil {
+		return err
+	}
+
+	utxo.Consume(e.State, tx.Ins)
+	if err := utxo.ProduceLocked(e.State, txID, tx.Outs, locked.StateDeposited); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// verifyNewDeposit verifies that deposit with [depositAmount] and [depositDuration]
+// could be created by [utx] with offer [depositOfferID] and rewards owner [rewardsOwner].
+// Returns deposit offer and [utx] credentials without offer owner credential.
+func (e *CaminoStandardTxExecutor) verifyNewDeposit(
+	utx txs.UnsignedTx,
+	depositOfferID ids.ID,
+	depositDuration uint32,
+	depositAmount uint64,
+	rewardsOwner fx.Owner,
+) (*deposits.Offer, []verify.Verifiable, error) {
+	depositOffer, err := e.State.GetDepositOffer(depositOfferID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	currentChainTime := e.State.GetTimestamp()
 
 	switch {
 	case depositOffer.Flags&deposits.OfferFlagLocked != 0:
-		return errDepositOfferInactive
+		return nil, nil, errDepositOfferInactive
+	case depositOffer.IsPaused():
+		return nil, nil, errDepositOfferPaused
 	case depositOffer.StartTime().After(currentChainTime):
-		return errDepositOfferNotActiveYet
+		return nil, nil, errDepositOfferNotActiveYet
 	case depositOffer.EndTime().Before(currentChainTime):
-		return errDepositOfferInactive
-	case tx.DepositDuration < depositOffer.MinDuration:
-		return errDepositDurationToSmall
-	case tx.DepositDuration > depositOffer.MaxDuration:
-		return errDepositDurationToBig
+		return nil, nil, errDepositOfferInactive
+	case depositDuration < depositOffer.MinDuration:
+		return nil, nil, errDepositDurationToSmall
+	case depositDuration > depositOffer.MaxDuration:
+		return nil, nil, errDepositDurationToBig
 	case depositAmount < depositOffer.MinAmount:
-		return errDepositToSmall
+		return nil, nil, errDepositToSmall
+	case depositOffer.MaxAmount > 0 && depositAmount > depositOffer.MaxAmount:
+		return nil, nil, errDepositToBig
 	case depositOffer.TotalMaxAmount > 0 && depositAmount > depositOffer.RemainingAmount():
-		return errDepositToBig
+		return nil, nil, errDepositToBig
 	}
 
-	rewardOwner, ok := tx.RewardsOwner.(*secp256k1fx.OutputOwners)
+	rewardOwner, ok := rewardsOwner.(*secp256k1fx.OutputOwners)
 	if !ok {
-		return errWrongOwnerType
+		return nil, nil, errWrongOwnerType
 	}
 
 	if err := e.Fx.VerifyMultisigOwner(
@@ -603,41 +755,73 @@ func (e *CaminoStandardTxExecutor) DepositTx(tx *txs.DepositTx) error {
 			OutputOwners: *rewardOwner,
 		}, e.State,
 	); err != nil {
-		return err
+		return nil, nil, err
 	}
 
-	if err := e.FlowChecker.VerifyLock(
-		tx,
-		e.State,
-		tx.Ins,
-		tx.Outs,
-		e.Tx.Creds,
-		e.Config.TxFee,
-		e.Ctx.AVAXAssetID,
-		locked.StateDeposited,
-	); err != nil {
-		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	if err := utxo.VerifyNotRetiredAliases(e.State, rewardOwner.Addrs); err != nil {
+		return nil, nil, err
 	}
 
-	txID := e.Tx.ID()
+	baseTxCreds := e.Tx.Creds
+	if depositOffer.IsRestricted() {
+		// offer owner credential is the last one
+		if len(e.Tx.Creds) == 0 {
+			return nil, nil, errWrongCredentialsNumber
+		}
+		baseTxCreds = e.Tx.Creds[:len(e.Tx.Creds)-1]
 
-	currentSupply, err := e.State.GetCurrentSupply(constants.PrimaryNetworkID)
-	if err != nil {
-		return err
+		if err := e.Fx.VerifyMultisigUnorderedPermission(
+			utx,
+			[]verify.Verifiable{e.Tx.Creds[len(e.Tx.Creds)-1]},
+			&secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{depositOffer.OwnerAddress},
+			},
+			e.State,
+		); err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", errOfferOwnerCredentialMismatch, err)
+		}
 	}
 
+	if depositOffer.RequiredAddressState != as.AddressStateEmpty {
+		if err := e.verifyDepositorAddressState(utx, baseTxCreds, depositOffer.RequiredAddressState); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return depositOffer, baseTxCreds, nil
+}
+
+// addNewDeposit adds new deposit created by tx [depositTxID] to state,
+// increasing current supply by its potential reward
+func (e *CaminoStandardTxExecutor) addNewDeposit(
+	depositTxID ids.ID,
+	depositOfferID ids.ID,
+	depositOffer *deposits.Offer,
+	depositDuration uint32,
+	depositAmount uint64,
+) error {
 	deposit := &deposits.Deposit{
-		DepositOfferID: tx.DepositOfferID,
-		Duration:       tx.DepositDuration,
+		DepositOfferID: depositOfferID,
+		Duration:       depositDuration,
 		Amount:         depositAmount,
-		Start:          uint64(currentChainTime.Unix()),
+		Start:          uint64(e.State.GetTimestamp().Unix()),
 	}
 
-	potentialReward := deposit.TotalReward(depositOffer)
+	// rewards in not primary network asset don't affect primary network supply
+	if depositOffer.RewardAsset(e.Ctx.AVAXAssetID) == e.Ctx.AVAXAssetID {
+		currentSupply, err := e.State.GetCurrentSupply(constants.PrimaryNetworkID)
+		if err != nil {
+			return err
+		}
+
+		potentialReward := deposit.TotalReward(depositOffer)
 
-	newSupply, err := math.Add64(currentSupply, potentialReward)
-	if err != nil || newSupply > e.Config.RewardConfig.SupplyCap {
-		return errSupplyOverflow
+		newSupply, err := math.Add64(currentSupply, potentialReward)
+		if err != nil || newSupply > e.Config.RewardConfig.SupplyCap {
+			return errSupplyOverflow
+		}
+		e.State.SetCurrentSupply(constants.PrimaryNetworkID, newSupply)
 	}
 
 	if depositOffer.TotalMaxAmount > 0 {
@@ -646,15 +830,33 @@ func (e *CaminoStandardTxExecutor) DepositTx(tx *txs.DepositTx) error {
 		e.State.SetDepositOffer(&updatedOffer)
 	}
 
-	e.State.SetCurrentSupply(constants.PrimaryNetworkID, newSupply)
-	e.State.AddDeposit(txID, deposit)
+	e.State.AddDeposit(depositTxID, deposit)
+	return nil
+}
 
-	utxo.Consume(e.State, tx.Ins)
-	if err := utxo.ProduceLocked(e.State, txID, tx.Outs, locked.StateDeposited); err != nil {
-		return err
+// verifyDepositorAddressState verifies that one of [utx] signers, recovered from [creds],
+// has all [requiredStates]
+func (e *CaminoStandardTxExecutor) verifyDepositorAddressState(
+	utx txs.UnsignedTx,
+	creds []verify.Verifiable,
+	requiredStates as.AddressState,
+) error {
+	addresses, err := e.Fx.RecoverAddresses(utx, creds)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errRecoverAdresses, err)
 	}
 
-	return nil
+	for addr := range addresses {
+		states, err := e.State.GetAddressStates(addr)
+		if err != nil {
+			return err
+		}
+		if states.Has(requiredStates) {
+			return nil
+		}
+	}
+
+	return errDepositorAddressState
 }
 
 func (e *CaminoStandardTxExecutor) UnlockDepositTx(tx *txs.UnlockDepositTx) error {
@@ -689,14 +891,15 @@ func (e *CaminoStandardTxExecutor) UnlockDepositTx(tx *txs.UnlockDepositTx) erro
 	}
 
 	txID := e.Tx.ID()
+	currentTimestamp := uint64(e.State.GetTimestamp().Unix())
 
-	for depositTxID, newUnlockedAmount := range newUnlockedAmounts {
+	for depositTxID, unlockedAmount := range newUnlockedAmounts {
 		deposit, err := e.State.GetDeposit(depositTxID)
 		if err != nil {
 			return err
 		}
 
-		newUnlockedAmount, err := math.Add64(newUnlockedAmount, deposit.UnlockedAmount)
+		newUnlockedAmount, err := math.Add64(unlockedAmount, deposit.UnlockedAmount)
 		if err != nil {
 			return err
 		}
@@ -707,45 +910,31 @@ func (e *CaminoStandardTxExecutor) UnlockDepositTx(tx *txs.UnlockDepositTx) erro
 				return err
 			}
 
-			if remainingReward := deposit.TotalReward(offer) - deposit.ClaimedRewardAmount; remainingReward > 0 {
-				signedDepositTx, _, err := e.State.GetTx(depositTxID)
-				if err != nil {
-					return fmt.Errorf("can't get depositTx: %w", err)
-				}
-				depositTx, ok := signedDepositTx.Unsigned.(*txs.DepositTx)
-				if !ok {
-					return fmt.Errorf("can't get depositTx: %w", errWrongTxType)
-				}
+			remainingReward := deposit.TotalReward(offer) - deposit.ClaimedRewardAmount
 
-				claimableOwnerID, err := txs.GetOwnerID(depositTx.RewardsOwner)
-				if err != nil {
-					return err
-				}
+			// early unlocked deposit gets only reduced reward accrued till now,
+			// the rest of potential reward is removed from supply
+			if !deposit.IsExpired(currentTimestamp) &&
+				unlockedAmount > deposit.UnlockableAmount(offer, currentTimestamp) {
+				earlyUnlockReward := deposit.EarlyUnlockReward(offer, currentTimestamp)
 
-				claimable, err := e.State.GetClaimable(claimableOwnerID)
-				if err == database.ErrNotFound {
-					scepOwner, ok := depositTx.RewardsOwner.(*secp256k1fx.OutputOwners)
-					if !ok {
-						return errWrongOwnerType
+				if offer.RewardAsset(e.Ctx.AVAXAssetID) == e.Ctx.AVAXAssetID {
+					currentSupply, err := e.State.GetCurrentSupply(constants.PrimaryNetworkID)
+					if err != nil {
+						return err
 					}
-					claimable = &state.Claimable{
-						Owner: scepOwner,
+					newSupply, err := math.Sub(currentSupply, remainingReward-earlyUnlockReward)
+					if err != nil {
+						return err
 					}
-				} else if err != nil {
-					return err
+					e.State.SetCurrentSupply(constants.PrimaryNetworkID, newSupply)
 				}
 
-				newClaimable := &state.Claimable{
-					Owner:           claimable.Owner,
-					ValidatorReward: claimable.ValidatorReward,
-				}
-
-				newClaimable.DepositReward, err = math.Add64(claimable.DepositReward, remainingReward)
-				if err != nil {
-					return err
-				}
+				remainingReward = earlyUnlockReward
+			}
 
-				e.State.SetClaimable(claimableOwnerID, newClaimable)
+			if err := e.addDepositRewardToClaimable(depositTxID, deposit, offer, remainingReward); err != nil {
+				return err
 			}
 			e.State.RemoveDeposit(depositTxID, deposit)
 		} else { // partial unlock
@@ -756,6 +945,7 @@ func (e *CaminoStandardTxExecutor) UnlockDepositTx(tx *txs.UnlockDepositTx) erro
 				Amount:              deposit.Amount,
 				Start:               deposit.Start,
 				Duration:            deposit.Duration,
+				RewardOwner:         deposit.RewardOwner,
 			})
 		}
 	}
@@ -766,9 +956,7 @@ func (e *CaminoStandardTxExecutor) UnlockDepositTx(tx *txs.UnlockDepositTx) erro
 	return nil
 }
 
-func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
-	// Basic checks
-
+func (e *CaminoStandardTxExecutor) RenewDepositTx(tx *txs.RenewDepositTx) error {
 	caminoConfig, err := e.State.CaminoConfig()
 	if err != nil {
 		return err
@@ -778,99 +966,1013 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 		return errWrongLockMode
 	}
 
+	if err := locked.VerifyLockMode(tx.Ins, tx.Outs, caminoConfig.LockModeBondDeposit); err != nil {
+		return err
+	}
+
 	if err := e.Tx.SyntacticVerify(e.Backend.Ctx); err != nil {
 		return err
 	}
 
-	// BaseTx / fee check
+	// verifying renewed deposit
+
+	oldDeposit, err := e.State.GetDeposit(tx.DepositTxID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDepositNotFound, err)
+	}
+
+	if !oldDeposit.IsExpired(uint64(e.State.GetTimestamp().Unix())) {
+		return errDepositNotExpired
+	}
+
+	oldDepositOffer, err := e.State.GetDepositOffer(oldDeposit.DepositOfferID)
+	if err != nil {
+		return err
+	}
+
+	// inputs consuming renewed deposit utxos are treated as if they weren't deposited,
+	// so they can be deposited again by this tx
+	renewedIns := make([]*avax.TransferableInput, len(tx.Ins))
+	renewedAmount := uint64(0)
+	for i, in := range tx.Ins {
+		renewedIns[i] = in
+		lockedIn, ok := in.In.(*locked.In)
+		if !ok || lockedIn.DepositTxID != tx.DepositTxID {
+			continue
+		}
+		renewedAmount, err = math.Add64(renewedAmount, lockedIn.Amount())
+		if err != nil {
+			return err
+		}
+		renewedIns[i] = &avax.TransferableInput{
+			UTXOID: in.UTXOID,
+			Asset:  in.Asset,
+			In: &locked.In{
+				IDs:            lockedIn.IDs.Unlock(locked.StateDeposited),
+				TransferableIn: lockedIn.TransferableIn,
+			},
+		}
+	}
+
+	if renewedAmount != oldDeposit.Amount-oldDeposit.UnlockedAmount {
+		return errRenewedAmountMismatch
+	}
+
+	// verifying new deposit
+
+	depositAmount, err := tx.DepositAmount()
+	if err != nil {
+		return err
+	}
+
+	if depositAmount < renewedAmount {
+		return errRenewedDepositToSmall
+	}
+
+	depositOffer, baseTxCreds, err := e.verifyNewDeposit(
+		tx,
+		tx.DepositOfferID,
+		tx.DepositDuration,
+		depositAmount,
+		tx.RewardsOwner,
+	)
+	if err != nil {
+		return err
+	}
 
 	if err := e.FlowChecker.VerifyLock(
 		tx,
-		e.State,
-		tx.Ins,
+		&renewedDepositUTXOGetter{Chain: e.State, depositTxID: tx.DepositTxID},
+		renewedIns,
 		tx.Outs,
-		e.Tx.Creds[:len(e.Tx.Creds)-1],
+		baseTxCreds,
 		e.Config.TxFee,
 		e.Ctx.AVAXAssetID,
-		locked.StateUnlocked,
+		locked.StateDeposited,
 	); err != nil {
 		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
 	}
 
-	// Common vars
+	// closing renewed deposit
+
+	remainingReward := oldDeposit.TotalReward(oldDepositOffer) - oldDeposit.ClaimedRewardAmount
+	if err := e.addDepositRewardToClaimable(tx.DepositTxID, oldDeposit, oldDepositOffer, remainingReward); err != nil {
+		return err
+	}
+	e.State.RemoveDeposit(tx.DepositTxID, oldDeposit)
+
+	// creating new deposit
 
-	currentTimestamp := uint64(e.State.GetTimestamp().Unix())
-	claimableCredential := []verify.Verifiable{e.Tx.Creds[len(e.Tx.Creds)-1]}
 	txID := e.Tx.ID()
 
-	newClaimTo := false
-	if secpOwner, ok := tx.ClaimTo.(*secp256k1fx.OutputOwners); !ok {
-		return errNotSECPOwner
-	} else if len(secpOwner.Addrs) != 0 {
-		newClaimTo = true
+	if err := e.addNewDeposit(txID, tx.DepositOfferID, depositOffer, tx.DepositDuration, depositAmount); err != nil {
+		return err
 	}
 
-	// Checking deposits sigs and creating reward outputs
+	utxo.Consume(e.State, tx.Ins)
+	if err := utxo.ProduceLocked(e.State, txID, tx.Outs, locked.StateDeposited); err != nil {
+		return err
+	}
 
-	mintedOutsCount := 0
+	return nil
+}
 
-	for _, depositTxID := range tx.DepositTxIDs {
-		// getting deposit tx
+// renewedDepositUTXOGetter returns utxos deposited with [depositTxID]
+// as if they weren't deposited. Other chain state methods are used by flow check
+// as is, e.g. chain time and short id links.
+type renewedDepositUTXOGetter struct {
+	state.Chain
+	depositTxID ids.ID
+}
 
-		signedDepositTx, txStatus, err := e.State.GetTx(depositTxID)
-		if err != nil {
-			return fmt.Errorf("%w: %s", errDepositNotFound, err)
-		}
-		if txStatus != status.Committed {
-			return fmt.Errorf("%w: %s", errDepositNotFound, "tx is not committed")
-		}
-		depositTx, ok := signedDepositTx.Unsigned.(*txs.DepositTx)
-		if !ok {
-			return fmt.Errorf("%w: %s", errDepositNotFound, errWrongTxType)
-		}
+func (g *renewedDepositUTXOGetter) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
+	consumedUTXO, err := g.Chain.GetUTXO(utxoID)
+	if err != nil {
+		return nil, err
+	}
 
-		// checking deposit signatures
+	lockedOut, ok := locked.AsOut(consumedUTXO.Out)
+	if !ok || lockedOut.DepositTxID != g.depositTxID {
+		return consumedUTXO, nil
+	}
 
-		depositRewardsOwner, ok := depositTx.RewardsOwner.(*secp256k1fx.OutputOwners)
-		if !ok {
-			return errNotSECPOwner
-		}
+	return &avax.UTXO{
+		UTXOID: consumedUTXO.UTXOID,
+		Asset:  consumedUTXO.Asset,
+		Out: &locked.Out{
+			IDs:             lockedOut.IDs.Unlock(locked.StateDeposited),
+			TransferableOut: lockedOut.TransferableOut,
+		},
+	}, nil
+}
 
-		if err := e.Fx.VerifyMultisigUnorderedPermission(
-			tx,
-			claimableCredential,
-			depositRewardsOwner,
-			e.State,
-		); err != nil {
-			return fmt.Errorf("%w: %s", errDepositCredentialMissmatch, err)
-		}
+func (e *CaminoStandardTxExecutor) DepositRewardsOwnerTx(tx *txs.DepositRewardsOwnerTx) error {
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
 
-		// creating reward output, if there is any
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
 
-		deposit, err := e.State.GetDeposit(depositTxID)
-		if err != nil {
-			return fmt.Errorf("%w: %s", errDepositNotFound, err)
-		}
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
 
-		depositOffer, err := e.State.GetDepositOffer(deposit.DepositOfferID)
+	if len(e.Tx.Creds) < 1 {
+		return errWrongCredentialsNumber
+	}
+
+	deposit, err := e.State.GetDeposit(tx.DepositTxID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDepositNotFound, err)
+	}
+
+	// verifying current rewards owner credential, which is the last one
+
+	currentRewardsOwner, err := state.DepositRewardsOwner(e.State, tx.DepositTxID, deposit)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDepositNotFound, err)
+	}
+
+	if err := e.Fx.VerifyMultisigUnorderedPermission(
+		tx,
+		[]verify.Verifiable{e.Tx.Creds[len(e.Tx.Creds)-1]},
+		currentRewardsOwner,
+		e.State,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errDepositCredentialMissmatch, err)
+	}
+
+	newRewardsOwner, ok := tx.RewardsOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return errWrongOwnerType
+	}
+
+	if err := e.Fx.VerifyMultisigOwner(
+		&secp256k1fx.TransferOutput{
+			OutputOwners: *newRewardsOwner,
+		}, e.State,
+	); err != nil {
+		return err
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, newRewardsOwner.Addrs); err != nil {
+		return err
+	}
+
+	// BaseTx / fee check
+
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		e.Tx.Creds[:len(e.Tx.Creds)-1],
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateUnlocked,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// setting new rewards owner
+
+	e.State.ModifyDeposit(tx.DepositTxID, &deposits.Deposit{
+		DepositOfferID:      deposit.DepositOfferID,
+		UnlockedAmount:      deposit.UnlockedAmount,
+		ClaimedRewardAmount: deposit.ClaimedRewardAmount,
+		Start:               deposit.Start,
+		Duration:            deposit.Duration,
+		Amount:              deposit.Amount,
+		RewardOwner:         newRewardsOwner,
+	})
+
+	txID := e.Tx.ID()
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
+func (e *CaminoStandardTxExecutor) SplitDepositTx(tx *txs.SplitDepositTx) error {
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
+
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
+
+	if err := locked.VerifyLockMode(tx.Ins, tx.Outs, caminoConfig.LockModeBondDeposit); err != nil {
+		return err
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if len(e.Tx.Creds) < 1 {
+		return errWrongCredentialsNumber
+	}
+
+	oldDeposit, err := e.State.GetDeposit(tx.DepositTxID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDepositNotFound, err)
+	}
+
+	depositOffer, err := e.State.GetDepositOffer(oldDeposit.DepositOfferID)
+	if err != nil {
+		return err
+	}
+
+	if tx.SplitAmount >= oldDeposit.Amount {
+		return errWrongSplitAmount
+	}
+
+	// verifying deposits, that split deposit will be split into
+
+	remainingDeposit, newDeposit := oldDeposit.Split(tx.SplitAmount)
+
+	remainingActiveAmount := remainingDeposit.Amount - remainingDeposit.UnlockedAmount
+	newActiveAmount := newDeposit.Amount - newDeposit.UnlockedAmount
+	remainingTotalReward := remainingDeposit.TotalReward(depositOffer)
+	newTotalReward := newDeposit.TotalReward(depositOffer)
+
+	switch {
+	case remainingActiveAmount == 0 || newActiveAmount == 0:
+		return errWrongSplitAmount
+	case remainingDeposit.Amount < depositOffer.MinAmount || newDeposit.Amount < depositOffer.MinAmount:
+		return errDepositToSmall
+	case remainingDeposit.ClaimedRewardAmount > remainingTotalReward ||
+		newDeposit.ClaimedRewardAmount > newTotalReward:
+		return errSplitClaimedReward
+	}
+
+	// verifying split deposit rewards owner credential, which is the last one
+
+	rewardsOwner, err := state.DepositRewardsOwner(e.State, tx.DepositTxID, oldDeposit)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errDepositNotFound, err)
+	}
+
+	if err := e.Fx.VerifyMultisigUnorderedPermission(
+		tx,
+		[]verify.Verifiable{e.Tx.Creds[len(e.Tx.Creds)-1]},
+		rewardsOwner,
+		e.State,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errDepositCredentialMissmatch, err)
+	}
+
+	newRewardsOwner, ok := tx.RewardsOwner.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return errWrongOwnerType
+	}
+
+	if err := e.Fx.VerifyMultisigOwner(
+		&secp256k1fx.TransferOutput{
+			OutputOwners: *newRewardsOwner,
+		}, e.State,
+	); err != nil {
+		return err
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, newRewardsOwner.Addrs); err != nil {
+		return err
+	}
+
+	// inputs consuming split deposit utxos are treated as if they weren't deposited,
+	// so they can be deposited again either with split deposit or with new deposit
+
+	splitIns := make([]*avax.TransferableInput, len(tx.Ins))
+	consumedAmount := uint64(0)
+	for i, in := range tx.Ins {
+		splitIns[i] = in
+		lockedIn, ok := in.In.(*locked.In)
+		if !ok || lockedIn.DepositTxID != tx.DepositTxID {
+			continue
+		}
+		consumedAmount, err = math.Add64(consumedAmount, lockedIn.Amount())
+		if err != nil {
+			return err
+		}
+		splitIns[i] = &avax.TransferableInput{
+			UTXOID: in.UTXOID,
+			Asset:  in.Asset,
+			In: &locked.In{
+				IDs:            lockedIn.IDs.Unlock(locked.StateDeposited),
+				TransferableIn: lockedIn.TransferableIn,
+			},
+		}
+	}
+
+	producedAmount := uint64(0)
+	newDepositAmount := uint64(0)
+	for _, out := range tx.Outs {
+		lockedOut, ok := locked.AsOut(out.Out)
+		if !ok || lockedOut.DepositTxID == ids.Empty {
+			continue
+		}
+		switch lockedOut.DepositTxID {
+		case locked.ThisTxID:
+			newDepositAmount, err = math.Add64(newDepositAmount, lockedOut.Amount())
+			if err != nil {
+				return err
+			}
+		case tx.DepositTxID:
+		default:
+			return errSplitDepositOutput
+		}
+		producedAmount, err = math.Add64(producedAmount, lockedOut.Amount())
+		if err != nil {
+			return err
+		}
+	}
+
+	if consumedAmount != producedAmount || newDepositAmount != newActiveAmount {
+		return errSplitAmountMismatch
+	}
+
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		&renewedDepositUTXOGetter{Chain: e.State, depositTxID: tx.DepositTxID},
+		splitIns,
+		tx.Outs,
+		e.Tx.Creds[:len(e.Tx.Creds)-1],
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateDeposited,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// total reward of split deposits could differ from split deposit total reward
+	// due to rounding or tiered interest rates, so potential reward in supply must be adjusted.
+	// Rewards in not primary network asset don't affect primary network supply.
+	if depositOffer.RewardAsset(e.Ctx.AVAXAssetID) == e.Ctx.AVAXAssetID {
+		currentSupply, err := e.State.GetCurrentSupply(constants.PrimaryNetworkID)
+		if err != nil {
+			return err
+		}
+
+		newSupply, err := math.Add64(currentSupply, remainingTotalReward)
+		if err != nil {
+			return errSupplyOverflow
+		}
+		newSupply, err = math.Add64(newSupply, newTotalReward)
+		if err != nil {
+			return errSupplyOverflow
+		}
+		newSupply, err = math.Sub(newSupply, oldDeposit.TotalReward(depositOffer))
+		if err != nil {
+			return err
+		}
+		if newSupply > e.Config.RewardConfig.SupplyCap {
+			return errSupplyOverflow
+		}
+		e.State.SetCurrentSupply(constants.PrimaryNetworkID, newSupply)
+	}
+
+	// splitting deposit
+
+	txID := e.Tx.ID()
+
+	e.State.ModifyDeposit(tx.DepositTxID, remainingDeposit)
+	e.State.AddDeposit(txID, newDeposit)
+
+	utxo.Consume(e.State, tx.Ins)
+	if err := utxo.ProduceLocked(e.State, txID, tx.Outs, locked.StateDeposited); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (e *CaminoStandardTxExecutor) MultisigAliasTx(tx *txs.MultisigAliasTx) error {
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if err := multisig.VerifyMemoSize(tx.MultisigAlias.Memo, e.Config.CaminoConfig.MaxMultisigAliasMemoSize); err != nil {
+		return err
+	}
+
+	aliasID := tx.MultisigAlias.ID
+	baseTxCreds := e.Tx.Creds
+
+	if aliasID == ids.ShortEmpty {
+		// creating new alias
+
+		aliasID = multisig.ComputeAliasID(e.Tx.ID())
+		if _, err := e.State.GetMultisigAlias(aliasID); err == nil {
+			return fmt.Errorf("%w: %s", errAliasAlreadyExists, aliasID)
+		} else if err != database.ErrNotFound {
+			return err
+		}
+	} else {
+		// verifying current alias owners credential, which is the last one
+
+		if len(e.Tx.Creds) < 1 {
+			return errWrongCredentialsNumber
+		}
+
+		currentAlias, err := e.State.GetMultisigAlias(aliasID)
+		if err == database.ErrNotFound {
+			return fmt.Errorf("%w: %s", secp256k1fx.ErrAliasNotFound, aliasID)
+		} else if err != nil {
+			return err
+		}
+
+		if err := utxo.VerifyNotRetiredAliases(e.State, []ids.ShortID{aliasID}); err != nil {
+			return err
+		}
+
+		if err := e.verifyMultisigPermission(
+			tx.ChangeAuth,
+			e.Tx.Creds[len(e.Tx.Creds)-1],
+			currentAlias.Owners,
+		); err != nil {
+			return fmt.Errorf("%w: %s", errAliasCredentialMismatch, err)
+		}
+
+		baseTxCreds = e.Tx.Creds[:len(e.Tx.Creds)-1]
+	}
+
+	// new owners could be either plain or weighted
+	newOwners, _, err := secp256k1fx.OwnersWeights(tx.MultisigAlias.Owners)
+	if err != nil {
+		return errWrongOwnerType
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, newOwners.Addrs); err != nil {
+		return err
+	}
+
+	newAlias := &multisig.Alias{
+		ID:     aliasID,
+		Memo:   tx.MultisigAlias.Memo,
+		Owners: tx.MultisigAlias.Owners,
+	}
+
+	// new alias owners must not create cycles or exceed nesting depth
+	if err := secp256k1fx.VerifyAliasNesting(
+		&secp256k1fx.OutputOwners{Addrs: []ids.ShortID{aliasID}},
+		&aliasOverrideGetter{AliasGetter: e.State, alias: newAlias},
+		e.Config.CaminoConfig.MaxMultisigAliasDepth,
+	); err != nil {
+		return err
+	}
+
+	// BaseTx / fee check
+
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		baseTxCreds,
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateUnlocked,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// update state
+
+	txID := e.Tx.ID()
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+	e.State.SetMultisigAlias(newAlias)
+
+	return nil
+}
+
+func (e *CaminoStandardTxExecutor) RotateMultisigAliasTx(tx *txs.RotateMultisigAliasTx) error {
+	if err := locked.VerifyNoLocks(tx.Ins, tx.Outs); err != nil {
+		return err
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if err := multisig.VerifyMemoSize(tx.Successor.Memo, e.Config.CaminoConfig.MaxMultisigAliasMemoSize); err != nil {
+		return err
+	}
+
+	if len(e.Tx.Creds) < 1 {
+		return errWrongCredentialsNumber
+	}
+
+	// verifying retired alias owners credential, which is the last one
+
+	retiredAlias, err := e.State.GetMultisigAlias(tx.RetiredAliasID)
+	if err == database.ErrNotFound {
+		return fmt.Errorf("%w: %s", secp256k1fx.ErrAliasNotFound, tx.RetiredAliasID)
+	} else if err != nil {
+		return err
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, []ids.ShortID{tx.RetiredAliasID}); err != nil {
+		return err
+	}
+
+	if err := e.verifyMultisigPermission(
+		tx.RetiredAliasAuth,
+		e.Tx.Creds[len(e.Tx.Creds)-1],
+		retiredAlias.Owners,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errAliasCredentialMismatch, err)
+	}
+
+	// verifying successor alias
+
+	txID := e.Tx.ID()
+	successorID := multisig.ComputeAliasID(txID)
+	if _, err := e.State.GetMultisigAlias(successorID); err == nil {
+		return fmt.Errorf("%w: %s", errAliasAlreadyExists, successorID)
+	} else if err != database.ErrNotFound {
+		return err
+	}
+
+	successorOwners, _, err := secp256k1fx.OwnersWeights(tx.Successor.Owners)
+	if err != nil {
+		return errWrongOwnerType
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, successorOwners.Addrs); err != nil {
+		return err
+	}
+
+	successor := &multisig.Alias{
+		ID:     successorID,
+		Memo:   tx.Successor.Memo,
+		Owners: tx.Successor.Owners,
+	}
+
+	if err := secp256k1fx.VerifyAliasNesting(
+		&secp256k1fx.OutputOwners{Addrs: []ids.ShortID{successorID}},
+		&aliasOverrideGetter{AliasGetter: e.State, alias: successor},
+		e.Config.CaminoConfig.MaxMultisigAliasDepth,
+	); err != nil {
+		return err
+	}
+
+	// BaseTx / fee check
+
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		e.Tx.Creds[:len(e.Tx.Creds)-1],
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateUnlocked,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// update state
+
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+	e.State.SetMultisigAlias(successor)
+	e.State.SetShortIDLink(tx.RetiredAliasID, state.ShortLinkKeyMultisigAliasSuccessor, &successorID)
+
+	return nil
+}
+
+// verifyMultisigPermission returns nil if [cred] proves that [owners] assents to executed tx with [auth].
+func (e *CaminoStandardTxExecutor) DepositOfferTx(tx *txs.DepositOfferTx) error {
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
+
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	if len(e.Tx.Creds) < 1 {
+		return errWrongCredentialsNumber
+	}
+
+	// verifying admin role and credential, which is the last one
+
+	adminAddressState, err := e.State.GetAddressStates(tx.AdminAddress)
+	if err != nil {
+		return err
+	}
+
+	if !adminAddressState.Has(as.AddressStateRoleAdmin) {
+		return errInvalidRoles
+	}
+
+	if err := e.verifyMultisigPermission(
+		tx.AdminAuth,
+		e.Tx.Creds[len(e.Tx.Creds)-1],
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{tx.AdminAddress},
+		},
+	); err != nil {
+		return fmt.Errorf("%w: %s", errAdminCredentialMismatch, err)
+	}
+
+	// verifying offer
+
+	offer := tx.Offer()
+
+	if !offer.EndTime().After(e.State.GetTimestamp()) {
+		return errDepositOfferEnded
+	}
+
+	if tx.DepositOfferID == ids.Empty {
+		if err := offer.SetID(); err != nil {
+			return err
+		}
+		if _, err := e.State.GetDepositOffer(offer.ID); err == nil {
+			return fmt.Errorf("%w: %s", errDepositOfferExists, offer.ID)
+		} else if err != database.ErrNotFound {
+			return err
+		}
+	} else {
+		currentOffer, err := e.State.GetDepositOffer(tx.DepositOfferID)
 		if err != nil {
 			return err
 		}
+		if currentOffer.Flags&deposits.OfferFlagLocked != 0 {
+			return errDepositOfferInactive
+		}
+		offer.DepositedAmount = currentOffer.DepositedAmount
+		if err := verifyDepositOfferTermsUnchanged(currentOffer, offer); err != nil {
+			return err
+		}
+		if err := offer.Verify(); err != nil {
+			return err
+		}
+	}
+
+	// BaseTx / fee check
+
+	if err := e.FlowChecker.VerifyLock(
+		tx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		e.Tx.Creds[:len(e.Tx.Creds)-1],
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateUnlocked,
+	); err != nil {
+		return fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// update state
+
+	if tx.DepositOfferID == ids.Empty {
+		e.State.SetDepositOffer(offer)
+	} else if err := state.UpdateDepositOffer(e.State, offer); err != nil {
+		return err
+	}
+
+	txID := e.Tx.ID()
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
+// verifyDepositOfferTermsUnchanged verifies that [updatedOffer] keeps terms of [currentOffer],
+// which are used to calculate rewards and unlocks of its existing deposits.
+// Offer availability for new deposits, display fields and paused and locked flags could be changed.
+func verifyDepositOfferTermsUnchanged(currentOffer, updatedOffer *deposits.Offer) error {
+	const updatableFlags = deposits.OfferFlagPaused | deposits.OfferFlagLocked
+
+	expectedOffer := *currentOffer
+	expectedOffer.Version = updatedOffer.Version
+	expectedOffer.Start = updatedOffer.Start
+	expectedOffer.End = updatedOffer.End
+	expectedOffer.MinAmount = updatedOffer.MinAmount
+	expectedOffer.MaxAmount = updatedOffer.MaxAmount
+	expectedOffer.TotalMaxAmount = updatedOffer.TotalMaxAmount
+	expectedOffer.MinDuration = updatedOffer.MinDuration
+	expectedOffer.MaxDuration = updatedOffer.MaxDuration
+	expectedOffer.RequiredAddressState = updatedOffer.RequiredAddressState
+	expectedOffer.OwnerAddress = updatedOffer.OwnerAddress
+	expectedOffer.Memo = updatedOffer.Memo
+	expectedOffer.Name = updatedOffer.Name
+	expectedOffer.URI = updatedOffer.URI
+	expectedOffer.Flags = currentOffer.Flags&^updatableFlags | updatedOffer.Flags&updatableFlags
+
+	if !reflect.DeepEqual(&expectedOffer, updatedOffer) {
+		return errDepositOfferTermsChanged
+	}
+	return nil
+}
+
+func (e *CaminoStandardTxExecutor) verifyMultisigPermission(auth, cred verify.Verifiable, owners interface{}) error {
+	in, ok := auth.(*secp256k1fx.Input)
+	if !ok {
+		return errWrongAuthType
+	}
+	secpCred, ok := cred.(secp256k1fx.CredentialIntf)
+	if !ok {
+		return errWrongCredentialType
+	}
+	msigOwners, ok := owners.(secp256k1fx.MultisigOwners)
+	if !ok {
+		return errWrongOwnerType
+	}
+	return e.Fx.VerifyMultisigPermission(e.Tx.Unsigned, in, secpCred, msigOwners, e.State)
+}
+
+// aliasOverrideGetter returns [alias] instead of alias with the same id from [AliasGetter]
+type aliasOverrideGetter struct {
+	secp256k1fx.AliasGetter
+	alias *multisig.Alias
+}
+
+func (g *aliasOverrideGetter) GetMultisigAlias(aliasID ids.ShortID) (*multisig.Alias, error) {
+	if aliasID == g.alias.ID {
+		return g.alias, nil
+	}
+	return g.AliasGetter.GetMultisigAlias(aliasID)
+}
+
+// addDepositRewardToClaimable adds [reward] of [deposit] created by tx [depositTxID]
+// to claimable of this deposit rewards owner
+func (e *CaminoStandardTxExecutor) addDepositRewardToClaimable(
+	depositTxID ids.ID,
+	deposit *deposits.Deposit,
+	offer *deposits.Offer,
+	reward uint64,
+) error {
+	if reward == 0 {
+		return nil
+	}
+
+	rewardsOwner, err := state.DepositRewardsOwner(e.State, depositTxID, deposit)
+	if err != nil {
+		return fmt.Errorf("can't get deposit rewards owner: %w", err)
+	}
+
+	ownerID, err := txs.GetOwnerID(rewardsOwner)
+	if err != nil {
+		return err
+	}
+
+	// rewards in not primary network asset are accounted in separate claimable
+	rewardAssetID := offer.RewardAssetID
+	if rewardAssetID == e.Ctx.AVAXAssetID {
+		rewardAssetID = ids.Empty
+	}
+	claimableOwnerID := state.ClaimableID(ownerID, rewardAssetID, e.Ctx.AVAXAssetID)
+
+	claimable, err := e.State.GetClaimable(claimableOwnerID)
+	if err == database.ErrNotFound {
+		claimable = &state.Claimable{
+			Owner:         rewardsOwner,
+			RewardAssetID: rewardAssetID,
+		}
+	} else if err != nil {
+		return err
+	}
+
+	newClaimable := &state.Claimable{
+		Owner:           claimable.Owner,
+		ValidatorReward: claimable.ValidatorReward,
+		RewardAssetID:   claimable.RewardAssetID,
+	}
+
+	newClaimable.DepositReward, err = math.Add64(claimable.DepositReward, reward)
+	if err != nil {
+		return err
+	}
+
+	e.State.SetClaimable(claimableOwnerID, newClaimable)
+	return nil
+}
+
+func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
+	// Basic checks
+
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
+
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Backend.Ctx); err != nil {
+		return err
+	}
+
+	_, err = e.claim(tx, tx, e.Tx.Creds, false)
+	return err
+}
+
+func (e *CaminoStandardTxExecutor) ClaimDepositTx(tx *txs.ClaimDepositTx) error {
+	caminoConfig, err := e.State.CaminoConfig()
+	if err != nil {
+		return err
+	}
+
+	if !caminoConfig.LockModeBondDeposit {
+		return errWrongLockMode
+	}
+
+	if err := e.Tx.SyntacticVerify(e.Backend.Ctx); err != nil {
+		return err
+	}
+
+	// offer owner credential of restricted offer is the last one, claim credentials are before it
+
+	depositOffer, err := e.State.GetDepositOffer(tx.DepositOfferID)
+	if err != nil {
+		return err
+	}
+
+	claimCreds := e.Tx.Creds
+	if depositOffer.IsRestricted() {
+		if len(claimCreds) == 0 {
+			return errWrongCredentialsNumber
+		}
+		claimCreds = claimCreds[:len(claimCreds)-1]
+	}
+
+	// claiming rewards, claimed outputs are deposited with this tx
+
+	depositAmount, err := e.claim(tx, &tx.ClaimTx, claimCreds, true)
+	if err != nil {
+		return err
+	}
+
+	// creating new deposit
+
+	depositOffer, _, err = e.verifyNewDeposit(
+		tx,
+		tx.DepositOfferID,
+		tx.DepositDuration,
+		depositAmount,
+		tx.RewardsOwner,
+	)
+	if err != nil {
+		return err
+	}
+
+	return e.addNewDeposit(e.Tx.ID(), tx.DepositOfferID, depositOffer, tx.DepositDuration, depositAmount)
+}
+
+// claim verifies claim of deposits rewards and claimables described by [tx], which is part of [utx],
+// updates them in state and mints claimed outputs after [tx] outputs. Fee is burned with [tx] base ins and outs.
+// Claimables credential is the last one of [creds], previous ones are base tx credentials.
+// If [depositClaimed] is true, claimed outputs are minted deposited with this tx and must be in primary network asset.
+// Returns total claimed amount.
+func (e *CaminoStandardTxExecutor) claim(
+	utx txs.UnsignedTx,
+	tx *txs.ClaimTx,
+	creds []verify.Verifiable,
+	depositClaimed bool,
+) (uint64, error) {
+	if len(creds) == 0 {
+		return 0, errWrongCredentialsNumber
+	}
+
+	// BaseTx / fee check
+
+	if err := e.FlowChecker.VerifyLock(
+		utx,
+		e.State,
+		tx.Ins,
+		tx.Outs,
+		creds[:len(creds)-1],
+		e.Config.TxFee,
+		e.Ctx.AVAXAssetID,
+		locked.StateUnlocked,
+	); err != nil {
+		return 0, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// Common vars
+
+	currentTimestamp := uint64(e.State.GetTimestamp().Unix())
+	claimableCredential := []verify.Verifiable{creds[len(creds)-1]}
+	txID := e.Tx.ID()
+	claimedAmount := uint64(0)
+
+	secpClaimTo, ok := tx.ClaimTo.(*secp256k1fx.OutputOwners)
+	if !ok {
+		return 0, errNotSECPOwner
+	}
+
+	if err := utxo.VerifyNotRetiredAliases(e.State, secpClaimTo.Addrs); err != nil {
+		return 0, err
+	}
+
+	newClaimTo := len(secpClaimTo.Addrs) != 0
+
+	// Checking deposits sigs and creating reward outputs
+
+	mintedOutsCount := 0
+
+	for _, depositTxID := range tx.DepositTxIDs {
+		// getting deposit and its rewards owner
+
+		deposit, err := e.State.GetDeposit(depositTxID)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errDepositNotFound, err)
+		}
+
+		rewardsOwner, err := state.DepositRewardsOwner(e.State, depositTxID, deposit)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errDepositNotFound, err)
+		}
+
+		// checking deposit signatures
+
+		if err := e.Fx.VerifyMultisigUnorderedPermission(
+			utx,
+			claimableCredential,
+			rewardsOwner,
+			e.State,
+		); err != nil {
+			return 0, fmt.Errorf("%w: %s", errDepositCredentialMissmatch, err)
+		}
+
+		// creating reward output, if there is any
+
+		depositOffer, err := e.State.GetDepositOffer(deposit.DepositOfferID)
+		if err != nil {
+			return 0, err
+		}
 
 		claimableReward := deposit.ClaimableReward(depositOffer, currentTimestamp)
 		if claimableReward > 0 {
-			claimTo := depositTx.RewardsOwner
+			var claimTo fx.Owner = rewardsOwner
 			if newClaimTo {
 				claimTo = tx.ClaimTo
 			}
 
-			outIntf, err := e.Fx.CreateOutput(claimableReward, claimTo)
+			rewardAssetID := depositOffer.RewardAsset(e.Ctx.AVAXAssetID)
+			out, err := e.newClaimedOut(claimableReward, claimTo, rewardAssetID, depositClaimed)
 			if err != nil {
-				return fmt.Errorf("failed to create output: %w", err)
-			}
-			out, ok := outIntf.(verify.State)
-			if !ok {
-				return errInvalidState
+				return 0, err
 			}
 
 			utxo := &avax.UTXO{
@@ -878,11 +1980,16 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 					TxID:        txID,
 					OutputIndex: uint32(len(tx.Outs) + mintedOutsCount),
 				},
-				Asset: avax.Asset{ID: e.Ctx.AVAXAssetID},
+				Asset: avax.Asset{ID: rewardAssetID},
 				Out:   out,
 			}
 			mintedOutsCount++
 
+			claimedAmount, err = math.Add64(claimedAmount, claimableReward)
+			if err != nil {
+				return 0, err
+			}
+
 			e.State.AddUTXO(utxo)
 			e.State.AddRewardUTXO(depositTxID, utxo)
 			e.State.ModifyDeposit(depositTxID, &deposits.Deposit{
@@ -892,6 +1999,12 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 				Start:               deposit.Start,
 				Duration:            deposit.Duration,
 				Amount:              deposit.Amount,
+				RewardOwner:         deposit.RewardOwner,
+			})
+			e.State.AddDepositClaim(depositTxID, &deposits.Claim{
+				ClaimTxID: txID,
+				Timestamp: currentTimestamp,
+				Amount:    claimableReward,
 			})
 		}
 	}
@@ -903,18 +2016,18 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 		claimable, err := e.State.GetClaimable(ownerID)
 		if err == database.ErrNotFound {
 			// tx.ClaimedAmount[i] > 0, so we'r trying to claim more, than available
-			return fmt.Errorf("no claimable found for the ownerID (%s): %w", ownerID, errWrongClaimedAmount)
+			return 0, fmt.Errorf("no claimable found for the ownerID (%s): %w", ownerID, errWrongClaimedAmount)
 		} else if err != nil {
-			return err
+			return 0, err
 		}
 
 		if err := e.Fx.VerifyMultisigUnorderedPermission(
-			tx,
+			utx,
 			claimableCredential,
 			claimable.Owner,
 			e.State,
 		); err != nil {
-			return fmt.Errorf("%w: %s", errClaimableCredentialMissmatch, err)
+			return 0, fmt.Errorf("%w: %s", errClaimableCredentialMissmatch, err)
 		}
 
 		amountToClaim := tx.ClaimedAmounts[i]
@@ -938,7 +2051,7 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 		}
 
 		if amountToClaim > 0 {
-			return errWrongClaimedAmount
+			return 0, errWrongClaimedAmount
 		}
 
 		var claimTo fx.Owner = claimable.Owner
@@ -946,13 +2059,14 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 			claimTo = tx.ClaimTo
 		}
 
-		outIntf, err := e.Fx.CreateOutput(tx.ClaimedAmounts[i], claimTo)
-		if err != nil {
-			return fmt.Errorf("failed to create output: %w", err)
+		claimedAssetID := e.Ctx.AVAXAssetID
+		if claimable.RewardAssetID != ids.Empty {
+			claimedAssetID = claimable.RewardAssetID
 		}
-		out, ok := outIntf.(verify.State)
-		if !ok {
-			return errInvalidState
+
+		out, err := e.newClaimedOut(tx.ClaimedAmounts[i], claimTo, claimedAssetID, depositClaimed)
+		if err != nil {
+			return 0, err
 		}
 
 		utxo := &avax.UTXO{
@@ -960,11 +2074,16 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 				TxID:        txID,
 				OutputIndex: uint32(len(tx.Outs) + mintedOutsCount),
 			},
-			Asset: avax.Asset{ID: e.Ctx.AVAXAssetID},
+			Asset: avax.Asset{ID: claimedAssetID},
 			Out:   out,
 		}
 		mintedOutsCount++
 
+		claimedAmount, err = math.Add64(claimedAmount, tx.ClaimedAmounts[i])
+		if err != nil {
+			return 0, err
+		}
+
 		e.State.AddUTXO(utxo)
 		e.State.AddRewardUTXO(txID, utxo)
 
@@ -974,6 +2093,7 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 				Owner:           claimable.Owner,
 				ValidatorReward: newClaimableValidatorReward,
 				DepositReward:   newClaimableDepositReward,
+				RewardAssetID:   claimable.RewardAssetID,
 			}
 		}
 		e.State.SetClaimable(ownerID, newClaimabe)
@@ -984,7 +2104,39 @@ func (e *CaminoStandardTxExecutor) ClaimTx(tx *txs.ClaimTx) error {
 	utxo.Consume(e.State, tx.Ins)
 	utxo.Produce(e.State, txID, tx.Outs)
 
-	return nil
+	return claimedAmount, nil
+}
+
+// newClaimedOut returns output with claimed [amount] of [assetID] for [owner].
+// If [deposit] is true, output is deposited with this tx.
+func (e *CaminoStandardTxExecutor) newClaimedOut(
+	amount uint64,
+	owner fx.Owner,
+	assetID ids.ID,
+	deposit bool,
+) (verify.State, error) {
+	if deposit && assetID != e.Ctx.AVAXAssetID {
+		return nil, errClaimedAssetNotDepositable
+	}
+
+	outIntf, err := e.Fx.CreateOutput(amount, owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output: %w", err)
+	}
+
+	if deposit {
+		transferOut, ok := outIntf.(avax.TransferableOut)
+		if !ok {
+			return nil, errInvalidState
+		}
+		outIntf = locked.NewOut(locked.IDs{DepositTxID: e.Tx.ID()}, transferOut)
+	}
+
+	out, ok := outIntf.(verify.State)
+	if !ok {
+		return nil, errInvalidState
+	}
+	return out, nil
 }
 
 func (e *CaminoStandardTxExecutor) RegisterNodeTx(tx *txs.RegisterNodeTx) error {
@@ -1003,7 +2155,7 @@ func (e *CaminoStandardTxExecutor) RegisterNodeTx(tx *txs.RegisterNodeTx) error
 		return err
 	}
 
-	if consortiumMemberAddressState&txs.AddressStateConsortiumBit == 0 {
+	if !consortiumMemberAddressState.Has(as.AddressStateConsortium) {
 		return errNotConsortiumMember
 	}
 
@@ -1027,15 +2179,13 @@ func (e *CaminoStandardTxExecutor) RegisterNodeTx(tx *txs.RegisterNodeTx) error
 	}
 
 	// verify consortium member cred
-	if err := e.Backend.Fx.VerifyMultisigPermission(
-		e.Tx.Unsigned,
+	if err := e.verifyMultisigPermission(
 		tx.ConsortiumMemberAuth,
 		e.Tx.Creds[len(e.Tx.Creds)-1], // consortium member cred
 		&secp256k1fx.OutputOwners{
 			Threshold: 1,
 			Addrs:     []ids.ShortID{tx.ConsortiumMemberAddress},
 		},
-		e.State,
 	); err != nil {
 		return fmt.Errorf("%w: %s", errConsortiumSignatureMissing, err)
 	}
@@ -1131,28 +2281,13 @@ func (e *CaminoStandardTxExecutor) RewardsImportTx(tx *txs.RewardsImportTx) erro
 	if e.Bootstrapped.GetValue() {
 		// Getting all treasury utxos exported from c-chain, collecting ones that are old enough
 
-		allUTXOBytes, _, _, err := e.Ctx.SharedMemory.Indexed(
+		utxos, err := treasury.ImportableUTXOs(
+			e.Ctx.SharedMemory,
 			e.Ctx.CChainID,
-			treasury.AddrTraitsBytes,
-			ids.ShortEmpty[:], ids.Empty[:], maxPageSize,
+			uint64(e.State.GetTimestamp().Unix()),
 		)
 		if err != nil {
-			return fmt.Errorf("error fetching atomic UTXOs: %w", err)
-		}
-
-		chainTimestamp := uint64(e.State.GetTimestamp().Unix())
-
-		utxos := []*avax.UTXO{}
-		for _, utxoBytes := range allUTXOBytes {
-			utxo := &avax.TimedUTXO{}
-			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
-				// that means that this could be simple, not-timed utxo
-				continue
-			}
-
-			if utxo.Timestamp <= chainTimestamp-atomic.SharedMemorySyncBound {
-				utxos = append(utxos, &utxo.UTXO)
-			}
+			return err
 		}
 
 		// Verifying that utxos match inputs
@@ -1307,7 +2442,30 @@ func (e *CaminoStandardTxExecutor) AddressStateTx(tx *txs.AddressStateTx) error
 		return err
 	}
 
-	addresses, err := e.Fx.RecoverAddresses(tx, e.Tx.Creds)
+	return e.addressStateTx(tx, tx.Ins, tx.Outs, tx.Address, []txs.AddressStateOp{{
+		State:  tx.State,
+		Remove: tx.Remove,
+	}})
+}
+
+func (e *CaminoStandardTxExecutor) AddressStateBatchTx(tx *txs.AddressStateBatchTx) error {
+	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
+		return err
+	}
+
+	return e.addressStateTx(tx, tx.Ins, tx.Outs, tx.Address, tx.Ops)
+}
+
+// addressStateTx verifies and executes address state tx [utx] with [ins] and [outs],
+// that applies [ops] to [address] states
+func (e *CaminoStandardTxExecutor) addressStateTx(
+	utx txs.UnsignedTx,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	address ids.ShortID,
+	ops []txs.AddressStateOp,
+) error {
+	addresses, err := e.Fx.RecoverAddresses(utx, e.Tx.Creds)
 	if err != nil {
 		return fmt.Errorf("%w: %s", errRecoverAdresses, err)
 	}
@@ -1317,40 +2475,43 @@ func (e *CaminoStandardTxExecutor) AddressStateTx(tx *txs.AddressStateTx) error
 	}
 
 	// Accumulate roles over all signers
-	roles := uint64(0)
-	for address := range addresses {
-		states, err := e.State.GetAddressStates(address)
+	roles := as.AddressStateEmpty
+	for addr := range addresses {
+		states, err := e.State.GetAddressStates(addr)
 		if err != nil {
 			return err
 		}
-		roles |= states
+		roles = roles.Set(states)
 	}
-	statesBit := uint64(1) << uint64(tx.State)
 
-	// Verify that roles are allowed to modify tx.State
-	if err := verifyAccess(roles, statesBit); err != nil {
-		return err
+	// Verify that roles are allowed to modify ops states
+	for _, op := range ops {
+		if err := verifyAccess(roles, op.State.ToAddressState()); err != nil {
+			return err
+		}
 	}
 
 	// Get the current state
-	states, err := e.State.GetAddressStates(tx.Address)
+	states, err := e.State.GetAddressStates(address)
 	if err != nil {
 		return err
 	}
 	// Calculate new states
 	newStates := states
-	if tx.Remove && (states&statesBit) != 0 {
-		newStates ^= statesBit
-	} else if !tx.Remove {
-		newStates |= statesBit
+	for _, op := range ops {
+		if op.Remove {
+			newStates = newStates.Remove(op.State.ToAddressState())
+		} else {
+			newStates = newStates.Set(op.State.ToAddressState())
+		}
 	}
 
 	// Verify the flowcheck
 	if err := e.FlowChecker.VerifySpend(
-		tx,
+		utx,
 		e.State,
-		tx.Ins,
-		tx.Outs,
+		ins,
+		outs,
 		e.Tx.Creds,
 		map[ids.ID]uint64{
 			e.Ctx.AVAXAssetID: e.Config.TxFee,
@@ -1361,13 +2522,16 @@ func (e *CaminoStandardTxExecutor) AddressStateTx(tx *txs.AddressStateTx) error
 
 	txID := e.Tx.ID()
 
-	if tx.State == txs.AddressStateNodeDeferred {
-		nodeShortID, err := e.State.GetShortIDLink(tx.Address, state.ShortLinkKeyRegisterNode)
+	for _, op := range ops {
+		if op.State != as.AddressStateBitNodeDeferred {
+			continue
+		}
+		nodeShortID, err := e.State.GetShortIDLink(address, state.ShortLinkKeyRegisterNode)
 		if err != nil {
 			return fmt.Errorf("couldn't get consortium member registered nodeID: %w", err)
 		}
 		nodeID := ids.NodeID(nodeShortID)
-		if tx.Remove {
+		if op.Remove {
 			// transfer staker to from deferred to current stakers set
 			stakerToReactivate, err := e.State.GetDeferredValidator(constants.PrimaryNetworkID, nodeID)
 			if err != nil {
@@ -1387,25 +2551,25 @@ func (e *CaminoStandardTxExecutor) AddressStateTx(tx *txs.AddressStateTx) error
 	}
 
 	// Consume the UTXOS
-	utxo.Consume(e.State, tx.Ins)
+	utxo.Consume(e.State, ins)
 	// Produce the UTXOS
-	utxo.Produce(e.State, txID, tx.Outs)
+	utxo.Produce(e.State, txID, outs)
 	// Set the new states if changed
 	if states != newStates {
-		e.State.SetAddressStates(tx.Address, newStates)
+		e.State.SetAddressStates(address, newStates)
 	}
 
 	return nil
 }
 
-func verifyAccess(roles, statesBit uint64) error {
+func verifyAccess(roles, statesBit as.AddressState) error {
 	switch {
-	case (roles & txs.AddressStateRoleAdminBit) != 0:
-	case (txs.AddressStateKycBits & statesBit) != 0:
-		if (roles & txs.AddressStateRoleKycBit) == 0 {
+	case roles.Has(as.AddressStateRoleAdmin):
+	case as.AddressStateKycBits.HasAny(statesBit):
+		if !roles.Has(as.AddressStateRoleKyc) {
 			return errInvalidRoles
 		}
-	case (txs.AddressStateRoleBits & statesBit) != 0:
+	case as.AddressStateRoleBits.HasAny(statesBit):
 		return errInvalidRoles
 	}
 	return nil
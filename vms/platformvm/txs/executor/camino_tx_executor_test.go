@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/multisig"
+	as "github.com/ava-labs/avalanchego/vms/platformvm/addrstate"
 	"github.com/ava-labs/avalanchego/vms/platformvm/api"
 	"github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -620,13 +622,27 @@ func TestCaminoStandardTxExecutorAddValidatorTxBody(t *testing.T) {
 	addr0 := caminoPreFundedKeys[0].Address()
 	env.state.SetShortIDLink(ids.ShortID(nodeID), state.ShortLinkKeyRegisterNode, &addr0)
 
-	existingTxID := ids.GenerateTestID()
 	env.config.BanffTime = env.state.GetTimestamp()
 	outputOwners := secp256k1fx.OutputOwners{
 		Locktime:  0,
 		Threshold: 1,
 		Addrs:     []ids.ShortID{caminoPreFundedKeys[0].PublicKey().Address()},
 	}
+
+	existingDepositOffer := &deposit.Offer{ID: ids.GenerateTestID(), End: uint64(defaultValidateEndTime.Unix())}
+	existingDepositTx, err := txs.NewSigned(&txs.DepositTx{
+		BaseTx:         txs.BaseTx{BaseTx: avax.BaseTx{NetworkID: env.ctx.NetworkID, BlockchainID: env.ctx.ChainID}},
+		DepositOfferID: existingDepositOffer.ID,
+		RewardsOwner:   &outputOwners,
+	}, txs.Codec, nil)
+	require.NoError(t, err)
+	existingTxID := existingDepositTx.ID()
+	env.state.SetDepositOffer(existingDepositOffer)
+	env.state.AddTx(existingDepositTx, status.Committed)
+	env.state.AddDeposit(existingTxID, &deposit.Deposit{
+		DepositOfferID: existingDepositOffer.ID,
+		Amount:         defaultCaminoValidatorWeight * 2,
+	})
 	sigIndices := []uint32{0}
 	inputSigners := []*crypto.PrivateKeySECP256K1R{caminoPreFundedKeys[0]}
 
@@ -758,6 +774,66 @@ func TestCaminoStandardTxExecutorAddValidatorTxBody(t *testing.T) {
 	}
 }
 
+func TestCaminoStandardTxExecutorVerifyDepositsBondable(t *testing.T) {
+	depositTxID1 := ids.ID{1}
+	depositTxID2 := ids.ID{2}
+	bondableOffer := &deposit.Offer{ID: ids.ID{11}}
+	notBondableOffer := &deposit.Offer{ID: ids.ID{12}, Flags: deposit.OfferFlagNoBonding}
+	bondableDeposit := &deposit.Deposit{DepositOfferID: bondableOffer.ID}
+	notBondableDeposit := &deposit.Deposit{DepositOfferID: notBondableOffer.ID}
+
+	ins := []*avax.TransferableInput{
+		generateTestIn(avaxAssetID, 1, ids.Empty, ids.Empty, []uint32{0}),
+		generateTestIn(avaxAssetID, 1, depositTxID1, ids.Empty, []uint32{0}),
+		generateTestIn(avaxAssetID, 1, depositTxID1, ids.Empty, []uint32{0}),
+		generateTestIn(avaxAssetID, 1, depositTxID2, ids.Empty, []uint32{0}),
+	}
+
+	tests := map[string]struct {
+		state       func(*gomock.Controller) *state.MockDiff
+		expectedErr error
+	}{
+		"Deposit not found": {
+			state: func(c *gomock.Controller) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetDeposit(depositTxID1).Return(nil, database.ErrNotFound)
+				return s
+			},
+			expectedErr: errDepositNotFound,
+		},
+		"Offer doesn't allow bonding": {
+			state: func(c *gomock.Controller) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetDeposit(depositTxID1).Return(bondableDeposit, nil)
+				s.EXPECT().GetDepositOffer(bondableOffer.ID).Return(bondableOffer, nil)
+				s.EXPECT().GetDeposit(depositTxID2).Return(notBondableDeposit, nil)
+				s.EXPECT().GetDepositOffer(notBondableOffer.ID).Return(notBondableOffer, nil)
+				return s
+			},
+			expectedErr: errDepositNotBondable,
+		},
+		"OK": {
+			state: func(c *gomock.Controller) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetDeposit(depositTxID1).Return(bondableDeposit, nil)
+				s.EXPECT().GetDepositOffer(bondableOffer.ID).Return(bondableOffer, nil)
+				s.EXPECT().GetDeposit(depositTxID2).Return(bondableDeposit, nil)
+				s.EXPECT().GetDepositOffer(bondableOffer.ID).Return(bondableOffer, nil)
+				return s
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			executor := &CaminoStandardTxExecutor{StandardTxExecutor{State: tt.state(ctrl)}}
+			require.ErrorIs(t, executor.verifyDepositsBondable(ins), tt.expectedErr)
+		})
+	}
+}
+
 func TestCaminoLockedInsOrLockedOuts(t *testing.T) {
 	outputOwners := secp256k1fx.OutputOwners{
 		Locktime:  0,
@@ -982,7 +1058,7 @@ func TestCaminoLockedInsOrLockedOuts(t *testing.T) {
 					Outs:         tt.outs,
 				}},
 				Address: caminoPreFundedKeys[0].PublicKey().Address(),
-				State:   uint8(0),
+				State:   as.AddressStateBitRoleAdmin,
 				Remove:  false,
 			}
 
@@ -1653,27 +1729,27 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 	tests := map[string]struct {
 		stateAddress  ids.ShortID
 		targetAddress ids.ShortID
-		txFlag        uint8
-		existingState uint64
+		txFlag        as.AddressStateBit
+		existingState as.AddressState
 		expectedErr   error
-		expectedState uint64
+		expectedState as.AddressState
 		remove        bool
 	}{
 		// Bob has Admin State, and he is trying to give himself Admin Role (again)
 		"State: Admin, Flag: Admin, Add, Same Address": {
 			stateAddress:  bob,
 			targetAddress: bob,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateRoleAdmin,
 			remove:        false,
 		},
 		// Bob has KYC State, and he is trying to give himself KYC Role (again)
 		"State: KYC, Flag: KYC, Add, Same Address": {
 			stateAddress:  bob,
 			targetAddress: bob,
-			txFlag:        txs.AddressStateRoleKyc,
-			existingState: txs.AddressStateRoleKycBit,
+			txFlag:        as.AddressStateBitRoleKyc,
+			existingState: as.AddressStateRoleKyc,
 			expectedErr:   errInvalidRoles,
 			remove:        false,
 		},
@@ -1681,8 +1757,8 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 		"State: KYC, Flag: Admin, Add, Same Address": {
 			stateAddress:  bob,
 			targetAddress: bob,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleKycBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleKyc,
 			expectedErr:   errInvalidRoles,
 			remove:        false,
 		},
@@ -1690,26 +1766,26 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 		"State: Admin, Flag: Admin, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateRoleAdmin,
 			remove:        false,
 		},
 		// Bob has Admin State, and he is trying to give Alice KYC Role
 		"State: Admin, Flag: kyc, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateRoleKyc,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateRoleKycBit,
+			txFlag:        as.AddressStateBitRoleKyc,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateRoleKyc,
 			remove:        false,
 		},
 		// Bob has Admin State, and he is trying to remove from Alice the KYC Role
 		"State: Admin, Flag: kyc, Remove, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateRoleKyc,
-			existingState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleKyc,
+			existingState: as.AddressStateRoleAdmin,
 			expectedState: 0,
 			remove:        true,
 		},
@@ -1717,53 +1793,53 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 		"State: Admin, Flag: KYC Verified, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateKycVerified,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateKycVerifiedBit,
+			txFlag:        as.AddressStateBitKycVerified,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateKycVerified,
 			remove:        false,
 		},
 		// Bob has Admin State, and he is trying to give Alice the KYC Expired State
 		"State: Admin, Flag: KYC Expired, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateKycExpired,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateKycExpiredBit,
+			txFlag:        as.AddressStateBitKycExpired,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateKycExpired,
 			remove:        false,
 		},
 		// Bob has Admin State, and he is trying to give Alice the Consortium State
 		"State: Admin, Flag: Consortium, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateConsortium,
-			existingState: txs.AddressStateRoleAdminBit,
-			expectedState: txs.AddressStateConsortiumBit,
+			txFlag:        as.AddressStateBitConsortium,
+			existingState: as.AddressStateRoleAdmin,
+			expectedState: as.AddressStateConsortium,
 			remove:        false,
 		},
 		// Bob has KYC State, and he is trying to give Alice KYC Expired State
 		"State: KYC, Flag: KYC Expired, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateKycExpired,
-			existingState: txs.AddressStateRoleKycBit,
-			expectedState: txs.AddressStateKycExpiredBit,
+			txFlag:        as.AddressStateBitKycExpired,
+			existingState: as.AddressStateRoleKyc,
+			expectedState: as.AddressStateKycExpired,
 			remove:        false,
 		},
 		// Bob has KYC State, and he is trying to give Alice KYC Expired State
 		"State: KYC, Flag: KYC Verified, Add, Different Address": {
 			stateAddress:  bob,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateKycVerified,
-			existingState: txs.AddressStateRoleKycBit,
-			expectedState: txs.AddressStateKycVerifiedBit,
+			txFlag:        as.AddressStateBitKycVerified,
+			existingState: as.AddressStateRoleKyc,
+			expectedState: as.AddressStateKycVerified,
 			remove:        false,
 		},
 		// Some Address has Admin State, and he is trying to give Alice Admin Role
 		"Wrong address": {
 			stateAddress:  ids.GenerateTestShortID(),
 			targetAddress: alice,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleAdmin,
 			expectedErr:   errInvalidRoles,
 			remove:        false,
 		},
@@ -1771,8 +1847,8 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 		"Empty State Address": {
 			stateAddress:  ids.ShortEmpty,
 			targetAddress: alice,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleAdmin,
 			expectedErr:   errInvalidRoles,
 			remove:        false,
 		},
@@ -1780,8 +1856,8 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 		"Empty Target Address": {
 			stateAddress:  bob,
 			targetAddress: ids.ShortEmpty,
-			txFlag:        txs.AddressStateRoleAdmin,
-			existingState: txs.AddressStateRoleAdminBit,
+			txFlag:        as.AddressStateBitRoleAdmin,
+			existingState: as.AddressStateRoleAdmin,
 			expectedErr:   txs.ErrEmptyAddress,
 			remove:        false,
 		},
@@ -1834,6 +1910,90 @@ func TestAddAddressStateTxExecutor(t *testing.T) {
 	}
 }
 
+func TestCaminoStandardTxExecutorAddressStateBatchTx(t *testing.T) {
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+	env := newCaminoEnvironment( /*postBanff*/ true, false, caminoGenesisConf)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownCaminoEnvironment(env))
+	}()
+
+	signer := caminoPreFundedKeys[0]
+	target := caminoPreFundedKeys[1].Address()
+
+	tests := map[string]struct {
+		signerState   as.AddressState
+		targetState   as.AddressState
+		ops           []txs.AddressStateOp
+		expectedState as.AddressState
+		expectedErr   error
+	}{
+		"Admin applies multiple states": {
+			signerState: as.AddressStateRoleAdmin,
+			targetState: as.AddressStateKycExpired,
+			ops: []txs.AddressStateOp{
+				{State: as.AddressStateBitKycExpired, Remove: true},
+				{State: as.AddressStateBitKycVerified},
+				{State: as.AddressStateBitConsortium},
+			},
+			expectedState: as.AddressStateKycVerified | as.AddressStateConsortium,
+		},
+		"KYC role applies kyc states": {
+			signerState: as.AddressStateRoleKyc,
+			ops: []txs.AddressStateOp{
+				{State: as.AddressStateBitKycVerified},
+				{State: as.AddressStateBitKycExpired},
+			},
+			expectedState: as.AddressStateKycBits,
+		},
+		"KYC role can't apply admin state": {
+			signerState: as.AddressStateRoleKyc,
+			ops: []txs.AddressStateOp{
+				{State: as.AddressStateBitKycVerified},
+				{State: as.AddressStateBitRoleAdmin},
+			},
+			expectedErr: errInvalidRoles,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx, err := env.txBuilder.NewAddressStateBatchTx(
+				target,
+				tt.ops,
+				[]*crypto.PrivateKeySECP256K1R{signer},
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+
+			onAcceptState, err := state.NewCaminoDiff(lastAcceptedID, env)
+			require.NoError(t, err)
+			onAcceptState.SetAddressStates(signer.Address(), tt.signerState)
+			onAcceptState.SetAddressStates(target, tt.targetState)
+
+			executor := CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   onAcceptState,
+					Tx:      tx,
+				},
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			targetState, err := onAcceptState.GetAddressStates(target)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedState, targetState)
+		})
+	}
+}
+
 func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 	currentTime := time.Now()
 
@@ -1867,6 +2027,15 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 	inputSigners := []*crypto.PrivateKeySECP256K1R{testKey.(*crypto.PrivateKeySECP256K1R)}
 	existingTxID := ids.GenerateTestID()
 
+	restrictedDepositOfferID := func(env caminoEnvironment) ids.ID {
+		genesisOffers, err := env.state.GetAllDepositOffers()
+		require.NoError(t, err)
+		offer := *genesisOffers[0]
+		offer.OwnerAddress = dummyKey.PublicKey().Address()
+		env.state.SetDepositOffer(&offer)
+		return offer.ID
+	}
+
 	tests := map[string]struct {
 		caminoGenesisConf api.Camino
 		utxos             []*avax.UTXO
@@ -2086,6 +2255,42 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			expectedErr: errDepositOfferInactive,
 		},
+		"Deposit offer is paused": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers: []*deposit.Offer{{
+					InterestRateNominator:   0,
+					Start:                   uint64(currentTime.Add(-60 * time.Hour).Unix()),
+					End:                     uint64(currentTime.Add(+60 * time.Hour).Unix()),
+					MinAmount:               1,
+					MinDuration:             60,
+					MaxDuration:             60,
+					UnlockPeriodDuration:    60,
+					NoRewardsPeriodDuration: 0,
+					Flags:                   deposit.OfferFlagPaused,
+				}},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				return genesisOffers[0].ID
+			},
+			expectedErr: errDepositOfferPaused,
+		},
 		"Deposit's duration is too small": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
@@ -2191,6 +2396,72 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			expectedErr: errDepositToSmall,
 		},
+		"Deposit exceeds offer total max amount": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers: []*deposit.Offer{{
+					InterestRateNominator:   0,
+					Start:                   uint64(currentTime.Add(-60 * time.Hour).Unix()),
+					End:                     uint64(currentTime.Add(+60 * time.Hour).Unix()),
+					MinAmount:               1,
+					TotalMaxAmount:          defaultCaminoValidatorWeight * 2,
+					DepositedAmount:         defaultCaminoValidatorWeight + 1,
+					MinDuration:             60,
+					MaxDuration:             60,
+					UnlockPeriodDuration:    60,
+					NoRewardsPeriodDuration: 0,
+				}},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				return genesisOffers[0].ID
+			},
+			expectedErr: errDepositToBig,
+		},
+		"Deposit exceeds offer max amount": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				offer := *genesisOffers[0]
+				offer.MaxAmount = defaultCaminoValidatorWeight - 1
+				env.state.SetDepositOffer(&offer)
+				return offer.ID
+			},
+			expectedErr: errDepositToBig,
+		},
 		"No fee burning": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
@@ -2330,7 +2601,7 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			expectedErr: errSupplyOverflow,
 		},
-		"Happy path deposit unlocked": {
+		"Restricted offer, no offer owner credential": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
 				LockModeBondDeposit: true,
@@ -2349,21 +2620,39 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
 				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
 			},
-			depositOfferID: func(env caminoEnvironment) ids.ID {
-				genesisOffers, err := env.state.GetAllDepositOffers()
-				require.NoError(t, err)
-				return genesisOffers[0].ID
+			depositOfferID: restrictedDepositOfferID,
+			expectedErr:    errOfferOwnerCredentialMismatch,
+		},
+		"Restricted offer, wrong offer owner credential": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
 			},
-			expectedErr: nil,
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: restrictedDepositOfferID,
+			expectedErr:    errOfferOwnerCredentialMismatch,
 		},
-		"Happy path deposit unlocked, fee change to new address": {
+		"Address state gated offer, signer doesn't have required address state": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
 				LockModeBondDeposit: true,
 				DepositOffers:       []*deposit.Offer{testDepositOffer},
 			},
 			utxos: []*avax.UTXO{
-				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance+10, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
 			},
 			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
 				return []*avax.TransferableInput{
@@ -2372,64 +2661,90 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
 			outs: []*avax.TransferableOutput{
-				generateTestOut(avaxAssetID, 10, dummyOutputOwners, ids.Empty, ids.Empty),
 				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
 				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
 			},
 			depositOfferID: func(env caminoEnvironment) ids.ID {
 				genesisOffers, err := env.state.GetAllDepositOffers()
 				require.NoError(t, err)
-				return genesisOffers[0].ID
+				offer := *genesisOffers[0]
+				offer.RequiredAddressState = as.AddressStateKycVerified
+				env.state.SetDepositOffer(&offer)
+				env.state.SetAddressStates(inputSigners[0].Address(), as.AddressStateKycExpired)
+				return offer.ID
 			},
-			expectedErr: nil,
+			expectedErr: errDepositorAddressState,
 		},
-		"Happy path deposit bonded": {
+		"Happy path address state gated offer": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
 				LockModeBondDeposit: true,
 				DepositOffers:       []*deposit.Offer{testDepositOffer},
 			},
 			utxos: []*avax.UTXO{
-				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultTxFee, outputOwners, ids.Empty, ids.Empty),
-				generateTestUTXO(ids.ID{2}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, existingTxID),
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
 			},
 			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
 				return []*avax.TransferableInput{
 					generateTestInFromUTXO(utxos[0], sigIndices),
-					generateTestInFromUTXO(utxos[1], sigIndices),
 				}
 			},
-			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, inputSigners},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
 			outs: []*avax.TransferableOutput{
-				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, existingTxID),
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
 			},
 			depositOfferID: func(env caminoEnvironment) ids.ID {
 				genesisOffers, err := env.state.GetAllDepositOffers()
 				require.NoError(t, err)
-				return genesisOffers[0].ID
+				offer := *genesisOffers[0]
+				offer.RequiredAddressState = as.AddressStateKycVerified
+				env.state.SetDepositOffer(&offer)
+				env.state.SetAddressStates(inputSigners[0].Address(), as.AddressStateKycVerified|as.AddressStateConsortium)
+				return offer.ID
 			},
 			expectedErr: nil,
 		},
-		"Happy path deposit bonded and unlocked": {
+		"Happy path restricted offer": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
 				LockModeBondDeposit: true,
 				DepositOffers:       []*deposit.Offer{testDepositOffer},
 			},
 			utxos: []*avax.UTXO{
-				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultTxFee+defaultCaminoValidatorWeight/2, outputOwners, ids.Empty, ids.Empty),
-				generateTestUTXO(ids.ID{2}, avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, ids.Empty, existingTxID),
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
 			},
 			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
 				return []*avax.TransferableInput{
 					generateTestInFromUTXO(utxos[0], sigIndices),
-					generateTestInFromUTXO(utxos[1], sigIndices),
 				}
 			},
-			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, inputSigners},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, {dummyKey.(*crypto.PrivateKeySECP256K1R)}},
 			outs: []*avax.TransferableOutput{
-				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, locked.ThisTxID, ids.Empty),
-				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, locked.ThisTxID, existingTxID),
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: restrictedDepositOfferID,
+			expectedErr:    nil,
+		},
+		"Happy path deposit unlocked": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
 			},
 			depositOfferID: func(env caminoEnvironment) ids.ID {
 				genesisOffers, err := env.state.GetAllDepositOffers()
@@ -2438,14 +2753,14 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
-		"Happy path, deposited amount transferred to another owner": {
+		"Happy path deposit unlocked, fee change to new address": {
 			caminoGenesisConf: api.Camino{
 				VerifyNodeSignature: true,
 				LockModeBondDeposit: true,
 				DepositOffers:       []*deposit.Offer{testDepositOffer},
 			},
 			utxos: []*avax.UTXO{
-				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance+10, outputOwners, ids.Empty, ids.Empty),
 			},
 			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
 				return []*avax.TransferableInput{
@@ -2454,8 +2769,9 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
 			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, 10, dummyOutputOwners, ids.Empty, ids.Empty),
 				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
-				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, dummyOutputOwners, locked.ThisTxID, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, ids.Empty),
 			},
 			depositOfferID: func(env caminoEnvironment) ids.ID {
 				genesisOffers, err := env.state.GetAllDepositOffers()
@@ -2464,10 +2780,91 @@ func TestCaminoStandardTxExecutorDepositTx(t *testing.T) {
 			},
 			expectedErr: nil,
 		},
-	}
-	for name, tt := range tests {
-		t.Run(name, func(t *testing.T) {
-			env := newCaminoEnvironment( /*postBanff*/ true, false, tt.caminoGenesisConf)
+		"Happy path deposit bonded": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{2}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, existingTxID),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+					generateTestInFromUTXO(utxos[1], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, outputOwners, locked.ThisTxID, existingTxID),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				return genesisOffers[0].ID
+			},
+			expectedErr: nil,
+		},
+		"Happy path deposit bonded and unlocked": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultTxFee+defaultCaminoValidatorWeight/2, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{2}, avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, ids.Empty, existingTxID),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+					generateTestInFromUTXO(utxos[1], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners, inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, locked.ThisTxID, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight/2, outputOwners, locked.ThisTxID, existingTxID),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				return genesisOffers[0].ID
+			},
+			expectedErr: nil,
+		},
+		"Happy path, deposited amount transferred to another owner": {
+			caminoGenesisConf: api.Camino{
+				VerifyNodeSignature: true,
+				LockModeBondDeposit: true,
+				DepositOffers:       []*deposit.Offer{testDepositOffer},
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, avaxAssetID, defaultCaminoBalance, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateIns: func(utxos []*avax.UTXO) []*avax.TransferableInput {
+				return []*avax.TransferableInput{
+					generateTestInFromUTXO(utxos[0], sigIndices),
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{inputSigners},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(avaxAssetID, defaultCaminoBalance-defaultCaminoValidatorWeight-defaultTxFee, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(avaxAssetID, defaultCaminoValidatorWeight, dummyOutputOwners, locked.ThisTxID, ids.Empty),
+			},
+			depositOfferID: func(env caminoEnvironment) ids.ID {
+				genesisOffers, err := env.state.GetAllDepositOffers()
+				require.NoError(t, err)
+				return genesisOffers[0].ID
+			},
+			expectedErr: nil,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			env := newCaminoEnvironment( /*postBanff*/ true, false, tt.caminoGenesisConf)
 			env.ctx.Lock.Lock()
 			defer func() {
 				if err := shutdownCaminoEnvironment(env); err != nil {
@@ -3195,6 +3592,9 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 				// verify unlock deposit flowcheck
 				expectGetUTXOsFromInputs(s, utx.Ins, utxos)
 				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: chain time
+				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
 				// state update: ins/outs/utxos
 				expectConsumeUTXOs(s, utx.Ins)
 				expectProduceUTXOs(s, utx.Outs, txID, 0)
@@ -3225,6 +3625,9 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 				s.EXPECT().GetTimestamp().Return(deposit1Expired)
 				s.EXPECT().GetDeposit(deposit1WithRewardTxID1).Return(deposit1WithReward, nil)
 				s.EXPECT().GetDepositOffer(deposit1WithReward.DepositOfferID).Return(depositOfferWithReward, nil)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: chain time
+				s.EXPECT().GetTimestamp().Return(deposit1Expired)
 				// state update: deposit1
 				s.EXPECT().GetDeposit(deposit1WithRewardTxID1).Return(deposit1WithReward, nil)
 				s.EXPECT().GetDepositOffer(deposit1WithReward.DepositOfferID).Return(depositOfferWithReward, nil)
@@ -3270,6 +3673,9 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(depositOffer, nil)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: chain time
+				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
 				// state update: deposit1
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				unlockableAmount := deposit1.UnlockableAmount(depositOffer, uint64(deposit1HalfUnlockTime.Unix()))
@@ -3318,6 +3724,9 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(depositOffer, nil)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: chain time
+				s.EXPECT().GetTimestamp().Return(deposit1HalfUnlockTime)
 				// state update: deposit1
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				unlockableAmount := deposit1.UnlockableAmount(depositOffer, uint64(deposit1HalfUnlockTime.Unix()))
@@ -3369,6 +3778,9 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 				s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(depositOffer, nil)
 				s.EXPECT().GetDeposit(depositTxID2).Return(deposit2, nil)
 				s.EXPECT().GetDepositOffer(deposit2.DepositOfferID).Return(depositOffer, nil)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: chain time
+				s.EXPECT().GetTimestamp().Return(deposit1Expired)
 				// state update: deposit1 (expired)
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(depositOffer, nil)
@@ -3433,32 +3845,45 @@ func TestCaminoStandardTxExecutorUnlockDepositTx(t *testing.T) {
 	}
 }
 
-func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
+func TestCaminoStandardTxExecutorRenewDepositTx(t *testing.T) {
 	ctx, _ := defaultCtx(nil)
-
-	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
-	depositRewardOwnerKey, _, depositRewardOwner := generateKeyAndOwner(t)
-	claimableOwnerKey1, _, claimableOwner1 := generateKeyAndOwner(t)
-	claimableOwnerKey2, _, claimableOwner2 := generateKeyAndOwner(t)
-
-	feeUTXO := generateTestUTXO(ids.GenerateTestID(), ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
-
-	depositOfferID := ids.GenerateTestID()
-	depositTxID1 := ids.GenerateTestID()
-	depositTxID2 := ids.GenerateTestID()
-	claimableOwnerID1 := ids.GenerateTestID()
-	claimableOwnerID2 := ids.GenerateTestID()
-	timestamp := time.Now()
-
 	caminoGenesisConf := api.Camino{
 		VerifyNodeSignature: true,
 		LockModeBondDeposit: true,
 	}
 
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	owner1Key, owner1Addr, owner1 := generateKeyAndOwner(t)
+	owner1ID, err := txs.GetOwnerID(owner1)
+	require.NoError(t, err)
+	depositTxID := ids.GenerateTestID()
+	depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &owner1}}
+
+	oldDepositOffer := &deposit.Offer{
+		ID:                    ids.GenerateTestID(),
+		MinAmount:             1,
+		MinDuration:           60,
+		MaxDuration:           100,
+		UnlockPeriodDuration:  50,
+		InterestRateNominator: 365 * 24 * 60 * 60 * 1_000_000 / 10, // 10%
+	}
+	oldDeposit := &deposit.Deposit{
+		Duration:            oldDepositOffer.MinDuration,
+		Amount:              10000 * units.Avax,
+		ClaimedRewardAmount: 1,
+		DepositOfferID:      oldDepositOffer.ID,
+	}
+	newDepositOffer := &deposit.Offer{
+		ID:          ids.GenerateTestID(),
+		End:         uint64(oldDeposit.EndTime().Add(time.Hour).Unix()),
+		MinAmount:   1,
+		MinDuration: 60,
+		MaxDuration: 100,
+	}
+	oldDepositExpired := oldDeposit.EndTime()
+
 	baseState := func(c *gomock.Controller) *state.MockState {
 		s := state.NewMockState(c)
-		// utxo handler, used in fx VerifyMultisigTransfer method for baseTx ins verification
-		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
 		// shutdown
 		s.EXPECT().SetHeight(uint64(math.MaxUint64))
 		s.EXPECT().Commit()
@@ -3466,131 +3891,1466 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 		return s
 	}
 
-	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{
-		NetworkID:    ctx.NetworkID,
-		BlockchainID: ctx.ChainID,
-		Ins:          []*avax.TransferableInput{generateTestInFromUTXO(feeUTXO, []uint32{0})},
-	}}
-
 	tests := map[string]struct {
-		state       func(*gomock.Controller, *txs.ClaimTx, ids.ID, []*state.Claimable) *state.MockDiff
-		utx         func([]*state.Claimable) *txs.ClaimTx
+		baseState   func(c *gomock.Controller) *state.MockState
+		state       func(*gomock.Controller, *txs.RenewDepositTx, ids.ID, []*avax.UTXO) *state.MockDiff
+		utx         func([]*avax.UTXO) *txs.RenewDepositTx
 		signers     [][]*crypto.PrivateKeySECP256K1R
-		claimables  []*state.Claimable
+		utxos       []*avax.UTXO
 		expectedErr error
 	}{
-		"Deposit not found": {
-			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+		"Deposit isn't expired": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RenewDepositTx, txID ids.ID, utxos []*avax.UTXO) *state.MockDiff {
 				s := state.NewMockDiff(c)
-				// common checks and fee
 				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
-				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
-				s.EXPECT().GetTimestamp().Return(timestamp)
-				// deposit
-				s.EXPECT().GetTx(depositTxID1).Return(nil, status.Unknown, database.ErrNotFound)
+				s.EXPECT().GetDeposit(depositTxID).Return(oldDeposit, nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired.Add(-time.Second))
 				return s
 			},
-			utx: func([]*state.Claimable) *txs.ClaimTx {
-				return &txs.ClaimTx{
-					BaseTx:       baseTx,
-					DepositTxIDs: []ids.ID{depositTxID1},
-					ClaimTo:      &secp256k1fx.OutputOwners{},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, oldDeposit.Amount, owner1, depositTxID, ids.Empty),
+			},
+			utx: func(utxos []*avax.UTXO) *txs.RenewDepositTx {
+				return &txs.RenewDepositTx{
+					BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+						Ins: generateInsFromUTXOs(utxos),
+						Outs: []*avax.TransferableOutput{
+							generateTestOut(ctx.AVAXAssetID, oldDeposit.Amount, owner1, locked.ThisTxID, ids.Empty),
+						},
+					}},
+					DepositTxID:     depositTxID,
+					DepositOfferID:  newDepositOffer.ID,
+					DepositDuration: newDepositOffer.MinDuration,
+					RewardsOwner:    &owner1,
 				}
 			},
-			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
-			expectedErr: errDepositNotFound,
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}},
+			expectedErr: errDepositNotExpired,
 		},
-		"Bad deposit credential": {
-			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+		"Not all deposited amount is renewed": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RenewDepositTx, txID ids.ID, utxos []*avax.UTXO) *state.MockDiff {
 				s := state.NewMockDiff(c)
-				// common checks and fee
 				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
-				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
-				s.EXPECT().GetTimestamp().Return(timestamp)
-				// deposit
-				s.EXPECT().GetTx(depositTxID1).Return(
-					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &depositRewardOwner}},
-					status.Committed,
-					nil,
-				)
-				expectVerifyMultisigPermission(s, depositRewardOwner.Addrs, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(oldDeposit, nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired)
+				s.EXPECT().GetDepositOffer(oldDepositOffer.ID).Return(oldDepositOffer, nil)
 				return s
 			},
-			utx: func([]*state.Claimable) *txs.ClaimTx {
-				return &txs.ClaimTx{
-					BaseTx:       baseTx,
-					DepositTxIDs: []ids.ID{depositTxID1},
-					ClaimTo:      &secp256k1fx.OutputOwners{},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, oldDeposit.Amount-1, owner1, depositTxID, ids.Empty),
+			},
+			utx: func(utxos []*avax.UTXO) *txs.RenewDepositTx {
+				return &txs.RenewDepositTx{
+					BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+						Ins: generateInsFromUTXOs(utxos),
+						Outs: []*avax.TransferableOutput{
+							generateTestOut(ctx.AVAXAssetID, oldDeposit.Amount-1, owner1, locked.ThisTxID, ids.Empty),
+						},
+					}},
+					DepositTxID:     depositTxID,
+					DepositOfferID:  newDepositOffer.ID,
+					DepositDuration: newDepositOffer.MinDuration,
+					RewardsOwner:    &owner1,
 				}
 			},
-			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
-			expectedErr: errDepositCredentialMissmatch,
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}},
+			expectedErr: errRenewedAmountMismatch,
 		},
-		"Bad claimable credential": {
-			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+		"New deposit is less than renewed amount": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RenewDepositTx, txID ids.ID, utxos []*avax.UTXO) *state.MockDiff {
 				s := state.NewMockDiff(c)
-				// common checks and fee
 				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
-				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
-				s.EXPECT().GetTimestamp().Return(timestamp)
-				// claimable
-				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
-				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(oldDeposit, nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired)
+				s.EXPECT().GetDepositOffer(oldDepositOffer.ID).Return(oldDepositOffer, nil)
 				return s
 			},
-			utx: func([]*state.Claimable) *txs.ClaimTx {
-				return &txs.ClaimTx{
-					BaseTx:            baseTx,
-					ClaimTo:           &secp256k1fx.OutputOwners{},
-					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1},
-					ClaimedAmounts:    []uint64{1},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, oldDeposit.Amount, owner1, depositTxID, ids.Empty),
+			},
+			utx: func(utxos []*avax.UTXO) *txs.RenewDepositTx {
+				return &txs.RenewDepositTx{
+					BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+						Ins: generateInsFromUTXOs(utxos),
+						Outs: []*avax.TransferableOutput{
+							generateTestOut(ctx.AVAXAssetID, 1, owner1, ids.Empty, ids.Empty),
+							generateTestOut(ctx.AVAXAssetID, oldDeposit.Amount-1, owner1, locked.ThisTxID, ids.Empty),
+						},
+					}},
+					DepositTxID:     depositTxID,
+					DepositOfferID:  newDepositOffer.ID,
+					DepositDuration: newDepositOffer.MinDuration,
+					RewardsOwner:    &owner1,
 				}
 			},
-			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
-			claimables:  []*state.Claimable{{Owner: &claimableOwner1}},
-			expectedErr: errClaimableCredentialMissmatch,
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}},
+			expectedErr: errRenewedDepositToSmall,
 		},
-		"Claimed more than available": {
-			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+		"OK": {
+			baseState: func(c *gomock.Controller) *state.MockState {
+				s := baseState(c)
+				// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+				s.EXPECT().GetMultisigAlias(owner1Addr).Return(nil, database.ErrNotFound)
+				s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+				return s
+			},
+			state: func(c *gomock.Controller, utx *txs.RenewDepositTx, txID ids.ID, utxos []*avax.UTXO) *state.MockDiff {
 				s := state.NewMockDiff(c)
-				// common checks and fee
+				// common checks
 				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
-				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
-				s.EXPECT().GetTimestamp().Return(timestamp)
-
-				// claimable 1
-				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
-				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
-				s.EXPECT().SetClaimable(claimableOwnerID1, &state.Claimable{
-					Owner:           claimables[0].Owner,
-					ValidatorReward: claimables[0].ValidatorReward - utx.ClaimedAmounts[0],
+				// verify renewed deposit
+				s.EXPECT().GetDeposit(depositTxID).Return(oldDeposit, nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired)
+				s.EXPECT().GetDepositOffer(oldDepositOffer.ID).Return(oldDepositOffer, nil)
+				// verify new deposit
+				s.EXPECT().GetDepositOffer(newDepositOffer.ID).Return(newDepositOffer, nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired)
+				expectNotRetiredAliases(s, owner1.Addrs...)
+				// verify lock flowcheck
+				expectVerifyLock(s, utx.Ins, utxos)
+				expectNotRetiredOuts(s, utx.Outs)
+				// state update: renewed deposit
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				s.EXPECT().GetClaimable(owner1ID).Return(&state.Claimable{Owner: &owner1}, nil)
+				s.EXPECT().SetClaimable(owner1ID, &state.Claimable{
+					Owner:         &owner1,
+					DepositReward: oldDeposit.TotalReward(oldDepositOffer) - oldDeposit.ClaimedRewardAmount,
 				})
-				claimableUTXO1 := &avax.UTXO{
-					UTXOID: avax.UTXOID{
-						TxID:        txID,
-						OutputIndex: uint32(len(utx.Outs)),
-					},
-					Asset: avax.Asset{ID: ctx.AVAXAssetID},
-					Out: &secp256k1fx.TransferOutput{
-						Amt:          utx.ClaimedAmounts[0],
-						OutputOwners: *claimables[0].Owner,
+				s.EXPECT().RemoveDeposit(depositTxID, oldDeposit)
+				// state update: new deposit
+				s.EXPECT().GetCurrentSupply(constants.PrimaryNetworkID).Return(uint64(1000), nil)
+				s.EXPECT().GetTimestamp().Return(oldDepositExpired)
+				s.EXPECT().SetCurrentSupply(constants.PrimaryNetworkID, uint64(1000))
+				s.EXPECT().AddDeposit(txID, &deposit.Deposit{
+					DepositOfferID: newDepositOffer.ID,
+					Duration:       newDepositOffer.MinDuration,
+					Amount:         oldDeposit.Amount,
+					Start:          uint64(oldDepositExpired.Unix()),
+				})
+				// state update: ins/outs/utxos
+				expectConsumeUTXOs(s, utx.Ins)
+				s.EXPECT().AddUTXO(&avax.UTXO{
+					UTXOID: avax.UTXOID{TxID: txID},
+					Asset:  utx.Outs[0].Asset,
+					Out: &locked.Out{
+						IDs:             locked.IDs{DepositTxID: txID},
+						TransferableOut: utx.Outs[0].Out.(*locked.Out).TransferableOut,
 					},
-				}
-				s.EXPECT().AddUTXO(claimableUTXO1)
-				s.EXPECT().AddRewardUTXO(txID, claimableUTXO1)
-
-				// claimable 2
-				s.EXPECT().GetClaimable(claimableOwnerID2).Return(claimables[1], nil)
-				expectVerifyMultisigPermission(s, claimableOwner2.Addrs, nil)
+				})
 				return s
 			},
-			utx: func(claimables []*state.Claimable) *txs.ClaimTx {
-				return &txs.ClaimTx{
-					BaseTx:            baseTx,
-					ClaimTo:           &secp256k1fx.OutputOwners{},
-					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1, claimableOwnerID2},
-					ClaimedAmounts:    []uint64{claimables[0].ValidatorReward - 1, claimables[1].ValidatorReward + 1},
-				}
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, oldDeposit.Amount, owner1, depositTxID, ids.Empty),
+				generateTestUTXO(ids.ID{2}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty),
+			},
+			utx: func(utxos []*avax.UTXO) *txs.RenewDepositTx {
+				return &txs.RenewDepositTx{
+					BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+						Ins: generateInsFromUTXOs(utxos),
+						Outs: []*avax.TransferableOutput{
+							generateTestOut(ctx.AVAXAssetID, oldDeposit.Amount, owner1, locked.ThisTxID, ids.Empty),
+						},
+					}},
+					DepositTxID:     depositTxID,
+					DepositOfferID:  newDepositOffer.ID,
+					DepositDuration: newDepositOffer.MinDuration,
+					RewardsOwner:    &owner1,
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			utx := tt.utx(tt.utxos)
+			utx.BlockchainID = env.ctx.ChainID
+			utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, utx, tx.ID(), tt.utxos),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorDepositRewardsOwnerTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	owner1Key, _, owner1 := generateKeyAndOwner(t)
+	owner2Key, _, owner2 := generateKeyAndOwner(t)
+	depositTxID := ids.GenerateTestID()
+	depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &owner1}}
+	feeUTXO := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+
+	testDeposit := &deposit.Deposit{
+		DepositOfferID:      ids.GenerateTestID(),
+		UnlockedAmount:      1,
+		ClaimedRewardAmount: 2,
+		Start:               3,
+		Duration:            4,
+		Amount:              5,
+	}
+	depositWithChangedOwner := *testDeposit
+	depositWithChangedOwner.RewardOwner = &owner2
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	utx := func(rewardsOwner *secp256k1fx.OutputOwners) *txs.DepositRewardsOwnerTx {
+		return &txs.DepositRewardsOwnerTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins: generateInsFromUTXOs([]*avax.UTXO{feeUTXO}),
+			}},
+			DepositTxID:  depositTxID,
+			RewardsOwner: rewardsOwner,
+		}
+	}
+
+	tests := map[string]struct {
+		baseState   func(c *gomock.Controller) *state.MockState
+		state       func(*gomock.Controller, *txs.DepositRewardsOwnerTx, ids.ID) *state.MockDiff
+		utx         *txs.DepositRewardsOwnerTx
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		"Deposit not found": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.DepositRewardsOwnerTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(nil, database.ErrNotFound)
+				return s
+			},
+			utx:         utx(&owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: errDepositNotFound,
+		},
+		"Not signed by deposit tx rewards owner": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.DepositRewardsOwnerTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				return s
+			},
+			utx:         utx(&owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner2Key}},
+			expectedErr: errDepositCredentialMissmatch,
+		},
+		"Signed by previous rewards owner": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.DepositRewardsOwnerTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(&depositWithChangedOwner, nil)
+				expectVerifyMultisigPermission(s, owner2.Addrs, nil)
+				return s
+			},
+			utx:         utx(&owner1),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: errDepositCredentialMissmatch,
+		},
+		"New rewards owner is retired alias": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.DepositRewardsOwnerTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				s.EXPECT().GetShortIDLink(owner2.Addrs[0], state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortID{1}, nil)
+				return s
+			},
+			utx:         utx(&owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: utxo.ErrAliasRetired,
+		},
+		"OK": {
+			baseState: func(c *gomock.Controller) *state.MockState {
+				s := baseState(c)
+				// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+				s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+				return s
+			},
+			state: func(c *gomock.Controller, utx *txs.DepositRewardsOwnerTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, owner2.Addrs...)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				expectNotRetiredOuts(s, utx.Outs)
+				s.EXPECT().ModifyDeposit(depositTxID, &depositWithChangedOwner)
+				expectConsumeUTXOs(s, utx.Ins)
+				return s
+			},
+			utx:     utx(&owner2),
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			tt.utx.BlockchainID = env.ctx.ChainID
+			tt.utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(tt.utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, tt.utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorMultisigAliasTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	owner1Key, owner1Addr, owner1 := generateKeyAndOwner(t)
+	owner2Key, owner2Addr, owner2 := generateKeyAndOwner(t)
+	aliasID := ids.ShortID{1}
+	feeUTXO := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+
+	alias := &multisig.Alias{
+		ID:     aliasID,
+		Memo:   []byte("memo"),
+		Owners: &owner1,
+	}
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	baseStateWithFeeOwner := func(c *gomock.Controller) *state.MockState {
+		s := baseState(c)
+		// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+		return s
+	}
+
+	utx := func(aliasID ids.ShortID, owners *secp256k1fx.OutputOwners) *txs.MultisigAliasTx {
+		return &txs.MultisigAliasTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins: generateInsFromUTXOs([]*avax.UTXO{feeUTXO}),
+			}},
+			MultisigAlias: multisig.Alias{
+				ID:     aliasID,
+				Memo:   []byte("new memo"),
+				Owners: owners,
+			},
+			ChangeAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+		}
+	}
+
+	tests := map[string]struct {
+		baseState   func(c *gomock.Controller) *state.MockState
+		state       func(*gomock.Controller, *txs.MultisigAliasTx, ids.ID) *state.MockDiff
+		utx         *txs.MultisigAliasTx
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		maxMemoSize int
+		expectedErr error
+	}{
+		"Memo is larger than max memo size": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				return state.NewMockDiff(c)
+			},
+			utx:         utx(aliasID, &owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			maxMemoSize: 2,
+			expectedErr: multisig.ErrMemoTooLarge,
+		},
+		"Updated alias not found": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(aliasID).Return(nil, database.ErrNotFound)
+				return s
+			},
+			utx:         utx(aliasID, &owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: secp256k1fx.ErrAliasNotFound,
+		},
+		"Updated alias is retired": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(aliasID).Return(alias, nil)
+				s.EXPECT().GetShortIDLink(aliasID, state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortID{2}, nil)
+				return s
+			},
+			utx:         utx(aliasID, &owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: utxo.ErrAliasRetired,
+		},
+		"New owners contain retired alias": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(multisig.ComputeAliasID(txID)).Return(nil, database.ErrNotFound)
+				s.EXPECT().GetShortIDLink(aliasID, state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortID{2}, nil)
+				return s
+			},
+			utx: utx(ids.ShortEmpty, &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{aliasID},
+			}),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}},
+			expectedErr: utxo.ErrAliasRetired,
+		},
+		"Not signed by current alias owners": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(aliasID).Return(alias, nil)
+				expectNotRetiredAliases(s, aliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				return s
+			},
+			utx:         utx(aliasID, &owner2),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner2Key}},
+			expectedErr: errAliasCredentialMismatch,
+		},
+		"Updated alias references itself": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(aliasID).Return(alias, nil)
+				expectNotRetiredAliases(s, aliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, aliasID)
+				return s
+			},
+			utx: utx(aliasID, &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{aliasID},
+			}),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: secp256k1fx.ErrCyclicAliases,
+		},
+		"Created alias already exists": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(multisig.ComputeAliasID(txID)).Return(alias, nil)
+				return s
+			},
+			utx:         utx(ids.ShortEmpty, &owner1),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}},
+			expectedErr: errAliasAlreadyExists,
+		},
+		"OK: update": {
+			baseState: baseStateWithFeeOwner,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(aliasID).Return(alias, nil)
+				expectNotRetiredAliases(s, aliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, owner2Addr)
+				s.EXPECT().GetMultisigAlias(owner2Addr).Return(nil, database.ErrNotFound)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				expectConsumeUTXOs(s, utx.Ins)
+				s.EXPECT().SetMultisigAlias(&multisig.Alias{
+					ID:     aliasID,
+					Memo:   utx.MultisigAlias.Memo,
+					Owners: &owner2,
+				})
+				return s
+			},
+			utx:     utx(aliasID, &owner2),
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+		},
+		"OK: create": {
+			baseState: baseStateWithFeeOwner,
+			state: func(c *gomock.Controller, utx *txs.MultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				newAliasID := multisig.ComputeAliasID(txID)
+				s.EXPECT().GetMultisigAlias(newAliasID).Return(nil, database.ErrNotFound)
+				expectNotRetiredAliases(s, owner1Addr)
+				s.EXPECT().GetMultisigAlias(owner1Addr).Return(nil, database.ErrNotFound)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				expectConsumeUTXOs(s, utx.Ins)
+				s.EXPECT().SetMultisigAlias(&multisig.Alias{
+					ID:     newAliasID,
+					Memo:   utx.MultisigAlias.Memo,
+					Owners: &owner1,
+				})
+				return s
+			},
+			utx:     utx(ids.ShortEmpty, &owner1),
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+			env.config.CaminoConfig.MaxMultisigAliasMemoSize = tt.maxMemoSize
+
+			tt.utx.BlockchainID = env.ctx.ChainID
+			tt.utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(tt.utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, tt.utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorRotateMultisigAliasTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	owner1Key, _, owner1 := generateKeyAndOwner(t)
+	owner2Key, owner2Addr, owner2 := generateKeyAndOwner(t)
+	retiredAliasID := ids.ShortID{1}
+	feeUTXO := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+
+	retiredAlias := &multisig.Alias{
+		ID:     retiredAliasID,
+		Memo:   []byte("memo"),
+		Owners: &owner1,
+	}
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	baseStateWithFeeOwner := func(c *gomock.Controller) *state.MockState {
+		s := baseState(c)
+		// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+		return s
+	}
+
+	utx := &txs.RotateMultisigAliasTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: generateInsFromUTXOs([]*avax.UTXO{feeUTXO}),
+		}},
+		RetiredAliasID: retiredAliasID,
+		Successor: multisig.Alias{
+			Memo:   []byte("successor memo"),
+			Owners: &owner2,
+		},
+		RetiredAliasAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+
+	tests := map[string]struct {
+		baseState   func(c *gomock.Controller) *state.MockState
+		state       func(*gomock.Controller, *txs.RotateMultisigAliasTx, ids.ID) *state.MockDiff
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		"Retired alias not found": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RotateMultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(retiredAliasID).Return(nil, database.ErrNotFound)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: secp256k1fx.ErrAliasNotFound,
+		},
+		"Alias is already retired": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RotateMultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(retiredAliasID).Return(retiredAlias, nil)
+				s.EXPECT().GetShortIDLink(retiredAliasID, state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortID{2}, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: utxo.ErrAliasRetired,
+		},
+		"Not signed by retired alias owners": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RotateMultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(retiredAliasID).Return(retiredAlias, nil)
+				expectNotRetiredAliases(s, retiredAliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner2Key}},
+			expectedErr: errAliasCredentialMismatch,
+		},
+		"Successor alias already exists": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.RotateMultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().GetMultisigAlias(retiredAliasID).Return(retiredAlias, nil)
+				expectNotRetiredAliases(s, retiredAliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				s.EXPECT().GetMultisigAlias(multisig.ComputeAliasID(txID)).Return(&multisig.Alias{}, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+			expectedErr: errAliasAlreadyExists,
+		},
+		"OK": {
+			baseState: baseStateWithFeeOwner,
+			state: func(c *gomock.Controller, utx *txs.RotateMultisigAliasTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				successorID := multisig.ComputeAliasID(txID)
+				s.EXPECT().GetMultisigAlias(retiredAliasID).Return(retiredAlias, nil)
+				expectNotRetiredAliases(s, retiredAliasID)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				s.EXPECT().GetMultisigAlias(successorID).Return(nil, database.ErrNotFound)
+				expectNotRetiredAliases(s, owner2Addr)
+				s.EXPECT().GetMultisigAlias(owner2Addr).Return(nil, database.ErrNotFound)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				expectConsumeUTXOs(s, utx.Ins)
+				s.EXPECT().SetMultisigAlias(&multisig.Alias{
+					ID:     successorID,
+					Memo:   utx.Successor.Memo,
+					Owners: &owner2,
+				})
+				s.EXPECT().SetShortIDLink(retiredAliasID, state.ShortLinkKeyMultisigAliasSuccessor, &successorID)
+				return s
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {owner1Key}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			utx := *utx
+			utx.BlockchainID = env.ctx.ChainID
+			utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(&utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, &utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorDepositOfferTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	adminKey, adminAddr, _ := generateKeyAndOwner(t)
+	otherKey, _, _ := generateKeyAndOwner(t)
+	feeUTXO := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+	chainTime := uint64(1000)
+
+	offer := &deposit.Offer{
+		InterestRateNominator: 80_000,
+		Start:                 chainTime,
+		End:                   chainTime + 1000,
+		MinAmount:             1,
+		MinDuration:           10,
+		MaxDuration:           20,
+		Memo:                  []byte("offer memo"),
+	}
+	tiers := []deposit.InterestRateTier{{MinAmount: 10, InterestRateNominator: 90_000}}
+
+	newOffer := *offer
+	newOffer.Tiers = tiers
+	newOffer.MaxAmount = 100
+	require.NoError(t, newOffer.SetID())
+
+	currentOffer := newOffer
+	currentOffer.Version = 2
+	currentOffer.DepositedAmount = 5
+
+	lockedOffer := currentOffer
+	lockedOffer.Flags = deposit.OfferFlagLocked
+
+	pausedOffer := currentOffer
+	pausedOffer.Flags = deposit.OfferFlagPaused
+	pausedOffer.Version++
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	baseStateWithFeeOwner := func(c *gomock.Controller) *state.MockState {
+		s := baseState(c)
+		// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+		return s
+	}
+
+	expectVerifyAdmin := func(s *state.MockDiff) {
+		s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+		s.EXPECT().GetAddressStates(adminAddr).Return(as.AddressStateRoleAdmin, nil)
+		expectVerifyMultisigPermission(s, []ids.ShortID{adminAddr}, nil)
+	}
+
+	createTx := &txs.DepositOfferTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			Ins: generateInsFromUTXOs([]*avax.UTXO{feeUTXO}),
+		}},
+		DepositOffer:          offer,
+		DepositOfferExtension: deposit.OfferExtension{Tiers: tiers, MaxAmount: newOffer.MaxAmount},
+		AdminAddress:          adminAddr,
+		AdminAuth:             &secp256k1fx.Input{SigIndices: []uint32{0}},
+	}
+
+	pauseTx := *createTx
+	pauseTx.DepositOfferID = currentOffer.ID
+	pauseTx.DepositOfferVersion = currentOffer.Version
+	pauseTx.DepositOffer = &deposit.Offer{
+		InterestRateNominator: offer.InterestRateNominator,
+		Start:                 offer.Start,
+		End:                   offer.End,
+		MinAmount:             offer.MinAmount,
+		MinDuration:           offer.MinDuration,
+		MaxDuration:           offer.MaxDuration,
+		Memo:                  offer.Memo,
+		Flags:                 deposit.OfferFlagPaused,
+	}
+
+	renameTx := pauseTx
+	renameTx.DepositOffer = &deposit.Offer{
+		InterestRateNominator: offer.InterestRateNominator,
+		Start:                 offer.Start,
+		End:                   offer.End,
+		MinAmount:             offer.MinAmount,
+		MinDuration:           offer.MinDuration,
+		MaxDuration:           offer.MaxDuration,
+		Memo:                  offer.Memo,
+	}
+	renameTx.DepositOfferExtension.Name = "new name"
+	renameTx.DepositOfferExtension.URI = "new uri"
+
+	renamedOffer := currentOffer
+	renamedOffer.Name = renameTx.DepositOfferExtension.Name
+	renamedOffer.URI = renameTx.DepositOfferExtension.URI
+	renamedOffer.Version++
+
+	changeTermsTx := pauseTx
+	changeTermsTx.DepositOffer = &deposit.Offer{
+		InterestRateNominator: offer.InterestRateNominator + 1,
+		Start:                 offer.Start,
+		End:                   offer.End,
+		MinAmount:             offer.MinAmount,
+		MinDuration:           offer.MinDuration,
+		MaxDuration:           offer.MaxDuration,
+		Memo:                  offer.Memo,
+	}
+
+	tests := map[string]struct {
+		baseState   func(c *gomock.Controller) *state.MockState
+		utx         *txs.DepositOfferTx
+		state       func(*gomock.Controller, *txs.DepositOfferTx, ids.ID) *state.MockDiff
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		"Wrong lock mode": {
+			baseState: baseState,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: false}, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errWrongLockMode,
+		},
+		"Not admin": {
+			baseState: baseState,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetAddressStates(adminAddr).Return(as.AddressStateKycVerified, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errInvalidRoles,
+		},
+		"Not signed by admin": {
+			baseState: baseState,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {otherKey}},
+			expectedErr: errAdminCredentialMismatch,
+		},
+		"Offer ended": {
+			baseState: baseState,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(offer.End), 0))
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errDepositOfferEnded,
+		},
+		"Create: offer already exists": {
+			baseState: baseState,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(newOffer.ID).Return(&currentOffer, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errDepositOfferExists,
+		},
+		"Update: offer is locked": {
+			baseState: baseState,
+			utx:       &pauseTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(currentOffer.ID).Return(&lockedOffer, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errDepositOfferInactive,
+		},
+		"Update: terms of existing deposits are changed": {
+			baseState: baseState,
+			utx:       &changeTermsTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(currentOffer.ID).Return(&currentOffer, nil)
+				return s
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+			expectedErr: errDepositOfferTermsChanged,
+		},
+		"OK: create": {
+			baseState: baseStateWithFeeOwner,
+			utx:       createTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(newOffer.ID).Return(nil, database.ErrNotFound)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().SetDepositOffer(&newOffer)
+				expectConsumeUTXOs(s, utx.Ins)
+				return s
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+		},
+		"OK: update": {
+			baseState: baseStateWithFeeOwner,
+			utx:       &pauseTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(currentOffer.ID).Return(&currentOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().SetDepositOffer(&pausedOffer)
+				expectConsumeUTXOs(s, utx.Ins)
+				return s
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+		},
+		"OK: update name and uri": {
+			baseState: baseStateWithFeeOwner,
+			utx:       &renameTx,
+			state: func(c *gomock.Controller, utx *txs.DepositOfferTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				expectVerifyAdmin(s)
+				s.EXPECT().GetTimestamp().Return(time.Unix(int64(chainTime), 0))
+				s.EXPECT().GetDepositOffer(currentOffer.ID).Return(&currentOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().SetDepositOffer(&renamedOffer)
+				expectConsumeUTXOs(s, utx.Ins)
+				return s
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {adminKey}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			utx := *tt.utx
+			utx.BlockchainID = env.ctx.ChainID
+			utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(&utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, &utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorPauseAndResumeDepositOffer(t *testing.T) {
+	require := require.New(t)
+	adminKey := caminoPreFundedKeys[0]
+	depositorKey := caminoPreFundedKeys[1]
+
+	env := newCaminoEnvironment( /*postBanff*/ true, false, api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+		InitialAdmin:        adminKey.Address(),
+		DepositOffers: []*deposit.Offer{{
+			InterestRateNominator: 80_000,
+			Start:                 uint64(defaultGenesisTime.Unix()),
+			End:                   uint64(defaultGenesisTime.Add(365 * 24 * time.Hour).Unix()),
+			MinAmount:             1,
+			MinDuration:           60,
+			MaxDuration:           60,
+		}},
+	})
+	env.ctx.Lock.Lock()
+	defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+
+	genesisOffers, err := env.state.GetAllDepositOffers()
+	require.NoError(err)
+	require.Len(genesisOffers, 1)
+	offerID := genesisOffers[0].ID
+
+	execute := func(tx *txs.Tx) error {
+		onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(err)
+		if err := tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+			StandardTxExecutor{
+				Backend: &env.backend,
+				State:   onAcceptState,
+				Tx:      tx,
+			},
+		}); err != nil {
+			return err
+		}
+		onAcceptState.AddTx(tx, status.Committed)
+		onAcceptState.Apply(env.state)
+		return env.state.Commit()
+	}
+
+	setOfferFlags := func(flags uint64) *deposit.Offer {
+		currentOffer, err := env.state.GetDepositOffer(offerID)
+		require.NoError(err)
+		updatedOffer := *currentOffer
+		updatedOffer.Flags = flags
+		tx, err := env.txBuilder.NewDepositOfferTx(
+			&updatedOffer,
+			adminKey.Address(),
+			[]*crypto.PrivateKeySECP256K1R{adminKey},
+			nil,
+			nil,
+		)
+		require.NoError(err)
+		require.NoError(execute(tx))
+		offer, err := env.state.GetDepositOffer(offerID)
+		require.NoError(err)
+		return offer
+	}
+
+	depositWithOffer := func() error {
+		tx, err := env.txBuilder.NewDepositTx(
+			10,
+			60,
+			offerID,
+			depositorKey.Address(),
+			[]*crypto.PrivateKeySECP256K1R{depositorKey},
+			nil,
+			nil,
+			nil,
+		)
+		require.NoError(err)
+		return execute(tx)
+	}
+
+	pausedOffer := setOfferFlags(deposit.OfferFlagPaused)
+	require.True(pausedOffer.IsPaused())
+	require.Equal(uint64(1), pausedOffer.Version)
+	require.ErrorIs(depositWithOffer(), errDepositOfferPaused)
+
+	resumedOffer := setOfferFlags(0)
+	require.False(resumedOffer.IsPaused())
+	require.Equal(uint64(2), resumedOffer.Version)
+	require.NoError(depositWithOffer())
+}
+
+func TestCaminoStandardTxExecutorSplitDepositTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	owner1Key, owner1Addr, owner1 := generateKeyAndOwner(t)
+	owner2Key, _, owner2 := generateKeyAndOwner(t)
+	// deposit tx id must be less than locked.ThisTxID, so relocked outs are sorted before new deposit outs
+	depositTxID := ids.ID{1}
+	depositTx := &txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &owner1}}
+
+	depositOffer := &deposit.Offer{
+		ID:                    ids.GenerateTestID(),
+		MinAmount:             1,
+		MinDuration:           60,
+		MaxDuration:           100,
+		InterestRateNominator: 365 * 24 * 60 * 60 * 1_000_000 / 10, // 10%
+	}
+	testDeposit := &deposit.Deposit{
+		DepositOfferID:      depositOffer.ID,
+		ClaimedRewardAmount: 10,
+		Duration:            depositOffer.MinDuration,
+		Amount:              10000 * units.Avax,
+	}
+	splitAmount := 4000 * units.Avax
+	remainingDeposit, newDeposit := testDeposit.Split(splitAmount)
+
+	depositUTXO := generateTestUTXO(ids.ID{11}, ctx.AVAXAssetID, testDeposit.Amount, owner1, depositTxID, ids.Empty)
+	feeUTXO := generateTestUTXO(ids.ID{12}, ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+	utxos := []*avax.UTXO{depositUTXO, feeUTXO}
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	utx := func(splitAmount, relockedAmount, newDepositAmount uint64, relockedDepositTxID ids.ID) *txs.SplitDepositTx {
+		return &txs.SplitDepositTx{
+			BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+				Ins: generateInsFromUTXOs(utxos),
+				Outs: []*avax.TransferableOutput{
+					generateTestOut(ctx.AVAXAssetID, relockedAmount, owner1, relockedDepositTxID, ids.Empty),
+					generateTestOut(ctx.AVAXAssetID, newDepositAmount, owner1, locked.ThisTxID, ids.Empty),
+				},
+			}},
+			DepositTxID:  depositTxID,
+			SplitAmount:  splitAmount,
+			RewardsOwner: &owner2,
+		}
+	}
+
+	tests := map[string]struct {
+		baseState   func(c *gomock.Controller) *state.MockState
+		state       func(*gomock.Controller, *txs.SplitDepositTx, ids.ID) *state.MockDiff
+		utx         *txs.SplitDepositTx
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		"Deposit not found": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(nil, database.ErrNotFound)
+				return s
+			},
+			utx:         utx(splitAmount, testDeposit.Amount-splitAmount, splitAmount, depositTxID),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner1Key}},
+			expectedErr: errDepositNotFound,
+		},
+		"Split amount isn't less than deposit amount": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				return s
+			},
+			utx:         utx(testDeposit.Amount, 1, testDeposit.Amount-1, depositTxID),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner1Key}},
+			expectedErr: errWrongSplitAmount,
+		},
+		"Not signed by deposit rewards owner": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				return s
+			},
+			utx:         utx(splitAmount, testDeposit.Amount-splitAmount, splitAmount, depositTxID),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner2Key}},
+			expectedErr: errDepositCredentialMissmatch,
+		},
+		"New deposit amount doesn't match split amount": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, owner2.Addrs...)
+				return s
+			},
+			utx:         utx(splitAmount, testDeposit.Amount-splitAmount+1, splitAmount-1, depositTxID),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner1Key}},
+			expectedErr: errSplitAmountMismatch,
+		},
+		"Output is deposited with another deposit": {
+			baseState: baseState,
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, owner2.Addrs...)
+				return s
+			},
+			utx:         utx(splitAmount, testDeposit.Amount-splitAmount, splitAmount, ids.ID{2}),
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner1Key}},
+			expectedErr: errSplitDepositOutput,
+		},
+		"OK": {
+			baseState: func(c *gomock.Controller) *state.MockState {
+				s := baseState(c)
+				// utxo handler, used in fx VerifyMultisigTransfer method for verify lock flowcheck
+				s.EXPECT().GetMultisigAlias(owner1Addr).Return(nil, database.ErrNotFound)
+				s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+				return s
+			},
+			state: func(c *gomock.Controller, utx *txs.SplitDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDeposit(depositTxID).Return(testDeposit, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				s.EXPECT().GetTx(depositTxID).Return(depositTx, status.Committed, nil)
+				expectVerifyMultisigPermission(s, owner1.Addrs, nil)
+				expectNotRetiredAliases(s, owner2.Addrs...)
+				expectVerifyLock(s, utx.Ins, utxos)
+				expectNotRetiredOuts(s, utx.Outs)
+				currentSupply := 10000 * units.Avax
+				s.EXPECT().GetCurrentSupply(constants.PrimaryNetworkID).Return(currentSupply, nil)
+				s.EXPECT().SetCurrentSupply(constants.PrimaryNetworkID, currentSupply+
+					remainingDeposit.TotalReward(depositOffer)+
+					newDeposit.TotalReward(depositOffer)-
+					testDeposit.TotalReward(depositOffer))
+				s.EXPECT().ModifyDeposit(depositTxID, remainingDeposit)
+				s.EXPECT().AddDeposit(txID, newDeposit)
+				expectConsumeUTXOs(s, utx.Ins)
+				s.EXPECT().AddUTXO(&avax.UTXO{
+					UTXOID: avax.UTXOID{TxID: txID},
+					Asset:  utx.Outs[0].Asset,
+					Out:    utx.Outs[0].Out,
+				})
+				s.EXPECT().AddUTXO(&avax.UTXO{
+					UTXOID: avax.UTXOID{TxID: txID, OutputIndex: 1},
+					Asset:  utx.Outs[1].Asset,
+					Out: &locked.Out{
+						IDs:             locked.IDs{DepositTxID: txID},
+						TransferableOut: utx.Outs[1].Out.(*locked.Out).TransferableOut,
+					},
+				})
+				return s
+			},
+			utx:     utx(splitAmount, testDeposit.Amount-splitAmount, splitAmount, depositTxID),
+			signers: [][]*crypto.PrivateKeySECP256K1R{{owner1Key}, {feeOwnerKey}, {owner1Key}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks(true, false, nil, caminoGenesisConf, tt.baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			tt.utx.BlockchainID = env.ctx.ChainID
+			tt.utx.NetworkID = env.ctx.NetworkID
+			tx, err := txs.NewSigned(tt.utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, tt.utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	depositRewardOwnerKey, _, depositRewardOwner := generateKeyAndOwner(t)
+	claimableOwnerKey1, _, claimableOwner1 := generateKeyAndOwner(t)
+	claimableOwnerKey2, _, claimableOwner2 := generateKeyAndOwner(t)
+
+	feeUTXO := generateTestUTXO(ids.GenerateTestID(), ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+
+	depositOfferID := ids.GenerateTestID()
+	depositTxID1 := ids.GenerateTestID()
+	depositTxID2 := ids.GenerateTestID()
+	claimableOwnerID1 := ids.GenerateTestID()
+	claimableOwnerID2 := ids.GenerateTestID()
+	rewardAssetID := ids.GenerateTestID()
+	timestamp := time.Now()
+
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// utxo handler, used in fx VerifyMultisigTransfer method for baseTx ins verification
+		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+		Ins:          []*avax.TransferableInput{generateTestInFromUTXO(feeUTXO, []uint32{0})},
+	}}
+
+	tests := map[string]struct {
+		state       func(*gomock.Controller, *txs.ClaimTx, ids.ID, []*state.Claimable) *state.MockDiff
+		utx         func([]*state.Claimable) *txs.ClaimTx
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		claimables  []*state.Claimable
+		expectedErr error
+	}{
+		"Deposit not found": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				// deposit
+				s.EXPECT().GetDeposit(depositTxID1).Return(nil, database.ErrNotFound)
+				return s
+			},
+			utx: func([]*state.Claimable) *txs.ClaimTx {
+				return &txs.ClaimTx{
+					BaseTx:       baseTx,
+					DepositTxIDs: []ids.ID{depositTxID1},
+					ClaimTo:      &secp256k1fx.OutputOwners{},
+				}
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
+			expectedErr: errDepositNotFound,
+		},
+		"Bad deposit credential": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				// deposit
+				s.EXPECT().GetTx(depositTxID1).Return(
+					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &depositRewardOwner}},
+					status.Committed,
+					nil,
+				)
+				s.EXPECT().GetDeposit(depositTxID1).Return(&deposit.Deposit{DepositOfferID: depositOfferID}, nil)
+				expectVerifyMultisigPermission(s, depositRewardOwner.Addrs, nil)
+				return s
+			},
+			utx: func([]*state.Claimable) *txs.ClaimTx {
+				return &txs.ClaimTx{
+					BaseTx:       baseTx,
+					DepositTxIDs: []ids.ID{depositTxID1},
+					ClaimTo:      &secp256k1fx.OutputOwners{},
+				}
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
+			expectedErr: errDepositCredentialMissmatch,
+		},
+		"Bad claimable credential": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				// claimable
+				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
+				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
+				return s
+			},
+			utx: func([]*state.Claimable) *txs.ClaimTx {
+				return &txs.ClaimTx{
+					BaseTx:            baseTx,
+					ClaimTo:           &secp256k1fx.OutputOwners{},
+					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1},
+					ClaimedAmounts:    []uint64{1},
+				}
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {}},
+			claimables:  []*state.Claimable{{Owner: &claimableOwner1}},
+			expectedErr: errClaimableCredentialMissmatch,
+		},
+		"Claimed more than available": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+
+				// claimable 1
+				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
+				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
+				s.EXPECT().SetClaimable(claimableOwnerID1, &state.Claimable{
+					Owner:           claimables[0].Owner,
+					ValidatorReward: claimables[0].ValidatorReward - utx.ClaimedAmounts[0],
+				})
+				claimableUTXO1 := &avax.UTXO{
+					UTXOID: avax.UTXOID{
+						TxID:        txID,
+						OutputIndex: uint32(len(utx.Outs)),
+					},
+					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          utx.ClaimedAmounts[0],
+						OutputOwners: *claimables[0].Owner,
+					},
+				}
+				s.EXPECT().AddUTXO(claimableUTXO1)
+				s.EXPECT().AddRewardUTXO(txID, claimableUTXO1)
+
+				// claimable 2
+				s.EXPECT().GetClaimable(claimableOwnerID2).Return(claimables[1], nil)
+				expectVerifyMultisigPermission(s, claimableOwner2.Addrs, nil)
+				return s
+			},
+			utx: func(claimables []*state.Claimable) *txs.ClaimTx {
+				return &txs.ClaimTx{
+					BaseTx:            baseTx,
+					ClaimTo:           &secp256k1fx.OutputOwners{},
+					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1, claimableOwnerID2},
+					ClaimedAmounts:    []uint64{claimables[0].ValidatorReward - 1, claimables[1].ValidatorReward + 1},
+				}
 			},
 			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey1, claimableOwnerKey2}},
 			claimables: []*state.Claimable{
@@ -3653,6 +5413,11 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 					Duration:            deposit1.Duration,
 					Amount:              deposit1.Amount,
 				})
+				s.EXPECT().AddDepositClaim(depositTxID1, &deposit.Claim{
+					ClaimTxID: txID,
+					Timestamp: uint64(timestamp.Unix()),
+					Amount:    claimedRewardAmount,
+				})
 
 				// deposit2
 				s.EXPECT().GetTx(depositTxID2).Return(
@@ -3693,20 +5458,140 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 					Duration:            deposit2.Duration,
 					Amount:              deposit2.Amount,
 				})
+				s.EXPECT().AddDepositClaim(depositTxID2, &deposit.Claim{
+					ClaimTxID: txID,
+					Timestamp: uint64(timestamp.Unix()),
+					Amount:    claimedRewardAmount,
+				})
+
+				// claimable
+				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
+				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
+				s.EXPECT().SetClaimable(claimableOwnerID1, nil)
+				claimableUTXO1 := &avax.UTXO{
+					UTXOID: avax.UTXOID{
+						TxID:        txID,
+						OutputIndex: uint32(len(utx.Outs) + 2),
+					},
+					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          utx.ClaimedAmounts[0],
+						OutputOwners: claimableOwner1,
+					},
+				}
+				s.EXPECT().AddUTXO(claimableUTXO1)
+				s.EXPECT().AddRewardUTXO(txID, claimableUTXO1)
+				return s
+			},
+			utx: func(claimables []*state.Claimable) *txs.ClaimTx {
+				claimedAmt := claimables[0].ValidatorReward + claimables[0].DepositReward
+				return &txs.ClaimTx{
+					BaseTx:            baseTx,
+					DepositTxIDs:      []ids.ID{depositTxID1, depositTxID2},
+					ClaimTo:           &secp256k1fx.OutputOwners{},
+					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1},
+					ClaimedAmounts:    []uint64{claimedAmt},
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {depositRewardOwnerKey, claimableOwnerKey1}},
+			claimables: []*state.Claimable{{
+				Owner:           &claimableOwner1,
+				ValidatorReward: 10,
+				DepositReward:   20,
+			}},
+		},
+		"OK, deposit with new DepositRewardsOwner, non-zero already claimed reward and no rewards period": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				expectNotRetiredAliases(s, feeOwner.Addrs...)
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				s.EXPECT().DeleteUTXO(feeUTXO.InputID())
+
+				// deposit
+				s.EXPECT().GetTx(depositTxID1).Return(
+					&txs.Tx{Unsigned: &txs.DepositTx{RewardsOwner: &depositRewardOwner}},
+					status.Committed,
+					nil,
+				)
+				expectVerifyMultisigPermission(s, depositRewardOwner.Addrs, nil)
+				deposit1 := &deposit.Deposit{
+					DepositOfferID:      depositOfferID,
+					Start:               uint64(timestamp.Unix()) - 365*24*60*60/12*6, // 6 month
+					Duration:            365 * 24 * 60 * 60 / 12 * 14,                 // 14 month
+					Amount:              10,
+					ClaimedRewardAmount: 1,
+				}
+				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
+				s.EXPECT().GetDepositOffer(depositOfferID).Return(&deposit.Offer{
+					NoRewardsPeriodDuration: 365 * 24 * 60 * 60 / 12 * 2, // 2 month
+					InterestRateNominator:   1_000_000,                   // 100%
+				}, nil)
+				claimedRewardAmount := uint64(4) // expected claimable reward amount: 10 * (6m / (14m - 2m)) - 1 = 10 * 0.5 - 1 = 5 - 1 = 4
+				depositRewardUTXO := &avax.UTXO{
+					UTXOID: avax.UTXOID{
+						TxID:        txID,
+						OutputIndex: uint32(len(utx.Outs)),
+					},
+					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          claimedRewardAmount,
+						OutputOwners: feeOwner, // not depositTx.RewardsOwner
+					},
+				}
+				s.EXPECT().AddUTXO(depositRewardUTXO)
+				s.EXPECT().AddRewardUTXO(depositTxID1, depositRewardUTXO)
+				s.EXPECT().ModifyDeposit(depositTxID1, &deposit.Deposit{
+					DepositOfferID:      deposit1.DepositOfferID,
+					UnlockedAmount:      deposit1.UnlockedAmount,
+					ClaimedRewardAmount: deposit1.ClaimedRewardAmount + claimedRewardAmount,
+					Start:               deposit1.Start,
+					Duration:            deposit1.Duration,
+					Amount:              deposit1.Amount,
+				})
+				s.EXPECT().AddDepositClaim(depositTxID1, &deposit.Claim{
+					ClaimTxID: txID,
+					Timestamp: uint64(timestamp.Unix()),
+					Amount:    claimedRewardAmount,
+				})
+				return s
+			},
+			utx: func([]*state.Claimable) *txs.ClaimTx {
+				return &txs.ClaimTx{
+					BaseTx:       baseTx,
+					DepositTxIDs: []ids.ID{depositTxID1},
+					ClaimTo:      &feeOwner, // not depositTx.RewardsOwner
+				}
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {depositRewardOwnerKey}},
+		},
+		"OK, partial claim": {
+			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				s.EXPECT().DeleteUTXO(feeUTXO.InputID())
 
 				// claimable
 				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
 				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
-				s.EXPECT().SetClaimable(claimableOwnerID1, nil)
+				s.EXPECT().SetClaimable(claimableOwnerID1, &state.Claimable{
+					Owner:         claimables[0].Owner,
+					DepositReward: claimables[0].ValidatorReward + claimables[0].DepositReward - utx.ClaimedAmounts[0],
+				})
 				claimableUTXO1 := &avax.UTXO{
 					UTXOID: avax.UTXOID{
 						TxID:        txID,
-						OutputIndex: uint32(len(utx.Outs) + 2),
+						OutputIndex: uint32(len(utx.Outs)),
 					},
 					Asset: avax.Asset{ID: ctx.AVAXAssetID},
 					Out: &secp256k1fx.TransferOutput{
 						Amt:          utx.ClaimedAmounts[0],
-						OutputOwners: claimableOwner1,
+						OutputOwners: *claimables[0].Owner,
 					},
 				}
 				s.EXPECT().AddUTXO(claimableUTXO1)
@@ -3714,10 +5599,9 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 				return s
 			},
 			utx: func(claimables []*state.Claimable) *txs.ClaimTx {
-				claimedAmt := claimables[0].ValidatorReward + claimables[0].DepositReward
+				claimedAmt := claimables[0].ValidatorReward + claimables[0].DepositReward/2
 				return &txs.ClaimTx{
 					BaseTx:            baseTx,
-					DepositTxIDs:      []ids.ID{depositTxID1, depositTxID2},
 					ClaimTo:           &secp256k1fx.OutputOwners{},
 					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1},
 					ClaimedAmounts:    []uint64{claimedAmt},
@@ -3730,7 +5614,7 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 				DepositReward:   20,
 			}},
 		},
-		"OK, deposit with new DepositRewardsOwner, non-zero already claimed reward and no rewards period": {
+		"OK, deposit and claimable with reward asset": {
 			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
 				s := state.NewMockDiff(c)
 				// common checks and fee
@@ -3747,72 +5631,57 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 				)
 				expectVerifyMultisigPermission(s, depositRewardOwner.Addrs, nil)
 				deposit1 := &deposit.Deposit{
-					DepositOfferID:      depositOfferID,
-					Start:               uint64(timestamp.Unix()) - 365*24*60*60/12*6, // 6 month
-					Duration:            365 * 24 * 60 * 60 / 12 * 14,                 // 14 month
-					Amount:              10,
-					ClaimedRewardAmount: 1,
+					DepositOfferID: depositOfferID,
+					Start:          uint64(timestamp.Unix()) - 365*24*60*60/2, // 6 month
+					Duration:       365 * 24 * 60 * 60,                        // 12 month
+					Amount:         10,
 				}
 				s.EXPECT().GetDeposit(depositTxID1).Return(deposit1, nil)
 				s.EXPECT().GetDepositOffer(depositOfferID).Return(&deposit.Offer{
-					NoRewardsPeriodDuration: 365 * 24 * 60 * 60 / 12 * 2, // 2 month
-					InterestRateNominator:   1_000_000,                   // 100%
+					InterestRateNominator: 1_000_000, // 100%
+					RewardAssetID:         rewardAssetID,
 				}, nil)
-				claimedRewardAmount := uint64(4) // expected claimable reward amount: 10 * (6m / (14m - 2m)) - 1 = 10 * 0.5 - 1 = 5 - 1 = 4
+				claimedRewardAmount := uint64(5) // expected claimable reward amount: 10 * (6m / 12m) = 5
 				depositRewardUTXO := &avax.UTXO{
 					UTXOID: avax.UTXOID{
 						TxID:        txID,
 						OutputIndex: uint32(len(utx.Outs)),
 					},
-					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					Asset: avax.Asset{ID: rewardAssetID},
 					Out: &secp256k1fx.TransferOutput{
 						Amt:          claimedRewardAmount,
-						OutputOwners: feeOwner, // not depositTx.RewardsOwner
+						OutputOwners: depositRewardOwner,
 					},
 				}
 				s.EXPECT().AddUTXO(depositRewardUTXO)
 				s.EXPECT().AddRewardUTXO(depositTxID1, depositRewardUTXO)
 				s.EXPECT().ModifyDeposit(depositTxID1, &deposit.Deposit{
 					DepositOfferID:      deposit1.DepositOfferID,
-					UnlockedAmount:      deposit1.UnlockedAmount,
-					ClaimedRewardAmount: deposit1.ClaimedRewardAmount + claimedRewardAmount,
+					ClaimedRewardAmount: claimedRewardAmount,
 					Start:               deposit1.Start,
 					Duration:            deposit1.Duration,
 					Amount:              deposit1.Amount,
 				})
-				return s
-			},
-			utx: func([]*state.Claimable) *txs.ClaimTx {
-				return &txs.ClaimTx{
-					BaseTx:       baseTx,
-					DepositTxIDs: []ids.ID{depositTxID1},
-					ClaimTo:      &feeOwner, // not depositTx.RewardsOwner
-				}
-			},
-			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {depositRewardOwnerKey}},
-		},
-		"OK, partial claim": {
-			state: func(c *gomock.Controller, utx *txs.ClaimTx, txID ids.ID, claimables []*state.Claimable) *state.MockDiff {
-				s := state.NewMockDiff(c)
-				// common checks and fee
-				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
-				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
-				s.EXPECT().GetTimestamp().Return(timestamp)
-				s.EXPECT().DeleteUTXO(feeUTXO.InputID())
+				s.EXPECT().AddDepositClaim(depositTxID1, &deposit.Claim{
+					ClaimTxID: txID,
+					Timestamp: uint64(timestamp.Unix()),
+					Amount:    claimedRewardAmount,
+				})
 
 				// claimable
 				s.EXPECT().GetClaimable(claimableOwnerID1).Return(claimables[0], nil)
 				expectVerifyMultisigPermission(s, claimableOwner1.Addrs, nil)
 				s.EXPECT().SetClaimable(claimableOwnerID1, &state.Claimable{
 					Owner:         claimables[0].Owner,
-					DepositReward: claimables[0].ValidatorReward + claimables[0].DepositReward - utx.ClaimedAmounts[0],
+					DepositReward: claimables[0].DepositReward - utx.ClaimedAmounts[0],
+					RewardAssetID: rewardAssetID,
 				})
 				claimableUTXO1 := &avax.UTXO{
 					UTXOID: avax.UTXOID{
 						TxID:        txID,
-						OutputIndex: uint32(len(utx.Outs)),
+						OutputIndex: uint32(len(utx.Outs) + 1),
 					},
-					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					Asset: avax.Asset{ID: rewardAssetID},
 					Out: &secp256k1fx.TransferOutput{
 						Amt:          utx.ClaimedAmounts[0],
 						OutputOwners: *claimables[0].Owner,
@@ -3823,19 +5692,19 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 				return s
 			},
 			utx: func(claimables []*state.Claimable) *txs.ClaimTx {
-				claimedAmt := claimables[0].ValidatorReward + claimables[0].DepositReward/2
 				return &txs.ClaimTx{
 					BaseTx:            baseTx,
+					DepositTxIDs:      []ids.ID{depositTxID1},
 					ClaimTo:           &secp256k1fx.OutputOwners{},
 					ClaimableOwnerIDs: []ids.ID{claimableOwnerID1},
-					ClaimedAmounts:    []uint64{claimedAmt},
+					ClaimedAmounts:    []uint64{claimables[0].DepositReward / 2},
 				}
 			},
 			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {depositRewardOwnerKey, claimableOwnerKey1}},
 			claimables: []*state.Claimable{{
-				Owner:           &claimableOwner1,
-				ValidatorReward: 10,
-				DepositReward:   20,
+				Owner:         &claimableOwner1,
+				DepositReward: 20,
+				RewardAssetID: rewardAssetID,
 			}},
 		},
 	}
@@ -3872,6 +5741,256 @@ func TestCaminoStandardTxExecutorClaimTx(t *testing.T) {
 	}
 }
 
+func TestCaminoStandardTxExecutorClaimDepositTx(t *testing.T) {
+	ctx, _ := defaultCtx(nil)
+
+	feeOwnerKey, feeOwnerAddr, feeOwner := generateKeyAndOwner(t)
+	_, _, depositRewardOwner := generateKeyAndOwner(t)
+	claimableOwnerKey, _, claimableOwner := generateKeyAndOwner(t)
+	offerOwnerKey, offerOwnerAddr, _ := generateKeyAndOwner(t)
+
+	feeUTXO := generateTestUTXO(ids.GenerateTestID(), ctx.AVAXAssetID, defaultTxFee, feeOwner, ids.Empty, ids.Empty)
+
+	claimableOwnerID := ids.GenerateTestID()
+	timestamp := time.Now()
+
+	depositOffer := &deposit.Offer{
+		ID:          ids.GenerateTestID(),
+		End:         uint64(timestamp.Add(time.Hour).Unix()),
+		MinAmount:   1,
+		MinDuration: 60,
+		MaxDuration: 100,
+	}
+	restrictedDepositOffer := &deposit.Offer{
+		ID:           ids.GenerateTestID(),
+		End:          depositOffer.End,
+		MinAmount:    1,
+		MinDuration:  60,
+		MaxDuration:  100,
+		OwnerAddress: offerOwnerAddr,
+	}
+	bigDepositOffer := &deposit.Offer{
+		ID:          ids.GenerateTestID(),
+		End:         depositOffer.End,
+		MinAmount:   100,
+		MinDuration: 60,
+		MaxDuration: 100,
+	}
+
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+
+	baseState := func(c *gomock.Controller) *state.MockState {
+		s := state.NewMockState(c)
+		// utxo handler, used in fx VerifyMultisigTransfer method for baseTx ins verification
+		s.EXPECT().GetMultisigAlias(feeOwnerAddr).Return(nil, database.ErrNotFound)
+		// shutdown
+		s.EXPECT().SetHeight(uint64(math.MaxUint64))
+		s.EXPECT().Commit()
+		s.EXPECT().Close()
+		return s
+	}
+
+	baseTx := txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    ctx.NetworkID,
+		BlockchainID: ctx.ChainID,
+		Ins:          []*avax.TransferableInput{generateTestInFromUTXO(feeUTXO, []uint32{0})},
+	}}
+
+	claimTx := txs.ClaimTx{
+		BaseTx:            baseTx,
+		ClaimTo:           &secp256k1fx.OutputOwners{},
+		ClaimableOwnerIDs: []ids.ID{claimableOwnerID},
+		ClaimedAmounts:    []uint64{10},
+	}
+
+	// expectClaim sets expectations for claim of all claimable validator reward,
+	// that is minted deposited with tx [txID]
+	expectClaim := func(s *state.MockDiff, txID ids.ID, claimable *state.Claimable) {
+		s.EXPECT().GetClaimable(claimableOwnerID).Return(claimable, nil)
+		expectVerifyMultisigPermission(s, claimableOwner.Addrs, nil)
+		claimedUTXO := &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: txID},
+			Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+			Out: &locked.Out{
+				IDs: locked.IDs{DepositTxID: txID},
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          claimable.ValidatorReward,
+					OutputOwners: claimableOwner,
+				},
+			},
+		}
+		s.EXPECT().AddUTXO(claimedUTXO)
+		s.EXPECT().AddRewardUTXO(txID, claimedUTXO)
+		s.EXPECT().SetClaimable(claimableOwnerID, nil)
+		s.EXPECT().DeleteUTXO(feeUTXO.InputID())
+	}
+
+	tests := map[string]struct {
+		state       func(*gomock.Controller, *txs.ClaimDepositTx, ids.ID) *state.MockDiff
+		utx         *txs.ClaimDepositTx
+		signers     [][]*crypto.PrivateKeySECP256K1R
+		expectedErr error
+	}{
+		"Claimed asset isn't primary network asset": {
+			state: func(c *gomock.Controller, utx *txs.ClaimDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp)
+				// claimable
+				s.EXPECT().GetClaimable(claimableOwnerID).Return(&state.Claimable{
+					Owner:           &claimableOwner,
+					ValidatorReward: 10,
+					RewardAssetID:   ids.GenerateTestID(),
+				}, nil)
+				expectVerifyMultisigPermission(s, claimableOwner.Addrs, nil)
+				return s
+			},
+			utx: &txs.ClaimDepositTx{
+				ClaimTx:         claimTx,
+				DepositOfferID:  depositOffer.ID,
+				DepositDuration: depositOffer.MinDuration,
+				RewardsOwner:    &depositRewardOwner,
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey}},
+			expectedErr: errClaimedAssetNotDepositable,
+		},
+		"Claimed amount is less than deposit offer minimum amount": {
+			state: func(c *gomock.Controller, utx *txs.ClaimDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDepositOffer(bigDepositOffer.ID).Return(bigDepositOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp).Times(2)
+				// claimable
+				expectClaim(s, txID, &state.Claimable{Owner: &claimableOwner, ValidatorReward: 10})
+				return s
+			},
+			utx: &txs.ClaimDepositTx{
+				ClaimTx:         claimTx,
+				DepositOfferID:  bigDepositOffer.ID,
+				DepositDuration: bigDepositOffer.MinDuration,
+				RewardsOwner:    &depositRewardOwner,
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey}},
+			expectedErr: errDepositToSmall,
+		},
+		"Bad offer owner credential": {
+			state: func(c *gomock.Controller, utx *txs.ClaimDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDepositOffer(restrictedDepositOffer.ID).Return(restrictedDepositOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp).Times(2)
+				// claimable
+				expectClaim(s, txID, &state.Claimable{Owner: &claimableOwner, ValidatorReward: 10})
+				// new deposit
+				expectNotRetiredAliases(s, depositRewardOwner.Addrs...)
+				expectVerifyMultisigPermission(s, []ids.ShortID{offerOwnerAddr}, nil)
+				return s
+			},
+			utx: &txs.ClaimDepositTx{
+				ClaimTx:         claimTx,
+				DepositOfferID:  restrictedDepositOffer.ID,
+				DepositDuration: restrictedDepositOffer.MinDuration,
+				RewardsOwner:    &depositRewardOwner,
+			},
+			signers:     [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey}, {claimableOwnerKey}},
+			expectedErr: errOfferOwnerCredentialMismatch,
+		},
+		"OK": {
+			state: func(c *gomock.Controller, utx *txs.ClaimDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDepositOffer(depositOffer.ID).Return(depositOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp).Times(3)
+				// claimable
+				expectClaim(s, txID, &state.Claimable{Owner: &claimableOwner, ValidatorReward: 10})
+				// new deposit
+				expectNotRetiredAliases(s, depositRewardOwner.Addrs...)
+				s.EXPECT().GetCurrentSupply(constants.PrimaryNetworkID).Return(uint64(1000), nil)
+				s.EXPECT().SetCurrentSupply(constants.PrimaryNetworkID, uint64(1000))
+				s.EXPECT().AddDeposit(txID, &deposit.Deposit{
+					DepositOfferID: depositOffer.ID,
+					Duration:       depositOffer.MinDuration,
+					Amount:         10,
+					Start:          uint64(timestamp.Unix()),
+				})
+				return s
+			},
+			utx: &txs.ClaimDepositTx{
+				ClaimTx:         claimTx,
+				DepositOfferID:  depositOffer.ID,
+				DepositDuration: depositOffer.MinDuration,
+				RewardsOwner:    &depositRewardOwner,
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey}},
+		},
+		"OK, restricted offer": {
+			state: func(c *gomock.Controller, utx *txs.ClaimDepositTx, txID ids.ID) *state.MockDiff {
+				s := state.NewMockDiff(c)
+				// common checks and fee
+				s.EXPECT().CaminoConfig().Return(&state.CaminoConfig{LockModeBondDeposit: true}, nil)
+				s.EXPECT().GetDepositOffer(restrictedDepositOffer.ID).Return(restrictedDepositOffer, nil).Times(2)
+				expectVerifyLock(s, utx.Ins, []*avax.UTXO{feeUTXO})
+				s.EXPECT().GetTimestamp().Return(timestamp).Times(3)
+				// claimable
+				expectClaim(s, txID, &state.Claimable{Owner: &claimableOwner, ValidatorReward: 10})
+				// new deposit
+				expectNotRetiredAliases(s, depositRewardOwner.Addrs...)
+				expectVerifyMultisigPermission(s, []ids.ShortID{offerOwnerAddr}, nil)
+				s.EXPECT().GetCurrentSupply(constants.PrimaryNetworkID).Return(uint64(1000), nil)
+				s.EXPECT().SetCurrentSupply(constants.PrimaryNetworkID, uint64(1000))
+				s.EXPECT().AddDeposit(txID, &deposit.Deposit{
+					DepositOfferID: restrictedDepositOffer.ID,
+					Duration:       restrictedDepositOffer.MinDuration,
+					Amount:         10,
+					Start:          uint64(timestamp.Unix()),
+				})
+				return s
+			},
+			utx: &txs.ClaimDepositTx{
+				ClaimTx:         claimTx,
+				DepositOfferID:  restrictedDepositOffer.ID,
+				DepositDuration: restrictedDepositOffer.MinDuration,
+				RewardsOwner:    &depositRewardOwner,
+			},
+			signers: [][]*crypto.PrivateKeySECP256K1R{{feeOwnerKey}, {claimableOwnerKey}, {offerOwnerKey}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			env := newCaminoEnvironmentWithMocks( /*postBanff*/ true, false, nil, caminoGenesisConf, baseState(ctrl), nil, nil)
+			defer func() { require.NoError(shutdownCaminoEnvironment(env)) }() //nolint:revive
+			env.ctx.Lock.Lock()
+
+			tx, err := txs.NewSigned(tt.utx, txs.Codec, tt.signers)
+			require.NoError(err)
+
+			err = tx.Unsigned.Visit(&CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   tt.state(ctrl, tt.utx, tx.ID()),
+					Tx:      tx,
+				},
+			})
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
 func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 	caminoGenesisConf := api.Camino{
 		VerifyNodeSignature: true,
@@ -3951,7 +6070,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				linkNode(caminoPreFundedKeys[4].Address(), newNodeID)
 			},
 			expectedErr: errConsortiumMemberHasNode,
@@ -3967,7 +6086,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				linkNode(caminoPreFundedKeys[4].Address(), caminoPreFundedNodeIDs[4])
 			},
 			expectedErr: errValidatorExists,
@@ -3983,7 +6102,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				linkNode(caminoPreFundedKeys[4].Address(), caminoPreFundedNodeIDs[3])
 				staker, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, caminoPreFundedNodeIDs[3])
 				require.NoError(t, err)
@@ -4003,7 +6122,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				linkNode(caminoPreFundedKeys[4].Address(), caminoPreFundedNodeIDs[2])
 				staker, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, caminoPreFundedNodeIDs[2])
 				require.NoError(t, err)
@@ -4023,7 +6142,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				linkNode(caminoPreFundedKeys[4].Address(), testNodeID)
 			},
 			expectedNodeID: newNodeID,
@@ -4039,7 +6158,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 				}
 			},
 			preExecute: func(t *testing.T, tx *txs.Tx) {
-				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), txs.AddressStateConsortiumBit)
+				env.state.SetAddressStates(caminoPreFundedKeys[4].Address(), as.AddressStateConsortium)
 				unlinkNode(caminoPreFundedKeys[4].Address(), newNodeID)
 			},
 			expectedNodeID: newNodeID,
@@ -4049,7 +6168,7 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			args := tt.generateArgs()
 			tx, err := env.txBuilder.NewRegisterNodeTx(
-				args.oldNodeID, args.newNodeID, args.consortiumMemberAddress, args.keys, args.change)
+				args.oldNodeID, args.newNodeID, args.consortiumMemberAddress, args.keys, args.change, nil)
 			require.NoError(t, err)
 
 			if tt.preExecute != nil {
@@ -4078,6 +6197,107 @@ func TestCaminoStandardTxExecutorRegisterNodeTx(t *testing.T) {
 	}
 }
 
+func TestCaminoStandardTxExecutorBaseTx(t *testing.T) {
+	caminoGenesisConf := api.Camino{
+		VerifyNodeSignature: true,
+		LockModeBondDeposit: true,
+	}
+	env := newCaminoEnvironment( /*postBanff*/ true, false, caminoGenesisConf)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownCaminoEnvironment(env))
+	}()
+
+	recipientKey, err := testKeyfactory.NewPrivateKey()
+	require.NoError(t, err)
+	recipientOwner := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{recipientKey.PublicKey().Address()},
+	}
+	sender := caminoPreFundedKeys[0]
+	amount := uint64(100)
+
+	signers := func(credsLen int, key *crypto.PrivateKeySECP256K1R) [][]*crypto.PrivateKeySECP256K1R {
+		signers := make([][]*crypto.PrivateKeySECP256K1R, credsLen)
+		for i := range signers {
+			signers[i] = []*crypto.PrivateKeySECP256K1R{key}
+		}
+		return signers
+	}
+
+	tests := map[string]struct {
+		modifyTx    func(*testing.T, *txs.Tx) *txs.Tx
+		expectedErr error
+	}{
+		"Happy path": {},
+		"Locked output": {
+			modifyTx: func(t *testing.T, tx *txs.Tx) *txs.Tx {
+				utx, ok := tx.Unsigned.(*txs.BaseTx)
+				require.True(t, ok)
+				utx.Outs = append(utx.Outs, generateTestOut(env.ctx.AVAXAssetID, 1, recipientOwner, ids.Empty, locked.ThisTxID))
+				avax.SortTransferableOutputs(utx.Outs, txs.Codec)
+				signedTx, err := txs.NewSigned(utx, txs.Codec, signers(len(utx.Ins), sender))
+				require.NoError(t, err)
+				return signedTx
+			},
+			expectedErr: locked.ErrWrongOutType,
+		},
+		"Wrong credential": {
+			modifyTx: func(t *testing.T, tx *txs.Tx) *txs.Tx {
+				signedTx, err := txs.NewSigned(tx.Unsigned, txs.Codec, signers(len(tx.Creds), caminoPreFundedKeys[1]))
+				require.NoError(t, err)
+				return signedTx
+			},
+			expectedErr: errFlowCheckFailed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tx, err := env.txBuilder.NewBaseTx(
+				amount,
+				&recipientOwner,
+				[]*crypto.PrivateKeySECP256K1R{sender},
+				nil,
+				nil,
+			)
+			require.NoError(t, err)
+			if tt.modifyTx != nil {
+				tx = tt.modifyTx(t, tx)
+			}
+
+			onAcceptState, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(t, err)
+
+			executor := CaminoStandardTxExecutor{
+				StandardTxExecutor{
+					Backend: &env.backend,
+					State:   onAcceptState,
+					Tx:      tx,
+				},
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			transferred := uint64(0)
+			for i, out := range tx.Unsigned.Outputs() {
+				secpOut, ok := out.Out.(*secp256k1fx.TransferOutput)
+				require.True(t, ok)
+				if !secpOut.OutputOwners.Equals(&recipientOwner) {
+					continue
+				}
+				utxoID := avax.UTXOID{TxID: tx.ID(), OutputIndex: uint32(i)}
+				_, err := onAcceptState.GetUTXO(utxoID.InputID())
+				require.NoError(t, err)
+				transferred += secpOut.Amt
+			}
+			require.Equal(t, amount, transferred)
+		})
+	}
+}
+
 func TestCaminoStandardTxExecutorRewardsImportTx(t *testing.T) {
 	ctx, _ := defaultCtx(nil)
 	caminoGenesisConf := api.Camino{
@@ -4119,7 +6339,7 @@ func TestCaminoStandardTxExecutorRewardsImportTx(t *testing.T) {
 			utxosBytes[i] = utxoBytes
 		}
 		shm.EXPECT().Indexed(ctx.CChainID, treasury.AddrTraitsBytes,
-			ids.ShortEmpty[:], ids.Empty[:], maxPageSize).Return(utxosBytes, nil, nil, nil)
+			ids.ShortEmpty[:], ids.Empty[:], treasury.ImportPageSize).Return(utxosBytes, nil, nil, nil)
 		return shm
 	}
 
@@ -4479,9 +6699,10 @@ func TestCaminoStandardTxExecutorSuspendValidator(t *testing.T) {
 			tx, err := env.txBuilder.NewAddressStateTx(
 				setAddressStateArgs.address,
 				setAddressStateArgs.remove,
-				txs.AddressStateNodeDeferred,
+				as.AddressStateBitNodeDeferred,
 				setAddressStateArgs.keys,
 				setAddressStateArgs.changeAddr,
+				nil,
 			)
 			require.NoError(t, err)
 
@@ -33,6 +33,42 @@ func (*StandardTxExecutor) RewardsImportTx(*txs.RewardsImportTx) error {
 	return errWrongTxType
 }
 
+func (*StandardTxExecutor) BaseTx(*txs.BaseTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) RenewDepositTx(*txs.RenewDepositTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) SplitDepositTx(*txs.SplitDepositTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) DepositOfferTx(*txs.DepositOfferTx) error {
+	return errWrongTxType
+}
+
+func (*StandardTxExecutor) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	return errWrongTxType
+}
+
 // Proposal
 
 func (*ProposalTxExecutor) AddressStateTx(*txs.AddressStateTx) error {
@@ -59,6 +95,42 @@ func (*ProposalTxExecutor) RewardsImportTx(*txs.RewardsImportTx) error {
 	return errWrongTxType
 }
 
+func (*ProposalTxExecutor) BaseTx(*txs.BaseTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) RenewDepositTx(*txs.RenewDepositTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) SplitDepositTx(*txs.SplitDepositTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) DepositOfferTx(*txs.DepositOfferTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	return errWrongTxType
+}
+
 // Atomic
 
 func (*AtomicTxExecutor) AddressStateTx(*txs.AddressStateTx) error {
@@ -85,6 +157,42 @@ func (*AtomicTxExecutor) RewardsImportTx(*txs.RewardsImportTx) error {
 	return errWrongTxType
 }
 
+func (*AtomicTxExecutor) BaseTx(*txs.BaseTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) RenewDepositTx(*txs.RenewDepositTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) SplitDepositTx(*txs.SplitDepositTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) DepositOfferTx(*txs.DepositOfferTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	return errWrongTxType
+}
+
 // MemPool
 
 func (v *MempoolTxVerifier) AddressStateTx(tx *txs.AddressStateTx) error {
@@ -110,3 +218,39 @@ func (v *MempoolTxVerifier) RegisterNodeTx(tx *txs.RegisterNodeTx) error {
 func (v *MempoolTxVerifier) RewardsImportTx(tx *txs.RewardsImportTx) error {
 	return v.standardTx(tx)
 }
+
+func (v *MempoolTxVerifier) BaseTx(tx *txs.BaseTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) AddressStateBatchTx(tx *txs.AddressStateBatchTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) RenewDepositTx(tx *txs.RenewDepositTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) DepositRewardsOwnerTx(tx *txs.DepositRewardsOwnerTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) SplitDepositTx(tx *txs.SplitDepositTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) MultisigAliasTx(tx *txs.MultisigAliasTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) RotateMultisigAliasTx(tx *txs.RotateMultisigAliasTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) DepositOfferTx(tx *txs.DepositOfferTx) error {
+	return v.standardTx(tx)
+}
+
+func (v *MempoolTxVerifier) ClaimDepositTx(tx *txs.ClaimDepositTx) error {
+	return v.standardTx(tx)
+}
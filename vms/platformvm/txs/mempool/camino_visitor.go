@@ -39,6 +39,51 @@ func (i *issuer) RewardsImportTx(*txs.RewardsImportTx) error {
 	return nil
 }
 
+func (i *issuer) BaseTx(*txs.BaseTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) RenewDepositTx(*txs.RenewDepositTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) SplitDepositTx(*txs.SplitDepositTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) DepositOfferTx(*txs.DepositOfferTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
+func (i *issuer) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
+
 // Remover
 
 func (r *remover) AddressStateTx(*txs.AddressStateTx) error {
@@ -70,3 +115,48 @@ func (r *remover) RewardsImportTx(*txs.RewardsImportTx) error {
 	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
 	return nil
 }
+
+func (r *remover) BaseTx(*txs.BaseTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) AddressStateBatchTx(*txs.AddressStateBatchTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) RenewDepositTx(*txs.RenewDepositTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) DepositRewardsOwnerTx(*txs.DepositRewardsOwnerTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) SplitDepositTx(*txs.SplitDepositTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) MultisigAliasTx(*txs.MultisigAliasTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) RotateMultisigAliasTx(*txs.RotateMultisigAliasTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) DepositOfferTx(*txs.DepositOfferTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
+func (r *remover) ClaimDepositTx(*txs.ClaimDepositTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
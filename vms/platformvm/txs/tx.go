@@ -113,7 +113,7 @@ func (tx *Tx) Sign(c codec.Manager, signers [][]*crypto.PrivateKeySECP256K1R) er
 	}
 
 	// Attach credentials
-	hash := hashing.ComputeHash256(unsignedBytes)
+	hash := SigningHash(unsignedBytes)
 	for _, keys := range signers {
 		cred := &secp256k1fx.Credential{
 			Sigs: make([][crypto.SECP256K1RSigLen]byte, len(keys)),
@@ -6,6 +6,8 @@ package utxo
 import (
 	"errors"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/crypto"
@@ -30,16 +32,24 @@ func NewCaminoHandler(
 	utxoReader avax.UTXOReader,
 	fx fx.Fx,
 	lockModeBondDeposit bool,
-) Handler {
+	minChangeAmount uint64,
+	registerer prometheus.Registerer,
+) (Handler, error) {
+	metrics, err := newHandlerMetrics("utxo_handler", registerer)
+	if err != nil {
+		return nil, err
+	}
 	return &caminoHandler{
 		handler: handler{
-			ctx:         ctx,
-			clk:         clk,
-			utxosReader: utxoReader,
-			fx:          fx,
+			ctx:             ctx,
+			clk:             clk,
+			utxosReader:     utxoReader,
+			fx:              fx,
+			minChangeAmount: minChangeAmount,
+			metrics:         metrics,
 		},
 		lockModeBondDeposit: lockModeBondDeposit,
-	}
+	}, nil
 }
 
 func (h *caminoHandler) Spend(
@@ -63,7 +73,7 @@ func (h *caminoHandler) Spend(
 				Addrs:     []ids.ShortID{changeAddr},
 			}
 		}
-		inputs, outputs, signers, _, err := h.Lock(keys, amount, fee, locked.StateUnlocked, nil, change, 0)
+		inputs, outputs, signers, _, err := h.Lock(keys, amount, fee, locked.StateUnlocked, nil, change, 0, 0, 0)
 		if err != nil {
 			return nil, nil, nil, nil, err
 		}
@@ -205,6 +205,22 @@ func generateTestUTXO(txID ids.ID, assetID ids.ID, amount uint64, outputOwners s
 	return testUTXO
 }
 
+func generateTestEscrowedUTXO(txID ids.ID, assetID ids.ID, amount uint64, outputOwners secp256k1fx.OutputOwners, escrowTxID ids.ID) *avax.UTXO {
+	testUTXO := &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: txID},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &locked.EscrowOut{
+			EscrowTxID: escrowTxID,
+			TransferableOut: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: outputOwners,
+			},
+		},
+	}
+	testUTXO.InputID()
+	return testUTXO
+}
+
 func generateTestStakeableUTXO(txID ids.ID, assetID ids.ID, amount, locktime uint64, outputOwners secp256k1fx.OutputOwners) *avax.UTXO {
 	return &avax.UTXO{
 		UTXOID: avax.UTXOID{TxID: txID},
@@ -235,6 +251,14 @@ func generateTestInFromUTXO(utxo *avax.UTXO, sigIndices []uint32) *avax.Transfer
 				Input: secp256k1fx.Input{SigIndices: sigIndices},
 			},
 		}
+	case *locked.EscrowOut:
+		in = &locked.EscrowIn{
+			EscrowTxID: out.EscrowTxID,
+			TransferableIn: &secp256k1fx.TransferInput{
+				Amt:   out.Amount(),
+				Input: secp256k1fx.Input{SigIndices: sigIndices},
+			},
+		}
 	default:
 		panic("unknown utxo.Out type")
 	}
@@ -7,25 +7,35 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"runtime"
 	"sort"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
+	deposits "github.com/ava-labs/avalanchego/vms/platformvm/deposit"
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 var (
+	ErrNeedsConsolidation = errors.New("too many inputs required, utxos must be consolidated first")
+	ErrAliasRetired       = errors.New("multisig alias is retired")
+
 	errInvalidTargetLockState    = errors.New("invalid target lock state")
 	errLockingLockedUTXO         = errors.New("utxo consumed for locking are already locked")
 	errUnlockingUnlockedUTXO     = errors.New("utxo consumed for unlocking are already unlocked")
@@ -43,12 +53,20 @@ var (
 	errFailToGetDeposit          = errors.New("couldn't get deposit")
 	errUnlockedMoreThanAvailable = errors.New("unlocked more deposited tokens, than was available for unlock")
 	errNotConsumedDeposit        = errors.New("didn't consume whole deposit amount, but deposit is expired and can't be partially unlocked")
+	errPartialEarlyUnlock        = errors.New("deposit can't be partially unlocked before it becomes unlockable")
+	errEarlyUnlockPenaltyNotPaid = errors.New("early unlock penalty isn't transferred to treasury")
 	errLockedUTXO                = errors.New("can't spend locked utxo")
 	errNotLockedUTXO             = errors.New("can't spend unlocked utxo")
+	errZeroTargetCount           = errors.New("target utxos count is zero")
+	errNothingToConsolidate      = errors.New("utxos count is already not bigger than target count")
+	errLockingNotFeeAsset        = errors.New("only fee asset can be locked with lock state other than unlocked")
+	errRelockingLockedUTXO       = errors.New("utxo consumed for relocking is already locked with applied lock state")
+	errNotAliasGetter            = errors.New("utxos reader isn't msig alias getter")
 )
 
 // Creates UTXOs from [outs] and adds them to the UTXO set.
 // UTXOs with LockedOut will have 'thisTxID' replaced with [txID].
+// Labels of labeled outs are preserved.
 // [txID] is the ID of the tx that created [outs].
 func ProduceLocked(
 	utxoDB state.UTXOAdder,
@@ -56,16 +74,19 @@ func ProduceLocked(
 	outs []*avax.TransferableOutput,
 	appliedLockState locked.State,
 ) error {
-	if appliedLockState != locked.StateBonded && appliedLockState != locked.StateDeposited {
+	switch appliedLockState {
+	case locked.StateBonded,
+		locked.StateDeposited,
+		locked.StateEscrowed:
+	default:
 		return errInvalidTargetLockState
 	}
 
 	for index, output := range outs {
 		out := output.Out
-		if lockedOut, ok := out.(*locked.Out); ok {
-			utxoLockedOut := *lockedOut
-			utxoLockedOut.FixLockID(txID, appliedLockState)
-			out = &utxoLockedOut
+		if lockIDs, innerOut, ok := locked.OutIDs(out); ok {
+			lockIDs.FixLockID(txID, appliedLockState)
+			out = locked.WithLabel(locked.NewOut(lockIDs, innerOut), locked.OutLabel(out))
 		}
 		utxoDB.AddUTXO(&avax.UTXO{
 			UTXOID: avax.UTXOID{
@@ -86,10 +107,16 @@ type CaminoSpender interface {
 	// - [keys] are the owners of the funds
 	// - [totalAmountToLock] is the amount of funds that are trying to be locked with [appliedLockState]
 	// - [totalAmountToBurn] is the amount of AVAX that should be burned
-	// - [appliedLockState] state to set (except BondDeposit)
+	// - [appliedLockState] state to set (except BondDeposit).
+	//   If it's Bonded, deposited funds are bonded only if unlocked funds are insufficient.
+	//   If it's Escrowed, only unlocked funds are escrowed.
 	// - [to] owner of unlocked amounts if appliedLockState is Unlocked
 	// - [change] owner of unlocked amounts resulting from splittig inputs
+	// - [maxInputs] is the max number of consumed inputs, zero means unlimited.
+	//   If more inputs are required, ErrNeedsConsolidation is returned.
 	// - [asOf] timestamp against LockTime is compared
+	// - [minChangeAmount] unlocked AVAX change outputs with lesser amount aren't produced,
+	//   but burned together with fee. Zero means handler's configured min change amount.
 	// Returns:
 	// - [inputs] the inputs that should be consumed to fund the outputs
 	// - [outputs] the outputs that should be returned to the UTXO set
@@ -102,7 +129,58 @@ type CaminoSpender interface {
 		appliedLockState locked.State,
 		to *secp256k1fx.OutputOwners,
 		change *secp256k1fx.OutputOwners,
+		maxInputs int,
+		asOf uint64,
+		minChangeAmount uint64,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // outputs
+		[][]*crypto.PrivateKeySECP256K1R, // signers
+		[]*secp256k1fx.OutputOwners, // owners
+		error,
+	)
+
+	// LockAssets is the same as Lock, but locks amounts of multiple assets.
+	// Produced ins and outs are grouped by asset and owner.
+	// Arguments:
+	// - [amountsToLock] map[assetID]amountToLock, assets other than AVAX
+	//   can only be locked with Unlocked [appliedLockState]
+	// - [amountToBurn] is the amount of AVAX that should be burned
+	// - other arguments and results are the same as for Lock
+	LockAssets(
+		keys []*crypto.PrivateKeySECP256K1R,
+		amountsToLock map[ids.ID]uint64,
+		amountToBurn uint64,
+		appliedLockState locked.State,
+		to *secp256k1fx.OutputOwners,
+		change *secp256k1fx.OutputOwners,
+		maxInputs int,
+		asOf uint64,
+		minChangeAmount uint64,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // outputs
+		[][]*crypto.PrivateKeySECP256K1R, // signers
+		[]*secp256k1fx.OutputOwners, // owners
+		error,
+	)
+
+	// LockAssetsFrom is the same as LockAssets, but utxos are read from caller-supplied [source]
+	// instead of chain state, so wallets and offline builders could select and lock their own utxos.
+	// If [source] implements secp256k1fx.AliasGetter, it's used to resolve multisig aliases.
+	// If [source] implements deposit and deposit offer getters, they are used to skip
+	// deposited utxos, that can't be bonded.
+	LockAssetsFrom(
+		source avax.UTXOReader,
+		keys []*crypto.PrivateKeySECP256K1R,
+		amountsToLock map[ids.ID]uint64,
+		amountToBurn uint64,
+		appliedLockState locked.State,
+		to *secp256k1fx.OutputOwners,
+		change *secp256k1fx.OutputOwners,
+		maxInputs int,
 		asOf uint64,
+		minChangeAmount uint64,
 	) (
 		[]*avax.TransferableInput, // inputs
 		[]*avax.TransferableOutput, // outputs
@@ -111,11 +189,12 @@ type CaminoSpender interface {
 		error,
 	)
 
-	// Undeposit all deposited by [depositTxIDs] utxos owned by [keys]. Returned results are unsorted.
+	// Undeposit utxos deposited by [amountsToUnlock] deposits and owned by [keys]. Returned results are unsorted.
 	// Arguments:
 	// - [state] chainstate which will be used to fetch utxos and deposit data
 	// - [keys] are the owners of the deposits
-	// - [depositTxIDs] ids of deposit transactions
+	// - [amountsToUnlock] map[depositTxID]amountToUnlock, zero amount means
+	//   that all currently unlockable amount of this deposit will be unlocked
 	// Returns:
 	// - [inputs] unsorted inputs that should be consumed to fund the outputs
 	// - [outputs] unsorted outputs that should be returned to the UTXO set
@@ -123,7 +202,60 @@ type CaminoSpender interface {
 	UnlockDeposit(
 		state state.Chain,
 		keys []*crypto.PrivateKeySECP256K1R,
-		depositTxIDs []ids.ID,
+		amountsToUnlock map[ids.ID]uint64,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // outputs
+		[][]*crypto.PrivateKeySECP256K1R, // signers
+		error,
+	)
+
+	// Relock spends utxos locked by [lockTxIDs] with [removedLockState] and owned by [keys],
+	// producing outputs with [removedLockState] replaced by [appliedLockState],
+	// so funds don't pass through unlocked state. Both lock states must be either
+	// Bonded or Deposited. Other lock states of outputs are preserved.
+	// Arguments:
+	// - [state] chainstate which will be used to fetch locked utxos
+	// - [keys] are the owners of the locked funds
+	// - [lockTxIDs] is array of lock transaction ids
+	// - [removedLockState] is lock state that will be removed from outputs
+	// - [appliedLockState] is lock state that will be applied to outputs
+	// Returns:
+	// - [inputs] the inputs that should be consumed to fund the outputs
+	// - [outputs] the outputs that should be returned to the UTXO set
+	// - [signers] the proof of ownership of the funds being moved
+	Relock(
+		state state.Chain,
+		keys []*crypto.PrivateKeySECP256K1R,
+		lockTxIDs []ids.ID,
+		removedLockState locked.State,
+		appliedLockState locked.State,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // outputs
+		[][]*crypto.PrivateKeySECP256K1R, // signers
+		error,
+	)
+
+	// Consolidate spends unlocked utxos of a single owner, producing at most [targetCount] outputs
+	// with the same owner. Consolidated owner is the one of [keys] owners, who has most utxos.
+	// Locked utxos are never consolidated. Smaller utxos are consolidated first.
+	// Arguments:
+	// - [keys] are the owners of the funds
+	// - [targetCount] is the max number of produced outputs
+	// - [maxInputs] is the max number of consumed inputs, zero means unlimited
+	// - [totalAmountToBurn] is the amount of AVAX that should be burned from consolidated funds
+	// - [asOf] timestamp against LockTime is compared
+	// Returns:
+	// - [inputs] the inputs that should be consumed to fund the outputs
+	// - [outputs] the outputs that should be returned to the UTXO set
+	// - [signers] the proof of ownership of the funds being moved
+	Consolidate(
+		keys []*crypto.PrivateKeySECP256K1R,
+		targetCount int,
+		maxInputs int,
+		totalAmountToBurn uint64,
+		asOf uint64,
 	) (
 		[]*avax.TransferableInput, // inputs
 		[]*avax.TransferableOutput, // outputs
@@ -142,6 +274,7 @@ type CaminoVerifier interface {
 	// - [ins] must have at least [burnedAmount] more than the [outs].
 	// - [assetID] is id of allowed asset, ins/outs with other assets will return error
 	// - [appliedLockState] are lockState that was applied to [ins] lockState to produce [outs]
+	// - [outs] can't be owned by retired multisig aliases, if [utxoDB] is ShortIDLinkGetter
 	//
 	// Precondition: [tx] has already been syntactically verified.
 	VerifyLock(
@@ -200,6 +333,35 @@ type Unlocker interface {
 		[]*avax.TransferableOutput, // outputs
 		error,
 	)
+
+	// UnlockChunks is the same as Unlock, but splits unlocking into chunks,
+	// so ins and outs of each chunk could be put into separate tx.
+	// Lock txs are never split between chunks and are added to chunks in ascending
+	// order of their ids, so result is deterministic.
+	// Arguments:
+	// - [state] are the state from which lock txs and locked utxos will be fetched.
+	// - [lockTxIDs] is array of lock transaction ids.
+	// - [removedLockState] is lock state that will be removed from result [outputs].
+	// - [maxOuts] is the max number of chunk outputs, zero means unlimited.
+	// - [maxSize] is the max size in bytes of chunk inputs and outputs, zero means unlimited.
+	//   Lock tx, that exceeds limits by itself, gets its own chunk.
+	// Returns:
+	// - [chunks] that unlock all [lockTxIDs], there is at least one chunk,
+	//   if [lockTxIDs] isn't empty
+	UnlockChunks(
+		state state.Chain,
+		lockTxIDs []ids.ID,
+		removedLockState locked.State,
+		maxOuts int,
+		maxSize int,
+	) ([]*UnlockChunk, error)
+}
+
+// UnlockChunk is a part of unlock, that unlocks utxos locked by [LockTxIDs]
+type UnlockChunk struct {
+	LockTxIDs []ids.ID
+	Ins       []*avax.TransferableInput
+	Outs      []*avax.TransferableOutput
 }
 
 func (h *handler) Lock(
@@ -209,7 +371,71 @@ func (h *handler) Lock(
 	appliedLockState locked.State,
 	to *secp256k1fx.OutputOwners,
 	change *secp256k1fx.OutputOwners,
+	maxInputs int,
+	asOf uint64,
+	minChangeAmount uint64,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // outputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	[]*secp256k1fx.OutputOwners, // owners
+	error,
+) {
+	return h.LockAssets(
+		keys,
+		map[ids.ID]uint64{h.ctx.AVAXAssetID: totalAmountToLock},
+		totalAmountToBurn,
+		appliedLockState,
+		to,
+		change,
+		maxInputs,
+		asOf,
+		minChangeAmount,
+	)
+}
+
+func (h *handler) LockAssets(
+	keys []*crypto.PrivateKeySECP256K1R,
+	amountsToLock map[ids.ID]uint64,
+	amountToBurn uint64,
+	appliedLockState locked.State,
+	to *secp256k1fx.OutputOwners,
+	change *secp256k1fx.OutputOwners,
+	maxInputs int,
 	asOf uint64,
+	minChangeAmount uint64,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // outputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	[]*secp256k1fx.OutputOwners, // owners
+	error,
+) {
+	return h.LockAssetsFrom(
+		h.utxosReader,
+		keys,
+		amountsToLock,
+		amountToBurn,
+		appliedLockState,
+		to,
+		change,
+		maxInputs,
+		asOf,
+		minChangeAmount,
+	)
+}
+
+func (h *handler) LockAssetsFrom(
+	source avax.UTXOReader,
+	keys []*crypto.PrivateKeySECP256K1R,
+	amountsToLock map[ids.ID]uint64,
+	amountToBurn uint64,
+	appliedLockState locked.State,
+	to *secp256k1fx.OutputOwners,
+	change *secp256k1fx.OutputOwners,
+	maxInputs int,
+	asOf uint64,
+	minChangeAmount uint64,
 ) (
 	[]*avax.TransferableInput, // inputs
 	[]*avax.TransferableOutput, // outputs
@@ -220,22 +446,42 @@ func (h *handler) Lock(
 	switch appliedLockState {
 	case locked.StateBonded,
 		locked.StateDeposited,
+		locked.StateEscrowed,
 		locked.StateUnlocked:
 	default:
 		return nil, nil, nil, nil, errInvalidTargetLockState
 	}
 
+	assetIDs := make([]ids.ID, 0, len(amountsToLock)+1)
+	for assetID, amount := range amountsToLock {
+		if assetID != h.ctx.AVAXAssetID {
+			if amount == 0 {
+				continue
+			}
+			if appliedLockState != locked.StateUnlocked {
+				return nil, nil, nil, nil, fmt.Errorf("%w: %s", errLockingNotFeeAsset, assetID)
+			}
+		}
+		assetIDs = append(assetIDs, assetID)
+	}
+	if _, ok := amountsToLock[h.ctx.AVAXAssetID]; !ok && amountToBurn > 0 {
+		assetIDs = append(assetIDs, h.ctx.AVAXAssetID)
+	}
+	utils.Sort(assetIDs)
+
 	addrs, signer := secp256k1fx.ExtractFromAndSigners(keys)
 
-	utxos, err := avax.GetAllUTXOs(h.utxosReader, addrs) // The UTXOs controlled by [keys]
+	utxos, err := avax.GetAllUTXOs(source, addrs) // The UTXOs controlled by [keys]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
 
-	sortUTXOs(utxos, h.ctx.AVAXAssetID, appliedLockState)
-
 	kc := secp256k1fx.NewKeychain(signer...) // Keychain consumes UTXOs and creates new ones
 
+	if minChangeAmount == 0 {
+		minChangeAmount = h.minChangeAmount
+	}
+
 	// Minimum time this transaction will be issued at
 	now := asOf
 	if now == 0 {
@@ -246,12 +492,7 @@ func (h *handler) Lock(
 	outs := []*avax.TransferableOutput{}
 	signers := [][]*crypto.PrivateKeySECP256K1R{}
 	owners := []*secp256k1fx.OutputOwners{}
-
-	// Amount of AVAX that has been locked
-	totalAmountLocked := uint64(0)
-
-	// Amount of AVAX that has been burned
-	totalAmountBurned := uint64(0)
+	numChangeOuts, numDustOuts := 0, 0
 
 	type lockedAndRemainedAmounts struct {
 		locked   uint64
@@ -265,10 +506,6 @@ func (h *handler) Lock(
 		amounts map[ids.ID]lockedAndRemainedAmounts
 		owners  secp256k1fx.OutputOwners
 	}
-	// Track the amount of transfers and their owners
-	// if appliedLockState == bond, then otherLockTxID is depositTxID and vice versa
-	// ownerID -> otherLockTxID -> AAAA
-	insAmounts := make(map[ids.ID]OwnerAmounts)
 
 	var toOwnerID *ids.ID
 	if to != nil && appliedLockState == locked.StateUnlocked {
@@ -288,209 +525,256 @@ func (h *handler) Lock(
 		changeOwnerID = &id
 	}
 
-	for _, utxo := range utxos {
-		// If we have consumed more AVAX than we are trying to lock,
-		// and we have burned more AVAX than we need to,
-		// then we have no need to consume more AVAX
-		if totalAmountBurned >= totalAmountToBurn && totalAmountLocked >= totalAmountToLock {
-			break
-		}
-
-		// We only care about locking AVAX,
-		// and because utxos are sorted we can skip other utxos
-		if assetID := utxo.AssetID(); assetID != h.ctx.AVAXAssetID {
-			break
-		}
-
-		out := utxo.Out
-		lockIDs := locked.IDsEmpty
-		if lockedOut, ok := out.(*locked.Out); ok {
-			// Resolves to true for StateUnlocked
-			if lockedOut.IsLockedWith(appliedLockState) {
-				// This output can't be locked with target lockState,
-				// and because utxos are sorted we can skip other utxos
-				break
-			}
-			out = lockedOut.TransferableOut
-			lockIDs = lockedOut.IDs
-		}
-
-		innerOut, ok := out.(*secp256k1fx.TransferOutput)
-		if !ok {
-			// We only know how to clone secp256k1 outputs for now
-			continue
-		}
-
-		outOwnerID, err := txs.GetOutputOwnerID(out)
-		if err != nil {
-			// We couldn't get owner of this output, so move on to the next one
-			continue
+	for _, assetID := range assetIDs {
+		totalAmountToLock := amountsToLock[assetID]
+		totalAmountToBurn := uint64(0)
+		if assetID == h.ctx.AVAXAssetID {
+			totalAmountToBurn = amountToBurn
 		}
 
-		inIntf, inSigners, err := kc.SpendMultiSig(innerOut, now, h.utxosReader)
-		if err != nil {
-			// We couldn't spend the output, so move on to the next one
-			continue
-		}
-		in, ok := inIntf.(avax.TransferableIn)
-		if !ok { // should never happen
-			h.ctx.Log.Warn("wrong input type",
-				zap.String("expectedType", "avax.TransferableIn"),
-				zap.String("actualType", fmt.Sprintf("%T", inIntf)),
-			)
-			continue
-		}
+		// Amount of asset that has been locked
+		totalAmountLocked := uint64(0)
 
-		remainingValue := in.Amount()
+		// Amount of asset that has been burned
+		totalAmountBurned := uint64(0)
 
-		lockedOwnerID := OwnerID{&innerOut.OutputOwners, &outOwnerID}
-		remainingOwnerID := lockedOwnerID
+		// Track the amount of transfers and their owners
+		// if appliedLockState == bond, then otherLockTxID is depositTxID and vice versa
+		// ownerID -> otherLockTxID -> AAAA
+		insAmounts := make(map[ids.ID]OwnerAmounts)
 
-		if !lockIDs.IsLocked() {
-			// Burn any value that should be burned
-			amountToBurn := math.Min(
-				totalAmountToBurn-totalAmountBurned, // Amount we still need to burn
-				remainingValue,                      // Amount available to burn
-			)
-			totalAmountBurned += amountToBurn
-			remainingValue -= amountToBurn
+		sortUTXOs(utxos, assetID, appliedLockState)
 
-			if toOwnerID != nil {
-				lockedOwnerID = OwnerID{to, toOwnerID}
+		for _, utxo := range utxos {
+			// If we have consumed more of asset than we are trying to lock,
+			// and we have burned more AVAX than we need to,
+			// then we have no need to consume more of asset
+			if totalAmountBurned >= totalAmountToBurn && totalAmountLocked >= totalAmountToLock {
+				break
 			}
-			if changeOwnerID != nil && !h.isMultisigTransferOutput(innerOut) {
-				remainingOwnerID = OwnerID{change, changeOwnerID}
+
+			// We only care about locking current asset,
+			// and because utxos are sorted we can skip other utxos
+			if utxo.AssetID() != assetID {
+				break
 			}
-		}
 
-		// Lock any value that should be locked
-		amountToLock := math.Min(
-			totalAmountToLock-totalAmountLocked, // Amount we still need to lock
-			remainingValue,                      // Amount available to lock
-		)
-		totalAmountLocked += amountToLock
-		remainingValue -= amountToLock
-
-		if amountToLock > 0 || totalAmountToBurn > 0 {
-			if lockIDs.IsLocked() {
-				in = &locked.In{
-					IDs:            lockIDs,
-					TransferableIn: in,
+			// Expired stakeable locked outs are spent as unlocked
+			out := spendableOut(utxo.Out, now)
+			lockIDs := locked.IDsEmpty
+			if lockedOut, ok := locked.AsOut(out); ok {
+				// Resolves to true for StateUnlocked.
+				// Escrow can't be combined with other locks.
+				if lockedOut.IsLockedWith(appliedLockState) || appliedLockState == locked.StateEscrowed {
+					// This output can't be locked with target lockState,
+					// and because utxos are sorted we can skip other utxos
+					break
 				}
+				out = lockedOut.TransferableOut
+				lockIDs = lockedOut.IDs
 			}
 
-			ins = append(ins, &avax.TransferableInput{
-				UTXOID: utxo.UTXOID,
-				Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
-				In:     in,
-			})
-			signers = append(signers, inSigners)
-			owners = append(owners, &innerOut.OutputOwners)
-
-			otherLockTxID := lockIDs.DepositTxID
-			if appliedLockState == locked.StateDeposited {
-				otherLockTxID = lockIDs.BondTxID
+			if appliedLockState == locked.StateBonded && lockIDs.DepositTxID != ids.Empty &&
+				!isDepositBondable(source, lockIDs.DepositTxID) {
+				// This output is deposited with offer, that doesn't allow bonding
+				continue
 			}
 
-			ownerAmounts, ok := insAmounts[*lockedOwnerID.ownersID]
+			innerOut, ok := out.(*secp256k1fx.TransferOutput)
 			if !ok {
-				ownerAmounts = OwnerAmounts{
-					amounts: make(map[ids.ID]lockedAndRemainedAmounts),
-					owners:  *lockedOwnerID.owners,
-				}
+				// We only know how to clone secp256k1 outputs for now
+				continue
 			}
 
-			amounts := ownerAmounts.amounts[otherLockTxID]
-			newAmount, err := math.Add64(amounts.locked, amountToLock)
+			outOwnerID, err := txs.GetOutputOwnerID(out)
 			if err != nil {
-				return nil, nil, nil, nil, err
+				// We couldn't get owner of this output, so move on to the next one
+				continue
 			}
 
-			amounts.locked = newAmount
-			ownerAmounts.amounts[otherLockTxID] = amounts
-			if !ok {
-				insAmounts[*lockedOwnerID.ownersID] = ownerAmounts
+			inIntf, inSigners, err := kc.SpendMultiSig(innerOut, now, source)
+			if err != nil {
+				// We couldn't spend the output, so move on to the next one
+				continue
+			}
+			in, ok := inIntf.(avax.TransferableIn)
+			if !ok { // should never happen
+				h.ctx.Log.Warn("wrong input type",
+					zap.String("expectedType", "avax.TransferableIn"),
+					zap.String("actualType", fmt.Sprintf("%T", inIntf)),
+				)
+				continue
 			}
 
-			ownerAmounts, ok = insAmounts[*remainingOwnerID.ownersID]
-			if !ok {
-				ownerAmounts = OwnerAmounts{
-					amounts: make(map[ids.ID]lockedAndRemainedAmounts),
-					owners:  *remainingOwnerID.owners,
+			remainingValue := in.Amount()
+
+			lockedOwnerID := OwnerID{&innerOut.OutputOwners, &outOwnerID}
+			remainingOwnerID := lockedOwnerID
+
+			if !lockIDs.IsLocked() {
+				// Burn any value that should be burned
+				amountToBurn := math.Min(
+					totalAmountToBurn-totalAmountBurned, // Amount we still need to burn
+					remainingValue,                      // Amount available to burn
+				)
+				totalAmountBurned += amountToBurn
+				remainingValue -= amountToBurn
+
+				if toOwnerID != nil {
+					lockedOwnerID = OwnerID{to, toOwnerID}
+				}
+				if changeOwnerID != nil && !h.isMultisigTransferOutput(innerOut) {
+					remainingOwnerID = OwnerID{change, changeOwnerID}
 				}
 			}
 
-			amounts = ownerAmounts.amounts[otherLockTxID]
-			newAmount, err = math.Add64(amounts.remained, remainingValue)
-			if err != nil {
-				return nil, nil, nil, nil, err
-			}
-			amounts.remained = newAmount
+			// Lock any value that should be locked
+			amountToLock := math.Min(
+				totalAmountToLock-totalAmountLocked, // Amount we still need to lock
+				remainingValue,                      // Amount available to lock
+			)
+			totalAmountLocked += amountToLock
+			remainingValue -= amountToLock
 
-			ownerAmounts.amounts[otherLockTxID] = amounts
-			if !ok {
-				insAmounts[*remainingOwnerID.ownersID] = ownerAmounts
+			if amountToLock > 0 || totalAmountToBurn > 0 {
+				if maxInputs > 0 && len(ins) >= maxInputs {
+					return nil, nil, nil, nil, fmt.Errorf("%w: more than %d inputs required", ErrNeedsConsolidation, maxInputs)
+				}
+
+				if lockIDs.IsLocked() {
+					in = &locked.In{
+						IDs:            lockIDs,
+						TransferableIn: in,
+					}
+				}
+
+				ins = append(ins, &avax.TransferableInput{
+					UTXOID: utxo.UTXOID,
+					Asset:  avax.Asset{ID: assetID},
+					In:     in,
+				})
+				signers = append(signers, inSigners)
+				owners = append(owners, &innerOut.OutputOwners)
+
+				otherLockTxID := lockIDs.DepositTxID
+				if appliedLockState == locked.StateDeposited {
+					otherLockTxID = lockIDs.BondTxID
+				}
+
+				ownerAmounts, ok := insAmounts[*lockedOwnerID.ownersID]
+				if !ok {
+					ownerAmounts = OwnerAmounts{
+						amounts: make(map[ids.ID]lockedAndRemainedAmounts),
+						owners:  *lockedOwnerID.owners,
+					}
+				}
+
+				amounts := ownerAmounts.amounts[otherLockTxID]
+				newAmount, err := math.Add64(amounts.locked, amountToLock)
+				if err != nil {
+					return nil, nil, nil, nil, err
+				}
+
+				amounts.locked = newAmount
+				ownerAmounts.amounts[otherLockTxID] = amounts
+				if !ok {
+					insAmounts[*lockedOwnerID.ownersID] = ownerAmounts
+				}
+
+				ownerAmounts, ok = insAmounts[*remainingOwnerID.ownersID]
+				if !ok {
+					ownerAmounts = OwnerAmounts{
+						amounts: make(map[ids.ID]lockedAndRemainedAmounts),
+						owners:  *remainingOwnerID.owners,
+					}
+				}
+
+				amounts = ownerAmounts.amounts[otherLockTxID]
+				newAmount, err = math.Add64(amounts.remained, remainingValue)
+				if err != nil {
+					return nil, nil, nil, nil, err
+				}
+				amounts.remained = newAmount
+
+				ownerAmounts.amounts[otherLockTxID] = amounts
+				if !ok {
+					insAmounts[*remainingOwnerID.ownersID] = ownerAmounts
+				}
 			}
 		}
-	}
 
-	for _, ownerAmounts := range insAmounts {
-		addOut := func(amt uint64, lockIDs locked.IDs, collect bool) uint64 {
-			if amt == 0 {
-				return 0
-			}
-			if lockIDs.IsLocked() {
-				outs = append(outs, &avax.TransferableOutput{
-					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-					Out: &locked.Out{
-						IDs: lockIDs,
-						TransferableOut: &secp256k1fx.TransferOutput{
+		for ownerID, ownerAmounts := range insAmounts {
+			// Unlocked AVAX outs of owners other than [to] are change
+			isChangeOwner := assetID == h.ctx.AVAXAssetID &&
+				(toOwnerID == nil || ownerID != *toOwnerID)
+
+			addOut := func(amt uint64, lockIDs locked.IDs, collect bool) uint64 {
+				if amt == 0 {
+					return 0
+				}
+				if lockIDs.IsLocked() {
+					outs = append(outs, &avax.TransferableOutput{
+						Asset: avax.Asset{ID: assetID},
+						Out: locked.NewOut(lockIDs, &secp256k1fx.TransferOutput{
+							Amt:          amt,
+							OutputOwners: ownerAmounts.owners,
+						}),
+					})
+				} else {
+					if collect {
+						return amt
+					}
+					outs = append(outs, &avax.TransferableOutput{
+						Asset: avax.Asset{ID: assetID},
+						Out: &secp256k1fx.TransferOutput{
 							Amt:          amt,
 							OutputOwners: ownerAmounts.owners,
 						},
-					},
-				})
-			} else {
-				if collect {
-					return amt
+					})
 				}
-				outs = append(outs, &avax.TransferableOutput{
-					Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-					Out: &secp256k1fx.TransferOutput{
-						Amt:          amt,
-						OutputOwners: ownerAmounts.owners,
-					},
-				})
+				return 0
 			}
-			return 0
-		}
 
-		for otherLockTxID, amounts := range ownerAmounts.amounts {
-			lockIDs := locked.IDs{}
-			switch appliedLockState {
-			case locked.StateBonded:
-				lockIDs.DepositTxID = otherLockTxID
-			case locked.StateDeposited:
-				lockIDs.BondTxID = otherLockTxID
-			}
+			for otherLockTxID, amounts := range ownerAmounts.amounts {
+				lockIDs := locked.IDs{}
+				switch appliedLockState {
+				case locked.StateBonded:
+					lockIDs.DepositTxID = otherLockTxID
+				case locked.StateDeposited:
+					lockIDs.BondTxID = otherLockTxID
+				}
 
-			// If out is unlocked no UTXO is written instead the amount is returned.
-			// We apply the unlocked amount in the remaining step to compact UTXOs
-			unlockAmount := addOut(amounts.locked, lockIDs.Lock(appliedLockState), true)
-			if unlockAmount, err = math.Add64(unlockAmount, amounts.remained); err != nil {
-				return nil, nil, nil, nil, err
+				// If out is unlocked no UTXO is written instead the amount is returned.
+				// We apply the unlocked amount in the remaining step to compact UTXOs
+				unlockAmount := addOut(amounts.locked, lockIDs.Lock(appliedLockState), true)
+				isChange := isChangeOwner && unlockAmount == 0 && !lockIDs.IsLocked()
+				if unlockAmount, err = math.Add64(unlockAmount, amounts.remained); err != nil {
+					return nil, nil, nil, nil, err
+				}
+				if isChange && unlockAmount < minChangeAmount {
+					// Dust change is burned together with fee
+					if unlockAmount > 0 {
+						numDustOuts++
+					}
+					continue
+				}
+				if isChange && unlockAmount > 0 {
+					numChangeOuts++
+				}
+				addOut(unlockAmount, lockIDs, false)
 			}
-			addOut(unlockAmount, lockIDs, false)
 		}
-	}
 
-	if totalAmountBurned < totalAmountToBurn || totalAmountLocked < totalAmountToLock {
-		return nil, nil, nil, nil, errInsufficientBalance
+		if totalAmountBurned < totalAmountToBurn || totalAmountLocked < totalAmountToLock {
+			return nil, nil, nil, nil, errInsufficientBalance
+		}
 	}
 
 	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
 	avax.SortTransferableOutputs(outs, txs.Codec)        // sort outputs
 
+	h.metrics.observeLock(len(ins), numChangeOuts, numDustOuts)
+
 	return ins, outs, signers, owners, nil
 }
 
@@ -503,7 +787,11 @@ func (h *handler) Unlock(
 	[]*avax.TransferableOutput, // outputs
 	error,
 ) {
-	if removedLockState != locked.StateBonded && removedLockState != locked.StateDeposited {
+	switch removedLockState {
+	case locked.StateBonded,
+		locked.StateDeposited,
+		locked.StateEscrowed:
+	default:
 		return nil, nil, errInvalidTargetLockState
 	}
 
@@ -524,12 +812,12 @@ func (h *handler) Unlock(
 
 		outputs := tx.Unsigned.Outputs()
 		for i, output := range outputs {
-			lockedOut, ok := output.Out.(*locked.Out)
-			if !ok || !lockedOut.IsNewlyLockedWith(removedLockState) {
+			lockIDs, lockedOut, ok := locked.OutIDs(output.Out)
+			if !ok || !lockIDs.IsNewlyLockedWith(removedLockState) {
 				// we'r only intersed in outs locked by this tx
 				continue
 			}
-			innerOut, ok := lockedOut.TransferableOut.(*secp256k1fx.TransferOutput)
+			innerOut, ok := lockedOut.(*secp256k1fx.TransferOutput)
 			if !ok {
 				return nil, nil, fmt.Errorf("could not cast locked out no. %d to transerfableOut from tx %s", i, lockTxID)
 			}
@@ -545,6 +833,77 @@ func (h *handler) Unlock(
 	return h.unlockUTXOs(utxos, removedLockState)
 }
 
+func (h *handler) UnlockChunks(
+	state state.Chain,
+	lockTxIDs []ids.ID,
+	removedLockState locked.State,
+	maxOuts int,
+	maxSize int,
+) ([]*UnlockChunk, error) {
+	sortedLockTxIDs := set.NewSet[ids.ID](len(lockTxIDs))
+	sortedLockTxIDs.Add(lockTxIDs...)
+	lockTxIDs = sortedLockTxIDs.List()
+	utils.Sort(lockTxIDs)
+
+	chunks := []*UnlockChunk{}
+	chunk := newUnlockChunk()
+	chunkSize := 0
+	for _, lockTxID := range lockTxIDs {
+		ins, outs, err := h.Unlock(state, []ids.ID{lockTxID}, removedLockState)
+		if err != nil {
+			return nil, err
+		}
+
+		size := 0
+		if maxSize > 0 {
+			for _, in := range ins {
+				inBytes, err := txs.Codec.Marshal(txs.Version, in)
+				if err != nil {
+					return nil, err
+				}
+				size += len(inBytes)
+			}
+			for _, out := range outs {
+				outBytes, err := txs.Codec.Marshal(txs.Version, out)
+				if err != nil {
+					return nil, err
+				}
+				size += len(outBytes)
+			}
+		}
+
+		if len(chunk.LockTxIDs) > 0 &&
+			(maxOuts > 0 && len(chunk.Outs)+len(outs) > maxOuts ||
+				maxSize > 0 && chunkSize+size > maxSize) {
+			chunks = append(chunks, chunk)
+			chunk = newUnlockChunk()
+			chunkSize = 0
+		}
+
+		chunk.LockTxIDs = append(chunk.LockTxIDs, lockTxID)
+		chunk.Ins = append(chunk.Ins, ins...)
+		chunk.Outs = append(chunk.Outs, outs...)
+		chunkSize += size
+	}
+	if len(chunk.LockTxIDs) > 0 {
+		chunks = append(chunks, chunk)
+	}
+
+	for _, chunk := range chunks {
+		avax.SortTransferableInputs(chunk.Ins)              // sort inputs
+		avax.SortTransferableOutputs(chunk.Outs, txs.Codec) // sort outputs
+	}
+
+	return chunks, nil
+}
+
+func newUnlockChunk() *UnlockChunk {
+	return &UnlockChunk{
+		Ins:  []*avax.TransferableInput{},
+		Outs: []*avax.TransferableOutput{},
+	}
+}
+
 // utxos that are not locked with [removedLockState] will be ignored
 func (h *handler) unlockUTXOs(
 	utxos []*avax.UTXO,
@@ -554,7 +913,11 @@ func (h *handler) unlockUTXOs(
 	[]*avax.TransferableOutput, // outputs
 	error,
 ) {
-	if removedLockState != locked.StateBonded && removedLockState != locked.StateDeposited {
+	switch removedLockState {
+	case locked.StateBonded,
+		locked.StateDeposited,
+		locked.StateEscrowed:
+	default:
 		return nil, nil, errInvalidTargetLockState
 	}
 
@@ -562,66 +925,302 @@ func (h *handler) unlockUTXOs(
 	outs := []*avax.TransferableOutput{}
 
 	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*locked.Out)
+		lockIDs, out, ok := locked.OutIDs(utxo.Out)
 		if !ok {
 			// This output isn't locked
 			return nil, nil, errNotLockedUTXO
-		} else if !out.IsLockedWith(removedLockState) {
+		} else if !lockIDs.IsLockedWith(removedLockState) {
 			// This output doesn't have required lockState
 			return nil, nil, errNotLockedUTXO
 		}
 
-		innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+		innerOut, ok := out.(*secp256k1fx.TransferOutput)
 		if !ok {
 			// We only know how to clone secp256k1 outputs for now
 			return nil, nil, errWrongOutType
 		}
 
-		// Add the input to the consumed inputs
-		ins = append(ins, &avax.TransferableInput{
-			UTXOID: utxo.UTXOID,
-			Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
-			In: &locked.In{
-				IDs: out.IDs,
-				TransferableIn: &secp256k1fx.TransferInput{
-					Amt:   out.Amount(),
-					Input: secp256k1fx.Input{},
-				},
+		// Add the input to the consumed inputs
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
+			In: locked.NewIn(lockIDs, &secp256k1fx.TransferInput{
+				Amt:   out.Amount(),
+				Input: secp256k1fx.Input{},
+			}),
+		})
+
+		if newLockIDs := lockIDs.Unlock(removedLockState); newLockIDs.IsLocked() {
+			outs = append(outs, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: locked.WithLabel(locked.NewOut(newLockIDs, &secp256k1fx.TransferOutput{
+					Amt:          innerOut.Amount(),
+					OutputOwners: innerOut.OutputOwners,
+				}), locked.OutLabel(utxo.Out)),
+			})
+		} else {
+			outs = append(outs, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          innerOut.Amount(),
+					OutputOwners: innerOut.OutputOwners,
+				},
+			})
+		}
+	}
+
+	avax.SortTransferableInputs(ins)              // sort inputs
+	avax.SortTransferableOutputs(outs, txs.Codec) // sort outputs
+
+	return ins, outs, nil
+}
+
+func (h *handler) Relock(
+	state state.Chain,
+	keys []*crypto.PrivateKeySECP256K1R,
+	lockTxIDs []ids.ID,
+	removedLockState locked.State,
+	appliedLockState locked.State,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // outputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	for _, lockState := range []locked.State{removedLockState, appliedLockState} {
+		if lockState != locked.StateBonded && lockState != locked.StateDeposited {
+			return nil, nil, nil, errInvalidTargetLockState
+		}
+	}
+
+	addrs := set.NewSet[ids.ShortID](len(keys)) // The addresses controlled by [keys]
+	for _, key := range keys {
+		addrs.Add(key.PublicKey().Address())
+	}
+
+	lockTxIDsSet := set.NewSet[ids.ID](len(lockTxIDs))
+	lockTxIDsSet.Add(lockTxIDs...)
+
+	utxos, err := state.LockedUTXOs(lockTxIDsSet, addrs, removedLockState)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Sorting utxos, so produced ins and outs are deterministic
+	sort.Slice(utxos, func(i, j int) bool {
+		utxoIDi := utxos[i].InputID()
+		utxoIDj := utxos[j].InputID()
+		return bytes.Compare(utxoIDi[:], utxoIDj[:]) < 0
+	})
+
+	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
+
+	// Minimum time this transaction will be issued at
+	currentTimestamp := uint64(h.clk.Time().Unix())
+
+	ins := []*avax.TransferableInput{}
+	outs := []*avax.TransferableOutput{}
+	signers := [][]*crypto.PrivateKeySECP256K1R{}
+
+	for _, utxo := range utxos {
+		out, ok := locked.AsOut(utxo.Out)
+		if !ok || !out.IDs.Match(removedLockState, lockTxIDsSet) {
+			// This output isn't locked by one of given lock tx ids
+			continue
+		}
+
+		newLockIDs := out.IDs.Unlock(removedLockState)
+		if newLockIDs.IsLockedWith(appliedLockState) {
+			return nil, nil, nil, fmt.Errorf("%w: utxo %s", errRelockingLockedUTXO, utxo.InputID())
+		}
+
+		innerOut, ok := out.TransferableOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			// We only know how to clone secp256k1 outputs for now
+			continue
+		}
+
+		inIntf, inSigners, err := kc.SpendMultiSig(innerOut, currentTimestamp, h.utxosReader)
+		if err != nil {
+			// We couldn't spend the output, so move on to the next one
+			continue
+		}
+
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok { // should never happen
+			h.ctx.Log.Warn("wrong input type",
+				zap.String("expectedType", "avax.TransferableIn"),
+				zap.String("actualType", fmt.Sprintf("%T", inIntf)),
+			)
+			continue
+		}
+
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
+			In: &locked.In{
+				IDs:            out.IDs,
+				TransferableIn: in,
+			},
+		})
+		signers = append(signers, inSigners)
+
+		outs = append(outs, &avax.TransferableOutput{
+			Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+			Out: locked.WithLabel(&locked.Out{
+				IDs: newLockIDs.Lock(appliedLockState),
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          in.Amount(),
+					OutputOwners: innerOut.OutputOwners,
+				},
+			}, locked.OutLabel(utxo.Out)),
+		})
+	}
+
+	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
+	avax.SortTransferableOutputs(outs, txs.Codec)        // sort outputs
+
+	return ins, outs, signers, nil
+}
+
+func (h *handler) Consolidate(
+	keys []*crypto.PrivateKeySECP256K1R,
+	targetCount int,
+	maxInputs int,
+	totalAmountToBurn uint64,
+	asOf uint64,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // outputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	if targetCount <= 0 {
+		return nil, nil, nil, errZeroTargetCount
+	}
+
+	addrs, signer := secp256k1fx.ExtractFromAndSigners(keys)
+
+	utxos, err := avax.GetAllUTXOs(h.utxosReader, addrs) // The UTXOs controlled by [keys]
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
+	}
+
+	kc := secp256k1fx.NewKeychain(signer...) // Keychain consumes UTXOs and creates new ones
+
+	// Minimum time this transaction will be issued at
+	now := asOf
+	if now == 0 {
+		now = uint64(h.clk.Time().Unix())
+	}
+
+	type ownerUTXOs struct {
+		ownerID ids.ID
+		owner   *secp256k1fx.OutputOwners
+		ins     []*avax.TransferableInput
+		signers [][]*crypto.PrivateKeySECP256K1R
+	}
+	owners := map[ids.ID]*ownerUTXOs{}
+
+	for _, utxo := range utxos {
+		if utxo.AssetID() != h.ctx.AVAXAssetID {
+			continue
+		}
+
+		// locked utxos are *locked.Out, so they will be skipped,
+		// expired stakeable locked outs are spent as unlocked
+		out, ok := spendableOut(utxo.Out, now).(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+
+		inIntf, inSigners, err := kc.SpendMultiSig(out, now, h.utxosReader)
+		if err != nil {
+			// We couldn't spend the output, so move on to the next one
+			continue
+		}
+		in, ok := inIntf.(avax.TransferableIn)
+		if !ok { // should never happen
+			continue
+		}
+
+		ownerID, err := txs.GetOwnerID(&out.OutputOwners)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		owner, ok := owners[ownerID]
+		if !ok {
+			owner = &ownerUTXOs{ownerID: ownerID, owner: &out.OutputOwners}
+			owners[ownerID] = owner
+		}
+		owner.ins = append(owner.ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: h.ctx.AVAXAssetID},
+			In:     in,
+		})
+		owner.signers = append(owner.signers, inSigners)
+	}
+
+	var consolidated *ownerUTXOs
+	for _, owner := range owners {
+		if consolidated == nil || len(owner.ins) > len(consolidated.ins) ||
+			len(owner.ins) == len(consolidated.ins) && bytes.Compare(owner.ownerID[:], consolidated.ownerID[:]) < 0 {
+			consolidated = owner
+		}
+	}
+	if consolidated == nil {
+		return nil, nil, nil, errInsufficientBalance
+	}
+
+	ins := consolidated.ins
+	signers := consolidated.signers
+	sort.Stable(&innerSortInputsByAmount{ins: ins, signers: signers})
+	if maxInputs > 0 && len(ins) > maxInputs {
+		ins = ins[:maxInputs]
+		signers = signers[:maxInputs]
+	}
+	if len(ins) <= targetCount {
+		return nil, nil, nil, errNothingToConsolidate
+	}
+
+	totalAmount := uint64(0)
+	for _, in := range ins {
+		totalAmount, err = math.Add64(totalAmount, in.In.Amount())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if totalAmount < totalAmountToBurn+uint64(targetCount) {
+		return nil, nil, nil, errInsufficientBalance
+	}
+
+	remainingAmount := totalAmount - totalAmountToBurn
+	outAmount := remainingAmount / uint64(targetCount)
+	outs := make([]*avax.TransferableOutput, targetCount)
+	for i := range outs {
+		amount := outAmount
+		if i == 0 {
+			amount += remainingAmount % uint64(targetCount)
+		}
+		outs[i] = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: *consolidated.owner,
 			},
-		})
-
-		if newLockIDs := out.Unlock(removedLockState); newLockIDs.IsLocked() {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-				Out: &locked.Out{
-					IDs: newLockIDs,
-					TransferableOut: &secp256k1fx.TransferOutput{
-						Amt:          innerOut.Amount(),
-						OutputOwners: innerOut.OutputOwners,
-					},
-				},
-			})
-		} else {
-			outs = append(outs, &avax.TransferableOutput{
-				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-				Out: &secp256k1fx.TransferOutput{
-					Amt:          innerOut.Amount(),
-					OutputOwners: innerOut.OutputOwners,
-				},
-			})
 		}
 	}
 
-	avax.SortTransferableInputs(ins)              // sort inputs
-	avax.SortTransferableOutputs(outs, txs.Codec) // sort outputs
+	avax.SortTransferableInputsWithSigners(ins, signers) // sort inputs and keys
+	avax.SortTransferableOutputs(outs, txs.Codec)        // sort outputs
 
-	return ins, outs, nil
+	return ins, outs, signers, nil
 }
 
 func (h *handler) UnlockDeposit(
 	state state.Chain,
 	keys []*crypto.PrivateKeySECP256K1R,
-	depositTxIDs []ids.ID,
+	amountsToUnlock map[ids.ID]uint64,
 ) (
 	[]*avax.TransferableInput, // inputs
 	[]*avax.TransferableOutput, // outputs
@@ -633,8 +1232,8 @@ func (h *handler) UnlockDeposit(
 		addrs.Add(key.PublicKey().Address())
 	}
 
-	depositTxSet := set.NewSet[ids.ID](len(depositTxIDs))
-	for _, depositTxID := range depositTxIDs {
+	depositTxSet := set.NewSet[ids.ID](len(amountsToUnlock))
+	for depositTxID := range amountsToUnlock {
 		depositTxSet.Add(depositTxID)
 	}
 
@@ -648,19 +1247,37 @@ func (h *handler) UnlockDeposit(
 		return nil, nil, nil, err
 	}
 
+	for depositTxID, amountToUnlock := range amountsToUnlock {
+		if amountToUnlock > unlockableAmounts[depositTxID] {
+			return nil, nil, nil, fmt.Errorf("%w: deposit %s, requested %d, unlockable %d",
+				errUnlockedMoreThanAvailable, depositTxID, amountToUnlock, unlockableAmounts[depositTxID])
+		}
+		if amountToUnlock > 0 {
+			unlockableAmounts[depositTxID] = amountToUnlock
+		}
+	}
+
 	utxos, err := state.LockedUTXOs(depositTxSet, addrs, locked.StateDeposited)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
+	// Sorting utxos, so produced ins and outs are deterministic
+	sort.Slice(utxos, func(i, j int) bool {
+		utxoIDi := utxos[i].InputID()
+		utxoIDj := utxos[j].InputID()
+		return bytes.Compare(utxoIDi[:], utxoIDj[:]) < 0
+	})
+
 	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
 
 	ins := []*avax.TransferableInput{}
 	outs := []*avax.TransferableOutput{}
 	signers := [][]*crypto.PrivateKeySECP256K1R{}
+	totalAmountUnlocked := uint64(0)
 
 	for _, utxo := range utxos {
-		out, ok := utxo.Out.(*locked.Out)
+		out, ok := locked.AsOut(utxo.Out)
 		if !ok {
 			// This output isn't locked
 			continue
@@ -712,17 +1329,18 @@ func (h *handler) UnlockDeposit(
 		amountToUnlock := math.Min(unlockableAmount, remainingValue)
 		remainingValue -= amountToUnlock
 		unlockableAmounts[out.DepositTxID] -= amountToUnlock
+		totalAmountUnlocked += amountToUnlock
 
 		if newLockIDs := out.Unlock(locked.StateDeposited); newLockIDs.IsLocked() {
 			outs = append(outs, &avax.TransferableOutput{
 				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-				Out: &locked.Out{
+				Out: locked.WithLabel(&locked.Out{
 					IDs: newLockIDs,
 					TransferableOut: &secp256k1fx.TransferOutput{
 						Amt:          amountToUnlock,
 						OutputOwners: innerOut.OutputOwners,
 					},
-				},
+				}, locked.OutLabel(utxo.Out)),
 			})
 		} else {
 			outs = append(outs, &avax.TransferableOutput{
@@ -738,17 +1356,26 @@ func (h *handler) UnlockDeposit(
 		if remainingValue > 0 {
 			outs = append(outs, &avax.TransferableOutput{
 				Asset: avax.Asset{ID: h.ctx.AVAXAssetID},
-				Out: &locked.Out{
+				Out: locked.WithLabel(&locked.Out{
 					IDs: out.IDs,
 					TransferableOut: &secp256k1fx.TransferOutput{
 						Amt:          remainingValue,
 						OutputOwners: innerOut.OutputOwners,
 					},
-				},
+				}, locked.OutLabel(utxo.Out)),
 			})
 		}
 	}
 
+	for depositTxID, amountToUnlock := range amountsToUnlock {
+		if amountToUnlock > 0 && unlockableAmounts[depositTxID] > 0 {
+			return nil, nil, nil, fmt.Errorf("%w: deposit %s, requested %d, missing %d",
+				errInsufficientBalance, depositTxID, amountToUnlock, unlockableAmounts[depositTxID])
+		}
+	}
+
+	h.metrics.observeUnlockDeposit(totalAmountUnlocked)
+
 	return ins, outs, signers, nil
 }
 
@@ -775,7 +1402,14 @@ func (h *handler) VerifyLock(
 		utxos[index] = utxo
 	}
 
-	return h.VerifyLockUTXOs(tx, utxos, ins, outs, creds, burnedAmount, assetID, appliedLockState)
+	recorder, _ := utxoDB.(secp256k1fx.AliasUsageRecorder)
+	chainTime := stakeableUnlockTime(utxoDB, utxos)
+	if err := h.verifyLockUTXOs(tx, recorder, chainTime, utxos, ins, outs, creds, burnedAmount, assetID, appliedLockState); err != nil {
+		return err
+	}
+
+	linkGetter, _ := utxoDB.(ShortIDLinkGetter)
+	return verifyNotRetiredOutsOwners(linkGetter, outs)
 }
 
 func (h *handler) VerifyLockUTXOs(
@@ -788,12 +1422,25 @@ func (h *handler) VerifyLockUTXOs(
 	assetID ids.ID,
 	appliedLockState locked.State,
 ) error {
-	if appliedLockState != locked.StateBonded &&
-		appliedLockState != locked.StateDeposited &&
-		appliedLockState != locked.StateUnlocked {
-		return errInvalidTargetLockState
-	}
+	// without chain state stakeable locked utxos are never treated as expired
+	return h.verifyLockUTXOs(tx, nil, 0, utxos, ins, outs, creds, burnedAmount, assetID, appliedLockState)
+}
 
+// verifyLockUTXOs is VerifyLockUTXOs, that reports multisig aliases used
+// by transfers verification to [recorder], if it isn't nil.
+// Stakeable locked utxos with locktime not after [chainTime] are spent as unlocked.
+func (h *handler) verifyLockUTXOs(
+	tx txs.UnsignedTx,
+	recorder secp256k1fx.AliasUsageRecorder,
+	chainTime uint64,
+	utxos []*avax.UTXO,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	creds []verify.Verifiable,
+	burnedAmount uint64,
+	assetID ids.ID,
+	appliedLockState locked.State,
+) error {
 	if len(ins) != len(creds) {
 		return fmt.Errorf(
 			"there are %d inputs and %d credentials: %w",
@@ -803,6 +1450,70 @@ func (h *handler) VerifyLockUTXOs(
 		)
 	}
 
+	for _, cred := range creds {
+		if err := cred.Verify(); err != nil {
+			return errWrongCredentials
+		}
+	}
+
+	utxos = unwrapExpiredStakeableUTXOs(utxos, chainTime)
+
+	if err := VerifyLockBalance(utxos, ins, outs, burnedAmount, assetID, appliedLockState); err != nil {
+		return err
+	}
+
+	transfers := make([]multisigTransfer, len(ins))
+	for index, input := range ins {
+		out := utxos[index].Out
+		if lockedOut, ok := locked.AsOut(out); ok {
+			out = lockedOut.TransferableOut
+		}
+		in := input.In
+		if lockedIn, ok := in.(*locked.In); ok {
+			in = lockedIn.TransferableIn
+		}
+		transfer, err := newMultisigTransfer(in, creds[index], out)
+		if err != nil {
+			return err
+		}
+		transfers[index] = transfer
+	}
+
+	if err := h.verifyMultisigTransfers(tx, recorder, transfers); err != nil {
+		return err
+	}
+
+	for _, output := range outs {
+		// unlocked tokens can be transferred and must be checked
+		if _, ok := locked.AsOut(output.Out); !ok {
+			if err := h.fx.VerifyMultisigOwner(output.Out, h.utxosReader); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// VerifyLockBalance verifies lock invariants of funds moved from [utxos], consumed by [ins], to [outs]:
+// ins must have the same lock IDs as consumed utxos, outs can only add [appliedLockState]
+// to the lock state of consumed funds of the same owner and at least [burnedAmount]
+// of unlocked funds must be burned. All ins, outs and utxos must have [assetID] asset.
+// It doesn't verify credentials or owners of produced outs.
+func VerifyLockBalance(
+	utxos []*avax.UTXO,
+	ins []*avax.TransferableInput,
+	outs []*avax.TransferableOutput,
+	burnedAmount uint64,
+	assetID ids.ID,
+	appliedLockState locked.State,
+) error {
+	if appliedLockState != locked.StateBonded &&
+		appliedLockState != locked.StateDeposited &&
+		appliedLockState != locked.StateUnlocked {
+		return errInvalidTargetLockState
+	}
+
 	if len(ins) != len(utxos) {
 		return fmt.Errorf(
 			"there are %d inputs and %d utxos: %w",
@@ -812,12 +1523,6 @@ func (h *handler) VerifyLockUTXOs(
 		)
 	}
 
-	for _, cred := range creds {
-		if err := cred.Verify(); err != nil {
-			return errWrongCredentials
-		}
-	}
-
 	// Track the amount of transfers and their owners
 	// if appliedLockState == bond, then otherLockTxID is depositTxID and vice versa
 	// ownerID -> otherLockTxID -> amount
@@ -852,7 +1557,7 @@ func (h *handler) VerifyLockUTXOs(
 		}
 
 		lockIDs := &locked.IDsEmpty
-		if lockedOut, ok := out.(*locked.Out); ok {
+		if lockedOut, ok := locked.AsOut(out); ok {
 			// can only spend unlocked utxos, if appliedLockState is unlocked
 			if appliedLockState == locked.StateUnlocked {
 				return errLockedUTXO
@@ -881,10 +1586,6 @@ func (h *handler) VerifyLockUTXOs(
 			return errLockedFundsNotMarkedAsLocked
 		}
 
-		if err := h.fx.VerifyMultisigTransfer(tx, in, creds[index], out, h.utxosReader); err != nil {
-			return fmt.Errorf("failed to verify transfer: %w", err)
-		}
-
 		otherLockTxID := &lockIDs.DepositTxID
 		if appliedLockState == locked.StateDeposited {
 			otherLockTxID = &lockIDs.BondTxID
@@ -930,14 +1631,9 @@ func (h *handler) VerifyLockUTXOs(
 		}
 
 		lockIDs := &locked.IDsEmpty
-		if lockedOut, ok := out.(*locked.Out); ok {
+		if lockedOut, ok := locked.AsOut(out); ok {
 			lockIDs = &lockedOut.IDs
 			out = lockedOut.TransferableOut
-		} else {
-			// unlocked tokens can be transferred and must be checked
-			if err := h.fx.VerifyMultisigOwner(out, h.utxosReader); err != nil {
-				return err
-			}
 		}
 
 		otherLockTxID := &lockIDs.DepositTxID
@@ -1020,7 +1716,16 @@ func (h *handler) VerifyUnlockDeposit(
 		utxos[index] = utxo
 	}
 
-	return h.VerifyUnlockDepositedUTXOs(state, tx, utxos, ins, outs, creds, burnedAmount, assetID)
+	unlockedAmounts, err := h.VerifyUnlockDepositedUTXOs(state, tx, utxos, ins, outs, creds, burnedAmount, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyNotRetiredOutsOwners(state, outs); err != nil {
+		return nil, err
+	}
+
+	return unlockedAmounts, nil
 }
 
 func (h *handler) VerifyUnlockDepositedUTXOs(
@@ -1068,6 +1773,15 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 
 	currentTimestamp := uint64(chainState.GetTimestamp().Unix())
 
+	treasuryOwnerID, err := txs.GetOwnerID(treasury.Owner)
+	if err != nil {
+		return nil, err
+	}
+	// amount of unlocked tokens produced for treasury, that isn't covered by treasury consumed tokens
+	producedTreasury := uint64(0)
+	// transfers of not deposited utxos, their credentials are verified after all ins are checked
+	transfers := make([]multisigTransfer, 0, len(ins))
+
 	// iterate over ins, get utxos, fill the maps (consumed, depositUnlock)
 	for index, input := range ins {
 		utxo := utxos[index] // The UTXO consumed by [input]
@@ -1095,7 +1809,7 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 		out := utxo.Out
 		lockIDs := &locked.IDsEmpty
 		isDeposited := false
-		if lockedOut, ok := out.(*locked.Out); ok {
+		if lockedOut, ok := locked.AsOut(out); ok {
 			// utxo isn't deposited, so it can't be unlocked
 			// bonded-not-deposited utxos are not allowed
 			if isDeposited = lockedOut.DepositTxID != ids.Empty; !isDeposited {
@@ -1156,9 +1870,11 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 			}
 			depUnlock.consumed = newAmount
 		} else {
-			if err := h.fx.VerifyMultisigTransfer(tx, in, creds[index], out, h.utxosReader); err != nil {
-				return nil, fmt.Errorf("failed to verify transfer: %w", err)
+			transfer, err := newMultisigTransfer(in, creds[index], out)
+			if err != nil {
+				return nil, err
 			}
+			transfers = append(transfers, transfer)
 
 			// calculating consumed amounts
 			newAmount, err := math.Add64(consumedUnlocked, consumedAmount)
@@ -1169,13 +1885,18 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 		}
 	}
 
+	recorder, _ := chainState.(secp256k1fx.AliasUsageRecorder)
+	if err := h.verifyMultisigTransfers(tx, recorder, transfers); err != nil {
+		return nil, err
+	}
+
 	// iterating over outs, checking produced amounts with consumed map
 	// filling deposit produced amounts
 
 	for _, output := range outs {
 		out := output.Out
 		lockIDs := &locked.IDsEmpty
-		lockedOut, isLocked := out.(*locked.Out)
+		lockedOut, isLocked := locked.AsOut(out)
 		if isLocked {
 			lockIDs = &lockedOut.IDs
 			out = lockedOut.TransferableOut
@@ -1217,6 +1938,15 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 
 			amountToRemoveFromConsumed = consumedAmount
 			amountToRemoveFromConsumedUnlocked := producedAmount - consumedAmount
+			if ownerID == treasuryOwnerID {
+				// could be early unlock penalty, will be checked after deposits
+				newAmount, err := math.Add64(producedTreasury, amountToRemoveFromConsumedUnlocked)
+				if err != nil {
+					return nil, err
+				}
+				producedTreasury = newAmount
+				amountToRemoveFromConsumedUnlocked = 0
+			}
 			if consumedUnlocked < amountToRemoveFromConsumedUnlocked {
 				return nil, fmt.Errorf(
 					"address %s produces %d and consumes %d unlocked and %d locked with %+v: %w",
@@ -1243,6 +1973,8 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 
 	// this map will list how much tokens was unlocked from each deposit
 	unlockedAmount := make(map[ids.ID]uint64) // depositTxID -> amount
+	// total amount of early unlock penalties, that must be transferred to treasury
+	earlyUnlockPenalty := uint64(0)
 	// if there are no deposits - its not system tx and we need to burn fee
 	needToBurn := len(depositUnlocks) == 0
 
@@ -1286,6 +2018,26 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 				errNotConsumedDeposit)
 		}
 
+		// deposits with early unlock offer could be fully unlocked before they become unlockable,
+		// paying penalty for amount, that wasn't unlockable yet
+		if !isExpired && unlockedDepositAmount > unlockableAmount && depositOffer.AllowsEarlyUnlock() {
+			if unlockedDepositAmount != deposit.Amount-deposit.UnlockedAmount {
+				return nil, fmt.Errorf("deposit (%s) remaining amount (%d) isn't equal to unlocked amount (%d): %w",
+					depositTxID,
+					deposit.Amount-deposit.UnlockedAmount,
+					unlockedDepositAmount,
+					errPartialEarlyUnlock)
+			}
+			newPenalty, err := math.Add64(earlyUnlockPenalty,
+				depositOffer.EarlyUnlockPenalty(unlockedDepositAmount-unlockableAmount))
+			if err != nil {
+				return nil, err
+			}
+			earlyUnlockPenalty = newPenalty
+			unlockedAmount[depositTxID] = unlockedDepositAmount
+			continue
+		}
+
 		// checking that we unlocked no more, than was available for unlock
 		if unlockedDepositAmount > unlockableAmount {
 			return nil, fmt.Errorf("unlockedDepositAmount %d > %d unlockableAmount: %w",
@@ -1297,6 +2049,40 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 		unlockedAmount[depositTxID] = unlockedDepositAmount
 	}
 
+	// checking that early unlock penalty is transferred to treasury from unlocked deposited tokens
+	// and that other tokens produced for treasury are covered by consumed unlocked tokens
+
+	if earlyUnlockPenalty > 0 {
+		notProducedDeposited := uint64(0)
+		for _, consumedOwnerAmounts := range consumed {
+			newAmount, err := math.Add64(notProducedDeposited, consumedOwnerAmounts[ids.Empty])
+			if err != nil {
+				return nil, err
+			}
+			notProducedDeposited = newAmount
+		}
+		if producedTreasury < earlyUnlockPenalty || notProducedDeposited < earlyUnlockPenalty {
+			return nil, fmt.Errorf(
+				"early unlock penalty is %d, but produced %d for treasury from %d unlocked deposited tokens: %w",
+				earlyUnlockPenalty,
+				producedTreasury,
+				notProducedDeposited,
+				errEarlyUnlockPenaltyNotPaid,
+			)
+		}
+		producedTreasury -= earlyUnlockPenalty
+	}
+
+	if consumedUnlocked < producedTreasury {
+		return nil, fmt.Errorf(
+			"treasury produces %d and consumes %d unlocked: %w",
+			producedTreasury,
+			consumedUnlocked,
+			errWrongProducedAmount,
+		)
+	}
+	consumedUnlocked -= producedTreasury
+
 	// checking that we burned required amount
 
 	if needToBurn && consumedUnlocked < burnedAmount {
@@ -1312,6 +2098,88 @@ func (h *handler) VerifyUnlockDepositedUTXOs(
 	return unlockedAmount, nil
 }
 
+// multisigTransfer is an input consuming out, that must be authorized by cred
+type multisigTransfer struct {
+	in   *secp256k1fx.TransferInput
+	cred secp256k1fx.CredentialIntf
+	out  *secp256k1fx.TransferOutput
+}
+
+func newMultisigTransfer(in avax.TransferableIn, cred verify.Verifiable, out verify.State) (multisigTransfer, error) {
+	secpIn, ok := in.(*secp256k1fx.TransferInput)
+	if !ok {
+		return multisigTransfer{}, fmt.Errorf("failed to verify transfer: %w", errWrongInType)
+	}
+	secpCred, ok := cred.(secp256k1fx.CredentialIntf)
+	if !ok {
+		return multisigTransfer{}, fmt.Errorf("failed to verify transfer: %w", errWrongCredentials)
+	}
+	secpOut, ok := out.(*secp256k1fx.TransferOutput)
+	if !ok {
+		return multisigTransfer{}, fmt.Errorf("failed to verify transfer: %w", errWrongUTXOOutType)
+	}
+	return multisigTransfer{in: secpIn, cred: secpCred, out: secpOut}, nil
+}
+
+// verifyMultisigTransfers verifies [transfers] credentials concurrently with
+// bounded number of workers. Transfers are independent, but signatures recovery
+// and aliases traversal of multisig txs with many signatures is expensive.
+// If several transfers are invalid, the error of the first one is returned.
+// If [recorder] isn't nil, multisig aliases used by verification are reported to it.
+func (h *handler) verifyMultisigTransfers(
+	tx txs.UnsignedTx,
+	recorder secp256k1fx.AliasUsageRecorder,
+	transfers []multisigTransfer,
+) error {
+	if len(transfers) == 0 {
+		return nil
+	}
+	msig, ok := h.utxosReader.(secp256k1fx.AliasGetter)
+	if !ok {
+		return fmt.Errorf("failed to verify transfer: %w", errNotAliasGetter)
+	}
+	if recorder != nil {
+		msig = secp256k1fx.WithAliasUsageRecorder(msig, recorder)
+	}
+
+	numWorkers := runtime.GOMAXPROCS(0)
+	if numWorkers > len(transfers) {
+		numWorkers = len(transfers)
+	}
+
+	errs := make([]error, len(transfers))
+	if numWorkers <= 1 {
+		for i, transfer := range transfers {
+			errs[i] = h.fx.VerifyMultisigTransfer(tx, transfer.in, transfer.cred, transfer.out, msig)
+		}
+	} else {
+		indices := make(chan int)
+		wg := sync.WaitGroup{}
+		wg.Add(numWorkers)
+		for w := 0; w < numWorkers; w++ {
+			go func() {
+				defer wg.Done()
+				for i := range indices {
+					transfer := transfers[i]
+					errs[i] = h.fx.VerifyMultisigTransfer(tx, transfer.in, transfer.cred, transfer.out, msig)
+				}
+			}()
+		}
+		for i := range transfers {
+			indices <- i
+		}
+		close(indices)
+		wg.Wait()
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return fmt.Errorf("failed to verify transfer: %w", err)
+		}
+	}
+	return nil
+}
+
 func (h *handler) isMultisigTransferOutput(out verify.State) bool {
 	secpOut, ok := out.(*secp256k1fx.TransferOutput)
 	if !ok {
@@ -1335,6 +2203,66 @@ func (h *handler) isMultisigTransferOutput(out verify.State) bool {
 	return false
 }
 
+// ShortIDLinkGetter is implemented by states, that know which multisig aliases are retired
+type ShortIDLinkGetter interface {
+	GetShortIDLink(id ids.ShortID, key state.ShortLinkKey) (ids.ShortID, error)
+}
+
+// VerifyNotRetiredAliases returns error if any of [addrs] is retired multisig alias.
+func VerifyNotRetiredAliases(linkGetter ShortIDLinkGetter, addrs []ids.ShortID) error {
+	for _, addr := range addrs {
+		successorID, err := linkGetter.GetShortIDLink(addr, state.ShortLinkKeyMultisigAliasSuccessor)
+		switch {
+		case err == nil:
+			return fmt.Errorf("%w: %s (successor %s)", ErrAliasRetired, addr, successorID)
+		case err != database.ErrNotFound:
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyNotRetiredOutsOwners returns error if any of [outs] is owned by retired multisig alias.
+// If [linkGetter] is nil, outs aren't verified.
+func verifyNotRetiredOutsOwners(linkGetter ShortIDLinkGetter, outs []*avax.TransferableOutput) error {
+	if linkGetter == nil {
+		return nil
+	}
+	for _, output := range outs {
+		out := output.Out
+		if lockedOut, ok := locked.AsOut(out); ok {
+			out = lockedOut.TransferableOut
+		} else if stakeableOut, ok := out.(*stakeable.LockOut); ok {
+			out = stakeableOut.TransferableOut
+		}
+		// other out types are verified by other flow check steps
+		if secpOut, ok := out.(*secp256k1fx.TransferOutput); ok {
+			if err := VerifyNotRetiredAliases(linkGetter, secpOut.Addrs); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type innerSortInputsByAmount struct {
+	ins     []*avax.TransferableInput
+	signers [][]*crypto.PrivateKeySECP256K1R
+}
+
+func (sort *innerSortInputsByAmount) Less(i, j int) bool {
+	return sort.ins[i].In.Amount() < sort.ins[j].In.Amount()
+}
+
+func (sort *innerSortInputsByAmount) Len() int {
+	return len(sort.ins)
+}
+
+func (sort *innerSortInputsByAmount) Swap(i, j int) {
+	sort.ins[j], sort.ins[i] = sort.ins[i], sort.ins[j]
+	sort.signers[j], sort.signers[i] = sort.signers[i], sort.signers[j]
+}
+
 type innerSortUTXOs struct {
 	utxos          []*avax.UTXO
 	allowedAssetID ids.ID
@@ -1351,19 +2279,19 @@ func (sort *innerSortUTXOs) Less(i, j int) bool {
 
 	iOut := iUTXO.Out
 	iLockIDs := &locked.IDsEmpty
-	if lockedOut, ok := iOut.(*locked.Out); ok {
+	if lockedOut, ok := locked.AsOut(iOut); ok {
 		iOut = lockedOut.TransferableOut
 		iLockIDs = &lockedOut.IDs
 	}
 
 	jOut := jUTXO.Out
 	jLockIDs := &locked.IDsEmpty
-	if lockedOut, ok := jOut.(*locked.Out); ok {
+	if lockedOut, ok := locked.AsOut(jOut); ok {
 		jOut = lockedOut.TransferableOut
 		jLockIDs = &lockedOut.IDs
 	}
 
-	if sort.lockState == locked.StateUnlocked {
+	if sort.lockState == locked.StateUnlocked || sort.lockState == locked.StateEscrowed {
 		// Sort all locks last
 		iEmpty := *iLockIDs == locked.IDsEmpty
 		if iEmpty != (*jLockIDs == locked.IDsEmpty) {
@@ -1387,6 +2315,15 @@ func (sort *innerSortUTXOs) Less(i, j int) bool {
 			return false
 		}
 
+		// Bond unlocked utxos first, deposited utxos are
+		// bonded only if unlocked funds are insufficient
+		if sort.lockState == locked.StateBonded {
+			iUnlocked := *iOtherLockTxID == ids.Empty
+			if iUnlocked != (*jOtherLockTxID == ids.Empty) {
+				return iUnlocked
+			}
+		}
+
 		switch bytes.Compare(iOtherLockTxID[:], jOtherLockTxID[:]) {
 		case -1:
 			return false
@@ -1405,7 +2342,15 @@ func (sort *innerSortUTXOs) Less(i, j int) bool {
 		jAmount = amounter.Amount()
 	}
 
-	return iAmount < jAmount
+	if iAmount != jAmount {
+		return iAmount < jAmount
+	}
+
+	// Utxos with the same amount are sorted by id, so that utxos selection
+	// doesn't depend on order in which utxos were read from state
+	iID := iUTXO.InputID()
+	jID := jUTXO.InputID()
+	return bytes.Compare(iID[:], jID[:]) < 0
 }
 
 func (sort *innerSortUTXOs) Len() int {
@@ -1421,6 +2366,87 @@ func sortUTXOs(utxos []*avax.UTXO, allowedAssetID ids.ID, lockState locked.State
 	sort.Sort(&innerSortUTXOs{utxos: utxos, allowedAssetID: allowedAssetID, lockState: lockState})
 }
 
+// depositReader is implemented by chain state, which is used as utxos reader by vm
+type depositReader interface {
+	GetDeposit(depositTxID ids.ID) (*deposits.Deposit, error)
+	GetDepositOffer(offerID ids.ID) (*deposits.Offer, error)
+}
+
+// spendableOut returns inner out of [out], if [out] is stakeable locked out,
+// which locktime is expired at [now]. Such outs come from AVAX-style networks
+// and are spent as unlocked in camino lock model. Otherwise, [out] is returned.
+func spendableOut(out verify.State, now uint64) verify.State {
+	if stakeableOut, ok := out.(*stakeable.LockOut); ok && stakeableOut.Locktime <= now {
+		return stakeableOut.TransferableOut
+	}
+	return out
+}
+
+// chainTimeGetter is implemented by chain states
+type chainTimeGetter interface {
+	GetTimestamp() time.Time
+}
+
+// stakeableUnlockTime returns chain time of [utxoDB], if any of [utxos] is stakeable locked
+// and [utxoDB] is chainTimeGetter, otherwise zero. Chain time is used instead of node clock,
+// so verification result doesn't depend on node.
+func stakeableUnlockTime(utxoDB state.UTXOGetter, utxos []*avax.UTXO) uint64 {
+	timeGetter, ok := utxoDB.(chainTimeGetter)
+	if !ok {
+		return 0
+	}
+	for _, utxo := range utxos {
+		if _, ok := utxo.Out.(*stakeable.LockOut); ok {
+			return uint64(timeGetter.GetTimestamp().Unix())
+		}
+	}
+	return 0
+}
+
+// unwrapExpiredStakeableUTXOs returns [utxos] with outs replaced by spendableOut.
+// [utxos] aren't modified, utxos with replaced outs are copied.
+func unwrapExpiredStakeableUTXOs(utxos []*avax.UTXO, now uint64) []*avax.UTXO {
+	var result []*avax.UTXO
+	for i, utxo := range utxos {
+		stakeableOut, ok := utxo.Out.(*stakeable.LockOut)
+		if !ok || stakeableOut.Locktime > now {
+			continue
+		}
+		if result == nil {
+			result = make([]*avax.UTXO, len(utxos))
+			copy(result, utxos)
+		}
+		result[i] = &avax.UTXO{
+			UTXOID: utxo.UTXOID,
+			Asset:  utxo.Asset,
+			Out:    stakeableOut.TransferableOut,
+		}
+	}
+	if result == nil {
+		return utxos
+	}
+	return result
+}
+
+// isDepositBondable returns false only if deposit [depositTxID] is known to be created
+// with offer, that doesn't allow bonding of deposited tokens. Deposits, that couldn't
+// be checked with [source] utxos reader, are left for tx verification.
+func isDepositBondable(source interface{}, depositTxID ids.ID) bool {
+	reader, ok := source.(depositReader)
+	if !ok {
+		return true
+	}
+	deposit, err := reader.GetDeposit(depositTxID)
+	if err != nil {
+		return true
+	}
+	depositOffer, err := reader.GetDepositOffer(deposit.DepositOfferID)
+	if err != nil {
+		return true
+	}
+	return depositOffer.AllowsBonding()
+}
+
 func getDepositUnlockableAmounts(
 	chainState state.Chain,
 	depositTxIDs set.Set[ids.ID],
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
@@ -23,6 +24,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/locked"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/treasury"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/golang/mock/gomock"
@@ -114,6 +117,35 @@ func TestUnlockUTXOs(t *testing.T) {
 			},
 			expectedError: errNotLockedUTXO,
 		},
+		"Unescrow escrowed UTXOs": {
+			lockState: locked.StateEscrowed,
+			utxos: []*avax.UTXO{
+				generateTestEscrowedUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 5, outputOwners, existingTxID),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins: []*avax.TransferableInput{
+						generateTestInFromUTXO(utxos[0], nil),
+					},
+					outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+					},
+				}
+			},
+		},
+		"Unescrow deposited UTXOs": {
+			lockState: locked.StateEscrowed,
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 5, outputOwners, existingTxID, ids.Empty),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins:  nil,
+					outs: nil,
+				}
+			},
+			expectedError: errNotLockedUTXO,
+		},
 		"Unlock unlocked UTXOs": {
 			lockState: locked.StateBonded,
 			utxos: []*avax.UTXO{
@@ -156,6 +188,91 @@ func TestUnlockUTXOs(t *testing.T) {
 	}
 }
 
+func TestUnlockChunks(t *testing.T) {
+	testHandler := defaultCaminoHandler(t, nil)
+	ctx := testHandler.ctx
+	outputOwners := defaultOwners()
+
+	bondTxIDs := []ids.ID{{1}, {2}, {3}}
+	bondTxs := map[ids.ID]*txs.Tx{}
+	bondedUTXOs := map[ids.ID]*avax.UTXO{}
+	for i, bondTxID := range bondTxIDs {
+		bondTxs[bondTxID] = &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+			Outs: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 10, outputOwners, ids.Empty, locked.ThisTxID),
+			},
+		}}}
+		bondedUTXOs[bondTxID] = generateTestUTXO(ids.ID{10, byte(i)}, ctx.AVAXAssetID, 10, outputOwners, ids.Empty, bondTxID)
+	}
+
+	expectedChunk := func(lockTxIDs ...ids.ID) *UnlockChunk {
+		chunk := &UnlockChunk{LockTxIDs: lockTxIDs}
+		for _, lockTxID := range lockTxIDs {
+			chunk.Ins = append(chunk.Ins, generateTestInFromUTXO(bondedUTXOs[lockTxID], nil))
+			chunk.Outs = append(chunk.Outs, generateTestOut(ctx.AVAXAssetID, 10, outputOwners, ids.Empty, ids.Empty))
+		}
+		avax.SortTransferableInputs(chunk.Ins)
+		avax.SortTransferableOutputs(chunk.Outs, txs.Codec)
+		return chunk
+	}
+
+	tests := map[string]struct {
+		lockTxIDs      []ids.ID
+		maxOuts        int
+		maxSize        int
+		expectedChunks []*UnlockChunk
+	}{
+		"No limits, unsorted lock txs with duplicates": {
+			lockTxIDs:      []ids.ID{bondTxIDs[2], bondTxIDs[0], bondTxIDs[1], bondTxIDs[0]},
+			expectedChunks: []*UnlockChunk{expectedChunk(bondTxIDs...)},
+		},
+		"Max outs": {
+			lockTxIDs: bondTxIDs,
+			maxOuts:   2,
+			expectedChunks: []*UnlockChunk{
+				expectedChunk(bondTxIDs[0], bondTxIDs[1]),
+				expectedChunk(bondTxIDs[2]),
+			},
+		},
+		"Lock tx exceeds max size": {
+			lockTxIDs: bondTxIDs,
+			maxSize:   1,
+			expectedChunks: []*UnlockChunk{
+				expectedChunk(bondTxIDs[0]),
+				expectedChunk(bondTxIDs[1]),
+				expectedChunk(bondTxIDs[2]),
+			},
+		},
+		"No lock txs": {
+			expectedChunks: []*UnlockChunk{},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			s := state.NewMockChain(ctrl)
+			s.EXPECT().GetTx(gomock.Any()).DoAndReturn(func(txID ids.ID) (*txs.Tx, status.Status, error) {
+				return bondTxs[txID], status.Committed, nil
+			}).AnyTimes()
+			s.EXPECT().LockedUTXOs(gomock.Any(), gomock.Any(), locked.StateBonded).DoAndReturn(
+				func(lockTxIDs set.Set[ids.ID], _ set.Set[ids.ShortID], _ locked.State) ([]*avax.UTXO, error) {
+					utxos := []*avax.UTXO{}
+					for lockTxID := range lockTxIDs {
+						utxos = append(utxos, bondedUTXOs[lockTxID])
+					}
+					return utxos, nil
+				},
+			).AnyTimes()
+
+			chunks, err := testHandler.UnlockChunks(s, tt.lockTxIDs, locked.StateBonded, tt.maxOuts, tt.maxSize)
+			require.NoError(err)
+			require.Equal(tt.expectedChunks, chunks)
+		})
+	}
+}
+
 func TestLock(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -201,6 +318,8 @@ func TestLock(t *testing.T) {
 	}
 
 	existingTxID := ids.GenerateTestID()
+	bondableOffer := &deposit.Offer{ID: ids.ID{1}}
+	notBondableOffer := &deposit.Offer{ID: ids.ID{2}, Flags: deposit.OfferFlagNoBonding}
 
 	type args struct {
 		totalAmountToSpend uint64
@@ -208,17 +327,21 @@ func TestLock(t *testing.T) {
 		appliedLockState   locked.State
 		recipient          *secp256k1fx.OutputOwners
 		change             *secp256k1fx.OutputOwners
+		maxInputs          int
+		minChangeAmount    uint64
 	}
 	type want struct {
 		ins  []*avax.TransferableInput
 		outs []*avax.TransferableOutput
 	}
 	tests := map[string]struct {
-		utxos        []*avax.UTXO
-		args         args
-		generateWant func([]*avax.UTXO) want
-		expectError  error
-		msg          string
+		utxos           []*avax.UTXO
+		depositOffers   map[ids.ID]*deposit.Offer // depositTxID -> offer
+		minChangeAmount uint64
+		args            args
+		generateWant    func([]*avax.UTXO) want
+		expectError     error
+		msg             string
 	}{
 		"Happy path bonding": {
 			args: args{
@@ -254,6 +377,7 @@ func TestLock(t *testing.T) {
 				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
 				generateTestUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 10, outputOwners, existingTxID, ids.Empty),
 			},
+			depositOffers: map[ids.ID]*deposit.Offer{existingTxID: bondableOffer},
 			generateWant: func(utxos []*avax.UTXO) want {
 				return want{
 					ins: []*avax.TransferableInput{
@@ -261,14 +385,51 @@ func TestLock(t *testing.T) {
 						generateTestInFromUTXO(utxos[1], []uint32{0}),
 					},
 					outs: []*avax.TransferableOutput{
-						generateTestOut(ctx.AVAXAssetID, 9, outputOwners, existingTxID, locked.ThisTxID),
-						generateTestOut(ctx.AVAXAssetID, 1, outputOwners, existingTxID, ids.Empty),
-						generateTestOut(ctx.AVAXAssetID, 4, outputOwners, ids.Empty, ids.Empty),
+						generateTestOut(ctx.AVAXAssetID, 4, outputOwners, ids.Empty, locked.ThisTxID),
+						generateTestOut(ctx.AVAXAssetID, 5, outputOwners, existingTxID, locked.ThisTxID),
+						generateTestOut(ctx.AVAXAssetID, 5, outputOwners, existingTxID, ids.Empty),
 					},
 				}
 			},
 			msg: "Happy path bonding deposited amount",
 		},
+		"Bonding deposited amount with not bondable offer": {
+			args: args{
+				totalAmountToSpend: 9,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateBonded,
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 10, outputOwners, existingTxID, ids.Empty),
+			},
+			depositOffers: map[ids.ID]*deposit.Offer{existingTxID: notBondableOffer},
+			expectError:   errInsufficientBalance,
+			msg:           "Bonding deposited amount with not bondable offer",
+		},
+		"Bonding prefers unlocked amount": {
+			args: args{
+				totalAmountToSpend: 9,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateBonded,
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 20, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 10, outputOwners, existingTxID, ids.Empty),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins: []*avax.TransferableInput{
+						generateTestInFromUTXO(utxos[0], []uint32{0}),
+					},
+					outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 9, outputOwners, ids.Empty, locked.ThisTxID),
+						generateTestOut(ctx.AVAXAssetID, 10, outputOwners, ids.Empty, ids.Empty),
+					},
+				}
+			},
+			msg: "Bonding prefers unlocked amount",
+		},
 		"Bonding already bonded amount": {
 			args: args{
 				totalAmountToSpend: 9,
@@ -431,6 +592,84 @@ func TestLock(t *testing.T) {
 				}
 			},
 		},
+		"Needs consolidation": {
+			args: args{
+				totalAmountToSpend: 9,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateBonded,
+				maxInputs:          1,
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+				generateTestUTXO(ids.ID{9, 9}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+			},
+			expectError: ErrNeedsConsolidation,
+		},
+		"Bonding burns dust change": {
+			args: args{
+				totalAmountToSpend: 9,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateBonded,
+			},
+			minChangeAmount: 2,
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 11, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins: []*avax.TransferableInput{
+						generateTestInFromUTXO(utxos[0], []uint32{0}),
+					},
+					outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 9, outputOwners, ids.Empty, locked.ThisTxID),
+					},
+				}
+			},
+		},
+		"Bonding burns dust change below requested min change amount": {
+			args: args{
+				totalAmountToSpend: 9,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateBonded,
+				minChangeAmount:    2,
+			},
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 11, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins: []*avax.TransferableInput{
+						generateTestInFromUTXO(utxos[0], []uint32{0}),
+					},
+					outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 9, outputOwners, ids.Empty, locked.ThisTxID),
+					},
+				}
+			},
+		},
+		"Recipient transfer below min change amount isn't burned": {
+			args: args{
+				totalAmountToSpend: 1,
+				totalAmountToBurn:  1,
+				appliedLockState:   locked.StateUnlocked,
+				change:             &changeOwners,
+				recipient:          &recipientOwners,
+			},
+			minChangeAmount: 4,
+			utxos: []*avax.UTXO{
+				generateTestUTXO(ids.ID{8, 8}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+			},
+			generateWant: func(utxos []*avax.UTXO) want {
+				return want{
+					ins: []*avax.TransferableInput{
+						generateTestInFromUTXO(utxos[0], []uint32{0}),
+					},
+					outs: []*avax.TransferableOutput{
+						generateTestOut(ctx.AVAXAssetID, 1, recipientOwners, ids.Empty, ids.Empty),
+					},
+				}
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -456,24 +695,465 @@ func TestLock(t *testing.T) {
 			}
 			internalState.EXPECT().UTXOIDs(address.Bytes(), ids.Empty, math.MaxInt).Return(utxoIDs, nil)
 			internalState.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+			for depositTxID, offer := range tt.depositOffers {
+				internalState.EXPECT().GetDeposit(depositTxID).Return(&deposit.Deposit{DepositOfferID: offer.ID}, nil).AnyTimes()
+				internalState.EXPECT().GetDepositOffer(offer.ID).Return(offer, nil).AnyTimes()
+			}
 
 			testHandler := defaultCaminoHandler(t, internalState)
+			testHandler.minChangeAmount = tt.minChangeAmount
 
 			ins, outs, signers, _, err := testHandler.Lock(
 				[]*crypto.PrivateKeySECP256K1R{secpKey},
 				tt.args.totalAmountToSpend,
 				tt.args.totalAmountToBurn,
-				tt.args.appliedLockState,
-				tt.args.recipient,
-				tt.args.change,
+				tt.args.appliedLockState,
+				tt.args.recipient,
+				tt.args.change,
+				tt.args.maxInputs,
+				0,
+				tt.args.minChangeAmount,
+			)
+
+			avax.SortTransferableOutputs(want.outs, txs.Codec)
+
+			require.ErrorIs(err, tt.expectError, tt.msg)
+			require.Equal(want.ins, ins)
+			require.Equal(want.outs, outs)
+			require.Equal(expectedSigners, signers)
+		})
+	}
+}
+
+func TestLockAssets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := defaultConfig()
+	ctx := snow.DefaultContextTest()
+	baseDBManager := db_manager.NewMemDB(version.Semantic1_0_0)
+	baseDB := versiondb.New(baseDBManager.Current().Database)
+	rewardsCalc := reward.NewCalculator(config.RewardConfig)
+
+	testState := defaultState(config, ctx, baseDB, rewardsCalc)
+
+	key := preFundedKeys[0]
+	address := key.Address()
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{address},
+	}
+	recipientOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	otherAssetID := ids.ID{1, 1, 1}
+
+	utxos := []*avax.UTXO{
+		generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{2}, otherAssetID, 10, outputOwners, ids.Empty, ids.Empty),
+	}
+
+	type args struct {
+		amountsToLock    map[ids.ID]uint64
+		amountToBurn     uint64
+		appliedLockState locked.State
+	}
+	tests := map[string]struct {
+		args         args
+		expectedIns  []*avax.TransferableInput
+		expectedOuts []*avax.TransferableOutput
+		expectedErr  error
+	}{
+		"Happy path": {
+			args: args{
+				amountsToLock: map[ids.ID]uint64{
+					ctx.AVAXAssetID: 2,
+					otherAssetID:    7,
+				},
+				amountToBurn:     1,
+				appliedLockState: locked.StateUnlocked,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(utxos[0], []uint32{0}),
+				generateTestInFromUTXO(utxos[1], []uint32{0}),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 2, recipientOwners, ids.Empty, ids.Empty),
+				generateTestOut(ctx.AVAXAssetID, 2, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(otherAssetID, 7, recipientOwners, ids.Empty, ids.Empty),
+				generateTestOut(otherAssetID, 3, outputOwners, ids.Empty, ids.Empty),
+			},
+		},
+		"Only burn, other asset isn't consumed": {
+			args: args{
+				amountsToLock: map[ids.ID]uint64{
+					otherAssetID: 0,
+				},
+				amountToBurn:     1,
+				appliedLockState: locked.StateUnlocked,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(utxos[0], []uint32{0}),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 4, outputOwners, ids.Empty, ids.Empty),
+			},
+		},
+		"Insufficient balance of other asset": {
+			args: args{
+				amountsToLock: map[ids.ID]uint64{
+					otherAssetID: 11,
+				},
+				appliedLockState: locked.StateUnlocked,
+			},
+			expectedErr: errInsufficientBalance,
+		},
+		"Bonding other asset": {
+			args: args{
+				amountsToLock: map[ids.ID]uint64{
+					otherAssetID: 1,
+				},
+				appliedLockState: locked.StateBonded,
+			},
+			expectedErr: errLockingNotFeeAsset,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			internalState := state.NewMockState(ctrl)
+			utxoIDs := []ids.ID{}
+			for _, utxo := range utxos {
+				testState.AddUTXO(utxo)
+				utxoIDs = append(utxoIDs, utxo.InputID())
+				internalState.EXPECT().GetUTXO(utxo.InputID()).Return(testState.GetUTXO(utxo.InputID())).AnyTimes()
+			}
+			internalState.EXPECT().UTXOIDs(address.Bytes(), ids.Empty, math.MaxInt).Return(utxoIDs, nil).AnyTimes()
+			internalState.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+
+			testHandler := defaultCaminoHandler(t, internalState)
+
+			ins, outs, signers, _, err := testHandler.LockAssets(
+				[]*crypto.PrivateKeySECP256K1R{key},
+				tt.args.amountsToLock,
+				tt.args.amountToBurn,
+				tt.args.appliedLockState,
+				&recipientOwners,
+				nil,
+				0,
+				0,
+				0,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			avax.SortTransferableOutputs(tt.expectedOuts, txs.Codec)
+			require.Equal(tt.expectedIns, ins)
+			require.Equal(tt.expectedOuts, outs)
+			require.Len(signers, len(ins))
+		})
+	}
+}
+
+func TestLockAssetsFrom(t *testing.T) {
+	require := require.New(t)
+	// handler utxos reader is empty, so only source utxos could be spent
+	testHandler := defaultCaminoHandler(t, nil)
+	ctx := testHandler.ctx
+
+	key := preFundedKeys[0]
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{key.Address()},
+	}
+	utxo := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty)
+
+	source := avax.NewUTXOState(memdb.New(), txs.Codec)
+	require.NoError(source.PutUTXO(utxo))
+
+	_, _, _, _, err := testHandler.LockAssets(
+		[]*crypto.PrivateKeySECP256K1R{key},
+		map[ids.ID]uint64{ctx.AVAXAssetID: 2},
+		1,
+		locked.StateBonded,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+	)
+	require.ErrorIs(err, errInsufficientBalance)
+
+	ins, outs, signers, _, err := testHandler.LockAssetsFrom(
+		source,
+		[]*crypto.PrivateKeySECP256K1R{key},
+		map[ids.ID]uint64{ctx.AVAXAssetID: 2},
+		1,
+		locked.StateBonded,
+		nil,
+		nil,
+		0,
+		0,
+		0,
+	)
+	require.NoError(err)
+
+	expectedOuts := []*avax.TransferableOutput{
+		generateTestOut(ctx.AVAXAssetID, 2, outputOwners, ids.Empty, locked.ThisTxID),
+		generateTestOut(ctx.AVAXAssetID, 2, outputOwners, ids.Empty, ids.Empty),
+	}
+	avax.SortTransferableOutputs(expectedOuts, txs.Codec)
+	require.Equal([]*avax.TransferableInput{generateTestInFromUTXO(utxo, []uint32{0})}, ins)
+	require.Equal(expectedOuts, outs)
+	require.Len(signers, 1)
+}
+
+func TestRelock(t *testing.T) {
+	testHandler := defaultCaminoHandler(t, nil)
+	ctx := testHandler.ctx
+
+	depositTxID := ids.GenerateTestID()
+	bondTxID := ids.GenerateTestID()
+	otherBondTxID := ids.GenerateTestID()
+	outputOwners := defaultOwners()
+	sigIndices := []uint32{0}
+
+	bondedUTXO := generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 10, outputOwners, ids.Empty, bondTxID)
+	depositedUTXO := generateTestUTXO(ids.ID{2}, ctx.AVAXAssetID, 20, outputOwners, depositTxID, ids.Empty)
+	depositedBondedUTXO := generateTestUTXO(ids.ID{3}, ctx.AVAXAssetID, 30, outputOwners, depositTxID, otherBondTxID)
+
+	type args struct {
+		lockTxIDs        []ids.ID
+		removedLockState locked.State
+		appliedLockState locked.State
+	}
+	tests := map[string]struct {
+		utxos        []*avax.UTXO
+		args         args
+		expectedIns  []*avax.TransferableInput
+		expectedOuts []*avax.TransferableOutput
+		expectedErr  error
+	}{
+		"Bonded to deposited": {
+			utxos: []*avax.UTXO{bondedUTXO},
+			args: args{
+				lockTxIDs:        []ids.ID{bondTxID},
+				removedLockState: locked.StateBonded,
+				appliedLockState: locked.StateDeposited,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(bondedUTXO, sigIndices),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 10, outputOwners, locked.ThisTxID, ids.Empty),
+			},
+		},
+		"Deposited to bonded": {
+			utxos: []*avax.UTXO{depositedUTXO},
+			args: args{
+				lockTxIDs:        []ids.ID{depositTxID},
+				removedLockState: locked.StateDeposited,
+				appliedLockState: locked.StateBonded,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(depositedUTXO, sigIndices),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 20, outputOwners, ids.Empty, locked.ThisTxID),
+			},
+		},
+		"Deposited to deposited, other locks are preserved": {
+			utxos: []*avax.UTXO{depositedUTXO, depositedBondedUTXO},
+			args: args{
+				lockTxIDs:        []ids.ID{depositTxID},
+				removedLockState: locked.StateDeposited,
+				appliedLockState: locked.StateDeposited,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(depositedUTXO, sigIndices),
+				generateTestInFromUTXO(depositedBondedUTXO, sigIndices),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 20, outputOwners, locked.ThisTxID, ids.Empty),
+				generateTestOut(ctx.AVAXAssetID, 30, outputOwners, locked.ThisTxID, otherBondTxID),
+			},
+		},
+		"Deposited to bonded, already bonded": {
+			utxos: []*avax.UTXO{depositedBondedUTXO},
+			args: args{
+				lockTxIDs:        []ids.ID{depositTxID},
+				removedLockState: locked.StateDeposited,
+				appliedLockState: locked.StateBonded,
+			},
+			expectedErr: errRelockingLockedUTXO,
+		},
+		"Unlocked applied lock state": {
+			args: args{
+				lockTxIDs:        []ids.ID{bondTxID},
+				removedLockState: locked.StateBonded,
+				appliedLockState: locked.StateUnlocked,
+			},
+			expectedErr: errInvalidTargetLockState,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			s := state.NewMockChain(ctrl)
+			if tt.utxos != nil {
+				lockTxIDsSet := set.NewSet[ids.ID](len(tt.args.lockTxIDs))
+				lockTxIDsSet.Add(tt.args.lockTxIDs...)
+				s.EXPECT().LockedUTXOs(lockTxIDsSet, gomock.Any(), tt.args.removedLockState).Return(tt.utxos, nil)
+			}
+
+			ins, outs, signers, err := testHandler.Relock(
+				s,
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				tt.args.lockTxIDs,
+				tt.args.removedLockState,
+				tt.args.appliedLockState,
+			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
+
+			avax.SortTransferableOutputs(tt.expectedOuts, txs.Codec)
+			require.Equal(tt.expectedIns, ins)
+			require.Equal(tt.expectedOuts, outs)
+			require.Len(signers, len(ins))
+		})
+	}
+}
+
+func TestConsolidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := defaultConfig()
+	ctx := snow.DefaultContextTest()
+	baseDBManager := db_manager.NewMemDB(version.Semantic1_0_0)
+	baseDB := versiondb.New(baseDBManager.Current().Database)
+	rewardsCalc := reward.NewCalculator(config.RewardConfig)
+
+	testState := defaultState(config, ctx, baseDB, rewardsCalc)
+
+	key := preFundedKeys[0]
+	address := key.Address()
+	outputOwners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{address},
+	}
+
+	utxos := []*avax.UTXO{
+		generateTestUTXO(ids.ID{1}, ctx.AVAXAssetID, 1, outputOwners, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{2}, ctx.AVAXAssetID, 2, outputOwners, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{3}, ctx.AVAXAssetID, 3, outputOwners, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{4}, ctx.AVAXAssetID, 10, outputOwners, ids.Empty, ids.Empty),
+		generateTestUTXO(ids.ID{5}, ctx.AVAXAssetID, 20, outputOwners, ids.GenerateTestID(), ids.Empty),
+	}
+
+	type args struct {
+		targetCount       int
+		maxInputs         int
+		totalAmountToBurn uint64
+	}
+	tests := map[string]struct {
+		args         args
+		expectedIns  []*avax.TransferableInput
+		expectedOuts []*avax.TransferableOutput
+		expectedErr  error
+	}{
+		"Happy path": {
+			args: args{
+				targetCount:       2,
+				totalAmountToBurn: 1,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(utxos[0], []uint32{0}),
+				generateTestInFromUTXO(utxos[1], []uint32{0}),
+				generateTestInFromUTXO(utxos[2], []uint32{0}),
+				generateTestInFromUTXO(utxos[3], []uint32{0}),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 8, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(ctx.AVAXAssetID, 7, outputOwners, ids.Empty, ids.Empty),
+			},
+		},
+		"Smallest utxos are consolidated first": {
+			args: args{
+				targetCount:       1,
+				maxInputs:         3,
+				totalAmountToBurn: 1,
+			},
+			expectedIns: []*avax.TransferableInput{
+				generateTestInFromUTXO(utxos[0], []uint32{0}),
+				generateTestInFromUTXO(utxos[1], []uint32{0}),
+				generateTestInFromUTXO(utxos[2], []uint32{0}),
+			},
+			expectedOuts: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, 5, outputOwners, ids.Empty, ids.Empty),
+			},
+		},
+		"Zero target count": {
+			expectedErr: errZeroTargetCount,
+		},
+		"Nothing to consolidate": {
+			args: args{
+				targetCount: 4,
+			},
+			expectedErr: errNothingToConsolidate,
+		},
+		"Insufficient balance": {
+			args: args{
+				targetCount:       1,
+				totalAmountToBurn: 16,
+			},
+			expectedErr: errInsufficientBalance,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			internalState := state.NewMockState(ctrl)
+			utxoIDs := []ids.ID{}
+			for _, utxo := range utxos {
+				testState.AddUTXO(utxo)
+				utxoIDs = append(utxoIDs, utxo.InputID())
+				internalState.EXPECT().GetUTXO(utxo.InputID()).Return(testState.GetUTXO(utxo.InputID())).AnyTimes()
+			}
+			internalState.EXPECT().UTXOIDs(address.Bytes(), ids.Empty, math.MaxInt).Return(utxoIDs, nil).AnyTimes()
+			internalState.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+
+			testHandler := defaultCaminoHandler(t, internalState)
+
+			ins, outs, signers, err := testHandler.Consolidate(
+				[]*crypto.PrivateKeySECP256K1R{key},
+				tt.args.targetCount,
+				tt.args.maxInputs,
+				tt.args.totalAmountToBurn,
 				0,
 			)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr != nil {
+				return
+			}
 
-			avax.SortTransferableOutputs(want.outs, txs.Codec)
-
-			require.ErrorIs(err, tt.expectError, tt.msg)
-			require.Equal(want.ins, ins)
-			require.Equal(want.outs, outs)
+			avax.SortTransferableOutputs(tt.expectedOuts, txs.Codec)
+			expectedSigners := make([][]*crypto.PrivateKeySECP256K1R, len(tt.expectedIns))
+			for i := range expectedSigners {
+				expectedSigners[i] = []*crypto.PrivateKeySECP256K1R{key}
+			}
+			require.Equal(tt.expectedIns, ins)
+			require.Equal(tt.expectedOuts, outs)
 			require.Equal(expectedSigners, signers)
 		})
 	}
@@ -492,6 +1172,7 @@ func TestVerifyLockUTXOs(t *testing.T) {
 	internalState := state.NewMockState(ctrl)
 	internalState.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
 	testHandler := defaultCaminoHandler(t, internalState)
+	chainTime := uint64(10)
 
 	assetID := testHandler.ctx.AVAXAssetID
 
@@ -661,9 +1342,9 @@ func TestVerifyLockUTXOs(t *testing.T) {
 			appliedLockState: locked.StateBonded,
 			expectedErr:      errWrongProducedAmount,
 		},
-		"utxos have stakable.LockedOut": {
+		"utxos have stakable.LockedOut, not expired by chain time": {
 			utxos: []*avax.UTXO{
-				generateTestStakeableUTXO(ids.ID{1}, assetID, 10, 0, outputOwners1),
+				generateTestStakeableUTXO(ids.ID{1}, assetID, 10, chainTime+1, outputOwners1),
 			},
 			ins: []*avax.TransferableInput{
 				generateTestIn(assetID, 10, ids.Empty, ids.Empty, sigIndices),
@@ -676,6 +1357,20 @@ func TestVerifyLockUTXOs(t *testing.T) {
 			appliedLockState: locked.StateBonded,
 			expectedErr:      errWrongUTXOOutType,
 		},
+		"utxos have expired stakable.LockedOut": {
+			utxos: []*avax.UTXO{
+				generateTestStakeableUTXO(ids.ID{1}, assetID, 10, chainTime, outputOwners1),
+			},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, ids.Empty, ids.Empty, sigIndices),
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 8, outputOwners1, ids.Empty, locked.ThisTxID),
+			},
+			burnedAmount:     2,
+			creds:            []verify.Verifiable{cred1},
+			appliedLockState: locked.StateBonded,
+		},
 		"outs have stakable.LockedOut": {
 			utxos: []*avax.UTXO{
 				generateTestUTXO(ids.ID{1}, assetID, 10, outputOwners1, ids.Empty, ids.Empty),
@@ -710,8 +1405,10 @@ func TestVerifyLockUTXOs(t *testing.T) {
 
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
-			err := testHandler.VerifyLockUTXOs(
+			err := testHandler.verifyLockUTXOs(
 				tx,
+				nil,
+				chainTime,
 				test.utxos,
 				test.ins,
 				test.outs,
@@ -725,6 +1422,63 @@ func TestVerifyLockUTXOs(t *testing.T) {
 	}
 }
 
+func TestVerifyMultisigTransfers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	internalState := state.NewMockState(ctrl)
+	internalState.EXPECT().GetMultisigAlias(gomock.Any()).Return(nil, database.ErrNotFound).AnyTimes()
+	testHandler := defaultCaminoHandler(t, internalState)
+	assetID := testHandler.ctx.AVAXAssetID
+
+	tx := &dummyUnsignedTx{txs.BaseTx{}}
+	tx.Initialize([]byte{0})
+
+	sigIndices := []uint32{0}
+	numTransfers := 16
+	transfers := make([]multisigTransfer, numTransfers)
+	for i := range transfers {
+		owners, cred := generateOwnersAndSig(tx)
+		utxo := generateTestUTXO(ids.ID{byte(i)}, assetID, 10, owners, ids.Empty, ids.Empty)
+		transfer, err := newMultisigTransfer(generateTestInFromUTXO(utxo, sigIndices).In, cred, utxo.Out)
+		require.NoError(t, err)
+		transfers[i] = transfer
+	}
+
+	tests := map[string]struct {
+		transfers   func() []multisigTransfer
+		expectedErr error
+	}{
+		"OK": {
+			transfers: func() []multisigTransfer { return transfers },
+		},
+		"OK: no transfers": {
+			transfers: func() []multisigTransfer { return nil },
+		},
+		"Fail: one transfer has wrong credential": {
+			transfers: func() []multisigTransfer {
+				badTransfers := append([]multisigTransfer{}, transfers...)
+				badTransfers[numTransfers/2].cred = transfers[0].cred
+				return badTransfers
+			},
+			expectedErr: secp256k1fx.ErrSignerNotInAlias,
+		},
+		"Fail: first error is returned": {
+			transfers: func() []multisigTransfer {
+				badTransfers := append([]multisigTransfer{}, transfers...)
+				badTransfers[1].cred = transfers[0].cred
+				badTransfers[numTransfers-1].in = nil
+				return badTransfers
+			},
+			expectedErr: secp256k1fx.ErrSignerNotInAlias,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := testHandler.verifyMultisigTransfers(tx, nil, tt.transfers())
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestGetDepositUnlockableAmounts(t *testing.T) {
 	config := defaultConfig()
 	ctx := snow.DefaultContextTest()
@@ -861,9 +1615,9 @@ func TestUnlockDeposit(t *testing.T) {
 	nowMinus10m := uint64(time.Now().Add(-10 * time.Minute).Unix())
 
 	type args struct {
-		state        func(*gomock.Controller) state.Chain
-		keys         []*crypto.PrivateKeySECP256K1R
-		depositTxIDs []ids.ID
+		state           func(*gomock.Controller) state.Chain
+		keys            []*crypto.PrivateKeySECP256K1R
+		amountsToUnlock map[ids.ID]uint64
 	}
 	sigIndices := []uint32{0}
 
@@ -894,8 +1648,8 @@ func TestUnlockDeposit(t *testing.T) {
 					s.EXPECT().LockedUTXOs(depositTxSet, gomock.Any(), locked.StateDeposited).Return(nil, fmt.Errorf("%w: %s", state.ErrMissingParentState, testID))
 					return s
 				},
-				keys:         preFundedKeys,
-				depositTxIDs: []ids.ID{testID},
+				keys:            preFundedKeys,
+				amountsToUnlock: map[ids.ID]uint64{testID: 0},
 			},
 			err: fmt.Errorf("%w: %s", state.ErrMissingParentState, testID),
 		},
@@ -920,8 +1674,8 @@ func TestUnlockDeposit(t *testing.T) {
 					s.EXPECT().LockedUTXOs(depositTxSet, gomock.Any(), locked.StateDeposited).Return(depositedUTXOs, nil)
 					return s
 				},
-				keys:         []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
-				depositTxIDs: []ids.ID{testID},
+				keys:            []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				amountsToUnlock: map[ids.ID]uint64{testID: 0},
 			},
 			want: []*avax.TransferableInput{
 				generateTestInFromUTXO(depositedUTXOs[0], sigIndices),
@@ -932,6 +1686,61 @@ func TestUnlockDeposit(t *testing.T) {
 			},
 			want2: [][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}},
 		},
+		"Successful unlock of requested amount": {
+			args: args{
+				state: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					deposit1 := deposit.Deposit{
+						DepositOfferID: testID,
+						Start:          nowMinus10m,
+						Duration:       uint32((15 * time.Minute).Seconds()),
+						Amount:         depositedAmount,
+					}
+					depositTxSet := set.NewSet[ids.ID](1)
+					depositTxSet.Add(testID)
+
+					s.EXPECT().GetDeposit(testID).Return(&deposit1, nil)
+					s.EXPECT().GetDepositOffer(testID).Return(&deposit.Offer{
+						Start:                nowMinus10m,
+						UnlockPeriodDuration: uint32((10 * time.Minute).Seconds()),
+					}, nil)
+					s.EXPECT().LockedUTXOs(depositTxSet, gomock.Any(), locked.StateDeposited).Return(depositedUTXOs, nil)
+					return s
+				},
+				keys:            []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				amountsToUnlock: map[ids.ID]uint64{testID: depositedAmount / 4},
+			},
+			want: []*avax.TransferableInput{
+				generateTestInFromUTXO(depositedUTXOs[0], sigIndices),
+			},
+			want1: []*avax.TransferableOutput{
+				generateTestOut(ctx.AVAXAssetID, depositedAmount/4, outputOwners, ids.Empty, ids.Empty),
+				generateTestOut(ctx.AVAXAssetID, depositedAmount*3/4, outputOwners, testID, ids.Empty),
+			},
+			want2: [][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}},
+		},
+		"Requested amount is bigger than unlockable amount": {
+			args: args{
+				state: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					deposit1 := deposit.Deposit{
+						DepositOfferID: testID,
+						Start:          nowMinus10m,
+						Duration:       uint32((15 * time.Minute).Seconds()),
+						Amount:         depositedAmount,
+					}
+					s.EXPECT().GetDeposit(testID).Return(&deposit1, nil)
+					s.EXPECT().GetDepositOffer(testID).Return(&deposit.Offer{
+						Start:                nowMinus10m,
+						UnlockPeriodDuration: uint32((10 * time.Minute).Seconds()),
+					}, nil)
+					return s
+				},
+				keys:            []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				amountsToUnlock: map[ids.ID]uint64{testID: depositedAmount/2 + 1},
+			},
+			err: errUnlockedMoreThanAvailable,
+		},
 		"Successful full unlock": {
 			args: args{
 				state: func(ctrl *gomock.Controller) state.Chain {
@@ -953,8 +1762,8 @@ func TestUnlockDeposit(t *testing.T) {
 					s.EXPECT().LockedUTXOs(depositTxSet, gomock.Any(), locked.StateDeposited).Return(depositedUTXOs, nil)
 					return s
 				},
-				keys:         []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
-				depositTxIDs: []ids.ID{testID},
+				keys:            []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				amountsToUnlock: map[ids.ID]uint64{testID: 0},
 			},
 			want: []*avax.TransferableInput{
 				generateTestInFromUTXO(depositedUTXOs[0], sigIndices),
@@ -968,7 +1777,7 @@ func TestUnlockDeposit(t *testing.T) {
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
-			got, got1, got2, err := testHandler.UnlockDeposit(tt.args.state(ctrl), tt.args.keys, tt.args.depositTxIDs)
+			got, got1, got2, err := testHandler.UnlockDeposit(tt.args.state(ctrl), tt.args.keys, tt.args.amountsToUnlock)
 			if tt.err != nil {
 				require.ErrorContains(t, err, tt.err.Error())
 				return
@@ -982,6 +1791,159 @@ func TestUnlockDeposit(t *testing.T) {
 	}
 }
 
+func TestVerifyLockBalance(t *testing.T) {
+	assetID := ids.ID{'a'}
+	owner := defaultOwners()
+	depositTxID := ids.ID{'d'}
+	sigIndices := []uint32{0}
+
+	unlockedUTXO := generateTestUTXO(ids.ID{1}, assetID, 10, owner, ids.Empty, ids.Empty)
+	depositedUTXO := generateTestUTXO(ids.ID{2}, assetID, 10, owner, depositTxID, ids.Empty)
+
+	tests := map[string]struct {
+		utxos            []*avax.UTXO
+		ins              []*avax.TransferableInput
+		outs             []*avax.TransferableOutput
+		burnedAmount     uint64
+		appliedLockState locked.State
+		expectedErr      error
+	}{
+		"OK": {
+			utxos: []*avax.UTXO{unlockedUTXO, depositedUTXO},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, ids.Empty, ids.Empty, sigIndices),
+				generateTestIn(assetID, 10, depositTxID, ids.Empty, sigIndices),
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 9, owner, ids.Empty, locked.ThisTxID),
+				generateTestOut(assetID, 10, owner, depositTxID, locked.ThisTxID),
+			},
+			burnedAmount:     1,
+			appliedLockState: locked.StateBonded,
+		},
+		"Wrong lock state": {
+			appliedLockState: locked.StateDepositedBonded,
+			expectedErr:      errInvalidTargetLockState,
+		},
+		"Inputs and utxos count mismatch": {
+			utxos: []*avax.UTXO{unlockedUTXO},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, ids.Empty, ids.Empty, sigIndices),
+				generateTestIn(assetID, 10, depositTxID, ids.Empty, sigIndices),
+			},
+			appliedLockState: locked.StateBonded,
+			expectedErr:      errInputsUTXOSMismatch,
+		},
+		"Input lock ids mismatch": {
+			utxos: []*avax.UTXO{depositedUTXO},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, ids.GenerateTestID(), ids.Empty, sigIndices),
+			},
+			appliedLockState: locked.StateBonded,
+			expectedErr:      errLockIDsMismatch,
+		},
+		"Deposited funds are transferred unlocked": {
+			utxos: []*avax.UTXO{depositedUTXO},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, depositTxID, ids.Empty, sigIndices),
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 10, owner, ids.Empty, locked.ThisTxID),
+			},
+			appliedLockState: locked.StateBonded,
+			expectedErr:      errWrongProducedAmount,
+		},
+		"Not burned enough": {
+			utxos: []*avax.UTXO{unlockedUTXO},
+			ins: []*avax.TransferableInput{
+				generateTestIn(assetID, 10, ids.Empty, ids.Empty, sigIndices),
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 10, owner, ids.Empty, locked.ThisTxID),
+			},
+			burnedAmount:     1,
+			appliedLockState: locked.StateBonded,
+			expectedErr:      errNotBurnedEnough,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyLockBalance(tt.utxos, tt.ins, tt.outs, tt.burnedAmount, assetID, tt.appliedLockState)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestVerifyNotRetiredOutsOwners(t *testing.T) {
+	assetID := ids.ID{'a'}
+	owner := defaultOwners()
+	retiredAliasOwner := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{{'r'}},
+	}
+	testErr := errors.New("test err")
+
+	tests := map[string]struct {
+		linkGetter  func(*gomock.Controller) ShortIDLinkGetter
+		outs        []*avax.TransferableOutput
+		expectedErr error
+	}{
+		"OK": {
+			linkGetter: func(c *gomock.Controller) ShortIDLinkGetter {
+				s := state.NewMockChain(c)
+				s.EXPECT().GetShortIDLink(owner.Addrs[0], state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortEmpty, database.ErrNotFound).Times(2)
+				return s
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 1, owner, ids.Empty, ids.Empty),
+				generateTestOut(assetID, 1, owner, ids.Empty, locked.ThisTxID),
+			},
+		},
+		"OK: no link getter": {
+			linkGetter: func(c *gomock.Controller) ShortIDLinkGetter { return nil },
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 1, retiredAliasOwner, ids.Empty, ids.Empty),
+			},
+		},
+		"Fail to get link": {
+			linkGetter: func(c *gomock.Controller) ShortIDLinkGetter {
+				s := state.NewMockChain(c)
+				s.EXPECT().GetShortIDLink(owner.Addrs[0], state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortEmpty, testErr)
+				return s
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 1, owner, ids.Empty, ids.Empty),
+			},
+			expectedErr: testErr,
+		},
+		"Locked out is owned by retired alias": {
+			linkGetter: func(c *gomock.Controller) ShortIDLinkGetter {
+				s := state.NewMockChain(c)
+				s.EXPECT().GetShortIDLink(owner.Addrs[0], state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortEmpty, database.ErrNotFound)
+				s.EXPECT().GetShortIDLink(retiredAliasOwner.Addrs[0], state.ShortLinkKeyMultisigAliasSuccessor).
+					Return(ids.ShortID{'s'}, nil)
+				return s
+			},
+			outs: []*avax.TransferableOutput{
+				generateTestOut(assetID, 1, owner, ids.Empty, ids.Empty),
+				generateTestOut(assetID, 1, retiredAliasOwner, ids.Empty, locked.ThisTxID),
+			},
+			expectedErr: ErrAliasRetired,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			err := verifyNotRetiredOutsOwners(tt.linkGetter(ctrl), tt.outs)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
 func TestVerifyUnlockDepositedUTXOs(t *testing.T) {
 	assetID := snow.DefaultContextTest().AVAXAssetID
 	tx := &dummyUnsignedTx{txs.BaseTx{}}
@@ -1005,6 +1967,12 @@ func TestVerifyUnlockDepositedUTXOs(t *testing.T) {
 	depositNotExpiredTime := deposit1.EndTime().Add(-1 * time.Second)
 	depositExpiredTime := deposit1.StartTime().Add(time.Duration(deposit1.Duration) * time.Second)
 	unlockableAmount := deposit1.UnlockableAmount(depositOffer, uint64(depositNotExpiredTime.Unix()))
+	earlyUnlockDepositOffer := &deposit.Offer{
+		UnlockPeriodDuration:        deposit1.Duration / 2,
+		Flags:                       deposit.OfferFlagEarlyUnlock,
+		EarlyUnlockPenaltyNominator: deposit.EarlyUnlockPenaltyDenominator / 10,
+	}
+	earlyUnlockPenalty := earlyUnlockDepositOffer.EarlyUnlockPenalty(deposit1.Amount - unlockableAmount)
 
 	type args struct {
 		chainState   func(ctrl *gomock.Controller) state.Chain
@@ -1212,6 +2180,106 @@ func TestVerifyUnlockDepositedUTXOs(t *testing.T) {
 			},
 			err: fmt.Errorf("unlockedDepositAmount %d > %d unlockableAmount: %w", unlockableAmount+1, unlockableAmount, errUnlockedMoreThanAvailable),
 		},
+		"Partial early unlock": {
+			args: args{
+				chainState: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					s.EXPECT().GetTimestamp().Return(depositNotExpiredTime)
+					s.EXPECT().GetDeposit(depositID).Return(deposit1, nil)
+					s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(earlyUnlockDepositOffer, nil)
+					return s
+				},
+				tx: tx,
+				utxos: []*avax.UTXO{
+					generateTestUTXO(ids.ID{9, 9}, assetID, deposit1.Amount-1, outputOwners, depositID, ids.Empty),
+				},
+				ins: []*avax.TransferableInput{
+					generateTestIn(assetID, deposit1.Amount-1, depositID, ids.Empty, sigIndices),
+				},
+				outs: []*avax.TransferableOutput{
+					generateTestOut(assetID, deposit1.Amount-1-earlyUnlockPenalty, outputOwners, ids.Empty, ids.Empty),
+					generateTestOut(assetID, earlyUnlockPenalty, *treasury.Owner, ids.Empty, ids.Empty),
+				},
+				creds:   []verify.Verifiable{cred1},
+				assetID: assetID,
+			},
+			err: errPartialEarlyUnlock,
+		},
+		"Early unlock penalty isn't transferred to treasury": {
+			args: args{
+				chainState: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					s.EXPECT().GetTimestamp().Return(depositNotExpiredTime)
+					s.EXPECT().GetDeposit(depositID).Return(deposit1, nil)
+					s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(earlyUnlockDepositOffer, nil)
+					return s
+				},
+				tx: tx,
+				utxos: []*avax.UTXO{
+					generateTestUTXO(ids.ID{9, 9}, assetID, deposit1.Amount, outputOwners, depositID, ids.Empty),
+				},
+				ins: []*avax.TransferableInput{
+					generateTestIn(assetID, deposit1.Amount, depositID, ids.Empty, sigIndices),
+				},
+				outs: []*avax.TransferableOutput{
+					generateTestOut(assetID, deposit1.Amount-earlyUnlockPenalty, outputOwners, ids.Empty, ids.Empty),
+					generateTestOut(assetID, earlyUnlockPenalty-1, *treasury.Owner, ids.Empty, ids.Empty),
+				},
+				creds:   []verify.Verifiable{cred1},
+				assetID: assetID,
+			},
+			err: errEarlyUnlockPenaltyNotPaid,
+		},
+		"Early unlock penalty is paid from unlocked deposited tokens, that are also produced": {
+			args: args{
+				chainState: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					s.EXPECT().GetTimestamp().Return(depositNotExpiredTime)
+					s.EXPECT().GetDeposit(depositID).Return(deposit1, nil)
+					s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(earlyUnlockDepositOffer, nil)
+					return s
+				},
+				tx: tx,
+				utxos: []*avax.UTXO{
+					generateTestUTXO(ids.ID{9, 9}, assetID, deposit1.Amount, outputOwners, depositID, ids.Empty),
+				},
+				ins: []*avax.TransferableInput{
+					generateTestIn(assetID, deposit1.Amount, depositID, ids.Empty, sigIndices),
+				},
+				outs: []*avax.TransferableOutput{
+					generateTestOut(assetID, deposit1.Amount, outputOwners, ids.Empty, ids.Empty),
+					generateTestOut(assetID, earlyUnlockPenalty, *treasury.Owner, ids.Empty, ids.Empty),
+				},
+				creds:   []verify.Verifiable{cred1},
+				assetID: assetID,
+			},
+			err: errEarlyUnlockPenaltyNotPaid,
+		},
+		"Success (early unlock)": {
+			args: args{
+				chainState: func(ctrl *gomock.Controller) state.Chain {
+					s := state.NewMockChain(ctrl)
+					s.EXPECT().GetTimestamp().Return(depositNotExpiredTime)
+					s.EXPECT().GetDeposit(depositID).Return(deposit1, nil)
+					s.EXPECT().GetDepositOffer(deposit1.DepositOfferID).Return(earlyUnlockDepositOffer, nil)
+					return s
+				},
+				tx: tx,
+				utxos: []*avax.UTXO{
+					generateTestUTXO(ids.ID{9, 9}, assetID, deposit1.Amount, outputOwners, depositID, ids.Empty),
+				},
+				ins: []*avax.TransferableInput{
+					generateTestIn(assetID, deposit1.Amount, depositID, ids.Empty, sigIndices),
+				},
+				outs: []*avax.TransferableOutput{
+					generateTestOut(assetID, deposit1.Amount-earlyUnlockPenalty, outputOwners, ids.Empty, ids.Empty),
+					generateTestOut(assetID, earlyUnlockPenalty, *treasury.Owner, ids.Empty, ids.Empty),
+				},
+				creds:   []verify.Verifiable{cred1},
+				assetID: assetID,
+			},
+			want: map[ids.ID]uint64{depositID: deposit1.Amount},
+		},
 		"Produces outputs exceed inputs (deposit expired)": {
 			args: args{
 				chainState: func(ctrl *gomock.Controller) state.Chain {
@@ -0,0 +1,75 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxo
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/metric"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// handlerMetrics allows to quantify utxo set growth caused by utxo handler.
+// Nil handlerMetrics doesn't record anything.
+type handlerMetrics struct {
+	lockInputs          metric.Averager
+	lockChangeOutputs   prometheus.Counter
+	lockDustOutputs     prometheus.Counter
+	unlockDepositAmount metric.Averager
+}
+
+func newHandlerMetrics(
+	namespace string,
+	registerer prometheus.Registerer,
+) (*handlerMetrics, error) {
+	errs := wrappers.Errs{}
+	m := &handlerMetrics{
+		lockInputs: metric.NewAveragerWithErrs(
+			namespace,
+			"lock_inputs",
+			"number of inputs consumed by lock",
+			registerer,
+			&errs,
+		),
+		lockChangeOutputs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "lock_change_outputs",
+			Help:      "Number of unlocked change outputs created by lock",
+		}),
+		lockDustOutputs: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "lock_dust_outputs",
+			Help:      "Number of unlocked change outputs with amount lower than min change amount, burned by lock",
+		}),
+		unlockDepositAmount: metric.NewAveragerWithErrs(
+			namespace,
+			"unlock_deposit_amount",
+			"amount unlocked by unlock deposit",
+			registerer,
+			&errs,
+		),
+	}
+
+	errs.Add(
+		registerer.Register(m.lockChangeOutputs),
+		registerer.Register(m.lockDustOutputs),
+	)
+	return m, errs.Err
+}
+
+func (m *handlerMetrics) observeLock(numIns, numChangeOuts, numDustOuts int) {
+	if m == nil {
+		return
+	}
+	m.lockInputs.Observe(float64(numIns))
+	m.lockChangeOutputs.Add(float64(numChangeOuts))
+	m.lockDustOutputs.Add(float64(numDustOuts))
+}
+
+func (m *handlerMetrics) observeUnlockDeposit(amount uint64) {
+	if m == nil {
+		return
+	}
+	m.unlockDepositAmount.Observe(float64(amount))
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2022-2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package utxo
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerMetrics(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	m, err := newHandlerMetrics("utxo_handler", registry)
+	require.NoError(err)
+
+	m.observeLock(2, 1, 3)
+	m.observeLock(4, 1, 0)
+	m.observeUnlockDeposit(100)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+
+	values := map[string]float64{}
+	for _, family := range families {
+		for _, metric := range family.Metric {
+			switch {
+			case metric.Counter != nil:
+				values[family.GetName()] = metric.Counter.GetValue()
+			case metric.Gauge != nil:
+				values[family.GetName()] = metric.Gauge.GetValue()
+			}
+		}
+	}
+	require.Equal(6.0, values["utxo_handler_lock_inputs_sum"])
+	require.Equal(2.0, values["utxo_handler_lock_inputs_count"])
+	require.Equal(2.0, values["utxo_handler_lock_change_outputs"])
+	require.Equal(3.0, values["utxo_handler_lock_dust_outputs"])
+	require.Equal(100.0, values["utxo_handler_unlock_deposit_amount_sum"])
+
+	// nil metrics doesn't record anything
+	var nilMetrics *handlerMetrics
+	nilMetrics.observeLock(1, 1, 1)
+	nilMetrics.observeUnlockDeposit(1)
+
+	_, err = newHandlerMetrics("utxo_handler", registry)
+	require.Error(err)
+}
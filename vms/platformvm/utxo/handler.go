@@ -179,6 +179,10 @@ type handler struct {
 	clk         *mockable.Clock
 	utxosReader avax.UTXOReader
 	fx          fx.Fx
+	// Unlocked AVAX change with lesser amount is burned by Lock
+	minChangeAmount uint64
+	// Could be nil, then no metrics are recorded
+	metrics *handlerMetrics
 }
 
 func (h *handler) Spend(
@@ -487,7 +491,12 @@ func (h *handler) VerifySpend(
 		utxos[index] = utxo
 	}
 
-	return h.VerifySpendUTXOs(tx, utxos, ins, outs, creds, unlockedProduced)
+	if err := h.VerifySpendUTXOs(tx, utxos, ins, outs, creds, unlockedProduced); err != nil {
+		return err
+	}
+
+	linkGetter, _ := utxoDB.(ShortIDLinkGetter)
+	return verifyNotRetiredOutsOwners(linkGetter, outs)
 }
 
 func (h *handler) VerifySpendUTXOs(
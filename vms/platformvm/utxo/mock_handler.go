@@ -60,10 +60,10 @@ func (mr *MockHandlerMockRecorder) Authorize(arg0, arg1, arg2 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorize", reflect.TypeOf((*MockHandler)(nil).Authorize), arg0, arg1, arg2)
 }
 
-// Lock mocks base method.
-func (m *MockHandler) Lock(arg0 []*crypto.PrivateKeySECP256K1R, arg1, arg2 uint64, arg3 locked.State, arg4, arg5 *secp256k1fx.OutputOwners, arg6 uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, error) {
+// Consolidate mocks base method.
+func (m *MockHandler) Consolidate(arg0 []*crypto.PrivateKeySECP256K1R, arg1, arg2 int, arg3, arg4 uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Lock", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret := m.ctrl.Call(m, "Consolidate", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*avax.TransferableInput)
 	ret1, _ := ret[1].([]*avax.TransferableOutput)
 	ret2, _ := ret[2].([][]*crypto.PrivateKeySECP256K1R)
@@ -71,10 +71,81 @@ func (m *MockHandler) Lock(arg0 []*crypto.PrivateKeySECP256K1R, arg1, arg2 uint6
 	return ret0, ret1, ret2, ret3
 }
 
+// Consolidate indicates an expected call of Consolidate.
+func (mr *MockHandlerMockRecorder) Consolidate(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consolidate", reflect.TypeOf((*MockHandler)(nil).Consolidate), arg0, arg1, arg2, arg3, arg4)
+}
+
+// Lock mocks base method.
+func (m *MockHandler) Lock(arg0 []*crypto.PrivateKeySECP256K1R, arg1, arg2 uint64, arg3 locked.State, arg4, arg5 *secp256k1fx.OutputOwners, arg6 int, arg7, arg8 uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, []*secp256k1fx.OutputOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].([]*avax.TransferableInput)
+	ret1, _ := ret[1].([]*avax.TransferableOutput)
+	ret2, _ := ret[2].([][]*crypto.PrivateKeySECP256K1R)
+	ret3, _ := ret[3].([]*secp256k1fx.OutputOwners)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
 // Lock indicates an expected call of Lock.
-func (mr *MockHandlerMockRecorder) Lock(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+func (mr *MockHandlerMockRecorder) Lock(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockHandler)(nil).Lock), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// LockAssets mocks base method.
+func (m *MockHandler) LockAssets(arg0 []*crypto.PrivateKeySECP256K1R, arg1 map[ids.ID]uint64, arg2 uint64, arg3 locked.State, arg4, arg5 *secp256k1fx.OutputOwners, arg6 int, arg7, arg8 uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, []*secp256k1fx.OutputOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAssets", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+	ret0, _ := ret[0].([]*avax.TransferableInput)
+	ret1, _ := ret[1].([]*avax.TransferableOutput)
+	ret2, _ := ret[2].([][]*crypto.PrivateKeySECP256K1R)
+	ret3, _ := ret[3].([]*secp256k1fx.OutputOwners)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// LockAssets indicates an expected call of LockAssets.
+func (mr *MockHandlerMockRecorder) LockAssets(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAssets", reflect.TypeOf((*MockHandler)(nil).LockAssets), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
+}
+
+// LockAssetsFrom mocks base method.
+func (m *MockHandler) LockAssetsFrom(arg0 avax.UTXOReader, arg1 []*crypto.PrivateKeySECP256K1R, arg2 map[ids.ID]uint64, arg3 uint64, arg4 locked.State, arg5, arg6 *secp256k1fx.OutputOwners, arg7 int, arg8, arg9 uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, []*secp256k1fx.OutputOwners, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAssetsFrom", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+	ret0, _ := ret[0].([]*avax.TransferableInput)
+	ret1, _ := ret[1].([]*avax.TransferableOutput)
+	ret2, _ := ret[2].([][]*crypto.PrivateKeySECP256K1R)
+	ret3, _ := ret[3].([]*secp256k1fx.OutputOwners)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// LockAssetsFrom indicates an expected call of LockAssetsFrom.
+func (mr *MockHandlerMockRecorder) LockAssetsFrom(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockHandler)(nil).Lock), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAssetsFrom", reflect.TypeOf((*MockHandler)(nil).LockAssetsFrom), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9)
+}
+
+// Relock mocks base method.
+func (m *MockHandler) Relock(arg0 state.Chain, arg1 []*crypto.PrivateKeySECP256K1R, arg2 []ids.ID, arg3, arg4 locked.State) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Relock", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*avax.TransferableInput)
+	ret1, _ := ret[1].([]*avax.TransferableOutput)
+	ret2, _ := ret[2].([][]*crypto.PrivateKeySECP256K1R)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// Relock indicates an expected call of Relock.
+func (mr *MockHandlerMockRecorder) Relock(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Relock", reflect.TypeOf((*MockHandler)(nil).Relock), arg0, arg1, arg2, arg3, arg4)
 }
 
 // Spend mocks base method.
@@ -111,8 +182,23 @@ func (mr *MockHandlerMockRecorder) Unlock(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockHandler)(nil).Unlock), arg0, arg1, arg2)
 }
 
+// UnlockChunks mocks base method.
+func (m *MockHandler) UnlockChunks(arg0 state.Chain, arg1 []ids.ID, arg2 locked.State, arg3, arg4 int) ([]*UnlockChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockChunks", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*UnlockChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockChunks indicates an expected call of UnlockChunks.
+func (mr *MockHandlerMockRecorder) UnlockChunks(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockChunks", reflect.TypeOf((*MockHandler)(nil).UnlockChunks), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UnlockDeposit mocks base method.
-func (m *MockHandler) UnlockDeposit(arg0 state.Chain, arg1 []*crypto.PrivateKeySECP256K1R, arg2 []ids.ID) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, error) {
+func (m *MockHandler) UnlockDeposit(arg0 state.Chain, arg1 []*crypto.PrivateKeySECP256K1R, arg2 map[ids.ID]uint64) ([]*avax.TransferableInput, []*avax.TransferableOutput, [][]*crypto.PrivateKeySECP256K1R, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UnlockDeposit", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*avax.TransferableInput)
@@ -58,6 +58,21 @@ func (mr *MockVerifierMockRecorder) Unlock(arg0, arg1, arg2 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockVerifier)(nil).Unlock), arg0, arg1, arg2)
 }
 
+// UnlockChunks mocks base method.
+func (m *MockVerifier) UnlockChunks(arg0 state.Chain, arg1 []ids.ID, arg2 locked.State, arg3, arg4 int) ([]*UnlockChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockChunks", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*UnlockChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockChunks indicates an expected call of UnlockChunks.
+func (mr *MockVerifierMockRecorder) UnlockChunks(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockChunks", reflect.TypeOf((*MockVerifier)(nil).UnlockChunks), arg0, arg1, arg2, arg3, arg4)
+}
+
 // VerifyLock mocks base method.
 func (m *MockVerifier) VerifyLock(arg0 txs.UnsignedTx, arg1 state.UTXOGetter, arg2 []*avax.TransferableInput, arg3 []*avax.TransferableOutput, arg4 []verify.Verifiable, arg5 uint64, arg6 ids.ID, arg7 locked.State) error {
 	m.ctrl.T.Helper()
@@ -53,6 +53,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/tenant"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
@@ -76,7 +77,6 @@ var (
 	_ validators.State           = (*VM)(nil)
 	_ validators.SubnetConnector = (*VM)(nil)
 
-	errWrongCacheType      = errors.New("unexpectedly cached type")
 	errMissingValidatorSet = errors.New("missing validator set")
 	errMissingValidator    = errors.New("missing validator")
 )
@@ -108,7 +108,7 @@ type VM struct {
 	// Maps caches for each subnet that is currently whitelisted.
 	// Key: Subnet ID
 	// Value: cache mapping height -> validator set map
-	validatorSetCaches map[ids.ID]cache.Cacher
+	validatorSetCaches map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput]
 
 	// sliding window of blocks that were recently accepted
 	recentlyAccepted window.Window[ids.ID]
@@ -116,6 +116,9 @@ type VM struct {
 	txBuilder         txbuilder.CaminoBuilder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	// Authorizes API requests, nil if there are no registered API tenants
+	apiTenants tenant.Manager
 }
 
 // Initialize this blockchain.
@@ -145,16 +148,32 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to initialize metrics: %w", err)
 	}
 
+	if len(vm.CaminoConfig.APITenants) > 0 {
+		vm.apiTenants, err = tenant.NewManager(vm.CaminoConfig.APITenants, "", registerer)
+		if err != nil {
+			return fmt.Errorf("failed to initialize api tenants: %w", err)
+		}
+	}
+
 	vm.ctx = chainCtx
 	vm.dbManager = dbManager
 
 	vm.codecRegistry = linearcodec.NewCaminoDefault()
-	vm.fx = &secp256k1fx.CaminoFx{}
+	vm.fx, err = fx.NewMeteredFx(
+		&secp256k1fx.CaminoFx{
+			Fx: secp256k1fx.Fx{MaxAliasDepth: vm.CaminoConfig.MaxMultisigAliasDepth},
+		},
+		"fx",
+		registerer,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize fx metrics: %w", err)
+	}
 	if err := vm.fx.Initialize(vm); err != nil {
 		return err
 	}
 
-	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher)
+	vm.validatorSetCaches = make(map[ids.ID]cache.Cacher[uint64, map[ids.NodeID]*validators.GetValidatorOutput])
 	vm.recentlyAccepted = window.New[ids.ID](
 		window.Config{
 			Clock:   &vm.clock,
@@ -177,21 +196,32 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	if vm.CaminoConfig.VerifyStateInvariants {
+		if err := vm.state.VerifyCaminoStateInvariants(); err != nil {
+			return fmt.Errorf("camino state is inconsistent: %w", err)
+		}
+	}
+
 	vm.atomicUtxosManager = avax.NewAtomicUTXOManager(chainCtx.SharedMemory, txs.Codec)
 
 	camCfg, _ := vm.state.CaminoConfig()
-	utxoHandler := utxo.NewCaminoHandler(
+	utxoHandler, err := utxo.NewCaminoHandler(
 		vm.ctx,
 		&vm.clock,
 		vm.state,
 		vm.fx,
 		camCfg != nil && camCfg.LockModeBondDeposit,
+		vm.CaminoConfig.MinChangeAmount,
+		registerer,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize utxo handler: %w", err)
+	}
 
 	vm.uptimeManager = uptime.NewManager(vm.state)
 	vm.UptimeLockedCalculator.SetCalculator(&vm.bootstrapped, &chainCtx.Lock, vm.uptimeManager)
 
-	vm.txBuilder = txbuilder.NewCamino(
+	vm.txBuilder, err = txbuilder.NewCamino(
 		vm.ctx,
 		&vm.Config,
 		&vm.clock,
@@ -199,7 +229,12 @@ func (vm *VM) Initialize(
 		vm.state,
 		vm.atomicUtxosManager,
 		utxoHandler,
+		"",
+		registerer,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tx builder: %w", err)
+	}
 
 	vm.txExecutorBackend = &txexecutor.Backend{
 		Config:       &vm.Config,
@@ -444,6 +479,9 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
 	server.RegisterInterceptFunc(vm.metrics.InterceptRequest)
 	server.RegisterAfterFunc(vm.metrics.AfterRequest)
+	if vm.apiTenants != nil {
+		server.RegisterValidateRequestFunc(vm.apiTenants.ValidateRequest)
+	}
 	if err := server.RegisterService(
 		&CaminoService{
 			Service: Service{
@@ -458,7 +496,11 @@ func (vm *VM) CreateHandlers(context.Context) (map[string]*common.HTTPHandler, e
 
 	return map[string]*common.HTTPHandler{
 		"": {
-			Handler: server,
+			// API handlers read state and mempool and issue txs, so they hold the same
+			// context lock as block processing. Because of that they never observe
+			// partially committed state.
+			LockOptions: common.WriteLock,
+			Handler:     server,
 		},
 	}, nil
 }
@@ -502,18 +544,14 @@ func (vm *VM) Disconnected(_ context.Context, nodeID ids.NodeID) error {
 func (vm *VM) GetValidatorSet(ctx context.Context, height uint64, subnetID ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
 	validatorSetsCache, exists := vm.validatorSetCaches[subnetID]
 	if !exists {
-		validatorSetsCache = &cache.LRU{Size: validatorSetsCacheSize}
+		validatorSetsCache = &cache.LRU[uint64, map[ids.NodeID]*validators.GetValidatorOutput]{Size: validatorSetsCacheSize}
 		// Only cache whitelisted subnets
 		if subnetID == constants.PrimaryNetworkID || vm.WhitelistedSubnets.Contains(subnetID) {
 			vm.validatorSetCaches[subnetID] = validatorSetsCache
 		}
 	}
 
-	if validatorSetIntf, ok := validatorSetsCache.Get(height); ok {
-		validatorSet, ok := validatorSetIntf.(map[ids.NodeID]*validators.GetValidatorOutput)
-		if !ok {
-			return nil, errWrongCacheType
-		}
+	if validatorSet, ok := validatorSetsCache.Get(height); ok {
 		vm.metrics.IncValidatorSetsCached()
 		return validatorSet, nil
 	}
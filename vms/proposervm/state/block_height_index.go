@@ -80,7 +80,7 @@ type heightIndex struct {
 	versiondb.Commitable
 
 	// Caches block height -> proposerVMBlockID.
-	heightsCache cache.Cacher
+	heightsCache cache.Cacher[uint64, ids.ID]
 
 	heightDB   database.Database
 	metadataDB database.Database
@@ -90,7 +90,7 @@ func NewHeightIndex(db database.Database, commitable versiondb.Commitable) Heigh
 	return &heightIndex{
 		Commitable: commitable,
 
-		heightsCache: &cache.LRU{Size: cacheSize},
+		heightsCache: &cache.LRU[uint64, ids.ID]{Size: cacheSize},
 		heightDB:     prefixdb.New(heightPrefix, db),
 		metadataDB:   prefixdb.New(metadataPrefix, db),
 	}
@@ -193,9 +193,8 @@ func (hi *heightIndex) ResetHeightIndex(log logging.Logger, baseDB versiondb.Com
 }
 
 func (hi *heightIndex) GetBlockIDAtHeight(height uint64) (ids.ID, error) {
-	if blkIDIntf, found := hi.heightsCache.Get(height); found {
-		res, _ := blkIDIntf.(ids.ID)
-		return res, nil
+	if blkID, found := hi.heightsCache.Get(height); found {
+		return blkID, nil
 	}
 
 	key := database.PackUInt64(height)
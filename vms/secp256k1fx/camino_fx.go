@@ -6,6 +6,7 @@ package secp256k1fx
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/database"
@@ -28,10 +29,53 @@ type Owned interface {
 	Owners() interface{}
 }
 
+// MultisigOwners is a control group, which permission can be proven with
+// multisig credentials: *OutputOwners, *WeightedOutputOwners or *TimeLockedOutputOwners.
+type MultisigOwners interface {
+	verify.Verifiable
+
+	multisigOwners() (*OutputOwners, *memberRules)
+}
+
 type AliasGetter interface {
 	GetMultisigAlias(ids.ShortID) (*multisig.Alias, error)
 }
 
+type chainTimestamper interface {
+	GetTimestamp() time.Time
+}
+
+// AliasUsageRecorder could be implemented by AliasGetter passed to fx verification methods
+// to be notified about multisig aliases, which met their threshold and contributed
+// to verification of owners. Implementation must be safe for concurrent use.
+type AliasUsageRecorder interface {
+	RecordAliasUsage(aliasID ids.ShortID)
+}
+
+// aliasGetterWrapper is implemented by AliasGetter, that wraps other one.
+type aliasGetterWrapper interface {
+	unwrapAliasGetter() AliasGetter
+}
+
+type recordingAliasGetter struct {
+	AliasGetter
+	recorder AliasUsageRecorder
+}
+
+// WithAliasUsageRecorder returns [msig], which reports aliases used by verification to [recorder].
+// Chain time provided by [msig], if any, is still used by verification.
+func WithAliasUsageRecorder(msig AliasGetter, recorder AliasUsageRecorder) AliasGetter {
+	return &recordingAliasGetter{AliasGetter: msig, recorder: recorder}
+}
+
+func (g *recordingAliasGetter) RecordAliasUsage(aliasID ids.ShortID) {
+	g.recorder.RecordAliasUsage(aliasID)
+}
+
+func (g *recordingAliasGetter) unwrapAliasGetter() AliasGetter {
+	return g.AliasGetter
+}
+
 type (
 	RecoverMap map[ids.ShortID][crypto.SECP256K1RSigLen]byte
 )
@@ -123,98 +167,75 @@ func (*Fx) VerifyMultisigOwner(outIntf, msigIntf interface{}) error {
 	return nil
 }
 
-func (fx *Fx) VerifyMultisigTransfer(txIntf, inIntf, credIntf, utxoIntf, msigIntf interface{}) error {
-	tx, ok := txIntf.(UnsignedTx)
-	if !ok {
-		return errWrongTxType
-	}
-	in, ok := inIntf.(*TransferInput)
-	if !ok {
-		return errWrongInputType
-	}
-	cred, ok := credIntf.(CredentialIntf)
-	if !ok {
+func (fx *Fx) VerifyMultisigTransfer(
+	tx UnsignedTx,
+	in *TransferInput,
+	cred CredentialIntf,
+	out *TransferOutput,
+	msig AliasGetter,
+) error {
+	if cred == nil {
 		return errWrongCredentialType
 	}
-	out, ok := utxoIntf.(*TransferOutput)
-	if !ok {
-		return errWrongUTXOType
-	}
-
-	msig, ok := msigIntf.(AliasGetter)
-	if !ok {
-		return errNotAliasGetter
-	}
-
 	if err := verify.All(out, in, cred); err != nil {
 		return err
 	} else if out.Amt != in.Amt {
 		return fmt.Errorf("out amount and input differ")
 	}
 
-	return fx.verifyMultisigCredentials(tx, &in.Input, cred, &out.OutputOwners, msig)
+	return fx.verifyMultisigCredentials(tx, &in.Input, cred, &out.OutputOwners, nil, msig)
 }
 
-func (fx *Fx) VerifyMultisigPermission(txIntf, inIntf, credIntf, ownerIntf, msigIntf interface{}) error {
-	tx, ok := txIntf.(UnsignedTx)
-	if !ok {
-		return errWrongTxType
-	}
-	in, ok := inIntf.(*Input)
-	if !ok {
-		return errWrongInputType
-	}
-	cred, ok := credIntf.(CredentialIntf)
-	if !ok {
+func (fx *Fx) VerifyMultisigPermission(
+	tx UnsignedTx,
+	in *Input,
+	cred CredentialIntf,
+	owners MultisigOwners,
+	msig AliasGetter,
+) error {
+	switch {
+	case cred == nil:
 		return errWrongCredentialType
+	case owners == nil:
+		return errWrongOwnerType
 	}
-	owners, ok := ownerIntf.(*OutputOwners)
-	if !ok {
-		return errWrongUTXOType
-	}
-
-	msig, ok := msigIntf.(AliasGetter)
-	if !ok {
-		return errNotAliasGetter
-	}
-
 	if err := verify.All(owners, in, cred); err != nil {
 		return err
 	}
 
-	return fx.verifyMultisigCredentials(tx, in, cred, owners, msig)
+	outputOwners, rules := owners.multisigOwners()
+	return fx.verifyMultisigCredentials(tx, in, cred, outputOwners, rules, msig)
 }
 
-func (fx *Fx) VerifyMultisigUnorderedPermission(txIntf, credIntf, ownerIntf, msigIntf interface{}) error {
-	tx, ok := txIntf.(UnsignedTx)
-	if !ok {
-		return errWrongTxType
-	}
-	cred, ok := credIntf.([]verify.Verifiable)
-	if !ok {
-		return errWrongCredentialType
+func (fx *Fx) VerifyMultisigUnorderedPermission(
+	tx UnsignedTx,
+	creds []verify.Verifiable,
+	owners MultisigOwners,
+	msig AliasGetter,
+) error {
+	if owners == nil {
+		return errWrongOwnerType
 	}
-	owners, ok := ownerIntf.(*OutputOwners)
-	if !ok {
-		return errWrongUTXOType
-	}
-	msig, ok := msigIntf.(AliasGetter)
-	if !ok {
-		return errNotAliasGetter
-	}
-
 	if err := owners.Verify(); err != nil {
 		return err
 	}
 
-	if err := verify.All(cred...); err != nil {
+	if err := verify.All(creds...); err != nil {
 		return err
 	}
 
-	return fx.verifyMultisigUnorderedCredentials(tx, cred, owners, msig)
+	outputOwners, rules := owners.multisigOwners()
+	return fx.verifyMultisigUnorderedCredentials(tx, creds, outputOwners, rules, msig)
 }
 
-func (fx *Fx) verifyMultisigCredentials(tx UnsignedTx, in *Input, cred CredentialIntf, owners *OutputOwners, msig AliasGetter) error {
+func (fx *Fx) verifyMultisigCredentials(
+	tx UnsignedTx,
+	in *Input,
+	cred CredentialIntf,
+	owners *OutputOwners,
+	rules *memberRules,
+	msig AliasGetter,
+) error {
 	sigIdxs := cred.SignatureIndices()
 	if sigIdxs == nil {
 		sigIdxs = in.SigIndices
@@ -247,18 +268,27 @@ func (fx *Fx) verifyMultisigCredentials(tx UnsignedTx, in *Input, cred Credentia
 		return false, nil
 	}
 
-	sigsVerified, err := TraverseOwners(owners, msig, tf)
-	if err != nil {
-		return err
+	members := map[ids.ShortID]bool{}
+	sigsVerified, err := traverseOwners(owners, rules, msig, fx.MaxAliasDepth, fx.chainTimeFn(msig), tf, members)
+	if err == nil && sigsVerified < uint32(len(sigIdxs)) {
+		err = errTooManySigners
 	}
-	if sigsVerified < uint32(len(sigIdxs)) {
-		return errTooManySigners
+	if err == ErrThresholdNotMet || err == errTooManySigners {
+		// more specific error, if credential is signed by someone, who can't sign for owners
+		if signersErr := verifySigners(members, resolved); signersErr != nil {
+			return signersErr
+		}
 	}
-
-	return nil
+	return err
 }
 
-func (fx *Fx) verifyMultisigUnorderedCredentials(tx UnsignedTx, creds []verify.Verifiable, owners *OutputOwners, msig AliasGetter) error {
+func (fx *Fx) verifyMultisigUnorderedCredentials(
+	tx UnsignedTx,
+	creds []verify.Verifiable,
+	owners *OutputOwners,
+	rules *memberRules,
+	msig AliasGetter,
+) error {
 	resolved, err := fx.RecoverAddresses(tx, creds)
 	if err != nil {
 		return err
@@ -271,11 +301,33 @@ func (fx *Fx) verifyMultisigUnorderedCredentials(tx UnsignedTx, creds []verify.V
 		return false, nil
 	}
 
-	if _, err = TraverseOwners(owners, msig, tf); err != nil {
-		return err
+	members := map[ids.ShortID]bool{}
+	_, err = traverseOwners(owners, rules, msig, fx.MaxAliasDepth, fx.chainTimeFn(msig), tf, members)
+	if err == ErrThresholdNotMet {
+		// more specific error, if credentials are signed by someone, who can't sign for owners
+		if signersErr := verifySigners(members, resolved); signersErr != nil {
+			return signersErr
+		}
 	}
+	return err
+}
 
-	return nil
+// chainTimeFn returns func, that returns current chain time as unix timestamp, if [msig] or getter wrapped by it provides it,
+// otherwise fx clock time. It is used to check if time-locked alias members are active.
+func (fx *Fx) chainTimeFn(msig AliasGetter) func() uint64 {
+	return func() uint64 {
+		getter := msig
+		for {
+			if timestamper, ok := getter.(chainTimestamper); ok {
+				return uint64(timestamper.GetTimestamp().Unix())
+			}
+			wrapper, ok := getter.(aliasGetterWrapper)
+			if !ok {
+				return fx.VM.Clock().Unix()
+			}
+			getter = wrapper.unwrapAliasGetter()
+		}
+	}
 }
 
 // ExtractFromAndSigners splits an array of PrivateKeys into `from` and `signers`
@@ -29,6 +29,9 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 	_, aliasAddr2 := generateKey(t)
 	tx := &TestTx{}
 	txHash := hashing.ComputeHash256(tx.Bytes())
+	// defaultFx clock time is in 2019
+	expiredTime := uint64(time.Date(2018, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())
+	pendingTime := uint64(time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC).Unix())
 
 	noAliasesMsigGetter := func(c *gomock.Controller) AliasGetter {
 		msig := NewMockAliasGetter(c)
@@ -90,7 +93,7 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				Addrs:     []ids.ShortID{addr1, addr2},
 			},
 			msig:          noAliasesMsigGetter,
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
 		},
 		"Fail: Wrong signature": {
 			in:      &Input{SigIndices: []uint32{0, 1}},
@@ -100,7 +103,17 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				Addrs:     []ids.ShortID{addr1, addr2},
 			},
 			msig:          noAliasesMsigGetter,
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
+		},
+		"Fail: Signer isn't owner": {
+			in:      &Input{SigIndices: []uint32{0, 1}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key3},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, addr2},
+			},
+			msig:          noAliasesMsigGetter,
+			expectedError: ErrSignerNotInAlias,
 		},
 		"Fail: Signature index points to wrong signature": {
 			in:      &Input{SigIndices: []uint32{0, 0}},
@@ -110,7 +123,7 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				Addrs:     []ids.ShortID{addr1, addr2},
 			},
 			msig:          noAliasesMsigGetter,
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
 		},
 		"OK msig: addr1, alias1{addr2, addr3, thresh: 1}": {
 			in:      &Input{SigIndices: []uint32{0, 1}},
@@ -150,6 +163,144 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				return msig
 			},
 		},
+		"OK weighted msig: addr1, alias1{addr2: 3, addr3: 1, thresh: 3}": {
+			in:      &Input{SigIndices: []uint32{0, 1}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key2},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &WeightedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 3,
+							Addrs:     []ids.ShortID{addr2, addr3},
+						},
+						Weights: []uint32{3, 1},
+					},
+				}})
+				return msig
+			},
+		},
+		"OK weighted msig: addr1, alias1{addr2: 1, addr3: 2, thresh: 3}": {
+			in:      &Input{SigIndices: []uint32{0, 1, 2}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key2, key3},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &WeightedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 3,
+							Addrs:     []ids.ShortID{addr2, addr3},
+						},
+						Weights: []uint32{1, 2},
+					},
+				}})
+				return msig
+			},
+		},
+		"Fail weighted msig: addr1, alias1{addr2: 1, addr3: 1, addr4: 2, thresh: 3} (addr2, addr3 signed)": {
+			in:      &Input{SigIndices: []uint32{0, 1, 2}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key2, key3},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &WeightedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 3,
+							Addrs:     []ids.ShortID{addr2, addr3, addr4},
+						},
+						Weights: []uint32{1, 1, 2},
+					},
+				}})
+				return msig
+			},
+			expectedError: ErrThresholdNotMet,
+		},
+		"OK time-locked msig: addr1, alias1{addr2 (expired), addr3, thresh: 1}": {
+			in:      &Input{SigIndices: []uint32{0, 2}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key3},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &TimeLockedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{addr2, addr3},
+						},
+						ActiveFrom:  []uint64{0, 0},
+						ActiveUntil: []uint64{expiredTime, 0},
+					},
+				}})
+				return msig
+			},
+		},
+		"Fail time-locked msig: addr1, alias1{addr2 (expired), addr3, thresh: 1} (addr2 signed)": {
+			in:      &Input{SigIndices: []uint32{0, 1}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key2},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &TimeLockedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{addr2, addr3},
+						},
+						ActiveFrom:  []uint64{0, 0},
+						ActiveUntil: []uint64{expiredTime, 0},
+					},
+				}})
+				return msig
+			},
+			expectedError: ErrMemberNotActive,
+		},
+		"Fail time-locked msig: addr1, alias1{addr3, addr4 (not active yet), thresh: 1} (addr4 signed)": {
+			in:      &Input{SigIndices: []uint32{0, 2}},
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key4},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, aliasAddr1},
+			},
+			msig: func(c *gomock.Controller) AliasGetter {
+				msig := NewMockAliasGetter(c)
+				expectGetMultisigAliases(msig, []*multisig.Alias{{
+					ID: aliasAddr1,
+					Owners: &TimeLockedOutputOwners{
+						OutputOwners: OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{addr3, addr4},
+						},
+						ActiveFrom:  []uint64{0, pendingTime},
+						ActiveUntil: []uint64{0, 0},
+					},
+				}})
+				return msig
+			},
+			expectedError: ErrMemberNotActive,
+		},
 		"OK msig: alias1{ alias2{addr1, addr2, thresh: 2}, addr3, thresh: 2 }, addr4": {
 			in:      &Input{SigIndices: []uint32{0, 1, 2, 3}},
 			signers: []*crypto.PrivateKeySECP256K1R{key1, key2, key3, key4},
@@ -205,7 +356,7 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				})
 				return msig
 			},
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
 		},
 	}
 	for name, tt := range tests {
@@ -221,7 +372,7 @@ func TestVerifyMultisigCredentials(t *testing.T) {
 				copy(cred.Sigs[i][:], sig)
 			}
 
-			err := fx.verifyMultisigCredentials(tx, tt.in, cred, tt.owners, tt.msig(ctrl))
+			err := fx.verifyMultisigCredentials(tx, tt.in, cred, tt.owners, nil, tt.msig(ctrl))
 			require.ErrorIs(t, err, tt.expectedError)
 		})
 	}
@@ -273,7 +424,16 @@ func TestVerifyMultisigUnorderedCredentials(t *testing.T) {
 				Addrs:     []ids.ShortID{addr1, addr2},
 			},
 			msig:          noAliasesMsigGetter,
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
+		},
+		"Signer isn't owner": {
+			signers: []*crypto.PrivateKeySECP256K1R{key1, key3},
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr1, addr2},
+			},
+			msig:          noAliasesMsigGetter,
+			expectedError: ErrSignerNotInAlias,
 		},
 		"Wrong signature": {
 			signers: []*crypto.PrivateKeySECP256K1R{key1, key1},
@@ -282,7 +442,7 @@ func TestVerifyMultisigUnorderedCredentials(t *testing.T) {
 				Addrs:     []ids.ShortID{addr1, addr2},
 			},
 			msig:          noAliasesMsigGetter,
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
 		},
 		"OK msig: addr1, alias1{addr2, addr3, thresh: 1}": {
 			signers: []*crypto.PrivateKeySECP256K1R{key1, key2},
@@ -400,7 +560,7 @@ func TestVerifyMultisigUnorderedCredentials(t *testing.T) {
 				})
 				return msig
 			},
-			expectedError: errCantSpend,
+			expectedError: ErrThresholdNotMet,
 		},
 	}
 	for name, tt := range tests {
@@ -416,7 +576,70 @@ func TestVerifyMultisigUnorderedCredentials(t *testing.T) {
 				copy(cred.Sigs[i][:], sig)
 			}
 
-			err := fx.verifyMultisigUnorderedCredentials(tx, []verify.Verifiable{cred}, tt.owners, tt.msig(ctrl))
+			err := fx.verifyMultisigUnorderedCredentials(tx, []verify.Verifiable{cred}, tt.owners, nil, tt.msig(ctrl))
+			require.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
+type timedAliasGetter struct {
+	testAliasGetter
+	timestamp time.Time
+}
+
+func (g *timedAliasGetter) GetTimestamp() time.Time {
+	return g.timestamp
+}
+
+func TestVerifyMultisigPermissionChainTime(t *testing.T) {
+	key, addr := generateKey(t)
+	_, aliasAddr := generateKey(t)
+	tx := &TestTx{}
+	txHash := hashing.ComputeHash256(tx.Bytes())
+	activeFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	aliases := testAliasGetter{aliasAddr: {
+		ID: aliasAddr,
+		Owners: &TimeLockedOutputOwners{
+			OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}},
+			ActiveFrom:   []uint64{uint64(activeFrom.Unix())},
+			ActiveUntil:  []uint64{0},
+		},
+	}}
+	owners := &OutputOwners{Threshold: 1, Addrs: []ids.ShortID{aliasAddr}}
+
+	sig, err := key.SignHash(txHash)
+	require.NoError(t, err)
+	cred := &Credential{Sigs: make([][crypto.SECP256K1RSigLen]byte, 1)}
+	copy(cred.Sigs[0][:], sig)
+	in := &Input{SigIndices: []uint32{0}}
+
+	tests := map[string]struct {
+		msig          AliasGetter
+		expectedError error
+	}{
+		"Fx clock time, member isn't active yet": {
+			msig:          aliases,
+			expectedError: ErrMemberNotActive,
+		},
+		"Chain time, member isn't active yet": {
+			msig:          &timedAliasGetter{testAliasGetter: aliases, timestamp: activeFrom.Add(-time.Second)},
+			expectedError: ErrMemberNotActive,
+		},
+		"OK: chain time, member is active": {
+			msig: &timedAliasGetter{testAliasGetter: aliases, timestamp: activeFrom},
+		},
+		"OK: chain time of wrapped getter, member is active": {
+			msig: WithAliasUsageRecorder(
+				&timedAliasGetter{testAliasGetter: aliases, timestamp: activeFrom},
+				&testAliasUsageRecorder{},
+			),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fx := defaultFx(t)
+			err := fx.VerifyMultisigPermission(tx, in, cred, owners, tt.msig)
 			require.ErrorIs(t, err, tt.expectedError)
 		})
 	}
@@ -5,19 +5,38 @@ package secp256k1fx
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 )
 
-const MaxSignatures = 256
+const (
+	MaxSignatures = 256
+	// DefaultMaxAliasDepth is the max nesting depth of multisig aliases,
+	// used when no other limit is configured
+	DefaultMaxAliasDepth = MaxSignatures
+)
 
 var (
+	// ErrCyclicAliases is returned when multisig alias references itself, directly or through other aliases
+	ErrCyclicAliases = errors.New("cyclic aliases not allowed")
+	// ErrAliasDepthExceeded is returned when multisig aliases are nested deeper than allowed
+	ErrAliasDepthExceeded = errors.New("multisig alias nesting depth exceeded")
+	// ErrThresholdNotMet is returned when owners or their nested aliases don't have enough valid signatures
+	ErrThresholdNotMet = errors.New("multisig threshold not met")
+	// ErrSignerNotInAlias is returned when signer isn't member of owners or their nested aliases
+	ErrSignerNotInAlias = errors.New("signer isn't multisig alias member")
+	// ErrMemberNotActive is returned when signer is time-locked alias member, that isn't active at current time
+	ErrMemberNotActive = errors.New("multisig alias member isn't active")
+	// ErrAliasNotFound is returned when multisig alias doesn't exist
+	ErrAliasNotFound = errors.New("multisig alias not found")
+
 	errTooManySignatures = errors.New("too many signatures")
-	errCyclicAliases     = errors.New("cyclic aliases not allowed")
 )
 
 // SpendMultisig attempts to create an input from outputowners which can contain multisig aliases
@@ -66,7 +85,7 @@ func (kc *Keychain) SpendMultiSig(
 		return false, nil
 	}
 
-	totalVerified, err := TraverseOwners(owners, msig, tf)
+	totalVerified, err := TraverseOwners(owners, msig, DefaultMaxAliasDepth, time, tf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -99,50 +118,98 @@ type TraverserOwnerFunc func(
 
 // TraverseOwners traverses through owners, visits every address and callbacks in case a
 // non-multisig address is visited. Nested multisig alias are excluded from sigIndex concept.
-func TraverseOwners(out *OutputOwners, msig AliasGetter, callback TraverserOwnerFunc) (uint32, error) {
+// Aliases nested deeper than [maxDepth] are rejected, if [maxDepth] is zero, DefaultMaxAliasDepth is used.
+// Time-locked alias members, that aren't active at [time], are skipped.
+func TraverseOwners(out *OutputOwners, msig AliasGetter, maxDepth int, time uint64, callback TraverserOwnerFunc) (uint32, error) {
+	return traverseOwners(out, nil, msig, maxDepth, func() uint64 { return time }, callback, nil)
+}
+
+// traverseOwners is TraverseOwners, where [out] addresses have member [rules].
+// [timeFn] is only called, if time-locked alias members are traversed.
+// If [members] isn't nil, every visited non-alias address is added to it
+// with true value, if it is active member.
+// If [msig] is AliasUsageRecorder, every nested alias, that met its threshold
+// and contributed to its parent owners, is reported to it.
+func traverseOwners(
+	out *OutputOwners,
+	rules *memberRules,
+	msig AliasGetter,
+	maxDepth int,
+	timeFn func() uint64,
+	callback TraverserOwnerFunc,
+	members map[ids.ShortID]bool,
+) (uint32, error) {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxAliasDepth
+	}
+
 	var addrVisited, addrVerified uint32
+	recorder, _ := msig.(AliasUsageRecorder)
 
 	type stackItem struct {
+		// aliasID is id of alias with these owners, empty for root owners
+		aliasID ids.ShortID
 		index,
-		verified,
 		addrVerifiedTotal uint32
+		// verified is total weight of verified addresses and nested aliases
+		verified       uint64
 		parentVerified bool
 		owners         *OutputOwners
+		rules          *memberRules
+		// weight is weight of this owners in parent owners
+		weight uint32
+	}
+
+	satisfied := func(item *stackItem) bool {
+		return item.verified >= uint64(item.owners.Threshold)
 	}
 
 	cycleCheck := set.Set[ids.ShortID]{}
-	stack := []*stackItem{{owners: out}}
+	stack := []*stackItem{{owners: out, rules: rules}}
 	for len(stack) > 0 {
 	Stack:
 		// get head
 		currentStack := stack[len(stack)-1]
 		for int(currentStack.index) < len(currentStack.owners.Addrs) {
 			// get the next address to check
-			addr := currentStack.owners.Addrs[currentStack.index]
+			addrIndex := currentStack.index
+			addr := currentStack.owners.Addrs[addrIndex]
 			currentStack.index++
 			// Is it a multi-sig address ?
 			alias, err := msig.GetMultisigAlias(addr)
 			switch err {
 			case nil: // multi-sig
-				if len(stack) > MaxSignatures {
-					return 0, errTooManySignatures
+				if !currentStack.rules.active(addrIndex, timeFn) {
+					// inactive alias member is skipped
+					continue
+				}
+				if len(stack) > maxDepth {
+					return 0, fmt.Errorf("%w: alias %s is nested deeper than %d", ErrAliasDepthExceeded, addr, maxDepth)
 				}
 				if cycleCheck.Contains(addr) {
-					return 0, errCyclicAliases
+					return 0, fmt.Errorf("%w: alias %s", ErrCyclicAliases, addr)
 				}
 				cycleCheck.Add(addr)
-				owners, ok := alias.Owners.(*OutputOwners)
-				if !ok {
-					return 0, errWrongOwnerType
+				owners, aliasRules, err := ownersRules(alias.Owners)
+				if err != nil {
+					return 0, err
 				}
 				stack = append(stack, &stackItem{
+					aliasID:           addr,
 					owners:            owners,
+					rules:             aliasRules,
+					weight:            currentStack.rules.weight(addrIndex),
 					addrVerifiedTotal: addrVerified,
-					parentVerified:    currentStack.parentVerified || currentStack.verified >= currentStack.owners.Threshold,
+					parentVerified:    currentStack.parentVerified || satisfied(currentStack),
 				})
 				goto Stack
 			case database.ErrNotFound: // non-multi-sig
-				if !currentStack.parentVerified && currentStack.verified < currentStack.owners.Threshold {
+				// inactive member is visited, but can't sign
+				active := currentStack.rules.active(addrIndex, timeFn)
+				if members != nil {
+					members[addr] = members[addr] || active
+				}
+				if !currentStack.parentVerified && !satisfied(currentStack) && active {
 					success, err := callback(
 						addr,
 						addrVisited,
@@ -152,7 +219,7 @@ func TraverseOwners(out *OutputOwners, msig AliasGetter, callback TraverserOwner
 						return 0, err
 					}
 					if success {
-						currentStack.verified++
+						currentStack.verified += uint64(currentStack.rules.weight(addrIndex))
 						addrVerified++
 
 						if addrVerified > MaxSignatures {
@@ -169,19 +236,88 @@ func TraverseOwners(out *OutputOwners, msig AliasGetter, callback TraverserOwner
 		// remove head
 		stack = stack[:len(stack)-1]
 		// verify current level
-		if currentStack.verified < currentStack.owners.Threshold {
+		if !satisfied(currentStack) {
 			if len(stack) == 0 {
-				return 0, errCantSpend
+				return 0, ErrThresholdNotMet
 			}
 			// We recover to previous state
 			addrVerified = currentStack.addrVerifiedTotal
 		} else if len(stack) > 0 {
-			currentStack = stack[len(stack)-1]
-			if currentStack.verified < currentStack.owners.Threshold {
+			parentStack := stack[len(stack)-1]
+			if !satisfied(parentStack) {
 				// apply child verification
-				currentStack.verified++
+				parentStack.verified += uint64(currentStack.weight)
+				if recorder != nil {
+					recorder.RecordAliasUsage(currentStack.aliasID)
+				}
 			}
 		}
 	}
 	return addrVerified, nil
 }
+
+// VerifyAliasNesting verifies that multisig aliases referenced by [owners] don't form
+// reference cycles and aren't nested deeper than [maxDepth].
+// If [maxDepth] is zero, DefaultMaxAliasDepth is used.
+func VerifyAliasNesting(owners *OutputOwners, msig AliasGetter, maxDepth int) error {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxAliasDepth
+	}
+	return verifyAliasNesting(owners, msig, maxDepth, 0, set.Set[ids.ShortID]{})
+}
+
+func verifyAliasNesting(
+	owners *OutputOwners,
+	msig AliasGetter,
+	maxDepth int,
+	depth int,
+	path set.Set[ids.ShortID],
+) error {
+	for _, addr := range owners.Addrs {
+		alias, err := msig.GetMultisigAlias(addr)
+		switch {
+		case err == database.ErrNotFound:
+			continue
+		case err != nil:
+			return err
+		case path.Contains(addr):
+			return fmt.Errorf("%w: alias %s", ErrCyclicAliases, addr)
+		case depth+1 > maxDepth:
+			return fmt.Errorf("%w: alias %s is nested deeper than %d", ErrAliasDepthExceeded, addr, maxDepth)
+		}
+
+		aliasOwners, _, err := OwnersWeights(alias.Owners)
+		if err != nil {
+			return err
+		}
+
+		path.Add(addr)
+		if err := verifyAliasNesting(aliasOwners, msig, maxDepth, depth+1, path); err != nil {
+			return err
+		}
+		path.Remove(addr)
+	}
+	return nil
+}
+
+// verifySigners returns error, if any of [signers] isn't active member from [members],
+// recorded by owners traversal. Signers are checked in ascending address order.
+// Members of inactive nested aliases aren't traversed, so they aren't members.
+func verifySigners(members map[ids.ShortID]bool, signers RecoverMap) error {
+	signerAddrs := make([]ids.ShortID, 0, len(signers))
+	for addr := range signers {
+		signerAddrs = append(signerAddrs, addr)
+	}
+	utils.Sort(signerAddrs)
+
+	for _, addr := range signerAddrs {
+		active, isMember := members[addr]
+		switch {
+		case !isMember:
+			return fmt.Errorf("%w: %s", ErrSignerNotInAlias, addr)
+		case !active:
+			return fmt.Errorf("%w: %s", ErrMemberNotActive, addr)
+		}
+	}
+	return nil
+}
@@ -172,7 +172,7 @@ func TestSpendMultiSigCycle(t *testing.T) {
 	require.NoError(transfer.Verify())
 
 	_, _, err := kc.SpendMultiSig(&transfer, 54321, &TestGetter{msig: msigAddress, addresses: []ids.ShortID{msigAddress}, threshold: 1})
-	require.ErrorIs(err, errCyclicAliases)
+	require.ErrorIs(err, ErrCyclicAliases)
 }
 
 // Verify that visited / verified items in nested ownergroups which don't
@@ -220,3 +220,133 @@ func TestUnverifiedNestedOwner(t *testing.T) {
 	require.Equal(1, len(sigs), 1)
 	require.Equal(uint32(2), ti.(*TransferInput).SigIndices[0])
 }
+
+type testAliasGetter map[ids.ShortID]*multisig.Alias
+
+func (g testAliasGetter) GetMultisigAlias(addr ids.ShortID) (*multisig.Alias, error) {
+	if alias, ok := g[addr]; ok {
+		return alias, nil
+	}
+	return nil, database.ErrNotFound
+}
+
+func TestVerifyAliasNesting(t *testing.T) {
+	addr := ids.ShortID{1}
+	alias1 := ids.ShortID{2}
+	alias2 := ids.ShortID{3}
+	alias3 := ids.ShortID{4}
+
+	newAlias := func(id ids.ShortID, addrs ...ids.ShortID) *multisig.Alias {
+		return &multisig.Alias{
+			ID:     id,
+			Owners: &OutputOwners{Threshold: 1, Addrs: addrs},
+		}
+	}
+
+	tests := map[string]struct {
+		aliases     testAliasGetter
+		maxDepth    int
+		expectedErr error
+	}{
+		"OK: nested aliases": {
+			aliases: testAliasGetter{
+				alias1: newAlias(alias1, addr, alias2),
+				alias2: newAlias(alias2, addr, alias3),
+				alias3: newAlias(alias3, addr),
+			},
+			maxDepth: 3,
+		},
+		"OK: same alias in different branches": {
+			aliases: testAliasGetter{
+				alias1: newAlias(alias1, alias2, alias3),
+				alias2: newAlias(alias2, alias3),
+				alias3: newAlias(alias3, addr),
+			},
+		},
+		"Fail: depth exceeded": {
+			aliases: testAliasGetter{
+				alias1: newAlias(alias1, addr, alias2),
+				alias2: newAlias(alias2, addr, alias3),
+				alias3: newAlias(alias3, addr),
+			},
+			maxDepth:    2,
+			expectedErr: ErrAliasDepthExceeded,
+		},
+		"Fail: self reference": {
+			aliases: testAliasGetter{
+				alias1: newAlias(alias1, addr, alias1),
+			},
+			expectedErr: ErrCyclicAliases,
+		},
+		"Fail: transitive cycle": {
+			aliases: testAliasGetter{
+				alias1: newAlias(alias1, addr, alias2),
+				alias2: newAlias(alias2, addr, alias3),
+				alias3: newAlias(alias3, addr, alias1),
+			},
+			expectedErr: ErrCyclicAliases,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyAliasNesting(
+				&OutputOwners{Threshold: 1, Addrs: []ids.ShortID{alias1}},
+				tt.aliases,
+				tt.maxDepth,
+			)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestTraverseOwnersMaxDepth(t *testing.T) {
+	require := require.New(t)
+
+	addr := ids.ShortID{1}
+	alias1 := ids.ShortID{2}
+	alias2 := ids.ShortID{3}
+	aliases := testAliasGetter{
+		alias1: {ID: alias1, Owners: &OutputOwners{Threshold: 1, Addrs: []ids.ShortID{alias2}}},
+		alias2: {ID: alias2, Owners: &OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr}}},
+	}
+	owners := &OutputOwners{Threshold: 1, Addrs: []ids.ShortID{alias1}}
+	callback := func(ids.ShortID, uint32, uint32) (bool, error) { return true, nil }
+
+	_, err := TraverseOwners(owners, aliases, 1, 0, callback)
+	require.ErrorIs(err, ErrAliasDepthExceeded)
+
+	verified, err := TraverseOwners(owners, aliases, 2, 0, callback)
+	require.NoError(err)
+	require.Equal(uint32(1), verified)
+}
+
+// testAliasUsageRecorder records reported alias ids
+type testAliasUsageRecorder struct {
+	usedAliases []ids.ShortID
+}
+
+func (r *testAliasUsageRecorder) RecordAliasUsage(aliasID ids.ShortID) {
+	r.usedAliases = append(r.usedAliases, aliasID)
+}
+
+func TestTraverseOwnersAliasUsage(t *testing.T) {
+	require := require.New(t)
+
+	addr1, addr2, addr3, addr4 := ids.ShortID{1}, ids.ShortID{2}, ids.ShortID{3}, ids.ShortID{4}
+	alias1, alias2 := ids.ShortID{0xa1}, ids.ShortID{0xa2}
+	aliases := testAliasGetter{
+		alias1: {ID: alias1, Owners: &OutputOwners{Threshold: 2, Addrs: []ids.ShortID{addr1, addr2}}},
+		alias2: {ID: alias2, Owners: &OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr3}}},
+	}
+	// alias1 doesn't meet its threshold, alias2 is used
+	owners := &OutputOwners{Threshold: 2, Addrs: []ids.ShortID{alias1, alias2, addr4}}
+	signers := map[ids.ShortID]bool{addr1: true, addr3: true, addr4: true}
+
+	recorder := &testAliasUsageRecorder{}
+	_, err := TraverseOwners(owners, WithAliasUsageRecorder(aliases, recorder), 0, 0,
+		func(addr ids.ShortID, _, _ uint32) (bool, error) {
+			return signers[addr], nil
+		})
+	require.NoError(err)
+	require.Equal([]ids.ShortID{alias2}, recorder.usedAliases)
+}
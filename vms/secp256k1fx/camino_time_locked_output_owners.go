@@ -0,0 +1,96 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ verify.State = (*TimeLockedOutputOwners)(nil)
+
+	errTimeLocksAddrsMismatch = errors.New("number of member time locks is different from number of addresses")
+	errMemberNeverActive      = errors.New("member expires before it becomes active")
+)
+
+// TimeLockedOutputOwners are owners, where each address is only an active member
+// from ActiveFrom[i] (inclusive) until ActiveUntil[i] (exclusive) unix time.
+// Zero ActiveUntil means that address never expires. Inactive addresses can't sign.
+// Time-locked owners could only be used as owners of multisig alias.
+type TimeLockedOutputOwners struct {
+	OutputOwners `serialize:"true"`
+	// ActiveFrom[i] is time when Addrs[i] becomes active
+	ActiveFrom []uint64 `serialize:"true" json:"activeFrom"`
+	// ActiveUntil[i] is time when Addrs[i] expires, zero means never
+	ActiveUntil []uint64 `serialize:"true" json:"activeUntil"`
+}
+
+// MarshalJSON marshals TimeLockedOutputOwners as JSON with human readable addresses.
+func (out *TimeLockedOutputOwners) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+	result["activeFrom"] = out.ActiveFrom
+	result["activeUntil"] = out.ActiveUntil
+	return json.Marshal(result)
+}
+
+func (out *TimeLockedOutputOwners) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case len(out.ActiveFrom) != len(out.Addrs) || len(out.ActiveUntil) != len(out.Addrs):
+		return errTimeLocksAddrsMismatch
+	case out.Threshold > uint32(len(out.Addrs)):
+		return errOutputUnspendable
+	case out.Threshold == 0 && len(out.Addrs) > 0:
+		return errOutputUnoptimized
+	case !utils.IsSortedAndUniqueSortable(out.Addrs):
+		return errAddrsNotSortedUnique
+	}
+	for i, activeUntil := range out.ActiveUntil {
+		if activeUntil != 0 && activeUntil <= out.ActiveFrom[i] {
+			return errMemberNeverActive
+		}
+	}
+	return nil
+}
+
+func (out *TimeLockedOutputOwners) VerifyState() error {
+	return out.Verify()
+}
+
+// Sort sorts addresses together with their time locks
+func (out *TimeLockedOutputOwners) Sort() {
+	sort.Sort((*timeLockedAddrs)(out))
+}
+
+type timeLockedAddrs TimeLockedOutputOwners
+
+func (t *timeLockedAddrs) Len() int {
+	return len(t.Addrs)
+}
+
+func (t *timeLockedAddrs) Less(i, j int) bool {
+	return t.Addrs[i].Less(t.Addrs[j])
+}
+
+func (t *timeLockedAddrs) Swap(i, j int) {
+	t.Addrs[i], t.Addrs[j] = t.Addrs[j], t.Addrs[i]
+	t.ActiveFrom[i], t.ActiveFrom[j] = t.ActiveFrom[j], t.ActiveFrom[i]
+	t.ActiveUntil[i], t.ActiveUntil[j] = t.ActiveUntil[j], t.ActiveUntil[i]
+}
+
+func (out *TimeLockedOutputOwners) multisigOwners() (*OutputOwners, *memberRules) {
+	return &out.OutputOwners, &memberRules{
+		activeFrom:  out.ActiveFrom,
+		activeUntil: out.ActiveUntil,
+	}
+}
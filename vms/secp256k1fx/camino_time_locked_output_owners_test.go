@@ -0,0 +1,61 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeLockedOutputOwnersVerify(t *testing.T) {
+	addr1 := ids.ShortID{1}
+	addr2 := ids.ShortID{2}
+
+	tests := map[string]struct {
+		owners      *TimeLockedOutputOwners
+		expectedErr error
+	}{
+		"Nil owners": {
+			expectedErr: errNilOutput,
+		},
+		"Time locks and addresses mismatch": {
+			owners: &TimeLockedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr1, addr2}},
+				ActiveFrom:   []uint64{0, 0},
+				ActiveUntil:  []uint64{0},
+			},
+			expectedErr: errTimeLocksAddrsMismatch,
+		},
+		"Threshold is greater than number of addresses": {
+			owners: &TimeLockedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 3, Addrs: []ids.ShortID{addr1, addr2}},
+				ActiveFrom:   []uint64{0, 0},
+				ActiveUntil:  []uint64{0, 0},
+			},
+			expectedErr: errOutputUnspendable,
+		},
+		"Member expires before activation": {
+			owners: &TimeLockedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr1, addr2}},
+				ActiveFrom:   []uint64{0, 10},
+				ActiveUntil:  []uint64{0, 10},
+			},
+			expectedErr: errMemberNeverActive,
+		},
+		"OK": {
+			owners: &TimeLockedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr1, addr2}},
+				ActiveFrom:   []uint64{0, 10},
+				ActiveUntil:  []uint64{20, 0},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.owners.Verify(), tt.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var (
+	_ verify.State = (*WeightedOutputOwners)(nil)
+
+	errWeightsAddrsMismatch = errors.New("number of weights is different from number of addresses")
+	errZeroWeight           = errors.New("address weight is zero")
+)
+
+// WeightedOutputOwners are owners, where each address has its own weight.
+// Threshold is the minimal total weight of addresses, that must sign to satisfy owners.
+// Weighted owners could only be used as owners of multisig alias.
+type WeightedOutputOwners struct {
+	OutputOwners `serialize:"true"`
+	// Weights[i] is weight of Addrs[i]
+	Weights []uint32 `serialize:"true" json:"weights"`
+}
+
+// MarshalJSON marshals WeightedOutputOwners as JSON with human readable addresses.
+func (out *WeightedOutputOwners) MarshalJSON() ([]byte, error) {
+	result, err := out.OutputOwners.Fields()
+	if err != nil {
+		return nil, err
+	}
+	result["weights"] = out.Weights
+	return json.Marshal(result)
+}
+
+// TotalWeight returns sum of all addresses weights
+func (out *WeightedOutputOwners) TotalWeight() uint64 {
+	total := uint64(0)
+	for _, weight := range out.Weights {
+		total += uint64(weight)
+	}
+	return total
+}
+
+func (out *WeightedOutputOwners) Verify() error {
+	switch {
+	case out == nil:
+		return errNilOutput
+	case len(out.Weights) != len(out.Addrs):
+		return errWeightsAddrsMismatch
+	case uint64(out.Threshold) > out.TotalWeight():
+		return errOutputUnspendable
+	case out.Threshold == 0 && len(out.Addrs) > 0:
+		return errOutputUnoptimized
+	case !utils.IsSortedAndUniqueSortable(out.Addrs):
+		return errAddrsNotSortedUnique
+	}
+	for _, weight := range out.Weights {
+		if weight == 0 {
+			return errZeroWeight
+		}
+	}
+	return nil
+}
+
+func (out *WeightedOutputOwners) VerifyState() error {
+	return out.Verify()
+}
+
+// Sort sorts addresses together with their weights
+func (out *WeightedOutputOwners) Sort() {
+	sort.Sort((*weightedAddrs)(out))
+}
+
+type weightedAddrs WeightedOutputOwners
+
+func (w *weightedAddrs) Len() int {
+	return len(w.Addrs)
+}
+
+func (w *weightedAddrs) Less(i, j int) bool {
+	return w.Addrs[i].Less(w.Addrs[j])
+}
+
+func (w *weightedAddrs) Swap(i, j int) {
+	w.Addrs[i], w.Addrs[j] = w.Addrs[j], w.Addrs[i]
+	w.Weights[i], w.Weights[j] = w.Weights[j], w.Weights[i]
+}
+
+// OwnersWeights returns plain owners and address weights of multisig alias [owners],
+// which must be either *OutputOwners, *WeightedOutputOwners or *TimeLockedOutputOwners.
+// Nil weights mean that every address has weight of 1.
+func OwnersWeights(owners interface{}) (*OutputOwners, []uint32, error) {
+	outputOwners, rules, err := ownersRules(owners)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rules == nil {
+		return outputOwners, nil, nil
+	}
+	return outputOwners, rules.weights, nil
+}
+
+// memberRules are per-address rules of multisig alias owners.
+// Nil rules mean that every address has weight of 1 and is always active.
+type memberRules struct {
+	weights     []uint32
+	activeFrom  []uint64
+	activeUntil []uint64
+}
+
+// ownersRules returns plain owners and per-address rules of multisig alias [owners].
+func ownersRules(owners interface{}) (*OutputOwners, *memberRules, error) {
+	msigOwners, ok := owners.(MultisigOwners)
+	if !ok {
+		return nil, nil, errWrongOwnerType
+	}
+	outputOwners, rules := msigOwners.multisigOwners()
+	return outputOwners, rules, nil
+}
+
+func (out *OutputOwners) multisigOwners() (*OutputOwners, *memberRules) {
+	return out, nil
+}
+
+func (out *WeightedOutputOwners) multisigOwners() (*OutputOwners, *memberRules) {
+	return &out.OutputOwners, &memberRules{weights: out.Weights}
+}
+
+// weight returns weight of address with index [i]
+func (r *memberRules) weight(i uint32) uint32 {
+	if r == nil || r.weights == nil {
+		return 1
+	}
+	return r.weights[i]
+}
+
+// active returns true if address with index [i] is active member at time returned by [timeFn]
+func (r *memberRules) active(i uint32, timeFn func() uint64) bool {
+	if r == nil || r.activeFrom == nil {
+		return true
+	}
+	time := timeFn()
+	return r.activeFrom[i] <= time && (r.activeUntil[i] == 0 || time < r.activeUntil[i])
+}
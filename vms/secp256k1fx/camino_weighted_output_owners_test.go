@@ -0,0 +1,81 @@
+// Copyright (C) 2023, Chain4Travel AG. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeightedOutputOwnersVerify(t *testing.T) {
+	addr1 := ids.ShortID{1}
+	addr2 := ids.ShortID{2}
+
+	tests := map[string]struct {
+		owners      *WeightedOutputOwners
+		expectedErr error
+	}{
+		"Nil owners": {
+			expectedErr: errNilOutput,
+		},
+		"Weights and addresses mismatch": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr1, addr2}},
+				Weights:      []uint32{1},
+			},
+			expectedErr: errWeightsAddrsMismatch,
+		},
+		"Threshold is greater than total weight": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 4, Addrs: []ids.ShortID{addr1, addr2}},
+				Weights:      []uint32{1, 2},
+			},
+			expectedErr: errOutputUnspendable,
+		},
+		"Zero threshold": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Addrs: []ids.ShortID{addr1, addr2}},
+				Weights:      []uint32{1, 2},
+			},
+			expectedErr: errOutputUnoptimized,
+		},
+		"Not sorted addresses": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr2, addr1}},
+				Weights:      []uint32{1, 2},
+			},
+			expectedErr: errAddrsNotSortedUnique,
+		},
+		"Zero weight": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{addr1, addr2}},
+				Weights:      []uint32{1, 0},
+			},
+			expectedErr: errZeroWeight,
+		},
+		"OK: threshold is greater than number of addresses": {
+			owners: &WeightedOutputOwners{
+				OutputOwners: OutputOwners{Threshold: 3, Addrs: []ids.ShortID{addr1, addr2}},
+				Weights:      []uint32{1, 2},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, tt.owners.Verify(), tt.expectedErr)
+		})
+	}
+}
+
+func TestWeightedOutputOwnersSort(t *testing.T) {
+	owners := &WeightedOutputOwners{
+		OutputOwners: OutputOwners{Threshold: 1, Addrs: []ids.ShortID{{3}, {1}, {2}}},
+		Weights:      []uint32{30, 10, 20},
+	}
+	owners.Sort()
+	require.Equal(t, []ids.ShortID{{1}, {2}, {3}}, owners.Addrs)
+	require.Equal(t, []uint32{10, 20, 30}, owners.Weights)
+}
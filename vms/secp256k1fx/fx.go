@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -15,7 +16,7 @@ import (
 )
 
 const (
-	defaultCacheSize = 256
+	defaultCacheSize = 2048
 )
 
 var (
@@ -41,6 +42,8 @@ type Fx struct {
 	VM           VM
 	SECPFactory  crypto.FactorySECP256K1R
 	bootstrapped bool
+	// Max nesting depth of multisig aliases, if zero, DefaultMaxAliasDepth is used
+	MaxAliasDepth int
 }
 
 func (fx *Fx) Initialize(vmIntf interface{}) error {
@@ -52,7 +55,7 @@ func (fx *Fx) Initialize(vmIntf interface{}) error {
 	log.Debug("initializing secp256k1 fx")
 
 	fx.SECPFactory = crypto.FactorySECP256K1R{
-		Cache: cache.LRU{Size: defaultCacheSize},
+		Cache: cache.LRU[ids.ID, *crypto.PublicKeySECP256K1R]{Size: defaultCacheSize},
 	}
 	c := fx.VM.CodecRegistry()
 	errs := wrappers.Errs{}
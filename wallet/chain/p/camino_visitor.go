@@ -34,6 +34,42 @@ func (*backendVisitor) RewardsImportTx(*txs.RewardsImportTx) error {
 	return errUnsupportedTxType
 }
 
+func (b *backendVisitor) BaseTx(tx *txs.BaseTx) error {
+	return b.baseTx(tx)
+}
+
+func (b *backendVisitor) AddressStateBatchTx(tx *txs.AddressStateBatchTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RenewDepositTx(tx *txs.RenewDepositTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) DepositRewardsOwnerTx(tx *txs.DepositRewardsOwnerTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) SplitDepositTx(tx *txs.SplitDepositTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) MultisigAliasTx(tx *txs.MultisigAliasTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) RotateMultisigAliasTx(tx *txs.RotateMultisigAliasTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) DepositOfferTx(tx *txs.DepositOfferTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) ClaimDepositTx(tx *txs.ClaimDepositTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (s *signerVisitor) AddressStateTx(tx *txs.AddressStateTx) error {
 	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
 	if err != nil {
@@ -77,3 +113,75 @@ func (s *signerVisitor) RegisterNodeTx(tx *txs.RegisterNodeTx) error {
 func (*signerVisitor) RewardsImportTx(*txs.RewardsImportTx) error {
 	return errUnsupportedTxType
 }
+
+func (s *signerVisitor) BaseTx(tx *txs.BaseTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) AddressStateBatchTx(tx *txs.AddressStateBatchTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) RenewDepositTx(tx *txs.RenewDepositTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) DepositRewardsOwnerTx(tx *txs.DepositRewardsOwnerTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) SplitDepositTx(tx *txs.SplitDepositTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) MultisigAliasTx(tx *txs.MultisigAliasTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) RotateMultisigAliasTx(tx *txs.RotateMultisigAliasTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) DepositOfferTx(tx *txs.DepositOfferTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}
+
+func (s *signerVisitor) ClaimDepositTx(tx *txs.ClaimDepositTx) error {
+	txSigners, err := s.getSigners(constants.PlatformChainID, tx.Ins)
+	if err != nil {
+		return err
+	}
+	return sign(s.tx, txSigners)
+}